@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/stats"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+// imports S3 server access logs of the storage bucket into the asset download stats,
+// every log file should be imported only once
+func main() {
+	_ = godotenv.Load()
+
+	flag.Usage = func() {
+		log.Printf("usage: %s <access log file>...", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pgConn, err := pgxpool.New(ctx, os.Getenv("POSTGRES_DSN"))
+	if err != nil {
+		log.Fatalf("failed create a connection pool to postgres: %v", err)
+	}
+	defer pgConn.Close()
+
+	recorder := stats.NewRecorder(db.New(pgConn))
+	total := 0
+	for _, path := range flag.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("failed to open %s: %v", path, err)
+		}
+		imported, err := stats.ImportS3AccessLog(recorder, file)
+		_ = file.Close()
+		if err != nil {
+			log.Fatalf("failed to read %s: %v", path, err)
+		}
+		total += imported
+	}
+
+	if err := recorder.Flush(ctx); err != nil {
+		log.Fatalf("failed to save download stats: %v", err)
+	}
+	log.Printf("imported %d downloads from %d files", total, flag.NArg())
+}
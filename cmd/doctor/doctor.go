@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Netflix/go-env"
+	"github.com/a-gierczak/paratrooper/internal/api"
+	"github.com/a-gierczak/paratrooper/internal/doctor"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	var config api.Config
+	_, err := env.UnmarshalFromEnviron(&config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the report is the output, logs of the checked components would only clutter it
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	results := doctor.Run(ctx, config)
+	if !doctor.PrintReport(os.Stdout, results) {
+		fmt.Println("\nsome checks failed")
+		os.Exit(1)
+	}
+	fmt.Println("\nall checks passed")
+}
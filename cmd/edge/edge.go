@@ -0,0 +1,32 @@
+package main
+
+import (
+	"log"
+
+	"github.com/Netflix/go-env"
+	"github.com/a-gierczak/paratrooper/internal/edge"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	var config edge.Config
+	_, err := env.UnmarshalFromEnviron(&config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := logger.NewLogger(config.DebugMode, config.Log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer logger.Sync()
+
+	if err := edge.Run(config, logger); err != nil {
+		logger.Fatal("failed to run edge server", zap.Error(err))
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/internal/loadgen"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	config := loadgen.DefaultConfig()
+	var expoProjectID, codePushProjectID, channels, runtimeVersions, platforms, updateIDs string
+
+	flag.StringVar(&config.BaseURL, "url", config.BaseURL, "base URL of the server")
+	flag.DurationVar(&config.Duration, "duration", config.Duration, "duration of the test")
+	flag.IntVar(&config.Concurrency, "concurrency", config.Concurrency, "number of concurrent clients")
+	flag.StringVar(&expoProjectID, "expo-project", "", "ID of an Expo project")
+	flag.StringVar(&codePushProjectID, "codepush-project", "", "ID of a CodePush project")
+	flag.StringVar(&channels, "channels", strings.Join(config.Channels, ","), "CodePush channels")
+	flag.StringVar(
+		&runtimeVersions,
+		"runtime-versions",
+		strings.Join(config.RuntimeVersions, ","),
+		"runtime (app) versions",
+	)
+	flag.StringVar(&platforms, "platforms", strings.Join(config.Platforms, ","), "platforms")
+	flag.StringVar(&updateIDs, "update-ids", "", "IDs of published updates clients may be running")
+	flag.Float64Var(
+		&config.UnknownUpdateRatio,
+		"unknown-update-ratio",
+		config.UnknownUpdateRatio,
+		"share of requests from clients running an update unknown to the server",
+	)
+	flag.Parse()
+
+	var err error
+	if expoProjectID != "" {
+		if config.ExpoProjectID, err = uuid.Parse(expoProjectID); err != nil {
+			log.Fatalf("invalid Expo project ID: %v", err)
+		}
+	}
+	if codePushProjectID != "" {
+		if config.CodePushProjectID, err = uuid.Parse(codePushProjectID); err != nil {
+			log.Fatalf("invalid CodePush project ID: %v", err)
+		}
+	}
+	config.Channels = splitList(channels)
+	config.RuntimeVersions = splitList(runtimeVersions)
+	config.Platforms = splitList(platforms)
+	for _, id := range splitList(updateIDs) {
+		updateID, err := uuid.Parse(id)
+		if err != nil {
+			log.Fatalf("invalid update ID: %v", err)
+		}
+		config.UpdateIDs = append(config.UpdateIDs, updateID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: config.Concurrency}}
+	report, err := loadgen.Run(ctx, client, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	report.Print(os.Stdout)
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/clientcheck"
+	"github.com/a-gierczak/paratrooper/internal/codepushimport"
+	"github.com/a-gierczak/paratrooper/internal/expoimport"
+
+	"github.com/google/uuid"
+)
+
+const usage = `usage: ptctl <command> [flags]
+
+commands:
+  check              check for an update like an Expo or CodePush client
+  import-codepush    import the release history of a CodePush deployment
+  import-expo        import the updates of an EAS Update branch or expo export outputs`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "check":
+		check(os.Args[2:])
+	case "import-codepush":
+		importCodePush(os.Args[2:])
+	case "import-expo":
+		importExpo(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func check(args []string) {
+	config := clientcheck.Config{}
+	var projectID string
+	var verify bool
+
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	flags.StringVar(&config.BaseURL, "url", "http://localhost:8080", "base URL of the server")
+	flags.StringVar(&projectID, "project", "", "ID of the project")
+	flags.StringVar(
+		&config.Protocol,
+		"protocol",
+		clientcheck.ProtocolExpo,
+		"update protocol of the project, expo or codepush",
+	)
+	flags.StringVar(&config.Platform, "platform", "", "platform, e.g. ios or android")
+	flags.StringVar(&config.RuntimeVersion, "runtime", "", "runtime (app) version")
+	flags.StringVar(&config.Channel, "channel", "production", "channel")
+	flags.StringVar(
+		&config.Current,
+		"current",
+		"",
+		"running update, its ID for Expo and package hash for CodePush, empty for the embedded one",
+	)
+	flags.StringVar(&config.ClientID, "client-id", "", "ID of the device, for rollouts")
+	flags.BoolVar(&verify, "verify", false, "download the assets and verify their hashes")
+	flags.Parse(args)
+
+	var err error
+	if config.ProjectID, err = uuid.Parse(projectID); err != nil {
+		log.Fatalf("invalid project ID: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client := http.DefaultClient
+	result, err := clientcheck.Check(ctx, client, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	result.Print(os.Stdout)
+
+	if !verify || len(result.Assets) == 0 {
+		return
+	}
+	fmt.Println("verify")
+	checks := clientcheck.Verify(ctx, client, result)
+	clientcheck.PrintChecks(os.Stdout, checks)
+	for _, check := range checks {
+		if check.Error != "" {
+			os.Exit(1)
+		}
+	}
+}
+
+func importCodePush(args []string) {
+	config := codepushimport.Config{}
+	var projectID string
+
+	flags := flag.NewFlagSet("import-codepush", flag.ExitOnError)
+	flags.StringVar(&config.BaseURL, "url", "http://localhost:8080", "base URL of the server")
+	flags.StringVar(&projectID, "project", "", "ID of the CodePush project")
+	flags.StringVar(&config.Platform, "platform", "", "platform of the deployment, e.g. ios")
+	flags.StringVar(&config.Channel, "channel", "", "channel, usually named like the deployment")
+	flags.StringVar(
+		&config.HistoryPath,
+		"history",
+		"",
+		"deployment history JSON, from code-push-server or an App Center export",
+	)
+	flags.StringVar(&config.From, "from", "", "label to resume the import from")
+	flags.DurationVar(
+		&config.PollInterval,
+		"poll-interval",
+		time.Second,
+		"interval of checking if the imported updates are published",
+	)
+	flags.Parse(args)
+
+	var err error
+	if config.ProjectID, err = uuid.Parse(projectID); err != nil {
+		log.Fatalf("invalid project ID: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := codepushimport.Import(ctx, http.DefaultClient, config, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func importExpo(args []string) {
+	config := expoimport.Config{}
+	var projectID string
+
+	flags := flag.NewFlagSet("import-expo", flag.ExitOnError)
+	flags.StringVar(&config.BaseURL, "url", "http://localhost:8080", "base URL of the server")
+	flags.StringVar(&projectID, "project", "", "ID of the Expo project")
+	flags.StringVar(&config.Channel, "channel", "", "channel, usually named like the branch")
+	flags.StringVar(
+		&config.HistoryPath,
+		"history",
+		"",
+		"JSON list of the updates to import with the paths of their expo export outputs",
+	)
+	flags.StringVar(
+		&config.IDPlatform,
+		"id-platform",
+		"",
+		"platform whose update ID the updates of EAS update groups keep",
+	)
+	flags.DurationVar(
+		&config.PollInterval,
+		"poll-interval",
+		time.Second,
+		"interval of checking if the imported updates are published",
+	)
+	flags.Parse(args)
+
+	var err error
+	if config.ProjectID, err = uuid.Parse(projectID); err != nil {
+		log.Fatalf("invalid project ID: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := expoimport.Import(ctx, http.DefaultClient, config, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
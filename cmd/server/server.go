@@ -1,16 +1,29 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
 
 	"github.com/Netflix/go-env"
 	"github.com/a-gierczak/paratrooper/internal/api"
+	"github.com/a-gierczak/paratrooper/internal/devmode"
 	"github.com/a-gierczak/paratrooper/internal/logger"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
 
 func main() {
+	dev := flag.Bool(
+		"dev",
+		false,
+		"run with an embedded database, in-process queue and local storage, no services needed",
+	)
+	flag.Parse()
+
 	_ = godotenv.Load()
 
 	var config api.Config
@@ -18,15 +31,58 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if *dev {
+		config.DebugMode = true
+	}
 
-	logger, err := logger.NewLogger(config.DebugMode)
+	logger, err := logger.NewLogger(config.DebugMode, config.Log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	defer logger.Sync()
 
-	if err := api.Run(config, logger); err != nil {
+	stopDev := func() {}
+	if *dev {
+		stopDev = runDev(&config, logger)
+	}
+
+	if err := api.Run(config, logger, nil); err != nil {
+		stopDev()
 		logger.Fatal("failed to run api", zap.Error(err))
 	}
 }
+
+// runDev starts the dev services and stops them on interrupt, as the API server never returns
+func runDev(config *api.Config, log *zap.Logger) (stop func()) {
+	var devConfig devmode.Config
+	if _, err := env.UnmarshalFromEnviron(&devConfig); err != nil {
+		log.Fatal("invalid dev mode config", zap.Error(err))
+	}
+
+	ctx := logger.ContextWithLogger(context.Background(), log)
+	stopServices, err := devmode.Setup(ctx, devConfig, config)
+	if err != nil {
+		log.Fatal("failed to set up dev mode", zap.Error(err))
+	}
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			if err := stopServices(); err != nil {
+				log.Error("failed to stop embedded postgres", zap.Error(err))
+			}
+		})
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt)
+	go func() {
+		<-signalChan
+		stop()
+		log.Sync()
+		os.Exit(0)
+	}()
+
+	return stop
+}
@@ -19,7 +19,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	logger, err := logger.NewLogger(config.DebugMode)
+	logger, err := logger.NewLogger(config.DebugMode, config.Log)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,11 @@
+// Package db embeds the database schema and the seed of the test projects,
+// queries are generated into generated/db
+package db
+
+import _ "embed"
+
+//go:embed schema.sql
+var Schema string
+
+//go:embed test-db-seed.sql
+var TestSeed string
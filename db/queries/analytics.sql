@@ -0,0 +1,24 @@
+-- name: CreateUpdateCheckEvents :copyfrom
+insert into update_check_events (project_id,
+                                 channel,
+                                 runtime_version,
+                                 platform,
+                                 decision,
+                                 update_id,
+                                 checked_at,
+                                 reason,
+                                 client_id,
+                                 current_update_id,
+                                 codepush_label)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: GetUpdateCheckEvents :many
+select *
+from update_check_events
+where project_id = sqlc.arg(project_id)
+  and checked_at >= sqlc.arg(checked_from)
+  and checked_at < sqlc.arg(checked_to)
+  and (client_id = sqlc.narg(client_id) or sqlc.narg(client_id) is null)
+  and (channel = sqlc.narg(channel) or sqlc.narg(channel) is null)
+order by checked_at desc
+limit sqlc.arg(row_limit);
@@ -0,0 +1,107 @@
+-- name: SetChannelPin :one
+insert into channel_pins (project_id, channel, runtime_version, update_id, created_at)
+values ($1, $2, $3, $4, current_timestamp)
+on conflict (project_id, channel, runtime_version) do update
+    set update_id  = excluded.update_id,
+        created_at = excluded.created_at
+returning *;
+
+-- name: DeleteChannelPin :execrows
+delete
+from channel_pins
+where project_id = $1
+  and channel = $2
+  and runtime_version = $3;
+
+-- name: GetChannelPins :many
+select *
+from channel_pins
+where project_id = $1
+order by channel, runtime_version;
+
+-- name: GetPinnedUpdate :one
+select sqlc.embed(updates), asset.content_sha256
+from channel_pins pin
+         inner join updates on updates.id = pin.update_id
+         left join update_assets asset
+                   on updates.id = asset.update_id and
+                      asset.platform = sqlc.arg(platform) and
+                      (asset.is_launch_asset = true or asset.is_archive = true)
+where pin.project_id = sqlc.arg(project_id)
+  and pin.runtime_version = sqlc.arg(runtime_version)
+  and pin.channel = sqlc.arg(channel)
+  and updates.status = 'published'
+  and (updates.expires_at is null or updates.expires_at > sqlc.arg(now))
+order by case
+             when asset.is_archive = true then 1 -- select archive asset if exists
+             else 2
+             end
+limit 1;
+
+-- name: IsUpdatePinned :one
+select exists(select 1
+              from channel_pins
+              where update_id = $1);
+
+-- name: CopyChannelPolicies :exec
+insert into channel_policies (project_id, channel, runtime_version_constraint, message_pattern,
+                              updated_at)
+select sqlc.arg(target_project_id), channel, runtime_version_constraint, message_pattern,
+       current_timestamp
+from channel_policies
+where project_id = sqlc.arg(source_project_id);
+
+-- name: SetChannelPolicy :one
+insert into channel_policies (project_id, channel, runtime_version_constraint, message_pattern,
+                              updated_at)
+values ($1, $2, $3, $4, current_timestamp)
+on conflict (project_id, channel) do update
+    set runtime_version_constraint = excluded.runtime_version_constraint,
+        message_pattern            = excluded.message_pattern,
+        updated_at                 = excluded.updated_at
+returning *;
+
+-- name: DeleteChannelPolicy :execrows
+delete
+from channel_policies
+where project_id = $1
+  and channel = $2;
+
+-- name: GetChannelPolicies :many
+select *
+from channel_policies
+where project_id = $1
+order by channel;
+
+-- name: GetChannelPolicy :one
+select *
+from channel_policies
+where project_id = $1
+  and channel = $2;
+
+-- name: SetChannelRollback :one
+insert into channel_rollbacks (project_id, channel, runtime_version, committed_at)
+values ($1, $2, $3, current_timestamp)
+on conflict (project_id, channel, runtime_version) do update
+    set committed_at = excluded.committed_at
+returning *;
+
+-- name: GetActiveChannelRollback :one
+-- the latest roll back of the channel no update of the flavor was published after
+select channel_rollbacks.*
+from channel_rollbacks
+where channel_rollbacks.project_id = sqlc.arg(project_id)
+  and channel_rollbacks.channel = sqlc.arg(channel)
+  and channel_rollbacks.runtime_version in ('', sqlc.arg(runtime_version)::text)
+  and not exists(select 1
+                 from updates
+                 where updates.project_id = channel_rollbacks.project_id
+                   and updates.channel = channel_rollbacks.channel
+                   and updates.runtime_version = sqlc.arg(runtime_version)
+                   and updates.status = 'published'
+                   and (cardinality(updates.flavors) = 0 or
+                        sqlc.arg(flavor)::text = any (updates.flavors))
+                   and coalesce(updates.committed_at, updates.created_at) >
+                       channel_rollbacks.committed_at)
+order by channel_rollbacks.committed_at desc
+limit 1;
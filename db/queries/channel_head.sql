@@ -0,0 +1,103 @@
+-- name: RefreshChannelHeads :exec
+-- resolves the latest updates of every platform like GetLatestPublishedAndCanceledUpdates, of
+-- heads refreshed concurrently the one that read the latest state is kept
+insert into channel_heads (project_id,
+                           channel,
+                           runtime_version,
+                           platform,
+                           published_update_id,
+                           published_content_sha256,
+                           canceled_update_id,
+                           canceled_content_sha256,
+                           exact,
+                           refreshed_at)
+select sqlc.arg(project_id)::uuid,
+       sqlc.arg(channel)::text,
+       sqlc.arg(runtime_version)::text,
+       platforms.platform,
+       published.id,
+       published.content_sha256,
+       canceled.id,
+       canceled.content_sha256,
+       not exists(select 1
+                  from updates
+                  where updates.project_id = sqlc.arg(project_id)
+                    and updates.channel = sqlc.arg(channel)
+                    and updates.runtime_version = sqlc.arg(runtime_version)
+                    and updates.status in ('published', 'canceled')
+                    and (cardinality(updates.flavors) > 0 or
+                         (updates.status = 'published' and
+                          updates.expires_at > current_timestamp) or
+                         (updates.status = 'published' and
+                          updates.expires_at is null and
+                          updates.rollout_percentage < 100))),
+       statement_timestamp()
+from unnest(sqlc.arg(platforms)::text[]) as platforms(platform)
+         left join lateral (select updates.id, asset.content_sha256
+                            from updates
+                                     left join update_assets asset
+                                               on updates.id = asset.update_id and
+                                                  asset.platform = platforms.platform and
+                                                  (asset.is_launch_asset = true or asset.is_archive = true)
+                            where updates.project_id = sqlc.arg(project_id)
+                              and updates.channel = sqlc.arg(channel)
+                              and updates.runtime_version = sqlc.arg(runtime_version)
+                              and updates.status = 'published'
+                              and (updates.expires_at is null or
+                                   updates.expires_at > current_timestamp)
+                            order by case when asset.is_archive = true then 1 else 2 end,
+                                     coalesce(updates.committed_at, updates.created_at) desc
+                            limit 1) published on true
+         left join lateral (select updates.id, asset.content_sha256
+                            from updates
+                                     left join update_assets asset
+                                               on updates.id = asset.update_id and
+                                                  asset.platform = platforms.platform and
+                                                  (asset.is_launch_asset = true or asset.is_archive = true)
+                            where updates.project_id = sqlc.arg(project_id)
+                              and updates.channel = sqlc.arg(channel)
+                              and updates.runtime_version = sqlc.arg(runtime_version)
+                              and (updates.status = 'canceled' or
+                                   (updates.status = 'published' and
+                                    updates.expires_at <= current_timestamp))
+                            order by case when asset.is_archive = true then 1 else 2 end,
+                                     coalesce(updates.committed_at, updates.created_at) desc
+                            limit 1) canceled on true
+on conflict (project_id, channel, runtime_version, platform) do update
+    set published_update_id      = excluded.published_update_id,
+        published_content_sha256 = excluded.published_content_sha256,
+        canceled_update_id       = excluded.canceled_update_id,
+        canceled_content_sha256  = excluded.canceled_content_sha256,
+        exact                    = excluded.exact,
+        refreshed_at             = excluded.refreshed_at
+where channel_heads.refreshed_at <= excluded.refreshed_at;
+
+-- name: GetChannelHeadUpdates :many
+-- the latest published update first, nothing when the head isn't exact
+select sqlc.embed(updates), heads.content_sha256
+from channel_heads
+         cross join lateral (values (channel_heads.published_update_id,
+                                     channel_heads.published_content_sha256,
+                                     1),
+                                    (channel_heads.canceled_update_id,
+                                     channel_heads.canceled_content_sha256,
+                                     2)) as heads(update_id, content_sha256, position)
+         inner join updates on updates.id = heads.update_id
+where channel_heads.project_id = sqlc.arg(project_id)
+  and channel_heads.channel = sqlc.arg(channel)
+  and channel_heads.runtime_version = sqlc.arg(runtime_version)
+  and channel_heads.platform = sqlc.arg(platform)
+  and channel_heads.exact
+order by heads.position;
+
+-- name: GetChannelsWithoutHeads :many
+-- channels with updates published before the heads were kept
+select distinct updates.project_id, updates.channel, updates.runtime_version
+from updates
+where updates.status in ('published', 'canceled')
+  and not exists(select 1
+                 from channel_heads
+                 where channel_heads.project_id = updates.project_id
+                   and channel_heads.channel = updates.channel
+                   and channel_heads.runtime_version = updates.runtime_version)
+limit sqlc.arg(row_limit);
@@ -0,0 +1,79 @@
+-- name: IncrementCodePushReleaseStats :exec
+-- reports of releases that aren't updates of the project are ignored
+insert into codepush_release_stats (update_id,
+                                    project_id,
+                                    downloads,
+                                    deployments_succeeded,
+                                    deployments_failed,
+                                    last_reported_at)
+select updates.id,
+       updates.project_id,
+       sqlc.arg(downloads)::bigint,
+       sqlc.arg(deployments_succeeded)::bigint,
+       sqlc.arg(deployments_failed)::bigint,
+       current_timestamp
+from updates
+where updates.id = sqlc.arg(update_id)
+  and updates.project_id = sqlc.arg(project_id)
+on conflict (update_id) do update
+    set downloads             = codepush_release_stats.downloads + excluded.downloads,
+        deployments_succeeded = codepush_release_stats.deployments_succeeded +
+                                excluded.deployments_succeeded,
+        deployments_failed    = codepush_release_stats.deployments_failed +
+                                excluded.deployments_failed,
+        last_reported_at      = excluded.last_reported_at;
+
+-- name: GetCodePushReleaseStats :many
+select *
+from codepush_release_stats
+where project_id = sqlc.arg(project_id)
+order by last_reported_at desc
+limit sqlc.arg(row_limit);
+
+-- name: GetCodePushReleaseStatsByUpdateID :one
+select *
+from codepush_release_stats
+where update_id = $1;
+
+-- name: GetUpdateIDByCodePushLabel :one
+select id
+from updates
+where project_id = sqlc.arg(project_id)
+  and channel = sqlc.arg(channel)
+  and codepush_label = sqlc.arg(codepush_label)::text;
+
+-- name: NextCodePushLabelNumber :one
+-- numbers of the labels set explicitly, e.g. of imported releases, are skipped
+insert into codepush_label_sequences (project_id, channel, last_number)
+select sqlc.arg(project_id)::uuid,
+       sqlc.arg(channel)::varchar,
+       coalesce(max(substring(codepush_label from '^v([0-9]{1,9})$')::integer), 0) + 1
+from updates
+where project_id = sqlc.arg(project_id)
+  and channel = sqlc.arg(channel)
+on conflict (project_id, channel) do update
+    set last_number = greatest(codepush_label_sequences.last_number + 1, excluded.last_number)
+returning last_number;
+
+-- name: HasSkippedMandatoryUpdate :one
+-- whether a mandatory update of the channel was published after the one the client runs, up
+-- to the one it gets. Clients running none of them, e.g. the binary's bundle, skipped all.
+select exists(select 1
+              from updates
+              where updates.project_id = sqlc.arg(project_id)
+                and updates.channel = sqlc.arg(channel)
+                and updates.runtime_version = sqlc.arg(runtime_version)
+                and updates.status = 'published'
+                and updates.is_mandatory
+                and updates.committed_at <= sqlc.arg(committed_at)
+                and updates.committed_at > coalesce(
+                      (select max(installed.committed_at)
+                       from updates installed
+                                join update_assets on update_assets.update_id =
+                                                      coalesce(installed.linked_update_id, installed.id)
+                       where installed.project_id = sqlc.arg(project_id)
+                         and installed.channel = sqlc.arg(channel)
+                         and update_assets.platform = sqlc.arg(platform)
+                         and (update_assets.is_launch_asset or update_assets.is_archive)
+                         and update_assets.content_sha256 = sqlc.arg(package_hash)::text),
+                      '-infinity'));
@@ -0,0 +1,41 @@
+-- name: GetCodePushDiffBases :many
+-- latest distinct packages published on the channels of the update and its linked updates
+select update_id, package_hash
+from (select distinct on (update_assets.content_sha256) update_assets.update_id,
+                                                        update_assets.content_sha256 as package_hash,
+                                                        coalesce(updates.committed_at, updates.created_at) as committed_at
+      from updates
+               inner join update_assets on update_assets.update_id = updates.id
+      where updates.project_id = sqlc.arg(project_id)
+        and updates.runtime_version = sqlc.arg(runtime_version)
+        and updates.status = 'published'
+        and updates.channel in (select channel
+                                from updates linked
+                                where linked.id = sqlc.arg(update_id)
+                                   or linked.linked_update_id = sqlc.arg(update_id))
+        and update_assets.is_archive = true
+        and update_assets.platform = sqlc.arg(platform)
+        and update_assets.content_sha256 <> sqlc.arg(package_hash)
+      order by update_assets.content_sha256, committed_at desc) bases
+order by committed_at desc
+limit sqlc.arg(row_limit);
+
+-- name: CreateCodePushDiffPackage :exec
+insert into codepush_diff_packages (update_id,
+                                    platform,
+                                    base_package_hash,
+                                    storage_object_path,
+                                    content_md5,
+                                    content_length)
+values ($1, $2, $3, $4, $5, $6)
+on conflict (update_id, platform, base_package_hash) do update
+    set storage_object_path = excluded.storage_object_path,
+        content_md5         = excluded.content_md5,
+        content_length      = excluded.content_length;
+
+-- name: GetCodePushDiffPackage :one
+select *
+from codepush_diff_packages
+where update_id = $1
+  and platform = $2
+  and base_package_hash = $3;
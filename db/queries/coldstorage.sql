@@ -0,0 +1,44 @@
+-- name: GetUpdatesToMoveToColdStorage :many
+select updates.*
+from updates
+         inner join projects on projects.id = updates.project_id
+where updates.status in ('published', 'canceled')
+  and updates.cold_storage_at is null
+  and updates.created_at < sqlc.arg(created_before)
+  and projects.storage_driver_url is null
+  and projects.deleted_at is null
+  and exists(select 1
+             from updates newer
+             where newer.project_id = updates.project_id
+               and newer.channel = updates.channel
+               and newer.runtime_version = updates.runtime_version
+               and newer.status = 'published'
+               and coalesce(newer.committed_at, newer.created_at) >
+                   coalesce(updates.committed_at, updates.created_at))
+  and not exists(select 1
+                 from channel_pins pins
+                 where pins.update_id = updates.id)
+  -- assets shared by linked updates aren't moved
+  and updates.linked_update_id is null
+  and not exists(select 1
+                 from updates linked
+                 where linked.linked_update_id = updates.id)
+  -- objects shared with other updates, or declared as shared by updates being processed
+  and not exists(select 1
+                 from update_assets own
+                          inner join update_assets shared
+                                     on shared.storage_object_path = own.storage_object_path
+                 where own.update_id = updates.id
+                   and shared.update_id <> updates.id)
+  and not exists(select 1
+                 from update_assets own
+                          inner join update_storage_objects pending
+                                     on pending.shared_object_key = own.storage_object_path
+                 where own.update_id = updates.id)
+order by updates.created_at
+limit sqlc.arg(row_limit);
+
+-- name: SetUpdateColdStorageAt :exec
+update updates
+set cold_storage_at = $2
+where id = $1;
@@ -0,0 +1,18 @@
+-- name: GetSharableAssets :many
+-- objects of moved, encoded and corrupted assets aren't shared
+select update_assets.storage_object_path,
+       update_assets.content_md5,
+       update_assets.content_sha256,
+       update_assets.content_length
+from update_assets
+         inner join updates on updates.id = update_assets.update_id
+where updates.project_id = sqlc.arg(project_id)
+  and updates.status = 'published'
+  and updates.cold_storage_at is null
+  and update_assets.content_encoding = ''
+  and update_assets.content_sha256 = any (sqlc.arg(content_hashes)::varchar[])
+  and not exists(select 1
+                 from asset_integrity_checks checks
+                 where checks.asset_id = update_assets.id
+                   and checks.status <> 'ok')
+order by updates.created_at desc;
@@ -0,0 +1,28 @@
+-- name: RegisterEmbeddedUpdate :one
+insert into embedded_updates (project_id, platform, embedded_id, runtime_version,
+                              launch_asset_sha256, created_at)
+values ($1, $2, $3, $4, $5, current_timestamp)
+on conflict (project_id, platform, embedded_id) do update
+    set runtime_version     = excluded.runtime_version,
+        launch_asset_sha256 = excluded.launch_asset_sha256,
+        created_at          = excluded.created_at
+returning *;
+
+-- name: GetEmbeddedUpdates :many
+select *
+from embedded_updates
+where project_id = $1
+order by created_at desc;
+
+-- name: MatchesEmbeddedUpdate :one
+select exists(select 1
+              from embedded_updates embedded
+                       inner join update_assets asset
+                                  on asset.update_id = sqlc.arg(update_id) and
+                                     asset.platform = embedded.platform and
+                                     asset.is_launch_asset = true
+              where embedded.project_id = sqlc.arg(project_id)
+                and embedded.platform = sqlc.arg(platform)
+                and embedded.embedded_id = sqlc.arg(embedded_id)
+                and embedded.runtime_version = sqlc.arg(runtime_version)
+                and asset.content_sha256 = embedded.launch_asset_sha256);
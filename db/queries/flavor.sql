@@ -0,0 +1,28 @@
+-- name: CreateProjectFlavor :one
+insert into project_flavors (project_id, name, created_at)
+values ($1, $2, current_timestamp)
+returning *;
+
+-- name: GetProjectFlavors :many
+select *
+from project_flavors
+where project_id = $1
+order by name;
+
+-- name: HasProjectFlavor :one
+select exists(select 1
+              from project_flavors
+              where project_id = $1
+                and name = $2);
+
+-- name: DeleteProjectFlavor :execrows
+delete
+from project_flavors
+where project_id = $1
+  and name = $2;
+
+-- name: CopyProjectFlavors :exec
+insert into project_flavors (project_id, name, created_at)
+select sqlc.arg(target_project_id), name, current_timestamp
+from project_flavors
+where project_id = sqlc.arg(source_project_id);
@@ -0,0 +1,35 @@
+-- name: GetAssetsToVerify :many
+select sqlc.embed(update_assets)
+from update_assets
+         inner join updates on updates.id = update_assets.update_id
+         left join asset_integrity_checks checks on checks.asset_id = update_assets.id
+where updates.status in ('published', 'canceled')
+  and updates.cold_storage_at is null
+  and (checks.checked_at is null or checks.checked_at < sqlc.arg(checked_before))
+  and coalesce(checks.quarantined, false) = false
+order by checks.checked_at nulls first, update_assets.created_at
+limit sqlc.arg(row_limit);
+
+-- name: SetAssetIntegrityCheck :exec
+insert into asset_integrity_checks (asset_id, status, actual_hash, error, quarantined, checked_at)
+values ($1, $2, $3, $4, $5, current_timestamp)
+on conflict (asset_id) do update
+    set status      = excluded.status,
+        actual_hash = excluded.actual_hash,
+        error       = excluded.error,
+        quarantined = excluded.quarantined,
+        checked_at  = excluded.checked_at;
+
+-- name: GetCorruptedAssets :many
+select sqlc.embed(update_assets), sqlc.embed(checks)
+from asset_integrity_checks checks
+         inner join update_assets on update_assets.id = checks.asset_id
+         inner join updates on updates.id = update_assets.update_id
+where updates.project_id = $1
+  and checks.status <> 'ok'
+order by checks.checked_at desc;
+
+-- name: CountCorruptedAssets :one
+select count(*)
+from asset_integrity_checks
+where status <> 'ok';
@@ -0,0 +1,2 @@
+-- name: TryAdvisoryLock :one
+select pg_try_advisory_lock(@key::bigint);
@@ -0,0 +1,9 @@
+-- name: GetMaintenanceMode :one
+select enabled
+from maintenance_mode;
+
+-- name: SetMaintenanceMode :exec
+insert into maintenance_mode (enabled)
+values ($1)
+on conflict (id) do update set enabled    = excluded.enabled,
+                               updated_at = CURRENT_TIMESTAMP;
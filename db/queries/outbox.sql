@@ -0,0 +1,17 @@
+-- name: CreateUpdateOutboxEntry :exec
+insert into update_outbox (update_id)
+values ($1)
+on conflict (update_id) do nothing;
+
+-- name: DeleteUpdateOutboxEntry :exec
+delete
+from update_outbox
+where update_id = $1;
+
+-- name: GetStaleUpdateOutboxEntries :many
+-- locked until the transaction ends, so the entries are published by a single relay
+select update_id
+from update_outbox
+where created_at < sqlc.arg(created_before)
+order by created_at
+limit sqlc.arg(row_limit) for update skip locked;
@@ -1,7 +1,137 @@
 -- name: CreateProject :one
-INSERT INTO projects (id, name, update_protocol, created_at)
-VALUES ($1, $2, $3, current_timestamp)
+INSERT INTO projects (id, name, update_protocol, environment, storage_driver_url, created_at)
+VALUES ($1, $2, $3, $4, $5, current_timestamp)
+RETURNING *;
+
+-- name: CloneProject :one
+INSERT INTO projects (id, name, update_protocol, public_assets_url, asset_url_template,
+                      replica_regions, environment, admin_allowed_cidrs, max_asset_count,
+                      codepush_suggest_binary_update, codepush_description_source,
+                      stable_asset_urls, storage_driver_url, asset_priorities,
+                      metered_download_limit, max_storage_bytes, created_at)
+SELECT sqlc.arg(id),
+       sqlc.arg(name),
+       update_protocol,
+       public_assets_url,
+       asset_url_template,
+       replica_regions,
+       sqlc.arg(environment),
+       admin_allowed_cidrs,
+       max_asset_count,
+       codepush_suggest_binary_update,
+       codepush_description_source,
+       stable_asset_urls,
+       storage_driver_url,
+       asset_priorities,
+       metered_download_limit,
+       max_storage_bytes,
+       current_timestamp
+FROM projects
+WHERE projects.id = sqlc.arg(source_id)
 RETURNING *;
 
 -- name: GetProjectById :one
-SELECT * FROM projects WHERE id = $1;
+SELECT * FROM projects WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetProjectByNameAndEnvironment :one
+SELECT * FROM projects WHERE name = $1 AND environment = $2;
+
+-- name: GetProjectIDs :many
+SELECT id FROM projects WHERE deleted_at IS NULL ORDER BY id;
+
+-- name: GetProjectEnvironments :many
+SELECT * FROM projects WHERE name = $1 AND deleted_at IS NULL ORDER BY environment;
+
+-- name: SetProjectConfig :one
+UPDATE projects
+SET public_assets_url  = $2,
+    asset_url_template = $3,
+    replica_regions    = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectPublicAssetsURL :one
+UPDATE projects
+SET public_assets_url = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectAssetURLTemplate :one
+UPDATE projects
+SET asset_url_template = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectAdminAllowedCIDRs :one
+UPDATE projects
+SET admin_allowed_cidrs = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectMaxAssetCount :one
+UPDATE projects
+SET max_asset_count = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectMeteredDownloadLimit :one
+UPDATE projects
+SET metered_download_limit = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectMaxStorageBytes :one
+UPDATE projects
+SET max_storage_bytes = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetProjectMaxAssetCount :one
+SELECT max_asset_count FROM projects WHERE id = $1;
+
+-- name: GetProjectStorageDriverURL :one
+SELECT storage_driver_url FROM projects WHERE id = $1;
+
+-- name: GetProjectUpdateProtocol :one
+SELECT update_protocol FROM projects WHERE id = $1;
+
+-- name: SetProjectCodePushSettings :one
+UPDATE projects
+SET codepush_suggest_binary_update = $2,
+    codepush_description_source    = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectReplicaRegions :one
+UPDATE projects
+SET replica_regions = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectStableAssetUrls :one
+UPDATE projects
+SET stable_asset_urls = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectAssetPriorities :one
+UPDATE projects
+SET asset_priorities = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProjectArchivedAt :one
+UPDATE projects
+SET archived_at = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SoftDeleteProject :one
+-- deleting the project again keeps the time of the first deletion
+UPDATE projects
+SET deleted_at = coalesce(deleted_at, current_timestamp)
+WHERE id = $1
+RETURNING *;
+
+-- name: GetProjectDeletedAt :one
+SELECT deleted_at FROM projects WHERE id = $1;
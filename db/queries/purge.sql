@@ -0,0 +1,132 @@
+-- name: GetProjectUpdateIDsToPurge :many
+-- linked updates first, they reference the update they were published with
+select id
+from updates
+where project_id = sqlc.arg(project_id)
+order by linked_update_id is null, id
+limit sqlc.arg(row_limit);
+
+-- name: DeleteIntegrityChecksOfUpdates :exec
+delete
+from asset_integrity_checks
+where asset_id in (select id
+                   from update_assets
+                   where update_id = any (sqlc.arg(update_ids)::uuid[]));
+
+-- name: DeleteAssetsOfUpdates :exec
+delete
+from update_assets
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteMetadataOfUpdates :exec
+delete
+from update_metadata
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteStorageObjectsOfUpdates :exec
+delete
+from update_storage_objects
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteProcessingReportsOfUpdates :exec
+delete
+from update_processing_reports
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteOutboxEntriesOfUpdates :exec
+delete
+from update_outbox
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteAdoptionStatsOfUpdates :exec
+delete
+from update_adoption_stats
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteReplicasOfUpdates :exec
+delete
+from update_replicas
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteCodePushDiffPackagesOfUpdates :exec
+delete
+from codepush_diff_packages
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteCodePushReleaseStatsOfUpdates :exec
+delete
+from codepush_release_stats
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteDownloadStatsOfUpdates :exec
+delete
+from asset_download_stats
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteChannelPinsOfUpdates :exec
+delete
+from channel_pins
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteUpdates :exec
+-- rows referencing the updates have to be deleted first
+delete
+from updates
+where id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: DeleteChannelHeadsOfProject :exec
+delete
+from channel_heads
+where project_id = sqlc.arg(project_id);
+
+-- name: DeleteChannelPoliciesOfProject :exec
+delete
+from channel_policies
+where project_id = $1;
+
+-- name: DeleteChannelRollbacksOfProject :exec
+delete
+from channel_rollbacks
+where project_id = sqlc.arg(project_id);
+
+-- name: DeleteSigningKeysOfProject :exec
+delete
+from signing_keys
+where project_id = $1;
+
+-- name: DeleteEmbeddedUpdatesOfProject :exec
+delete
+from embedded_updates
+where project_id = $1;
+
+-- name: DeleteFlavorsOfProject :exec
+delete
+from project_flavors
+where project_id = $1;
+
+-- name: DeleteCodePushLabelSequencesOfProject :exec
+delete
+from codepush_label_sequences
+where project_id = $1;
+
+-- name: DeleteUpdateCheckEventsOfProject :exec
+delete
+from update_check_events
+where project_id = $1;
+
+-- name: DeleteAPIUsageStatsOfProject :exec
+delete
+from api_usage_stats
+where project_id = $1;
+
+-- name: DeleteUsageOfProject :exec
+delete
+from project_usage
+where project_id = $1;
+
+-- name: DeleteProject :exec
+-- only deleted projects are purged, rows referencing the project have to be deleted first
+delete
+from projects
+where id = $1
+  and deleted_at is not null;
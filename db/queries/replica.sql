@@ -0,0 +1,10 @@
+-- name: CreateUpdateReplicas :exec
+insert into update_replicas (update_id, region)
+select sqlc.arg(update_id), unnest(sqlc.arg(regions)::text[])
+on conflict (update_id, region) do nothing;
+
+-- name: GetUpdateReplicaRegions :many
+select region
+from update_replicas
+where update_id = $1
+order by region;
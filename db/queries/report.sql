@@ -0,0 +1,20 @@
+-- name: CreateUpdateProcessingReport :exec
+insert into update_processing_reports (id,
+                                       update_id,
+                                       status,
+                                       error,
+                                       asset_count,
+                                       archive_count,
+                                       unpacked_files,
+                                       hashed_files,
+                                       bytes_hashed,
+                                       warnings,
+                                       started_at,
+                                       duration_ms)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: GetUpdateProcessingReports :many
+select *
+from update_processing_reports
+where update_id = $1
+order by started_at desc;
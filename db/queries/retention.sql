@@ -0,0 +1,55 @@
+-- name: GetUpdatesPastRetention :many
+-- updates with at least keep_last newer published updates of their channel, runtime version and
+-- flavors, updates still being uploaded or processed are left alone
+select updates.*
+from updates
+         inner join projects on projects.id = updates.project_id
+where updates.status in ('published', 'failed', 'canceled')
+  and projects.deleted_at is null
+  and (select count(*)
+       from updates newer
+       where newer.project_id = updates.project_id
+         and newer.channel = updates.channel
+         and newer.runtime_version = updates.runtime_version
+         and newer.flavors = updates.flavors
+         and newer.status = 'published'
+         and coalesce(newer.committed_at, newer.created_at) >
+             coalesce(updates.committed_at, updates.created_at)) >= sqlc.arg(keep_last)::bigint
+  and not exists(select 1
+                 from channel_pins pins
+                 where pins.update_id = updates.id)
+  -- linked updates reference the update, it's deleted after them
+  and not exists(select 1
+                 from updates linked
+                 where linked.linked_update_id = updates.id)
+order by updates.created_at
+limit sqlc.arg(row_limit);
+
+-- name: GetObjectKeysOfUpdates :many
+-- objects the updates were served from, some of them stored under the prefixes of other updates
+select storage_object_path::varchar as object_key
+from update_assets
+where update_id = any (sqlc.arg(update_ids)::uuid[])
+union
+select shared_object_key::varchar
+from update_storage_objects
+where update_id = any (sqlc.arg(update_ids)::uuid[])
+  and shared_object_key is not null
+union
+select storage_object_path::varchar
+from codepush_diff_packages
+where update_id = any (sqlc.arg(update_ids)::uuid[]);
+
+-- name: GetReferencedObjectKeys :many
+-- keys of the objects still served or shared by an update
+select storage_object_path::varchar as object_key
+from update_assets
+where storage_object_path = any (sqlc.arg(object_keys)::varchar[])
+union
+select shared_object_key::varchar
+from update_storage_objects
+where shared_object_key = any (sqlc.arg(object_keys)::varchar[])
+union
+select storage_object_path::varchar
+from codepush_diff_packages
+where storage_object_path = any (sqlc.arg(object_keys)::varchar[]);
@@ -0,0 +1,24 @@
+-- name: CreateSigningKey :one
+insert into signing_keys (id, project_id, key_id, algorithm, private_key, certificate_chain, created_at)
+values ($1, $2, $3, $4, $5, $6, current_timestamp)
+returning *;
+
+-- name: GetSigningKeys :many
+select *
+from signing_keys
+where project_id = $1
+order by created_at desc;
+
+-- name: RetireSigningKey :execrows
+update signing_keys
+set retired_at = current_timestamp
+where project_id = $1
+  and key_id = $2
+  and (retired_at is null or retired_at > current_timestamp);
+
+-- name: ScheduleSigningKeysRetirement :exec
+update signing_keys
+set retired_at = sqlc.arg(retired_at)
+where project_id = sqlc.arg(project_id)
+  and id <> sqlc.arg(id)
+  and retired_at is null;
@@ -0,0 +1,85 @@
+-- name: IncrementAssetDownloads :exec
+insert into asset_download_stats (object_key,
+                                  project_id,
+                                  update_id,
+                                  path,
+                                  downloads,
+                                  bytes_served,
+                                  last_downloaded_at)
+values ($1, $2, $3, $4, $5, $6, $7)
+on conflict (object_key) do update
+    set downloads          = asset_download_stats.downloads + excluded.downloads,
+        bytes_served       = asset_download_stats.bytes_served + excluded.bytes_served,
+        last_downloaded_at = greatest(asset_download_stats.last_downloaded_at,
+                                      excluded.last_downloaded_at);
+
+-- name: GetAssetDownloadStats :many
+select *
+from asset_download_stats
+where project_id = sqlc.arg(project_id)
+  and (update_id = sqlc.narg(update_id) or sqlc.narg(update_id) is null)
+order by bytes_served desc, object_key
+limit sqlc.arg(row_limit);
+
+-- name: IncrementUpdateAdoptionStats :exec
+-- checks of updates that aren't updates of the project, e.g. embedded ones, are ignored
+insert into update_adoption_stats (update_id,
+                                   project_id,
+                                   day,
+                                   platform,
+                                   downloads,
+                                   departures,
+                                   rollbacks)
+select updates.id,
+       updates.project_id,
+       sqlc.arg(day)::date,
+       sqlc.arg(platform)::varchar,
+       sqlc.arg(downloads)::bigint,
+       sqlc.arg(departures)::bigint,
+       sqlc.arg(rollbacks)::bigint
+from updates
+where updates.id = sqlc.arg(update_id)
+  and updates.project_id = sqlc.arg(project_id)
+on conflict (update_id, day, platform) do update
+    set downloads  = update_adoption_stats.downloads + excluded.downloads,
+        departures = update_adoption_stats.departures + excluded.departures,
+        rollbacks  = update_adoption_stats.rollbacks + excluded.rollbacks;
+
+-- name: GetUpdateAdoptionStats :many
+select *
+from update_adoption_stats
+where update_id = $1
+order by day desc, platform;
+
+-- name: IncrementAPIUsageStats :exec
+-- requests of deleted projects and of project IDs that don't exist are ignored
+insert into api_usage_stats (project_id,
+                             operation,
+                             period_start,
+                             latency_le_ms,
+                             requests,
+                             client_errors,
+                             server_errors)
+select projects.id,
+       sqlc.arg(operation)::varchar,
+       sqlc.arg(period_start)::timestamptz,
+       sqlc.arg(latency_le_ms)::integer,
+       sqlc.arg(requests)::bigint,
+       sqlc.arg(client_errors)::bigint,
+       sqlc.arg(server_errors)::bigint
+from projects
+where projects.id = sqlc.arg(project_id)
+  and projects.deleted_at is null
+on conflict (project_id, period_start, operation, latency_le_ms) do update
+    set requests      = api_usage_stats.requests + excluded.requests,
+        client_errors = api_usage_stats.client_errors + excluded.client_errors,
+        server_errors = api_usage_stats.server_errors + excluded.server_errors;
+
+-- name: GetAPIUsageStats :many
+select *
+from api_usage_stats
+where project_id = sqlc.arg(project_id)
+  and period_start >= sqlc.arg(period_from)
+  and period_start < sqlc.arg(period_to)
+  and (operation = sqlc.narg(operation) or sqlc.narg(operation) is null)
+order by period_start, operation, latency_le_ms;
@@ -1,5 +1,8 @@
 -- name: GetLatestPublishedAndCanceledUpdates :many
-select distinct on (updates.status) sqlc.embed(updates), asset.content_sha256
+-- expired updates are returned as the canceled ones
+select distinct on (updates.status = 'published' and
+                    (updates.expires_at is null or updates.expires_at > sqlc.arg(now)))
+    sqlc.embed(updates), asset.content_sha256
 from updates
          left join update_assets asset
                    on updates.id = asset.update_id and
@@ -9,12 +12,26 @@ where updates.project_id = sqlc.arg(project_id)
   and updates.runtime_version = sqlc.arg(runtime_version)
   and updates.channel = sqlc.arg(channel)
   and updates.status in ('published', 'canceled')
-order by updates.status,
+  and (cardinality(updates.flavors) = 0 or sqlc.arg(flavor)::text = any (updates.flavors))
+  -- updates rolled out partially are published only to the devices of the rolled out buckets
+  and (updates.rollout_percentage >= 100 or
+       updates.status = 'canceled' or
+       updates.expires_at <= sqlc.arg(now) or
+       sqlc.arg(rollout_bucket)::int < updates.rollout_percentage)
+order by updates.status = 'published' and
+         (updates.expires_at is null or updates.expires_at > sqlc.arg(now)) desc,
          case
              when asset.is_archive = true then 1 -- select archive asset if exists
              else 2
              end,
-         updates.created_at desc;
+         coalesce(updates.committed_at, updates.created_at) desc;
+
+-- name: GetUpdateByExternalID :one
+select *
+from updates
+where project_id = sqlc.arg(project_id)
+  and external_id = sqlc.arg(external_id)
+limit 1;
 
 -- name: GetUpdateByID :one
 select *
@@ -24,13 +41,66 @@ where id = sqlc.arg(update_id)
 limit 1;
 
 -- name: GetUpdateByIDWithProtocol :one
-select u.*, p.update_protocol as protocol
+select u.*, p.update_protocol as protocol, p.replica_regions, p.max_asset_count
 from updates u
          inner join projects p on u.project_id = p.id
 where u.id = sqlc.arg(update_id)
 limit 1;
 
 
+-- name: SetUpdateContentHash :exec
+update updates
+set content_hash = $2
+where id = $1;
+
+-- name: SetUpdateExpiresAt :one
+update updates
+set expires_at = sqlc.narg(expires_at)
+where id = sqlc.arg(id)
+  and project_id = sqlc.arg(project_id)
+returning *;
+
+-- name: SetUpdateRolloutPercentage :one
+update updates
+set rollout_percentage = sqlc.arg(rollout_percentage)
+where id = sqlc.arg(id)
+  and project_id = sqlc.arg(project_id)
+returning *;
+
+-- name: SetUpdateIsMandatory :one
+update updates
+set is_mandatory = sqlc.arg(is_mandatory)
+where id = sqlc.arg(id)
+  and project_id = sqlc.arg(project_id)
+returning *;
+
+-- name: GetLinkedUpdates :many
+select *
+from updates
+where linked_update_id = sqlc.arg(update_id)::uuid
+order by channel;
+
+-- name: SetLinkedUpdatesStatus :exec
+update updates
+set status = sqlc.arg(status)
+where linked_update_id = sqlc.arg(update_id)::uuid;
+
+-- name: SetUpdateCommitted :one
+-- sets the update pending and records when it was committed, the latest committed update
+-- of a channel is served even when an earlier one finishes processing after it. Committed
+-- updates aren't returned, so an update is processed once.
+update updates
+set status       = 'pending',
+    committed_at = current_timestamp
+where id = $1
+  and status = 'empty'
+returning *;
+
+-- name: SetLinkedUpdatesCommittedAt :exec
+update updates
+set committed_at = sqlc.arg(committed_at)
+where linked_update_id = sqlc.arg(update_id)::uuid;
+
 -- name: SetUpdateStatus :one
 UPDATE updates
 SET status = $2
@@ -43,15 +113,26 @@ INSERT INTO updates (id,
                      runtime_version,
                      message,
                      channel,
+                     flavors,
+                     expires_at,
+                     rollout_percentage,
+                     linked_update_id,
+                     codepush_label,
+                     is_mandatory,
+                     external_id,
                      status,
                      created_at)
-VALUES ($1, $2, $3, $4, $5, 'empty', current_timestamp);
+VALUES ($1, $2, $3, $4, $5, coalesce(sqlc.narg(flavors)::text[], '{}'), sqlc.narg(expires_at),
+        coalesce(sqlc.narg(rollout_percentage)::smallint, 100), sqlc.narg(linked_update_id),
+        sqlc.narg(codepush_label), sqlc.arg(is_mandatory), sqlc.narg(external_id), 'empty',
+        coalesce(sqlc.narg(created_at), current_timestamp));
 
 -- name: CreateUpdateAssets :copyfrom
 INSERT INTO update_assets (id,
                            update_id,
                            storage_object_path,
                            content_type,
+                           content_encoding,
                            extension,
                            content_md5,
                            content_sha256,
@@ -59,7 +140,7 @@ INSERT INTO update_assets (id,
                            is_archive,
                            platform,
                            content_length)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 
 -- name: CreateUpdateMetadata :exec
 INSERT INTO update_metadata (id,
@@ -68,6 +149,20 @@ INSERT INTO update_metadata (id,
                              created_at)
 VALUES ($1, $2, $3, current_timestamp);
 
+-- name: CopyUpdateMetadata :exec
+insert into update_metadata (id, update_id, expo_app_config, created_at)
+select sqlc.arg(id), sqlc.arg(target_update_id), expo_app_config, current_timestamp
+from update_metadata
+where update_id = sqlc.arg(source_update_id);
+
+-- name: GetPublishedAsset :one
+-- assets of published updates, served by their ID
+select update_assets.*
+from update_assets
+         inner join updates on updates.id = update_assets.update_id
+where update_assets.id = $1
+  and updates.status = 'published';
+
 -- name: GetUpdateAssetsByPlatform :many
 select *
 from update_assets
@@ -75,6 +170,19 @@ where update_id = $1
   and platform = $2
   and is_archive = false;
 
+-- name: GetUpdateAssets :many
+select *
+from update_assets
+where update_id = $1
+  and is_archive = false
+order by platform, storage_object_path;
+
+-- name: GetAllUpdateAssets :many
+select *
+from update_assets
+where update_id = $1
+order by is_archive, platform, storage_object_path;
+
 -- name: GetLaunchAssetOrArchiveByPlatform :one
 select *
 from update_assets
@@ -93,3 +201,44 @@ WHERE project_id = @project_id
   AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
 ORDER BY created_at DESC
 LIMIT $1;
+
+-- name: GetLatestPublishedUpdates :many
+select distinct on (channel, runtime_version) *
+from updates
+where project_id = $1
+  and status = 'published'
+order by channel, runtime_version, coalesce(committed_at, created_at) desc;
+
+-- name: CreateUpdateStorageObjects :copyfrom
+INSERT INTO update_storage_objects (id,
+                                    update_id,
+                                    path,
+                                    content_type,
+                                    content_encoding,
+                                    extension,
+                                    content_md5,
+                                    content_sha256,
+                                    content_length,
+                                    is_archive,
+                                    shared_object_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: GetUpdateStorageObjects :many
+select *
+from update_storage_objects
+where update_id = $1;
+
+-- name: GetUpdateStorageObjectByPath :one
+select *
+from update_storage_objects
+where update_id = sqlc.arg(update_id)
+  and path = sqlc.arg(path)
+limit 1;
+
+-- name: HasPublishedOrCanceledUpdates :one
+select exists(select 1
+              from updates
+              where project_id = sqlc.arg(project_id)
+                and runtime_version = sqlc.arg(runtime_version)
+                and channel = sqlc.arg(channel)
+                and status in ('published', 'canceled'));
@@ -0,0 +1,41 @@
+-- name: RefreshProjectUsage :one
+-- files of assets and CodePush diff packages, files shared by several updates are counted once
+insert into project_usage (project_id, storage_bytes, updated_at)
+select projects.id,
+       (select coalesce(sum(objects.content_length), 0)
+        from (select distinct on (object_key) object_key, content_length
+              from (select update_assets.storage_object_path::varchar as object_key,
+                           update_assets.content_length
+                    from update_assets
+                             inner join updates on updates.id = update_assets.update_id
+                    where updates.project_id = projects.id
+                    union all
+                    select codepush_diff_packages.storage_object_path::varchar,
+                           codepush_diff_packages.content_length
+                    from codepush_diff_packages
+                             inner join updates on updates.id = codepush_diff_packages.update_id
+                    where updates.project_id = projects.id) files
+              order by object_key) objects)::bigint,
+       current_timestamp
+from projects
+where projects.id = sqlc.arg(project_id)
+  and projects.deleted_at is null
+on conflict (project_id) do update
+    set storage_bytes = excluded.storage_bytes,
+        updated_at    = excluded.updated_at
+returning *;
+
+-- name: GetProjectStorageQuota :one
+select projects.max_storage_bytes,
+       coalesce(project_usage.storage_bytes, 0)::bigint as storage_bytes
+from projects
+         left join project_usage on project_usage.project_id = projects.id
+where projects.id = $1;
+
+-- name: GetProjectIDsWithStorageQuota :many
+-- usage of the projects without a quota is refreshed only when their updates are processed
+select id
+from projects
+where max_storage_bytes > 0
+  and deleted_at is null
+order by id;
@@ -2,10 +2,44 @@ create type update_protocol as enum ('expo', 'codepush');
 
 create table projects
 (
-    id              uuid                                  not null primary key,
-    name            varchar(512)                          not null,
-    update_protocol update_protocol                       not null,
-    created_at      timestamptz default CURRENT_TIMESTAMP not null
+    id                             uuid                                  not null primary key,
+    name                           varchar(512)                          not null,
+    update_protocol                update_protocol                       not null,
+    -- when set, assets are served from a public bucket under this URL without signing
+    public_assets_url              varchar(512),
+    -- when set, asset URLs are built from this template instead of the bucket URLs
+    asset_url_template             varchar(1024),
+    -- regions of the storage replicas published assets are copied to
+    replica_regions                text[]      default '{}'               not null,
+    -- e.g. staging, projects of the same name in other environments are the same app
+    environment                    varchar(64) default 'production'       not null,
+    -- CIDR ranges allowed to call the management endpoints of the project, any when empty
+    admin_allowed_cidrs            text[]      default '{}'               not null,
+    -- maximum number of files of an update, metadata.json included
+    max_asset_count                integer     default 1000               not null,
+    -- CodePush responses without an update tell the client a newer binary is available
+    codepush_suggest_binary_update boolean     default false              not null,
+    -- description of CodePush updates, message of the update or none
+    codepush_description_source    varchar(16) default 'message'          not null,
+    -- assets are served by a route of the API whose URLs never expire instead of signed URLs
+    stable_asset_urls              boolean     default false              not null,
+    -- when set, assets of the project are stored in this bucket instead of STORAGE_DRIVER_URL,
+    -- it's set when the project is created and can't be changed
+    storage_driver_url             varchar(1024),
+    created_at                     timestamptz default CURRENT_TIMESTAMP not null,
+    -- archived projects keep serving their updates, but new updates are rejected
+    archived_at                    timestamptz,
+    -- deleted projects aren't served, they're purged by the worker, then the name can be reused
+    deleted_at                     timestamptz,
+    -- Expo manifests list the assets of these extensions or content types first, in this order,
+    -- so clients download the critical ones first, images when empty
+    asset_priorities               text[]      default '{}'               not null,
+    -- clients on metered connections don't get optional updates downloading more bytes than
+    -- this, until they're on Wi-Fi, 0 disables it
+    metered_download_limit         bigint      default 0                  not null,
+    -- total size of the stored files of the project, new updates are rejected over it, 0 disables it
+    max_storage_bytes              bigint      default 0                  not null,
+    unique (name, environment)
 );
 
 create type update_status as enum (
@@ -26,15 +60,50 @@ create table updates
     message         varchar(512),
     channel         varchar(512)  default 'production'      not null,
     created_at      timestamptz   default CURRENT_TIMESTAMP not null,
-    constraint fk_project_id foreign key (project_id) references projects (id)
+    -- hash of the update and its assets, set when the update is published
+    content_hash    varchar(64),
+    -- when set, the assets of the superseded update were moved to the cold storage bucket
+    cold_storage_at timestamptz,
+    -- names of the project flavors the update targets, empty targets all of them
+    flavors         text[]        default '{}'              not null,
+    -- published updates are treated as canceled from then on
+    expires_at      timestamptz,
+    -- share of the devices getting the published update, by their rollout bucket
+    rollout_percentage smallint   default 100               not null,
+    -- the update published together with this one, whose uploaded assets this one shares
+    linked_update_id uuid,
+    -- when the update was committed, of the published updates of a channel the one committed
+    -- last is the latest, regardless of the order their processing finished in
+    committed_at    timestamptz,
+    -- label of the CodePush release the update was imported from, served to the clients instead
+    -- of the update ID
+    codepush_label  varchar(64),
+    -- CodePush clients install mandatory updates right away, also the newer updates when they
+    -- skipped a mandatory one
+    is_mandatory    boolean       default true              not null,
+    -- reference of the update in the deployment system that published it, e.g. a release ID
+    external_id     varchar(128),
+    constraint fk_project_id foreign key (project_id) references projects (id),
+    constraint fk_linked_update_id foreign key (linked_update_id) references updates (id)
 );
 
+-- CodePush labels are unique in a channel, like in a deployment
+create unique index idx_updates_codepush_label
+    on updates (project_id, channel, codepush_label)
+    where codepush_label is not null;
+
+-- external IDs are unique in a project, so publishing the same release again is rejected
+create unique index idx_updates_external_id
+    on updates (project_id, external_id)
+    where external_id is not null;
+
 create table update_assets
 (
     id                  uuid                                  not null primary key,
     update_id           uuid                                  not null,
     storage_object_path varchar(512)                          not null,
     content_type        varchar(32)                           not null,
+    content_encoding    varchar(16) default ''                not null,
     extension           varchar(32)                            not null,
     content_md5         varchar(32)                           not null,
     content_sha256      varchar(64)                           not null,
@@ -46,6 +115,13 @@ create table update_assets
     constraint fk_update_id foreign key (update_id) references updates (id)
 );
 
+-- objects of assets are shared with new updates by their content hash
+create index idx_update_assets_content_sha256
+    on update_assets (content_sha256);
+
+create index idx_update_assets_storage_object_path
+    on update_assets (storage_object_path);
+
 create table update_metadata
 (
     id              uuid                                  not null primary key,
@@ -54,3 +130,321 @@ create table update_metadata
     created_at      timestamptz default CURRENT_TIMESTAMP not null,
     constraint fk_update_id foreign key (update_id) references updates (id)
 );
+
+create table update_storage_objects
+(
+    id                uuid                                  not null primary key,
+    update_id         uuid                                  not null,
+    path              varchar(512)                          not null,
+    content_type      varchar(64)                           not null,
+    content_encoding  varchar(16) default ''                not null,
+    extension         varchar(32)                           not null,
+    content_md5       varchar(32)                           not null,
+    -- declared by the client, the worker verifies only a sample of the declared hashes
+    content_sha256    varchar(64),
+    content_length    bigint                                not null,
+    is_archive        boolean     default false              not null,
+    -- object of a published update with the same path and content, the file isn't uploaded
+    shared_object_key varchar(1024),
+    created_at        timestamptz default CURRENT_TIMESTAMP not null,
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+create index idx_update_storage_objects_shared_object_key
+    on update_storage_objects (shared_object_key);
+
+-- one report per processing run of an update, retried runs add more reports
+create table update_processing_reports
+(
+    id             uuid                                  not null primary key,
+    update_id      uuid                                  not null,
+    -- published or failed
+    status         varchar(16)                           not null,
+    error          text,
+    asset_count    integer                               not null,
+    archive_count  integer                               not null,
+    -- files extracted from uploaded archives
+    unpacked_files integer                               not null,
+    -- files read and hashed by the worker, the others had known hashes
+    hashed_files   integer                               not null,
+    bytes_hashed   bigint                                not null,
+    warnings       text[]      default '{}'              not null,
+    started_at     timestamptz                           not null,
+    duration_ms    bigint                                not null,
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+create index idx_update_processing_reports_update_id
+    on update_processing_reports (update_id, started_at);
+
+-- pinned channels serve the pinned update instead of the latest published one
+create table channel_pins
+(
+    project_id      uuid                                  not null,
+    channel         varchar(512)                          not null,
+    runtime_version varchar(64)                           not null,
+    update_id       uuid                                  not null,
+    created_at      timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (project_id, channel, runtime_version),
+    constraint fk_project_id foreign key (project_id) references projects (id),
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+-- Expo clients of the channel roll back to their embedded update, until an update is published
+-- to the channel after the roll back
+create table channel_rollbacks
+(
+    project_id      uuid         not null,
+    channel         varchar(512) not null,
+    -- runtime version rolled back, all of them when empty
+    runtime_version varchar(64)  not null,
+    committed_at    timestamptz  not null,
+    primary key (project_id, channel, runtime_version),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- latest published and canceled update of a channel per runtime version and platform, refreshed
+-- when the updates of the channel change, so update checks read them instead of resolving them
+create table channel_heads
+(
+    project_id               uuid                                  not null,
+    channel                  varchar(512)                          not null,
+    runtime_version          varchar(64)                           not null,
+    platform                 varchar(8)                            not null,
+    published_update_id      uuid,
+    -- hash of the archive or launch asset of the update for the platform
+    published_content_sha256 varchar(64),
+    -- the latest canceled or expired update
+    canceled_update_id       uuid,
+    canceled_content_sha256  varchar(64),
+    -- false when the latest updates depend on the client or on the time, as updates of the
+    -- channel target flavors, are rolled out partially or expire, update checks resolve them
+    exact                    boolean                               not null,
+    refreshed_at             timestamptz                           not null,
+    primary key (project_id, channel, runtime_version, platform),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- constraints checked when an update of the channel is prepared
+create table channel_policies
+(
+    project_id                 uuid                                  not null,
+    channel                    varchar(512)                          not null,
+    -- semver constraint, e.g. 1.x
+    runtime_version_constraint varchar(256),
+    -- regular expression the update message has to match
+    message_pattern            varchar(512),
+    updated_at                 timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (project_id, channel),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- downloads served by the local asset endpoint and the edge cache, or imported from access logs
+create table asset_download_stats
+(
+    object_key         varchar(1024)                         not null primary key,
+    project_id         uuid                                  not null,
+    update_id          uuid                                  not null,
+    path               varchar(512)                          not null,
+    downloads          bigint      default 0                 not null,
+    bytes_served       bigint      default 0                 not null,
+    last_downloaded_at timestamptz default CURRENT_TIMESTAMP not null
+);
+
+-- keys signing the manifests of the project, a rotated key is still used until it's retired
+create table signing_keys
+(
+    id                uuid                                  not null primary key,
+    project_id        uuid                                  not null,
+    key_id            varchar(64)                           not null,
+    algorithm         varchar(32)                           not null,
+    private_key       text                                  not null,
+    certificate_chain text                                  not null,
+    created_at        timestamptz default CURRENT_TIMESTAMP not null,
+    retired_at        timestamptz,
+    constraint fk_project_id foreign key (project_id) references projects (id),
+    constraint uq_project_key_id unique (project_id, key_id)
+);
+
+-- updates embedded in the store binaries, registered by CI, so clients running them aren't served
+-- published updates with the same launch asset
+create table embedded_updates
+(
+    project_id          uuid                                  not null,
+    platform            varchar(8)                            not null,
+    -- reported by clients running the embedded update, Expo embedded update ID
+    -- or CodePush binary package hash
+    embedded_id         varchar(64)                           not null,
+    runtime_version     varchar(64)                           not null,
+    launch_asset_sha256 varchar(64)                           not null,
+    created_at          timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (project_id, platform, embedded_id),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- builds of the same app within a project, e.g. white-label apps sharing the codebase,
+-- their clients check for updates with the flavor's deployment key or update URL
+create table project_flavors
+(
+    project_id uuid                                  not null,
+    name       varchar(64)                           not null,
+    created_at timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (project_id, name),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- results of the background re-verification of stored assets
+create table asset_integrity_checks
+(
+    asset_id     uuid                                  not null primary key,
+    status       varchar(16)                           not null,
+    actual_hash  varchar(64),
+    error        varchar(512),
+    quarantined  boolean     default false              not null,
+    checked_at   timestamptz default CURRENT_TIMESTAMP not null,
+    constraint fk_asset_id foreign key (asset_id) references update_assets (id)
+);
+
+-- sampled update checks written by the analytics pipeline
+create table update_check_events
+(
+    project_id        uuid         not null,
+    channel           varchar(512) not null,
+    runtime_version   varchar(64)  not null,
+    platform          varchar(8)   not null,
+    -- update, no_update or roll_back_to_embedded
+    decision          varchar(32)  not null,
+    update_id         uuid,
+    checked_at        timestamptz  not null,
+    -- update_canceled or channel_rollback for rollbacks, null for other decisions
+    reason            varchar(32),
+    -- EAS client ID or CodePush client unique ID of the device
+    client_id         varchar(128),
+    -- update the device was running, sent only by Expo clients
+    current_update_id uuid,
+    -- CodePush label of the served update, the update ID is null for labels that aren't IDs
+    codepush_label    varchar(64),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+create index idx_update_check_events_project_checked_at
+    on update_check_events (project_id, checked_at);
+
+create index idx_update_check_events_project_client_id
+    on update_check_events (project_id, client_id, checked_at)
+    where client_id is not null;
+
+-- downloads and installs reported by the CodePush clients, per release
+create table codepush_release_stats
+(
+    update_id             uuid                                  not null primary key,
+    project_id            uuid                                  not null,
+    downloads             bigint      default 0                 not null,
+    deployments_succeeded bigint      default 0                 not null,
+    deployments_failed    bigint      default 0                 not null,
+    last_reported_at      timestamptz default CURRENT_TIMESTAMP not null
+);
+
+create index idx_codepush_release_stats_project_id
+    on codepush_release_stats (project_id, last_reported_at);
+
+-- last number of the v1, v2, … labels given to the CodePush releases of a channel, it never
+-- decreases, so labels of deleted releases aren't given again
+create table codepush_label_sequences
+(
+    project_id  uuid         not null,
+    channel     varchar(512) not null,
+    last_number integer      not null,
+    primary key (project_id, channel),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- committed updates whose process message wasn't published yet, e.g. while the queue was down,
+-- they're published again by the outbox relay
+create table update_outbox
+(
+    update_id  uuid                                  not null primary key,
+    created_at timestamptz default CURRENT_TIMESTAMP not null,
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+-- replica regions the objects of the update were copied to, projects replicate only the updates
+-- published after a region is added
+create table update_replicas
+(
+    update_id     uuid                                  not null,
+    region        varchar(64)                           not null,
+    replicated_at timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (update_id, region),
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+-- update checks per update, day and platform, rolled up in memory by the API servers
+create table update_adoption_stats
+(
+    update_id  uuid             not null,
+    project_id uuid             not null,
+    day        date             not null,
+    platform   varchar(8)       not null,
+    -- checks serving the update to clients running another update
+    downloads  bigint default 0 not null,
+    -- checks serving another update to clients running the update
+    departures bigint default 0 not null,
+    -- checks telling clients running the update to roll back to the embedded update
+    rollbacks  bigint default 0 not null,
+    primary key (update_id, day, platform),
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+-- CodePush packages with only the files changed since an earlier release, for the clients
+-- running it, like the diffPackageMap of the standalone server
+create table codepush_diff_packages
+(
+    update_id           uuid                                  not null,
+    platform            varchar(8)                            not null,
+    -- package hash of the earlier release
+    base_package_hash   varchar(64)                           not null,
+    storage_object_path varchar(1024)                         not null,
+    content_md5         varchar(32)                           not null,
+    content_length      bigint                                not null,
+    created_at          timestamptz default CURRENT_TIMESTAMP not null,
+    primary key (update_id, platform, base_package_hash),
+    constraint fk_update_id foreign key (update_id) references updates (id)
+);
+
+-- requests of the API operations per project, hour and latency bucket, rolled up in memory by
+-- the API servers
+create table api_usage_stats
+(
+    project_id    uuid             not null,
+    operation     varchar(64)      not null,
+    period_start  timestamptz      not null,
+    -- upper bound of the latency bucket in milliseconds
+    latency_le_ms integer          not null,
+    requests      bigint default 0 not null,
+    -- requests answered with a 4xx status
+    client_errors bigint default 0 not null,
+    -- requests answered with a 5xx status
+    server_errors bigint default 0 not null,
+    primary key (project_id, period_start, operation, latency_le_ms),
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- storage used by the projects, recalculated by the worker
+create table project_usage
+(
+    project_id    uuid                                  not null primary key,
+    -- total size of the stored files, files shared by several updates are counted once
+    storage_bytes bigint      default 0                 not null,
+    updated_at    timestamptz default CURRENT_TIMESTAMP not null,
+    constraint fk_project_id foreign key (project_id) references projects (id)
+);
+
+-- maintenance mode switched at runtime, a single row read by every API server
+create table maintenance_mode
+(
+    id         boolean     default true              not null primary key,
+    enabled    boolean                               not null,
+    updated_at timestamptz default CURRENT_TIMESTAMP not null,
+    constraint single_row check (id)
+);
@@ -4,20 +4,102 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AssetIntegrityCheckStatus.
+const (
+	Corrupted AssetIntegrityCheckStatus = "corrupted"
+	Missing   AssetIntegrityCheckStatus = "missing"
+)
+
+// Defines values for CodePushDescriptionSource.
+const (
+	Message CodePushDescriptionSource = "message"
+	None    CodePushDescriptionSource = "none"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeBadRequest       ErrorCode = "bad_request"
+	ErrorCodeConflict         ErrorCode = "conflict"
+	ErrorCodeForbidden        ErrorCode = "forbidden"
+	ErrorCodeInternal         ErrorCode = "internal"
+	ErrorCodeNotFound         ErrorCode = "not_found"
+	ErrorCodePayloadTooLarge  ErrorCode = "payload_too_large"
+	ErrorCodeQuotaExceeded    ErrorCode = "quota_exceeded"
+	ErrorCodeRateLimited      ErrorCode = "rate_limited"
+	ErrorCodeTimeout          ErrorCode = "timeout"
+	ErrorCodeUnauthorized     ErrorCode = "unauthorized"
+	ErrorCodeUnavailable      ErrorCode = "unavailable"
+	ErrorCodeValidationFailed ErrorCode = "validation_failed"
+)
+
+// Defines values for FileUploadState.
+const (
+	FileUploadStatePartial  FileUploadState = "partial"
+	FileUploadStatePending  FileUploadState = "pending"
+	FileUploadStateUploaded FileUploadState = "uploaded"
+)
+
+// Defines values for ProcessingReportStatus.
+const (
+	Failed    ProcessingReportStatus = "failed"
+	Published ProcessingReportStatus = "published"
+)
+
+// Defines values for SigningKeyStatus.
+const (
+	Active   SigningKeyStatus = "active"
+	Retired  SigningKeyStatus = "retired"
+	Retiring SigningKeyStatus = "retiring"
+)
+
+// Defines values for StorageObjectContentEncoding.
+const (
+	Br   StorageObjectContentEncoding = "br"
+	Gzip StorageObjectContentEncoding = "gzip"
+)
+
+// Defines values for UpdateCheckEventDecision.
+const (
+	UpdateCheckEventDecisionNoUpdate           UpdateCheckEventDecision = "no_update"
+	UpdateCheckEventDecisionRollBackToEmbedded UpdateCheckEventDecision = "roll_back_to_embedded"
+	UpdateCheckEventDecisionUpdate             UpdateCheckEventDecision = "update"
+)
+
+// Defines values for UpdateCheckEventReason.
+const (
+	UpdateCheckEventReasonChannelRollback UpdateCheckEventReason = "channel_rollback"
+	UpdateCheckEventReasonUpdateCanceled  UpdateCheckEventReason = "update_canceled"
+)
+
+// Defines values for UpdateCheckTraceDecision.
+const (
+	UpdateCheckTraceDecisionNoUpdateAvailable  UpdateCheckTraceDecision = "noUpdateAvailable"
+	UpdateCheckTraceDecisionRollBackToEmbedded UpdateCheckTraceDecision = "rollBackToEmbedded"
+	UpdateCheckTraceDecisionUpdate             UpdateCheckTraceDecision = "update"
+)
+
 // Defines values for UpdateProtocol.
 const (
 	Codepush UpdateProtocol = "codepush"
@@ -26,13 +108,178 @@ const (
 
 // Defines values for UpdateStatus.
 const (
-	Canceled   UpdateStatus = "canceled"
-	Failed     UpdateStatus = "failed"
-	Pending    UpdateStatus = "pending"
-	Processing UpdateStatus = "processing"
-	Published  UpdateStatus = "published"
+	UpdateStatusCanceled   UpdateStatus = "canceled"
+	UpdateStatusFailed     UpdateStatus = "failed"
+	UpdateStatusPending    UpdateStatus = "pending"
+	UpdateStatusProcessing UpdateStatus = "processing"
+	UpdateStatusPublished  UpdateStatus = "published"
 )
 
+// APIUsageStats defines model for APIUsageStats.
+type APIUsageStats struct {
+	// ClientErrors Requests answered with a 4xx status
+	ClientErrors int64 `json:"clientErrors"`
+
+	// ErrorRate Share of the requests answered with a 4xx or 5xx status
+	ErrorRate float64 `json:"errorRate"`
+
+	// Operation ID of the operation in this spec
+	Operation string `json:"operation"`
+
+	// P95LatencyMs Upper bound of the latency bucket of the 95th percentile request, in milliseconds, the
+	// buckets end at 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000 and 60000
+	P95LatencyMs int32 `json:"p95LatencyMs"`
+
+	// PeriodStart Start of the hour
+	PeriodStart time.Time `json:"periodStart"`
+	Requests    int64     `json:"requests"`
+
+	// ServerErrors Requests answered with a 5xx status
+	ServerErrors int64 `json:"serverErrors"`
+}
+
+// AssetDownloadStats defines model for AssetDownloadStats.
+type AssetDownloadStats struct {
+	BytesServed int64 `json:"bytesServed"`
+
+	// Downloads Number of downloads, including partial (range) downloads
+	Downloads        int64     `json:"downloads"`
+	LastDownloadedAt time.Time `json:"lastDownloadedAt"`
+
+	// Path Path of the file within the update, `<platform>.zip` for CodePush archives
+	Path     string             `json:"path"`
+	UpdateID openapi_types.UUID `json:"updateId"`
+}
+
+// AssetIntegrityCheck defines model for AssetIntegrityCheck.
+type AssetIntegrityCheck struct {
+	ActualHash *string   `json:"actualHash,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	Error      *string   `json:"error,omitempty"`
+
+	// ExpectedHash SHA256 of the decoded content, MD5 of the stored bytes for archives
+	ExpectedHash *string `json:"expectedHash,omitempty"`
+	ObjectKey    string  `json:"objectKey"`
+
+	// Quarantined The object was moved under the `quarantine/` prefix and is no longer served
+	Quarantined bool                      `json:"quarantined"`
+	Status      AssetIntegrityCheckStatus `json:"status"`
+	UpdateID    openapi_types.UUID        `json:"updateId"`
+}
+
+// AssetIntegrityCheckStatus defines model for AssetIntegrityCheck.Status.
+type AssetIntegrityCheckStatus string
+
+// ChannelPin defines model for ChannelPin.
+type ChannelPin struct {
+	Channel        string             `json:"channel"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	RuntimeVersion string             `json:"runtimeVersion"`
+	UpdateID       openapi_types.UUID `json:"updateId"`
+}
+
+// ChannelPolicy defines model for ChannelPolicy.
+type ChannelPolicy struct {
+	Channel string `json:"channel"`
+
+	// MessagePattern Regular expression the message of updates has to match
+	MessagePattern *string `json:"messagePattern,omitempty"`
+
+	// RuntimeVersionConstraint Semver constraint the runtime version of updates has to satisfy
+	RuntimeVersionConstraint *string   `json:"runtimeVersionConstraint,omitempty"`
+	UpdatedAt                time.Time `json:"updatedAt"`
+}
+
+// ChannelRollback defines model for ChannelRollback.
+type ChannelRollback struct {
+	Channel string `json:"channel"`
+
+	// CommitTime Commit time of the roll back directive, updates published to the channel after it
+	// are served again
+	CommitTime time.Time `json:"commitTime"`
+
+	// RuntimeVersion Runtime version rolled back, all of them when not set
+	RuntimeVersion *string `json:"runtimeVersion,omitempty"`
+}
+
+// ChunkedUploadStatus defines model for ChunkedUploadStatus.
+type ChunkedUploadStatus struct {
+	ChunkSize      int64  `json:"chunkSize"`
+	MissingChunks  []int  `json:"missingChunks"`
+	Path           string `json:"path"`
+	ReceivedChunks []int  `json:"receivedChunks"`
+	TotalChunks    int    `json:"totalChunks"`
+}
+
+// CloneProjectParams defines model for CloneProjectParams.
+type CloneProjectParams struct {
+	// CopyLatestUpdates Copy the latest published update of every channel and runtime version, with copies
+	// of their assets
+	CopyLatestUpdates *bool `json:"copyLatestUpdates,omitempty"`
+
+	// Environment Environment of the new project, defaults to the environment of the project
+	Environment *string `binding:"omitempty,printascii,max=64" json:"environment,omitempty"`
+
+	// Name Name of the new project
+	Name string `binding:"required,max=512" json:"name"`
+}
+
+// CodePushDeployReport Reported by the CodePush client after installing a release, or the binary when the label
+// is missing
+type CodePushDeployReport struct {
+	AppVersion                string  `json:"app_version"`
+	ClientUniqueID            *string `json:"client_unique_id,omitempty"`
+	DeploymentKey             string  `json:"deployment_key"`
+	Label                     *string `json:"label,omitempty"`
+	PreviousDeploymentKey     *string `json:"previous_deployment_key,omitempty"`
+	PreviousLabelOrAppVersion *string `json:"previous_label_or_app_version,omitempty"`
+	Status                    *string `binding:"omitempty,oneof=DeploymentSucceeded DeploymentFailed" json:"status,omitempty"`
+}
+
+// CodePushDescriptionSource Description of the CodePush updates shown by apps prompting to install them, `message` of
+// the update or `none` to hide the messages, e.g. when they're meant for the team only
+type CodePushDescriptionSource string
+
+// CodePushDownloadReport Reported by the CodePush client after downloading a release
+type CodePushDownloadReport struct {
+	ClientUniqueID *string `json:"client_unique_id,omitempty"`
+	DeploymentKey  string  `json:"deployment_key"`
+	Label          string  `json:"label"`
+}
+
+// CodePushLegacyDeployReport CodePushDeployReport with the camelCase fields of the standalone CodePush server
+type CodePushLegacyDeployReport struct {
+	AppVersion                string  `json:"appVersion"`
+	ClientUniqueID            *string `json:"clientUniqueId,omitempty"`
+	DeploymentKey             string  `json:"deploymentKey"`
+	Label                     *string `json:"label,omitempty"`
+	PreviousDeploymentKey     *string `json:"previousDeploymentKey,omitempty"`
+	PreviousLabelOrAppVersion *string `json:"previousLabelOrAppVersion,omitempty"`
+	Status                    *string `binding:"omitempty,oneof=DeploymentSucceeded DeploymentFailed" json:"status,omitempty"`
+}
+
+// CodePushLegacyDownloadReport CodePushDownloadReport with the camelCase fields of the standalone CodePush server
+type CodePushLegacyDownloadReport struct {
+	ClientUniqueID *string `json:"clientUniqueId,omitempty"`
+	DeploymentKey  string  `json:"deploymentKey"`
+	Label          string  `json:"label"`
+}
+
+// CodePushLegacyUpdate CodePushUpdate with the camelCase fields of the standalone CodePush server
+type CodePushLegacyUpdate struct {
+	AppVersion             string  `json:"appVersion"`
+	Description            *string `json:"description,omitempty"`
+	DownloadURL            string  `json:"downloadURL"`
+	IsAvailable            bool    `json:"isAvailable"`
+	IsMandatory            bool    `json:"isMandatory"`
+	Label                  string  `json:"label"`
+	PackageHash            string  `json:"packageHash"`
+	PackageSize            int     `json:"packageSize"`
+	ShouldRunBinaryVersion bool    `json:"shouldRunBinaryVersion"`
+	TargetBinaryRange      string  `json:"targetBinaryRange"`
+	UpdateAppVersion       bool    `json:"updateAppVersion"`
+}
+
 // CodePushPackageInfo defines model for CodePushPackageInfo.
 type CodePushPackageInfo struct {
 	AppVersion  string   `json:"app_version"`
@@ -44,6 +291,20 @@ type CodePushPackageInfo struct {
 	Rollout     *float32 `json:"rollout,omitempty"`
 }
 
+// CodePushReleaseStats defines model for CodePushReleaseStats.
+type CodePushReleaseStats struct {
+	// DeploymentsFailed Installs rolled back by the clients
+	DeploymentsFailed int64 `json:"deploymentsFailed"`
+
+	// DeploymentsSucceeded Installs reported by the clients
+	DeploymentsSucceeded int64     `json:"deploymentsSucceeded"`
+	Downloads            int64     `json:"downloads"`
+	LastReportedAt       time.Time `json:"lastReportedAt"`
+
+	// UpdateID The update of the release, its label
+	UpdateID openapi_types.UUID `json:"updateId"`
+}
+
 // CodePushUpdate defines model for CodePushUpdate.
 type CodePushUpdate struct {
 	AppVersion             string   `json:"app_version"`
@@ -61,51 +322,387 @@ type CodePushUpdate struct {
 	UpdateAppVersion       bool     `json:"update_app_version"`
 }
 
+// CopyProjectParams defines model for CopyProjectParams.
+type CopyProjectParams struct {
+	// Environment Environment to copy the project configuration to
+	Environment string `binding:"required,printascii,max=64" json:"environment"`
+}
+
+// CreateFlavorParams defines model for CreateFlavorParams.
+type CreateFlavorParams struct {
+	// Name Lowercase letters, digits and dashes, e.g. acme-bank
+	Name string `binding:"required,max=64" json:"name"`
+}
+
 // CreateProjectParams defines model for CreateProjectParams.
 type CreateProjectParams struct {
-	Name           string         `binding:"required,max=512" json:"name"`
-	UpdateProtocol UpdateProtocol `binding:"required,oneof=expo codepush" json:"updateProtocol"`
+	// Environment Environment of the project, e.g. `staging`, defaults to `production`. Names of projects
+	// are unique within an environment.
+	Environment *string `binding:"omitempty,printascii,max=64" json:"environment,omitempty"`
+	Name        string  `binding:"required,max=512" json:"name"`
+
+	// StorageDriverUrl gocloud.dev URL of the bucket storing the assets of the project instead of the one of
+	// STORAGE_DRIVER_URL, e.g. `s3://eu-assets?region=eu-central-1`. It can't be changed
+	// once the project is created. Requires external storage.
+	StorageDriverUrl *string        `binding:"omitempty,max=1024" json:"storageDriverUrl,omitempty"`
+	UpdateProtocol   UpdateProtocol `binding:"required,oneof=expo codepush" json:"updateProtocol"`
+}
+
+// EmbeddedUpdate defines model for EmbeddedUpdate.
+type EmbeddedUpdate struct {
+	CreatedAt       time.Time `json:"createdAt"`
+	EmbeddedID      string    `json:"embeddedId"`
+	LaunchAssetHash string    `json:"launchAssetHash"`
+	Platform        string    `json:"platform"`
+	RuntimeVersion  string    `json:"runtimeVersion"`
+}
+
+// ErrorCode Kind of the error, the same for every error response with the same cause, unlike the
+// message
+type ErrorCode string
+
+// FileUploadState defines model for FileUploadState.
+type FileUploadState string
+
+// FileUploadStatus defines model for FileUploadStatus.
+type FileUploadStatus struct {
+	ContentLength int64 `json:"contentLength"`
+
+	// LastModified Time of the most recently received data of the file, absent when nothing was received
+	LastModified  *time.Time      `json:"lastModified,omitempty"`
+	Path          string          `json:"path"`
+	ReceivedBytes int64           `json:"receivedBytes"`
+	State         FileUploadState `json:"state"`
+}
+
+// Flavor defines model for Flavor.
+type Flavor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Name      string    `json:"name"`
 }
 
-// GenericError defines model for GenericError.
+// GenericError Every error response has the message, and the code and retryability of the error. Requests
+// failing validation list the errors of the fields too.
 type GenericError struct {
-	Error string `json:"error"`
+	// Code Kind of the error, the same for every error response with the same cause, unlike the
+	// message
+	Code   *ErrorCode             `json:"code,omitempty"`
+	Error  string                 `json:"error"`
+	Errors []ValidationFieldError `json:"errors,omitempty"`
+
+	// Retryable Sending the same request again later may succeed
+	Retryable bool `json:"retryable,omitempty"`
 }
 
 // GetUpdatesResponse defines model for GetUpdatesResponse.
 type GetUpdatesResponse = []Update
 
+// LogLevels defines model for LogLevels.
+type LogLevels struct {
+	// Levels Log level per component (api, worker, storage, queue, cache) and the `default` level
+	// of components without their own. Empty level resets the component to the default.
+	Levels map[string]string `json:"levels"`
+}
+
+// MaintenanceMode defines model for MaintenanceMode.
+type MaintenanceMode struct {
+	// Enabled Mutating requests are rejected with 503 while enabled, update checks and GET requests
+	// are still served. Only this server instance is affected.
+	Enabled bool `json:"enabled"`
+}
+
+// PatchUpdateParams defines model for PatchUpdateParams.
+type PatchUpdateParams struct {
+	// IsMandatory CodePush clients install the update right away, the change applies to the published
+	// update without publishing it again
+	IsMandatory *bool `json:"isMandatory,omitempty"`
+}
+
+// PinChannelParams defines model for PinChannelParams.
+type PinChannelParams struct {
+	// UpdateID Published update to serve, the pin applies to the channel and runtime version of the update
+	UpdateID openapi_types.UUID `binding:"required" json:"updateId"`
+}
+
 // PrepareUpdateBody defines model for PrepareUpdateBody.
 type PrepareUpdateBody struct {
-	Channel        *string                 `binding:"omitempty,printascii,max=100" json:"channel,omitempty"`
-	ExpoAppConfig  *map[string]interface{} `json:"expoAppConfig,omitempty"`
-	FileMetadata   []StorageObject         `binding:"required,min=1,dive" json:"fileMetadata"`
-	Message        string                  `binding:"required,min=1,max=500" json:"message"`
-	RuntimeVersion string                  `binding:"required,semver" json:"runtimeVersion"`
+	// AdditionalChannels Channels the update is published to together with the channel, the files are uploaded
+	// only once. A linked update is created for every channel, all of them are published
+	// at once when the update is committed and processed.
+	AdditionalChannels []string `binding:"omitempty,max=10,dive,printascii,max=100" json:"additionalChannels,omitempty"`
+
+	// Archive Single zip or tar.gz archive containing all files of the update (including metadata.json),
+	// unpacked by the worker. Mutually exclusive with fileMetadata.
+	Archive *StorageObject `json:"archive,omitempty"`
+	Channel *string        `binding:"omitempty,printascii,max=100" json:"channel,omitempty"`
+
+	// CodePushLabel Label of the CodePush release the update is imported from, e.g. v12. CodePush clients
+	// get it instead of the update ID, so the releases they report keep their labels.
+	// Labels are unique in a channel. Without it, updates of CodePush projects are labeled
+	// with the next number of each of their channels, skipping the numbers of labels set
+	// explicitly.
+	CodePushLabel *string `binding:"omitempty,printascii,max=64" json:"codePushLabel,omitempty"`
+
+	// CreatedAt When the update was created, e.g. by EAS, for imported updates. Expo manifests of the
+	// update are served with it. The time the update is prepared when omitted, it can't be
+	// in the future.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// ExpiresAt Clients are rolled back from the update at the time, like from a canceled update
+	ExpiresAt     *time.Time              `json:"expiresAt,omitempty"`
+	ExpoAppConfig *map[string]interface{} `json:"expoAppConfig,omitempty"`
+
+	// ExternalID Reference of the update in the deployment system publishing it, e.g. its release ID.
+	// External IDs are unique in the project, preparing an update with the external ID of
+	// another update is rejected, so retried releases aren't published twice. The update
+	// is found by it with the by-external-id endpoint.
+	ExternalID *string `binding:"omitempty,printascii,min=1,max=128" json:"externalID,omitempty"`
+
+	// FileMetadata Files of the update, required unless archive is provided
+	FileMetadata []StorageObject `binding:"omitempty,dive" json:"fileMetadata,omitempty"`
+
+	// Flavors Flavors of the project the update targets, clients of other flavors don't get it.
+	// The update targets all flavors and clients without a flavor when omitted.
+	Flavors []string `binding:"omitempty,dive,max=64" json:"flavors,omitempty"`
+
+	// ID ID of the update, e.g. of the EAS update it's imported from, so Expo clients running
+	// it don't download it again. Generated when omitted, IDs of other updates are rejected.
+	ID *openapi_types.UUID `json:"id,omitempty"`
+
+	// IsMandatory CodePush clients install the update right away instead of on the next restart, also
+	// when they get a newer update, skipping this one. True when omitted.
+	IsMandatory *bool  `json:"isMandatory,omitempty"`
+	Message     string `binding:"required,min=1,max=500" json:"message"`
+
+	// RolloutPercentage Share of the devices getting the update once it's published, 100 when omitted.
+	// Devices are bucketed by their client ID, clients without it get only updates
+	// rolled out to all devices.
+	RolloutPercentage *int   `binding:"omitempty,min=1,max=100" json:"rolloutPercentage,omitempty"`
+	RuntimeVersion    string `binding:"required,semver" json:"runtimeVersion"`
 }
 
 // PrepareUpdateResponse defines model for PrepareUpdateResponse.
 type PrepareUpdateResponse struct {
-	UpdateID   openapi_types.UUID         `json:"updateID"`
+	// LinkedUpdateIDs Updates of the additional channels, in the order of the channels
+	LinkedUpdateIDs []openapi_types.UUID `json:"linkedUpdateIDs"`
+
+	// SharedFiles Files with the same path and SHA256 as a file of a published update of the project, they
+	// share its stored object and aren't uploaded, so they have no upload URL
+	SharedFiles []string           `json:"sharedFiles"`
+	UpdateID    openapi_types.UUID `json:"updateID"`
+
+	// UploadPlan What the client has to upload, and the limits the files have to fit in
+	UploadPlan UploadPlan                 `json:"uploadPlan"`
 	UploadURLs []StorageObjectPathWithURL `json:"uploadURLs"`
 }
 
+// ProcessingReport Report of a processing run of the update, failed runs are retried in a new run
+type ProcessingReport struct {
+	// ArchiveCount CodePush archives built from the assets
+	ArchiveCount int `json:"archiveCount"`
+
+	// AssetCount Bundles and assets parsed from the uploaded files
+	AssetCount  int   `json:"assetCount"`
+	BytesHashed int64 `json:"bytesHashed"`
+	DurationMs  int64 `json:"durationMs"`
+
+	// Error Why the run failed
+	Error *string `json:"error,omitempty"`
+
+	// HashedFiles Files read and hashed by the worker, the others had known hashes
+	HashedFiles int                    `json:"hashedFiles"`
+	ID          openapi_types.UUID     `json:"id"`
+	StartedAt   time.Time              `json:"startedAt"`
+	Status      ProcessingReportStatus `json:"status"`
+
+	// UnpackedFiles Files extracted from uploaded archives
+	UnpackedFiles int `json:"unpackedFiles"`
+
+	// Warnings Issues that didn't fail the run, e.g. missing platform metadata
+	Warnings []string `json:"warnings"`
+}
+
+// ProcessingReportStatus defines model for ProcessingReport.Status.
+type ProcessingReportStatus string
+
 // Project defines model for Project.
 type Project struct {
-	ID             openapi_types.UUID `json:"id"`
-	Name           string             `json:"name"`
-	UpdateProtocol UpdateProtocol     `binding:"required,oneof=expo codepush" json:"updateProtocol"`
+	// AdminAllowedCidrs CIDR ranges allowed to call the management endpoints of the project
+	AdminAllowedCidrs []string `json:"adminAllowedCidrs"`
+
+	// Archived Archived projects keep serving their updates, but new updates are rejected
+	Archived bool `json:"archived"`
+
+	// AssetPriorities Extensions and content types of the assets Expo clients download first
+	AssetPriorities []string `json:"assetPriorities"`
+
+	// AssetUrlTemplate Template of asset URLs, takes precedence over publicAssetsUrl
+	AssetUrlTemplate *string `json:"assetUrlTemplate,omitempty"`
+
+	// CodePushDescriptionSource Description of the CodePush updates shown by apps prompting to install them, `message` of
+	// the update or `none` to hide the messages, e.g. when they're meant for the team only
+	CodePushDescriptionSource CodePushDescriptionSource `json:"codePushDescriptionSource"`
+
+	// CodePushSuggestBinaryUpdate CodePush responses without an update tell apps a newer binary is available
+	CodePushSuggestBinaryUpdate bool               `json:"codePushSuggestBinaryUpdate"`
+	Environment                 string             `json:"environment"`
+	ID                          openapi_types.UUID `json:"id"`
+
+	// MaxAssetCount Maximum number of files of an update, metadata.json included
+	MaxAssetCount int32 `json:"maxAssetCount"`
+
+	// MaxStorageBytes Storage quota of the project in bytes, no quota when 0
+	MaxStorageBytes int64 `json:"maxStorageBytes"`
+
+	// MeteredDownloadLimit Size of the largest optional update served on metered connections, no limit when 0
+	MeteredDownloadLimit int64  `json:"meteredDownloadLimit"`
+	Name                 string `json:"name"`
+
+	// PublicAssetsUrl Base URL of a public bucket serving the assets, asset URLs are not signed when set
+	PublicAssetsUrl *string `json:"publicAssetsUrl,omitempty"`
+
+	// ReplicaRegions Regions of the storage replicas the published assets are copied to
+	ReplicaRegions []string `json:"replicaRegions"`
+
+	// StableAssetUrls Assets are served by the stable asset route, with URLs that never expire
+	StableAssetUrls bool `json:"stableAssetUrls"`
+
+	// StorageDriverUrl Bucket storing the assets of the project, the primary bucket when not set
+	StorageDriverUrl *string        `json:"storageDriverUrl,omitempty"`
+	UpdateProtocol   UpdateProtocol `binding:"required,oneof=expo codepush" json:"updateProtocol"`
+}
+
+// ProjectClone defines model for ProjectClone.
+type ProjectClone struct {
+	Project Project `json:"project"`
+
+	// Updates Copied updates, empty unless copyLatestUpdates was set
+	Updates []Update `json:"updates"`
+}
+
+// QuotaExceededError The files of the update would take the storage of the project over its quota
+type QuotaExceededError struct {
+	// Code Kind of the error, the same for every error response with the same cause, unlike the
+	// message
+	Code  ErrorCode `json:"code"`
+	Error string    `json:"error"`
+
+	// MaxStorageBytes Storage quota of the project
+	MaxStorageBytes int64 `json:"maxStorageBytes"`
+
+	// StorageBytesUsed Storage used by the project when the worker last recalculated it
+	StorageBytesUsed int64 `json:"storageBytesUsed"`
+
+	// UploadBytes Size of the files of the update to upload, shared files aren't counted
+	UploadBytes int64 `json:"uploadBytes"`
+}
+
+// RegisterEmbeddedUpdateParams defines model for RegisterEmbeddedUpdateParams.
+type RegisterEmbeddedUpdateParams struct {
+	// EmbeddedID What clients running the embedded update report as their current update, the embedded
+	// update ID of Expo builds or the binary package hash of CodePush builds
+	EmbeddedID string `binding:"required,max=64" json:"embeddedId"`
+
+	// LaunchAssetHash SHA-256 hash of the embedded JS bundle, hex encoded
+	LaunchAssetHash string `binding:"required,len=64,hexadecimal" json:"launchAssetHash"`
+	Platform        string `binding:"required,max=8" json:"platform"`
+
+	// RuntimeVersion Expo runtime version or CodePush app version of the binary
+	RuntimeVersion string `binding:"required,semver" json:"runtimeVersion"`
+}
+
+// RollBackToEmbeddedParams defines model for RollBackToEmbeddedParams.
+type RollBackToEmbeddedParams struct {
+	// RuntimeVersion Rolls back only the clients of the runtime version
+	RuntimeVersion *string `binding:"omitempty,semver" json:"runtimeVersion,omitempty"`
+}
+
+// RotateSigningKeyParams defines model for RotateSigningKeyParams.
+type RotateSigningKeyParams struct {
+	// CertificateChain PEM encoded certificates, the certificate of the private key first, followed by
+	// the intermediate certificates up to the one embedded in the apps
+	CertificateChain string `binding:"required,max=65536" json:"certificateChain"`
+
+	// KeyID Key ID the clients request in the expo-expect-signature header
+	KeyID string `binding:"required,printascii,max=64" json:"keyId"`
+
+	// OverlapHours How long the previously active keys still sign manifests of clients requesting them
+	OverlapHours *int `binding:"omitempty,min=0,max=8760" json:"overlapHours,omitempty"`
+
+	// PrivateKey PEM encoded RSA private key
+	PrivateKey string `binding:"required,max=16384" json:"privateKey"`
+}
+
+// SetChannelPolicyParams defines model for SetChannelPolicyParams.
+type SetChannelPolicyParams struct {
+	Channel string `binding:"required,printascii,max=100" json:"channel"`
+
+	// MessagePattern Regular expression the message of updates has to match, e.g. `^\[release\]` to require
+	// a label in the message. Empty allows any message.
+	MessagePattern *string `binding:"omitempty,max=512" json:"messagePattern,omitempty"`
+
+	// RuntimeVersionConstraint Semver constraint the runtime version of updates has to satisfy, e.g. `1.x` or
+	// `>= 2.0, < 3`. Empty allows any runtime version.
+	RuntimeVersionConstraint *string `binding:"omitempty,max=256" json:"runtimeVersionConstraint,omitempty"`
+}
+
+// SetRolloutParams defines model for SetRolloutParams.
+type SetRolloutParams struct {
+	// Percentage Share of the devices getting the update, 100 publishes it to all of them. Devices
+	// that got the update keep it when the percentage is raised.
+	Percentage int `binding:"required,min=1,max=100" json:"percentage"`
+}
+
+// SetUpdateExpiryParams defines model for SetUpdateExpiryParams.
+type SetUpdateExpiryParams struct {
+	// ExpiresAt Clients are rolled back from the update at the time, a time in the past rolls them
+	// back right away. The expiry is removed when omitted.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	Algorithm string `json:"algorithm"`
+
+	// CertificateChain PEM encoded certificates, the certificate of the key first
+	CertificateChain string     `json:"certificateChain"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	KeyID            string     `json:"keyId"`
+	RetiresAt        *time.Time `json:"retiresAt,omitempty"`
+
+	// Status Active keys sign the manifests, retiring keys sign only manifests of clients requesting
+	// them by their key ID until the overlap period ends, retired keys aren't used anymore
+	Status SigningKeyStatus `json:"status"`
 }
 
+// SigningKeyStatus Active keys sign the manifests, retiring keys sign only manifests of clients requesting
+// them by their key ID until the overlap period ends, retired keys aren't used anymore
+type SigningKeyStatus string
+
 // StorageObject defines model for StorageObject.
 type StorageObject struct {
-	ContentLength int    `binding:"required,max_object_size" json:"contentLength"`
-	ContentType   string `binding:"required,max=60" json:"contentType"`
-	Extension     string `binding:"required,max=10" json:"extension"`
-	MD5Hash       string `binding:"required,max=32" json:"md5Hash"`
-	Path          string `binding:"required,asset_path,max=400" json:"path"`
+	// ContentEncoding Encoding of an already compressed file, e.g. `entry.hbc.gz` or `entry.hbc.br`. The file must
+	// be uploaded with the same Content-Encoding header, it's served with that header and hashed
+	// after decoding.
+	ContentEncoding StorageObjectContentEncoding `binding:"omitempty,oneof=gzip br" json:"contentEncoding,omitempty"`
+	ContentLength   int                          `binding:"required,max_object_size" json:"contentLength"`
+	ContentType     string                       `binding:"required,max=60" json:"contentType"`
+	Extension       string                       `binding:"required,max=10" json:"extension"`
+	MD5Hash         string                       `binding:"required,max=32" json:"md5Hash"`
+	Path            string                       `binding:"required,asset_path,max=400" json:"path"`
+
+	// SHA256Hash Hex encoded SHA256 of the file, calculated by the client. The worker verifies only a sample
+	// of the declared hashes (`CLIENT_HASH_VERIFY_RATE`) and trusts the rest, instead of reading
+	// every file. Ignored for files with a content encoding and for archives. Files with the
+	// path and hashes of a file of a published update aren't uploaded again, see `sharedFiles`.
+	SHA256Hash string `binding:"omitempty,len=64,hexadecimal" json:"sha256Hash,omitempty"`
 }
 
+// StorageObjectContentEncoding Encoding of an already compressed file, e.g. `entry.hbc.gz` or `entry.hbc.br`. The file must
+// be uploaded with the same Content-Encoding header, it's served with that header and hashed
+// after decoding.
+type StorageObjectContentEncoding string
+
 // StorageObjectPathWithURL defines model for StorageObjectPathWithURL.
 type StorageObjectPathWithURL struct {
 	Path string `json:"path"`
@@ -114,38 +711,398 @@ type StorageObjectPathWithURL struct {
 
 // Update defines model for Update.
 type Update struct {
-	Channel        string             `json:"channel"`
-	CreatedAt      time.Time          `json:"createdAt"`
-	ID             openapi_types.UUID `json:"id"`
-	Message        string             `json:"message"`
-	RuntimeVersion string             `json:"runtimeVersion"`
-	Status         UpdateStatus       `json:"status"`
+	Channel string `json:"channel"`
+
+	// CodePushLabel Label CodePush clients get instead of the update ID, e.g. v12. Updates of CodePush
+	// projects are numbered per channel unless the label is set when preparing them.
+	CodePushLabel *string `json:"codePushLabel,omitempty"`
+
+	// ColdStorageAt Set when the assets of the superseded update were moved to the cold storage bucket,
+	// they're moved back when the update is served or pinned again.
+	ColdStorageAt *time.Time `json:"coldStorageAt,omitempty"`
+
+	// ContentHash Hash of the update and its assets, set when the update is published. Expo manifests
+	// of the update are served with it in the ETag header.
+	ContentHash *string   `json:"contentHash,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// ExpiresAt The update is treated as canceled from then on
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// ExternalID Reference of the update in the deployment system that published it
+	ExternalID *string `json:"externalID,omitempty"`
+
+	// Flavors Flavors the update targets, empty when it targets all of them
+	Flavors []string           `json:"flavors,omitempty"`
+	ID      openapi_types.UUID `json:"id"`
+
+	// IsMandatory CodePush clients install the update right away
+	IsMandatory bool `json:"isMandatory"`
+
+	// LinkedUpdateID Update prepared with the additional channels, this update shares its uploaded assets
+	// and is published together with it
+	LinkedUpdateID *openapi_types.UUID `json:"linkedUpdateId,omitempty"`
+	Message        string              `json:"message"`
+
+	// RolloutPercentage Share of the devices getting the published update
+	RolloutPercentage int          `json:"rolloutPercentage"`
+	RuntimeVersion    string       `json:"runtimeVersion"`
+	Status            UpdateStatus `json:"status"`
+}
+
+// UpdateCheckCandidate defines model for UpdateCheckCandidate.
+type UpdateCheckCandidate struct {
+	// ContentSha256 Hash of the platform's launch asset or archive, matched against packageHash
+	ContentSha256 *string `json:"contentSha256,omitempty"`
+
+	// IsCurrent Whether the candidate is the update the client is running
+	IsCurrent bool   `json:"isCurrent"`
+	Update    Update `json:"update"`
+}
+
+// UpdateCheckEvent defines model for UpdateCheckEvent.
+type UpdateCheckEvent struct {
+	Channel   string    `json:"channel"`
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// ClientID EAS client ID or CodePush client unique ID of the device
+	ClientID *string `json:"clientId,omitempty"`
+
+	// CodePushLabel Label of the served CodePush update, when it isn't the update ID
+	CodePushLabel *string `json:"codePushLabel,omitempty"`
+
+	// CurrentUpdateID Update the device was running, sent only by Expo clients
+	CurrentUpdateID *openapi_types.UUID      `json:"currentUpdateId,omitempty"`
+	Decision        UpdateCheckEventDecision `json:"decision"`
+	Platform        string                   `json:"platform"`
+
+	// Reason Why the device was told to roll back to the embedded update
+	Reason         *UpdateCheckEventReason `json:"reason,omitempty"`
+	RuntimeVersion string                  `json:"runtimeVersion"`
+
+	// UpdateID Update served to the device, or the canceled update rolled back
+	UpdateID *openapi_types.UUID `json:"updateId,omitempty"`
+}
+
+// UpdateCheckEventDecision defines model for UpdateCheckEvent.Decision.
+type UpdateCheckEventDecision string
+
+// UpdateCheckEventReason Why the device was told to roll back to the embedded update
+type UpdateCheckEventReason string
+
+// UpdateCheckTrace defines model for UpdateCheckTrace.
+type UpdateCheckTrace struct {
+	// CacheKey Key of the cached Expo response, the debug check itself bypasses the cache
+	CacheKey *string `json:"cacheKey,omitempty"`
+
+	// Cached Whether clients are currently served a cached response
+	Cached bool `json:"cached"`
+
+	// Candidates Latest published and latest canceled update of the channel and runtime version,
+	// or only the pinned update when the channel is pinned
+	Candidates      []UpdateCheckCandidate   `json:"candidates"`
+	Channel         string                   `json:"channel"`
+	CurrentUpdateID *openapi_types.UUID      `json:"currentUpdateId,omitempty"`
+	Decision        UpdateCheckTraceDecision `json:"decision"`
+	Flavor          *string                  `json:"flavor,omitempty"`
+	PackageHash     *string                  `json:"packageHash,omitempty"`
+	PinnedUpdateID  *openapi_types.UUID      `json:"pinnedUpdateId,omitempty"`
+	Platform        string                   `json:"platform"`
+	ProjectID       openapi_types.UUID       `json:"projectId"`
+	Reason          string                   `json:"reason"`
+
+	// RolloutBucket Rollout bucket of the client, it gets updates rolled out to more percent of the
+	// devices, 100 for clients without a client ID
+	RolloutBucket int `json:"rolloutBucket"`
+
+	// RuntimeVersion Runtime version after normalization, as matched against the updates
+	RuntimeVersion string              `json:"runtimeVersion"`
+	UpdateID       *openapi_types.UUID `json:"updateId,omitempty"`
+	UpdateProtocol UpdateProtocol      `binding:"required,oneof=expo codepush" json:"updateProtocol"`
+}
+
+// UpdateCheckTraceDecision defines model for UpdateCheckTrace.Decision.
+type UpdateCheckTraceDecision string
+
+// UpdateDiff defines model for UpdateDiff.
+type UpdateDiff struct {
+	Added   []UpdateDiffFile `json:"added"`
+	Changed []UpdateDiffFile `json:"changed"`
+
+	// OtherUpdateID Update compared to the base update
+	OtherUpdateID openapi_types.UUID `json:"otherUpdateId"`
+	Removed       []UpdateDiffFile   `json:"removed"`
+
+	// SizeDelta Total size difference of the files in bytes
+	SizeDelta int64 `json:"sizeDelta"`
+
+	// UpdateID Base update
+	UpdateID openapi_types.UUID `json:"updateId"`
+}
+
+// UpdateDiffFile defines model for UpdateDiffFile.
+type UpdateDiffFile struct {
+	ContentLength *int64 `json:"contentLength,omitempty"`
+
+	// ContentSha256 Hash of the file in the compared update, missing for removed files
+	ContentSha256 *string `json:"contentSha256,omitempty"`
+
+	// Path Path of the file within the update
+	Path                  string `json:"path"`
+	Platform              string `json:"platform"`
+	PreviousContentLength *int64 `json:"previousContentLength,omitempty"`
+
+	// PreviousContentSha256 Hash of the file in the base update, missing for added files
+	PreviousContentSha256 *string `json:"previousContentSha256,omitempty"`
+
+	// SizeDelta Difference of the stored, possibly compressed, sizes in bytes
+	SizeDelta int64 `json:"sizeDelta"`
+}
+
+// UpdateFilesMismatch defines model for UpdateFilesMismatch.
+type UpdateFilesMismatch struct {
+	// CorruptFiles Declared files uploaded with another size or MD5 hash than declared
+	CorruptFiles []string `json:"corruptFiles"`
+	Error        string   `json:"error"`
+
+	// ExtraFiles Declared files that are not referenced in metadata.json
+	ExtraFiles []string `json:"extraFiles"`
+
+	// MissingFiles Files referenced in metadata.json that were not declared or uploaded
+	MissingFiles []string `json:"missingFiles"`
+}
+
+// UpdateProjectParams defines model for UpdateProjectParams.
+type UpdateProjectParams struct {
+	// AdminAllowedCidrs CIDR ranges, e.g. `10.0.0.0/8`, allowed to call the management endpoints of the project,
+	// such as publishing and rolling back updates. The update check endpoints stay open.
+	// Empty array allows all IPs.
+	AdminAllowedCidrs *[]string `binding:"omitempty,max=32,dive,cidr" json:"adminAllowedCidrs,omitempty"`
+
+	// Archived Whether the project is archived. Archived projects keep serving their published
+	// updates, but preparing and committing updates is rejected with 409.
+	Archived *bool `json:"archived,omitempty"`
+
+	// AssetPriorities File extensions, e.g. `.ttf`, and content types, e.g. `image/png` or `image/*`, of
+	// the assets Expo clients download first. The `prefetchAssets` extension of manifests
+	// lists the keys of the launch asset and then of the assets matching these, in their
+	// order, so clients with selective downloads fetch the critical path first. Empty array
+	// restores the default, `image/*`.
+	AssetPriorities *[]string `binding:"omitempty,max=32,dive,max=128" json:"assetPriorities,omitempty"`
+
+	// AssetUrlTemplate Template of asset URLs used in manifests and download URLs, so the assets can be served
+	// by an existing asset pipeline, e.g. `https://assets.example.com/{project}/{update}/{path}?v={sha256}`.
+	// Supported placeholders are `{project}`, `{update}`, `{path}` (path of the file within the update,
+	// `<platform>.zip` for CodePush archives), `{key}` (object key in the bucket), `{sha256}` and `{md5}`.
+	// Empty string disables it.
+	AssetUrlTemplate          *string                    `binding:"omitempty,max=1024" json:"assetUrlTemplate,omitempty"`
+	CodePushDescriptionSource *CodePushDescriptionSource `binding:"omitempty,oneof=message none" json:"codePushDescriptionSource,omitempty"`
+
+	// CodePushSuggestBinaryUpdate Whether CodePush responses without an update set `update_app_version`, so apps can
+	// suggest installing a newer binary from the store. Off by default.
+	CodePushSuggestBinaryUpdate *bool `json:"codePushSuggestBinaryUpdate,omitempty"`
+
+	// MaxAssetCount Maximum number of files of an update, metadata.json included. Updates with more files
+	// are rejected when they're prepared, or fail processing when the files come in an archive.
+	MaxAssetCount *int32 `binding:"omitempty,min=1,max=100000" json:"maxAssetCount,omitempty"`
+
+	// MaxStorageBytes Storage quota in bytes, new updates whose files would take the stored files of the
+	// project over it are rejected. The usage is recalculated by the worker when an update
+	// is processed and every `PROJECT_USAGE_REFRESH_INTERVAL`. 0 removes the quota.
+	MaxStorageBytes *int64 `binding:"omitempty,min=0" json:"maxStorageBytes,omitempty"`
+
+	// MeteredDownloadLimit Size in bytes of the largest update not marked mandatory that devices reporting
+	// a `cellular` or `metered` connection in the `X-Pt-Network` header get. Larger updates
+	// are deferred until they report another connection, they get no update meanwhile.
+	// 0 disables it.
+	MeteredDownloadLimit *int64 `binding:"omitempty,min=0" json:"meteredDownloadLimit,omitempty"`
+
+	// PublicAssetsUrl Base URL of a public or CDN fronted bucket, manifests then contain unsigned URLs
+	// of the form `<publicAssetsUrl>/<projectID>/<updateID>/<path>`. Empty string disables it.
+	PublicAssetsUrl *string `binding:"omitempty,max=512,eq=|url" json:"publicAssetsUrl,omitempty"`
+
+	// ReplicaRegions Regions of the storage replicas (configured with `STORAGE_REPLICAS_FILE`) the assets
+	// of updates published from now on are copied to. Clients from the countries of a replica
+	// get URLs of the replica. Empty array disables replication.
+	ReplicaRegions *[]string `binding:"omitempty,max=16,dive,max=64" json:"replicaRegions,omitempty"`
+
+	// StableAssetUrls Whether Expo manifests and CodePush responses reference assets by the stable asset
+	// route of the API (`/assets/{assetID}`), whose URLs never expire, instead of signed URLs,
+	// so slow downloads don't fail midway. Requires `API_PUBLIC_URL`. Public asset URLs and
+	// URL templates take precedence. Off by default.
+	StableAssetUrls *bool `json:"stableAssetUrls,omitempty"`
 }
 
 // UpdateProtocol defines model for UpdateProtocol.
 type UpdateProtocol string
 
+// UpdateStats defines model for UpdateStats.
+type UpdateStats struct {
+	// ActiveInstallEstimate Downloads not followed by a switch to another update or a rollback, CodePush clients don't send the update they run, so only their rollbacks are subtracted
+	ActiveInstallEstimate int64 `json:"activeInstallEstimate"`
+
+	// Days Update checks per day and platform, the most recent first
+	Days []UpdateStatsDay `json:"days"`
+
+	// Downloads Update checks serving the update to clients running another update
+	Downloads int64 `json:"downloads"`
+
+	// Rollbacks Expo clients running the update told to roll back to the embedded update and CodePush installs of the update rolled back
+	Rollbacks int64              `json:"rollbacks"`
+	UpdateID  openapi_types.UUID `json:"updateId"`
+}
+
+// UpdateStatsDay defines model for UpdateStatsDay.
+type UpdateStatsDay struct {
+	Day openapi_types.Date `json:"day"`
+
+	// Departures Update checks serving another update to clients running the update
+	Departures int64  `json:"departures"`
+	Downloads  int64  `json:"downloads"`
+	Platform   string `json:"platform"`
+	Rollbacks  int64  `json:"rollbacks"`
+}
+
 // UpdateStatus defines model for UpdateStatus.
 type UpdateStatus string
 
+// UpdateUploadStatus defines model for UpdateUploadStatus.
+type UpdateUploadStatus struct {
+	Files         []FileUploadStatus `json:"files"`
+	ReceivedBytes int64              `json:"receivedBytes"`
+	TotalBytes    int64              `json:"totalBytes"`
+	TotalFiles    int                `json:"totalFiles"`
+	UploadedFiles int                `json:"uploadedFiles"`
+}
+
+// UploadPlan What the client has to upload, and the limits the files have to fit in
+type UploadPlan struct {
+	// ChunkSize Size of the chunks of chunked uploads, for files too large to send at once
+	ChunkSize int64 `json:"chunkSize"`
+
+	// MaxObjectSize Size limit of every file
+	MaxObjectSize int64 `json:"maxObjectSize"`
+
+	// RemainingAssetCount Files left of the file limit of the project after the declared files
+	RemainingAssetCount int `json:"remainingAssetCount"`
+
+	// RemainingStorageBytes Bytes left of the storage quota of the project after the uploaded files, omitted when the
+	// project has no quota
+	RemainingStorageBytes *int64 `json:"remainingStorageBytes,omitempty"`
+
+	// RemainingUpdateSize Bytes left of the size limit of the update after the declared files
+	RemainingUpdateSize int64 `json:"remainingUpdateSize"`
+
+	// SharedBytes Size of the shared files, which aren't uploaded
+	SharedBytes int64 `json:"sharedBytes"`
+
+	// UploadBytes Size of the files with an upload URL
+	UploadBytes int64 `json:"uploadBytes"`
+}
+
 // ValidationFieldError defines model for ValidationFieldError.
 type ValidationFieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// ChunkedFilePath defines model for ChunkedFilePath.
+type ChunkedFilePath = string
+
+// Network defines model for Network.
+type Network = string
+
 // ProjectID defines model for ProjectID.
 type ProjectID = openapi_types.UUID
 
 // UpdateID defines model for UpdateID.
 type UpdateID = openapi_types.UUID
 
-// InternalServerError defines model for InternalServerError.
+// InternalServerError Every error response has the message, and the code and retryability of the error. Requests
+// failing validation list the errors of the fields too.
 type InternalServerError = GenericError
 
 // ValidationError defines model for ValidationError.
 type ValidationError struct {
-	Errors []ValidationFieldError `json:"errors"`
+	// Code Kind of the error, the same for every error response with the same cause, unlike the
+	// message
+	Code      *ErrorCode             `json:"code,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	Errors    []ValidationFieldError `json:"errors"`
+	Retryable bool                   `json:"retryable,omitempty"`
+}
+
+// DeleteChannelPolicyParams defines parameters for DeleteChannelPolicy.
+type DeleteChannelPolicyParams struct {
+	Channel string `binding:"required,printascii,max=100" form:"channel" json:"channel"`
+}
+
+// UnpinChannelParams defines parameters for UnpinChannel.
+type UnpinChannelParams struct {
+	Channel        string `binding:"required,printascii,max=100" form:"channel" json:"channel"`
+	RuntimeVersion string `binding:"required,semver" form:"runtimeVersion" json:"runtimeVersion"`
+}
+
+// GetAssetDownloadStatsParams defines parameters for GetAssetDownloadStats.
+type GetAssetDownloadStatsParams struct {
+	// UpdateID Only assets of the update
+	UpdateID *openapi_types.UUID `form:"updateId,omitempty" json:"updateId,omitempty"`
+	Limit    *int32              `binding:"omitempty,min=1,max=1000" form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCodePushReleaseStatsParams defines parameters for GetCodePushReleaseStats.
+type GetCodePushReleaseStatsParams struct {
+	Limit *int32 `binding:"omitempty,min=1,max=1000" form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUpdateCheckEventsParams defines parameters for GetUpdateCheckEvents.
+type GetUpdateCheckEventsParams struct {
+	// From Start of the time range, 24 hours before `to` by default
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the time range, now by default, at most 31 days after `from`
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// ClientID Only checks of the device, by its EAS client ID or CodePush client unique ID
+	ClientID *string `binding:"omitempty,max=128" form:"clientId,omitempty" json:"clientId,omitempty"`
+
+	// Channel Only checks of the channel
+	Channel *string `binding:"omitempty,printascii,max=100" form:"channel,omitempty" json:"channel,omitempty"`
+	Limit   *int32  `binding:"omitempty,min=1,max=1000" form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAPIUsageStatsParams defines parameters for GetAPIUsageStats.
+type GetAPIUsageStatsParams struct {
+	// From Start of the time range, 24 hours before `to` by default
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the time range, now by default, at most 31 days after `from`
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Operation ID of the operation to return, e.g. getExpoUpdate, all by default
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty"`
+}
+
+// UploadUpdateAssetsMultipartBody defines parameters for UploadUpdateAssets.
+type UploadUpdateAssetsMultipartBody map[string]openapi_types.File
+
+// GetChunkedUploadStatusParams defines parameters for GetChunkedUploadStatus.
+type GetChunkedUploadStatusParams struct {
+	// Path Path of the file declared when preparing the update
+	Path ChunkedFilePath `binding:"required,asset_path,max=400" form:"path" json:"path"`
+}
+
+// CompleteChunkedUploadParams defines parameters for CompleteChunkedUpload.
+type CompleteChunkedUploadParams struct {
+	// Path Path of the file declared when preparing the update
+	Path ChunkedFilePath `binding:"required,asset_path,max=400" form:"path" json:"path"`
+}
+
+// UploadChunkParams defines parameters for UploadChunk.
+type UploadChunkParams struct {
+	// Path Path of the file declared when preparing the update
+	Path       ChunkedFilePath `binding:"required,asset_path,max=400" form:"path" json:"path"`
+	ContentMD5 string          `binding:"required,base64" json:"Content-MD5"`
 }
 
 // GetUpdatesParams defines parameters for GetUpdates.
@@ -160,14 +1117,68 @@ type GetUpdatesParams struct {
 	Channel *string `binding:"omitempty,printascii,max=100" form:"channel,omitempty" json:"channel,omitempty"`
 }
 
+// DebugUpdateCheckParams defines parameters for DebugUpdateCheck.
+type DebugUpdateCheckParams struct {
+	ProjectID openapi_types.UUID `form:"projectId" json:"projectId"`
+
+	// RuntimeVersion Expo runtime version or CodePush app version
+	RuntimeVersion  string              `binding:"required,semver" form:"runtimeVersion" json:"runtimeVersion"`
+	Platform        string              `binding:"required,max=8" form:"platform" json:"platform"`
+	Channel         *string             `binding:"omitempty,max=512" form:"channel,omitempty" json:"channel,omitempty"`
+	CurrentUpdateID *openapi_types.UUID `form:"currentUpdateId,omitempty" json:"currentUpdateId,omitempty"`
+	PackageHash     *string             `form:"packageHash,omitempty" json:"packageHash,omitempty"`
+	Flavor          *string             `form:"flavor,omitempty" json:"flavor,omitempty"`
+
+	// ClientID EAS client ID of Expo clients or client_unique_id of CodePush clients
+	ClientID *string `form:"clientId,omitempty" json:"clientId,omitempty"`
+}
+
 // GetExpoUpdateParams defines parameters for GetExpoUpdate.
 type GetExpoUpdateParams struct {
-	Platform            *string             `binding:"omitempty,required,max=8" form:"platform,omitempty" json:"platform,omitempty"`
-	RuntimeVersion      *string             `binding:"omitempty,required,semver" form:"runtime-version,omitempty" json:"runtime-version,omitempty"`
-	CurrentUpdateId     *openapi_types.UUID `binding:"omitempty,required,uuid" form:"current-update-id,omitempty" json:"current-update-id,omitempty"`
+	Platform        *string             `binding:"omitempty,required,max=8" form:"platform,omitempty" json:"platform,omitempty"`
+	RuntimeVersion  *string             `binding:"omitempty,required,semver" form:"runtime-version,omitempty" json:"runtime-version,omitempty"`
+	CurrentUpdateId *openapi_types.UUID `binding:"omitempty,required,uuid" form:"current-update-id,omitempty" json:"current-update-id,omitempty"`
+
+	// ChannelName Channel of the build, used when the Expo-Channel-Name header isn't sent
+	ChannelName *string `binding:"omitempty,printascii,max=100" form:"channel-name,omitempty" json:"channel-name,omitempty"`
+
+	// Flavor Flavor of the app, set in the update URL of the flavor's builds. Clients without it
+	// get only the updates targeting all flavors.
+	Flavor      *string `form:"flavor,omitempty" json:"flavor,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+
+	// ExpoExpectSignature Sent by apps with code signing enabled, e.g. `sig, keyid="main", alg="rsa-v1_5-sha256"`.
+	// The manifest or directive part is then signed with the signing key of the project with
+	// the key ID, and the signature is sent in the expo-signature header of the part.
+	ExpoExpectSignature *string             `json:"Expo-Expect-Signature,omitempty"`
 	ExpoPlatform        *string             `binding:"omitempty,required,max=8" json:"Expo-Platform,omitempty"`
 	ExpoRuntimeVersion  *string             `binding:"omitempty,required,semver" json:"Expo-Runtime-Version,omitempty"`
 	ExpoCurrentUpdateId *openapi_types.UUID `binding:"omitempty,required,uuid" json:"Expo-Current-Update-Id,omitempty"`
+
+	// EASClientID ID of the device, used for the rollouts
+	EASClientID *string `binding:"omitempty,max=128" json:"EAS-Client-ID,omitempty"`
+
+	// ExpoChannelName Channel of the build, production when not set
+	ExpoChannelName *string `binding:"omitempty,printascii,max=100" json:"Expo-Channel-Name,omitempty"`
+
+	// Network Connection of the device, e.g. `wifi`. Devices on `cellular` or other `metered`
+	// connections don't get optional updates larger than the metered download limit of the
+	// project, they're told there's no update until they report another connection.
+	Network *Network `binding:"omitempty,max=16" json:"X-Pt-Network,omitempty"`
+}
+
+// GetCodePushLegacyUpdateParams defines parameters for GetCodePushLegacyUpdate.
+type GetCodePushLegacyUpdateParams struct {
+	AppVersion     string  `form:"appVersion" json:"appVersion"`
+	DeploymentKey  string  `form:"deploymentKey" json:"deploymentKey"`
+	PackageHash    *string `form:"packageHash,omitempty" json:"packageHash,omitempty"`
+	IsCompanion    *bool   `form:"isCompanion,omitempty" json:"isCompanion,omitempty"`
+	ClientUniqueID *string `binding:"uuid_rfc4122" form:"clientUniqueId,omitempty" json:"clientUniqueId,omitempty"`
+
+	// Network Connection of the device, e.g. `wifi`. Devices on `cellular` or other `metered`
+	// connections don't get optional updates larger than the metered download limit of the
+	// project, they're told there's no update until they report another connection.
+	Network *Network `binding:"omitempty,max=16" json:"X-Pt-Network,omitempty"`
 }
 
 // GetCodePushUpdateParams defines parameters for GetCodePushUpdate.
@@ -177,43 +1188,249 @@ type GetCodePushUpdateParams struct {
 	PackageHash    *string `form:"package_hash,omitempty" json:"package_hash,omitempty"`
 	IsCompanion    *bool   `form:"is_companion,omitempty" json:"is_companion,omitempty"`
 	ClientUniqueID *string `binding:"uuid_rfc4122" form:"client_unique_id,omitempty" json:"client_unique_id,omitempty"`
+
+	// Network Connection of the device, e.g. `wifi`. Devices on `cellular` or other `metered`
+	// connections don't get optional updates larger than the metered download limit of the
+	// project, they're told there's no update until they report another connection.
+	Network *Network `binding:"omitempty,max=16" json:"X-Pt-Network,omitempty"`
 }
 
+// SetLogLevelsJSONRequestBody defines body for SetLogLevels for application/json ContentType.
+type SetLogLevelsJSONRequestBody = LogLevels
+
+// SetMaintenanceModeJSONRequestBody defines body for SetMaintenanceMode for application/json ContentType.
+type SetMaintenanceModeJSONRequestBody = MaintenanceMode
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = CreateProjectParams
 
-// PrepareUpdateJSONRequestBody defines body for PrepareUpdate for application/json ContentType.
+// UpdateProjectJSONRequestBody defines body for UpdateProject for application/json ContentType.
+type UpdateProjectJSONRequestBody = UpdateProjectParams
+
+// CloneProjectJSONRequestBody defines body for CloneProject for application/json ContentType.
+type CloneProjectJSONRequestBody = CloneProjectParams
+
+// CopyProjectToEnvironmentJSONRequestBody defines body for CopyProjectToEnvironment for application/json ContentType.
+type CopyProjectToEnvironmentJSONRequestBody = CopyProjectParams
+
+// SetChannelPolicyJSONRequestBody defines body for SetChannelPolicy for application/json ContentType.
+type SetChannelPolicyJSONRequestBody = SetChannelPolicyParams
+
+// RollBackToEmbeddedJSONRequestBody defines body for RollBackToEmbedded for application/json ContentType.
+type RollBackToEmbeddedJSONRequestBody = RollBackToEmbeddedParams
+
+// RegisterEmbeddedUpdateJSONRequestBody defines body for RegisterEmbeddedUpdate for application/json ContentType.
+type RegisterEmbeddedUpdateJSONRequestBody = RegisterEmbeddedUpdateParams
+
+// CreateFlavorJSONRequestBody defines body for CreateFlavor for application/json ContentType.
+type CreateFlavorJSONRequestBody = CreateFlavorParams
+
+// PinChannelJSONRequestBody defines body for PinChannel for application/json ContentType.
+type PinChannelJSONRequestBody = PinChannelParams
+
+// RotateSigningKeyJSONRequestBody defines body for RotateSigningKey for application/json ContentType.
+type RotateSigningKeyJSONRequestBody = RotateSigningKeyParams
+
+// PrepareUpdateJSONRequestBody defines body for PrepareUpdate for application/json ContentType.
 type PrepareUpdateJSONRequestBody = PrepareUpdateBody
 
+// PatchUpdateJSONRequestBody defines body for PatchUpdate for application/json ContentType.
+type PatchUpdateJSONRequestBody = PatchUpdateParams
+
+// UploadUpdateAssetsMultipartRequestBody defines body for UploadUpdateAssets for multipart/form-data ContentType.
+type UploadUpdateAssetsMultipartRequestBody UploadUpdateAssetsMultipartBody
+
+// SetUpdateExpiryJSONRequestBody defines body for SetUpdateExpiry for application/json ContentType.
+type SetUpdateExpiryJSONRequestBody = SetUpdateExpiryParams
+
+// SetUpdateRolloutJSONRequestBody defines body for SetUpdateRollout for application/json ContentType.
+type SetUpdateRolloutJSONRequestBody = SetRolloutParams
+
+// ReportCodePushLegacyDeployStatusJSONRequestBody defines body for ReportCodePushLegacyDeployStatus for application/json ContentType.
+type ReportCodePushLegacyDeployStatusJSONRequestBody = CodePushLegacyDeployReport
+
+// ReportCodePushLegacyDownloadStatusJSONRequestBody defines body for ReportCodePushLegacyDownloadStatus for application/json ContentType.
+type ReportCodePushLegacyDownloadStatusJSONRequestBody = CodePushLegacyDownloadReport
+
+// ReportCodePushDeployStatusJSONRequestBody defines body for ReportCodePushDeployStatus for application/json ContentType.
+type ReportCodePushDeployStatusJSONRequestBody = CodePushDeployReport
+
+// ReportCodePushDownloadStatusJSONRequestBody defines body for ReportCodePushDownloadStatus for application/json ContentType.
+type ReportCodePushDownloadStatusJSONRequestBody = CodePushDownloadReport
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get log levels of the server components
+	// (GET /api/v1/admin/log-levels)
+	GetLogLevels(c *gin.Context)
+	// Change log levels of the server components
+	// (PUT /api/v1/admin/log-levels)
+	SetLogLevels(c *gin.Context)
+	// Get the maintenance mode of the server
+	// (GET /api/v1/admin/maintenance)
+	GetMaintenanceMode(c *gin.Context)
+	// Enable or disable the maintenance mode of every server
+	// (PUT /api/v1/admin/maintenance)
+	SetMaintenanceMode(c *gin.Context)
 	// Create a project
 	// (POST /api/v1/admin/project)
 	CreateProject(c *gin.Context)
+	// Delete the project
+	// (DELETE /api/v1/admin/project/{projectID})
+	DeleteProject(c *gin.Context, projectID ProjectID)
 	// Get project by id
 	// (GET /api/v1/admin/project/{projectID})
 	GetProjectByID(c *gin.Context, projectID ProjectID)
+	// Update project settings
+	// (PATCH /api/v1/admin/project/{projectID})
+	UpdateProject(c *gin.Context, projectID ProjectID)
+	// Clone the project
+	// (POST /api/v1/admin/project/{projectID}/clone)
+	CloneProject(c *gin.Context, projectID ProjectID)
+	// Get the project in all environments
+	// (GET /api/v1/admin/project/{projectID}/environments)
+	GetProjectEnvironments(c *gin.Context, projectID ProjectID)
+	// Copy the project configuration to another environment
+	// (POST /api/v1/admin/project/{projectID}/environments)
+	CopyProjectToEnvironment(c *gin.Context, projectID ProjectID)
+	// Remove the policy of a channel
+	// (DELETE /api/v1/admin/{projectID}/channel-policies)
+	DeleteChannelPolicy(c *gin.Context, projectID ProjectID, params DeleteChannelPolicyParams)
+	// List channel policies
+	// (GET /api/v1/admin/{projectID}/channel-policies)
+	GetChannelPolicies(c *gin.Context, projectID ProjectID)
+	// Set the policy of a channel
+	// (PUT /api/v1/admin/{projectID}/channel-policies)
+	SetChannelPolicy(c *gin.Context, projectID ProjectID)
+	// Roll back a channel to the embedded update
+	// (POST /api/v1/admin/{projectID}/channels/{channel}/rollback-to-embedded)
+	RollBackToEmbedded(c *gin.Context, projectID ProjectID, channel string)
+	// List embedded updates
+	// (GET /api/v1/admin/{projectID}/embedded-updates)
+	GetEmbeddedUpdates(c *gin.Context, projectID ProjectID)
+	// Register an embedded update
+	// (PUT /api/v1/admin/{projectID}/embedded-updates)
+	RegisterEmbeddedUpdate(c *gin.Context, projectID ProjectID)
+	// List flavors
+	// (GET /api/v1/admin/{projectID}/flavors)
+	GetFlavors(c *gin.Context, projectID ProjectID)
+	// Create a flavor
+	// (POST /api/v1/admin/{projectID}/flavors)
+	CreateFlavor(c *gin.Context, projectID ProjectID)
+	// Delete a flavor
+	// (DELETE /api/v1/admin/{projectID}/flavors/{flavor})
+	DeleteFlavor(c *gin.Context, projectID ProjectID, flavor string)
+	// Remove a channel pin
+	// (DELETE /api/v1/admin/{projectID}/pins)
+	UnpinChannel(c *gin.Context, projectID ProjectID, params UnpinChannelParams)
+	// List channel pins
+	// (GET /api/v1/admin/{projectID}/pins)
+	GetChannelPins(c *gin.Context, projectID ProjectID)
+	// Pin a channel to an update
+	// (PUT /api/v1/admin/{projectID}/pins)
+	PinChannel(c *gin.Context, projectID ProjectID)
+	// List signing keys
+	// (GET /api/v1/admin/{projectID}/signing-keys)
+	GetSigningKeys(c *gin.Context, projectID ProjectID)
+	// Rotate the signing key
+	// (POST /api/v1/admin/{projectID}/signing-keys)
+	RotateSigningKey(c *gin.Context, projectID ProjectID)
+	// Retire a signing key immediately
+	// (DELETE /api/v1/admin/{projectID}/signing-keys/{keyID})
+	RetireSigningKey(c *gin.Context, projectID ProjectID, keyID string)
+	// Asset download statistics
+	// (GET /api/v1/admin/{projectID}/stats/assets)
+	GetAssetDownloadStats(c *gin.Context, projectID ProjectID, params GetAssetDownloadStatsParams)
+	// CodePush release statistics
+	// (GET /api/v1/admin/{projectID}/stats/codepush-releases)
+	GetCodePushReleaseStats(c *gin.Context, projectID ProjectID, params GetCodePushReleaseStatsParams)
+	// Assets that failed integrity verification
+	// (GET /api/v1/admin/{projectID}/stats/integrity)
+	GetCorruptedAssets(c *gin.Context, projectID ProjectID)
+	// Recorded update checks
+	// (GET /api/v1/admin/{projectID}/stats/update-checks)
+	GetUpdateCheckEvents(c *gin.Context, projectID ProjectID, params GetUpdateCheckEventsParams)
+	// API usage statistics
+	// (GET /api/v1/admin/{projectID}/stats/usage)
+	GetAPIUsageStats(c *gin.Context, projectID ProjectID, params GetAPIUsageStatsParams)
 	// Prepare a new update
 	// (POST /api/v1/admin/{projectID}/update)
 	PrepareUpdate(c *gin.Context, projectID ProjectID)
+	// Delete update
+	// (DELETE /api/v1/admin/{projectID}/update/{updateID})
+	DeleteUpdate(c *gin.Context, projectID ProjectID, updateID UpdateID)
 	// Get update
 	// (GET /api/v1/admin/{projectID}/update/{updateID})
 	GetUpdate(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Change the properties of an update
+	// (PATCH /api/v1/admin/{projectID}/update/{updateID})
+	PatchUpdate(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Upload update files through the API
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/assets)
+	UploadUpdateAssets(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Get chunked upload status of a file
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/chunks)
+	GetChunkedUploadStatus(c *gin.Context, projectID ProjectID, updateID UpdateID, params GetChunkedUploadStatusParams)
+	// Assemble uploaded chunks of a file
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/chunks/complete)
+	CompleteChunkedUpload(c *gin.Context, projectID ProjectID, updateID UpdateID, params CompleteChunkedUploadParams)
+	// Upload a chunk of a file
+	// (PUT /api/v1/admin/{projectID}/update/{updateID}/chunks/{chunkIndex})
+	UploadChunk(c *gin.Context, projectID ProjectID, updateID UpdateID, chunkIndex int, params UploadChunkParams)
 	// Commit update
 	// (POST /api/v1/admin/{projectID}/update/{updateID}/commit)
 	CommitUpdate(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Compare files of two updates
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/diff/{otherUpdateID})
+	DiffUpdates(c *gin.Context, projectID ProjectID, updateID UpdateID, otherUpdateID openapi_types.UUID)
+	// Set the expiry of an update
+	// (PUT /api/v1/admin/{projectID}/update/{updateID}/expiry)
+	SetUpdateExpiry(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Get reports of the processing runs of an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/reports)
+	GetProcessingReports(c *gin.Context, projectID ProjectID, updateID UpdateID)
 	// Rollback an update
 	// (POST /api/v1/admin/{projectID}/update/{updateID}/rollback)
 	RollbackUpdate(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Change the rollout percentage of an update
+	// (PATCH /api/v1/admin/{projectID}/update/{updateID}/rollout)
+	SetUpdateRollout(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Adoption statistics of an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/stats)
+	GetUpdateStats(c *gin.Context, projectID ProjectID, updateID UpdateID)
+	// Get upload progress of the files declared for an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/upload-status)
+	GetUploadStatus(c *gin.Context, projectID ProjectID, updateID UpdateID)
 	// Get all updates
 	// (GET /api/v1/admin/{projectID}/updates)
 	GetUpdates(c *gin.Context, projectID ProjectID, params GetUpdatesParams)
+	// Get update by its external ID
+	// (GET /api/v1/admin/{projectID}/updates/by-external-id/{externalID})
+	GetUpdateByExternalID(c *gin.Context, projectID ProjectID, externalID string)
+	// Explain the result of an update check
+	// (GET /api/v1/debug/update-check)
+	DebugUpdateCheck(c *gin.Context, params DebugUpdateCheckParams)
 	// Health check
 	// (GET /api/v1/health)
 	HealthCheck(c *gin.Context)
 	// Get Expo update
 	// (GET /api/v1/public/{projectID}/expo)
 	GetExpoUpdate(c *gin.Context, projectID ProjectID, params GetExpoUpdateParams)
+	// Report CodePush install status, legacy path of the standalone CodePush server
+	// (POST /reportStatus/deploy)
+	ReportCodePushLegacyDeployStatus(c *gin.Context)
+	// Report CodePush download, legacy path of the standalone CodePush server
+	// (POST /reportStatus/download)
+	ReportCodePushLegacyDownloadStatus(c *gin.Context)
+	// Get CodePush update, legacy path of the standalone CodePush server
+	// (GET /updateCheck)
+	GetCodePushLegacyUpdate(c *gin.Context, params GetCodePushLegacyUpdateParams)
+	// Report CodePush install status
+	// (POST /v0.1/public/codepush/report_status/deploy)
+	ReportCodePushDeployStatus(c *gin.Context)
+	// Report CodePush download
+	// (POST /v0.1/public/codepush/report_status/download)
+	ReportCodePushDownloadStatus(c *gin.Context)
 	// Get CodePush update
 	// (GET /v0.1/public/codepush/update_check)
 	GetCodePushUpdate(c *gin.Context, params GetCodePushUpdateParams)
@@ -228,6 +1445,58 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// GetLogLevels operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevels(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLogLevels(c)
+}
+
+// SetLogLevels operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevels(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetLogLevels(c)
+}
+
+// GetMaintenanceMode operation middleware
+func (siw *ServerInterfaceWrapper) GetMaintenanceMode(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMaintenanceMode(c)
+}
+
+// SetMaintenanceMode operation middleware
+func (siw *ServerInterfaceWrapper) SetMaintenanceMode(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetMaintenanceMode(c)
+}
+
 // CreateProject operation middleware
 func (siw *ServerInterfaceWrapper) CreateProject(c *gin.Context) {
 
@@ -241,8 +1510,8 @@ func (siw *ServerInterfaceWrapper) CreateProject(c *gin.Context) {
 	siw.Handler.CreateProject(c)
 }
 
-// GetProjectByID operation middleware
-func (siw *ServerInterfaceWrapper) GetProjectByID(c *gin.Context) {
+// DeleteProject operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProject(c *gin.Context) {
 
 	var err error
 
@@ -262,11 +1531,11 @@ func (siw *ServerInterfaceWrapper) GetProjectByID(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetProjectByID(c, projectID)
+	siw.Handler.DeleteProject(c, projectID)
 }
 
-// PrepareUpdate operation middleware
-func (siw *ServerInterfaceWrapper) PrepareUpdate(c *gin.Context) {
+// GetProjectByID operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectByID(c *gin.Context) {
 
 	var err error
 
@@ -286,11 +1555,11 @@ func (siw *ServerInterfaceWrapper) PrepareUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PrepareUpdate(c, projectID)
+	siw.Handler.GetProjectByID(c, projectID)
 }
 
-// GetUpdate operation middleware
-func (siw *ServerInterfaceWrapper) GetUpdate(c *gin.Context) {
+// UpdateProject operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProject(c *gin.Context) {
 
 	var err error
 
@@ -303,15 +1572,6 @@ func (siw *ServerInterfaceWrapper) GetUpdate(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "updateID" -------------
-	var updateID UpdateID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -319,11 +1579,11 @@ func (siw *ServerInterfaceWrapper) GetUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUpdate(c, projectID, updateID)
+	siw.Handler.UpdateProject(c, projectID)
 }
 
-// CommitUpdate operation middleware
-func (siw *ServerInterfaceWrapper) CommitUpdate(c *gin.Context) {
+// CloneProject operation middleware
+func (siw *ServerInterfaceWrapper) CloneProject(c *gin.Context) {
 
 	var err error
 
@@ -336,15 +1596,6 @@ func (siw *ServerInterfaceWrapper) CommitUpdate(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "updateID" -------------
-	var updateID UpdateID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -352,11 +1603,11 @@ func (siw *ServerInterfaceWrapper) CommitUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CommitUpdate(c, projectID, updateID)
+	siw.Handler.CloneProject(c, projectID)
 }
 
-// RollbackUpdate operation middleware
-func (siw *ServerInterfaceWrapper) RollbackUpdate(c *gin.Context) {
+// GetProjectEnvironments operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectEnvironments(c *gin.Context) {
 
 	var err error
 
@@ -369,12 +1620,27 @@ func (siw *ServerInterfaceWrapper) RollbackUpdate(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "updateID" -------------
-	var updateID UpdateID
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	siw.Handler.GetProjectEnvironments(c, projectID)
+}
+
+// CopyProjectToEnvironment operation middleware
+func (siw *ServerInterfaceWrapper) CopyProjectToEnvironment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -385,11 +1651,11 @@ func (siw *ServerInterfaceWrapper) RollbackUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RollbackUpdate(c, projectID, updateID)
+	siw.Handler.CopyProjectToEnvironment(c, projectID)
 }
 
-// GetUpdates operation middleware
-func (siw *ServerInterfaceWrapper) GetUpdates(c *gin.Context) {
+// DeleteChannelPolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChannelPolicy(c *gin.Context) {
 
 	var err error
 
@@ -403,27 +1669,18 @@ func (siw *ServerInterfaceWrapper) GetUpdates(c *gin.Context) {
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUpdatesParams
-
-	// ------------- Optional query parameter "status" -------------
+	var params DeleteChannelPolicyParams
 
-	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
-		return
-	}
+	// ------------- Required query parameter "channel" -------------
 
-	// ------------- Optional query parameter "runtimeVersion" -------------
+	if paramValue := c.Query("channel"); paramValue != "" {
 
-	err = runtime.BindQueryParameter("form", true, false, "runtimeVersion", c.Request.URL.Query(), &params.RuntimeVersion)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtimeVersion: %w", err), http.StatusBadRequest)
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument channel is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "channel" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "channel", c.Request.URL.Query(), &params.Channel)
+	err = runtime.BindQueryParameter("form", true, true, "channel", c.Request.URL.Query(), &params.Channel)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
 		return
@@ -436,11 +1693,22 @@ func (siw *ServerInterfaceWrapper) GetUpdates(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUpdates(c, projectID, params)
+	siw.Handler.DeleteChannelPolicy(c, projectID, params)
 }
 
-// HealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
+// GetChannelPolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelPolicies(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -449,11 +1717,11 @@ func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.HealthCheck(c)
+	siw.Handler.GetChannelPolicies(c, projectID)
 }
 
-// GetExpoUpdate operation middleware
-func (siw *ServerInterfaceWrapper) GetExpoUpdate(c *gin.Context) {
+// SetChannelPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetChannelPolicy(c *gin.Context) {
 
 	var err error
 
@@ -466,90 +1734,85 @@ func (siw *ServerInterfaceWrapper) GetExpoUpdate(c *gin.Context) {
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetExpoUpdateParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// ------------- Optional query parameter "platform" -------------
+	siw.Handler.SetChannelPolicy(c, projectID)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "platform", c.Request.URL.Query(), &params.Platform)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter platform: %w", err), http.StatusBadRequest)
-		return
-	}
+// RollBackToEmbedded operation middleware
+func (siw *ServerInterfaceWrapper) RollBackToEmbedded(c *gin.Context) {
 
-	// ------------- Optional query parameter "runtime-version" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "runtime-version", c.Request.URL.Query(), &params.RuntimeVersion)
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtime-version: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "current-update-id" -------------
+	// ------------- Path parameter "channel" -------------
+	var channel string
 
-	err = runtime.BindQueryParameter("form", true, false, "current-update-id", c.Request.URL.Query(), &params.CurrentUpdateId)
+	err = runtime.BindStyledParameterWithOptions("simple", "channel", c.Param("channel"), &channel, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter current-update-id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	headers := c.Request.Header
-
-	// ------------- Optional header parameter "Expo-Platform" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Platform")]; found {
-		var ExpoPlatform string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Platform, got %d", n), http.StatusBadRequest)
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Platform", valueList[0], &ExpoPlatform, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Platform: %w", err), http.StatusBadRequest)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
 			return
 		}
-
-		params.ExpoPlatform = &ExpoPlatform
-
 	}
 
-	// ------------- Optional header parameter "Expo-Runtime-Version" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Runtime-Version")]; found {
-		var ExpoRuntimeVersion string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Runtime-Version, got %d", n), http.StatusBadRequest)
-			return
-		}
+	siw.Handler.RollBackToEmbedded(c, projectID, channel)
+}
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Runtime-Version", valueList[0], &ExpoRuntimeVersion, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Runtime-Version: %w", err), http.StatusBadRequest)
-			return
-		}
+// GetEmbeddedUpdates operation middleware
+func (siw *ServerInterfaceWrapper) GetEmbeddedUpdates(c *gin.Context) {
 
-		params.ExpoRuntimeVersion = &ExpoRuntimeVersion
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	// ------------- Optional header parameter "Expo-Current-Update-Id" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Current-Update-Id")]; found {
-		var ExpoCurrentUpdateId openapi_types.UUID
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Current-Update-Id, got %d", n), http.StatusBadRequest)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
 			return
 		}
+	}
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Current-Update-Id", valueList[0], &ExpoCurrentUpdateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Current-Update-Id: %w", err), http.StatusBadRequest)
-			return
-		}
+	siw.Handler.GetEmbeddedUpdates(c, projectID)
+}
 
-		params.ExpoCurrentUpdateId = &ExpoCurrentUpdateId
+// RegisterEmbeddedUpdate operation middleware
+func (siw *ServerInterfaceWrapper) RegisterEmbeddedUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -559,68 +1822,77 @@ func (siw *ServerInterfaceWrapper) GetExpoUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetExpoUpdate(c, projectID, params)
+	siw.Handler.RegisterEmbeddedUpdate(c, projectID)
 }
 
-// GetCodePushUpdate operation middleware
-func (siw *ServerInterfaceWrapper) GetCodePushUpdate(c *gin.Context) {
+// GetFlavors operation middleware
+func (siw *ServerInterfaceWrapper) GetFlavors(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCodePushUpdateParams
-
-	// ------------- Required query parameter "app_version" -------------
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-	if paramValue := c.Query("app_version"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument app_version is required, but not found"), http.StatusBadRequest)
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "app_version", c.Request.URL.Query(), &params.AppVersion)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter app_version: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Required query parameter "deployment_key" -------------
+	siw.Handler.GetFlavors(c, projectID)
+}
 
-	if paramValue := c.Query("deployment_key"); paramValue != "" {
+// CreateFlavor operation middleware
+func (siw *ServerInterfaceWrapper) CreateFlavor(c *gin.Context) {
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument deployment_key is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, true, "deployment_key", c.Request.URL.Query(), &params.DeploymentKey)
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deployment_key: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "package_hash" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "package_hash", c.Request.URL.Query(), &params.PackageHash)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter package_hash: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "is_companion" -------------
+	siw.Handler.CreateFlavor(c, projectID)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "is_companion", c.Request.URL.Query(), &params.IsCompanion)
+// DeleteFlavor operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlavor(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_companion: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "client_unique_id" -------------
+	// ------------- Path parameter "flavor" -------------
+	var flavor string
 
-	err = runtime.BindQueryParameter("form", true, false, "client_unique_id", c.Request.URL.Query(), &params.ClientUniqueID)
+	err = runtime.BindStyledParameterWithOptions("simple", "flavor", c.Param("flavor"), &flavor, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter client_unique_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter flavor: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -631,470 +1903,5520 @@ func (siw *ServerInterfaceWrapper) GetCodePushUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetCodePushUpdate(c, params)
+	siw.Handler.DeleteFlavor(c, projectID, flavor)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// UnpinChannel operation middleware
+func (siw *ServerInterfaceWrapper) UnpinChannel(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	router.POST(options.BaseURL+"/api/v1/admin/project", wrapper.CreateProject)
-	router.GET(options.BaseURL+"/api/v1/admin/project/:projectID", wrapper.GetProjectByID)
-	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update", wrapper.PrepareUpdate)
-	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID", wrapper.GetUpdate)
-	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/commit", wrapper.CommitUpdate)
-	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/rollback", wrapper.RollbackUpdate)
-	router.GET(options.BaseURL+"/api/v1/admin/:projectID/updates", wrapper.GetUpdates)
-	router.GET(options.BaseURL+"/api/v1/health", wrapper.HealthCheck)
-	router.GET(options.BaseURL+"/api/v1/public/:projectID/expo", wrapper.GetExpoUpdate)
-	router.GET(options.BaseURL+"/v0.1/public/codepush/update_check", wrapper.GetCodePushUpdate)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnpinChannelParams
 
-type InternalServerErrorJSONResponse GenericError
+	// ------------- Required query parameter "channel" -------------
 
-type ValidationErrorJSONResponse struct {
-	Errors []ValidationFieldError `json:"errors"`
-}
+	if paramValue := c.Query("channel"); paramValue != "" {
 
-type CreateProjectRequestObject struct {
-	Body *CreateProjectJSONRequestBody
-}
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument channel is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-type CreateProjectResponseObject interface {
-	VisitCreateProjectResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, true, "channel", c.Request.URL.Query(), &params.Channel)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type CreateProject200JSONResponse Project
+	// ------------- Required query parameter "runtimeVersion" -------------
 
-func (response CreateProject200JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	if paramValue := c.Query("runtimeVersion"); paramValue != "" {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument runtimeVersion is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-type CreateProject400JSONResponse struct{ ValidationErrorJSONResponse }
+	err = runtime.BindQueryParameter("form", true, true, "runtimeVersion", c.Request.URL.Query(), &params.RuntimeVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtimeVersion: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response CreateProject400JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.UnpinChannel(c, projectID, params)
 }
 
-type CreateProject500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+// GetChannelPins operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelPins(c *gin.Context) {
 
-func (response CreateProject500JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type GetProjectByIDRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetProjectByIDResponseObject interface {
-	VisitGetProjectByIDResponse(w http.ResponseWriter) error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type GetProjectByID200JSONResponse Project
+	siw.Handler.GetChannelPins(c, projectID)
+}
 
-func (response GetProjectByID200JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PinChannel operation middleware
+func (siw *ServerInterfaceWrapper) PinChannel(c *gin.Context) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetProjectByID400JSONResponse struct{ ValidationErrorJSONResponse }
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-func (response GetProjectByID400JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type GetProjectByID404Response struct {
+	siw.Handler.PinChannel(c, projectID)
 }
 
-func (response GetProjectByID404Response) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+// GetSigningKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetSigningKeys(c *gin.Context) {
 
-type GetProjectByID500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+	var err error
 
-func (response GetProjectByID500JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PrepareUpdateRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	Body      *PrepareUpdateJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type PrepareUpdateResponseObject interface {
-	VisitPrepareUpdateResponse(w http.ResponseWriter) error
+	siw.Handler.GetSigningKeys(c, projectID)
 }
 
-type PrepareUpdate201JSONResponse PrepareUpdateResponse
+// RotateSigningKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateSigningKey(c *gin.Context) {
 
-func (response PrepareUpdate201JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type PrepareUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response PrepareUpdate400JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.RotateSigningKey(c, projectID)
 }
 
-type PrepareUpdate500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+// RetireSigningKey operation middleware
+func (siw *ServerInterfaceWrapper) RetireSigningKey(c *gin.Context) {
 
-func (response PrepareUpdate500JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type GetUpdateRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	UpdateID  UpdateID  `json:"updateID"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetUpdateResponseObject interface {
-	VisitGetUpdateResponse(w http.ResponseWriter) error
-}
+	// ------------- Path parameter "keyID" -------------
+	var keyID string
 
-type GetUpdate200JSONResponse Update
+	err = runtime.BindStyledParameterWithOptions("simple", "keyID", c.Param("keyID"), &keyID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter keyID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetUpdate200JSONResponse) VisitGetUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.RetireSigningKey(c, projectID, keyID)
 }
 
-type GetUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
-
-func (response GetUpdate400JSONResponse) VisitGetUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// GetAssetDownloadStats operation middleware
+func (siw *ServerInterfaceWrapper) GetAssetDownloadStats(c *gin.Context) {
 
-type GetUpdate404Response struct {
-}
+	var err error
 
-func (response GetUpdate404Response) VisitGetUpdateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type CommitUpdateRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	UpdateID  UpdateID  `json:"updateID"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type CommitUpdateResponseObject interface {
-	VisitCommitUpdateResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAssetDownloadStatsParams
 
-type CommitUpdate204Response struct {
-}
+	// ------------- Optional query parameter "updateId" -------------
 
-func (response CommitUpdate204Response) VisitCommitUpdateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindQueryParameter("form", true, false, "updateId", c.Request.URL.Query(), &params.UpdateID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateId: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type CommitUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+	// ------------- Optional query parameter "limit" -------------
 
-func (response CommitUpdate400JSONResponse) VisitCommitUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type CommitUpdate500JSONResponse struct {
-	InternalServerErrorJSONResponse
+	siw.Handler.GetAssetDownloadStats(c, projectID, params)
 }
 
-func (response CommitUpdate500JSONResponse) VisitCommitUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// GetCodePushReleaseStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCodePushReleaseStats(c *gin.Context) {
 
-type RollbackUpdateRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	UpdateID  UpdateID  `json:"updateID"`
-}
+	var err error
 
-type RollbackUpdateResponseObject interface {
-	VisitRollbackUpdateResponse(w http.ResponseWriter) error
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type RollbackUpdate204Response struct {
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response RollbackUpdate204Response) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCodePushReleaseStatsParams
 
-type RollbackUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+	// ------------- Optional query parameter "limit" -------------
 
-func (response RollbackUpdate400JSONResponse) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type RollbackUpdate500JSONResponse struct {
-	InternalServerErrorJSONResponse
+	siw.Handler.GetCodePushReleaseStats(c, projectID, params)
 }
 
-func (response RollbackUpdate500JSONResponse) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// GetCorruptedAssets operation middleware
+func (siw *ServerInterfaceWrapper) GetCorruptedAssets(c *gin.Context) {
 
-type GetUpdatesRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	Params    GetUpdatesParams
-}
+	var err error
 
-type GetUpdatesResponseObject interface {
-	VisitGetUpdatesResponse(w http.ResponseWriter) error
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type GetUpdates200JSONResponse GetUpdatesResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetUpdates200JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.GetCorruptedAssets(c, projectID)
 }
 
-type GetUpdates400JSONResponse struct{ ValidationErrorJSONResponse }
+// GetUpdateCheckEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetUpdateCheckEvents(c *gin.Context) {
 
-func (response GetUpdates400JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
 
-type GetUpdates500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetUpdates500JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUpdateCheckEventsParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "from" -------------
 
-type HealthCheckRequestObject struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type HealthCheckResponseObject interface {
-	VisitHealthCheckResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "to" -------------
 
-type HealthCheck200JSONResponse struct {
-	Status string `json:"status"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Optional query parameter "clientId" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "clientId", c.Request.URL.Query(), &params.ClientID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clientId: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetExpoUpdateRequestObject struct {
-	ProjectID ProjectID `json:"projectID"`
-	Params    GetExpoUpdateParams
-}
+	// ------------- Optional query parameter "channel" -------------
 
-type GetExpoUpdateResponseObject interface {
-	VisitGetExpoUpdateResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "channel", c.Request.URL.Query(), &params.Channel)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetExpoUpdate200ResponseHeaders struct {
-	CacheControl        string
-	ExpoProtocolVersion string
-	ExpoSfvVersion      string
-}
+	// ------------- Optional query parameter "limit" -------------
 
-type GetExpoUpdate200MultipartResponse struct {
-	Body    func(writer *multipart.Writer) error
-	Headers GetExpoUpdate200ResponseHeaders
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetExpoUpdate200MultipartResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
-	writer := multipart.NewWriter(w)
-	w.Header().Set("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": writer.Boundary()}))
-	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
-	w.Header().Set("Expo-Protocol-Version", fmt.Sprint(response.Headers.ExpoProtocolVersion))
-	w.Header().Set("Expo-Sfv-Version", fmt.Sprint(response.Headers.ExpoSfvVersion))
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	defer writer.Close()
-	return response.Body(writer)
+	siw.Handler.GetUpdateCheckEvents(c, projectID, params)
 }
 
-type GetExpoUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+// GetAPIUsageStats operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIUsageStats(c *gin.Context) {
 
-func (response GetExpoUpdate400JSONResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAPIUsageStatsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "operation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operation", c.Request.URL.Query(), &params.Operation)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter operation: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAPIUsageStats(c, projectID, params)
+}
+
+// PrepareUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PrepareUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PrepareUpdate(c, projectID)
+}
+
+// DeleteUpdate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUpdate(c, projectID, updateID)
+}
+
+// GetUpdate operation middleware
+func (siw *ServerInterfaceWrapper) GetUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUpdate(c, projectID, updateID)
+}
+
+// PatchUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PatchUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchUpdate(c, projectID, updateID)
+}
+
+// UploadUpdateAssets operation middleware
+func (siw *ServerInterfaceWrapper) UploadUpdateAssets(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UploadUpdateAssets(c, projectID, updateID)
+}
+
+// GetChunkedUploadStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetChunkedUploadStatus(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChunkedUploadStatusParams
+
+	// ------------- Required query parameter "path" -------------
+
+	if paramValue := c.Query("path"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument path is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetChunkedUploadStatus(c, projectID, updateID, params)
+}
+
+// CompleteChunkedUpload operation middleware
+func (siw *ServerInterfaceWrapper) CompleteChunkedUpload(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CompleteChunkedUploadParams
+
+	// ------------- Required query parameter "path" -------------
+
+	if paramValue := c.Query("path"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument path is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CompleteChunkedUpload(c, projectID, updateID, params)
+}
+
+// UploadChunk operation middleware
+func (siw *ServerInterfaceWrapper) UploadChunk(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "chunkIndex" -------------
+	var chunkIndex int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chunkIndex", c.Param("chunkIndex"), &chunkIndex, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter chunkIndex: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UploadChunkParams
+
+	// ------------- Required query parameter "path" -------------
+
+	if paramValue := c.Query("path"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument path is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Required header parameter "Content-MD5" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Content-MD5")]; found {
+		var ContentMD5 string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Content-MD5, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Content-MD5", valueList[0], &ContentMD5, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Content-MD5: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ContentMD5 = ContentMD5
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Header parameter Content-MD5 is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UploadChunk(c, projectID, updateID, chunkIndex, params)
+}
+
+// CommitUpdate operation middleware
+func (siw *ServerInterfaceWrapper) CommitUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CommitUpdate(c, projectID, updateID)
+}
+
+// DiffUpdates operation middleware
+func (siw *ServerInterfaceWrapper) DiffUpdates(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "otherUpdateID" -------------
+	var otherUpdateID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "otherUpdateID", c.Param("otherUpdateID"), &otherUpdateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter otherUpdateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DiffUpdates(c, projectID, updateID, otherUpdateID)
+}
+
+// SetUpdateExpiry operation middleware
+func (siw *ServerInterfaceWrapper) SetUpdateExpiry(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetUpdateExpiry(c, projectID, updateID)
+}
+
+// GetProcessingReports operation middleware
+func (siw *ServerInterfaceWrapper) GetProcessingReports(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProcessingReports(c, projectID, updateID)
+}
+
+// RollbackUpdate operation middleware
+func (siw *ServerInterfaceWrapper) RollbackUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RollbackUpdate(c, projectID, updateID)
+}
+
+// SetUpdateRollout operation middleware
+func (siw *ServerInterfaceWrapper) SetUpdateRollout(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetUpdateRollout(c, projectID, updateID)
+}
+
+// GetUpdateStats operation middleware
+func (siw *ServerInterfaceWrapper) GetUpdateStats(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUpdateStats(c, projectID, updateID)
+}
+
+// GetUploadStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetUploadStatus(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "updateID" -------------
+	var updateID UpdateID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "updateID", c.Param("updateID"), &updateID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updateID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUploadStatus(c, projectID, updateID)
+}
+
+// GetUpdates operation middleware
+func (siw *ServerInterfaceWrapper) GetUpdates(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUpdatesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "runtimeVersion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "runtimeVersion", c.Request.URL.Query(), &params.RuntimeVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtimeVersion: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "channel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel", c.Request.URL.Query(), &params.Channel)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUpdates(c, projectID, params)
+}
+
+// GetUpdateByExternalID operation middleware
+func (siw *ServerInterfaceWrapper) GetUpdateByExternalID(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "externalID" -------------
+	var externalID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "externalID", c.Param("externalID"), &externalID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter externalID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUpdateByExternalID(c, projectID, externalID)
+}
+
+// DebugUpdateCheck operation middleware
+func (siw *ServerInterfaceWrapper) DebugUpdateCheck(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DebugUpdateCheckParams
+
+	// ------------- Required query parameter "projectId" -------------
+
+	if paramValue := c.Query("projectId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument projectId is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "projectId", c.Request.URL.Query(), &params.ProjectID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "runtimeVersion" -------------
+
+	if paramValue := c.Query("runtimeVersion"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument runtimeVersion is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "runtimeVersion", c.Request.URL.Query(), &params.RuntimeVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtimeVersion: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "platform" -------------
+
+	if paramValue := c.Query("platform"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument platform is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "platform", c.Request.URL.Query(), &params.Platform)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter platform: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "channel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel", c.Request.URL.Query(), &params.Channel)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "currentUpdateId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currentUpdateId", c.Request.URL.Query(), &params.CurrentUpdateID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter currentUpdateId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "packageHash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "packageHash", c.Request.URL.Query(), &params.PackageHash)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter packageHash: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "flavor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "flavor", c.Request.URL.Query(), &params.Flavor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter flavor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "clientId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "clientId", c.Request.URL.Query(), &params.ClientID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clientId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DebugUpdateCheck(c, params)
+}
+
+// HealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HealthCheck(c)
+}
+
+// GetExpoUpdate operation middleware
+func (siw *ServerInterfaceWrapper) GetExpoUpdate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID ProjectID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectID", c.Param("projectID"), &projectID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExpoUpdateParams
+
+	// ------------- Optional query parameter "platform" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "platform", c.Request.URL.Query(), &params.Platform)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter platform: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "runtime-version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "runtime-version", c.Request.URL.Query(), &params.RuntimeVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter runtime-version: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "current-update-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "current-update-id", c.Request.URL.Query(), &params.CurrentUpdateId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter current-update-id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "channel-name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel-name", c.Request.URL.Query(), &params.ChannelName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel-name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "flavor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "flavor", c.Request.URL.Query(), &params.Flavor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter flavor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "Expo-Expect-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Expect-Signature")]; found {
+		var ExpoExpectSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Expect-Signature, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Expect-Signature", valueList[0], &ExpoExpectSignature, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Expect-Signature: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ExpoExpectSignature = &ExpoExpectSignature
+
+	}
+
+	// ------------- Optional header parameter "Expo-Platform" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Platform")]; found {
+		var ExpoPlatform string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Platform, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Platform", valueList[0], &ExpoPlatform, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Platform: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ExpoPlatform = &ExpoPlatform
+
+	}
+
+	// ------------- Optional header parameter "Expo-Runtime-Version" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Runtime-Version")]; found {
+		var ExpoRuntimeVersion string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Runtime-Version, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Runtime-Version", valueList[0], &ExpoRuntimeVersion, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Runtime-Version: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ExpoRuntimeVersion = &ExpoRuntimeVersion
+
+	}
+
+	// ------------- Optional header parameter "Expo-Current-Update-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Current-Update-Id")]; found {
+		var ExpoCurrentUpdateId openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Current-Update-Id, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Current-Update-Id", valueList[0], &ExpoCurrentUpdateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Current-Update-Id: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ExpoCurrentUpdateId = &ExpoCurrentUpdateId
+
+	}
+
+	// ------------- Optional header parameter "EAS-Client-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("EAS-Client-ID")]; found {
+		var EASClientID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for EAS-Client-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "EAS-Client-ID", valueList[0], &EASClientID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter EAS-Client-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.EASClientID = &EASClientID
+
+	}
+
+	// ------------- Optional header parameter "Expo-Channel-Name" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Expo-Channel-Name")]; found {
+		var ExpoChannelName string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Expo-Channel-Name, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Expo-Channel-Name", valueList[0], &ExpoChannelName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Expo-Channel-Name: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.ExpoChannelName = &ExpoChannelName
+
+	}
+
+	// ------------- Optional header parameter "X-Pt-Network" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Pt-Network")]; found {
+		var Network Network
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-Pt-Network, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Pt-Network", valueList[0], &Network, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-Pt-Network: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Network = &Network
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetExpoUpdate(c, projectID, params)
+}
+
+// ReportCodePushLegacyDeployStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReportCodePushLegacyDeployStatus(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReportCodePushLegacyDeployStatus(c)
+}
+
+// ReportCodePushLegacyDownloadStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReportCodePushLegacyDownloadStatus(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReportCodePushLegacyDownloadStatus(c)
+}
+
+// GetCodePushLegacyUpdate operation middleware
+func (siw *ServerInterfaceWrapper) GetCodePushLegacyUpdate(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCodePushLegacyUpdateParams
+
+	// ------------- Required query parameter "appVersion" -------------
+
+	if paramValue := c.Query("appVersion"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument appVersion is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "appVersion", c.Request.URL.Query(), &params.AppVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appVersion: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "deploymentKey" -------------
+
+	if paramValue := c.Query("deploymentKey"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument deploymentKey is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "deploymentKey", c.Request.URL.Query(), &params.DeploymentKey)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deploymentKey: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "packageHash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "packageHash", c.Request.URL.Query(), &params.PackageHash)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter packageHash: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "isCompanion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "isCompanion", c.Request.URL.Query(), &params.IsCompanion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter isCompanion: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "clientUniqueId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "clientUniqueId", c.Request.URL.Query(), &params.ClientUniqueID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clientUniqueId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-Pt-Network" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Pt-Network")]; found {
+		var Network Network
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-Pt-Network, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Pt-Network", valueList[0], &Network, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-Pt-Network: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Network = &Network
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCodePushLegacyUpdate(c, params)
+}
+
+// ReportCodePushDeployStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReportCodePushDeployStatus(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReportCodePushDeployStatus(c)
+}
+
+// ReportCodePushDownloadStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReportCodePushDownloadStatus(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReportCodePushDownloadStatus(c)
+}
+
+// GetCodePushUpdate operation middleware
+func (siw *ServerInterfaceWrapper) GetCodePushUpdate(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCodePushUpdateParams
+
+	// ------------- Required query parameter "app_version" -------------
+
+	if paramValue := c.Query("app_version"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument app_version is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "app_version", c.Request.URL.Query(), &params.AppVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter app_version: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "deployment_key" -------------
+
+	if paramValue := c.Query("deployment_key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument deployment_key is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "deployment_key", c.Request.URL.Query(), &params.DeploymentKey)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deployment_key: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "package_hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "package_hash", c.Request.URL.Query(), &params.PackageHash)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter package_hash: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "is_companion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_companion", c.Request.URL.Query(), &params.IsCompanion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_companion: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "client_unique_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "client_unique_id", c.Request.URL.Query(), &params.ClientUniqueID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter client_unique_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-Pt-Network" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Pt-Network")]; found {
+		var Network Network
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-Pt-Network, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Pt-Network", valueList[0], &Network, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-Pt-Network: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Network = &Network
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCodePushUpdate(c, params)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/api/v1/admin/log-levels", wrapper.GetLogLevels)
+	router.PUT(options.BaseURL+"/api/v1/admin/log-levels", wrapper.SetLogLevels)
+	router.GET(options.BaseURL+"/api/v1/admin/maintenance", wrapper.GetMaintenanceMode)
+	router.PUT(options.BaseURL+"/api/v1/admin/maintenance", wrapper.SetMaintenanceMode)
+	router.POST(options.BaseURL+"/api/v1/admin/project", wrapper.CreateProject)
+	router.DELETE(options.BaseURL+"/api/v1/admin/project/:projectID", wrapper.DeleteProject)
+	router.GET(options.BaseURL+"/api/v1/admin/project/:projectID", wrapper.GetProjectByID)
+	router.PATCH(options.BaseURL+"/api/v1/admin/project/:projectID", wrapper.UpdateProject)
+	router.POST(options.BaseURL+"/api/v1/admin/project/:projectID/clone", wrapper.CloneProject)
+	router.GET(options.BaseURL+"/api/v1/admin/project/:projectID/environments", wrapper.GetProjectEnvironments)
+	router.POST(options.BaseURL+"/api/v1/admin/project/:projectID/environments", wrapper.CopyProjectToEnvironment)
+	router.DELETE(options.BaseURL+"/api/v1/admin/:projectID/channel-policies", wrapper.DeleteChannelPolicy)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/channel-policies", wrapper.GetChannelPolicies)
+	router.PUT(options.BaseURL+"/api/v1/admin/:projectID/channel-policies", wrapper.SetChannelPolicy)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/channels/:channel/rollback-to-embedded", wrapper.RollBackToEmbedded)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/embedded-updates", wrapper.GetEmbeddedUpdates)
+	router.PUT(options.BaseURL+"/api/v1/admin/:projectID/embedded-updates", wrapper.RegisterEmbeddedUpdate)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/flavors", wrapper.GetFlavors)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/flavors", wrapper.CreateFlavor)
+	router.DELETE(options.BaseURL+"/api/v1/admin/:projectID/flavors/:flavor", wrapper.DeleteFlavor)
+	router.DELETE(options.BaseURL+"/api/v1/admin/:projectID/pins", wrapper.UnpinChannel)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/pins", wrapper.GetChannelPins)
+	router.PUT(options.BaseURL+"/api/v1/admin/:projectID/pins", wrapper.PinChannel)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/signing-keys", wrapper.GetSigningKeys)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/signing-keys", wrapper.RotateSigningKey)
+	router.DELETE(options.BaseURL+"/api/v1/admin/:projectID/signing-keys/:keyID", wrapper.RetireSigningKey)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/stats/assets", wrapper.GetAssetDownloadStats)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/stats/codepush-releases", wrapper.GetCodePushReleaseStats)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/stats/integrity", wrapper.GetCorruptedAssets)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/stats/update-checks", wrapper.GetUpdateCheckEvents)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/stats/usage", wrapper.GetAPIUsageStats)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update", wrapper.PrepareUpdate)
+	router.DELETE(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID", wrapper.DeleteUpdate)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID", wrapper.GetUpdate)
+	router.PATCH(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID", wrapper.PatchUpdate)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/assets", wrapper.UploadUpdateAssets)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/chunks", wrapper.GetChunkedUploadStatus)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/chunks/complete", wrapper.CompleteChunkedUpload)
+	router.PUT(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/chunks/:chunkIndex", wrapper.UploadChunk)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/commit", wrapper.CommitUpdate)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/diff/:otherUpdateID", wrapper.DiffUpdates)
+	router.PUT(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/expiry", wrapper.SetUpdateExpiry)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/reports", wrapper.GetProcessingReports)
+	router.POST(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/rollback", wrapper.RollbackUpdate)
+	router.PATCH(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/rollout", wrapper.SetUpdateRollout)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/stats", wrapper.GetUpdateStats)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/update/:updateID/upload-status", wrapper.GetUploadStatus)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/updates", wrapper.GetUpdates)
+	router.GET(options.BaseURL+"/api/v1/admin/:projectID/updates/by-external-id/:externalID", wrapper.GetUpdateByExternalID)
+	router.GET(options.BaseURL+"/api/v1/debug/update-check", wrapper.DebugUpdateCheck)
+	router.GET(options.BaseURL+"/api/v1/health", wrapper.HealthCheck)
+	router.GET(options.BaseURL+"/api/v1/public/:projectID/expo", wrapper.GetExpoUpdate)
+	router.POST(options.BaseURL+"/reportStatus/deploy", wrapper.ReportCodePushLegacyDeployStatus)
+	router.POST(options.BaseURL+"/reportStatus/download", wrapper.ReportCodePushLegacyDownloadStatus)
+	router.GET(options.BaseURL+"/updateCheck", wrapper.GetCodePushLegacyUpdate)
+	router.POST(options.BaseURL+"/v0.1/public/codepush/report_status/deploy", wrapper.ReportCodePushDeployStatus)
+	router.POST(options.BaseURL+"/v0.1/public/codepush/report_status/download", wrapper.ReportCodePushDownloadStatus)
+	router.GET(options.BaseURL+"/v0.1/public/codepush/update_check", wrapper.GetCodePushUpdate)
+}
+
+type InternalServerErrorJSONResponse GenericError
+
+type ValidationErrorJSONResponse struct {
+	// Code Kind of the error, the same for every error response with the same cause, unlike the
+	// message
+	Code      *ErrorCode             `json:"code,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	Errors    []ValidationFieldError `json:"errors"`
+	Retryable bool                   `json:"retryable,omitempty"`
+}
+
+type GetLogLevelsRequestObject struct {
+}
+
+type GetLogLevelsResponseObject interface {
+	VisitGetLogLevelsResponse(w http.ResponseWriter) error
+}
+
+type GetLogLevels200JSONResponse LogLevels
+
+func (response GetLogLevels200JSONResponse) VisitGetLogLevelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevelsRequestObject struct {
+	Body *SetLogLevelsJSONRequestBody
+}
+
+type SetLogLevelsResponseObject interface {
+	VisitSetLogLevelsResponse(w http.ResponseWriter) error
+}
+
+type SetLogLevels200JSONResponse LogLevels
+
+func (response SetLogLevels200JSONResponse) VisitSetLogLevelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevels400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response SetLogLevels400JSONResponse) VisitSetLogLevelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenanceModeRequestObject struct {
+}
+
+type GetMaintenanceModeResponseObject interface {
+	VisitGetMaintenanceModeResponse(w http.ResponseWriter) error
+}
+
+type GetMaintenanceMode200JSONResponse MaintenanceMode
+
+func (response GetMaintenanceMode200JSONResponse) VisitGetMaintenanceModeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMaintenanceModeRequestObject struct {
+	Body *SetMaintenanceModeJSONRequestBody
+}
+
+type SetMaintenanceModeResponseObject interface {
+	VisitSetMaintenanceModeResponse(w http.ResponseWriter) error
+}
+
+type SetMaintenanceMode200JSONResponse MaintenanceMode
+
+func (response SetMaintenanceMode200JSONResponse) VisitSetMaintenanceModeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProjectRequestObject struct {
+	Body *CreateProjectJSONRequestBody
+}
+
+type CreateProjectResponseObject interface {
+	VisitCreateProjectResponse(w http.ResponseWriter) error
+}
+
+type CreateProject200JSONResponse Project
+
+func (response CreateProject200JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProject400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CreateProject400JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProject500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreateProject500JSONResponse) VisitCreateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProjectRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type DeleteProjectResponseObject interface {
+	VisitDeleteProjectResponse(w http.ResponseWriter) error
+}
+
+type DeleteProject202Response struct {
+}
+
+func (response DeleteProject202Response) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type DeleteProject400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DeleteProject400JSONResponse) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProject404Response struct {
+}
+
+func (response DeleteProject404Response) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteProject500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteProject500JSONResponse) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectByIDRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetProjectByIDResponseObject interface {
+	VisitGetProjectByIDResponse(w http.ResponseWriter) error
+}
+
+type GetProjectByID200JSONResponse Project
+
+func (response GetProjectByID200JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectByID400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetProjectByID400JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectByID404Response struct {
+}
+
+func (response GetProjectByID404Response) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetProjectByID500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetProjectByID500JSONResponse) VisitGetProjectByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProjectRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *UpdateProjectJSONRequestBody
+}
+
+type UpdateProjectResponseObject interface {
+	VisitUpdateProjectResponse(w http.ResponseWriter) error
+}
+
+type UpdateProject200JSONResponse Project
+
+func (response UpdateProject200JSONResponse) VisitUpdateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProject400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response UpdateProject400JSONResponse) VisitUpdateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProject404Response struct {
+}
+
+func (response UpdateProject404Response) VisitUpdateProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UpdateProject500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UpdateProject500JSONResponse) VisitUpdateProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneProjectRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *CloneProjectJSONRequestBody
+}
+
+type CloneProjectResponseObject interface {
+	VisitCloneProjectResponse(w http.ResponseWriter) error
+}
+
+type CloneProject200JSONResponse ProjectClone
+
+func (response CloneProject200JSONResponse) VisitCloneProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneProject400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CloneProject400JSONResponse) VisitCloneProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneProject404Response struct {
+}
+
+func (response CloneProject404Response) VisitCloneProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CloneProject500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CloneProject500JSONResponse) VisitCloneProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectEnvironmentsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetProjectEnvironmentsResponseObject interface {
+	VisitGetProjectEnvironmentsResponse(w http.ResponseWriter) error
+}
+
+type GetProjectEnvironments200JSONResponse []Project
+
+func (response GetProjectEnvironments200JSONResponse) VisitGetProjectEnvironmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectEnvironments404Response struct {
+}
+
+func (response GetProjectEnvironments404Response) VisitGetProjectEnvironmentsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetProjectEnvironments500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetProjectEnvironments500JSONResponse) VisitGetProjectEnvironmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CopyProjectToEnvironmentRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *CopyProjectToEnvironmentJSONRequestBody
+}
+
+type CopyProjectToEnvironmentResponseObject interface {
+	VisitCopyProjectToEnvironmentResponse(w http.ResponseWriter) error
+}
+
+type CopyProjectToEnvironment200JSONResponse Project
+
+func (response CopyProjectToEnvironment200JSONResponse) VisitCopyProjectToEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CopyProjectToEnvironment400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CopyProjectToEnvironment400JSONResponse) VisitCopyProjectToEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CopyProjectToEnvironment404Response struct {
+}
+
+func (response CopyProjectToEnvironment404Response) VisitCopyProjectToEnvironmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CopyProjectToEnvironment500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CopyProjectToEnvironment500JSONResponse) VisitCopyProjectToEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelPolicyRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    DeleteChannelPolicyParams
+}
+
+type DeleteChannelPolicyResponseObject interface {
+	VisitDeleteChannelPolicyResponse(w http.ResponseWriter) error
+}
+
+type DeleteChannelPolicy204Response struct {
+}
+
+func (response DeleteChannelPolicy204Response) VisitDeleteChannelPolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteChannelPolicy400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DeleteChannelPolicy400JSONResponse) VisitDeleteChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelPolicy404JSONResponse GenericError
+
+func (response DeleteChannelPolicy404JSONResponse) VisitDeleteChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteChannelPolicy500JSONResponse) VisitDeleteChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelPoliciesRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetChannelPoliciesResponseObject interface {
+	VisitGetChannelPoliciesResponse(w http.ResponseWriter) error
+}
+
+type GetChannelPolicies200JSONResponse []ChannelPolicy
+
+func (response GetChannelPolicies200JSONResponse) VisitGetChannelPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelPolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetChannelPolicies500JSONResponse) VisitGetChannelPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetChannelPolicyRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *SetChannelPolicyJSONRequestBody
+}
+
+type SetChannelPolicyResponseObject interface {
+	VisitSetChannelPolicyResponse(w http.ResponseWriter) error
+}
+
+type SetChannelPolicy200JSONResponse ChannelPolicy
+
+func (response SetChannelPolicy200JSONResponse) VisitSetChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetChannelPolicy400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response SetChannelPolicy400JSONResponse) VisitSetChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetChannelPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response SetChannelPolicy500JSONResponse) VisitSetChannelPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollBackToEmbeddedRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Channel   string    `json:"channel"`
+	Body      *RollBackToEmbeddedJSONRequestBody
+}
+
+type RollBackToEmbeddedResponseObject interface {
+	VisitRollBackToEmbeddedResponse(w http.ResponseWriter) error
+}
+
+type RollBackToEmbedded201JSONResponse ChannelRollback
+
+func (response RollBackToEmbedded201JSONResponse) VisitRollBackToEmbeddedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollBackToEmbedded400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response RollBackToEmbedded400JSONResponse) VisitRollBackToEmbeddedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollBackToEmbedded404JSONResponse GenericError
+
+func (response RollBackToEmbedded404JSONResponse) VisitRollBackToEmbeddedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollBackToEmbedded500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RollBackToEmbedded500JSONResponse) VisitRollBackToEmbeddedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmbeddedUpdatesRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetEmbeddedUpdatesResponseObject interface {
+	VisitGetEmbeddedUpdatesResponse(w http.ResponseWriter) error
+}
+
+type GetEmbeddedUpdates200JSONResponse []EmbeddedUpdate
+
+func (response GetEmbeddedUpdates200JSONResponse) VisitGetEmbeddedUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmbeddedUpdates400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetEmbeddedUpdates400JSONResponse) VisitGetEmbeddedUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmbeddedUpdates500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetEmbeddedUpdates500JSONResponse) VisitGetEmbeddedUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterEmbeddedUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *RegisterEmbeddedUpdateJSONRequestBody
+}
+
+type RegisterEmbeddedUpdateResponseObject interface {
+	VisitRegisterEmbeddedUpdateResponse(w http.ResponseWriter) error
+}
+
+type RegisterEmbeddedUpdate200JSONResponse EmbeddedUpdate
+
+func (response RegisterEmbeddedUpdate200JSONResponse) VisitRegisterEmbeddedUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterEmbeddedUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response RegisterEmbeddedUpdate400JSONResponse) VisitRegisterEmbeddedUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterEmbeddedUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RegisterEmbeddedUpdate500JSONResponse) VisitRegisterEmbeddedUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlavorsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetFlavorsResponseObject interface {
+	VisitGetFlavorsResponse(w http.ResponseWriter) error
+}
+
+type GetFlavors200JSONResponse []Flavor
+
+func (response GetFlavors200JSONResponse) VisitGetFlavorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlavors400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetFlavors400JSONResponse) VisitGetFlavorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlavors500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetFlavors500JSONResponse) VisitGetFlavorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlavorRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *CreateFlavorJSONRequestBody
+}
+
+type CreateFlavorResponseObject interface {
+	VisitCreateFlavorResponse(w http.ResponseWriter) error
+}
+
+type CreateFlavor201JSONResponse Flavor
+
+func (response CreateFlavor201JSONResponse) VisitCreateFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlavor400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CreateFlavor400JSONResponse) VisitCreateFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlavor500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreateFlavor500JSONResponse) VisitCreateFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlavorRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Flavor    string    `json:"flavor"`
+}
+
+type DeleteFlavorResponseObject interface {
+	VisitDeleteFlavorResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlavor204Response struct {
+}
+
+func (response DeleteFlavor204Response) VisitDeleteFlavorResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlavor400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DeleteFlavor400JSONResponse) VisitDeleteFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlavor404JSONResponse GenericError
+
+func (response DeleteFlavor404JSONResponse) VisitDeleteFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlavor500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteFlavor500JSONResponse) VisitDeleteFlavorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpinChannelRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    UnpinChannelParams
+}
+
+type UnpinChannelResponseObject interface {
+	VisitUnpinChannelResponse(w http.ResponseWriter) error
+}
+
+type UnpinChannel204Response struct {
+}
+
+func (response UnpinChannel204Response) VisitUnpinChannelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnpinChannel400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response UnpinChannel400JSONResponse) VisitUnpinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpinChannel404JSONResponse GenericError
+
+func (response UnpinChannel404JSONResponse) VisitUnpinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpinChannel500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UnpinChannel500JSONResponse) VisitUnpinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelPinsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetChannelPinsResponseObject interface {
+	VisitGetChannelPinsResponse(w http.ResponseWriter) error
+}
+
+type GetChannelPins200JSONResponse []ChannelPin
+
+func (response GetChannelPins200JSONResponse) VisitGetChannelPinsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelPins400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetChannelPins400JSONResponse) VisitGetChannelPinsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelPins500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetChannelPins500JSONResponse) VisitGetChannelPinsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PinChannelRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *PinChannelJSONRequestBody
+}
+
+type PinChannelResponseObject interface {
+	VisitPinChannelResponse(w http.ResponseWriter) error
+}
+
+type PinChannel200JSONResponse ChannelPin
+
+func (response PinChannel200JSONResponse) VisitPinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PinChannel400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response PinChannel400JSONResponse) VisitPinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PinChannel500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PinChannel500JSONResponse) VisitPinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeysRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetSigningKeysResponseObject interface {
+	VisitGetSigningKeysResponse(w http.ResponseWriter) error
+}
+
+type GetSigningKeys200JSONResponse []SigningKey
+
+func (response GetSigningKeys200JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetSigningKeys400JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKeys500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetSigningKeys500JSONResponse) VisitGetSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateSigningKeyRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *RotateSigningKeyJSONRequestBody
+}
+
+type RotateSigningKeyResponseObject interface {
+	VisitRotateSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type RotateSigningKey200JSONResponse SigningKey
+
+func (response RotateSigningKey200JSONResponse) VisitRotateSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateSigningKey400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response RotateSigningKey400JSONResponse) VisitRotateSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateSigningKey500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RotateSigningKey500JSONResponse) VisitRotateSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetireSigningKeyRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	KeyID     string    `json:"keyID"`
+}
+
+type RetireSigningKeyResponseObject interface {
+	VisitRetireSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type RetireSigningKey204Response struct {
+}
+
+func (response RetireSigningKey204Response) VisitRetireSigningKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RetireSigningKey400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response RetireSigningKey400JSONResponse) VisitRetireSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetireSigningKey404JSONResponse GenericError
+
+func (response RetireSigningKey404JSONResponse) VisitRetireSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetireSigningKey500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RetireSigningKey500JSONResponse) VisitRetireSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAssetDownloadStatsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetAssetDownloadStatsParams
+}
+
+type GetAssetDownloadStatsResponseObject interface {
+	VisitGetAssetDownloadStatsResponse(w http.ResponseWriter) error
+}
+
+type GetAssetDownloadStats200JSONResponse []AssetDownloadStats
+
+func (response GetAssetDownloadStats200JSONResponse) VisitGetAssetDownloadStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAssetDownloadStats400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetAssetDownloadStats400JSONResponse) VisitGetAssetDownloadStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAssetDownloadStats500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetAssetDownloadStats500JSONResponse) VisitGetAssetDownloadStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushReleaseStatsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetCodePushReleaseStatsParams
+}
+
+type GetCodePushReleaseStatsResponseObject interface {
+	VisitGetCodePushReleaseStatsResponse(w http.ResponseWriter) error
+}
+
+type GetCodePushReleaseStats200JSONResponse []CodePushReleaseStats
+
+func (response GetCodePushReleaseStats200JSONResponse) VisitGetCodePushReleaseStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushReleaseStats400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetCodePushReleaseStats400JSONResponse) VisitGetCodePushReleaseStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushReleaseStats500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetCodePushReleaseStats500JSONResponse) VisitGetCodePushReleaseStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCorruptedAssetsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+}
+
+type GetCorruptedAssetsResponseObject interface {
+	VisitGetCorruptedAssetsResponse(w http.ResponseWriter) error
+}
+
+type GetCorruptedAssets200JSONResponse []AssetIntegrityCheck
+
+func (response GetCorruptedAssets200JSONResponse) VisitGetCorruptedAssetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCorruptedAssets500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetCorruptedAssets500JSONResponse) VisitGetCorruptedAssetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateCheckEventsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetUpdateCheckEventsParams
+}
+
+type GetUpdateCheckEventsResponseObject interface {
+	VisitGetUpdateCheckEventsResponse(w http.ResponseWriter) error
+}
+
+type GetUpdateCheckEvents200JSONResponse []UpdateCheckEvent
+
+func (response GetUpdateCheckEvents200JSONResponse) VisitGetUpdateCheckEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateCheckEvents400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetUpdateCheckEvents400JSONResponse) VisitGetUpdateCheckEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateCheckEvents500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetUpdateCheckEvents500JSONResponse) VisitGetUpdateCheckEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAPIUsageStatsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetAPIUsageStatsParams
+}
+
+type GetAPIUsageStatsResponseObject interface {
+	VisitGetAPIUsageStatsResponse(w http.ResponseWriter) error
+}
+
+type GetAPIUsageStats200JSONResponse []APIUsageStats
+
+func (response GetAPIUsageStats200JSONResponse) VisitGetAPIUsageStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAPIUsageStats400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetAPIUsageStats400JSONResponse) VisitGetAPIUsageStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAPIUsageStats500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetAPIUsageStats500JSONResponse) VisitGetAPIUsageStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrepareUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Body      *PrepareUpdateJSONRequestBody
+}
+
+type PrepareUpdateResponseObject interface {
+	VisitPrepareUpdateResponse(w http.ResponseWriter) error
+}
+
+type PrepareUpdate201JSONResponse PrepareUpdateResponse
+
+func (response PrepareUpdate201JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrepareUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response PrepareUpdate400JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrepareUpdate403JSONResponse QuotaExceededError
+
+func (response PrepareUpdate403JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrepareUpdate409Response struct {
+}
+
+func (response PrepareUpdate409Response) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PrepareUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PrepareUpdate500JSONResponse) VisitPrepareUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type DeleteUpdateResponseObject interface {
+	VisitDeleteUpdateResponse(w http.ResponseWriter) error
+}
+
+type DeleteUpdate204Response struct {
+}
+
+func (response DeleteUpdate204Response) VisitDeleteUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DeleteUpdate400JSONResponse) VisitDeleteUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUpdate404Response struct {
+}
+
+func (response DeleteUpdate404Response) VisitDeleteUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteUpdate409Response struct {
+}
+
+func (response DeleteUpdate409Response) VisitDeleteUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type DeleteUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteUpdate500JSONResponse) VisitDeleteUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type GetUpdateResponseObject interface {
+	VisitGetUpdateResponse(w http.ResponseWriter) error
+}
+
+type GetUpdate200JSONResponse Update
+
+func (response GetUpdate200JSONResponse) VisitGetUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetUpdate400JSONResponse) VisitGetUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdate404Response struct {
+}
+
+func (response GetUpdate404Response) VisitGetUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PatchUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Body      *PatchUpdateJSONRequestBody
+}
+
+type PatchUpdateResponseObject interface {
+	VisitPatchUpdateResponse(w http.ResponseWriter) error
+}
+
+type PatchUpdate200JSONResponse Update
+
+func (response PatchUpdate200JSONResponse) VisitPatchUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response PatchUpdate400JSONResponse) VisitPatchUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUpdate404Response struct {
+}
+
+func (response PatchUpdate404Response) VisitPatchUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PatchUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PatchUpdate500JSONResponse) VisitPatchUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadUpdateAssetsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Body      *multipart.Reader
+}
+
+type UploadUpdateAssetsResponseObject interface {
+	VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error
+}
+
+type UploadUpdateAssets200JSONResponse struct {
+	UploadedFiles []string `json:"uploadedFiles"`
+}
+
+func (response UploadUpdateAssets200JSONResponse) VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadUpdateAssets400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response UploadUpdateAssets400JSONResponse) VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadUpdateAssets404Response struct {
+}
+
+func (response UploadUpdateAssets404Response) VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UploadUpdateAssets409Response struct {
+}
+
+func (response UploadUpdateAssets409Response) VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type UploadUpdateAssets500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UploadUpdateAssets500JSONResponse) VisitUploadUpdateAssetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChunkedUploadStatusRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Params    GetChunkedUploadStatusParams
+}
+
+type GetChunkedUploadStatusResponseObject interface {
+	VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error
+}
+
+type GetChunkedUploadStatus200JSONResponse ChunkedUploadStatus
+
+func (response GetChunkedUploadStatus200JSONResponse) VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChunkedUploadStatus400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetChunkedUploadStatus400JSONResponse) VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChunkedUploadStatus404Response struct {
+}
+
+func (response GetChunkedUploadStatus404Response) VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetChunkedUploadStatus409Response struct {
+}
+
+func (response GetChunkedUploadStatus409Response) VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetChunkedUploadStatus500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetChunkedUploadStatus500JSONResponse) VisitGetChunkedUploadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteChunkedUploadRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Params    CompleteChunkedUploadParams
+}
+
+type CompleteChunkedUploadResponseObject interface {
+	VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error
+}
+
+type CompleteChunkedUpload204Response struct {
+}
+
+func (response CompleteChunkedUpload204Response) VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CompleteChunkedUpload400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CompleteChunkedUpload400JSONResponse) VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteChunkedUpload404Response struct {
+}
+
+func (response CompleteChunkedUpload404Response) VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CompleteChunkedUpload409Response struct {
+}
+
+func (response CompleteChunkedUpload409Response) VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type CompleteChunkedUpload500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CompleteChunkedUpload500JSONResponse) VisitCompleteChunkedUploadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadChunkRequestObject struct {
+	ProjectID  ProjectID `json:"projectID"`
+	UpdateID   UpdateID  `json:"updateID"`
+	ChunkIndex int       `json:"chunkIndex"`
+	Params     UploadChunkParams
+	Body       io.Reader
+}
+
+type UploadChunkResponseObject interface {
+	VisitUploadChunkResponse(w http.ResponseWriter) error
+}
+
+type UploadChunk204Response struct {
+}
+
+func (response UploadChunk204Response) VisitUploadChunkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UploadChunk400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response UploadChunk400JSONResponse) VisitUploadChunkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadChunk404Response struct {
+}
+
+func (response UploadChunk404Response) VisitUploadChunkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UploadChunk409Response struct {
+}
+
+func (response UploadChunk409Response) VisitUploadChunkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type UploadChunk500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UploadChunk500JSONResponse) VisitUploadChunkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CommitUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type CommitUpdateResponseObject interface {
+	VisitCommitUpdateResponse(w http.ResponseWriter) error
+}
+
+type CommitUpdate204Response struct {
+}
+
+func (response CommitUpdate204Response) VisitCommitUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CommitUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CommitUpdate400JSONResponse) VisitCommitUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CommitUpdate409JSONResponse UpdateFilesMismatch
+
+func (response CommitUpdate409JSONResponse) VisitCommitUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CommitUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CommitUpdate500JSONResponse) VisitCommitUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffUpdatesRequestObject struct {
+	ProjectID     ProjectID          `json:"projectID"`
+	UpdateID      UpdateID           `json:"updateID"`
+	OtherUpdateID openapi_types.UUID `json:"otherUpdateID"`
+}
+
+type DiffUpdatesResponseObject interface {
+	VisitDiffUpdatesResponse(w http.ResponseWriter) error
+}
+
+type DiffUpdates200JSONResponse UpdateDiff
+
+func (response DiffUpdates200JSONResponse) VisitDiffUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffUpdates400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DiffUpdates400JSONResponse) VisitDiffUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffUpdates404JSONResponse GenericError
+
+func (response DiffUpdates404JSONResponse) VisitDiffUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffUpdates500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DiffUpdates500JSONResponse) VisitDiffUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateExpiryRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Body      *SetUpdateExpiryJSONRequestBody
+}
+
+type SetUpdateExpiryResponseObject interface {
+	VisitSetUpdateExpiryResponse(w http.ResponseWriter) error
+}
+
+type SetUpdateExpiry200JSONResponse Update
+
+func (response SetUpdateExpiry200JSONResponse) VisitSetUpdateExpiryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateExpiry400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response SetUpdateExpiry400JSONResponse) VisitSetUpdateExpiryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateExpiry404Response struct {
+}
+
+func (response SetUpdateExpiry404Response) VisitSetUpdateExpiryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type SetUpdateExpiry500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response SetUpdateExpiry500JSONResponse) VisitSetUpdateExpiryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProcessingReportsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type GetProcessingReportsResponseObject interface {
+	VisitGetProcessingReportsResponse(w http.ResponseWriter) error
+}
+
+type GetProcessingReports200JSONResponse []ProcessingReport
+
+func (response GetProcessingReports200JSONResponse) VisitGetProcessingReportsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProcessingReports404Response struct {
+}
+
+func (response GetProcessingReports404Response) VisitGetProcessingReportsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetProcessingReports500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetProcessingReports500JSONResponse) VisitGetProcessingReportsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollbackUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type RollbackUpdateResponseObject interface {
+	VisitRollbackUpdateResponse(w http.ResponseWriter) error
+}
+
+type RollbackUpdate204Response struct {
+}
+
+func (response RollbackUpdate204Response) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RollbackUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response RollbackUpdate400JSONResponse) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RollbackUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RollbackUpdate500JSONResponse) VisitRollbackUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateRolloutRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+	Body      *SetUpdateRolloutJSONRequestBody
+}
+
+type SetUpdateRolloutResponseObject interface {
+	VisitSetUpdateRolloutResponse(w http.ResponseWriter) error
+}
+
+type SetUpdateRollout200JSONResponse Update
+
+func (response SetUpdateRollout200JSONResponse) VisitSetUpdateRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateRollout400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response SetUpdateRollout400JSONResponse) VisitSetUpdateRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUpdateRollout404Response struct {
+}
+
+func (response SetUpdateRollout404Response) VisitSetUpdateRolloutResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type SetUpdateRollout500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response SetUpdateRollout500JSONResponse) VisitSetUpdateRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateStatsRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type GetUpdateStatsResponseObject interface {
+	VisitGetUpdateStatsResponse(w http.ResponseWriter) error
+}
+
+type GetUpdateStats200JSONResponse UpdateStats
+
+func (response GetUpdateStats200JSONResponse) VisitGetUpdateStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateStats404Response struct {
+}
+
+func (response GetUpdateStats404Response) VisitGetUpdateStatsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetUpdateStats500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetUpdateStats500JSONResponse) VisitGetUpdateStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUploadStatusRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	UpdateID  UpdateID  `json:"updateID"`
+}
+
+type GetUploadStatusResponseObject interface {
+	VisitGetUploadStatusResponse(w http.ResponseWriter) error
+}
+
+type GetUploadStatus200JSONResponse UpdateUploadStatus
+
+func (response GetUploadStatus200JSONResponse) VisitGetUploadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUploadStatus404Response struct {
+}
+
+func (response GetUploadStatus404Response) VisitGetUploadStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetUploadStatus500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetUploadStatus500JSONResponse) VisitGetUploadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdatesRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetUpdatesParams
+}
+
+type GetUpdatesResponseObject interface {
+	VisitGetUpdatesResponse(w http.ResponseWriter) error
+}
+
+type GetUpdates200JSONResponse GetUpdatesResponse
+
+func (response GetUpdates200JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdates400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetUpdates400JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdates500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetUpdates500JSONResponse) VisitGetUpdatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateByExternalIDRequestObject struct {
+	ProjectID  ProjectID `json:"projectID"`
+	ExternalID string    `json:"externalID"`
+}
+
+type GetUpdateByExternalIDResponseObject interface {
+	VisitGetUpdateByExternalIDResponse(w http.ResponseWriter) error
+}
+
+type GetUpdateByExternalID200JSONResponse Update
+
+func (response GetUpdateByExternalID200JSONResponse) VisitGetUpdateByExternalIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateByExternalID400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetUpdateByExternalID400JSONResponse) VisitGetUpdateByExternalIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUpdateByExternalID404Response struct {
+}
+
+func (response GetUpdateByExternalID404Response) VisitGetUpdateByExternalIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetUpdateByExternalID500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetUpdateByExternalID500JSONResponse) VisitGetUpdateByExternalIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DebugUpdateCheckRequestObject struct {
+	Params DebugUpdateCheckParams
+}
+
+type DebugUpdateCheckResponseObject interface {
+	VisitDebugUpdateCheckResponse(w http.ResponseWriter) error
+}
+
+type DebugUpdateCheck200JSONResponse UpdateCheckTrace
+
+func (response DebugUpdateCheck200JSONResponse) VisitDebugUpdateCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DebugUpdateCheck400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response DebugUpdateCheck400JSONResponse) VisitDebugUpdateCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DebugUpdateCheck401Response struct {
+}
+
+func (response DebugUpdateCheck401Response) VisitDebugUpdateCheckResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DebugUpdateCheck404Response struct {
+}
+
+func (response DebugUpdateCheck404Response) VisitDebugUpdateCheckResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DebugUpdateCheck500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DebugUpdateCheck500JSONResponse) VisitDebugUpdateCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthCheckRequestObject struct {
+}
+
+type HealthCheckResponseObject interface {
+	VisitHealthCheckResponse(w http.ResponseWriter) error
+}
+
+type HealthCheck200JSONResponse struct {
+	// Cache ok, or fallback while the in-memory cache is used instead of Redis
+	Cache *string `json:"cache,omitempty"`
+
+	// CacheFallbacks Cache operations served by the in-memory fallback since the start
+	CacheFallbacks *int64 `json:"cacheFallbacks,omitempty"`
+
+	// CorruptedAssets Number of assets that failed integrity verification
+	CorruptedAssets *int64 `json:"corruptedAssets,omitempty"`
+
+	// Queue ok or unavailable, commits are accepted and update checks are served while the queue is unavailable
+	Queue *string `json:"queue,omitempty"`
+
+	// Status ok, or degraded when the queue or the cache is unreachable
+	Status string `json:"status"`
+}
+
+func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExpoUpdateRequestObject struct {
+	ProjectID ProjectID `json:"projectID"`
+	Params    GetExpoUpdateParams
+}
+
+type GetExpoUpdateResponseObject interface {
+	VisitGetExpoUpdateResponse(w http.ResponseWriter) error
+}
+
+type GetExpoUpdate200ResponseHeaders struct {
+	CacheControl        string
+	ETag                string
+	ExpoProtocolVersion string
+	ExpoSfvVersion      string
+}
+
+type GetExpoUpdate200MultipartResponse struct {
+	Body    func(writer *multipart.Writer) error
+	Headers GetExpoUpdate200ResponseHeaders
+}
+
+func (response GetExpoUpdate200MultipartResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
+	writer := multipart.NewWriter(w)
+	w.Header().Set("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": writer.Boundary()}))
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Expo-Protocol-Version", fmt.Sprint(response.Headers.ExpoProtocolVersion))
+	w.Header().Set("Expo-Sfv-Version", fmt.Sprint(response.Headers.ExpoSfvVersion))
+	w.WriteHeader(200)
+
+	defer writer.Close()
+	return response.Body(writer)
+}
+
+type GetExpoUpdate304ResponseHeaders struct {
+	ETag string
+}
+
+type GetExpoUpdate304Response struct {
+	Headers GetExpoUpdate304ResponseHeaders
+}
+
+func (response GetExpoUpdate304Response) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetExpoUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetExpoUpdate400JSONResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExpoUpdate500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetExpoUpdate500JSONResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportCodePushLegacyDeployStatusRequestObject struct {
+	Body *ReportCodePushLegacyDeployStatusJSONRequestBody
+}
+
+type ReportCodePushLegacyDeployStatusResponseObject interface {
+	VisitReportCodePushLegacyDeployStatusResponse(w http.ResponseWriter) error
+}
+
+type ReportCodePushLegacyDeployStatus200Response struct {
+}
+
+func (response ReportCodePushLegacyDeployStatus200Response) VisitReportCodePushLegacyDeployStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ReportCodePushLegacyDeployStatus400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response ReportCodePushLegacyDeployStatus400JSONResponse) VisitReportCodePushLegacyDeployStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportCodePushLegacyDownloadStatusRequestObject struct {
+	Body *ReportCodePushLegacyDownloadStatusJSONRequestBody
+}
+
+type ReportCodePushLegacyDownloadStatusResponseObject interface {
+	VisitReportCodePushLegacyDownloadStatusResponse(w http.ResponseWriter) error
+}
+
+type ReportCodePushLegacyDownloadStatus200Response struct {
+}
+
+func (response ReportCodePushLegacyDownloadStatus200Response) VisitReportCodePushLegacyDownloadStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ReportCodePushLegacyDownloadStatus400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response ReportCodePushLegacyDownloadStatus400JSONResponse) VisitReportCodePushLegacyDownloadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushLegacyUpdateRequestObject struct {
+	Params GetCodePushLegacyUpdateParams
+}
+
+type GetCodePushLegacyUpdateResponseObject interface {
+	VisitGetCodePushLegacyUpdateResponse(w http.ResponseWriter) error
+}
+
+type GetCodePushLegacyUpdate200JSONResponse struct {
+	// UpdateInfo CodePushUpdate with the camelCase fields of the standalone CodePush server
+	UpdateInfo CodePushLegacyUpdate `json:"updateInfo"`
+}
+
+func (response GetCodePushLegacyUpdate200JSONResponse) VisitGetCodePushLegacyUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushLegacyUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetCodePushLegacyUpdate400JSONResponse) VisitGetCodePushLegacyUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportCodePushDeployStatusRequestObject struct {
+	Body *ReportCodePushDeployStatusJSONRequestBody
+}
+
+type ReportCodePushDeployStatusResponseObject interface {
+	VisitReportCodePushDeployStatusResponse(w http.ResponseWriter) error
+}
+
+type ReportCodePushDeployStatus200Response struct {
+}
+
+func (response ReportCodePushDeployStatus200Response) VisitReportCodePushDeployStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ReportCodePushDeployStatus400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response ReportCodePushDeployStatus400JSONResponse) VisitReportCodePushDeployStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportCodePushDownloadStatusRequestObject struct {
+	Body *ReportCodePushDownloadStatusJSONRequestBody
+}
+
+type ReportCodePushDownloadStatusResponseObject interface {
+	VisitReportCodePushDownloadStatusResponse(w http.ResponseWriter) error
+}
+
+type ReportCodePushDownloadStatus200Response struct {
+}
+
+func (response ReportCodePushDownloadStatus200Response) VisitReportCodePushDownloadStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ReportCodePushDownloadStatus400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response ReportCodePushDownloadStatus400JSONResponse) VisitReportCodePushDownloadStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushUpdateRequestObject struct {
+	Params GetCodePushUpdateParams
+}
+
+type GetCodePushUpdateResponseObject interface {
+	VisitGetCodePushUpdateResponse(w http.ResponseWriter) error
+}
+
+type GetCodePushUpdate200JSONResponse struct {
+	UpdateInfo CodePushUpdate `json:"update_info"`
+}
+
+func (response GetCodePushUpdate200JSONResponse) VisitGetCodePushUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCodePushUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response GetCodePushUpdate400JSONResponse) VisitGetCodePushUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get log levels of the server components
+	// (GET /api/v1/admin/log-levels)
+	GetLogLevels(ctx context.Context, request GetLogLevelsRequestObject) (GetLogLevelsResponseObject, error)
+	// Change log levels of the server components
+	// (PUT /api/v1/admin/log-levels)
+	SetLogLevels(ctx context.Context, request SetLogLevelsRequestObject) (SetLogLevelsResponseObject, error)
+	// Get the maintenance mode of the server
+	// (GET /api/v1/admin/maintenance)
+	GetMaintenanceMode(ctx context.Context, request GetMaintenanceModeRequestObject) (GetMaintenanceModeResponseObject, error)
+	// Enable or disable the maintenance mode of every server
+	// (PUT /api/v1/admin/maintenance)
+	SetMaintenanceMode(ctx context.Context, request SetMaintenanceModeRequestObject) (SetMaintenanceModeResponseObject, error)
+	// Create a project
+	// (POST /api/v1/admin/project)
+	CreateProject(ctx context.Context, request CreateProjectRequestObject) (CreateProjectResponseObject, error)
+	// Delete the project
+	// (DELETE /api/v1/admin/project/{projectID})
+	DeleteProject(ctx context.Context, request DeleteProjectRequestObject) (DeleteProjectResponseObject, error)
+	// Get project by id
+	// (GET /api/v1/admin/project/{projectID})
+	GetProjectByID(ctx context.Context, request GetProjectByIDRequestObject) (GetProjectByIDResponseObject, error)
+	// Update project settings
+	// (PATCH /api/v1/admin/project/{projectID})
+	UpdateProject(ctx context.Context, request UpdateProjectRequestObject) (UpdateProjectResponseObject, error)
+	// Clone the project
+	// (POST /api/v1/admin/project/{projectID}/clone)
+	CloneProject(ctx context.Context, request CloneProjectRequestObject) (CloneProjectResponseObject, error)
+	// Get the project in all environments
+	// (GET /api/v1/admin/project/{projectID}/environments)
+	GetProjectEnvironments(ctx context.Context, request GetProjectEnvironmentsRequestObject) (GetProjectEnvironmentsResponseObject, error)
+	// Copy the project configuration to another environment
+	// (POST /api/v1/admin/project/{projectID}/environments)
+	CopyProjectToEnvironment(ctx context.Context, request CopyProjectToEnvironmentRequestObject) (CopyProjectToEnvironmentResponseObject, error)
+	// Remove the policy of a channel
+	// (DELETE /api/v1/admin/{projectID}/channel-policies)
+	DeleteChannelPolicy(ctx context.Context, request DeleteChannelPolicyRequestObject) (DeleteChannelPolicyResponseObject, error)
+	// List channel policies
+	// (GET /api/v1/admin/{projectID}/channel-policies)
+	GetChannelPolicies(ctx context.Context, request GetChannelPoliciesRequestObject) (GetChannelPoliciesResponseObject, error)
+	// Set the policy of a channel
+	// (PUT /api/v1/admin/{projectID}/channel-policies)
+	SetChannelPolicy(ctx context.Context, request SetChannelPolicyRequestObject) (SetChannelPolicyResponseObject, error)
+	// Roll back a channel to the embedded update
+	// (POST /api/v1/admin/{projectID}/channels/{channel}/rollback-to-embedded)
+	RollBackToEmbedded(ctx context.Context, request RollBackToEmbeddedRequestObject) (RollBackToEmbeddedResponseObject, error)
+	// List embedded updates
+	// (GET /api/v1/admin/{projectID}/embedded-updates)
+	GetEmbeddedUpdates(ctx context.Context, request GetEmbeddedUpdatesRequestObject) (GetEmbeddedUpdatesResponseObject, error)
+	// Register an embedded update
+	// (PUT /api/v1/admin/{projectID}/embedded-updates)
+	RegisterEmbeddedUpdate(ctx context.Context, request RegisterEmbeddedUpdateRequestObject) (RegisterEmbeddedUpdateResponseObject, error)
+	// List flavors
+	// (GET /api/v1/admin/{projectID}/flavors)
+	GetFlavors(ctx context.Context, request GetFlavorsRequestObject) (GetFlavorsResponseObject, error)
+	// Create a flavor
+	// (POST /api/v1/admin/{projectID}/flavors)
+	CreateFlavor(ctx context.Context, request CreateFlavorRequestObject) (CreateFlavorResponseObject, error)
+	// Delete a flavor
+	// (DELETE /api/v1/admin/{projectID}/flavors/{flavor})
+	DeleteFlavor(ctx context.Context, request DeleteFlavorRequestObject) (DeleteFlavorResponseObject, error)
+	// Remove a channel pin
+	// (DELETE /api/v1/admin/{projectID}/pins)
+	UnpinChannel(ctx context.Context, request UnpinChannelRequestObject) (UnpinChannelResponseObject, error)
+	// List channel pins
+	// (GET /api/v1/admin/{projectID}/pins)
+	GetChannelPins(ctx context.Context, request GetChannelPinsRequestObject) (GetChannelPinsResponseObject, error)
+	// Pin a channel to an update
+	// (PUT /api/v1/admin/{projectID}/pins)
+	PinChannel(ctx context.Context, request PinChannelRequestObject) (PinChannelResponseObject, error)
+	// List signing keys
+	// (GET /api/v1/admin/{projectID}/signing-keys)
+	GetSigningKeys(ctx context.Context, request GetSigningKeysRequestObject) (GetSigningKeysResponseObject, error)
+	// Rotate the signing key
+	// (POST /api/v1/admin/{projectID}/signing-keys)
+	RotateSigningKey(ctx context.Context, request RotateSigningKeyRequestObject) (RotateSigningKeyResponseObject, error)
+	// Retire a signing key immediately
+	// (DELETE /api/v1/admin/{projectID}/signing-keys/{keyID})
+	RetireSigningKey(ctx context.Context, request RetireSigningKeyRequestObject) (RetireSigningKeyResponseObject, error)
+	// Asset download statistics
+	// (GET /api/v1/admin/{projectID}/stats/assets)
+	GetAssetDownloadStats(ctx context.Context, request GetAssetDownloadStatsRequestObject) (GetAssetDownloadStatsResponseObject, error)
+	// CodePush release statistics
+	// (GET /api/v1/admin/{projectID}/stats/codepush-releases)
+	GetCodePushReleaseStats(ctx context.Context, request GetCodePushReleaseStatsRequestObject) (GetCodePushReleaseStatsResponseObject, error)
+	// Assets that failed integrity verification
+	// (GET /api/v1/admin/{projectID}/stats/integrity)
+	GetCorruptedAssets(ctx context.Context, request GetCorruptedAssetsRequestObject) (GetCorruptedAssetsResponseObject, error)
+	// Recorded update checks
+	// (GET /api/v1/admin/{projectID}/stats/update-checks)
+	GetUpdateCheckEvents(ctx context.Context, request GetUpdateCheckEventsRequestObject) (GetUpdateCheckEventsResponseObject, error)
+	// API usage statistics
+	// (GET /api/v1/admin/{projectID}/stats/usage)
+	GetAPIUsageStats(ctx context.Context, request GetAPIUsageStatsRequestObject) (GetAPIUsageStatsResponseObject, error)
+	// Prepare a new update
+	// (POST /api/v1/admin/{projectID}/update)
+	PrepareUpdate(ctx context.Context, request PrepareUpdateRequestObject) (PrepareUpdateResponseObject, error)
+	// Delete update
+	// (DELETE /api/v1/admin/{projectID}/update/{updateID})
+	DeleteUpdate(ctx context.Context, request DeleteUpdateRequestObject) (DeleteUpdateResponseObject, error)
+	// Get update
+	// (GET /api/v1/admin/{projectID}/update/{updateID})
+	GetUpdate(ctx context.Context, request GetUpdateRequestObject) (GetUpdateResponseObject, error)
+	// Change the properties of an update
+	// (PATCH /api/v1/admin/{projectID}/update/{updateID})
+	PatchUpdate(ctx context.Context, request PatchUpdateRequestObject) (PatchUpdateResponseObject, error)
+	// Upload update files through the API
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/assets)
+	UploadUpdateAssets(ctx context.Context, request UploadUpdateAssetsRequestObject) (UploadUpdateAssetsResponseObject, error)
+	// Get chunked upload status of a file
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/chunks)
+	GetChunkedUploadStatus(ctx context.Context, request GetChunkedUploadStatusRequestObject) (GetChunkedUploadStatusResponseObject, error)
+	// Assemble uploaded chunks of a file
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/chunks/complete)
+	CompleteChunkedUpload(ctx context.Context, request CompleteChunkedUploadRequestObject) (CompleteChunkedUploadResponseObject, error)
+	// Upload a chunk of a file
+	// (PUT /api/v1/admin/{projectID}/update/{updateID}/chunks/{chunkIndex})
+	UploadChunk(ctx context.Context, request UploadChunkRequestObject) (UploadChunkResponseObject, error)
+	// Commit update
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/commit)
+	CommitUpdate(ctx context.Context, request CommitUpdateRequestObject) (CommitUpdateResponseObject, error)
+	// Compare files of two updates
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/diff/{otherUpdateID})
+	DiffUpdates(ctx context.Context, request DiffUpdatesRequestObject) (DiffUpdatesResponseObject, error)
+	// Set the expiry of an update
+	// (PUT /api/v1/admin/{projectID}/update/{updateID}/expiry)
+	SetUpdateExpiry(ctx context.Context, request SetUpdateExpiryRequestObject) (SetUpdateExpiryResponseObject, error)
+	// Get reports of the processing runs of an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/reports)
+	GetProcessingReports(ctx context.Context, request GetProcessingReportsRequestObject) (GetProcessingReportsResponseObject, error)
+	// Rollback an update
+	// (POST /api/v1/admin/{projectID}/update/{updateID}/rollback)
+	RollbackUpdate(ctx context.Context, request RollbackUpdateRequestObject) (RollbackUpdateResponseObject, error)
+	// Change the rollout percentage of an update
+	// (PATCH /api/v1/admin/{projectID}/update/{updateID}/rollout)
+	SetUpdateRollout(ctx context.Context, request SetUpdateRolloutRequestObject) (SetUpdateRolloutResponseObject, error)
+	// Adoption statistics of an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/stats)
+	GetUpdateStats(ctx context.Context, request GetUpdateStatsRequestObject) (GetUpdateStatsResponseObject, error)
+	// Get upload progress of the files declared for an update
+	// (GET /api/v1/admin/{projectID}/update/{updateID}/upload-status)
+	GetUploadStatus(ctx context.Context, request GetUploadStatusRequestObject) (GetUploadStatusResponseObject, error)
+	// Get all updates
+	// (GET /api/v1/admin/{projectID}/updates)
+	GetUpdates(ctx context.Context, request GetUpdatesRequestObject) (GetUpdatesResponseObject, error)
+	// Get update by its external ID
+	// (GET /api/v1/admin/{projectID}/updates/by-external-id/{externalID})
+	GetUpdateByExternalID(ctx context.Context, request GetUpdateByExternalIDRequestObject) (GetUpdateByExternalIDResponseObject, error)
+	// Explain the result of an update check
+	// (GET /api/v1/debug/update-check)
+	DebugUpdateCheck(ctx context.Context, request DebugUpdateCheckRequestObject) (DebugUpdateCheckResponseObject, error)
+	// Health check
+	// (GET /api/v1/health)
+	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
+	// Get Expo update
+	// (GET /api/v1/public/{projectID}/expo)
+	GetExpoUpdate(ctx context.Context, request GetExpoUpdateRequestObject) (GetExpoUpdateResponseObject, error)
+	// Report CodePush install status, legacy path of the standalone CodePush server
+	// (POST /reportStatus/deploy)
+	ReportCodePushLegacyDeployStatus(ctx context.Context, request ReportCodePushLegacyDeployStatusRequestObject) (ReportCodePushLegacyDeployStatusResponseObject, error)
+	// Report CodePush download, legacy path of the standalone CodePush server
+	// (POST /reportStatus/download)
+	ReportCodePushLegacyDownloadStatus(ctx context.Context, request ReportCodePushLegacyDownloadStatusRequestObject) (ReportCodePushLegacyDownloadStatusResponseObject, error)
+	// Get CodePush update, legacy path of the standalone CodePush server
+	// (GET /updateCheck)
+	GetCodePushLegacyUpdate(ctx context.Context, request GetCodePushLegacyUpdateRequestObject) (GetCodePushLegacyUpdateResponseObject, error)
+	// Report CodePush install status
+	// (POST /v0.1/public/codepush/report_status/deploy)
+	ReportCodePushDeployStatus(ctx context.Context, request ReportCodePushDeployStatusRequestObject) (ReportCodePushDeployStatusResponseObject, error)
+	// Report CodePush download
+	// (POST /v0.1/public/codepush/report_status/download)
+	ReportCodePushDownloadStatus(ctx context.Context, request ReportCodePushDownloadStatusRequestObject) (ReportCodePushDownloadStatusResponseObject, error)
+	// Get CodePush update
+	// (GET /v0.1/public/codepush/update_check)
+	GetCodePushUpdate(ctx context.Context, request GetCodePushUpdateRequestObject) (GetCodePushUpdateResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetLogLevels operation middleware
+func (sh *strictHandler) GetLogLevels(ctx *gin.Context) {
+	var request GetLogLevelsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLogLevels(ctx, request.(GetLogLevelsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLogLevels")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetLogLevelsResponseObject); ok {
+		if err := validResponse.VisitGetLogLevelsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetLogLevels operation middleware
+func (sh *strictHandler) SetLogLevels(ctx *gin.Context) {
+	var request SetLogLevelsRequestObject
+
+	var body SetLogLevelsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SetLogLevels(ctx, request.(SetLogLevelsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetLogLevels")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(SetLogLevelsResponseObject); ok {
+		if err := validResponse.VisitSetLogLevelsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMaintenanceMode operation middleware
+func (sh *strictHandler) GetMaintenanceMode(ctx *gin.Context) {
+	var request GetMaintenanceModeRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMaintenanceMode(ctx, request.(GetMaintenanceModeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMaintenanceMode")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetMaintenanceModeResponseObject); ok {
+		if err := validResponse.VisitGetMaintenanceModeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetMaintenanceMode operation middleware
+func (sh *strictHandler) SetMaintenanceMode(ctx *gin.Context) {
+	var request SetMaintenanceModeRequestObject
+
+	var body SetMaintenanceModeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SetMaintenanceMode(ctx, request.(SetMaintenanceModeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetMaintenanceMode")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(SetMaintenanceModeResponseObject); ok {
+		if err := validResponse.VisitSetMaintenanceModeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateProject operation middleware
+func (sh *strictHandler) CreateProject(ctx *gin.Context) {
+	var request CreateProjectRequestObject
+
+	var body CreateProjectJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProject(ctx, request.(CreateProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateProjectResponseObject); ok {
+		if err := validResponse.VisitCreateProjectResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteProject operation middleware
+func (sh *strictHandler) DeleteProject(ctx *gin.Context, projectID ProjectID) {
+	var request DeleteProjectRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProject(ctx, request.(DeleteProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteProjectResponseObject); ok {
+		if err := validResponse.VisitDeleteProjectResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProjectByID operation middleware
+func (sh *strictHandler) GetProjectByID(ctx *gin.Context, projectID ProjectID) {
+	var request GetProjectByIDRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjectByID(ctx, request.(GetProjectByIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjectByID")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetProjectByIDResponseObject); ok {
+		if err := validResponse.VisitGetProjectByIDResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateProject operation middleware
+func (sh *strictHandler) UpdateProject(ctx *gin.Context, projectID ProjectID) {
+	var request UpdateProjectRequestObject
+
+	request.ProjectID = projectID
+
+	var body UpdateProjectJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateProject(ctx, request.(UpdateProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateProject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateProjectResponseObject); ok {
+		if err := validResponse.VisitUpdateProjectResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CloneProject operation middleware
+func (sh *strictHandler) CloneProject(ctx *gin.Context, projectID ProjectID) {
+	var request CloneProjectRequestObject
+
+	request.ProjectID = projectID
+
+	var body CloneProjectJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CloneProject(ctx, request.(CloneProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CloneProject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CloneProjectResponseObject); ok {
+		if err := validResponse.VisitCloneProjectResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProjectEnvironments operation middleware
+func (sh *strictHandler) GetProjectEnvironments(ctx *gin.Context, projectID ProjectID) {
+	var request GetProjectEnvironmentsRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjectEnvironments(ctx, request.(GetProjectEnvironmentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjectEnvironments")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetProjectEnvironmentsResponseObject); ok {
+		if err := validResponse.VisitGetProjectEnvironmentsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CopyProjectToEnvironment operation middleware
+func (sh *strictHandler) CopyProjectToEnvironment(ctx *gin.Context, projectID ProjectID) {
+	var request CopyProjectToEnvironmentRequestObject
+
+	request.ProjectID = projectID
+
+	var body CopyProjectToEnvironmentJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CopyProjectToEnvironment(ctx, request.(CopyProjectToEnvironmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CopyProjectToEnvironment")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CopyProjectToEnvironmentResponseObject); ok {
+		if err := validResponse.VisitCopyProjectToEnvironmentResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteChannelPolicy operation middleware
+func (sh *strictHandler) DeleteChannelPolicy(ctx *gin.Context, projectID ProjectID, params DeleteChannelPolicyParams) {
+	var request DeleteChannelPolicyRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteChannelPolicy(ctx, request.(DeleteChannelPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteChannelPolicy")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteChannelPolicyResponseObject); ok {
+		if err := validResponse.VisitDeleteChannelPolicyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetChannelPolicies operation middleware
+func (sh *strictHandler) GetChannelPolicies(ctx *gin.Context, projectID ProjectID) {
+	var request GetChannelPoliciesRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannelPolicies(ctx, request.(GetChannelPoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannelPolicies")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetChannelPoliciesResponseObject); ok {
+		if err := validResponse.VisitGetChannelPoliciesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetChannelPolicy operation middleware
+func (sh *strictHandler) SetChannelPolicy(ctx *gin.Context, projectID ProjectID) {
+	var request SetChannelPolicyRequestObject
+
+	request.ProjectID = projectID
+
+	var body SetChannelPolicyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SetChannelPolicy(ctx, request.(SetChannelPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetChannelPolicy")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(SetChannelPolicyResponseObject); ok {
+		if err := validResponse.VisitSetChannelPolicyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RollBackToEmbedded operation middleware
+func (sh *strictHandler) RollBackToEmbedded(ctx *gin.Context, projectID ProjectID, channel string) {
+	var request RollBackToEmbeddedRequestObject
+
+	request.ProjectID = projectID
+	request.Channel = channel
+
+	var body RollBackToEmbeddedJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RollBackToEmbedded(ctx, request.(RollBackToEmbeddedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RollBackToEmbedded")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RollBackToEmbeddedResponseObject); ok {
+		if err := validResponse.VisitRollBackToEmbeddedResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEmbeddedUpdates operation middleware
+func (sh *strictHandler) GetEmbeddedUpdates(ctx *gin.Context, projectID ProjectID) {
+	var request GetEmbeddedUpdatesRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEmbeddedUpdates(ctx, request.(GetEmbeddedUpdatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEmbeddedUpdates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetEmbeddedUpdatesResponseObject); ok {
+		if err := validResponse.VisitGetEmbeddedUpdatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RegisterEmbeddedUpdate operation middleware
+func (sh *strictHandler) RegisterEmbeddedUpdate(ctx *gin.Context, projectID ProjectID) {
+	var request RegisterEmbeddedUpdateRequestObject
+
+	request.ProjectID = projectID
+
+	var body RegisterEmbeddedUpdateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RegisterEmbeddedUpdate(ctx, request.(RegisterEmbeddedUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RegisterEmbeddedUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RegisterEmbeddedUpdateResponseObject); ok {
+		if err := validResponse.VisitRegisterEmbeddedUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlavors operation middleware
+func (sh *strictHandler) GetFlavors(ctx *gin.Context, projectID ProjectID) {
+	var request GetFlavorsRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlavors(ctx, request.(GetFlavorsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlavors")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetFlavorsResponseObject); ok {
+		if err := validResponse.VisitGetFlavorsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFlavor operation middleware
+func (sh *strictHandler) CreateFlavor(ctx *gin.Context, projectID ProjectID) {
+	var request CreateFlavorRequestObject
+
+	request.ProjectID = projectID
+
+	var body CreateFlavorJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFlavor(ctx, request.(CreateFlavorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFlavor")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateFlavorResponseObject); ok {
+		if err := validResponse.VisitCreateFlavorResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFlavor operation middleware
+func (sh *strictHandler) DeleteFlavor(ctx *gin.Context, projectID ProjectID, flavor string) {
+	var request DeleteFlavorRequestObject
+
+	request.ProjectID = projectID
+	request.Flavor = flavor
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFlavor(ctx, request.(DeleteFlavorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFlavor")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteFlavorResponseObject); ok {
+		if err := validResponse.VisitDeleteFlavorResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnpinChannel operation middleware
+func (sh *strictHandler) UnpinChannel(ctx *gin.Context, projectID ProjectID, params UnpinChannelParams) {
+	var request UnpinChannelRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnpinChannel(ctx, request.(UnpinChannelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnpinChannel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UnpinChannelResponseObject); ok {
+		if err := validResponse.VisitUnpinChannelResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetExpoUpdate500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+// GetChannelPins operation middleware
+func (sh *strictHandler) GetChannelPins(ctx *gin.Context, projectID ProjectID) {
+	var request GetChannelPinsRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannelPins(ctx, request.(GetChannelPinsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannelPins")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetChannelPinsResponseObject); ok {
+		if err := validResponse.VisitGetChannelPinsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PinChannel operation middleware
+func (sh *strictHandler) PinChannel(ctx *gin.Context, projectID ProjectID) {
+	var request PinChannelRequestObject
+
+	request.ProjectID = projectID
+
+	var body PinChannelJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PinChannel(ctx, request.(PinChannelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PinChannel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PinChannelResponseObject); ok {
+		if err := validResponse.VisitPinChannelResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSigningKeys operation middleware
+func (sh *strictHandler) GetSigningKeys(ctx *gin.Context, projectID ProjectID) {
+	var request GetSigningKeysRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSigningKeys(ctx, request.(GetSigningKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSigningKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetSigningKeysResponseObject); ok {
+		if err := validResponse.VisitGetSigningKeysResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateSigningKey operation middleware
+func (sh *strictHandler) RotateSigningKey(ctx *gin.Context, projectID ProjectID) {
+	var request RotateSigningKeyRequestObject
+
+	request.ProjectID = projectID
+
+	var body RotateSigningKeyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateSigningKey(ctx, request.(RotateSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateSigningKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RotateSigningKeyResponseObject); ok {
+		if err := validResponse.VisitRotateSigningKeyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RetireSigningKey operation middleware
+func (sh *strictHandler) RetireSigningKey(ctx *gin.Context, projectID ProjectID, keyID string) {
+	var request RetireSigningKeyRequestObject
+
+	request.ProjectID = projectID
+	request.KeyID = keyID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RetireSigningKey(ctx, request.(RetireSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetireSigningKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RetireSigningKeyResponseObject); ok {
+		if err := validResponse.VisitRetireSigningKeyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAssetDownloadStats operation middleware
+func (sh *strictHandler) GetAssetDownloadStats(ctx *gin.Context, projectID ProjectID, params GetAssetDownloadStatsParams) {
+	var request GetAssetDownloadStatsRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAssetDownloadStats(ctx, request.(GetAssetDownloadStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAssetDownloadStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAssetDownloadStatsResponseObject); ok {
+		if err := validResponse.VisitGetAssetDownloadStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCodePushReleaseStats operation middleware
+func (sh *strictHandler) GetCodePushReleaseStats(ctx *gin.Context, projectID ProjectID, params GetCodePushReleaseStatsParams) {
+	var request GetCodePushReleaseStatsRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCodePushReleaseStats(ctx, request.(GetCodePushReleaseStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCodePushReleaseStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCodePushReleaseStatsResponseObject); ok {
+		if err := validResponse.VisitGetCodePushReleaseStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCorruptedAssets operation middleware
+func (sh *strictHandler) GetCorruptedAssets(ctx *gin.Context, projectID ProjectID) {
+	var request GetCorruptedAssetsRequestObject
+
+	request.ProjectID = projectID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCorruptedAssets(ctx, request.(GetCorruptedAssetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCorruptedAssets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCorruptedAssetsResponseObject); ok {
+		if err := validResponse.VisitGetCorruptedAssetsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUpdateCheckEvents operation middleware
+func (sh *strictHandler) GetUpdateCheckEvents(ctx *gin.Context, projectID ProjectID, params GetUpdateCheckEventsParams) {
+	var request GetUpdateCheckEventsRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUpdateCheckEvents(ctx, request.(GetUpdateCheckEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUpdateCheckEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUpdateCheckEventsResponseObject); ok {
+		if err := validResponse.VisitGetUpdateCheckEventsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAPIUsageStats operation middleware
+func (sh *strictHandler) GetAPIUsageStats(ctx *gin.Context, projectID ProjectID, params GetAPIUsageStatsParams) {
+	var request GetAPIUsageStatsRequestObject
+
+	request.ProjectID = projectID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAPIUsageStats(ctx, request.(GetAPIUsageStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAPIUsageStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAPIUsageStatsResponseObject); ok {
+		if err := validResponse.VisitGetAPIUsageStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PrepareUpdate operation middleware
+func (sh *strictHandler) PrepareUpdate(ctx *gin.Context, projectID ProjectID) {
+	var request PrepareUpdateRequestObject
+
+	request.ProjectID = projectID
+
+	var body PrepareUpdateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PrepareUpdate(ctx, request.(PrepareUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PrepareUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PrepareUpdateResponseObject); ok {
+		if err := validResponse.VisitPrepareUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUpdate operation middleware
+func (sh *strictHandler) DeleteUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request DeleteUpdateRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUpdate(ctx, request.(DeleteUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUpdate")
+	}
 
-func (response GetExpoUpdate500JSONResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUpdateResponseObject); ok {
+		if err := validResponse.VisitDeleteUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetCodePushUpdateRequestObject struct {
-	Params GetCodePushUpdateParams
+// GetUpdate operation middleware
+func (sh *strictHandler) GetUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request GetUpdateRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUpdate(ctx, request.(GetUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUpdateResponseObject); ok {
+		if err := validResponse.VisitGetUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetCodePushUpdateResponseObject interface {
-	VisitGetCodePushUpdateResponse(w http.ResponseWriter) error
+// PatchUpdate operation middleware
+func (sh *strictHandler) PatchUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request PatchUpdateRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	var body PatchUpdateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchUpdate(ctx, request.(PatchUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PatchUpdateResponseObject); ok {
+		if err := validResponse.VisitPatchUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetCodePushUpdate200JSONResponse struct {
-	UpdateInfo CodePushUpdate `json:"update_info"`
+// UploadUpdateAssets operation middleware
+func (sh *strictHandler) UploadUpdateAssets(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request UploadUpdateAssetsRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	if reader, err := ctx.Request.MultipartReader(); err == nil {
+		request.Body = reader
+	} else {
+		ctx.Error(err)
+		return
+	}
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadUpdateAssets(ctx, request.(UploadUpdateAssetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadUpdateAssets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UploadUpdateAssetsResponseObject); ok {
+		if err := validResponse.VisitUploadUpdateAssetsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response GetCodePushUpdate200JSONResponse) VisitGetCodePushUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetChunkedUploadStatus operation middleware
+func (sh *strictHandler) GetChunkedUploadStatus(ctx *gin.Context, projectID ProjectID, updateID UpdateID, params GetChunkedUploadStatusParams) {
+	var request GetChunkedUploadStatusRequestObject
 
-	return json.NewEncoder(w).Encode(response)
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChunkedUploadStatus(ctx, request.(GetChunkedUploadStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChunkedUploadStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetChunkedUploadStatusResponseObject); ok {
+		if err := validResponse.VisitGetChunkedUploadStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetCodePushUpdate400JSONResponse struct{ ValidationErrorJSONResponse }
+// CompleteChunkedUpload operation middleware
+func (sh *strictHandler) CompleteChunkedUpload(ctx *gin.Context, projectID ProjectID, updateID UpdateID, params CompleteChunkedUploadParams) {
+	var request CompleteChunkedUploadRequestObject
 
-func (response GetCodePushUpdate400JSONResponse) VisitGetCodePushUpdateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CompleteChunkedUpload(ctx, request.(CompleteChunkedUploadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CompleteChunkedUpload")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CompleteChunkedUploadResponseObject); ok {
+		if err := validResponse.VisitCompleteChunkedUploadResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Create a project
-	// (POST /api/v1/admin/project)
-	CreateProject(ctx context.Context, request CreateProjectRequestObject) (CreateProjectResponseObject, error)
-	// Get project by id
-	// (GET /api/v1/admin/project/{projectID})
-	GetProjectByID(ctx context.Context, request GetProjectByIDRequestObject) (GetProjectByIDResponseObject, error)
-	// Prepare a new update
-	// (POST /api/v1/admin/{projectID}/update)
-	PrepareUpdate(ctx context.Context, request PrepareUpdateRequestObject) (PrepareUpdateResponseObject, error)
-	// Get update
-	// (GET /api/v1/admin/{projectID}/update/{updateID})
-	GetUpdate(ctx context.Context, request GetUpdateRequestObject) (GetUpdateResponseObject, error)
-	// Commit update
-	// (POST /api/v1/admin/{projectID}/update/{updateID}/commit)
-	CommitUpdate(ctx context.Context, request CommitUpdateRequestObject) (CommitUpdateResponseObject, error)
-	// Rollback an update
-	// (POST /api/v1/admin/{projectID}/update/{updateID}/rollback)
-	RollbackUpdate(ctx context.Context, request RollbackUpdateRequestObject) (RollbackUpdateResponseObject, error)
-	// Get all updates
-	// (GET /api/v1/admin/{projectID}/updates)
-	GetUpdates(ctx context.Context, request GetUpdatesRequestObject) (GetUpdatesResponseObject, error)
-	// Health check
-	// (GET /api/v1/health)
-	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
-	// Get Expo update
-	// (GET /api/v1/public/{projectID}/expo)
-	GetExpoUpdate(ctx context.Context, request GetExpoUpdateRequestObject) (GetExpoUpdateResponseObject, error)
-	// Get CodePush update
-	// (GET /v0.1/public/codepush/update_check)
-	GetCodePushUpdate(ctx context.Context, request GetCodePushUpdateRequestObject) (GetCodePushUpdateResponseObject, error)
+// UploadChunk operation middleware
+func (sh *strictHandler) UploadChunk(ctx *gin.Context, projectID ProjectID, updateID UpdateID, chunkIndex int, params UploadChunkParams) {
+	var request UploadChunkRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+	request.ChunkIndex = chunkIndex
+	request.Params = params
+
+	request.Body = ctx.Request.Body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadChunk(ctx, request.(UploadChunkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadChunk")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UploadChunkResponseObject); ok {
+		if err := validResponse.VisitUploadChunkResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// CommitUpdate operation middleware
+func (sh *strictHandler) CommitUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request CommitUpdateRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CommitUpdate(ctx, request.(CommitUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CommitUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CommitUpdateResponseObject); ok {
+		if err := validResponse.VisitCommitUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+// DiffUpdates operation middleware
+func (sh *strictHandler) DiffUpdates(ctx *gin.Context, projectID ProjectID, updateID UpdateID, otherUpdateID openapi_types.UUID) {
+	var request DiffUpdatesRequestObject
+
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+	request.OtherUpdateID = otherUpdateID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DiffUpdates(ctx, request.(DiffUpdatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DiffUpdates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DiffUpdatesResponseObject); ok {
+		if err := validResponse.VisitDiffUpdatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// CreateProject operation middleware
-func (sh *strictHandler) CreateProject(ctx *gin.Context) {
-	var request CreateProjectRequestObject
+// SetUpdateExpiry operation middleware
+func (sh *strictHandler) SetUpdateExpiry(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request SetUpdateExpiryRequestObject
 
-	var body CreateProjectJSONRequestBody
+	request.ProjectID = projectID
+	request.UpdateID = updateID
+
+	var body SetUpdateExpiryJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -1103,10 +7425,10 @@ func (sh *strictHandler) CreateProject(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateProject(ctx, request.(CreateProjectRequestObject))
+		return sh.ssi.SetUpdateExpiry(ctx, request.(SetUpdateExpiryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateProject")
+		handler = middleware(handler, "SetUpdateExpiry")
 	}
 
 	response, err := handler(ctx, request)
@@ -1114,8 +7436,8 @@ func (sh *strictHandler) CreateProject(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(CreateProjectResponseObject); ok {
-		if err := validResponse.VisitCreateProjectResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(SetUpdateExpiryResponseObject); ok {
+		if err := validResponse.VisitSetUpdateExpiryResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1123,17 +7445,18 @@ func (sh *strictHandler) CreateProject(ctx *gin.Context) {
 	}
 }
 
-// GetProjectByID operation middleware
-func (sh *strictHandler) GetProjectByID(ctx *gin.Context, projectID ProjectID) {
-	var request GetProjectByIDRequestObject
+// GetProcessingReports operation middleware
+func (sh *strictHandler) GetProcessingReports(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request GetProcessingReportsRequestObject
 
 	request.ProjectID = projectID
+	request.UpdateID = updateID
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetProjectByID(ctx, request.(GetProjectByIDRequestObject))
+		return sh.ssi.GetProcessingReports(ctx, request.(GetProcessingReportsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetProjectByID")
+		handler = middleware(handler, "GetProcessingReports")
 	}
 
 	response, err := handler(ctx, request)
@@ -1141,8 +7464,8 @@ func (sh *strictHandler) GetProjectByID(ctx *gin.Context, projectID ProjectID) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetProjectByIDResponseObject); ok {
-		if err := validResponse.VisitGetProjectByIDResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetProcessingReportsResponseObject); ok {
+		if err := validResponse.VisitGetProcessingReportsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1150,25 +7473,18 @@ func (sh *strictHandler) GetProjectByID(ctx *gin.Context, projectID ProjectID) {
 	}
 }
 
-// PrepareUpdate operation middleware
-func (sh *strictHandler) PrepareUpdate(ctx *gin.Context, projectID ProjectID) {
-	var request PrepareUpdateRequestObject
+// RollbackUpdate operation middleware
+func (sh *strictHandler) RollbackUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request RollbackUpdateRequestObject
 
 	request.ProjectID = projectID
-
-	var body PrepareUpdateJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
-		ctx.Error(err)
-		return
-	}
-	request.Body = &body
+	request.UpdateID = updateID
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PrepareUpdate(ctx, request.(PrepareUpdateRequestObject))
+		return sh.ssi.RollbackUpdate(ctx, request.(RollbackUpdateRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PrepareUpdate")
+		handler = middleware(handler, "RollbackUpdate")
 	}
 
 	response, err := handler(ctx, request)
@@ -1176,8 +7492,8 @@ func (sh *strictHandler) PrepareUpdate(ctx *gin.Context, projectID ProjectID) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PrepareUpdateResponseObject); ok {
-		if err := validResponse.VisitPrepareUpdateResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(RollbackUpdateResponseObject); ok {
+		if err := validResponse.VisitRollbackUpdateResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1185,18 +7501,26 @@ func (sh *strictHandler) PrepareUpdate(ctx *gin.Context, projectID ProjectID) {
 	}
 }
 
-// GetUpdate operation middleware
-func (sh *strictHandler) GetUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
-	var request GetUpdateRequestObject
+// SetUpdateRollout operation middleware
+func (sh *strictHandler) SetUpdateRollout(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request SetUpdateRolloutRequestObject
 
 	request.ProjectID = projectID
 	request.UpdateID = updateID
 
+	var body SetUpdateRolloutJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetUpdate(ctx, request.(GetUpdateRequestObject))
+		return sh.ssi.SetUpdateRollout(ctx, request.(SetUpdateRolloutRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetUpdate")
+		handler = middleware(handler, "SetUpdateRollout")
 	}
 
 	response, err := handler(ctx, request)
@@ -1204,8 +7528,8 @@ func (sh *strictHandler) GetUpdate(ctx *gin.Context, projectID ProjectID, update
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetUpdateResponseObject); ok {
-		if err := validResponse.VisitGetUpdateResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(SetUpdateRolloutResponseObject); ok {
+		if err := validResponse.VisitSetUpdateRolloutResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1213,18 +7537,18 @@ func (sh *strictHandler) GetUpdate(ctx *gin.Context, projectID ProjectID, update
 	}
 }
 
-// CommitUpdate operation middleware
-func (sh *strictHandler) CommitUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
-	var request CommitUpdateRequestObject
+// GetUpdateStats operation middleware
+func (sh *strictHandler) GetUpdateStats(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request GetUpdateStatsRequestObject
 
 	request.ProjectID = projectID
 	request.UpdateID = updateID
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CommitUpdate(ctx, request.(CommitUpdateRequestObject))
+		return sh.ssi.GetUpdateStats(ctx, request.(GetUpdateStatsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CommitUpdate")
+		handler = middleware(handler, "GetUpdateStats")
 	}
 
 	response, err := handler(ctx, request)
@@ -1232,8 +7556,8 @@ func (sh *strictHandler) CommitUpdate(ctx *gin.Context, projectID ProjectID, upd
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(CommitUpdateResponseObject); ok {
-		if err := validResponse.VisitCommitUpdateResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetUpdateStatsResponseObject); ok {
+		if err := validResponse.VisitGetUpdateStatsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1241,18 +7565,18 @@ func (sh *strictHandler) CommitUpdate(ctx *gin.Context, projectID ProjectID, upd
 	}
 }
 
-// RollbackUpdate operation middleware
-func (sh *strictHandler) RollbackUpdate(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
-	var request RollbackUpdateRequestObject
+// GetUploadStatus operation middleware
+func (sh *strictHandler) GetUploadStatus(ctx *gin.Context, projectID ProjectID, updateID UpdateID) {
+	var request GetUploadStatusRequestObject
 
 	request.ProjectID = projectID
 	request.UpdateID = updateID
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.RollbackUpdate(ctx, request.(RollbackUpdateRequestObject))
+		return sh.ssi.GetUploadStatus(ctx, request.(GetUploadStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RollbackUpdate")
+		handler = middleware(handler, "GetUploadStatus")
 	}
 
 	response, err := handler(ctx, request)
@@ -1260,8 +7584,8 @@ func (sh *strictHandler) RollbackUpdate(ctx *gin.Context, projectID ProjectID, u
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(RollbackUpdateResponseObject); ok {
-		if err := validResponse.VisitRollbackUpdateResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetUploadStatusResponseObject); ok {
+		if err := validResponse.VisitGetUploadStatusResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1297,6 +7621,61 @@ func (sh *strictHandler) GetUpdates(ctx *gin.Context, projectID ProjectID, param
 	}
 }
 
+// GetUpdateByExternalID operation middleware
+func (sh *strictHandler) GetUpdateByExternalID(ctx *gin.Context, projectID ProjectID, externalID string) {
+	var request GetUpdateByExternalIDRequestObject
+
+	request.ProjectID = projectID
+	request.ExternalID = externalID
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUpdateByExternalID(ctx, request.(GetUpdateByExternalIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUpdateByExternalID")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUpdateByExternalIDResponseObject); ok {
+		if err := validResponse.VisitGetUpdateByExternalIDResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DebugUpdateCheck operation middleware
+func (sh *strictHandler) DebugUpdateCheck(ctx *gin.Context, params DebugUpdateCheckParams) {
+	var request DebugUpdateCheckRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DebugUpdateCheck(ctx, request.(DebugUpdateCheckRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DebugUpdateCheck")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DebugUpdateCheckResponseObject); ok {
+		if err := validResponse.VisitDebugUpdateCheckResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // HealthCheck operation middleware
 func (sh *strictHandler) HealthCheck(ctx *gin.Context) {
 	var request HealthCheckRequestObject
@@ -1350,6 +7729,165 @@ func (sh *strictHandler) GetExpoUpdate(ctx *gin.Context, projectID ProjectID, pa
 	}
 }
 
+// ReportCodePushLegacyDeployStatus operation middleware
+func (sh *strictHandler) ReportCodePushLegacyDeployStatus(ctx *gin.Context) {
+	var request ReportCodePushLegacyDeployStatusRequestObject
+
+	var body ReportCodePushLegacyDeployStatusJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportCodePushLegacyDeployStatus(ctx, request.(ReportCodePushLegacyDeployStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportCodePushLegacyDeployStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ReportCodePushLegacyDeployStatusResponseObject); ok {
+		if err := validResponse.VisitReportCodePushLegacyDeployStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReportCodePushLegacyDownloadStatus operation middleware
+func (sh *strictHandler) ReportCodePushLegacyDownloadStatus(ctx *gin.Context) {
+	var request ReportCodePushLegacyDownloadStatusRequestObject
+
+	var body ReportCodePushLegacyDownloadStatusJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportCodePushLegacyDownloadStatus(ctx, request.(ReportCodePushLegacyDownloadStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportCodePushLegacyDownloadStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ReportCodePushLegacyDownloadStatusResponseObject); ok {
+		if err := validResponse.VisitReportCodePushLegacyDownloadStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCodePushLegacyUpdate operation middleware
+func (sh *strictHandler) GetCodePushLegacyUpdate(ctx *gin.Context, params GetCodePushLegacyUpdateParams) {
+	var request GetCodePushLegacyUpdateRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCodePushLegacyUpdate(ctx, request.(GetCodePushLegacyUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCodePushLegacyUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCodePushLegacyUpdateResponseObject); ok {
+		if err := validResponse.VisitGetCodePushLegacyUpdateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReportCodePushDeployStatus operation middleware
+func (sh *strictHandler) ReportCodePushDeployStatus(ctx *gin.Context) {
+	var request ReportCodePushDeployStatusRequestObject
+
+	var body ReportCodePushDeployStatusJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportCodePushDeployStatus(ctx, request.(ReportCodePushDeployStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportCodePushDeployStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ReportCodePushDeployStatusResponseObject); ok {
+		if err := validResponse.VisitReportCodePushDeployStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReportCodePushDownloadStatus operation middleware
+func (sh *strictHandler) ReportCodePushDownloadStatus(ctx *gin.Context) {
+	var request ReportCodePushDownloadStatusRequestObject
+
+	var body ReportCodePushDownloadStatusJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportCodePushDownloadStatus(ctx, request.(ReportCodePushDownloadStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportCodePushDownloadStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ReportCodePushDownloadStatusResponseObject); ok {
+		if err := validResponse.VisitReportCodePushDownloadStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetCodePushUpdate operation middleware
 func (sh *strictHandler) GetCodePushUpdate(ctx *gin.Context, params GetCodePushUpdateParams) {
 	var request GetCodePushUpdateRequestObject
@@ -1376,3 +7914,336 @@ func (sh *strictHandler) GetCodePushUpdate(ctx *gin.Context, params GetCodePushU
 		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9e3PjtrIg/lVQ+v2qTs4WJXuemzNVqVuO7Zz4Zmbi60fOvXWVtWASknBMAQwA2lZm",
+	"57tvofEkCephyzOePVv5I2ORBBqNRnejn58GOV9UnBGm5ODdp0GFBV4QRQT8dTiv2Q0pfqIlOcVqrn8q",
+	"iMwFrRTlbPBuoH9FfIrUnKApLQkqSF5iQQp0NycMVYJUWFA2gxfqqsCKDLIB1Z/+UROxHGQDhhdk8G5Q",
+	"6fGzgSB/1FSQYvBOiZpkA5nPyQLridWy0u9JpccbfM4G90OOKzrMeUFmhA3JvRJ4qPAMIL+mrNDvvfMj",
+	"ZlhKoq70PNkC3//wen9/8PlzNvhI1B0XN921HXLGSK7/cCssyC3NSYbIaDZCkzs6pZMROoIfJeIMTXJS",
+	"lnWJxQRxgbiaE4EmgE1STMYs9wNKVHD2F4VmRCEO8+HSokeiEosZEUjNMYNZ7QCo4Hes5LhAJV1QZWEa",
+	"s0rwf5JcZfqv5V8EQYqXhf5DkL9IxLgdF9VM0RJeQoJUXCiEmQExwDUaM7c9c4ILIsL+/OfwVA0drtbs",
+	"y4wP7Vfhgw13iy+oIotKLWGPXryFLTo1Szw50u8CdJZaHO3456sIaMrFAqvBu0Fd02KQtQH/nA0uAVO9",
+	"09Tu8WNm+aw/lhVnksDST5giguHynIhbIo6F4EL/nHOmCFP6n7iqSppjvT97/5SaND9F8/3/gkwH7wb/",
+	"3144x3vmqdz7O2FE0NwMClM3KdzNjSRMjoh5MRv8hktawIzbA1QJXhGhqFme3u91YMIch/rFz9mAuAmb",
+	"iLNkpX8cyhtaDd2xGVac6mWYnXADwNyakuS6ycNSf6KkLI4dCuz0WAi8HMCmKbHE1yWJYLvmvCSYbQzc",
+	"55hw/ttB+rufjF9rMk7tVIDSbdJnR3SwwoPTk0uJZ+RcYSUTu1BSwtSxx0xz8DPyR02kkggzeQes5o6q",
+	"OcLo9f09kgqrWg6yQNiUqbevA2XrBc6I8Ht3hhXpznE+x4I4PipWTcgFepOct+C1Rr+fmNWLazOvXio2",
+	"E7XnPTlyk/qXENV8lUokK5J3T2g2qP725j1WhOXLDwlsXVYVEeia16xwQ5fmbXRd5zfEcWb0tzdqjioi",
+	"cqI5r191pudf0LKkkuScFTIzfNx8LBFhBcIKvcnQi/0MvXyToTf7+t/wx77+y/xp/jY/2F/2M/RK/w9h",
+	"VqC3+l9jFmOQMvXqZXLnKiIoL84VFiqxd/pnt6o5r0VjV7AiQ0UXJIVJt9ENxthPPzLwwG3I9M1WZNo6",
+	"hIF2mliIgM+a56cFZ0z2LdrpnuxscCAlUUdWlPcc1+ulIhIEQrEh5pxukEDbRzgmevv8S5oE87LWIhdV",
+	"WCiKS/SdwGxG/hpe2uzIl1j61ZDiQDXgXUkb1WYKpd5jyiL9MUOTcb2//yqvSqz0VPAXGf1JqwmacoG0",
+	"JDmt5Rxhkc/pLZGp2a0sL9aL7KZC41WENh35ATOnNcSYjHc0gbReQtESeiaoWh7OSX7TpRScqxqXP2M5",
+	"T6piuf5qu23pkb/6yX1FckUKN1uLSfx88PLN26Apa8FfIKs1ZOjD0Rv3TCquDy+gBDZs1T4ZfPxClkmQ",
+	"/qixwExRRoouRBea6cPn6A5LtOC3pEA1K0C3JmgSPt6boEqQKb0HxklBay45mxGBpNuzlsjX3MqwnHef",
+	"BoTVC00DOReirhS8v6BSaih//8LEFxDmIWziKaaKFN0dzjFjpDylLKFHmGdpWhMEq+1oTeg7yYL8RoS0",
+	"wvvpUeWW0Jk9i7EYFrMKRbyk+XI7LC2I1HraKVaKCJYScjN9gUTkvhJEasDsHRA+00fIXRPnWCLF0QKr",
+	"fL4euYecSSUwZSn5Tha35hpoX4Ep7ffo1gyQmFpiReV02c9etyCG3l0KI63YiTNeltc4xSBXUixfLKi6",
+	"0PAkLv/6GQIMOK2VlyXSs6CCCpIreksyj5Kqvi6pnJNCI0a/bSdGeKqIQFSNGRbEshOEZ5iypm625UFp",
+	"UU1rrzSomsXi/CZDuCztEhbGKsO4QpKoLTYhwlR6F8BMdFk5naZO3UH0S+f0T7KhSmMZKIzdvM51321f",
+	"1pxu0UUkyQm9JcWDRlVc4TJ8uUaxtFpAWHZzgA4s7RUnEV1yRqw15FRb6lJ45tVSq6BSGR4oU8RdLf3F",
+	"RaqIeK2tiE8RuSViGaiYFW2GkBn1O+cVJXLMDIVRgcDMJoG4uzKTsFsqOFuQFB86Dg/dmWPkDnkDV0Gm",
+	"uC6VdEeMdN+37yaFw5b2p0pQprDMKQVT1NvXsMNGvHR0bLwgCZAfDoY3Wuqp37x46QxHgbwAkCSNWPX3",
+	"iFQlX56BqS8lZ/TvoIYB1O4rZK46jm0xqXBZ6nsCRoKUBEuSIW4UqGvKsFgalmKI6ZqUY0YlsoQMNNDS",
+	"V6vq6naFuDezX9WM/lGTK1r0GoKsmD+E9y/hdS3ss0EBy9Y0cXXTozUCoMknlSC3lNfyaoNR/Lsw3BUX",
+	"V+sWFxTGRxMnZ4RPfzjyUJ7XeU5IQQoUfvsJ05IUXcqJwezgazVFeQo657XIEwchesWdB/e1F5Vyzu+Y",
+	"pjtcVVIflUWlwEXAHb2BrMrQxGo9E8SnYxZugJoAJ4wzMtHfzGlBYh1JWvu8o0uwiS8IZgouG/pNRfAC",
+	"cVYugUKd9m6/H2QDPXZScfeYsJe3x50ud0FsHK/OkfmqR6JFOq2R3HeriOY9meF8uZoZpViWkS6gSeEF",
+	"KQ+xJGhKSVnIcI/ErMBaIgb8GstMiu38tpbrWJQ9CsG/PJjlHK0dw735Xo/1qzhYvabnw2x+S/CaX8hy",
+	"E6pZc8zSx3GnlPP1SKP36P2y1ckzSmA/7szzL3naGnCkntvdvDx7vw7fR9Grn7MBlQe3mJY9Xhp44YNe",
+	"heJimX5hxTnF+Q2ekV5Tm33uLjgJ4/ac12VxVrMfQW/qYigCQ2ExI8q8eKbtsitsI0k+4MdaeRwj7DWR",
+	"18SUQ0sTCc0lJ6DpXXJqfasI+dTMc8KmPGEBXaN0raM2Kq8KKvWqiz6auVo8kmiu5n1Uoy/qvFbRM+fV",
+	"Wqm0tfbDjN8CdRVGz4yu0eN+CKxGWrbedasZRU3Glgan7BiWuaH/IJrLi5VV07UUq+3mip0kG/o2nCa3",
+	"jVkztll2TdLhim1cofZSRZVEblt3bROOPRFJhGeJPe+sfxVBBSmDy/LX6eDdf6/2uqeO9uesQ4gW7qta",
+	"lFuLgiu8Wha4syPXcOwrUbMrc9dNMJoO03avijVsu+e22Me4G+tpAZ8cMmtib9Vq0qB3t/t32PBqucYA",
+	"tbGNR3FtPVrGhhtthJ7SWW099YrvwISSMuS0sBuDnCRzcAf8VOJbLvqWnbYMved3RORYElQSpYiQGSro",
+	"jIIXu0AFlnN/YcX5ggyvMbvZkdkotdB+qxGscFc727TGuYA5qfCMstmkacmbVIIXNYSfTUZIW9JA57Tf",
+	"SmM3N7df5wzGLLb9jcbs4Rjb1N63OzteNpCKCzwjR4LeEnEpyi4uZzwveV2MCnKLLs/eO3za+BL9vYup",
+	"NNbWFsLBjkKwj0/hjIAJ5fzi17ODvx9fHZ2d/HZ8dnV59t5vzat3e3ukHprh/k2QGeXsB1IPc8KUwOXw",
+	"xWSEThTKsQ5bvDbujRkpxoyznDTnlsh6z0bozCxfInLvIs3M2ne0ZxAjuP/SbJVhgqeCK57zcl3c12Xz",
+	"7eRB6YyZOjnHi2tSFNr74URg6wa5vV+U2CHXXzjd5OayWeKa5XMIHOi/p9ioieTDtQ7Zto/DDdaAOeFa",
+	"bUO2zsMaQgI7Z+MXGgKvIFgB4qeQxAsCBj7juIAnyEVahustvJbjWpIM1aykN8REX1njX8MgeOuD7q6m",
+	"Tie6xsWVDQzS5MFwreZc0D/h4ZSLa1oUhIENUV1NdZCYXitn05KCP6DCS5DJivMrCPQF7zxX+Irce2VM",
+	"aGEOUb7wp0Ylr+188R3Nhm92TZWaSPQqhrdYaEqRejkep1HIo1uWf/ajtqK45flfL5vr9L//FC3Y//iR",
+	"q5/swv1vhwED/rdTg4oLzt9bRPhH/6ExchwQ4h/oKKv3HjH+5wuPoRjkCFX+5xOPs8/ZQMe1B6cliUM5",
+	"KmK4TTawAVLADEoI2EmahptjJR2gJhbmPWEz453c8ArygRd0SpMRLpF3esGlQoJofl0ukfMtogIrHMdT",
+	"ZQhfS8KU9wTPtSTR8THuk42d0mudrD8urfdxg4VKtwGreHZ7v3pcrmasrIXwNlwptmM0vJ3w8LTi0CNm",
+	"VjPDRhh3V+9K8TuI0Ag+kQy0Tf2Dlq3wh41npiVVywY7NVKbSK16aban6SNwQlRSqcLLMtAWGAsV56OE",
+	"229XUeBPFN69eXB5Iwq8HUUDHCNIGSsmTLAHuNoFWuAlkuba3fWPPyaMvIdwXAzAmSWMjfFmvktFQbzn",
+	"s/fklpQJDlf633FRUAP7aeON1eY5PTaCQVAFAUkWLPQdrmiGdCYJEZnTITP0R01qkqEc53PyV0/iE3u7",
+	"mJihIDQhrBBUAV4rG63A79gIHWtl0k4sCCjU5qy4+W3AgR24obz6mP3mplhUpHblA9abyTDLyQd7LtrX",
+	"LG+ObKLnQ60wuEpDAL0gSJB/QlwmrAy92X+F7ua0JMgO42KUEIT9mXvn348v/Bg2MEnR0uaBFCP0KyuX",
+	"NkaeQGYIOGa1pk8lwtMpzDdKxnh0btZmLSlEnOrQNauH99w4Wwb7tAvDWQFj97FbtKCzuUL4Di8zH5Y1",
+	"IwhSWIiPJPEBMGNWB3+IphL7RCOdqihuK7Hs7vooc5GCPcvrtxSetkNyFDd7YZZRUdZew4pQHcekzVBj",
+	"9lAT49bX3q4Bwq84SRCQLkjMfD/yIhFbGRiLRW0qvMk+iemAtkP0+IxA8ltwfJmvMq8qmdPllD590y2X",
+	"SF93R+gAlVRHvEWjWxEeXUH8gHEAnh4yojasYMQQRBMNCDF3eki9o5XgOZHSnbp21FpgqA8RbA++eWeF",
+	"DoJsWU5e2MRKG9a9uVH43PD1X525sS0dzimblQT9SSsIQcJiNPvTBY9DpDmmDGIpytJuYIPu0Xch62FB",
+	"FNaq8Ujnr/01G7OaaZNqcCwYWTNCH2odYF8uEbnPy1rqmYBi9Pgf3CBjZoLte0JNH2+McijNnWvXeZxa",
+	"4lP/3Im7sS6GFnnRhfWkTAVfWDPQ7YuXo3asihyzGVGIdoxKdqiTowxJHvsyZCPJ9IaQyspacG/I0ZgB",
+	"nBJFhj3NztyBGaF/WOZLVQiw5dMAmbMNwggwqj5L/iQzcq8Q82kvBOcupYQKN4nMkD4QldPYzOswjQET",
+	"SaLGjNzrVEeqyuWTWhkb94vmnv6jxRn0Vc2+bnfteomOD84z4Dx+Vy3aRuj4vtJB4oxOQWFwWcN2tCgm",
+	"GdBH1QhpJxVIjxb7NMzZZnZzw5syRINRcMxsos60VrUgo6agWW3xuq+oIDKFgEMr3DWosbdR020MIjbX",
+	"Ej1DhsCwA29gDV6uKSQp/tZBxQ+q6hB8EtG5DhLL2TVPjrqAn5EpEURz9+aJsUgKHjckl1KRRVPXsJtL",
+	"lfQH+ORoNGbHdkZ0ctQ+QQ2Te0i9xwzVrSAPEgYBw7DLBA+77ZRKONyCKEFJEQ44FkRveSRR72hODOk4",
+	"LFOd3KOTJK+Xmkj83NfLoZt+SAtEWAGyqO98RbbOgOtHHjzKfnhh+OrL7+H8xdy8u5E/dWVJhpxSo62I",
+	"REovh+Ck8FtqDFcb3bVacu+LyXAtvM3yweyR0KSMPaTjXoiI2XgOZeZ1cD61hQ/soFGlA6o3+aLzrZHX",
+	"9m2t67ihnA6O7dMG53kmehAoQBEnp8WqHGRHPHC07W/HB+f+3Km/dASz5IaFO6SImjGIrabKotbXhXAX",
+	"lBECixGoo01mfXIUbZCTrPEdcrT15cB4HnZ4T4v1DM6CQBdEKiyUVqclHzMf3gukhXXYvV9TQ7ZTiTjT",
+	"vEnUJEFBXT++iwPehdfPc5o3VoOzAT+nJi3cTrMiVb6w1UVmRKlmHRVzcQCS8XwY0sDbi3QFSrBwHkSv",
+	"5lJh9wTUuPa5o+bYwr3HEsuYWRGsnysOR9eC2ECns+w+5GYRuLPD2Rqv1Pb7IiHjrXs77fitHC2sva3G",
+	"BraWUYya3Chzh04WFPAaLnh0/Q030lWtdOeiMDptdGGVMRtcU32ka82TmtigwI/sE3xN35m2sQOXtgm/",
+	"WGr+TEugWJxMJWroJfrIjhlMC8qNzQY2aIWBrXLhLt7uerFEc3xLTEEb/UQ7xreRASEw62gjTJlZTkvM",
+	"1ttK/Zv+u8uz95sbqRsKgM6A1xcgG8jUXEPamnI0aEzb3NWsQ4GNtaUpG4wNlM1WJzjYLfdva+HUFnXG",
+	"c6qfOEljdEm48elcKVEn0oSMMnXIa7Yi7NsnjqPrmpYq3AhMFEPSzwSPesb9sWYFmH00EcIQqMJCkiKM",
+	"7IjS2BeSM0Beu3Zyb168wYY7fdjUWUbSnqB/zJcucxd5h3WHrucA2soTL7T41Vgw7zZtIsZABiqEzgQu",
+	"0A3T+TzwahojdOvsbaNUgLzfzuvWzcn3DEnrNQYpyZR8a/9ZiReQK7nTzQIxdAsYRKu/w0IrbIlBT6Ss",
+	"wVqCFSpooZmehtDtoVUTbTIdcrEW3na1BetrBxMWcXGA6EhkzZPXRkuTeJqkHi003rkGdffwGvhnwtK7",
+	"oOygLPkdKQ5pkbqeHJ4cnSGIVoRLhH4TggqdbrnADM8IXLHdNVN2M0U3lx8WOQkV/8A+CaYpsHtp04rV",
+	"2qhXuDN0XStgfSkNPKmSwhadCsoFDUHaDSfwvSJMQmU5uEEZzzfSIwXFwrC0xm3C3x2mVMgtkaGHuxTl",
+	"BVlo0kwosu4JiAn9thbZMkMK3xCwJ+WkMPYR7WKCg5pDiJC8hIjVRML+ikTETcKNux9Go57XsxmRNith",
+	"XdqMd7VH11RvZ1GkLE12o7uX2FxZKlEclbI2P7qDgYey0gW+P1gh+T7ge7qoF5HR1NvP/aqyptHclhAi",
+	"xWYFphb43mo5PiqkXWQKniIIhurGM5pyMZlW/8wbcMnZ3yzw31ZQdCHiEECUgID+SUJFL6FpoV2c0dlK",
+	"OfNVGaOijgCdKc64DXQ9kSLZoH0iugoLlsTFh1rVO/dBooH12JOfRYcQWA7UgaAz5swEyZoQ2UAQKPd3",
+	"BjGhybpc8CAu76M30n4mmw5Wx4X0/FA6oDBB3pvzHan06Tmw3CcBz0GYwW6X1V/MlxYLgteapuFyAxgB",
+	"McyIZkXGGt1T9Gdd7O6PGwbpWkeuoAvNGeyura7PseMYV+Ad6UDXzq43WVOWkM5tJrOasa5i5t097krA",
+	"nkPdZTSR1F6hfkBhja4OUgXlZBWe7SBhg9I1N2jwy2QIjB3OeNyp2QFuHkMDjwrXae14UHocnCmcNKIv",
+	"e6LOLryTvOncuNNJJiDhG8ygxc9B4FMlDS9/ynCxR8mdzfi3jMa/lKTon6OWgRk5VHjPv7ldIR3xiQTJ",
+	"cZnXJZhw6YZwmOtI3zIj4ZbaN+XMKhkyxoMQAqHvJbk+0qTYBJBkdJo57oMEslInNl5JikA1U5KKiGbo",
+	"fW/WSiOavn1lxqptWge0uI8cflzJZOnMprUQhCmvG8XfeO+qMfyDwq0tFIVslU6xWVxwd244uM3b6z1i",
+	"Ifr/ESlCybyBTnG/oTb2OUAbGPr3c3QNlpMMzck9IkyDUOwgiakk7Ie3r7M5uccFyekCGxHWn8HwMCR8",
+	"32Nkbt+wKt6Nq4qrTVZVO97KbPMgezJ79YPzL5Knipfljzi/ueCOrvpO1NpyZFzn6oKXnrOykanrUNPC",
+	"5C5CK2IkJRansCLndKZP+C9k2be0XP9zSnOsyOEc08TiTo8/OBpH0dvSxheGX4Iwobf6zxuyNNdsHaph",
+	"rRXXS1PYBpyQC1JQrBpjSFRXLsCPs+jQWYeAvmQ+JjClyQ3evHllCq7fkGWKW/5ClpqnxdvpgpwtPDpi",
+	"YmgKhQ711QKrWhDka8mvYma/kOWD+Fg6okZrGCWufua1MxxB6O7g3Yu337fjyn7md1Du0+6WqbJSLhGG",
+	"Gn9636SLj6Uz1gymaeHBio/FDn1h+4ZJ/c+3xhlmqclWE+knzbPzg5jydkQiL96++j6RVmropQFc1j1K",
+	"KaZzTlSjmmbvuXx0cF0fwTgv49NU5nQJlv9rPP5vGz4zHv8OhassQGOGTcgZoo0RXVg6GDa1WW/pn+wu",
+	"edKlpH6xYqEOHy9G97pJxpiZYs7kB/RytJ8h+CNHryaJ1bfm2CEWXr5526VpR3E9VHtmHfk99Fo93sFv",
+	"nPnOciIR9R538+nC9x7RMgQrNOON0BywQNPohhFggggvTGUrCGJrTpUIdDDHqa2nBGz0oNOo8Mfa8NLL",
+	"A3YdJIjh/z5yDi5eoLkYDg4DhLgUE90GMIAhVxBTUbobUrJhvdsuGrx20l07LmdcUDVP5+0+gdbitZWk",
+	"IX77fDivUqzXAYB4VNjqbV1/LXNgLMW1/LaeISPDMwRT6ZMX3gCldY2YB71tEeJoboxu5LvsIKuBINNU",
+	"ABFWuMlIYeZygQ4SYvyXCy6a+cdG/xhYbBhs2QESPswekRwIJ0EmkRdwdQpiM0SxL7X1WBMYcIZucQjz",
+	"xHoUcCkILpaQYSUgrcEmpxrhQJgSy9H8Oh/N/pxAtcfwy7WYmJOoP0CLWqoxu44c881olUMD2NDPb1TR",
+	"zMRNxZHPwEHN08j1PWa2VCMx348aGzT7k1aDbHAt0hnYTxNxaKrx6anRtbBZAa3E4sezc3x/ZTbfVJiJ",
+	"ZrmAsXdz87YqLXHOy12N+8JqdMWbdBmEJtP5cGRee9hcr4wClU6G3lmTMjnHL9+8TRtnfg5GF9TswmDO",
+	"VGRKbFTNMsfImh1viaBTCm3M9M1Hn56qJK6sc2jrZsI80HeTw/cnxx8vrn4+OP/56rfjs5Of/uvq7ODi",
+	"eGLzMEUtbRalsD1vfFCnPvrAQE2alAZyhE5mDOLAdBLDNISdYe/KJu4EY2beckEXI9QMUxszH6JmgQXv",
+	"2IoQtVbAmQmizZAkBE2iSKrJ+hB1g35PTU/DAJJmsZ7i460MeHci4jPXPNlrmX8cntbVevtKAiQLePUA",
+	"rd9NgdFbYGVlif8NEqY68ckQrN6b7RRSpS67uUm+H591sYJDnRQmn9kA6pw+vmY2gjxblWiZuEiRHCyr",
+	"LOyuHCTvaJHW33Q+yroiQpLIsn1HBLHNUVwmKS8L77kxjslszHwpZXgVlONE1qJzkAtUUcbcYdomFchS",
+	"Yw+viwzQ7vSyAlxJzskt48Unsj/bKVGex/WmRLkbwvEFdgpE375srxevuNRcNBagzNgIy5DR5K43WmXd",
+	"IqtphzlLoDYFjkrV5kk8mySepDJNjOcUtpiqRhKJvRfvPC/kEYE3O0yMSMYjNGJ7i77g8ih5z6nHyTBz",
+	"yJawE4Pkk3C0gmS0vR1sm6Q4ozpOp9bdTrZMI3nfiFFemYaxoyyKthqQ9Klu0KEo3DvXO+htaaBkIEbH",
+	"ZxOYSXRN6648iwrXh44tMd31C1PoKnaIWUF7RKvhxeegfa7mxs4V9ReJjLPJEAsKmlpmLKJOJkiFmuWE",
+	"E6V5D42XNZkVC+RmKlZb+IFLRgzDa7qIeudu8hDVXrPYJMYiGYM/iMFdg/DjW7umLfSY7Ru5maWneILO",
+	"OPNpPw0fpv3VppSeHDXPz5ojbGxwJ0cb6112cCtsW30iMs/hqdTaeUMJS67XIH8tKwzrMWWvDF1kCKpi",
+	"we3nemlUhERx4Q042WEDDlsEPqeOfzjbgScbxq/8v/XpvtKq1ZXiV87Xl4xXX13Gj2DJWX+CQLR80yya",
+	"R82trBLYCoCIrB7mhyunhAS2cyVcG67fH9/sLbl3llQUj5bh+9G08rxjO/CuaiqHY5itaiQXueX91q/h",
+	"CRcC5ykGjPN52tenfbEuEQwDVwWadWHJmcXQdT0zhX+0ICflFF0vK82YZfgyeZhgyH6+m0f2dnvwyqXb",
+	"HuwgcsAkma5n2zLFH1p9qbTCYZtVtbe5mQ2X7FQ1ZlyESAR7MXHXH3dVcANQad9oJpetlwwtYZoIXV3J",
+	"4bvs6ym5jugEewArMt+HRgCpgzz1RfK2b5IAiH3oGk/jr4/WckHXFX77eXw7+QYv7dNCTcBvOgqG16rV",
+	"FdqcnMwm10rvJm3m1S648P46X7PDarHGL6hNYN1EeS/WU269BzQSNNZvppFX0j8hjyfTN9C2Jhek8xN1",
+	"291x6HOgjVToc0cT96w+4uwR/4rOmieYNnl4ntovCI7odJqseGU48RasSI+kbZZ9TGi20xEhF3Ct3qWH",
+	"xiJI72ssSVAutqGKX6P57BkFo9QOl6S9HkekTFUDueAKl0i/gAo6bZlLjOnapatsGrfbh7kfH4yijToF",
+	"N7Yts4QWsBlIJcbHavIFfO6k8u0WV0+NdGeh8lTmk5Zs+qTml3Zl7eTdx/ckH2yrobsos8MHYKb17bYY",
+	"is5dEzuw//24WXEkjjrnwGT1Z6jiUtLrMvb0ZnB2tjsk/WGvruTvBvQJTpwPVILsShEpNO/uSQA+cg4w",
+	"c8Kb7mZXPgmYAhfQ7BxCpdUcM+872yrLaVUbdiXwZlCCcdZlegln2oXg0UYu31aQWZJZk0DeO5cB6o5Y",
+	"qBxyNNocUh+e2OySDRowNlCWNfe5n1rWtMLYLjvZR73tj+C/ve8n2UMzlrMxkzWY1+KCYXDt4aZVK1zj",
+	"fQG4yItgLoJhYKnwEvGKaP+MDbfTaPVBd2WJTk7l1pWWHhCB9+qlqaSU00LExSOL1aa/qOeE+2CENsrF",
+	"7lR8tWnZcdm0wtXg1H/at+LaaObwv97/W18lobVp25oIkXfFejIZKTWdZN1MbvecLvCM7FVsZoJjzJ//",
+	"Y5L5PqnrU70NXUwqfVKVzQyQkwCLJrrIRVZS586HyCWfJRsZem35ZdZKNwdua/EOfa6Y2QF9J4dIHMkb",
+	"9xgkSWmaq3uIJQIYjXzXqMxxaerQ2KVEtDtmgoDwkXHF5iwg6UtSc1xi7qHp8iY8jMZh5xrTfjdNSr2t",
+	"wGlxnmOGrp21bMx0q12GyD018elm7IpWpKTMx1zNlarku709M8SI3EMAyCjni71P9iB93vtkTsHnvU8a",
+	"/Z//7faHTyYy5bPG63ld2dJlVYlzMudlQYSxEU38GJMMTdww8G8YaYK+q9ZqWZmLFs6dAgB/kdGftJqA",
+	"CtOpDPNXPcMNWeoJDIfXBOxVIbiawTtuGYDcyadF8QaWZCjLUAeyfQKlKWS38/41K6sbbNdWrTvE598f",
+	"AJ4JNXMR7tAV+fO2BRMcu96ocIImzEm3mdkECBwKKuSYafkHEzfbkzcKLfi4X+AFI/TrVFs+kwXc48pv",
+	"T1clIQSLAJMD+w58bcqvB5ESd612XlswcUNpmKjWknvTApFzE8qMmSP+VtBFT22Gx1Zo23fpE9sl2kYV",
+	"HaJCKHdzLt2CEpnEXrF1FrFWPnGzlKHRfKQLdyfdODgb+waYxCyuXOrLbQM/MIFqk9OzX//9+PDi6vJc",
+	"N9E6O/7p7Pj856uTjxfHZ78dvJ+M0L69YRrZAwvtbsLb1zvbBIv5zatbOKy3y1zY46cV8gUWN6RAvo+o",
+	"LU9kHegmDRai9zCa5KQsdU6MUUMsHJOoJIZjtJP/HJ6q4UeiNMYnLsx2RtQIQe8fEeoL6i0syJQIU1vV",
+	"RlP7ctLuqhXmyEIVSMbtONDvHbogjMZsv8u4n3Q/Hla2Q0uvo4+acTGgUBN2FUl90K1slXNUM1u4Q4t/",
+	"H8Gkl4WciGxCAT+SPfvMGbgbv7pics1XsZqbH3xKzpNKwzcvXmbkjx/+dy2c0fZxxUe+cw0lnbo+cU3w",
+	"zo5P358cHpxf/XTyXoetBv0J8OlYUvBBgUxh/A5xZvxernzJCLn0Ey92IF9eUBd8asExpdRBq7Pw2gcN",
+	"FTZg1j5VPtvpadXWF2875WzXFltx4r1VZlxzzYTE93YBp6kmKrPoEqN18OsdnJ6g7yZWLd37BP8/Ofo8",
+	"+WtmpQWgM67b0gg2jo6JvjhzJEt+F90sTAldkK0LWkCaj29aODk4Pbk6vfzx/cmh7pU4GaFTc1bjOjqs",
+	"GDN9lpXV3qURWaG61WbKx+dVhgjv8XBuPJ1ma2sqVHUjnfvBgedG1yNwY3TDfvZA9HSKNhkqtjvzsVR0",
+	"kdQBjzy6tYSJkp8RRvKOwt2Oo1YRci70wbERBVk3SNfsnCS2608I+lma4nmSe5cvFX4gWxeovraV/Dbs",
+	"HI2Xst+xYfrqVESgAi9Ntw57P8nardm6ddY2CxqTR8b41jbGNVpar4IuLgcVSn60K180t2Az3HjM9tRM",
+	"SFXX8BBsFnzSZCfUNQNvhqj2hHts5nd5yo7b6UMSI84SWL8x0pNA5wQWeNmAv88bUZAKC1ULsjGltI6j",
+	"WrWTT9N/fXWMU0x22zoQChCScZhOs0O6Q1U8zerdaRUdDd0j/YVtkKVqkWYDF8+SjLUwE6zuKzl1lviN",
+	"eEqnT2WCqzykgaPSbtGtP/BehL5yRqTofaW1p9F47Y8b0LWXl1kEpvc3rgGdKB0UxZjaPHdXRcl1o4N6",
+	"gDK6qEMVa8XRFBILurWv5jW7Obc96ftrOMFrJjVV/4sUdmKZRSlUinNzxUPQPYwVyPab2rBsIr43OT8r",
+	"wIHlaTBCMtdmgwuyMO2aVllcYPtQSaaqYRn0k8Y+ABOq0khW6y/S7GdfbbOAnxvzy1VlKgMIzTrRmcsP",
+	"90abYLrQZOOKWm50N42ht/wnuT0J2BsbFqe89KNuA2hMitwGdcfiwmJae6f5vJ179ySVzqyPNqob/wCf",
+	"czxnc83tg5JFZzi9V2nqTzGgZPvShAQgZZEuf9ebRNFanhliVcMD/QVlUw5jUVUSiIwQWAmuYdEXtUE2",
+	"cPWc3g1eaEenhoFXhOGKDt4NXo32R6+s0x4A38MV3bt9sQfe1L2Sz4ahhejMxNTpsQEBWlHTHU1D/9Fs",
+	"4G+W+s2X+/tRsIn+J678/XnvnzaKz8jBdVIyTALr7ulSKs0duV7oEp4GOlT6h4349qibqTS1XROrO2+v",
+	"DooNuEaIT7GwQAK+we3XxWjEi0z0kUbW6/39vuE9vFGnX9vkt7E1hzDYZrvzOWsR5iJ0bF1Fme3Grk+I",
+	"zfZUCZxGr6CFeadNqybioPlaEy8RqXZzE+F16lt4uBxBrLCOMYr6BIARxwwooW2p7wimu6lOPhxoG/rH",
+	"g4+HKas6CMXOOUnhevenJYnmL3dmHrDLifPT2PZj6MeLuHBWxl4iMAqdI4POmYhK41ZcJg7EoSAhjOaJ",
+	"9qcxhw3V+cJ75BaY2Bv7yLVrfDgnywZvNvnuhJnc2nPYsyQXBEhMzxbftjq5r97pf3L02Zz+kiSDFiL1",
+	"VypeSXRNtG3ApoLE5ZNOQrR75suAs5BpbhQNMNCNWVWLWcdR5yMY85uZ0L0FM+MV0T9q44wLfBCk1qWt",
+	"0JEG2niHDfxF0LtNV3bfSNtkhIoZMQ8y63qy7bU0LClGBBNENF5pEiSKCNnrqg+v7EV5Br93KPRlIg7V",
+	"gm7XYovYGHwbGB9DYq/3X/dPaey2NSt2SIwGefEFSg/eJ1wtJD+aIpE7RPSXZAXf1P5oDcGdFt3Ds7AR",
+	"0vm8u0GNmMlH78/uBUUqpvP5CAoDXYGqb5BKfIEBKwNMhr3cTLLs5b7uvtUhWiG0IK9sGxU/R2jZbn27",
+	"gIKWMead9dI5c7YDLLMWuWzMXAAugId0uK7MrOPdxF7ajMBKVyalOpbWVuiz5dviVyjzPTXGzHiGR+hX",
+	"W00C4t8rSlzNl1ZyY0hkbDSO70llhHA9xdE150oqgSuLHdsj1WIBV1VKYEGfg+d7TGPwvuopBUBSRxUe",
+	"fJsnFUBvituNzmjU+CM2zCQBD9dqvLA6GWUQSB6PkplGlkG/a/YW6ZP9xzEgX1EH2MjPEkn8VspCn3Ig",
+	"v5qUb7Vaam8XyP00fw5cLc1t0XdVFDIhte01akPkgyYMqzPRLkiYkJ6/jpniDdBSpNWiHmdWpr5Jvi8j",
+	"UXAChSQgDno0ZpfuKtLk4fpC0uDyNlHF8HQbbAaJE5Jo8jLFkQzjjgBJMl9eLe1WXvDjBsk/O0YcQH22",
+	"1+ru7n9b7JhXzU4wTW0mCohprLDDshvqlCHlodNYmjf31N21UXz9MYSYfRpQjbI/agItL2ywRFQlo0E7",
+	"WUQHHa/FTiq6d7l8aoNh3S4xdAf0sxPih/btNHdU01VCLMeyvsPKbN7uSPMM0GGI0yAIohjdXq64pcfk",
+	"ZNp0PW8p3ST/DWT1YetOsEOsv6dSoTwxftIA3+oa7r6D3nGm1L4vdGa2cIM0A11JecoFgXL7Jmc4pLqO",
+	"0HlcPc0Oaq1okPATQoE75vqdsZknknc9bSi+sNBrUeMa6ls+A4PyudMeU2xiE1El9z7Zf33ec7FWQ8WH",
+	"vvhVr22gEVzYOgVYrAgutNftVisdKkzXrUwnO9qck26RHFRQYbMTdWevg3Ob1hNCwKPyob69LxwSznLd",
+	"64OlapI60Cz8I3QWp/Ka70NiiclKheYFqeN2lirt82i5bhPtn4dY3z0D6G1+9dnygMaRf7HrI39mSX/V",
+	"oY8DXL8RRcV7LOK71y4VFX+2Pdvpq1+3mhu5t4dR/84+HafZdfD56zhNeDdRclx/RVK00Wj7hTByR6QL",
+	"Zv/6Ugg0pzakvZqTW53skwbYRDTYXFKbIH29RIcnkXcdhIVn+2PmgqJNFqJJTQAp0LbzylaLijiJfoQc",
+	"cK7DiHnHQxdKcrrg5bYKpp8JGEREiUMtCZHsn/kM1bKVjT6/sHLWPkbdY3Pc6RnqjtEzOCMOlVAKYCve",
+	"GBXp7mOJtlz3s2eFBs5NWKBdUbsh8DPhdW5LVhhmW9lSLiAWPrSFYKZcdHhSKLHQzBEdmV/N980M0UYt",
+	"BvujFcY2czSuG6+NqlmzMomb20I3ZmBDQp5OUKMKhDYcJ62rYO+1O/wMLaoReNswsN2pmo74+4j9OcYp",
+	"TR3MGzCovU/mHyvjlQ6T50EqXvni8F7Tsf+wLQaCcEc3pFKjnmCgxxNg+sY1deNufOHazAJq997g65ux",
+	"gDqon+heYbZyU/rTjqNVlvZLVlF2GGqI/l9jYu8BqFM/9Qnh8k2fNzP3g6b8Tdr6TRV8U0B596Z+HHtB",
+	"NzHwa5L/Voz7ZkVrvfCAWocH+VxUvdg73XulPewxg3YDiFBvs6NOafQxa/XA6gQucUaihpcVZVFf1BHS",
+	"CO0mEvtiJXCxXQVp40pb0eRN9nQnjPWJVL4A3Nd1J1BmZu7xJXiW8pXJ/RRML5EZz9vK18hfG68x1FeL",
+	"VbfU0F/3+fOuAOsmvKsRl9gqF/psDXZxmE3/TfbCQK/fQtck5wsibWP+bFW/fuPtNM12g9Gu2ZM37TxR",
+	"kKXpsf8MjWItEL8Oc4kJtEuQH8ldvL/Pwf4FWLN50BFgG3OWPV1HspMN06IeoLjdUE/6Gggw7P4WqLvJ",
+	"2OPyrSjGGuTG/Q9xAYWAbW/paDk705X1iAjHBIToYkEKihUp1xKTwkraQla9cbShVJJWiUyxPudIIMJ4",
+	"CbK41qs3nkGVocYHpjrumEVjCluaLITellxX04XBfGVoX7yCFDPbJCiLamZJJbDO6xozqHeWl7yOsrh0",
+	"zSUbc6k5dU6k1Gm3ZnK6MBViU6z370RBNrwD19ScetwJaiL3Vw1bsx9q6N6WuMhGBX0Cza4uFfS5t4FJ",
+	"+q4MyQiN8W2ZsMG7F/v768t3fn50/c4ki3gCjSaxtxtoNvCVpz2kzxCViubP4X62ArYNGIGrsjYUpCRY",
+	"kk15Qrv8VVRmz4xjS2W2233LTjGyEqpl23eBWaRPpZvhzEywi3P5r30UkgjdJPiwtdXP6zishG6DAwH7",
+	"KKha9h6EAyvyoO6jrToQZQ37biZgGdZ6ARSfB7K3reCjGsChFI/rBIejur+Jw+J65AfBmj4s0F6CFAbY",
+	"Z3/RBDBPHOqhh91mpGiXaeXprtmqbV5iqqQhTxp2D8ySN6Iqs7lDU9yul7KaJfAEybnw3V0mBx8P3v/X",
+	"xcnh+dX5wYfT98dXZwcXx5Oe2o5Z5Eq1hEVwPk/13LSc3PWvdOGPUYwhVWjGVaZf1jAxqUSdqzG707jB",
+	"nTHhxxm9JcwEB6JfXc9DCdX8C7dAczs2i+yh43a/2t1qYucKC5/Qo2E1jVIy9PI1mvNaSGQjkSeKT6Li",
+	"qT2KmtZD00raiha5XaiOWZGCSdf8DSBkGs2w7a9eIF230doXJhqISQ+Aiu8APNhNu4ONxryZBo/qhh8b",
+	"d/btgdM3DV7jpEl0/X1o0eGX32+41qi59Uov2OO9SwHCbdxe/zr6S5s3bBZTaHlq3ItIPovAqDRgm8gW",
+	"V1stKVPObG2RDBE9rdHe//ZGzV1jTyhliBVh+TKudu1ZMagrmmtPuWh1gKqICO/BwJprGpFEsCipt/aO",
+	"kIPDRcYme0wFoWUn0QzExt1AZxbMWhjqFD8MtoW+2/3pyaXG2O4v9v9PnDwAvBBLGigJFA1VC2ajXmdE",
+	"6VCtS9vbRGsra7HnRxtsZ5Z8CuW2QXGbmBtOT2zLkOdlaUiCtZJD1b4fT7o816lJ+nq2ob8N+GDkLxwu",
+	"1wDgzM7SX73FZ9E9zn7+amfw/0fNFT6+zwkpSNFrRb/wVVKbgQGm+w6Bz9cWvjWg/60/fzrqzwe9jFq1",
+	"xaF08tx0wtMHAZ0c7fDw2I20ZVI28iibl1y7szUl0EywWCObAKQp2L/hLptZk5yrd4b1T/qfVMlO+TO4",
+	"9zI+bqIITPpS4w5Kz8qouNmamjLBpb7AN7rDThwhAsM2/KiIM5IhSIFz/SSpghyb/gJoj2ci2dqXgyV9",
+	"I4eWeX2HYY3p4dvxh8lTEHXgpL5eWzA0hQ2i0kXgKI6ogqNClTFMRamCM2i5MmZAZXXIAzYEYwfzFZZd",
+	"vfFrjw1v6915wGTtU4z6YjC+Nqns77ik2AqBUBCFaSm/EO11irqEvfA12xJXbMvFbTFpX2GRFyZhPHQA",
+	"b2kPesivsJdPoGeElXydQIp+OrrwRVyLaDe/CB/blUsAoG8TWdwvcVtBHPnP0/FCByUABeZTXz+I2DZQ",
+	"oQK87ZPATEs67VMggjQUnTmXoLiY3tk4n+tquaMxMz0J9OGQShC8CNnb9sss1CPlU2P8rbBQaFFrBz0J",
+	"V2oQzlBmwZXd15ZdwqImxEGnSElf057C7OHj3Q07O4yLulRUL3lPX0yHBTY948NxwEVBTWW802Yde3eP",
+	"NZmXKed6oij9bo9qs7J+pwnJAxuTN8dJF9dPtb5w3z3VwW8UNkoqL5dBRXeRNdcEOhEuTE+LndaShONp",
+	"dSXXw17wejZ31rGt+YVplLIqLPRwDg1UGk1xvtAxWv+uBU5TwylW86dVaVKYSJtyoX8OXGGczzU0pHGN",
+	"N/41aFarWs0WPHDBq2Vg7w+kWYDIXTl7hJ2UZHFdurogZgv0rlhXdeg7ZNLEpanW8uHoDTjEQ7uXfrGT",
+	"qGJnoGoQyzd1YF73NOLHFpv/ItzWEU9oVhTO8OMI9xP8/4QV5B4sJ8mclUiPqkrohaV4i4tAwyJjj46i",
+	"mfQr7ow5B0Zmm3K7Nq37une7LaarX3e6lyTM1g/G6BpL8vY1IiznevH6UBTU9hW3kVXAU4dwXKBlcb8S",
+	"BmT3jE9BXxkft0+bhBRv76j0TZHt7AaLYf4Iw0+bJmj2epvyQTxXRA2Nit+UoGu11E2U0gSTgD3719T4",
+	"sD2mj+A8ANyKbiTw/PkaKhvIffi+/23HZglg0h+ohEC6lC541GgYh+5Is59bIhSPiqB2AIPX+orTSLIx",
+	"MwP5TtFQkWdBFC6wwiO9hvaAraZ1WbOcMbSUj1wPkZW+n8J3ahs1pPdQe0dBp9O9T2DavYy9EcmQA51X",
+	"Je1W4KLQS7bpmL5avTYmfXe9RJZIAOt/dRKvmaip14tFsG9YjRAdGHxa4X1Dq8rFS7orG1kCJUCRJGUk",
+	"sgkPMAMmPQl0Ot1BRa0txWhCJjZQvVIqrckE+ALWZ4205KmkU3t8tL9B3RESV1CR30qKz1NbJw05Rv7P",
+	"Ox5QtOU5hd7zy0jb7dRANas5Nu9920bz1mqem+H8MvLCGr8y4PwbM5672qoG+sdZza3/uVdwmLuSqBkK",
+	"DatjUYmLQiJs3dgZoiwv68K9Y4O0Rc1kFnujV4XJn/ppzixo34hLcNPmD43VbdgFwiHe7dY2qvwOzVl2",
+	"+ijIwwNWs0e6b1yQe7+m7kqhPl9dfUeFWHdYA9WUQH3UpnAjtXr6e3mGf2Zf/ebll13IcxdddmtcyDCe",
+	"fcs+4O5iHsdMIJJqfdpk5ipD+NRJKDdjz00r8i3zCdJwbTGNXeLAZ13DW97F3Ysa3W1t5rRPvLCGSstT",
+	"M190/05QpQizd6sxc21z7Ywv9pEkOWeFXJmfs4tQ6mcUTWOWk4rLLTj8sxOW++XpOQHK46jY2EqGhlBW",
+	"OSq/hofy6fd8nbfxMjjVoh55DZvP11KUrL+vEnwmiGw4P2UEIRdbUscaKtiFkaTji1EkFIO9Xlq21RPh",
+	"7x9ue7ZhhzeYvVUKrAeMTp3BXWZ++cKCG4D7bDLTnvK0BtJbFQx/gEooxTLdha1ph0dVZ65sY9qRe9fL",
+	"oYtHH9Ji75P7o2l/7TmePy6P/eu7LwJE4rG38pqFvM0GfI+tBvry+ycmvq8WbPuRf5kkhRCx63J5G/NE",
+	"FFuQ63rWSGtfkYEoeXnbTE1wuYQmTdymBevrhlbMFTQPMFCbuj+2M6OqBZNozu8Q1ennWKKK5jekMNmF",
+	"VNg5Jge1mnNB/wTMvkM/EiyIQKYm+MHpydXR8Y+Xf7+6+PWX44+uNni/Z/1IrzTKNe0epBS7dUe5eIzt",
+	"vnlWVolOqGTeKbIZZV7jqtpWjn2Jerk9ScyumvvTQqGZxvf9QDyNxNSTvnnxcsW0tRCEWQ7+mKpPh42B",
+	"+iveVDi/wTPyM5bzVWvt+9yXBF/5ZYtcmxUCps1K/FzYf16ZQgFXFDJY290Edl884AsID2AhFwLnJB3T",
+	"KHlZ6z+QMu88RpC86PLiD6E2DWW3+iMEnBwpfkPY5h1InQ/bfBwSuLEgqKASu/i1Hcml4/uqxNY7LIis",
+	"S9W47BqTSEM+zQku1bxXRfoZHjt+vsNQbRBXXQTyG3D7T7G1kt7NdaifXg5lwwVZcB0jpj+FXoeSFCiq",
+	"enxGCipTpx2++MmOKbuzHsKIUSa/Lcl3vWxN7eGSlOUuAQELNWjWi3j7OlkvIm8VG+qqLxAVB1u2cVWd",
+	"jWb+oyZ1Etsa2TXDt5iWmhYzG1RhCBTnOTFFgzrVBKJK1GGLYBbYmDBiajuC7SS5+QWZCVy4MNcwsD1K",
+	"YfuZT/RIJdA3IvrtlJuE8uvTRXOYwRyOZeuQmTOROEumeXazU9l9xVd2J/Pp+ru4d7QD9k6mw4+ckeEH",
+	"cBNsJXnOtcy5Xmp9yJab0KLb19IkDFiXrTswkXSW6fqatPhhPFhgysYDXX5g9sN4ICQe3r64ejOUc/zy",
+	"zdvxYDIaswtT2IJOiSkEGmooQe4NBf2UIZsHFDpwRZU8W/Us9DsmWVY/PDnKvG1Xf4RVLWBHIaLUMki9",
+	"N8Pw1CDPj4uFdYYmMav3bXh8X5FcDc/dEBtpBcmRToMet0sdalMNrvrC0ydxcGb06uHTGIg2Vqmtej+8",
+	"/cJgJHFiVdOhYRHD7bXch4IHI65TvW37xSH9CmD1FUdxJbZAN5hGtdp4HTThDq4PzodGuR2eHDXWspt7",
+	"TE/VLFfT34JuGtpqjlbUuX7FCEDoEU3USk5kRxp+xAvyhWpqbbIU2AQvxTuQOpZrmqVIwtRqm+yQfa3l",
+	"2QZGdnW4qjK9Jd1OZ81GVX+RBhEy9Bt2phttl5kRZeovhzHi7lXaBmrGkZEgetClco0m8dGk1W5yrQvJ",
+	"ogt6b/o998/cbbmob6++hrPZfZgIFPChzi8QvFw9aDY4vsCzrvL4D4JvkMIzvQVOtZAZKoigt849bNox",
+	"h0jaTlnpldOCoBZc8ZyXXkq9+7T+o/Pp7Wbv6y9epe6VDX0JQqmtCa+h4SHPGyLUOmytnvUZ2Pxj4tBP",
+	"bRSccUTtmZ6EK+KQ4GVn+nhPZjhfHsE33gP7JH0CExO6ILKNg1Tatg39OVQppY/qfNWqmwejOnh9NVNz",
+	"McpQCQvwme72ZssKXHIWx0fAFqb2x4ZvbLlDUYHxL7VHdspvYpccVh+0P3Vki+/zOZxrMYwl2rvdH/kL",
+	"rKt1bke4gpuurZSb4wUpD7EkoeGnCaOZUlIWclVBcoP/vttuSrrhqnqAmb1Paw1tTU2vj0cP+EiDMJUQ",
+	"0s56bhnXnJcEs/7vjQH3Eky/25tx7Xdb+BO18n0lpvnrFy9f7lqv2K7KAxjq2ZRvd/Ivg2xpFnvww21i",
+	"HvJH7bE1XjoCsDXyg8588hgbRn0lHyJJv6AM/Wal5+ao31JIflHx+E0KxhWoj4XXyoIidswtBdPV7VNI",
+	"pqubnYqmq/mDZdNVvgPhFByT/2ri6YpuIZ9WSqYr+uxEk5ncBlHDIWmnGd+SkleaoJ10yga1KAfvBnOl",
+	"qnd7e9DHas6levf9/vf7g8+/f/4/AwCWmKJd21ABAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -9,6 +9,48 @@ import (
 	"context"
 )
 
+// iteratorForCreateUpdateCheckEvents implements pgx.CopyFromSource.
+type iteratorForCreateUpdateCheckEvents struct {
+	rows                 []CreateUpdateCheckEventsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateUpdateCheckEvents) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateUpdateCheckEvents) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ProjectID,
+		r.rows[0].Channel,
+		r.rows[0].RuntimeVersion,
+		r.rows[0].Platform,
+		r.rows[0].Decision,
+		r.rows[0].UpdateID,
+		r.rows[0].CheckedAt,
+		r.rows[0].Reason,
+		r.rows[0].ClientID,
+		r.rows[0].CurrentUpdateID,
+		r.rows[0].CodepushLabel,
+	}, nil
+}
+
+func (r iteratorForCreateUpdateCheckEvents) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateUpdateCheckEvents(ctx context.Context, arg []CreateUpdateCheckEventsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"update_check_events"}, []string{"project_id", "channel", "runtime_version", "platform", "decision", "update_id", "checked_at", "reason", "client_id", "current_update_id", "codepush_label"}, &iteratorForCreateUpdateCheckEvents{rows: arg})
+}
+
 // iteratorForCreateUpdateAssets implements pgx.CopyFromSource.
 type iteratorForCreateUpdateAssets struct {
 	rows                 []CreateUpdateAssetsParams
@@ -33,6 +75,7 @@ func (r iteratorForCreateUpdateAssets) Values() ([]interface{}, error) {
 		r.rows[0].UpdateID,
 		r.rows[0].StorageObjectPath,
 		r.rows[0].ContentType,
+		r.rows[0].ContentEncoding,
 		r.rows[0].Extension,
 		r.rows[0].ContentMd5,
 		r.rows[0].ContentSha256,
@@ -48,5 +91,47 @@ func (r iteratorForCreateUpdateAssets) Err() error {
 }
 
 func (q *Queries) CreateUpdateAssets(ctx context.Context, arg []CreateUpdateAssetsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"update_assets"}, []string{"id", "update_id", "storage_object_path", "content_type", "extension", "content_md5", "content_sha256", "is_launch_asset", "is_archive", "platform", "content_length"}, &iteratorForCreateUpdateAssets{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"update_assets"}, []string{"id", "update_id", "storage_object_path", "content_type", "content_encoding", "extension", "content_md5", "content_sha256", "is_launch_asset", "is_archive", "platform", "content_length"}, &iteratorForCreateUpdateAssets{rows: arg})
+}
+
+// iteratorForCreateUpdateStorageObjects implements pgx.CopyFromSource.
+type iteratorForCreateUpdateStorageObjects struct {
+	rows                 []CreateUpdateStorageObjectsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateUpdateStorageObjects) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateUpdateStorageObjects) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].UpdateID,
+		r.rows[0].Path,
+		r.rows[0].ContentType,
+		r.rows[0].ContentEncoding,
+		r.rows[0].Extension,
+		r.rows[0].ContentMd5,
+		r.rows[0].ContentSha256,
+		r.rows[0].ContentLength,
+		r.rows[0].IsArchive,
+		r.rows[0].SharedObjectKey,
+	}, nil
+}
+
+func (r iteratorForCreateUpdateStorageObjects) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateUpdateStorageObjects(ctx context.Context, arg []CreateUpdateStorageObjectsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"update_storage_objects"}, []string{"id", "update_id", "path", "content_type", "content_encoding", "extension", "content_md5", "content_sha256", "content_length", "is_archive", "shared_object_key"}, &iteratorForCreateUpdateStorageObjects{rows: arg})
 }
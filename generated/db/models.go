@@ -100,28 +100,192 @@ func (ns NullUpdateStatus) Value() (driver.Value, error) {
 	return string(ns.UpdateStatus), nil
 }
 
-type Project struct {
-	ID             uuid.UUID
-	Name           string
-	UpdateProtocol UpdateProtocol
-	CreatedAt      pgtype.Timestamptz
+type ApiUsageStat struct {
+	ProjectID    uuid.UUID
+	Operation    string
+	PeriodStart  pgtype.Timestamptz
+	LatencyLeMs  int32
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
 }
 
-type Update struct {
-	ID             uuid.UUID
+type AssetDownloadStat struct {
+	ObjectKey        string
+	ProjectID        uuid.UUID
+	UpdateID         uuid.UUID
+	Path             string
+	Downloads        int64
+	BytesServed      int64
+	LastDownloadedAt pgtype.Timestamptz
+}
+
+type AssetIntegrityCheck struct {
+	AssetID     uuid.UUID
+	Status      string
+	ActualHash  pgtype.Text
+	Error       pgtype.Text
+	Quarantined bool
+	CheckedAt   pgtype.Timestamptz
+}
+
+type ChannelHead struct {
+	ProjectID              uuid.UUID
+	Channel                string
+	RuntimeVersion         string
+	Platform               string
+	PublishedUpdateID      pgtype.UUID
+	PublishedContentSha256 pgtype.Text
+	CanceledUpdateID       pgtype.UUID
+	CanceledContentSha256  pgtype.Text
+	Exact                  bool
+	RefreshedAt            pgtype.Timestamptz
+}
+
+type ChannelPin struct {
 	ProjectID      uuid.UUID
-	RuntimeVersion string
-	Status         UpdateStatus
-	Message        pgtype.Text
 	Channel        string
+	RuntimeVersion string
+	UpdateID       uuid.UUID
 	CreatedAt      pgtype.Timestamptz
 }
 
+type ChannelPolicy struct {
+	ProjectID                uuid.UUID
+	Channel                  string
+	RuntimeVersionConstraint pgtype.Text
+	MessagePattern           pgtype.Text
+	UpdatedAt                pgtype.Timestamptz
+}
+
+type ChannelRollback struct {
+	ProjectID      uuid.UUID
+	Channel        string
+	RuntimeVersion string
+	CommittedAt    pgtype.Timestamptz
+}
+
+type CodepushDiffPackage struct {
+	UpdateID          uuid.UUID
+	Platform          string
+	BasePackageHash   string
+	StorageObjectPath string
+	ContentMd5        string
+	ContentLength     int64
+	CreatedAt         pgtype.Timestamptz
+}
+
+type CodepushLabelSequence struct {
+	ProjectID  uuid.UUID
+	Channel    string
+	LastNumber int32
+}
+
+type CodepushReleaseStat struct {
+	UpdateID             uuid.UUID
+	ProjectID            uuid.UUID
+	Downloads            int64
+	DeploymentsSucceeded int64
+	DeploymentsFailed    int64
+	LastReportedAt       pgtype.Timestamptz
+}
+
+type EmbeddedUpdate struct {
+	ProjectID         uuid.UUID
+	Platform          string
+	EmbeddedID        string
+	RuntimeVersion    string
+	LaunchAssetSha256 string
+	CreatedAt         pgtype.Timestamptz
+}
+
+type MaintenanceMode struct {
+	ID        bool
+	Enabled   bool
+	UpdatedAt pgtype.Timestamptz
+}
+
+type Project struct {
+	ID                          uuid.UUID
+	Name                        string
+	UpdateProtocol              UpdateProtocol
+	PublicAssetsUrl             pgtype.Text
+	AssetUrlTemplate            pgtype.Text
+	ReplicaRegions              []string
+	Environment                 string
+	AdminAllowedCidrs           []string
+	MaxAssetCount               int32
+	CodepushSuggestBinaryUpdate bool
+	CodepushDescriptionSource   string
+	StableAssetUrls             bool
+	StorageDriverUrl            pgtype.Text
+	CreatedAt                   pgtype.Timestamptz
+	ArchivedAt                  pgtype.Timestamptz
+	DeletedAt                   pgtype.Timestamptz
+	AssetPriorities             []string
+	MeteredDownloadLimit        int64
+	MaxStorageBytes             int64
+}
+
+type ProjectFlavor struct {
+	ProjectID uuid.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type ProjectUsage struct {
+	ProjectID    uuid.UUID
+	StorageBytes int64
+	UpdatedAt    pgtype.Timestamptz
+}
+
+type SigningKey struct {
+	ID               uuid.UUID
+	ProjectID        uuid.UUID
+	KeyID            string
+	Algorithm        string
+	PrivateKey       string
+	CertificateChain string
+	CreatedAt        pgtype.Timestamptz
+	RetiredAt        pgtype.Timestamptz
+}
+
+type Update struct {
+	ID                uuid.UUID
+	ProjectID         uuid.UUID
+	RuntimeVersion    string
+	Status            UpdateStatus
+	Message           pgtype.Text
+	Channel           string
+	CreatedAt         pgtype.Timestamptz
+	ContentHash       pgtype.Text
+	ColdStorageAt     pgtype.Timestamptz
+	Flavors           []string
+	ExpiresAt         pgtype.Timestamptz
+	RolloutPercentage int16
+	LinkedUpdateID    pgtype.UUID
+	CommittedAt       pgtype.Timestamptz
+	CodepushLabel     pgtype.Text
+	IsMandatory       bool
+	ExternalID        pgtype.Text
+}
+
+type UpdateAdoptionStat struct {
+	UpdateID   uuid.UUID
+	ProjectID  uuid.UUID
+	Day        pgtype.Date
+	Platform   string
+	Downloads  int64
+	Departures int64
+	Rollbacks  int64
+}
+
 type UpdateAsset struct {
 	ID                uuid.UUID
 	UpdateID          uuid.UUID
 	StorageObjectPath string
 	ContentType       string
+	ContentEncoding   string
 	Extension         string
 	ContentMd5        string
 	ContentSha256     string
@@ -132,9 +296,64 @@ type UpdateAsset struct {
 	CreatedAt         pgtype.Timestamptz
 }
 
+type UpdateCheckEvent struct {
+	ProjectID       uuid.UUID
+	Channel         string
+	RuntimeVersion  string
+	Platform        string
+	Decision        string
+	UpdateID        pgtype.UUID
+	CheckedAt       pgtype.Timestamptz
+	Reason          pgtype.Text
+	ClientID        pgtype.Text
+	CurrentUpdateID pgtype.UUID
+	CodepushLabel   pgtype.Text
+}
+
 type UpdateMetadatum struct {
 	ID            uuid.UUID
 	UpdateID      uuid.UUID
 	ExpoAppConfig []byte
 	CreatedAt     pgtype.Timestamptz
 }
+
+type UpdateOutbox struct {
+	UpdateID  uuid.UUID
+	CreatedAt pgtype.Timestamptz
+}
+
+type UpdateProcessingReport struct {
+	ID            uuid.UUID
+	UpdateID      uuid.UUID
+	Status        string
+	Error         pgtype.Text
+	AssetCount    int32
+	ArchiveCount  int32
+	UnpackedFiles int32
+	HashedFiles   int32
+	BytesHashed   int64
+	Warnings      []string
+	StartedAt     pgtype.Timestamptz
+	DurationMs    int64
+}
+
+type UpdateReplica struct {
+	UpdateID     uuid.UUID
+	Region       string
+	ReplicatedAt pgtype.Timestamptz
+}
+
+type UpdateStorageObject struct {
+	ID              uuid.UUID
+	UpdateID        uuid.UUID
+	Path            string
+	ContentType     string
+	ContentEncoding string
+	Extension       string
+	ContentMd5      string
+	ContentSha256   pgtype.Text
+	ContentLength   int64
+	IsArchive       bool
+	SharedObjectKey pgtype.Text
+	CreatedAt       pgtype.Timestamptz
+}
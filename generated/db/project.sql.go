@@ -9,28 +9,125 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cloneProject = `-- name: CloneProject :one
+INSERT INTO projects (id, name, update_protocol, public_assets_url, asset_url_template,
+                      replica_regions, environment, admin_allowed_cidrs, max_asset_count,
+                      codepush_suggest_binary_update, codepush_description_source,
+                      stable_asset_urls, storage_driver_url, asset_priorities,
+                      metered_download_limit, max_storage_bytes, created_at)
+SELECT $1,
+       $2,
+       update_protocol,
+       public_assets_url,
+       asset_url_template,
+       replica_regions,
+       $3,
+       admin_allowed_cidrs,
+       max_asset_count,
+       codepush_suggest_binary_update,
+       codepush_description_source,
+       stable_asset_urls,
+       storage_driver_url,
+       asset_priorities,
+       metered_download_limit,
+       max_storage_bytes,
+       current_timestamp
+FROM projects
+WHERE projects.id = $4
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+type CloneProjectParams struct {
+	ID          uuid.UUID
+	Name        string
+	Environment string
+	SourceID    uuid.UUID
+}
+
+func (q *Queries) CloneProject(ctx context.Context, arg CloneProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, cloneProject,
+		arg.ID,
+		arg.Name,
+		arg.Environment,
+		arg.SourceID,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
 const createProject = `-- name: CreateProject :one
-INSERT INTO projects (id, name, update_protocol, created_at)
-VALUES ($1, $2, $3, current_timestamp)
-RETURNING id, name, update_protocol, created_at
+INSERT INTO projects (id, name, update_protocol, environment, storage_driver_url, created_at)
+VALUES ($1, $2, $3, $4, $5, current_timestamp)
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
 `
 
-func (q *Queries) CreateProject(ctx context.Context, iD uuid.UUID, name string, updateProtocol UpdateProtocol) (Project, error) {
-	row := q.db.QueryRow(ctx, createProject, iD, name, updateProtocol)
+type CreateProjectParams struct {
+	ID               uuid.UUID
+	Name             string
+	UpdateProtocol   UpdateProtocol
+	Environment      string
+	StorageDriverUrl pgtype.Text
+}
+
+func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, createProject,
+		arg.ID,
+		arg.Name,
+		arg.UpdateProtocol,
+		arg.Environment,
+		arg.StorageDriverUrl,
+	)
 	var i Project
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
 	)
 	return i, err
 }
 
 const getProjectById = `-- name: GetProjectById :one
-SELECT id, name, update_protocol, created_at FROM projects WHERE id = $1
+SELECT id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes FROM projects WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetProjectById(ctx context.Context, id uuid.UUID) (Project, error) {
@@ -40,7 +137,623 @@ func (q *Queries) GetProjectById(ctx context.Context, id uuid.UUID) (Project, er
 		&i.ID,
 		&i.Name,
 		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const getProjectByNameAndEnvironment = `-- name: GetProjectByNameAndEnvironment :one
+SELECT id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes FROM projects WHERE name = $1 AND environment = $2
+`
+
+func (q *Queries) GetProjectByNameAndEnvironment(ctx context.Context, name string, environment string) (Project, error) {
+	row := q.db.QueryRow(ctx, getProjectByNameAndEnvironment, name, environment)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const getProjectDeletedAt = `-- name: GetProjectDeletedAt :one
+SELECT deleted_at FROM projects WHERE id = $1
+`
+
+func (q *Queries) GetProjectDeletedAt(ctx context.Context, id uuid.UUID) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getProjectDeletedAt, id)
+	var deleted_at pgtype.Timestamptz
+	err := row.Scan(&deleted_at)
+	return deleted_at, err
+}
+
+const getProjectEnvironments = `-- name: GetProjectEnvironments :many
+SELECT id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes FROM projects WHERE name = $1 AND deleted_at IS NULL ORDER BY environment
+`
+
+func (q *Queries) GetProjectEnvironments(ctx context.Context, name string) ([]Project, error) {
+	rows, err := q.db.Query(ctx, getProjectEnvironments, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UpdateProtocol,
+			&i.PublicAssetsUrl,
+			&i.AssetUrlTemplate,
+			&i.ReplicaRegions,
+			&i.Environment,
+			&i.AdminAllowedCidrs,
+			&i.MaxAssetCount,
+			&i.CodepushSuggestBinaryUpdate,
+			&i.CodepushDescriptionSource,
+			&i.StableAssetUrls,
+			&i.StorageDriverUrl,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.DeletedAt,
+			&i.AssetPriorities,
+			&i.MeteredDownloadLimit,
+			&i.MaxStorageBytes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectIDs = `-- name: GetProjectIDs :many
+SELECT id FROM projects WHERE deleted_at IS NULL ORDER BY id
+`
+
+func (q *Queries) GetProjectIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectMaxAssetCount = `-- name: GetProjectMaxAssetCount :one
+SELECT max_asset_count FROM projects WHERE id = $1
+`
+
+func (q *Queries) GetProjectMaxAssetCount(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRow(ctx, getProjectMaxAssetCount, id)
+	var max_asset_count int32
+	err := row.Scan(&max_asset_count)
+	return max_asset_count, err
+}
+
+const getProjectStorageDriverURL = `-- name: GetProjectStorageDriverURL :one
+SELECT storage_driver_url FROM projects WHERE id = $1
+`
+
+func (q *Queries) GetProjectStorageDriverURL(ctx context.Context, id uuid.UUID) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getProjectStorageDriverURL, id)
+	var storage_driver_url pgtype.Text
+	err := row.Scan(&storage_driver_url)
+	return storage_driver_url, err
+}
+
+const getProjectUpdateProtocol = `-- name: GetProjectUpdateProtocol :one
+SELECT update_protocol FROM projects WHERE id = $1
+`
+
+func (q *Queries) GetProjectUpdateProtocol(ctx context.Context, id uuid.UUID) (UpdateProtocol, error) {
+	row := q.db.QueryRow(ctx, getProjectUpdateProtocol, id)
+	var update_protocol UpdateProtocol
+	err := row.Scan(&update_protocol)
+	return update_protocol, err
+}
+
+const setProjectAdminAllowedCIDRs = `-- name: SetProjectAdminAllowedCIDRs :one
+UPDATE projects
+SET admin_allowed_cidrs = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectAdminAllowedCIDRs(ctx context.Context, iD uuid.UUID, adminAllowedCidrs []string) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectAdminAllowedCIDRs, iD, adminAllowedCidrs)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectArchivedAt = `-- name: SetProjectArchivedAt :one
+UPDATE projects
+SET archived_at = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectArchivedAt(ctx context.Context, iD uuid.UUID, archivedAt pgtype.Timestamptz) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectArchivedAt, iD, archivedAt)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectAssetPriorities = `-- name: SetProjectAssetPriorities :one
+UPDATE projects
+SET asset_priorities = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectAssetPriorities(ctx context.Context, iD uuid.UUID, assetPriorities []string) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectAssetPriorities, iD, assetPriorities)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectAssetURLTemplate = `-- name: SetProjectAssetURLTemplate :one
+UPDATE projects
+SET asset_url_template = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectAssetURLTemplate(ctx context.Context, iD uuid.UUID, assetUrlTemplate pgtype.Text) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectAssetURLTemplate, iD, assetUrlTemplate)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectCodePushSettings = `-- name: SetProjectCodePushSettings :one
+UPDATE projects
+SET codepush_suggest_binary_update = $2,
+    codepush_description_source    = $3
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectCodePushSettings(ctx context.Context, iD uuid.UUID, codepushSuggestBinaryUpdate bool, codepushDescriptionSource string) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectCodePushSettings, iD, codepushSuggestBinaryUpdate, codepushDescriptionSource)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectConfig = `-- name: SetProjectConfig :one
+UPDATE projects
+SET public_assets_url  = $2,
+    asset_url_template = $3,
+    replica_regions    = $4
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+type SetProjectConfigParams struct {
+	ID               uuid.UUID
+	PublicAssetsUrl  pgtype.Text
+	AssetUrlTemplate pgtype.Text
+	ReplicaRegions   []string
+}
+
+func (q *Queries) SetProjectConfig(ctx context.Context, arg SetProjectConfigParams) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectConfig,
+		arg.ID,
+		arg.PublicAssetsUrl,
+		arg.AssetUrlTemplate,
+		arg.ReplicaRegions,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectMaxAssetCount = `-- name: SetProjectMaxAssetCount :one
+UPDATE projects
+SET max_asset_count = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectMaxAssetCount(ctx context.Context, iD uuid.UUID, maxAssetCount int32) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectMaxAssetCount, iD, maxAssetCount)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectMaxStorageBytes = `-- name: SetProjectMaxStorageBytes :one
+UPDATE projects
+SET max_storage_bytes = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectMaxStorageBytes(ctx context.Context, iD uuid.UUID, maxStorageBytes int64) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectMaxStorageBytes, iD, maxStorageBytes)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectMeteredDownloadLimit = `-- name: SetProjectMeteredDownloadLimit :one
+UPDATE projects
+SET metered_download_limit = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectMeteredDownloadLimit(ctx context.Context, iD uuid.UUID, meteredDownloadLimit int64) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectMeteredDownloadLimit, iD, meteredDownloadLimit)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectPublicAssetsURL = `-- name: SetProjectPublicAssetsURL :one
+UPDATE projects
+SET public_assets_url = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectPublicAssetsURL(ctx context.Context, iD uuid.UUID, publicAssetsUrl pgtype.Text) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectPublicAssetsURL, iD, publicAssetsUrl)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectReplicaRegions = `-- name: SetProjectReplicaRegions :one
+UPDATE projects
+SET replica_regions = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectReplicaRegions(ctx context.Context, iD uuid.UUID, replicaRegions []string) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectReplicaRegions, iD, replicaRegions)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const setProjectStableAssetUrls = `-- name: SetProjectStableAssetUrls :one
+UPDATE projects
+SET stable_asset_urls = $2
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+func (q *Queries) SetProjectStableAssetUrls(ctx context.Context, iD uuid.UUID, stableAssetUrls bool) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectStableAssetUrls, iD, stableAssetUrls)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
+	)
+	return i, err
+}
+
+const softDeleteProject = `-- name: SoftDeleteProject :one
+UPDATE projects
+SET deleted_at = coalesce(deleted_at, current_timestamp)
+WHERE id = $1
+RETURNING id, name, update_protocol, public_assets_url, asset_url_template, replica_regions, environment, admin_allowed_cidrs, max_asset_count, codepush_suggest_binary_update, codepush_description_source, stable_asset_urls, storage_driver_url, created_at, archived_at, deleted_at, asset_priorities, metered_download_limit, max_storage_bytes
+`
+
+// deleting the project again keeps the time of the first deletion
+func (q *Queries) SoftDeleteProject(ctx context.Context, id uuid.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, softDeleteProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UpdateProtocol,
+		&i.PublicAssetsUrl,
+		&i.AssetUrlTemplate,
+		&i.ReplicaRegions,
+		&i.Environment,
+		&i.AdminAllowedCidrs,
+		&i.MaxAssetCount,
+		&i.CodepushSuggestBinaryUpdate,
+		&i.CodepushDescriptionSource,
+		&i.StableAssetUrls,
+		&i.StorageDriverUrl,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.DeletedAt,
+		&i.AssetPriorities,
+		&i.MeteredDownloadLimit,
+		&i.MaxStorageBytes,
 	)
 	return i, err
 }
@@ -12,23 +12,53 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const copyUpdateMetadata = `-- name: CopyUpdateMetadata :exec
+insert into update_metadata (id, update_id, expo_app_config, created_at)
+select $1, $2, expo_app_config, current_timestamp
+from update_metadata
+where update_id = $3
+`
+
+func (q *Queries) CopyUpdateMetadata(ctx context.Context, iD uuid.UUID, targetUpdateID uuid.UUID, sourceUpdateID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, copyUpdateMetadata, iD, targetUpdateID, sourceUpdateID)
+	return err
+}
+
 const createUpdate = `-- name: CreateUpdate :exec
 INSERT INTO updates (id,
                      project_id,
                      runtime_version,
                      message,
                      channel,
+                     flavors,
+                     expires_at,
+                     rollout_percentage,
+                     linked_update_id,
+                     codepush_label,
+                     is_mandatory,
+                     external_id,
                      status,
                      created_at)
-VALUES ($1, $2, $3, $4, $5, 'empty', current_timestamp)
+VALUES ($1, $2, $3, $4, $5, coalesce($6::text[], '{}'), $7,
+        coalesce($8::smallint, 100), $9,
+        $10, $11, $12, 'empty',
+        coalesce($13, current_timestamp))
 `
 
 type CreateUpdateParams struct {
-	ID             uuid.UUID
-	ProjectID      uuid.UUID
-	RuntimeVersion string
-	Message        pgtype.Text
-	Channel        string
+	ID                uuid.UUID
+	ProjectID         uuid.UUID
+	RuntimeVersion    string
+	Message           pgtype.Text
+	Channel           string
+	Flavors           []string
+	ExpiresAt         pgtype.Timestamptz
+	RolloutPercentage pgtype.Int2
+	LinkedUpdateID    pgtype.UUID
+	CodepushLabel     pgtype.Text
+	IsMandatory       bool
+	ExternalID        pgtype.Text
+	CreatedAt         pgtype.Timestamptz
 }
 
 func (q *Queries) CreateUpdate(ctx context.Context, arg CreateUpdateParams) error {
@@ -38,6 +68,14 @@ func (q *Queries) CreateUpdate(ctx context.Context, arg CreateUpdateParams) erro
 		arg.RuntimeVersion,
 		arg.Message,
 		arg.Channel,
+		arg.Flavors,
+		arg.ExpiresAt,
+		arg.RolloutPercentage,
+		arg.LinkedUpdateID,
+		arg.CodepushLabel,
+		arg.IsMandatory,
+		arg.ExternalID,
+		arg.CreatedAt,
 	)
 	return err
 }
@@ -47,6 +85,7 @@ type CreateUpdateAssetsParams struct {
 	UpdateID          uuid.UUID
 	StorageObjectPath string
 	ContentType       string
+	ContentEncoding   string
 	Extension         string
 	ContentMd5        string
 	ContentSha256     string
@@ -69,8 +108,63 @@ func (q *Queries) CreateUpdateMetadata(ctx context.Context, iD uuid.UUID, update
 	return err
 }
 
+type CreateUpdateStorageObjectsParams struct {
+	ID              uuid.UUID
+	UpdateID        uuid.UUID
+	Path            string
+	ContentType     string
+	ContentEncoding string
+	Extension       string
+	ContentMd5      string
+	ContentSha256   pgtype.Text
+	ContentLength   int64
+	IsArchive       bool
+	SharedObjectKey pgtype.Text
+}
+
+const getAllUpdateAssets = `-- name: GetAllUpdateAssets :many
+select id, update_id, storage_object_path, content_type, content_encoding, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
+from update_assets
+where update_id = $1
+order by is_archive, platform, storage_object_path
+`
+
+func (q *Queries) GetAllUpdateAssets(ctx context.Context, updateID uuid.UUID) ([]UpdateAsset, error) {
+	rows, err := q.db.Query(ctx, getAllUpdateAssets, updateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpdateAsset
+	for rows.Next() {
+		var i UpdateAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.UpdateID,
+			&i.StorageObjectPath,
+			&i.ContentType,
+			&i.ContentEncoding,
+			&i.Extension,
+			&i.ContentMd5,
+			&i.ContentSha256,
+			&i.IsLaunchAsset,
+			&i.IsArchive,
+			&i.Platform,
+			&i.ContentLength,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastNUpdates = `-- name: GetLastNUpdates :many
-SELECT id, project_id, runtime_version, status, message, channel, created_at
+SELECT id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
 FROM updates
 WHERE project_id = $2
   AND (runtime_version = $3 OR $3 IS NULL)
@@ -111,6 +205,16 @@ func (q *Queries) GetLastNUpdates(ctx context.Context, arg GetLastNUpdatesParams
 			&i.Message,
 			&i.Channel,
 			&i.CreatedAt,
+			&i.ContentHash,
+			&i.ColdStorageAt,
+			&i.Flavors,
+			&i.ExpiresAt,
+			&i.RolloutPercentage,
+			&i.LinkedUpdateID,
+			&i.CommittedAt,
+			&i.CodepushLabel,
+			&i.IsMandatory,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -123,29 +227,40 @@ func (q *Queries) GetLastNUpdates(ctx context.Context, arg GetLastNUpdatesParams
 }
 
 const getLatestPublishedAndCanceledUpdates = `-- name: GetLatestPublishedAndCanceledUpdates :many
-select distinct on (updates.status) updates.id, updates.project_id, updates.runtime_version, updates.status, updates.message, updates.channel, updates.created_at, asset.content_sha256
+select distinct on (updates.status = 'published' and
+                    (updates.expires_at is null or updates.expires_at > $1))
+    updates.id, updates.project_id, updates.runtime_version, updates.status, updates.message, updates.channel, updates.created_at, updates.content_hash, updates.cold_storage_at, updates.flavors, updates.expires_at, updates.rollout_percentage, updates.linked_update_id, updates.committed_at, updates.codepush_label, updates.is_mandatory, updates.external_id, asset.content_sha256
 from updates
          left join update_assets asset
                    on updates.id = asset.update_id and
-                      asset.platform = $1 and
+                      asset.platform = $2 and
                       (asset.is_launch_asset = true or asset.is_archive = true)
-where updates.project_id = $2
-  and updates.runtime_version = $3
-  and updates.channel = $4
+where updates.project_id = $3
+  and updates.runtime_version = $4
+  and updates.channel = $5
   and updates.status in ('published', 'canceled')
-order by updates.status,
+  and (cardinality(updates.flavors) = 0 or $6::text = any (updates.flavors))
+  and (updates.rollout_percentage >= 100 or
+       updates.status = 'canceled' or
+       updates.expires_at <= $1 or
+       $7::int < updates.rollout_percentage)
+order by updates.status = 'published' and
+         (updates.expires_at is null or updates.expires_at > $1) desc,
          case
              when asset.is_archive = true then 1 -- select archive asset if exists
              else 2
              end,
-         updates.created_at desc
+         coalesce(updates.committed_at, updates.created_at) desc
 `
 
 type GetLatestPublishedAndCanceledUpdatesParams struct {
+	Now            pgtype.Timestamptz
 	Platform       string
 	ProjectID      uuid.UUID
 	RuntimeVersion string
 	Channel        string
+	Flavor         string
+	RolloutBucket  int32
 }
 
 type GetLatestPublishedAndCanceledUpdatesRow struct {
@@ -153,12 +268,16 @@ type GetLatestPublishedAndCanceledUpdatesRow struct {
 	ContentSha256 pgtype.Text
 }
 
+// expired updates are returned as the canceled ones
 func (q *Queries) GetLatestPublishedAndCanceledUpdates(ctx context.Context, arg GetLatestPublishedAndCanceledUpdatesParams) ([]GetLatestPublishedAndCanceledUpdatesRow, error) {
 	rows, err := q.db.Query(ctx, getLatestPublishedAndCanceledUpdates,
+		arg.Now,
 		arg.Platform,
 		arg.ProjectID,
 		arg.RuntimeVersion,
 		arg.Channel,
+		arg.Flavor,
+		arg.RolloutBucket,
 	)
 	if err != nil {
 		return nil, err
@@ -175,6 +294,16 @@ func (q *Queries) GetLatestPublishedAndCanceledUpdates(ctx context.Context, arg
 			&i.Update.Message,
 			&i.Update.Channel,
 			&i.Update.CreatedAt,
+			&i.Update.ContentHash,
+			&i.Update.ColdStorageAt,
+			&i.Update.Flavors,
+			&i.Update.ExpiresAt,
+			&i.Update.RolloutPercentage,
+			&i.Update.LinkedUpdateID,
+			&i.Update.CommittedAt,
+		&i.Update.CodepushLabel,
+		&i.Update.IsMandatory,
+		&i.Update.ExternalID,
 			&i.ContentSha256,
 		); err != nil {
 			return nil, err
@@ -187,8 +316,54 @@ func (q *Queries) GetLatestPublishedAndCanceledUpdates(ctx context.Context, arg
 	return items, nil
 }
 
+const getLatestPublishedUpdates = `-- name: GetLatestPublishedUpdates :many
+select distinct on (channel, runtime_version) id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+from updates
+where project_id = $1
+  and status = 'published'
+order by channel, runtime_version, coalesce(committed_at, created_at) desc
+`
+
+func (q *Queries) GetLatestPublishedUpdates(ctx context.Context, projectID uuid.UUID) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLatestPublishedUpdates, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Update
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.RuntimeVersion,
+			&i.Status,
+			&i.Message,
+			&i.Channel,
+			&i.CreatedAt,
+			&i.ContentHash,
+			&i.ColdStorageAt,
+			&i.Flavors,
+			&i.ExpiresAt,
+			&i.RolloutPercentage,
+			&i.LinkedUpdateID,
+			&i.CommittedAt,
+			&i.CodepushLabel,
+			&i.IsMandatory,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLaunchAssetOrArchiveByPlatform = `-- name: GetLaunchAssetOrArchiveByPlatform :one
-select id, update_id, storage_object_path, content_type, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
+select id, update_id, storage_object_path, content_type, content_encoding, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
 from update_assets
 where update_id = $1
   and (is_launch_asset = true or is_archive = true)
@@ -205,6 +380,7 @@ func (q *Queries) GetLaunchAssetOrArchiveByPlatform(ctx context.Context, updateI
 		&i.UpdateID,
 		&i.StorageObjectPath,
 		&i.ContentType,
+		&i.ContentEncoding,
 		&i.Extension,
 		&i.ContentMd5,
 		&i.ContentSha256,
@@ -217,8 +393,125 @@ func (q *Queries) GetLaunchAssetOrArchiveByPlatform(ctx context.Context, updateI
 	return i, err
 }
 
+const getLinkedUpdates = `-- name: GetLinkedUpdates :many
+select id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+from updates
+where linked_update_id = $1::uuid
+order by channel
+`
+
+func (q *Queries) GetLinkedUpdates(ctx context.Context, updateID uuid.UUID) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLinkedUpdates, updateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Update
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.RuntimeVersion,
+			&i.Status,
+			&i.Message,
+			&i.Channel,
+			&i.CreatedAt,
+			&i.ContentHash,
+			&i.ColdStorageAt,
+			&i.Flavors,
+			&i.ExpiresAt,
+			&i.RolloutPercentage,
+			&i.LinkedUpdateID,
+			&i.CommittedAt,
+			&i.CodepushLabel,
+			&i.IsMandatory,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublishedAsset = `-- name: GetPublishedAsset :one
+select update_assets.id, update_assets.update_id, update_assets.storage_object_path, update_assets.content_type, update_assets.content_encoding, update_assets.extension, update_assets.content_md5, update_assets.content_sha256, update_assets.is_launch_asset, update_assets.is_archive, update_assets.platform, update_assets.content_length, update_assets.created_at
+from update_assets
+         inner join updates on updates.id = update_assets.update_id
+where update_assets.id = $1
+  and updates.status = 'published'
+`
+
+// assets of published updates, served by their ID
+func (q *Queries) GetPublishedAsset(ctx context.Context, id uuid.UUID) (UpdateAsset, error) {
+	row := q.db.QueryRow(ctx, getPublishedAsset, id)
+	var i UpdateAsset
+	err := row.Scan(
+		&i.ID,
+		&i.UpdateID,
+		&i.StorageObjectPath,
+		&i.ContentType,
+		&i.ContentEncoding,
+		&i.Extension,
+		&i.ContentMd5,
+		&i.ContentSha256,
+		&i.IsLaunchAsset,
+		&i.IsArchive,
+		&i.Platform,
+		&i.ContentLength,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUpdateAssets = `-- name: GetUpdateAssets :many
+select id, update_id, storage_object_path, content_type, content_encoding, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
+from update_assets
+where update_id = $1
+  and is_archive = false
+order by platform, storage_object_path
+`
+
+func (q *Queries) GetUpdateAssets(ctx context.Context, updateID uuid.UUID) ([]UpdateAsset, error) {
+	rows, err := q.db.Query(ctx, getUpdateAssets, updateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpdateAsset
+	for rows.Next() {
+		var i UpdateAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.UpdateID,
+			&i.StorageObjectPath,
+			&i.ContentType,
+			&i.ContentEncoding,
+			&i.Extension,
+			&i.ContentMd5,
+			&i.ContentSha256,
+			&i.IsLaunchAsset,
+			&i.IsArchive,
+			&i.Platform,
+			&i.ContentLength,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUpdateAssetsByPlatform = `-- name: GetUpdateAssetsByPlatform :many
-select id, update_id, storage_object_path, content_type, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
+select id, update_id, storage_object_path, content_type, content_encoding, extension, content_md5, content_sha256, is_launch_asset, is_archive, platform, content_length, created_at
 from update_assets
 where update_id = $1
   and platform = $2
@@ -239,6 +532,7 @@ func (q *Queries) GetUpdateAssetsByPlatform(ctx context.Context, updateID uuid.U
 			&i.UpdateID,
 			&i.StorageObjectPath,
 			&i.ContentType,
+			&i.ContentEncoding,
 			&i.Extension,
 			&i.ContentMd5,
 			&i.ContentSha256,
@@ -258,8 +552,41 @@ func (q *Queries) GetUpdateAssetsByPlatform(ctx context.Context, updateID uuid.U
 	return items, nil
 }
 
+const getUpdateByExternalID = `-- name: GetUpdateByExternalID :one
+select id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+from updates
+where project_id = $1
+  and external_id = $2
+limit 1
+`
+
+func (q *Queries) GetUpdateByExternalID(ctx context.Context, projectID uuid.UUID, externalID string) (Update, error) {
+	row := q.db.QueryRow(ctx, getUpdateByExternalID, projectID, externalID)
+	var i Update
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.RuntimeVersion,
+		&i.Status,
+		&i.Message,
+		&i.Channel,
+		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const getUpdateByID = `-- name: GetUpdateByID :one
-select id, project_id, runtime_version, status, message, channel, created_at
+select id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
 from updates
 where id = $1
   and project_id = $2
@@ -277,12 +604,22 @@ func (q *Queries) GetUpdateByID(ctx context.Context, updateID uuid.UUID, project
 		&i.Message,
 		&i.Channel,
 		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
 const getUpdateByIDWithProtocol = `-- name: GetUpdateByIDWithProtocol :one
-select u.id, u.project_id, u.runtime_version, u.status, u.message, u.channel, u.created_at, p.update_protocol as protocol
+select u.id, u.project_id, u.runtime_version, u.status, u.message, u.channel, u.created_at, u.content_hash, u.cold_storage_at, u.flavors, u.expires_at, u.rollout_percentage, u.linked_update_id, u.committed_at, u.codepush_label, u.is_mandatory, u.external_id, p.update_protocol as protocol, p.replica_regions, p.max_asset_count
 from updates u
          inner join projects p on u.project_id = p.id
 where u.id = $1
@@ -290,14 +627,26 @@ limit 1
 `
 
 type GetUpdateByIDWithProtocolRow struct {
-	ID             uuid.UUID
-	ProjectID      uuid.UUID
-	RuntimeVersion string
-	Status         UpdateStatus
-	Message        pgtype.Text
-	Channel        string
-	CreatedAt      pgtype.Timestamptz
-	Protocol       UpdateProtocol
+	ID                uuid.UUID
+	ProjectID         uuid.UUID
+	RuntimeVersion    string
+	Status            UpdateStatus
+	Message           pgtype.Text
+	Channel           string
+	CreatedAt         pgtype.Timestamptz
+	ContentHash       pgtype.Text
+	ColdStorageAt     pgtype.Timestamptz
+	Flavors           []string
+	ExpiresAt         pgtype.Timestamptz
+	RolloutPercentage int16
+	LinkedUpdateID    pgtype.UUID
+	CommittedAt       pgtype.Timestamptz
+	CodepushLabel     pgtype.Text
+	IsMandatory       bool
+	ExternalID        pgtype.Text
+	Protocol          UpdateProtocol
+	ReplicaRegions    []string
+	MaxAssetCount     int32
 }
 
 func (q *Queries) GetUpdateByIDWithProtocol(ctx context.Context, updateID uuid.UUID) (GetUpdateByIDWithProtocolRow, error) {
@@ -311,7 +660,271 @@ func (q *Queries) GetUpdateByIDWithProtocol(ctx context.Context, updateID uuid.U
 		&i.Message,
 		&i.Channel,
 		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
 		&i.Protocol,
+		&i.ReplicaRegions,
+		&i.MaxAssetCount,
+	)
+	return i, err
+}
+
+const getUpdateStorageObjectByPath = `-- name: GetUpdateStorageObjectByPath :one
+select id, update_id, path, content_type, content_encoding, extension, content_md5, content_sha256, content_length, is_archive, shared_object_key, created_at
+from update_storage_objects
+where update_id = $1
+  and path = $2
+limit 1
+`
+
+func (q *Queries) GetUpdateStorageObjectByPath(ctx context.Context, updateID uuid.UUID, path string) (UpdateStorageObject, error) {
+	row := q.db.QueryRow(ctx, getUpdateStorageObjectByPath, updateID, path)
+	var i UpdateStorageObject
+	err := row.Scan(
+		&i.ID,
+		&i.UpdateID,
+		&i.Path,
+		&i.ContentType,
+		&i.ContentEncoding,
+		&i.Extension,
+		&i.ContentMd5,
+		&i.ContentSha256,
+		&i.ContentLength,
+		&i.IsArchive,
+		&i.SharedObjectKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUpdateStorageObjects = `-- name: GetUpdateStorageObjects :many
+select id, update_id, path, content_type, content_encoding, extension, content_md5, content_sha256, content_length, is_archive, shared_object_key, created_at
+from update_storage_objects
+where update_id = $1
+`
+
+func (q *Queries) GetUpdateStorageObjects(ctx context.Context, updateID uuid.UUID) ([]UpdateStorageObject, error) {
+	rows, err := q.db.Query(ctx, getUpdateStorageObjects, updateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpdateStorageObject
+	for rows.Next() {
+		var i UpdateStorageObject
+		if err := rows.Scan(
+			&i.ID,
+			&i.UpdateID,
+			&i.Path,
+			&i.ContentType,
+			&i.ContentEncoding,
+			&i.Extension,
+			&i.ContentMd5,
+			&i.ContentSha256,
+			&i.ContentLength,
+			&i.IsArchive,
+			&i.SharedObjectKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasPublishedOrCanceledUpdates = `-- name: HasPublishedOrCanceledUpdates :one
+select exists(select 1
+              from updates
+              where project_id = $1
+                and runtime_version = $2
+                and channel = $3
+                and status in ('published', 'canceled'))
+`
+
+func (q *Queries) HasPublishedOrCanceledUpdates(ctx context.Context, projectID uuid.UUID, runtimeVersion string, channel string) (bool, error) {
+	row := q.db.QueryRow(ctx, hasPublishedOrCanceledUpdates, projectID, runtimeVersion, channel)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const setLinkedUpdatesCommittedAt = `-- name: SetLinkedUpdatesCommittedAt :exec
+update updates
+set committed_at = $1
+where linked_update_id = $2::uuid
+`
+
+func (q *Queries) SetLinkedUpdatesCommittedAt(ctx context.Context, committedAt pgtype.Timestamptz, updateID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setLinkedUpdatesCommittedAt, committedAt, updateID)
+	return err
+}
+
+const setLinkedUpdatesStatus = `-- name: SetLinkedUpdatesStatus :exec
+update updates
+set status = $1
+where linked_update_id = $2::uuid
+`
+
+func (q *Queries) SetLinkedUpdatesStatus(ctx context.Context, status UpdateStatus, updateID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setLinkedUpdatesStatus, status, updateID)
+	return err
+}
+
+const setUpdateCommitted = `-- name: SetUpdateCommitted :one
+update updates
+set status       = 'pending',
+    committed_at = current_timestamp
+where id = $1
+  and status = 'empty'
+returning id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+`
+
+// sets the update pending and records when it was committed, the latest committed update
+// of a channel is served even when an earlier one finishes processing after it. Committed
+// updates aren't returned, so an update is processed once.
+func (q *Queries) SetUpdateCommitted(ctx context.Context, id uuid.UUID) (Update, error) {
+	row := q.db.QueryRow(ctx, setUpdateCommitted, id)
+	var i Update
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.RuntimeVersion,
+		&i.Status,
+		&i.Message,
+		&i.Channel,
+		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const setUpdateContentHash = `-- name: SetUpdateContentHash :exec
+update updates
+set content_hash = $2
+where id = $1
+`
+
+func (q *Queries) SetUpdateContentHash(ctx context.Context, iD uuid.UUID, contentHash pgtype.Text) error {
+	_, err := q.db.Exec(ctx, setUpdateContentHash, iD, contentHash)
+	return err
+}
+
+const setUpdateExpiresAt = `-- name: SetUpdateExpiresAt :one
+update updates
+set expires_at = $1
+where id = $2
+  and project_id = $3
+returning id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+`
+
+func (q *Queries) SetUpdateExpiresAt(ctx context.Context, expiresAt pgtype.Timestamptz, iD uuid.UUID, projectID uuid.UUID) (Update, error) {
+	row := q.db.QueryRow(ctx, setUpdateExpiresAt, expiresAt, iD, projectID)
+	var i Update
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.RuntimeVersion,
+		&i.Status,
+		&i.Message,
+		&i.Channel,
+		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const setUpdateIsMandatory = `-- name: SetUpdateIsMandatory :one
+update updates
+set is_mandatory = $1
+where id = $2
+  and project_id = $3
+returning id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+`
+
+func (q *Queries) SetUpdateIsMandatory(ctx context.Context, isMandatory bool, iD uuid.UUID, projectID uuid.UUID) (Update, error) {
+	row := q.db.QueryRow(ctx, setUpdateIsMandatory, isMandatory, iD, projectID)
+	var i Update
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.RuntimeVersion,
+		&i.Status,
+		&i.Message,
+		&i.Channel,
+		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const setUpdateRolloutPercentage = `-- name: SetUpdateRolloutPercentage :one
+update updates
+set rollout_percentage = $1
+where id = $2
+  and project_id = $3
+returning id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
+`
+
+func (q *Queries) SetUpdateRolloutPercentage(ctx context.Context, rolloutPercentage int16, iD uuid.UUID, projectID uuid.UUID) (Update, error) {
+	row := q.db.QueryRow(ctx, setUpdateRolloutPercentage, rolloutPercentage, iD, projectID)
+	var i Update
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.RuntimeVersion,
+		&i.Status,
+		&i.Message,
+		&i.Channel,
+		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -320,7 +933,7 @@ const setUpdateStatus = `-- name: SetUpdateStatus :one
 UPDATE updates
 SET status = $2
 WHERE id = $1
-RETURNING id, project_id, runtime_version, status, message, channel, created_at
+RETURNING id, project_id, runtime_version, status, message, channel, created_at, content_hash, cold_storage_at, flavors, expires_at, rollout_percentage, linked_update_id, committed_at, codepush_label, is_mandatory, external_id
 `
 
 func (q *Queries) SetUpdateStatus(ctx context.Context, iD uuid.UUID, status UpdateStatus) (Update, error) {
@@ -334,6 +947,16 @@ func (q *Queries) SetUpdateStatus(ctx context.Context, iD uuid.UUID, status Upda
 		&i.Message,
 		&i.Channel,
 		&i.CreatedAt,
+		&i.ContentHash,
+		&i.ColdStorageAt,
+		&i.Flavors,
+		&i.ExpiresAt,
+		&i.RolloutPercentage,
+		&i.LinkedUpdateID,
+		&i.CommittedAt,
+		&i.CodepushLabel,
+		&i.IsMandatory,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -0,0 +1,128 @@
+// Package hooks lets programs embedding the server (see package server) rewrite the responses
+// of update checks, e.g. to inject feature flags or swap asset hosts, without forking the
+// services building them
+package hooks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/expo"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ExpoManifest           = expo.Manifest
+	ExpoManifestExtensions = expo.ManifestExtensions
+	CodePushUpdate         = api.CodePushUpdate
+)
+
+// Request is the update check a response is rewritten for. Rewritten responses are cached for
+// all clients of the same request, so hooks must not depend on anything else.
+type Request struct {
+	ProjectID      uuid.UUID
+	Channel        string
+	RuntimeVersion string
+	Platform       string
+	// Flavor of the app, empty for apps without flavors
+	Flavor string
+}
+
+// ManifestHook rewrites the responses of update checks before they're cached and sent, an error
+// fails the update check
+type ManifestHook interface {
+	// RewriteExpoManifest gets the manifest of the update to install, and its extensions, which
+	// are sent only when they have any asset request headers. It isn't called for directives.
+	RewriteExpoManifest(
+		ctx context.Context,
+		request Request,
+		manifest *ExpoManifest,
+		extensions *ExpoManifestExtensions,
+	) error
+	// RewriteCodePushUpdate gets every CodePush response, whether an update is available or not
+	RewriteCodePushUpdate(ctx context.Context, request Request, update *CodePushUpdate) error
+}
+
+// Base leaves the responses as they are, embed it in hooks rewriting only some of them
+type Base struct{}
+
+func (Base) RewriteExpoManifest(
+	context.Context,
+	Request,
+	*ExpoManifest,
+	*ExpoManifestExtensions,
+) error {
+	return nil
+}
+
+func (Base) RewriteCodePushUpdate(context.Context, Request, *CodePushUpdate) error {
+	return nil
+}
+
+// Registry holds the hooks of the server, hooks registered for all projects run before the hooks
+// of the project, each in the order they were registered. A nil Registry has no hooks.
+type Registry struct {
+	mu        sync.RWMutex
+	all       []ManifestHook
+	byProject map[uuid.UUID][]ManifestHook
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byProject: make(map[uuid.UUID][]ManifestHook)}
+}
+
+// Register adds the hook of the project
+func (r *Registry) Register(projectID uuid.UUID, hook ManifestHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byProject[projectID] = append(r.byProject[projectID], hook)
+}
+
+// RegisterAll adds the hook of all projects
+func (r *Registry) RegisterAll(hook ManifestHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.all = append(r.all, hook)
+}
+
+func (r *Registry) hooks(projectID uuid.UUID) []ManifestHook {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hooks := make([]ManifestHook, 0, len(r.all)+len(r.byProject[projectID]))
+	hooks = append(hooks, r.all...)
+	return append(hooks, r.byProject[projectID]...)
+}
+
+// RewriteExpoManifest runs the hooks of the project, stopping at the first error
+func (r *Registry) RewriteExpoManifest(
+	ctx context.Context,
+	request Request,
+	manifest *ExpoManifest,
+	extensions *ExpoManifestExtensions,
+) error {
+	for _, hook := range r.hooks(request.ProjectID) {
+		if err := hook.RewriteExpoManifest(ctx, request, manifest, extensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RewriteCodePushUpdate runs the hooks of the project, stopping at the first error
+func (r *Registry) RewriteCodePushUpdate(
+	ctx context.Context,
+	request Request,
+	update *CodePushUpdate,
+) error {
+	for _, hook := range r.hooks(request.ProjectID) {
+		if err := hook.RewriteCodePushUpdate(ctx, request, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
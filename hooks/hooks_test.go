@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type labelHook struct {
+	Base
+	label string
+	err   error
+}
+
+func (h *labelHook) RewriteCodePushUpdate(
+	ctx context.Context,
+	request Request,
+	update *CodePushUpdate,
+) error {
+	update.Label += h.label
+	return h.err
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	projectID, otherProjectID := uuid.New(), uuid.New()
+
+	registry := NewRegistry()
+	registry.Register(projectID, &labelHook{label: "b"})
+	registry.RegisterAll(&labelHook{label: "a"})
+	registry.Register(projectID, &labelHook{label: "c"})
+
+	t.Run("runs the hooks of all projects first", func(t *testing.T) {
+		update := &CodePushUpdate{}
+		err := registry.RewriteCodePushUpdate(ctx, Request{ProjectID: projectID}, update)
+		require.NoError(t, err)
+		require.Equal(t, "abc", update.Label)
+
+		update = &CodePushUpdate{}
+		err = registry.RewriteCodePushUpdate(ctx, Request{ProjectID: otherProjectID}, update)
+		require.NoError(t, err)
+		require.Equal(t, "a", update.Label)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		hookErr := errors.New("hook failed")
+		registry.Register(otherProjectID, &labelHook{label: "x", err: hookErr})
+		registry.Register(otherProjectID, &labelHook{label: "y"})
+
+		update := &CodePushUpdate{}
+		err := registry.RewriteCodePushUpdate(ctx, Request{ProjectID: otherProjectID}, update)
+		require.ErrorIs(t, err, hookErr)
+		require.Equal(t, "ax", update.Label)
+	})
+
+	t.Run("nil registry has no hooks", func(t *testing.T) {
+		var registry *Registry
+		manifest := &ExpoManifest{Id: "id"}
+		err := registry.RewriteExpoManifest(
+			ctx,
+			Request{ProjectID: projectID},
+			manifest,
+			&ExpoManifestExtensions{},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "id", manifest.Id)
+	})
+}
@@ -0,0 +1,264 @@
+// Package adminclient publishes updates through the admin API of a running server, for the
+// tools importing the history of other update servers
+package adminclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/google/uuid"
+)
+
+type Client struct {
+	HTTP *http.Client
+	// BaseURL of the server, e.g. http://localhost:8080
+	BaseURL   string
+	ProjectID uuid.UUID
+	// PollInterval of the status of the committed updates
+	PollInterval time.Duration
+}
+
+// Archive is the file of an update uploaded as a single archive
+type Archive struct {
+	// Name is the path of the archive, ending with .zip, .tar.gz or .tgz
+	Name    string
+	Content []byte
+}
+
+func (a Archive) contentType() string {
+	if strings.HasSuffix(a.Name, ".zip") {
+		return "application/zip"
+	}
+	return "application/gzip"
+}
+
+func (a Archive) extension() string {
+	if strings.HasSuffix(a.Name, ".tar.gz") {
+		return ".tar.gz"
+	}
+	return a.Name[strings.LastIndex(a.Name, "."):]
+}
+
+// PublishArchive prepares the update with the archive, uploads and commits it, and waits until
+// it's published. It returns the ID of the update.
+func (c *Client) PublishArchive(
+	ctx context.Context,
+	body api.PrepareUpdateBody,
+	archive Archive,
+) (uuid.UUID, error) {
+	body.Archive = &api.StorageObject{
+		Path:          archive.Name,
+		ContentType:   archive.contentType(),
+		Extension:     archive.extension(),
+		ContentLength: len(archive.Content),
+		MD5Hash:       fmt.Sprintf("%x", md5.Sum(archive.Content)),
+	}
+	var prepared api.PrepareUpdateResponse
+	if err := c.call(ctx, http.MethodPost, "/update", body, &prepared); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to prepare update: %w", err)
+	}
+	updateID := prepared.UpdateID
+
+	if err := c.upload(ctx, updateID, archive); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to upload update %s: %w", updateID, err)
+	}
+	commitPath := fmt.Sprintf("/update/%s/commit", updateID)
+	if err := c.call(ctx, http.MethodPost, commitPath, nil, nil); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit update %s: %w", updateID, err)
+	}
+	if err := c.waitForPublished(ctx, updateID); err != nil {
+		return uuid.Nil, err
+	}
+	return updateID, nil
+}
+
+// GetUpdate returns the update of the project, nil when the project has no such update
+func (c *Client) GetUpdate(ctx context.Context, updateID uuid.UUID) (*api.Update, error) {
+	var update api.Update
+	err := c.call(ctx, http.MethodGet, fmt.Sprintf("/update/%s", updateID), nil, &update)
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get update %s: %w", updateID, err)
+	}
+	return &update, nil
+}
+
+// RollbackUpdate rolls back the published update
+func (c *Client) RollbackUpdate(ctx context.Context, updateID uuid.UUID) error {
+	rollbackPath := fmt.Sprintf("/update/%s/rollback", updateID)
+	if err := c.call(ctx, http.MethodPost, rollbackPath, nil, nil); err != nil {
+		return fmt.Errorf("failed to roll back update %s: %w", updateID, err)
+	}
+	return nil
+}
+
+func (c *Client) upload(ctx context.Context, updateID uuid.UUID, archive Archive) error {
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	part, err := mw.CreateFormFile(archive.Name, archive.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(archive.Content); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.adminURL(fmt.Sprintf("/update/%s/assets", updateID)),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return c.do(req, nil)
+}
+
+// waitForPublished polls the status of the committed update until it's processed
+func (c *Client) waitForPublished(ctx context.Context, updateID uuid.UUID) error {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		update, err := c.GetUpdate(ctx, updateID)
+		if err != nil {
+			return err
+		}
+		if update == nil {
+			return fmt.Errorf("update %s not found", updateID)
+		}
+		switch update.Status {
+		case api.UpdateStatusPublished:
+			return nil
+		case api.UpdateStatusFailed, api.UpdateStatusCanceled:
+			return fmt.Errorf("update %s is %s, see its processing reports", updateID, update.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) adminURL(path string) string {
+	return fmt.Sprintf("%s/api/v1/admin/%s%s", c.BaseURL, c.ProjectID, path)
+}
+
+// call sends the JSON request body to the admin API and decodes the response into result
+func (c *Client) call(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.adminURL(path), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, result)
+}
+
+func (c *Client) do(req *http.Request, result any) error {
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// ResponseError is the response of the server to a failed request
+type ResponseError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	// Envelope is the error the server responded with, nil when the body isn't one, e.g.
+	// the response of a proxy
+	Envelope *api.GenericError
+}
+
+func (e *ResponseError) Error() string {
+	if e.Envelope == nil {
+		return fmt.Sprintf("server responded with %s: %s", e.Status, e.Body)
+	}
+	return fmt.Sprintf("server responded with %s: %s", e.Status, e.Envelope.Error)
+}
+
+// Retryable reports whether the server may accept the same request later
+func (e *ResponseError) Retryable() bool {
+	if e.Envelope == nil {
+		return e.StatusCode >= 500
+	}
+	return e.Envelope.Retryable
+}
+
+// IsValidationError reports whether the request was rejected for the field
+func IsValidationError(err error, field string) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	var body api.ValidationErrorJSONResponse
+	if err := json.Unmarshal([]byte(respErr.Body), &body); err != nil {
+		return false
+	}
+	return slices.ContainsFunc(body.Errors, func(fieldErr api.ValidationFieldError) bool {
+		return fieldErr.Field == field
+	})
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	var envelope api.GenericError
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Code != nil {
+		respErr.Envelope = &envelope
+	}
+	return respErr
+}
@@ -0,0 +1,62 @@
+// Package analytics records sampled update checks, so adoption and traffic can be charted and
+// the answers given to the devices can be audited
+package analytics
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DecisionUpdate             = "update"
+	DecisionNoUpdate           = "no_update"
+	DecisionRollBackToEmbedded = "roll_back_to_embedded"
+)
+
+// Reasons of the rollbacks to the embedded update
+const (
+	// ReasonUpdateCanceled is recorded when the update of the channel was canceled
+	ReasonUpdateCanceled = "update_canceled"
+	// ReasonChannelRollback is recorded when the whole channel was rolled back
+	ReasonChannelRollback = "channel_rollback"
+)
+
+type Config struct {
+	// SampleRate is the fraction of update checks recorded, from 0 (disabled) to 1 (all)
+	SampleRate float64 `env:"ANALYTICS_SAMPLE_RATE"`
+}
+
+// Recorder publishes sampled update checks to the queue, they're written to the database by
+// the Writer consuming the queue. A nil Recorder records nothing.
+type Recorder struct {
+	queue      queue.Queue
+	sampleRate float64
+}
+
+// NewRecorder returns nil when the sample rate is not positive
+func NewRecorder(queueConn queue.Queue, config Config) *Recorder {
+	if config.SampleRate <= 0 {
+		return nil
+	}
+	return &Recorder{queue: queueConn, sampleRate: min(config.SampleRate, 1)}
+}
+
+// Record publishes the event if it's sampled, it doesn't wait for the event to be written
+func (r *Recorder) Record(ctx context.Context, event queue.UpdateCheckEventPayload) {
+	if r == nil || rand.Float64() >= r.sampleRate {
+		return
+	}
+	if event.CheckedAt.IsZero() {
+		event.CheckedAt = time.Now()
+	}
+
+	if err := r.queue.PublishUpdateCheckEvent(ctx, event); err != nil {
+		logger.FromContext(ctx).Warn("failed to publish update check event", zap.Error(err))
+	}
+}
@@ -0,0 +1,109 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewRecorder(t *testing.T) {
+	require.Nil(t, NewRecorder(nil, Config{}))
+	require.Nil(t, NewRecorder(nil, Config{SampleRate: -1}))
+	require.Equal(t, 1.0, NewRecorder(nil, Config{SampleRate: 2}).sampleRate)
+
+	// a nil recorder records nothing
+	var recorder *Recorder
+	recorder.Record(context.Background(), queue.UpdateCheckEventPayload{})
+}
+
+func TestRecordUpdateCheck(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	require.NoError(t, err)
+	defer queueConn.Close()
+
+	consumed := make(chan []byte, 1)
+	err = queueConn.ConsumeUpdateCheckEvents(ctx, func(data []byte) { consumed <- data })
+	require.NoError(t, err)
+
+	updateID := uuid.New()
+	event := queue.UpdateCheckEventPayload{
+		ProjectID:      uuid.New(),
+		Channel:        "production",
+		RuntimeVersion: "1.0.0",
+		Platform:       "ios",
+		Decision:       DecisionUpdate,
+		UpdateID:       &updateID,
+		ClientID:       "device",
+	}
+	NewRecorder(queueConn, Config{SampleRate: 1}).Record(ctx, event)
+
+	var data []byte
+	select {
+	case data = <-consumed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("update check event was not consumed")
+	}
+
+	writer := NewWriter(nil)
+	require.NoError(t, writer.add(data))
+	require.Len(t, writer.pending, 1)
+	written := writer.pending[0]
+	require.Equal(t, event.ProjectID, written.ProjectID)
+	require.Equal(t, event.Channel, written.Channel)
+	require.Equal(t, event.RuntimeVersion, written.RuntimeVersion)
+	require.Equal(t, event.Platform, written.Platform)
+	require.Equal(t, DecisionUpdate, written.Decision)
+	require.True(t, written.UpdateID.Valid)
+	require.Equal(t, updateID, uuid.UUID(written.UpdateID.Bytes))
+	require.False(t, written.CheckedAt.Time.IsZero())
+	require.Equal(t, "device", written.ClientID.String)
+	require.False(t, written.Reason.Valid)
+	require.False(t, written.CurrentUpdateID.Valid)
+
+	require.Error(t, writer.add([]byte("invalid")))
+}
+
+func TestWriterAddAuditFields(t *testing.T) {
+	currentUpdateID := uuid.New()
+	add := func(event queue.UpdateCheckEventPayload) db.CreateUpdateCheckEventsParams {
+		t.Helper()
+		data, err := json.Marshal(event)
+		require.NoError(t, err)
+		writer := NewWriter(nil)
+		require.NoError(t, writer.add(data))
+		require.Len(t, writer.pending, 1)
+		return writer.pending[0]
+	}
+
+	written := add(queue.UpdateCheckEventPayload{
+		Decision:        DecisionRollBackToEmbedded,
+		Reason:          ReasonChannelRollback,
+		ClientID:        "device",
+		CurrentUpdateID: &currentUpdateID,
+	})
+	require.Equal(t, ReasonChannelRollback, written.Reason.String)
+	require.Equal(t, "device", written.ClientID.String)
+	require.Equal(t, currentUpdateID, uuid.UUID(written.CurrentUpdateID.Bytes))
+	require.False(t, written.CodepushLabel.Valid)
+
+	written = add(queue.UpdateCheckEventPayload{
+		Decision:      DecisionUpdate,
+		CodePushLabel: "v3",
+		ClientID:      strings.Repeat("a", maxClientIDLength+1),
+	})
+	require.Equal(t, "v3", written.CodepushLabel.String)
+	require.False(t, written.UpdateID.Valid)
+	// too long for the column, the rest of the event is still recorded
+	require.False(t, written.ClientID.Valid)
+}
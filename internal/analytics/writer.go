@@ -0,0 +1,136 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	// FlushInterval is how often the consumed events are written to the database
+	FlushInterval = 10 * time.Second
+	// maxPendingEvents caps the events waiting for a flush, further events are dropped
+	maxPendingEvents = 50_000
+	// maxClientIDLength is the size of the column, longer client IDs aren't recorded
+	maxClientIDLength = 128
+)
+
+// Writer consumes update check events of the queue and writes them to the database in batches
+type Writer struct {
+	q       *db.Queries
+	mu      sync.Mutex
+	pending []db.CreateUpdateCheckEventsParams
+	dropped int
+}
+
+func NewWriter(q *db.Queries) *Writer {
+	return &Writer{q: q}
+}
+
+// Start consumes the events and flushes them every FlushInterval until ctx is canceled
+func (w *Writer) Start(ctx context.Context, queueConn queue.Queue) error {
+	log := logger.FromContext(ctx)
+	err := queueConn.ConsumeUpdateCheckEvents(ctx, func(data []byte) {
+		if err := w.add(data); err != nil {
+			log.Warn("invalid update check event", zap.Error(err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume update check events: %w", err)
+	}
+
+	go w.run(ctx)
+	return nil
+}
+
+func (w *Writer) add(data []byte) error {
+	event, err := queue.ParseUpdateCheckEvent(data)
+	if err != nil {
+		return err
+	}
+
+	params := db.CreateUpdateCheckEventsParams{
+		ProjectID:      event.ProjectID,
+		Channel:        event.Channel,
+		RuntimeVersion: event.RuntimeVersion,
+		Platform:       event.Platform,
+		Decision:       event.Decision,
+		CheckedAt:      pgtype.Timestamptz{Time: event.CheckedAt, Valid: true},
+	}
+	if event.UpdateID != nil {
+		params.UpdateID = pgtype.UUID{Bytes: *event.UpdateID, Valid: true}
+	}
+	if event.Reason != "" {
+		params.Reason = pgtype.Text{String: event.Reason, Valid: true}
+	}
+	if event.ClientID != "" && len(event.ClientID) <= maxClientIDLength {
+		params.ClientID = pgtype.Text{String: event.ClientID, Valid: true}
+	}
+	if event.CurrentUpdateID != nil {
+		params.CurrentUpdateID = pgtype.UUID{Bytes: *event.CurrentUpdateID, Valid: true}
+	}
+	if event.CodePushLabel != "" {
+		params.CodepushLabel = pgtype.Text{String: event.CodePushLabel, Valid: true}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) >= maxPendingEvents {
+		w.dropped++
+		return nil
+	}
+	w.pending = append(w.pending, params)
+	return nil
+}
+
+// Flush writes the consumed events to the database, events failed to be written are dropped
+func (w *Writer) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	pending, dropped := w.pending, w.dropped
+	w.pending, w.dropped = nil, 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		logger.FromContext(ctx).Warn(
+			"dropped update check events, the database can't keep up",
+			zap.Int("dropped", dropped),
+		)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if _, err := w.q.CreateUpdateCheckEvents(ctx, pending); err != nil {
+		return fmt.Errorf("CreateUpdateCheckEvents: %w", err)
+	}
+	return nil
+}
+
+func (w *Writer) run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write the events consumed since the last flush before stopping
+			if err := w.Flush(context.WithoutCancel(ctx)); err != nil {
+				log.Error("failed to flush update check events", zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := w.Flush(ctx); err != nil {
+				log.Error("failed to flush update check events", zap.Error(err))
+			}
+		}
+	}
+}
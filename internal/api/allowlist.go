@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/clientip"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/project"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NewIPAllowlistMiddleware rejects requests to the management endpoints of projects restricted
+// to CIDR ranges from other IPs, the device facing endpoints stay open
+func NewIPAllowlistMiddleware(projectSvc project.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !strings.HasPrefix(ctx.Request.URL.Path, project.AdminPathPrefix) {
+			ctx.Next()
+			return
+		}
+
+		projectID := project.IDFromAdminPath(ctx.Request.URL.Path)
+		if projectID == uuid.Nil {
+			ctx.Next()
+			return
+		}
+
+		proj, err := projectSvc.ProjectByID(ctx, projectID)
+		if err != nil {
+			_ = ctx.Error(fmt.Errorf("projectSvc.ProjectByID: %w", err))
+			ctx.Abort()
+			return
+		}
+
+		// unknown projects are rejected by the handlers
+		if proj == nil {
+			ctx.Next()
+			return
+		}
+
+		ip := clientip.FromContext(ctx)
+		if !project.IPAllowed(proj.AdminAllowedCidrs, ip) {
+			logger.FromContext(ctx).Warn(
+				"management request rejected by the IP allowlist",
+				zap.String("project_id", projectID.String()),
+			)
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				api.GenericError{Error: "IP address is not allowed to manage the project"},
+			)
+			return
+		}
+
+		ctx.Next()
+	}
+}
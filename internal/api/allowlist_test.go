@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/clientip"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/project"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type stubProjectService struct {
+	project.Service
+	projects map[uuid.UUID]*db.Project
+}
+
+func (s *stubProjectService) ProjectByID(_ context.Context, id uuid.UUID) (*db.Project, error) {
+	return s.projects[id], nil
+}
+
+func (s *stubProjectService) DeleteProject(_ context.Context, id uuid.UUID) (*db.Project, error) {
+	return s.projects[id], nil
+}
+
+func (s *stubProjectService) SetPublicAssetsURL(
+	_ context.Context,
+	id uuid.UUID,
+	publicAssetsURL string,
+) (*db.Project, error) {
+	proj := s.projects[id]
+	if proj != nil {
+		proj.PublicAssetsUrl = pgtype.Text{String: publicAssetsURL, Valid: publicAssetsURL != ""}
+	}
+	return proj, nil
+}
+
+func (s *stubProjectService) SetMaxAssetCount(
+	_ context.Context,
+	id uuid.UUID,
+	maxAssetCount int32,
+) (*db.Project, error) {
+	proj := s.projects[id]
+	if proj != nil {
+		proj.MaxAssetCount = maxAssetCount
+	}
+	return proj, nil
+}
+
+func TestIPAllowlistMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	restricted := &db.Project{ID: uuid.New(), AdminAllowedCidrs: []string{"10.0.0.0/8"}}
+	open := &db.Project{ID: uuid.New(), AdminAllowedCidrs: []string{}}
+	projectSvc := &stubProjectService{projects: map[uuid.UUID]*db.Project{
+		restricted.ID: restricted,
+		open.ID:       open,
+	}}
+
+	r := gin.New()
+	r.Use(logger.NewMiddleware(zap.NewNop()))
+	r.Use(clientip.NewMiddleware())
+	r.Use(NewIPAllowlistMiddleware(projectSvc))
+	r.Any("/*path", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	serve := func(path, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = remoteAddr
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	rollbackPath := "/api/v1/admin/" + restricted.ID.String() + "/update/" + uuid.NewString() +
+		"/rollback"
+	assert.Equal(t, http.StatusOK, serve(rollbackPath, "10.1.2.3:1234"))
+	assert.Equal(t, http.StatusForbidden, serve(rollbackPath, "203.0.113.7:1234"))
+	assert.Equal(
+		t,
+		http.StatusForbidden,
+		serve("/api/v1/admin/project/"+restricted.ID.String(), "203.0.113.7:1234"),
+	)
+	assert.Equal(
+		t,
+		http.StatusOK,
+		serve("/api/v1/admin/"+open.ID.String()+"/updates", "203.0.113.7:1234"),
+	)
+	// update checks stay open
+	assert.Equal(
+		t,
+		http.StatusOK,
+		serve("/api/v1/public/"+restricted.ID.String()+"/expo", "203.0.113.7:1234"),
+	)
+}
@@ -2,18 +2,29 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/hooks"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
 	"github.com/a-gierczak/paratrooper/internal/cache"
+	"github.com/a-gierczak/paratrooper/internal/clientip"
 	"github.com/a-gierczak/paratrooper/internal/codepush"
+	"github.com/a-gierczak/paratrooper/internal/edge"
 	"github.com/a-gierczak/paratrooper/internal/expo"
+	"github.com/a-gierczak/paratrooper/internal/geo"
 	"github.com/a-gierczak/paratrooper/internal/infra"
+	"github.com/a-gierczak/paratrooper/internal/listener"
 	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/mtls"
 	"github.com/a-gierczak/paratrooper/internal/project"
 	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/schema"
+	"github.com/a-gierczak/paratrooper/internal/signing"
+	"github.com/a-gierczak/paratrooper/internal/stats"
 	"github.com/a-gierczak/paratrooper/internal/storage"
 	"github.com/a-gierczak/paratrooper/internal/update"
 
@@ -27,13 +38,52 @@ import (
 type Config struct {
 	PostgresDSN string `env:"POSTGRES_DSN"`
 	DebugMode   bool   `env:"DEBUG"`
-	NATSURL     string `env:"NATS_URL"`
+	Queue       queue.Config
 	Storage     storage.Config
 	Cache       cache.Config
+	Listener    listener.Config
+	ClientIP    clientip.Config
+	Geo         geo.Config
+	Log         logger.Config
+	RequestLog  logger.RequestLogConfig
+	// DocsUI serves Swagger UI at /docs, the spec at /openapi.json is always served
+	DocsUI bool `env:"API_DOCS_UI"`
+	// EdgeCache makes the API server serve external storage assets from a local disk cache
+	EdgeCache edge.CacheConfig
+	// DebugToken enables /api/v1/debug endpoints for requests with the bearer token
+	DebugToken string `env:"API_DEBUG_TOKEN"`
+	// Integrity verification runs in the API server only with the in-process queue
+	Integrity update.IntegrityConfig
+	// Superseded updates are moved to cold storage by the API server only with the in-process queue
+	ColdStorage update.ColdStorageConfig
+	// Updates past the retention are deleted by the API server only with the in-process queue
+	Retention update.RetentionConfig
+	// Usage of the projects is refreshed by the API server only with the in-process queue
+	Quota update.QuotaConfig
+	// Stale chunks are deleted by the API server only with the in-process queue
+	Chunks update.ChunkConfig
+	// Processing of updates by the API server with the in-process queue
+	Processing update.ProcessingConfig
+	// MTLS requires client certificates on the management endpoints
+	MTLS mtls.Config
+	// Analytics samples update checks, they're written by the worker consuming the queue
+	Analytics analytics.Config
+	Schema    schema.Config
+	// Maintenance mode can be also switched with PUT /api/v1/admin/maintenance
+	Maintenance MaintenanceConfig
+	// RequestTimeout is the deadline of the API operations, except chunk uploads, 0 disables it
+	RequestTimeout time.Duration `env:"API_REQUEST_TIMEOUT,default=30s"`
+	// AssetSecurity headers of the assets served by the API
+	AssetSecurity AssetSecurityConfig
+	// HTTP limits of the server and its route groups
+	HTTP HTTPConfig
 }
 
-func Run(config Config, log *zap.Logger) error {
+// Run serves the API until it fails, manifestHooks rewrite the responses of update checks of
+// programs embedding the server and may be nil
+func Run(config Config, log *zap.Logger, manifestHooks *hooks.Registry) error {
 	var err error
+	log = logger.Component(log, logger.ComponentAPI)
 
 	if config.DebugMode {
 		gin.SetMode(gin.DebugMode)
@@ -59,11 +109,15 @@ func Run(config Config, log *zap.Logger) error {
 		return fmt.Errorf("failed create a connection pool to postgres: %w", err)
 	}
 	queries := db.New(pgConn)
+	readOnly, err := schema.Check(ctx, pgConn, config.Schema)
+	if err != nil {
+		return err
+	}
 
-	// connect to nats
-	queueConn, err := queue.Connect(ctx, config.NATSURL)
+	// connect to the queue
+	queueConn, err := queue.New(ctx, config.Queue)
 	if err != nil {
-		return fmt.Errorf("failed to connect to NATS: %w", err)
+		return fmt.Errorf("failed to init queue: %w", err)
 	}
 	defer queueConn.Close()
 
@@ -73,38 +127,165 @@ func Run(config Config, log *zap.Logger) error {
 		return fmt.Errorf("failed to init storage: %w", err)
 	}
 
+	projectSvc := project.NewService(queries)
+	storageDriver.RouteProjectBuckets(projectSvc.StorageDriverURL)
+
 	r := gin.New()
+	// handlers get the gin context, make it carry the deadline and cancellation of the request
+	r.ContextWithFallback = true
+	if err := clientip.Configure(r, config.ClientIP); err != nil {
+		return err
+	}
 	r.Use(logger.NewMiddleware(log))
-	r.Use(ginzap.Ginzap(log, time.RFC3339, true))
+	r.Use(clientip.NewMiddleware())
+	r.Use(geo.NewMiddleware(config.Geo))
+	r.Use(logger.NewRequestLogMiddleware(log, config.RequestLog))
 	r.Use(ginzap.RecoveryWithZap(log, true))
+	usageRecorder := stats.NewUsageRecorder(queries)
+	go usageRecorder.Run(ctx)
+	r.Use(newUsageMiddleware(usageRecorder))
 	r.Use(NewErrorHandlingMiddleware())
+	r.Use(NewHTTPLimitsMiddleware(config.HTTP))
+	r.Use(NewAPIVersionMiddleware())
+	r.Use(NewDebugAuthMiddleware(config.DebugToken))
+	if config.MTLS.ClientsFile != "" {
+		if config.Listener.TLSClientCAPath == "" {
+			return errors.New("MTLS_CLIENTS_FILE requires TLS_CLIENT_CA_PATH")
+		}
+		clients, err := mtls.LoadClients(config.MTLS.ClientsFile)
+		if err != nil {
+			return err
+		}
+		r.Use(mtls.NewMiddleware(clients))
+		log.Info(
+			"management endpoints require client certificates",
+			zap.Int("clients", len(clients)),
+		)
+	}
+	r.Use(NewIPAllowlistMiddleware(projectSvc))
+	readOnlyMode := NewReadOnlyMode(queries, config.Maintenance, readOnly)
+	r.Use(NewReadOnlyMiddleware(readOnlyMode))
 
 	// init cache
 	cacheDriver, err := cache.New(ctx, config.Cache)
 	if err != nil {
 		return fmt.Errorf("failed to init cache: %w", err)
 	}
+	if err := ConsumeChannelChangedEvents(ctx, queueConn, cacheDriver); err != nil {
+		return err
+	}
 
 	updateSvc := update.NewService(queries, pgConn, storageDriver, queueConn)
+	analyticsRecorder := analytics.NewRecorder(queueConn, config.Analytics)
+	if !readOnly {
+		// commits are accepted while the queue is down, they're published once it's reachable
+		go update.NewOutboxRelay(queries, pgConn, queueConn).Run(ctx)
+	}
+	if config.Queue.Driver == queue.DriverMemory && !readOnly {
+		// nothing else can consume the in-process queue
+		workerCtx := logger.ContextWithLogger(ctx, logger.Component(log, logger.ComponentWorker))
+		processor := update.NewProcessor(
+			updateSvc,
+			storageDriver,
+			queueConn,
+			config.Processing,
+		)
+		if err := processor.Start(workerCtx); err != nil {
+			return fmt.Errorf("failed to start in-process worker: %w", err)
+		}
+		go processor.RunDeadLetterConsumer(workerCtx)
+		go update.NewVerifier(queries, storageDriver, config.Integrity).Run(workerCtx)
+		go update.NewColdStorageMover(
+			queries,
+			storageDriver,
+			queueConn,
+			config.ColdStorage,
+		).Run(workerCtx)
+		go update.NewRetentionEnforcer(
+			queries,
+			pgConn,
+			storageDriver,
+			queueConn,
+			config.Retention,
+		).Run(workerCtx)
+		go update.NewChannelHeadBackfill(queries).Run(workerCtx)
+		go update.NewUsageRefresher(queries, config.Quota).Run(workerCtx)
+		go update.NewChunkCleaner(queries, storageDriver, config.Chunks).Run(workerCtx)
+		purger := project.NewPurger(queries, pgConn, storageDriver)
+		if err := purger.Start(workerCtx, queueConn); err != nil {
+			return fmt.Errorf("failed to start in-process purger: %w", err)
+		}
+		if analyticsRecorder != nil {
+			if err := analytics.NewWriter(queries).Start(workerCtx, queueConn); err != nil {
+				return fmt.Errorf("failed to start in-process analytics writer: %w", err)
+			}
+		}
+	}
+	adoptionRecorder := stats.NewAdoptionRecorder(queries)
+	go adoptionRecorder.Run(ctx)
 	server := NewServer(
 		updateSvc,
 		codepush.NewService(queries, storageDriver),
-		expo.NewService(queries, storageDriver),
-		project.NewService(queries),
+		expo.NewService(queries, storageDriver, cacheDriver),
+		projectSvc,
 		infra.NewService(pgConn, queueConn, cacheDriver),
+		queueConn,
+		storageDriver,
+		stats.NewService(queries),
+		signing.NewService(queries, pgConn),
+		analyticsRecorder,
+		adoptionRecorder,
+		readOnlyMode,
+		manifestHooks,
 	)
 
 	h := api.NewStrictHandler(server, []api.StrictMiddlewareFunc{
 		logger.NewOperationNameStrictMiddleware(),
+		newUsageOperationStrictMiddleware(),
 		validateRequestMiddleware,
+		newRequestTimeoutMiddleware(config.RequestTimeout),
 	})
+	downloadRecorder := stats.NewRecorder(queries)
+	go downloadRecorder.Run(ctx)
 	if storageDriver.Provider() == storage.ProviderLocal {
-		addStorageRoutes(r, storageDriver)
+		addStorageRoutes(r, storageDriver, updateSvc, config.AssetSecurity, downloadRecorder)
+	}
+	if storageDriver.StableAssetURLs() {
+		addStableAssetRoute(r, updateSvc, storageDriver, config.AssetSecurity, downloadRecorder)
 	}
+	if config.EdgeCache.Dir != "" {
+		if storageDriver.EdgeURLSigner() == nil {
+			return errors.New("EDGE_CACHE_DIR requires STORAGE_EDGE_BASE_URL")
+		}
+		edgeCache, err := edge.NewCache(ctx, storageDriver.Bucket(), config.EdgeCache)
+		if err != nil {
+			return fmt.Errorf("failed to init edge cache: %w", err)
+		}
+		defer edgeCache.Close()
+		if err := edge.ConsumeObjectsDeletedEvents(ctx, queueConn, edgeCache); err != nil {
+			return err
+		}
+		edge.AddRoutes(
+			r,
+			edgeCache,
+			storageDriver.EdgeURLSigner(),
+			storageDriver.AssetTokenSigner(),
+			downloadRecorder,
+		)
+	}
+	addAPIVersionsRoute(r)
 	api.RegisterHandlers(r, h)
+	if err := addDocsRoutes(r, config.DocsUI); err != nil {
+		return err
+	}
+
+	l, err := listener.Listen(ctx, config.Listener)
+	if err != nil {
+		return err
+	}
 
 	log.Info("API server started")
-	return r.Run()
+	return config.HTTP.server(r).Serve(l)
 }
 
 // validateRequestMiddleware validates the request parameters using the validator library.
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAllowedContentTypes are the types of the files of Expo and CodePush updates
+var defaultAllowedContentTypes = []string{
+	"application/javascript",
+	"application/json",
+	"application/zip",
+	"application/octet-stream",
+	"image/*",
+	"font/*",
+	"audio/*",
+	"video/*",
+}
+
+type AssetSecurityConfig struct {
+	// CSP is the Content-Security-Policy of the assets served by the API, no asset is meant to
+	// be rendered as a page, so the default forbids running or loading anything
+	CSP string `env:"ASSET_CONTENT_SECURITY_POLICY,default=default-src 'none'; sandbox"`
+	// AllowedContentTypes is a comma separated list of media types served as they're stored,
+	// e.g. image/*, others are served as application/octet-stream. The types of update files
+	// are allowed when it's empty.
+	AllowedContentTypes string `env:"ASSET_ALLOWED_CONTENT_TYPES"`
+}
+
+func (c AssetSecurityConfig) allowedContentTypes() []string {
+	if c.AllowedContentTypes == "" {
+		return defaultAllowedContentTypes
+	}
+	allowed := make([]string, 0)
+	for _, contentType := range strings.Split(c.AllowedContentTypes, ",") {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			allowed = append(allowed, strings.ToLower(contentType))
+		}
+	}
+	return allowed
+}
+
+// allowedContentType returns the content type to serve, the stored one when its media type is
+// allowed, application/octet-stream otherwise
+func (c AssetSecurityConfig) allowedContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	for _, allowed := range c.allowedContentTypes() {
+		prefix, wildcard := strings.CutSuffix(allowed, "/*")
+		if mediaType == allowed || (wildcard && strings.HasPrefix(mediaType, prefix+"/")) {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// setAssetSecurityHeaders keeps browsers from rendering or sniffing the served assets, e.g. an
+// uploaded HTML page, and returns the content type to serve the object with
+func setAssetSecurityHeaders(
+	ctx *gin.Context,
+	config AssetSecurityConfig,
+	objectKey string,
+	contentType string,
+) string {
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	if config.CSP != "" {
+		ctx.Header("Content-Security-Policy", config.CSP)
+	}
+	ctx.Header(
+		"Content-Disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(objectKey)}),
+	)
+	return config.allowedContentType(contentType)
+}
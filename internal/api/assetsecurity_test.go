@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(config AssetSecurityConfig, contentType string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.GET("/assets", func(ctx *gin.Context) {
+			objectKey := "project/update/index.html"
+			contentType := setAssetSecurityHeaders(ctx, config, objectKey, contentType)
+			ctx.Data(http.StatusOK, contentType, nil)
+		})
+
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/assets", nil))
+		return resp
+	}
+
+	config := AssetSecurityConfig{CSP: "default-src 'none'; sandbox"}
+	resp := serve(config, "text/html; charset=utf-8")
+	assert.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", resp.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "default-src 'none'; sandbox", resp.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, `attachment; filename=index.html`, resp.Header().Get("Content-Disposition"))
+
+	assert.Equal(t, "image/png", serve(config, "image/png").Header().Get("Content-Type"))
+	assert.Equal(
+		t,
+		"application/javascript",
+		serve(config, "application/javascript").Header().Get("Content-Type"),
+	)
+
+	config = AssetSecurityConfig{AllowedContentTypes: "text/html, image/*"}
+	resp = serve(config, "text/html")
+	assert.Equal(t, "text/html", resp.Header().Get("Content-Type"))
+	assert.Empty(t, resp.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "image/webp", serve(config, "image/webp").Header().Get("Content-Type"))
+	assert.Equal(
+		t,
+		"application/octet-stream",
+		serve(config, "application/json").Header().Get("Content-Type"),
+	)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/internal/cache"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// channelGenerationTTL outlives the cached responses of the generation
+const channelGenerationTTL = 24 * 60 * 60
+
+// channelGenerationKey points to the generation of the cached Expo and CodePush responses of
+// the channel, deleting it invalidates all of them at once
+func channelGenerationKey(projectID uuid.UUID, channel string) string {
+	return strings.ToLower(fmt.Sprintf("pt:channel:%s:%s:generation", projectID, channel))
+}
+
+// projectGenerationKey points to the generation of the cached responses of all channels of
+// the project
+func projectGenerationKey(projectID uuid.UUID) string {
+	return strings.ToLower(fmt.Sprintf("pt:project:%s:generation", projectID))
+}
+
+// channelGeneration returns the current generation of the cached responses of the channel,
+// which changes when either the channel or its project was invalidated
+func channelGeneration(
+	ctx context.Context,
+	c cache.Cache,
+	projectID uuid.UUID,
+	channel string,
+) (string, error) {
+	projectGeneration, err := cacheGeneration(ctx, c, projectGenerationKey(projectID))
+	if err != nil {
+		return "", err
+	}
+	generation, err := cacheGeneration(ctx, c, channelGenerationKey(projectID, channel))
+	if err != nil {
+		return "", err
+	}
+	return projectGeneration + "." + generation, nil
+}
+
+// cachedResponsePrefixes are the prefixes of the keys of the cached Expo and CodePush responses
+// of the channel, of all channels of the project when it's empty
+func cachedResponsePrefixes(projectID uuid.UUID, channel string) []string {
+	prefixes := []string{
+		fmt.Sprintf("pt:update:%s:", projectID),
+		fmt.Sprintf("pt:codepush:%s:", projectID),
+	}
+	if channel != "" {
+		for i := range prefixes {
+			prefixes[i] = strings.ToLower(prefixes[i] + channel + ":")
+		}
+	}
+	return prefixes
+}
+
+// cacheGeneration returns the generation the key points to, starting a new one when it was
+// invalidated
+func cacheGeneration(ctx context.Context, c cache.Cache, key string) (string, error) {
+	generation, err := c.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("cache.Get: %w", err)
+	}
+	if generation != "" {
+		return generation, nil
+	}
+
+	generation = uuid.NewString()
+	if err := c.Set(ctx, key, generation, channelGenerationTTL); err != nil {
+		return "", fmt.Errorf("cache.Set: %w", err)
+	}
+	return generation, nil
+}
+
+// ConsumeChannelChangedEvents invalidates the cached Expo and CodePush responses of the channels
+// an update was published, rolled back or pinned on, and of the archived or deleted projects
+func ConsumeChannelChangedEvents(ctx context.Context, queueConn queue.Queue, c cache.Cache) error {
+	log := logger.FromContext(ctx)
+	return queueConn.ConsumeChannelChangedEvents(ctx, func(data []byte) {
+		event, err := queue.ParseChannelChangedEvent(data)
+		if err != nil {
+			log.Error("failed to parse channel changed event", zap.Error(err))
+			return
+		}
+
+		key := channelGenerationKey(event.ProjectID, event.Channel)
+		if event.Channel == "" {
+			key = projectGenerationKey(event.ProjectID)
+		}
+		if err := c.Delete(ctx, key); err != nil {
+			log.Error(
+				"failed to invalidate cached responses",
+				zap.String("project_id", event.ProjectID.String()),
+				zap.String("channel", event.Channel),
+				zap.Error(err),
+			)
+			return
+		}
+
+		// the responses of the old generation are never read again, they're deleted so they
+		// don't take up the cache until they expire
+		for _, prefix := range cachedResponsePrefixes(event.ProjectID, event.Channel) {
+			if err := c.DeleteByPrefix(ctx, prefix); err != nil {
+				log.Warn(
+					"failed to delete invalidated responses",
+					zap.String("prefix", prefix),
+					zap.Error(err),
+				)
+			}
+		}
+	})
+}
+
+// notifyProjectChanged makes the API servers invalidate the cached responses of all channels
+// of the project, the cached responses expire anyway, so a failure is only logged
+func (srv *apiServer) notifyProjectChanged(ctx context.Context, projectID uuid.UUID) {
+	err := srv.queueConn.PublishChannelChangedEvent(
+		context.WithoutCancel(ctx),
+		queue.ChannelChangedEventPayload{ProjectID: projectID},
+	)
+	if err != nil {
+		logger.FromContext(ctx).Warn(
+			"failed to publish project changed event",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+	}
+}
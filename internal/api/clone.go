@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/project"
+)
+
+func (srv *apiServer) CloneProject(
+	ctx context.Context,
+	request api.CloneProjectRequestObject,
+) (api.CloneProjectResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var environment string
+	if request.Body.Environment != nil {
+		environment = *request.Body.Environment
+	}
+
+	clone, err := srv.projectSvc.CloneProject(ctx, proj.ID, request.Body.Name, environment)
+	if err != nil {
+		if errors.Is(err, project.ErrProjectExists) {
+			return api.CloneProject400JSONResponse(
+				NewValidationErrorResponse("name", err.Error()),
+			), nil
+		}
+		return nil, fmt.Errorf("projectSvc.CloneProject: %w", err)
+	}
+	if clone == nil {
+		return nil, NewNotFoundError("project not found")
+	}
+
+	response := api.CloneProject200JSONResponse{
+		Project: projectResponse(clone),
+		Updates: make([]api.Update, 0),
+	}
+	if request.Body.CopyLatestUpdates != nil && *request.Body.CopyLatestUpdates {
+		updates, err := srv.updateSvc.CopyLatestUpdates(ctx, proj.ID, clone)
+		if err != nil {
+			return nil, fmt.Errorf("updateSvc.CopyLatestUpdates: %w", err)
+		}
+		for _, u := range updates {
+			response.Updates = append(response.Updates, updateResponse(&u))
+		}
+	}
+	return response, nil
+}
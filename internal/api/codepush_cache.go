@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/hooks"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type codePushUpdateParams struct {
+	ProjectID uuid.UUID
+	Channel   string
+	// Flavor of the deployment key, empty for apps without flavors
+	Flavor      string
+	Platform    string
+	AppVersion  string
+	PackageHash *string
+	// RolloutBucket of the device, by its client_unique_id
+	RolloutBucket int
+	// Region of the storage replica serving the client, empty for the primary bucket
+	Region string
+	// Metered is set for devices on metered connections
+	Metered bool
+}
+
+func (params *codePushUpdateParams) hooksRequest() hooks.Request {
+	return hooks.Request{
+		ProjectID:      params.ProjectID,
+		Channel:        params.Channel,
+		RuntimeVersion: params.AppVersion,
+		Platform:       params.Platform,
+		Flavor:         params.Flavor,
+	}
+}
+
+func codePushUpdateCacheKey(generation string, params *codePushUpdateParams) string {
+	packageHash := "none"
+	if params.PackageHash != nil {
+		packageHash = *params.PackageHash
+	}
+
+	// devices of different rollout buckets may get different updates
+	key := fmt.Sprintf(
+		"pt:codepush:%s:%s:%s:%s:%s:%s:%d",
+		params.ProjectID,
+		params.Channel,
+		generation,
+		params.Platform,
+		params.AppVersion,
+		packageHash,
+		params.RolloutBucket,
+	)
+	if params.Flavor != "" {
+		key += ":flavor:" + params.Flavor
+	}
+	if params.Region != "" {
+		key += ":" + params.Region
+	}
+	if params.Metered {
+		key += ":metered"
+	}
+
+	return strings.ToLower(key)
+}
+
+// codePushCachedResponse returns the cached update info, nil when it's not cached,
+// and the key to cache it with
+func (srv *apiServer) codePushCachedResponse(
+	ctx context.Context,
+	params *codePushUpdateParams,
+) (*api.CodePushUpdate, string, error) {
+	c := srv.infraSvc.Cache()
+	generation, err := channelGeneration(ctx, c, params.ProjectID, params.Channel)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cacheKey := codePushUpdateCacheKey(generation, params)
+	cachedResponseStr, err := c.Get(ctx, cacheKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("cache.Get: %w", err)
+	}
+	if cachedResponseStr == "" {
+		return nil, cacheKey, nil
+	}
+
+	var cachedResponse api.CodePushUpdate
+	if err := json.Unmarshal([]byte(cachedResponseStr), &cachedResponse); err != nil {
+		return nil, "", fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return &cachedResponse, cacheKey, nil
+}
+
+func (srv *apiServer) codePushSetCachedResponse(
+	ctx context.Context,
+	cacheKey string,
+	updateInfo *api.CodePushUpdate,
+) error {
+	responseJson, err := json.Marshal(updateInfo)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return srv.infraSvc.Cache().Set(ctx, cacheKey, string(responseJson), downloadURLCacheTTL())
+}
+
+// downloadURLCacheTTL is the TTL in seconds of responses with signed download URLs, they're
+// cached until the URLs are signed again, so clients get at least storage.DownloadURLExpiry
+// to download the assets
+func downloadURLCacheTTL() int {
+	// zero TTL would never expire
+	return max(int(storage.DownloadURLCacheTTL().Seconds()), 1)
+}
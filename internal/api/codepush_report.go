@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/codepush"
+)
+
+func (srv *apiServer) ReportCodePushDeployStatus(
+	ctx context.Context,
+	request api.ReportCodePushDeployStatusRequestObject,
+) (api.ReportCodePushDeployStatusResponseObject, error) {
+	projectID, _, _, channel, err := codepush.ParseDeploymentKey(request.Body.DeploymentKey)
+	if err != nil {
+		return api.ReportCodePushDeployStatus400JSONResponse(
+			NewValidationErrorResponse("deployment_key", "invalid deployment key"),
+		), nil
+	}
+
+	// reports of the binary have no label
+	var label, status string
+	if request.Body.Label != nil {
+		label = *request.Body.Label
+	}
+	if request.Body.Status != nil {
+		status = *request.Body.Status
+	}
+
+	err = srv.codePushSvc.ReportDeployment(ctx, projectID, channel, label, status)
+	if err != nil {
+		return nil, fmt.Errorf("codePushSvc.ReportDeployment: %w", err)
+	}
+	return api.ReportCodePushDeployStatus200Response{}, nil
+}
+
+func (srv *apiServer) ReportCodePushDownloadStatus(
+	ctx context.Context,
+	request api.ReportCodePushDownloadStatusRequestObject,
+) (api.ReportCodePushDownloadStatusResponseObject, error) {
+	projectID, _, _, channel, err := codepush.ParseDeploymentKey(request.Body.DeploymentKey)
+	if err != nil {
+		return api.ReportCodePushDownloadStatus400JSONResponse(
+			NewValidationErrorResponse("deployment_key", "invalid deployment key"),
+		), nil
+	}
+
+	err = srv.codePushSvc.ReportDownload(ctx, projectID, channel, request.Body.Label)
+	if err != nil {
+		return nil, fmt.Errorf("codePushSvc.ReportDownload: %w", err)
+	}
+	return api.ReportCodePushDownloadStatus200Response{}, nil
+}
+
+// ReportCodePushLegacyDeployStatus serves apps with the path of the standalone CodePush server
+// baked in
+func (srv *apiServer) ReportCodePushLegacyDeployStatus(
+	ctx context.Context,
+	request api.ReportCodePushLegacyDeployStatusRequestObject,
+) (api.ReportCodePushLegacyDeployStatusResponseObject, error) {
+	resp, err := srv.ReportCodePushDeployStatus(ctx, api.ReportCodePushDeployStatusRequestObject{
+		Body: &api.CodePushDeployReport{
+			AppVersion:                request.Body.AppVersion,
+			ClientUniqueID:            request.Body.ClientUniqueID,
+			DeploymentKey:             request.Body.DeploymentKey,
+			Label:                     request.Body.Label,
+			PreviousDeploymentKey:     request.Body.PreviousDeploymentKey,
+			PreviousLabelOrAppVersion: request.Body.PreviousLabelOrAppVersion,
+			Status:                    request.Body.Status,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := resp.(type) {
+	case api.ReportCodePushDeployStatus200Response:
+		return api.ReportCodePushLegacyDeployStatus200Response{}, nil
+	case api.ReportCodePushDeployStatus400JSONResponse:
+		return api.ReportCodePushLegacyDeployStatus400JSONResponse(resp), nil
+	default:
+		return nil, fmt.Errorf("unexpected CodePush deploy report response %T", resp)
+	}
+}
+
+// ReportCodePushLegacyDownloadStatus serves apps with the path of the standalone CodePush server
+// baked in
+func (srv *apiServer) ReportCodePushLegacyDownloadStatus(
+	ctx context.Context,
+	request api.ReportCodePushLegacyDownloadStatusRequestObject,
+) (api.ReportCodePushLegacyDownloadStatusResponseObject, error) {
+	resp, err := srv.ReportCodePushDownloadStatus(ctx, api.ReportCodePushDownloadStatusRequestObject{
+		Body: &api.CodePushDownloadReport{
+			ClientUniqueID: request.Body.ClientUniqueID,
+			DeploymentKey:  request.Body.DeploymentKey,
+			Label:          request.Body.Label,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := resp.(type) {
+	case api.ReportCodePushDownloadStatus200Response:
+		return api.ReportCodePushLegacyDownloadStatus200Response{}, nil
+	case api.ReportCodePushDownloadStatus400JSONResponse:
+		return api.ReportCodePushLegacyDownloadStatus400JSONResponse(resp), nil
+	default:
+		return nil, fmt.Errorf("unexpected CodePush download report response %T", resp)
+	}
+}
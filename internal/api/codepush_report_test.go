@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/codepush"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestReportCodePushLegacyDeployStatus(t *testing.T) {
+	srv := &apiServer{}
+	resp, err := srv.ReportCodePushLegacyDeployStatus(
+		logger.ContextWithLogger(context.Background(), zap.NewNop()),
+		api.ReportCodePushLegacyDeployStatusRequestObject{
+			Body: &api.CodePushLegacyDeployReport{
+				AppVersion:    "1.0.0",
+				DeploymentKey: "invalid",
+			},
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		api.ReportCodePushLegacyDeployStatus400JSONResponse(
+			NewValidationErrorResponse("deployment_key", "invalid deployment key"),
+		),
+		resp,
+	)
+}
+
+func TestCodePushDeployReportValidation(t *testing.T) {
+	for _, status := range []string{codepush.DeploymentSucceeded, codepush.DeploymentFailed} {
+		obj := api.CodePushDeployReport{Status: &status}
+		assert.NoError(t, binding.Validator.ValidateStruct(&obj), status)
+	}
+
+	status := "Downloaded"
+	obj := api.CodePushDeployReport{Status: &status}
+	assert.Error(t, binding.Validator.ValidateStruct(&obj))
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/update"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/gin-gonic/gin"
+)
+
+const debugPathPrefix = "/api/v1/debug/"
+
+// NewDebugAuthMiddleware guards the debug endpoints with a bearer token,
+// they're not served at all when the token isn't configured
+func NewDebugAuthMiddleware(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !strings.HasPrefix(ctx.Request.URL.Path, debugPathPrefix) {
+			ctx.Next()
+			return
+		}
+
+		if token == "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusNotFound,
+				api.GenericError{Error: "debug endpoints are disabled"},
+			)
+			return
+		}
+
+		requestToken, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			ctx.AbortWithStatusJSON(
+				http.StatusUnauthorized,
+				api.GenericError{Error: "invalid debug token"},
+			)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func (srv *apiServer) DebugUpdateCheck(
+	ctx context.Context,
+	request api.DebugUpdateCheckRequestObject,
+) (api.DebugUpdateCheckResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.Params.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeVersion, err := semver.NewVersion(request.Params.RuntimeVersion)
+	if err != nil {
+		return nil, NewValidationError("runtime_version", "invalid runtime version")
+	}
+
+	channel := update.DefaultChannelName
+	if request.Params.Channel != nil && *request.Params.Channel != "" {
+		channel = *request.Params.Channel
+	}
+
+	filter := update.CurrentUpdateFilter{
+		ID:     request.Params.CurrentUpdateID,
+		SHA256: request.Params.PackageHash,
+	}
+	var flavor string
+	if request.Params.Flavor != nil {
+		flavor = *request.Params.Flavor
+	}
+	if err := srv.checkClientFlavor(ctx, proj.ID, flavor); err != nil {
+		return nil, err
+	}
+
+	rolloutBucket := update.NoRolloutBucket
+	if request.Params.ClientID != nil {
+		rolloutBucket = update.RolloutBucket(*request.Params.ClientID)
+	}
+
+	resolution, err := srv.updateSvc.ResolveUpdateToInstall(
+		ctx,
+		proj.ID,
+		runtimeVersion.String(),
+		channel,
+		request.Params.Platform,
+		flavor,
+		rolloutBucket,
+		filter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.ResolveUpdateToInstall: %w", err)
+	}
+
+	trace := api.UpdateCheckTrace{
+		ProjectID:       proj.ID,
+		UpdateProtocol:  api.UpdateProtocol(proj.UpdateProtocol),
+		RuntimeVersion:  runtimeVersion.String(),
+		Channel:         channel,
+		Platform:        request.Params.Platform,
+		CurrentUpdateID: request.Params.CurrentUpdateID,
+		PackageHash:     request.Params.PackageHash,
+		Flavor:          request.Params.Flavor,
+		RolloutBucket:   rolloutBucket,
+		Candidates:      make([]api.UpdateCheckCandidate, 0, len(resolution.Candidates)),
+		Decision:        api.UpdateCheckTraceDecisionNoUpdateAvailable,
+		PinnedUpdateID:  resolution.PinnedUpdateID,
+		Reason:          resolution.Reason,
+	}
+
+	for _, candidate := range resolution.Candidates {
+		traceCandidate := api.UpdateCheckCandidate{
+			Update:    updateResponse(&candidate.Update),
+			IsCurrent: filter.IsCurrentUpdate(&candidate),
+		}
+		if candidate.ContentSha256.Valid {
+			traceCandidate.ContentSha256 = &candidate.ContentSha256.String
+		}
+		trace.Candidates = append(trace.Candidates, traceCandidate)
+	}
+
+	if resolution.Update != nil {
+		trace.UpdateID = &resolution.Update.Update.ID
+		trace.Decision = api.UpdateCheckTraceDecisionUpdate
+		if resolution.Update.Update.Status == db.UpdateStatusCanceled {
+			trace.Decision = api.UpdateCheckTraceDecisionRollBackToEmbedded
+		}
+	}
+
+	if proj.UpdateProtocol == db.UpdateProtocolExpo {
+		rollback, err := srv.updateSvc.ActiveChannelRollback(
+			ctx,
+			proj.ID,
+			channel,
+			trace.RuntimeVersion,
+			flavor,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("updateSvc.ActiveChannelRollback: %w", err)
+		}
+		if rollback != nil {
+			trace.UpdateID = nil
+			trace.Decision = api.UpdateCheckTraceDecisionRollBackToEmbedded
+			trace.Reason = "channel was rolled back to the embedded update"
+		}
+	}
+
+	// only Expo responses are cached
+	if proj.UpdateProtocol == db.UpdateProtocolExpo {
+		params := &expoUpdateParams{
+			RuntimeVersion:  trace.RuntimeVersion,
+			Platform:        trace.Platform,
+			CurrentUpdateId: trace.CurrentUpdateID,
+			Channel:         channel,
+			ProjectID:       proj.ID,
+			Flavor:          flavor,
+			RolloutBucket:   rolloutBucket,
+			Region:          srv.storage.RequestRegion(ctx),
+		}
+		cachedResponse, err := srv.expoUpdateCachedResponse(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("expoUpdateCachedResponse: %w", err)
+		}
+		trace.Cached = cachedResponse != nil
+
+		cacheKey := expoUpdateCacheKey(params)
+		trace.CacheKey = &cacheKey
+	}
+
+	return api.DebugUpdateCheck200JSONResponse(trace), nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(token, path, authorization string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(NewDebugAuthMiddleware(token))
+		r.GET(path, func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := serve("", "/api/v1/debug/update-check", "Bearer secret")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = serve("secret", "/api/v1/debug/update-check", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.JSONEq(t, `{"error": "invalid debug token"}`, resp.Body.String())
+
+	resp = serve("secret", "/api/v1/debug/update-check", "Bearer other")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = serve("secret", "/api/v1/debug/update-check", "Bearer secret")
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// other routes aren't affected
+	resp = serve("", "/api/v1/health", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	openAPIPath = "/openapi.json"
+	docsUIPath  = "/docs"
+)
+
+// docsUIPage renders Swagger UI of the served spec, the UI itself is loaded from a CDN
+const docsUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Paratrooper API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + openAPIPath + `", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// addDocsRoutes serves the OpenAPI spec the server was generated from
+func addDocsRoutes(r gin.IRoutes, uiEnabled bool) error {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+
+	specJSON, err := spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+
+	r.GET(openAPIPath, func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json", specJSON)
+	})
+
+	if uiEnabled {
+		r.GET(docsUIPath, func(ctx *gin.Context) {
+			ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsUIPage))
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func embeddedUpdateResponse(embedded *db.EmbeddedUpdate) api.EmbeddedUpdate {
+	return api.EmbeddedUpdate{
+		Platform:        embedded.Platform,
+		EmbeddedID:      embedded.EmbeddedID,
+		RuntimeVersion:  embedded.RuntimeVersion,
+		LaunchAssetHash: embedded.LaunchAssetSha256,
+		CreatedAt:       embedded.CreatedAt.Time.UTC().Truncate(time.Second),
+	}
+}
+
+func (srv *apiServer) GetEmbeddedUpdates(
+	ctx context.Context,
+	request api.GetEmbeddedUpdatesRequestObject,
+) (api.GetEmbeddedUpdatesResponseObject, error) {
+	embeddedUpdates, err := srv.updateSvc.EmbeddedUpdates(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.EmbeddedUpdates: %w", err)
+	}
+
+	response := make(api.GetEmbeddedUpdates200JSONResponse, 0, len(embeddedUpdates))
+	for _, embedded := range embeddedUpdates {
+		response = append(response, embeddedUpdateResponse(&embedded))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) RegisterEmbeddedUpdate(
+	ctx context.Context,
+	request api.RegisterEmbeddedUpdateRequestObject,
+) (api.RegisterEmbeddedUpdateResponseObject, error) {
+	// normalized, so it matches the runtime version of the clients
+	runtimeVersion, err := semver.NewVersion(request.Body.RuntimeVersion)
+	if err != nil {
+		return nil, NewValidationError("runtime_version", "invalid runtime version")
+	}
+
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, err := srv.updateSvc.RegisterEmbeddedUpdate(ctx, db.RegisterEmbeddedUpdateParams{
+		ProjectID:         proj.ID,
+		Platform:          strings.ToLower(request.Body.Platform),
+		EmbeddedID:        request.Body.EmbeddedID,
+		RuntimeVersion:    runtimeVersion.String(),
+		LaunchAssetSha256: request.Body.LaunchAssetHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.RegisterEmbeddedUpdate: %w", err)
+	}
+
+	return api.RegisterEmbeddedUpdate200JSONResponse(embeddedUpdateResponse(embedded)), nil
+}
+
+func channelRollbackResponse(rollback *db.ChannelRollback) api.ChannelRollback {
+	response := api.ChannelRollback{
+		Channel:    rollback.Channel,
+		CommitTime: rollback.CommittedAt.Time.UTC(),
+	}
+	if rollback.RuntimeVersion != "" {
+		response.RuntimeVersion = &rollback.RuntimeVersion
+	}
+	return response
+}
+
+func (srv *apiServer) RollBackToEmbedded(
+	ctx context.Context,
+	request api.RollBackToEmbeddedRequestObject,
+) (api.RollBackToEmbeddedResponseObject, error) {
+	if request.Channel == "" || len(request.Channel) > 100 {
+		return nil, NewValidationError("channel", "invalid channel")
+	}
+
+	var runtimeVersion string
+	if request.Body != nil && request.Body.RuntimeVersion != nil {
+		// normalized, so it matches the runtime version of the clients
+		version, err := semver.NewVersion(*request.Body.RuntimeVersion)
+		if err != nil {
+			return nil, NewValidationError("runtime_version", "invalid runtime version")
+		}
+		runtimeVersion = version.String()
+	}
+
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if proj.UpdateProtocol != db.UpdateProtocolExpo {
+		return nil, NewValidationError("project_id", "project does not use Expo update protocol")
+	}
+
+	rollback, err := srv.updateSvc.RollBackToEmbedded(
+		ctx,
+		proj.ID,
+		request.Channel,
+		runtimeVersion,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.RollBackToEmbedded: %w", err)
+	}
+
+	return api.RollBackToEmbedded201JSONResponse(channelRollbackResponse(rollback)), nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelRollbackResponse(t *testing.T) {
+	committedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	rollback := &db.ChannelRollback{
+		Channel:     "production",
+		CommittedAt: pgtype.Timestamptz{Time: committedAt, Valid: true},
+	}
+
+	response := channelRollbackResponse(rollback)
+	require.Nil(t, response.RuntimeVersion)
+	require.Equal(t, committedAt, response.CommitTime)
+
+	rollback.RuntimeVersion = "1.0.0"
+	require.Equal(t, "1.0.0", *channelRollbackResponse(rollback).RuntimeVersion)
+
+	resp := newExpoRollBackToEmbeddedResponse(committedAt)
+	resp.ChannelRollback = true
+	require.Equal(t, analytics.DecisionRollBackToEmbedded, resp.decision())
+	require.Equal(t, analytics.ReasonChannelRollback, resp.reason())
+	require.Equal(
+		t,
+		gin.H{"commitTime": "2024-05-01T12:30:00.0Z"},
+		resp.Payload.(gin.H)["parameters"],
+	)
+
+	updateID := uuid.New()
+	resp = newExpoRollBackToEmbeddedResponse(committedAt)
+	resp.UpdateID = &updateID
+	require.Equal(t, analytics.ReasonUpdateCanceled, resp.reason())
+	require.Empty(t, newExpoNoUpdateResponse().reason())
+}
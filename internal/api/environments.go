@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/project"
+)
+
+func (srv *apiServer) GetProjectEnvironments(
+	ctx context.Context,
+	request api.GetProjectEnvironmentsRequestObject,
+) (api.GetProjectEnvironmentsResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := srv.projectSvc.Environments(ctx, proj)
+	if err != nil {
+		return nil, fmt.Errorf("projectSvc.Environments: %w", err)
+	}
+
+	response := make(api.GetProjectEnvironments200JSONResponse, 0, len(projects))
+	for _, p := range projects {
+		response = append(response, projectResponse(&p))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) CopyProjectToEnvironment(
+	ctx context.Context,
+	request api.CopyProjectToEnvironmentRequestObject,
+) (api.CopyProjectToEnvironmentResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := srv.projectSvc.CopyToEnvironment(ctx, proj.ID, request.Body.Environment)
+	if err != nil {
+		if errors.Is(err, project.ErrSameEnvironment) ||
+			errors.Is(err, project.ErrUpdateProtocolMismatch) ||
+			errors.Is(err, project.ErrProjectDeleted) {
+			return api.CopyProjectToEnvironment400JSONResponse(
+				NewValidationErrorResponse("environment", err.Error()),
+			), nil
+		}
+		return nil, fmt.Errorf("projectSvc.CopyToEnvironment: %w", err)
+	}
+	if target == nil {
+		return nil, NewNotFoundError("project not found")
+	}
+
+	return api.CopyProjectToEnvironment200JSONResponse(projectResponse(target)), nil
+}
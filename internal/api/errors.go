@@ -1,9 +1,13 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 
@@ -52,8 +56,135 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation failed for field %s: %s", e.Field, e.Message)
 }
 
+// errorCode is the code of error responses with the status, validation errors have their own
+func errorCode(status int) api.ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return api.ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return api.ErrorCodeForbidden
+	case http.StatusNotFound:
+		return api.ErrorCodeNotFound
+	case http.StatusConflict:
+		return api.ErrorCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return api.ErrorCodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return api.ErrorCodeRateLimited
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return api.ErrorCodeTimeout
+	case http.StatusServiceUnavailable:
+		return api.ErrorCodeUnavailable
+	}
+	if status >= 500 {
+		return api.ErrorCodeInternal
+	}
+	return api.ErrorCodeBadRequest
+}
+
+// retryableStatus reports whether the same request may succeed later, server errors are
+// usually caused by an unavailable dependency
+func retryableStatus(status int) bool {
+	return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests ||
+		(status >= 500 && status != http.StatusNotImplemented)
+}
+
+// errorEnvelopeWriter holds back JSON error responses, so the code and the retryability of
+// the error are added to the ones written without them
+type errorEnvelopeWriter struct {
+	gin.ResponseWriter
+	buffering bool
+	body      bytes.Buffer
+}
+
+func (w *errorEnvelopeWriter) Write(data []byte) (int, error) {
+	if !w.buffering && !w.Written() && w.Status() >= 400 &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.buffering = true
+	}
+	if w.buffering {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorEnvelopeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Unwrap gives http.ResponseController access to the connection
+func (w *errorEnvelopeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flush writes the held back response
+func (w *errorEnvelopeWriter) flush() {
+	if !w.buffering {
+		return
+	}
+	body := completeErrorEnvelope(w.Status(), w.body.Bytes())
+	w.Header().Del("Content-Length")
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// completeErrorEnvelope adds the fields of the error envelope missing from the response body,
+// bodies that aren't JSON objects are kept as they are
+func completeErrorEnvelope(status int, body []byte) []byte {
+	var envelope map[string]any
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope == nil {
+		return body
+	}
+
+	if _, ok := envelope["code"]; !ok {
+		envelope["code"] = errorCode(status)
+		if _, ok := envelope["errors"]; ok {
+			envelope["code"] = api.ErrorCodeValidationFailed
+		}
+	}
+	if _, ok := envelope["retryable"]; !ok {
+		envelope["retryable"] = retryableStatus(status)
+	}
+	if message, _ := envelope["error"].(string); message == "" {
+		envelope["error"] = errorMessage(status, envelope["errors"])
+	}
+
+	completed, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return append(completed, '\n')
+}
+
+// errorMessage describes the field errors of a validation failure, e.g. for the CLI to show
+// them as they are, or the status of other errors
+func errorMessage(status int, fieldErrors any) string {
+	list, _ := fieldErrors.([]any)
+	messages := make([]string, 0, len(list))
+	for _, item := range list {
+		fieldError, _ := item.(map[string]any)
+		field, _ := fieldError["field"].(string)
+		message, _ := fieldError["message"].(string)
+		if message != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", field, message))
+		}
+	}
+	if len(messages) == 0 {
+		return strings.ToLower(http.StatusText(status))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NewErrorHandlingMiddleware turns the errors of the handlers into error responses, every JSON
+// error response is completed with the code and the retryability of the error
 func NewErrorHandlingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		writer := &errorEnvelopeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			writer.flush()
+			c.Writer = writer.ResponseWriter
+		}()
+
 		c.Next()
 
 		if len(c.Errors) == 0 {
@@ -93,7 +224,24 @@ func NewErrorHandlingMiddleware() gin.HandlerFunc {
 				continue
 			}
 
-			if errors.As(err.Err, &httpError) {
+			if errors.Is(err.Err, context.DeadlineExceeded) {
+				c.AbortWithStatusJSON(
+					http.StatusGatewayTimeout,
+					api.GenericError{Error: "request timed out"},
+				)
+				return
+			}
+
+			// nobody reads the response, the status is only logged
+			if errors.Is(err.Err, context.Canceled) && c.Request.Context().Err() != nil {
+				c.AbortWithStatus(statusClientClosedRequest)
+				return
+			}
+
+			if tooLarge := bodyTooLargeError(err.Err); tooLarge != nil {
+				httpError = tooLarge
+			}
+			if httpError != nil || errors.As(err.Err, &httpError) {
 				c.AbortWithStatusJSON(
 					httpError.StatusCode,
 					api.GenericError{
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(NewErrorHandlingMiddleware())
+	r.GET("/validation", func(ctx *gin.Context) {
+		ctx.Error(NewValidationError("files", "file a.png exceeds the size limit"))
+	})
+	r.GET("/internal", func(ctx *gin.Context) {
+		ctx.Error(errors.New("connection refused"))
+	})
+	r.GET("/not-found", func(ctx *gin.Context) {
+		// written by the handler itself
+		ctx.JSON(http.StatusNotFound, api.GenericError{Error: "update not found"})
+	})
+	r.GET("/ok", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	resp := serve("/validation")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{
+		"error": "files: file a.png exceeds the size limit",
+		"code": "validation_failed",
+		"retryable": false,
+		"errors": [{"field": "files", "message": "file a.png exceeds the size limit"}]
+	}`, resp.Body.String())
+
+	resp = serve("/internal")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(
+		t,
+		`{"error": "connection refused", "code": "internal", "retryable": true}`,
+		resp.Body.String(),
+	)
+
+	resp = serve("/not-found")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(
+		t,
+		`{"error": "update not found", "code": "not_found", "retryable": false}`,
+		resp.Body.String(),
+	)
+
+	resp = serve("/ok")
+	assert.JSONEq(t, `{"status": "ok"}`, resp.Body.String())
+}
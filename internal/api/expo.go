@@ -1,18 +1,85 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"strings"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+	"github.com/a-gierczak/paratrooper/internal/expo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type expoUpdateMultipartResponse struct {
 	PartName string `json:"partName"`
 	Payload  any    `json:"payload"`
+	// Extensions are sent in a separate part, only alongside a manifest
+	Extensions any `json:"extensions,omitempty"`
+	// UpdateID of the served manifest or the canceled update rolled back, it's not sent
+	UpdateID *uuid.UUID `json:"updateId,omitempty"`
+	// ChannelRollback is set when the channel was rolled back to the embedded update
+	ChannelRollback bool `json:"channelRollback,omitempty"`
+	// ETag of the manifest, empty for directives and updates published without a content hash
+	ETag string `json:"etag,omitempty"`
+	// signer signs the manifest or directive part when the client expects a signature
+	signer *expo.Signer
+}
+
+// expoManifestETag is weak, the asset URLs of the manifest are signed again over time,
+// but it keeps pointing to the same assets
+func expoManifestETag(contentHash pgtype.Text, platform string) string {
+	if !contentHash.Valid {
+		return ""
+	}
+	return fmt.Sprintf(`W/"%s-%s"`, contentHash.String, platform)
+}
+
+// etagMatches compares the If-None-Match header with the ETag, weakly, as required for GET
+func etagMatches(ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil || etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(*ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" ||
+			strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// decision of the update check, for analytics
+func (resp *expoUpdateMultipartResponse) decision() string {
+	switch {
+	case resp.PartName == "manifest":
+		return analytics.DecisionUpdate
+	case resp.UpdateID != nil, resp.ChannelRollback:
+		return analytics.DecisionRollBackToEmbedded
+	default:
+		return analytics.DecisionNoUpdate
+	}
+}
+
+// reason of the rollback to the embedded update, for analytics, empty for other decisions
+func (resp *expoUpdateMultipartResponse) reason() string {
+	switch {
+	case resp.PartName == "manifest":
+		return ""
+	case resp.ChannelRollback:
+		return analytics.ReasonChannelRollback
+	case resp.UpdateID != nil:
+		return analytics.ReasonUpdateCanceled
+	default:
+		return ""
+	}
 }
 
 func (resp *expoUpdateMultipartResponse) VisitGetExpoUpdateResponse(w http.ResponseWriter) error {
@@ -20,23 +87,28 @@ func (resp *expoUpdateMultipartResponse) VisitGetExpoUpdateResponse(w http.Respo
 		ExpoProtocolVersion: "1",
 		ExpoSfvVersion:      "0",
 		CacheControl:        "private, max-age=0",
+		ETag:                resp.ETag,
 	}
 
 	body := func(w *multipart.Writer) error {
-		partWriter, err := w.CreatePart(textproto.MIMEHeader{
-			"Content-Disposition": []string{"form-data; name=" + resp.PartName},
-			"Content-Type":        []string{"application/json"},
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create part: %w", err)
+		if err := writeJSONPart(w, resp.PartName, resp.Payload, resp.signer); err != nil {
+			return err
 		}
 
-		jsonEncoder := json.NewEncoder(partWriter)
-		jsonEncoder.SetEscapeHTML(false)
+		if resp.Extensions != nil {
+			if err := writeJSONPart(w, "extensions", resp.Extensions, nil); err != nil {
+				return err
+			}
+		}
 
-		err = jsonEncoder.Encode(resp.Payload)
-		if err != nil {
-			return fmt.Errorf("failed to JSON encode payload: %w", err)
+		if resp.signer != nil && resp.signer.CertificateChain != "" {
+			return writePart(
+				w,
+				"certificate_chain",
+				"application/x-pem-file",
+				[]byte(resp.signer.CertificateChain),
+				nil,
+			)
 		}
 
 		return nil
@@ -49,3 +121,48 @@ func (resp *expoUpdateMultipartResponse) VisitGetExpoUpdateResponse(w http.Respo
 
 	return apiResp.VisitGetExpoUpdateResponse(w)
 }
+
+// writeJSONPart writes the payload, signed when the signer is set
+func writeJSONPart(w *multipart.Writer, name string, payload any, signer *expo.Signer) error {
+	body := new(bytes.Buffer)
+	jsonEncoder := json.NewEncoder(body)
+	jsonEncoder.SetEscapeHTML(false)
+
+	err := jsonEncoder.Encode(payload)
+	if err != nil {
+		return fmt.Errorf("failed to JSON encode payload: %w", err)
+	}
+
+	return writePart(w, name, "application/json", body.Bytes(), signer)
+}
+
+// writePart writes the part, the signature is calculated over the exact bytes of the body
+func writePart(
+	w *multipart.Writer,
+	name string,
+	contentType string,
+	body []byte,
+	signer *expo.Signer,
+) error {
+	header := textproto.MIMEHeader{
+		"Content-Disposition": []string{"form-data; name=" + name},
+		"Content-Type":        []string{contentType},
+	}
+	if signer != nil {
+		signature, err := signer.Signature(body)
+		if err != nil {
+			return fmt.Errorf("failed to sign part: %w", err)
+		}
+		header.Set("expo-signature", signature)
+	}
+
+	partWriter, err := w.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create part: %w", err)
+	}
+	if _, err := partWriter.Write(body); err != nil {
+		return fmt.Errorf("failed to write part: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/project"
+
+	"github.com/google/uuid"
+)
+
+func flavorResponse(flavor *db.ProjectFlavor) api.Flavor {
+	return api.Flavor{
+		Name:      flavor.Name,
+		CreatedAt: flavor.CreatedAt.Time.UTC().Truncate(time.Second),
+	}
+}
+
+func (srv *apiServer) GetFlavors(
+	ctx context.Context,
+	request api.GetFlavorsRequestObject,
+) (api.GetFlavorsResponseObject, error) {
+	flavors, err := srv.projectSvc.Flavors(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("projectSvc.Flavors: %w", err)
+	}
+
+	response := make(api.GetFlavors200JSONResponse, 0, len(flavors))
+	for _, flavor := range flavors {
+		response = append(response, flavorResponse(&flavor))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) CreateFlavor(
+	ctx context.Context,
+	request api.CreateFlavorRequestObject,
+) (api.CreateFlavorResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	flavor, err := srv.projectSvc.CreateFlavor(ctx, proj.ID, request.Body.Name)
+	if err != nil {
+		if errors.Is(err, project.ErrInvalidFlavorName) ||
+			errors.Is(err, project.ErrFlavorExists) {
+			return api.CreateFlavor400JSONResponse(
+				NewValidationErrorResponse("name", err.Error()),
+			), nil
+		}
+		return nil, fmt.Errorf("projectSvc.CreateFlavor: %w", err)
+	}
+
+	return api.CreateFlavor201JSONResponse(flavorResponse(flavor)), nil
+}
+
+func (srv *apiServer) DeleteFlavor(
+	ctx context.Context,
+	request api.DeleteFlavorRequestObject,
+) (api.DeleteFlavorResponseObject, error) {
+	err := srv.projectSvc.DeleteFlavor(ctx, request.ProjectID, request.Flavor)
+	if err != nil {
+		if errors.Is(err, project.ErrFlavorNotFound) {
+			return api.DeleteFlavor404JSONResponse{Error: err.Error()}, nil
+		}
+		return nil, fmt.Errorf("projectSvc.DeleteFlavor: %w", err)
+	}
+
+	return api.DeleteFlavor204Response{}, nil
+}
+
+// validateTargetFlavors checks that the flavors an update targets exist, and removes
+// the duplicates
+func (srv *apiServer) validateTargetFlavors(
+	ctx context.Context,
+	projectID uuid.UUID,
+	targets []string,
+) ([]string, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	flavors, err := srv.projectSvc.Flavors(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("projectSvc.Flavors: %w", err)
+	}
+
+	for _, target := range targets {
+		exists := slices.ContainsFunc(flavors, func(flavor db.ProjectFlavor) bool {
+			return flavor.Name == target
+		})
+		if !exists {
+			return nil, NewValidationError(
+				"flavors",
+				fmt.Sprintf("project has no flavor %s", target),
+			)
+		}
+	}
+
+	targets = slices.Clone(targets)
+	slices.Sort(targets)
+	return slices.Compact(targets), nil
+}
+
+// checkClientFlavor returns a ValidationError when the client's flavor doesn't exist,
+// clients without a flavor are always valid
+func (srv *apiServer) checkClientFlavor(
+	ctx context.Context,
+	projectID uuid.UUID,
+	flavor string,
+) error {
+	if flavor == "" {
+		return nil
+	}
+
+	exists, err := srv.projectSvc.HasFlavor(ctx, projectID, flavor)
+	if err != nil {
+		return fmt.Errorf("projectSvc.HasFlavor: %w", err)
+	}
+	if !exists {
+		return NewValidationError("flavor", "flavor not found")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPConfig tunes the HTTP server. Headers are limited for all requests, bodies and timeouts
+// per route group: uploads stream large bodies for as long as the client needs, downloads
+// write large responses, and the JSON endpoints get small bodies and short timeouts, so slow
+// clients can't hold connections open.
+type HTTPConfig struct {
+	MaxHeaderBytes    int           `env:"API_MAX_HEADER_BYTES,default=65536"`
+	ReadHeaderTimeout time.Duration `env:"API_READ_HEADER_TIMEOUT,default=10s"`
+	IdleTimeout       time.Duration `env:"API_IDLE_TIMEOUT,default=2m"`
+	// JSONMaxBodyBytes limits the bodies of the JSON endpoints, e.g. the file list of a prepared
+	// update
+	JSONMaxBodyBytes int64         `env:"API_JSON_MAX_BODY_BYTES,default=4194304"`
+	JSONReadTimeout  time.Duration `env:"API_JSON_READ_TIMEOUT,default=30s"`
+	JSONWriteTimeout time.Duration `env:"API_JSON_WRITE_TIMEOUT,default=1m"`
+	// UploadMaxBodyBytes limits the files uploaded to the local storage, the chunks of chunked
+	// uploads and the multipart asset uploads
+	UploadMaxBodyBytes int64 `env:"API_UPLOAD_MAX_BODY_BYTES,default=104857600"`
+	// UploadReadTimeout and DownloadWriteTimeout are disabled by default, large files are sent
+	// over slow networks too
+	UploadReadTimeout    time.Duration `env:"API_UPLOAD_READ_TIMEOUT,default=0"`
+	DownloadWriteTimeout time.Duration `env:"API_DOWNLOAD_WRITE_TIMEOUT,default=0"`
+}
+
+func (c HTTPConfig) server(handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		MaxHeaderBytes:    c.MaxHeaderBytes,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		IdleTimeout:       c.IdleTimeout,
+	}
+}
+
+type routeGroup int
+
+const (
+	routeGroupJSON routeGroup = iota
+	routeGroupUpload
+	routeGroupDownload
+)
+
+// uploadRoutes stream the request body to the storage
+var uploadRoutes = map[string]bool{
+	http.MethodPut + " " + storage.AssetEndpointPath:                                 true,
+	http.MethodPut + " /api/v1/admin/:projectID/update/:updateID/chunks/:chunkIndex": true,
+	http.MethodPost + " /api/v1/admin/:projectID/update/:updateID/assets":            true,
+}
+
+// downloadRoutes stream stored files
+var downloadRoutes = map[string]bool{
+	storage.AssetEndpointPath:       true,
+	storage.StableAssetEndpointPath: true,
+	storage.EdgeEndpointPath:        true,
+}
+
+func routeGroupOf(ctx *gin.Context) routeGroup {
+	if uploadRoutes[ctx.Request.Method+" "+ctx.FullPath()] {
+		return routeGroupUpload
+	}
+	if downloadRoutes[ctx.FullPath()] &&
+		(ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead) {
+		return routeGroupDownload
+	}
+	return routeGroupJSON
+}
+
+// NewHTTPLimitsMiddleware applies the body limit and the timeouts of the route group of
+// the request, it has to run after the error handling middleware
+func NewHTTPLimitsMiddleware(config HTTPConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		maxBodyBytes := config.JSONMaxBodyBytes
+		readTimeout, writeTimeout := config.JSONReadTimeout, config.JSONWriteTimeout
+		switch routeGroupOf(ctx) {
+		case routeGroupUpload:
+			maxBodyBytes = config.UploadMaxBodyBytes
+			readTimeout = config.UploadReadTimeout
+		case routeGroupDownload:
+			writeTimeout = config.DownloadWriteTimeout
+		}
+
+		if maxBodyBytes > 0 {
+			if ctx.Request.ContentLength > maxBodyBytes {
+				ctx.Error(newBodyTooLargeError(maxBodyBytes))
+				ctx.Abort()
+				return
+			}
+			ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes)
+		}
+
+		// the deadlines of the previous request of the connection are cleared too, deadlines
+		// aren't supported by every connection, e.g. of the tests
+		controller := http.NewResponseController(ctx.Writer)
+		now := time.Now()
+		_ = controller.SetReadDeadline(deadline(now, readTimeout))
+		_ = controller.SetWriteDeadline(deadline(now, writeTimeout))
+
+		ctx.Next()
+	}
+}
+
+// deadline is after the timeout, zero time without one
+func deadline(now time.Time, timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return now.Add(timeout)
+}
+
+func newBodyTooLargeError(limit int64) *HTTPError {
+	return &HTTPError{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Message:    fmt.Sprintf("request body is larger than %d bytes", limit),
+	}
+}
+
+// bodyTooLargeError returns the error of the request with a body larger than the limit,
+// nil for other errors
+func bodyTooLargeError(err error) *HTTPError {
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		return nil
+	}
+	return newBodyTooLargeError(maxBytesErr.Limit)
+}
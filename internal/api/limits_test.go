@@ -0,0 +1,75 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPLimitsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(NewErrorHandlingMiddleware())
+	r.Use(NewHTTPLimitsMiddleware(HTTPConfig{JSONMaxBodyBytes: 10, UploadMaxBodyBytes: 100}))
+	handler := func(ctx *gin.Context) {
+		if _, err := io.ReadAll(ctx.Request.Body); err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	}
+	r.POST("/api/v1/admin/:projectID/update", handler)
+	r.PUT(storage.AssetEndpointPath, handler)
+
+	serve := func(method, path string, body io.Reader) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest(method, path, body))
+		return resp
+	}
+
+	body := strings.Repeat("a", 50)
+	resp := serve(http.MethodPost, "/api/v1/admin/1/update", strings.NewReader(body))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"code":"payload_too_large"`)
+
+	// the body size isn't known up front
+	resp = serve(http.MethodPost, "/api/v1/admin/1/update", io.LimitReader(strings.NewReader(body), 50))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+
+	resp = serve(http.MethodPut, storage.AssetEndpointPath, strings.NewReader(body))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	resp = serve(http.MethodPut, storage.AssetEndpointPath, strings.NewReader(body+body+body))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+}
+
+func TestHTTPLimitsMiddlewareMultipartUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(NewErrorHandlingMiddleware())
+	r.Use(NewHTTPLimitsMiddleware(HTTPConfig{
+		JSONMaxBodyBytes:   4 << 20,
+		UploadMaxBodyBytes: 100 << 20,
+	}))
+	r.POST("/api/v1/admin/:projectID/update/:updateID/assets", func(ctx *gin.Context) {
+		if _, err := io.Copy(io.Discard, ctx.Request.Body); err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+
+	// bundles uploaded as multipart forms are larger than the bodies of the JSON endpoints
+	body := strings.NewReader(strings.Repeat("a", 5<<20))
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/api/v1/admin/1/update/2/assets", body))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
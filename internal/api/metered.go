@@ -0,0 +1,30 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+)
+
+// meteredNetworks are the values of the X-Pt-Network header of metered connections
+var meteredNetworks = []string{"cellular", "metered"}
+
+// isMeteredNetwork reports whether the device reported a metered connection, devices that
+// didn't report theirs aren't on one
+func isMeteredNetwork(network *string) bool {
+	if network == nil {
+		return false
+	}
+	for _, metered := range meteredNetworks {
+		if strings.EqualFold(strings.TrimSpace(*network), metered) {
+			return true
+		}
+	}
+	return false
+}
+
+// deferredOnMeteredNetwork reports whether the optional update downloading size bytes is
+// served to the devices on metered connections only once they're on another one
+func deferredOnMeteredNetwork(proj *db.Project, mandatory bool, size int64) bool {
+	return !mandatory && proj.MeteredDownloadLimit > 0 && size > proj.MeteredDownloadLimit
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferredOnMeteredNetwork(t *testing.T) {
+	network := func(value string) *string { return &value }
+	require.True(t, isMeteredNetwork(network("cellular")))
+	require.True(t, isMeteredNetwork(network(" Metered")))
+	require.False(t, isMeteredNetwork(network("wifi")))
+	require.False(t, isMeteredNetwork(nil))
+
+	proj := &db.Project{MeteredDownloadLimit: 1000}
+	require.True(t, deferredOnMeteredNetwork(proj, false, 1001))
+	require.False(t, deferredOnMeteredNetwork(proj, false, 1000))
+	require.False(t, deferredOnMeteredNetwork(proj, true, 1001))
+	require.False(t, deferredOnMeteredNetwork(&db.Project{}, false, 1001))
+
+	params := &expoUpdateParams{RuntimeVersion: "1.0.0", Platform: "ios"}
+	key := expoUpdateCacheKey(params)
+	params.Metered = true
+	require.Equal(t, key+":metered", expoUpdateCacheKey(params))
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/update"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func channelPinResponse(pin *db.ChannelPin) api.ChannelPin {
+	return api.ChannelPin{
+		Channel:        pin.Channel,
+		RuntimeVersion: pin.RuntimeVersion,
+		UpdateID:       pin.UpdateID,
+		CreatedAt:      pin.CreatedAt.Time.UTC().Truncate(time.Second),
+	}
+}
+
+func (srv *apiServer) GetChannelPins(
+	ctx context.Context,
+	request api.GetChannelPinsRequestObject,
+) (api.GetChannelPinsResponseObject, error) {
+	pins, err := srv.updateSvc.ChannelPins(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.ChannelPins: %w", err)
+	}
+
+	response := make(api.GetChannelPins200JSONResponse, 0, len(pins))
+	for _, pin := range pins {
+		response = append(response, channelPinResponse(&pin))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) PinChannel(
+	ctx context.Context,
+	request api.PinChannelRequestObject,
+) (api.PinChannelResponseObject, error) {
+	pin, err := srv.updateSvc.PinChannel(ctx, request.ProjectID, request.Body.UpdateID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return api.PinChannel400JSONResponse(
+				NewValidationErrorResponse("update_id", "update not found"),
+			), nil
+		}
+		if errors.Is(err, update.ErrPinNotPublished) {
+			return api.PinChannel400JSONResponse(
+				NewValidationErrorResponse("update_id", "update not published"),
+			), nil
+		}
+		return nil, fmt.Errorf("updateSvc.PinChannel: %w", err)
+	}
+
+	return api.PinChannel200JSONResponse(channelPinResponse(pin)), nil
+}
+
+func (srv *apiServer) UnpinChannel(
+	ctx context.Context,
+	request api.UnpinChannelRequestObject,
+) (api.UnpinChannelResponseObject, error) {
+	runtimeVersion, err := semver.NewVersion(request.Params.RuntimeVersion)
+	if err != nil {
+		return nil, NewValidationError("runtime_version", "invalid runtime version")
+	}
+
+	err = srv.updateSvc.UnpinChannel(
+		ctx,
+		request.ProjectID,
+		request.Params.Channel,
+		runtimeVersion.String(),
+	)
+	if err != nil {
+		if errors.Is(err, update.ErrChannelPinNotFound) {
+			return api.UnpinChannel404JSONResponse{Error: err.Error()}, nil
+		}
+		return nil, fmt.Errorf("updateSvc.UnpinChannel: %w", err)
+	}
+
+	return api.UnpinChannel204Response{}, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/update"
+)
+
+func channelPolicyResponse(policy *db.ChannelPolicy) api.ChannelPolicy {
+	resp := api.ChannelPolicy{
+		Channel:   policy.Channel,
+		UpdatedAt: policy.UpdatedAt.Time.UTC().Truncate(time.Second),
+	}
+	if policy.RuntimeVersionConstraint.Valid {
+		resp.RuntimeVersionConstraint = &policy.RuntimeVersionConstraint.String
+	}
+	if policy.MessagePattern.Valid {
+		resp.MessagePattern = &policy.MessagePattern.String
+	}
+	return resp
+}
+
+func (srv *apiServer) GetChannelPolicies(
+	ctx context.Context,
+	request api.GetChannelPoliciesRequestObject,
+) (api.GetChannelPoliciesResponseObject, error) {
+	policies, err := srv.updateSvc.ChannelPolicies(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.ChannelPolicies: %w", err)
+	}
+
+	response := make(api.GetChannelPolicies200JSONResponse, 0, len(policies))
+	for _, policy := range policies {
+		response = append(response, channelPolicyResponse(&policy))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) SetChannelPolicy(
+	ctx context.Context,
+	request api.SetChannelPolicyRequestObject,
+) (api.SetChannelPolicyResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var params update.ChannelPolicyParams
+	if request.Body.RuntimeVersionConstraint != nil {
+		params.RuntimeVersionConstraint = *request.Body.RuntimeVersionConstraint
+	}
+	if request.Body.MessagePattern != nil {
+		params.MessagePattern = *request.Body.MessagePattern
+	}
+
+	policy, err := srv.updateSvc.SetChannelPolicy(ctx, proj.ID, request.Body.Channel, params)
+	if err != nil {
+		var policyErr *update.PolicyViolationError
+		if errors.As(err, &policyErr) {
+			return api.SetChannelPolicy400JSONResponse(
+				NewValidationErrorResponse(policyErr.Field, policyErr.Message),
+			), nil
+		}
+		return nil, fmt.Errorf("updateSvc.SetChannelPolicy: %w", err)
+	}
+
+	return api.SetChannelPolicy200JSONResponse(channelPolicyResponse(policy)), nil
+}
+
+func (srv *apiServer) DeleteChannelPolicy(
+	ctx context.Context,
+	request api.DeleteChannelPolicyRequestObject,
+) (api.DeleteChannelPolicyResponseObject, error) {
+	err := srv.updateSvc.DeleteChannelPolicy(ctx, request.ProjectID, request.Params.Channel)
+	if err != nil {
+		if errors.Is(err, update.ErrChannelPolicyNotFound) {
+			return api.DeleteChannelPolicy404JSONResponse{Error: err.Error()}, nil
+		}
+		return nil, fmt.Errorf("updateSvc.DeleteChannelPolicy: %w", err)
+	}
+
+	return api.DeleteChannelPolicy204Response{}, nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/project"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// maintenancePath stays writable, so the maintenance mode can be disabled
+const maintenancePath = "/api/v1/admin/maintenance"
+
+type MaintenanceConfig struct {
+	// Enabled starts the server in the maintenance mode, e.g. while the database is migrated,
+	// until the mode is switched at runtime
+	Enabled bool `env:"MAINTENANCE_MODE"`
+	// RetryAfter is sent in the Retry-After header of the rejected requests
+	RetryAfter time.Duration `env:"MAINTENANCE_RETRY_AFTER,default=5m"`
+	// RefreshInterval is how often the mode switched at runtime on any API server is read
+	RefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL,default=5s"`
+}
+
+// MaintenanceStore keeps the maintenance mode switched at runtime, so every API server reads
+// it, it's implemented by db.Queries. GetMaintenanceMode returns pgx.ErrNoRows until the mode
+// is switched for the first time.
+type MaintenanceStore interface {
+	GetMaintenanceMode(ctx context.Context) (bool, error)
+	SetMaintenanceMode(ctx context.Context, enabled bool) error
+}
+
+// ReadOnlyMode makes the server reject requests changing data, either during the maintenance
+// mode or when the database schema drifted
+type ReadOnlyMode struct {
+	store       MaintenanceStore
+	config      MaintenanceConfig
+	schemaDrift bool
+
+	mu          sync.Mutex
+	maintenance bool
+	refreshedAt time.Time
+}
+
+func NewReadOnlyMode(
+	store MaintenanceStore,
+	config MaintenanceConfig,
+	schemaDrift bool,
+) *ReadOnlyMode {
+	return &ReadOnlyMode{
+		store:       store,
+		config:      config,
+		schemaDrift: schemaDrift,
+		maintenance: config.Enabled,
+	}
+}
+
+// Maintenance reports whether the server is in the maintenance mode, the mode switched on
+// another API server is applied within the refresh interval
+func (m *ReadOnlyMode) Maintenance(ctx context.Context) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.refreshedAt) < m.config.RefreshInterval {
+		return m.maintenance
+	}
+
+	enabled, err := m.store.GetMaintenanceMode(ctx)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		m.maintenance = m.config.Enabled
+	case err != nil:
+		// e.g. while the database is migrated, the last known mode is kept
+		logger.FromContext(ctx).Warn("failed to read maintenance mode", zap.Error(err))
+	default:
+		m.maintenance = enabled
+	}
+	m.refreshedAt = time.Now()
+	return m.maintenance
+}
+
+// SetMaintenance switches the maintenance mode of every API server
+func (m *ReadOnlyMode) SetMaintenance(ctx context.Context, enabled bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.store.SetMaintenanceMode(ctx, enabled); err != nil {
+		return fmt.Errorf("SetMaintenanceMode: %w", err)
+	}
+	m.maintenance = enabled
+	m.refreshedAt = time.Now()
+	return nil
+}
+
+// NewReadOnlyMiddleware rejects the mutating requests during the maintenance mode, and the
+// mutating management requests when the database schema drifted, so the queries writing to
+// the drifted tables don't fail halfway. Update checks and GET requests are still served.
+func NewReadOnlyMiddleware(mode *ReadOnlyMode) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		method := ctx.Request.Method
+		path := ctx.Request.URL.Path
+		if method == http.MethodGet || method == http.MethodHead || path == maintenancePath {
+			ctx.Next()
+			return
+		}
+
+		if mode.Maintenance(ctx) {
+			retryAfter := int(mode.config.RetryAfter.Seconds())
+			ctx.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			ctx.AbortWithStatusJSON(
+				http.StatusServiceUnavailable,
+				api.GenericError{Error: "server is in maintenance mode"},
+			)
+			return
+		}
+
+		if mode.schemaDrift && strings.HasPrefix(path, project.AdminPathPrefix) {
+			ctx.AbortWithStatusJSON(
+				http.StatusServiceUnavailable,
+				api.GenericError{Error: "server is read-only until the database schema is updated"},
+			)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryMaintenanceStore is the database shared by the API servers
+type memoryMaintenanceStore struct {
+	mu      sync.Mutex
+	enabled *bool
+}
+
+func (s *memoryMaintenanceStore) GetMaintenanceMode(_ context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enabled == nil {
+		return false, pgx.ErrNoRows
+	}
+	return *s.enabled, nil
+}
+
+func (s *memoryMaintenanceStore) SetMaintenanceMode(_ context.Context, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enabled = &enabled
+	return nil
+}
+
+func serveReadOnly(mode *ReadOnlyMode, method, path string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(NewReadOnlyMiddleware(mode))
+	r.Any("/*path", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(method, path, nil))
+	return resp
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := serveReadOnly
+
+	updatesPath := "/api/v1/admin/019393ed-5085-71ec-943a-1c71617a6282/updates"
+	reportPath := "/api/v1/public/codepush/report_status"
+
+	schemaDrift := NewReadOnlyMode(&memoryMaintenanceStore{}, MaintenanceConfig{}, true)
+	assert.Equal(t, http.StatusOK, serve(schemaDrift, http.MethodGet, updatesPath).Code)
+	assert.Equal(
+		t,
+		http.StatusServiceUnavailable,
+		serve(schemaDrift, http.MethodPost, updatesPath).Code,
+	)
+	// device facing endpoints keep working
+	assert.Equal(t, http.StatusOK, serve(schemaDrift, http.MethodPost, reportPath).Code)
+
+	maintenance := NewReadOnlyMode(
+		&memoryMaintenanceStore{},
+		MaintenanceConfig{Enabled: true, RetryAfter: time.Minute},
+		false,
+	)
+	assert.Equal(t, http.StatusOK, serve(maintenance, http.MethodGet, updatesPath).Code)
+	resp := serve(maintenance, http.MethodPost, reportPath)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "60", resp.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, serve(maintenance, http.MethodPut, maintenancePath).Code)
+
+	require.NoError(t, maintenance.SetMaintenance(context.Background(), false))
+	assert.Equal(t, http.StatusOK, serve(maintenance, http.MethodPost, updatesPath).Code)
+}
+
+func TestMaintenanceModeSharedByServers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := &memoryMaintenanceStore{}
+	config := MaintenanceConfig{RetryAfter: time.Minute, RefreshInterval: time.Hour}
+	first := NewReadOnlyMode(store, config, false)
+	second := NewReadOnlyMode(store, config, false)
+	updatesPath := "/api/v1/admin/019393ed-5085-71ec-943a-1c71617a6282/updates"
+
+	assert.Equal(t, http.StatusOK, serveReadOnly(second, http.MethodPost, updatesPath).Code)
+	require.NoError(t, first.SetMaintenance(ctx, true))
+	assert.True(t, first.Maintenance(ctx))
+	// the second server reads the switched mode once its refresh interval passed
+	assert.False(t, second.Maintenance(ctx))
+	second.refreshedAt = time.Time{}
+	assert.Equal(
+		t,
+		http.StatusServiceUnavailable,
+		serveReadOnly(second, http.MethodPost, updatesPath).Code,
+	)
+
+	require.NoError(t, second.SetMaintenance(ctx, false))
+	first.refreshedAt = time.Time{}
+	assert.Equal(t, http.StatusOK, serveReadOnly(first, http.MethodPost, updatesPath).Code)
+
+	// the mode switched at runtime takes precedence over MAINTENANCE_MODE
+	started := NewReadOnlyMode(store, MaintenanceConfig{Enabled: true}, false)
+	assert.False(t, started.Maintenance(ctx))
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/update"
+)
+
+func processingReportResponse(report *db.UpdateProcessingReport) api.ProcessingReport {
+	resp := api.ProcessingReport{
+		ID:            report.ID,
+		Status:        api.ProcessingReportStatus(report.Status),
+		AssetCount:    int(report.AssetCount),
+		ArchiveCount:  int(report.ArchiveCount),
+		UnpackedFiles: int(report.UnpackedFiles),
+		HashedFiles:   int(report.HashedFiles),
+		BytesHashed:   report.BytesHashed,
+		Warnings:      report.Warnings,
+		StartedAt:     report.StartedAt.Time.UTC().Truncate(time.Second),
+		DurationMs:    report.DurationMs,
+	}
+	if report.Error.Valid {
+		resp.Error = &report.Error.String
+	}
+	return resp
+}
+
+func (srv *apiServer) GetProcessingReports(
+	ctx context.Context,
+	request api.GetProcessingReportsRequestObject,
+) (api.GetProcessingReportsResponseObject, error) {
+	reports, err := srv.updateSvc.ProcessingReports(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.ProcessingReports: %w", err)
+	}
+
+	response := make(api.GetProcessingReports200JSONResponse, 0, len(reports))
+	for _, report := range reports {
+		response = append(response, processingReportResponse(&report))
+	}
+	return response, nil
+}
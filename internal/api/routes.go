@@ -5,16 +5,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/hooks"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
 	"github.com/a-gierczak/paratrooper/internal/codepush"
 	"github.com/a-gierczak/paratrooper/internal/expo"
 	"github.com/a-gierczak/paratrooper/internal/infra"
 	"github.com/a-gierczak/paratrooper/internal/logger"
 	"github.com/a-gierczak/paratrooper/internal/project"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/signing"
+	"github.com/a-gierczak/paratrooper/internal/stats"
 	"github.com/a-gierczak/paratrooper/internal/storage"
 	"github.com/a-gierczak/paratrooper/internal/update"
 	"github.com/a-gierczak/paratrooper/internal/util"
@@ -33,6 +41,15 @@ type apiServer struct {
 	expoSvc     expo.Service
 	projectSvc  project.Service
 	infraSvc    infra.Service
+	queueConn   queue.Queue
+	storage     *storage.Storage
+	statsSvc    stats.Service
+	signingSvc  signing.Service
+	analytics   *analytics.Recorder
+	adoption    *stats.AdoptionRecorder
+	readOnly    *ReadOnlyMode
+	// hooks of the program embedding the server, nil without any
+	hooks *hooks.Registry
 }
 
 func NewServer(
@@ -41,6 +58,14 @@ func NewServer(
 	expoSvc expo.Service,
 	projectSvc project.Service,
 	infraSvc infra.Service,
+	queueConn queue.Queue,
+	st *storage.Storage,
+	statsSvc stats.Service,
+	signingSvc signing.Service,
+	analyticsRecorder *analytics.Recorder,
+	adoptionRecorder *stats.AdoptionRecorder,
+	readOnly *ReadOnlyMode,
+	manifestHooks *hooks.Registry,
 ) api.StrictServerInterface {
 	return &apiServer{
 		updateSvc,
@@ -48,6 +73,14 @@ func NewServer(
 		expoSvc,
 		projectSvc,
 		infraSvc,
+		queueConn,
+		st,
+		statsSvc,
+		signingSvc,
+		analyticsRecorder,
+		adoptionRecorder,
+		readOnly,
+		manifestHooks,
 	}
 }
 
@@ -68,6 +101,17 @@ func (srv *apiServer) projectByID(ctx context.Context, projectID uuid.UUID) (*db
 	return proj, nil
 }
 
+// rejectArchived rejects new updates of archived projects, their updates are still served
+func rejectArchived(proj *db.Project) error {
+	if proj.ArchivedAt.Valid {
+		return &HTTPError{
+			StatusCode: http.StatusConflict,
+			Message:    project.ErrProjectArchived.Error(),
+		}
+	}
+	return nil
+}
+
 func (srv *apiServer) PrepareUpdate(
 	ctx context.Context,
 	request api.PrepareUpdateRequestObject,
@@ -83,23 +127,99 @@ func (srv *apiServer) PrepareUpdate(
 	}
 	request.Body.RuntimeVersion = runtimeVersion.String()
 
+	if (len(request.Body.FileMetadata) > 0) == (request.Body.Archive != nil) {
+		return nil, NewValidationError(
+			"file_metadata",
+			"either file metadata or archive must be provided",
+		)
+	}
+
+	if request.Body.Archive != nil && !update.IsUploadArchivePath(request.Body.Archive.Path) {
+		return nil, NewValidationError("archive", update.ErrUnsupportedArchive.Error())
+	}
+
+	if request.Body.Archive != nil && request.Body.Archive.ContentEncoding != "" {
+		return nil, NewValidationError("archive", "archive can't have a content encoding")
+	}
+
+	for _, object := range request.Body.FileMetadata {
+		if storage.CleanPath(object.Path) == update.MetadataFileName &&
+			object.ContentLength > update.MaxMetadataSize {
+			return nil, NewValidationError("file_metadata", update.ErrMetadataTooLarge.Error())
+		}
+	}
+
+	if request.Body.ExpiresAt != nil && !request.Body.ExpiresAt.After(time.Now()) {
+		return nil, NewValidationError("expires_at", "expiry must be in the future")
+	}
+
+	if request.Body.CreatedAt != nil && request.Body.CreatedAt.After(time.Now()) {
+		return nil, NewValidationError("created_at", "creation time can't be in the future")
+	}
+
 	proj, err := srv.projectByID(ctx, request.ProjectID)
 	if err != nil {
 		return nil, err
 	}
+	if err := rejectArchived(proj); err != nil {
+		return nil, err
+	}
+
+	request.Body.Flavors, err = srv.validateTargetFlavors(ctx, proj.ID, request.Body.Flavors)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAdditionalChannels(*request.Body.Channel, request.Body.AdditionalChannels)
+	if err != nil {
+		return nil, err
+	}
 
-	updateID, uploadURLs, err := srv.updateSvc.PrepareUpdate(ctx, proj.ID, *request.Body)
+	prepared, err := srv.updateSvc.PrepareUpdate(ctx, proj.ID, *request.Body)
 	if err != nil {
-		if errors.Is(err, storage.ErrUpdateTooLarge) {
+		if errors.Is(err, storage.ErrUpdateTooLarge) || errors.Is(err, storage.ErrTooManyAssets) {
 			return nil, NewValidationError("file_metadata", err.Error())
 		}
+		if errors.Is(err, update.ErrCodePushLabelTaken) {
+			return nil, NewValidationError("code_push_label", err.Error())
+		}
+		if errors.Is(err, update.ErrUpdateIDTaken) {
+			return nil, NewValidationError("id", err.Error())
+		}
+		if errors.Is(err, update.ErrExternalIDTaken) {
+			return nil, &HTTPError{StatusCode: http.StatusConflict, Message: err.Error()}
+		}
+		var policyErr *update.PolicyViolationError
+		if errors.As(err, &policyErr) {
+			return nil, NewValidationError(policyErr.Field, policyErr.Message)
+		}
+		var quotaErr *update.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return api.PrepareUpdate403JSONResponse{
+				Error:            quotaErr.Error(),
+				Code:             api.ErrorCodeQuotaExceeded,
+				StorageBytesUsed: quotaErr.StorageBytesUsed,
+				MaxStorageBytes:  quotaErr.MaxStorageBytes,
+				UploadBytes:      quotaErr.UploadBytes,
+			}, nil
+		}
 		return nil, fmt.Errorf("updateSvc.PrepareUpdate: %w", err)
 	}
 
-	return api.PrepareUpdate201JSONResponse(api.PrepareUpdateResponse{
-		UpdateID:   updateID,
-		UploadURLs: uploadURLs,
-	}), nil
+	return api.PrepareUpdate201JSONResponse(*prepared), nil
+}
+
+// validateAdditionalChannels rejects channels the update would be published to twice
+func validateAdditionalChannels(channel string, additionalChannels []string) error {
+	for i, additional := range additionalChannels {
+		if additional == channel || slices.Contains(additionalChannels[:i], additional) {
+			return NewValidationError(
+				"additional_channels",
+				fmt.Sprintf("channel %s is listed more than once", additional),
+			)
+		}
+	}
+	return nil
 }
 
 func (srv *apiServer) CommitUpdate(
@@ -109,6 +229,9 @@ func (srv *apiServer) CommitUpdate(
 	if err != nil {
 		return nil, err
 	}
+	if err := rejectArchived(proj); err != nil {
+		return nil, err
+	}
 
 	u, err := srv.updateSvc.UpdateByID(ctx, proj.ID, request.UpdateID)
 	if err != nil {
@@ -122,14 +245,240 @@ func (srv *apiServer) CommitUpdate(
 		return nil, NewNotFoundError("update not found")
 	}
 
-	err = srv.updateSvc.CommitUpdate(ctx, request.UpdateID)
+	if u.LinkedUpdateID.Valid {
+		return nil, NewValidationError("update_id", update.ErrLinkedUpdateCommit.Error())
+	}
+	if u.Status != db.UpdateStatusEmpty {
+		return nil, newAlreadyCommittedError()
+	}
+
+	err = srv.updateSvc.CommitUpdate(ctx, proj.ID, request.UpdateID)
 	if err != nil {
+		if errors.Is(err, update.ErrAlreadyCommitted) {
+			return nil, newAlreadyCommittedError()
+		}
+		if errors.Is(err, storage.ErrTooManyAssets) {
+			return nil, NewValidationError("metadata", err.Error())
+		}
+		var metadataErr *update.InvalidMetadataError
+		if errors.As(err, &metadataErr) {
+			return nil, NewValidationError("metadata", metadataErr.Error())
+		}
+		var mismatchErr *update.FilesMismatchError
+		if errors.As(err, &mismatchErr) {
+			return api.CommitUpdate409JSONResponse{
+				Error:        mismatchErr.Error(),
+				MissingFiles: mismatchErr.Missing,
+				ExtraFiles:   mismatchErr.Extra,
+				CorruptFiles: mismatchErr.Corrupt,
+			}, nil
+		}
 		return nil, fmt.Errorf("updateSvc.CommitUpdate: %w", err)
 	}
 
 	return api.CommitUpdate204Response{}, nil
 }
 
+func (srv *apiServer) UploadUpdateAssets(
+	ctx context.Context,
+	request api.UploadUpdateAssetsRequestObject,
+) (api.UploadUpdateAssetsResponseObject, error) {
+	u, err := srv.uncommittedUpdate(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx)
+	uploadedFiles := make([]string, 0)
+	for {
+		part, err := request.Body.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, NewValidationError("body", "invalid multipart body")
+		}
+
+		filePath := storage.CleanPath(part.FormName())
+		err = srv.updateSvc.UploadFile(ctx, *u, filePath, part)
+		util.CloseWithLogger(log, part)
+		if err != nil {
+			if errors.Is(err, update.ErrFileNotDeclared) ||
+				errors.Is(err, update.ErrFileSizeMismatch) ||
+				errors.Is(err, update.ErrFileChecksumMismatch) {
+				return nil, NewValidationError(filePath, err.Error())
+			}
+			return nil, fmt.Errorf("updateSvc.UploadFile: %w", err)
+		}
+
+		log.Debug("uploaded update file", zap.String("path", filePath))
+		uploadedFiles = append(uploadedFiles, filePath)
+	}
+
+	return api.UploadUpdateAssets200JSONResponse{UploadedFiles: uploadedFiles}, nil
+}
+
+func (srv *apiServer) uncommittedUpdate(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+) (*db.Update, error) {
+	proj, err := srv.projectByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := srv.updateSvc.UpdateByID(ctx, proj.ID, updateID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.UpdateByID: %w", err)
+	}
+
+	if u.Status != db.UpdateStatusEmpty {
+		return nil, newAlreadyCommittedError()
+	}
+
+	return u, nil
+}
+
+func newAlreadyCommittedError() *HTTPError {
+	return &HTTPError{
+		StatusCode: http.StatusConflict,
+		Message:    update.ErrAlreadyCommitted.Error(),
+	}
+}
+
+func chunkUploadError(filePath string, err error) error {
+	switch {
+	case errors.Is(err, update.ErrChunkOutOfRange):
+		return NewValidationError("chunk_index", err.Error())
+	case errors.Is(err, update.ErrChunkChecksumMismatch):
+		return NewValidationError("content_md5", err.Error())
+	case errors.Is(err, update.ErrFileNotDeclared),
+		errors.Is(err, update.ErrChunkSizeMismatch),
+		errors.Is(err, update.ErrChunksMissing),
+		errors.Is(err, update.ErrFileChecksumMismatch):
+		return NewValidationError(filePath, err.Error())
+	}
+	return err
+}
+
+func (srv *apiServer) GetChunkedUploadStatus(
+	ctx context.Context,
+	request api.GetChunkedUploadStatusRequestObject,
+) (api.GetChunkedUploadStatusResponseObject, error) {
+	u, err := srv.uncommittedUpdate(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := storage.CleanPath(request.Params.Path)
+	status, err := srv.updateSvc.ChunkedUploadStatus(ctx, *u, filePath)
+	if err != nil {
+		return nil, chunkUploadError(filePath, err)
+	}
+
+	return api.GetChunkedUploadStatus200JSONResponse{
+		Path:           status.Path,
+		ChunkSize:      status.ChunkSize,
+		TotalChunks:    status.TotalChunks,
+		ReceivedChunks: status.ReceivedChunks,
+		MissingChunks:  status.MissingChunks,
+	}, nil
+}
+
+func (srv *apiServer) UploadChunk(
+	ctx context.Context,
+	request api.UploadChunkRequestObject,
+) (api.UploadChunkResponseObject, error) {
+	u, err := srv.uncommittedUpdate(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := storage.CleanPath(request.Params.Path)
+	err = srv.updateSvc.UploadChunk(
+		ctx,
+		*u,
+		filePath,
+		request.ChunkIndex,
+		request.Params.ContentMD5,
+		request.Body,
+	)
+	if err != nil {
+		return nil, chunkUploadError(filePath, err)
+	}
+
+	return api.UploadChunk204Response{}, nil
+}
+
+func (srv *apiServer) CompleteChunkedUpload(
+	ctx context.Context,
+	request api.CompleteChunkedUploadRequestObject,
+) (api.CompleteChunkedUploadResponseObject, error) {
+	u, err := srv.uncommittedUpdate(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := storage.CleanPath(request.Params.Path)
+	if err := srv.updateSvc.CompleteChunkedUpload(ctx, *u, filePath); err != nil {
+		return nil, chunkUploadError(filePath, err)
+	}
+
+	return api.CompleteChunkedUpload204Response{}, nil
+}
+
+func (srv *apiServer) GetUploadStatus(
+	ctx context.Context,
+	request api.GetUploadStatusRequestObject,
+) (api.GetUploadStatusResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := srv.updateSvc.UpdateByID(ctx, proj.ID, request.UpdateID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.UpdateByID: %w", err)
+	}
+
+	files, err := srv.updateSvc.UploadStatus(ctx, *u)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.UploadStatus: %w", err)
+	}
+
+	resp := api.GetUploadStatus200JSONResponse{
+		TotalFiles: len(files),
+		Files:      make([]api.FileUploadStatus, 0, len(files)),
+	}
+	for _, file := range files {
+		fileStatus := api.FileUploadStatus{
+			Path:          file.Path,
+			State:         api.FileUploadState(file.State),
+			ContentLength: file.ContentLength,
+			ReceivedBytes: file.ReceivedBytes,
+		}
+		if !file.LastModified.IsZero() {
+			lastModified := file.LastModified.UTC()
+			fileStatus.LastModified = &lastModified
+		}
+		if file.State == update.FileUploadStateUploaded {
+			resp.UploadedFiles++
+		}
+		resp.TotalBytes += file.ContentLength
+		resp.ReceivedBytes += file.ReceivedBytes
+		resp.Files = append(resp.Files, fileStatus)
+	}
+
+	return resp, nil
+}
+
 func (srv *apiServer) GetUpdate(
 	ctx context.Context,
 	request api.GetUpdateRequestObject,
@@ -146,14 +495,62 @@ func (srv *apiServer) GetUpdate(
 		return nil, err
 	}
 
-	return api.GetUpdate200JSONResponse{
-		ID:             u.ID,
-		Channel:        u.Channel,
-		CreatedAt:      u.CreatedAt.Time.UTC().Truncate(time.Second),
-		Message:        u.Message.String,
-		RuntimeVersion: u.RuntimeVersion,
-		Status:         api.UpdateStatus(u.Status),
-	}, nil
+	return api.GetUpdate200JSONResponse(updateResponse(u)), nil
+}
+
+func (srv *apiServer) GetUpdateByExternalID(
+	ctx context.Context,
+	request api.GetUpdateByExternalIDRequestObject,
+) (api.GetUpdateByExternalIDResponseObject, error) {
+	if request.ExternalID == "" || len(request.ExternalID) > 128 {
+		return nil, NewValidationError("external_id", "invalid external ID")
+	}
+
+	u, err := srv.updateSvc.UpdateByExternalID(ctx, request.ProjectID, request.ExternalID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, err
+	}
+
+	return api.GetUpdateByExternalID200JSONResponse(updateResponse(u)), nil
+}
+
+func updateResponse(u *db.Update) api.Update {
+	resp := api.Update{
+		ID:                u.ID,
+		Channel:           u.Channel,
+		CreatedAt:         u.CreatedAt.Time.UTC().Truncate(time.Second),
+		Message:           u.Message.String,
+		RuntimeVersion:    u.RuntimeVersion,
+		Status:            api.UpdateStatus(u.Status),
+		Flavors:           u.Flavors,
+		RolloutPercentage: int(u.RolloutPercentage),
+		IsMandatory:       u.IsMandatory,
+	}
+	if u.ContentHash.Valid {
+		resp.ContentHash = &u.ContentHash.String
+	}
+	if u.ColdStorageAt.Valid {
+		coldStorageAt := u.ColdStorageAt.Time.UTC().Truncate(time.Second)
+		resp.ColdStorageAt = &coldStorageAt
+	}
+	if u.ExpiresAt.Valid {
+		expiresAt := u.ExpiresAt.Time.UTC().Truncate(time.Second)
+		resp.ExpiresAt = &expiresAt
+	}
+	if u.LinkedUpdateID.Valid {
+		linkedUpdateID := uuid.UUID(u.LinkedUpdateID.Bytes)
+		resp.LinkedUpdateID = &linkedUpdateID
+	}
+	if u.CodepushLabel.Valid {
+		resp.CodePushLabel = &u.CodepushLabel.String
+	}
+	if u.ExternalID.Valid {
+		resp.ExternalID = &u.ExternalID.String
+	}
+	return resp
 }
 
 func (srv *apiServer) GetUpdates(
@@ -180,14 +577,7 @@ func (srv *apiServer) GetUpdates(
 	response := make(api.GetUpdatesResponse, 0)
 
 	for _, u := range updates {
-		response = append(response, api.Update{
-			ID:             u.ID,
-			RuntimeVersion: u.RuntimeVersion,
-			CreatedAt:      u.CreatedAt.Time.UTC().Truncate(time.Second),
-			Status:         api.UpdateStatus(u.Status),
-			Message:        u.Message.String,
-			Channel:        u.Channel,
-		})
+		response = append(response, updateResponse(&u))
 	}
 
 	return api.GetUpdates200JSONResponse(response), nil
@@ -201,24 +591,72 @@ func expoUpdateCacheKey(
 		currentUpdateIdStr = params.CurrentUpdateId.String()
 	}
 
+	// devices of different rollout buckets may get different updates
+	key := fmt.Sprintf(
+		"pt:update:%s:%s:%s:%s:%s:%s:%d",
+		params.ProjectID,
+		params.Channel,
+		params.Generation,
+		params.RuntimeVersion,
+		params.Platform,
+		currentUpdateIdStr,
+		params.RolloutBucket,
+	)
+	if params.Flavor != "" {
+		key += ":flavor:" + params.Flavor
+	}
+	// manifests point to the replica of the client's region
+	if params.Region != "" {
+		key += ":" + params.Region
+	}
+	if params.Metered {
+		key += ":metered"
+	}
+
+	return strings.ToLower(key)
+}
+
+// expoNoUpdateCacheKey is shared by all clients of the channel, runtime version and platform,
+// whatever their current update and flavor, until the channel changes
+func expoNoUpdateCacheKey(params *expoUpdateParams) string {
 	return strings.ToLower(
 		fmt.Sprintf(
-			"pt:update:%s:%s:%s:%s:%s",
+			"pt:update:%s:%s:%s:%s:%s:no-update",
 			params.ProjectID,
 			params.Channel,
+			params.Generation,
 			params.RuntimeVersion,
 			params.Platform,
-			currentUpdateIdStr,
 		),
 	)
 }
 
+// expoNoUpdateCacheTTL is short, so the first update published to the channel is picked up soon
+// when the API servers miss the invalidation
+const expoNoUpdateCacheTTL = 30
+
+// expoUpdateCachedResponse returns the cached response, nil when it's not cached, and sets
+// the generation of the params the response is cached with
 func (srv *apiServer) expoUpdateCachedResponse(
 	ctx context.Context,
 	params *expoUpdateParams,
 ) (*expoUpdateMultipartResponse, error) {
-	cacheKey := expoUpdateCacheKey(params)
 	cache := srv.infraSvc.Cache()
+	generation, err := channelGeneration(ctx, cache, params.ProjectID, params.Channel)
+	if err != nil {
+		return nil, err
+	}
+	params.Generation = generation
+
+	noUpdate, err := cache.Get(ctx, expoNoUpdateCacheKey(params))
+	if err != nil {
+		return nil, fmt.Errorf("cache.Get: %w", err)
+	}
+	if noUpdate != "" {
+		return newExpoNoUpdateResponse(), nil
+	}
+
+	cacheKey := expoUpdateCacheKey(params)
 	cachedResponseStr, err := cache.Get(ctx, cacheKey)
 	if err != nil {
 		return nil, fmt.Errorf("cache.Get: %w", err)
@@ -240,22 +678,53 @@ func (srv *apiServer) expoUpdateSetCachedResponse(
 	params *expoUpdateParams,
 	response expoUpdateMultipartResponse,
 ) error {
+	// the response could outlive the invalidation of an unknown generation
+	if params.Generation == "" {
+		return nil
+	}
+
 	cacheKey := expoUpdateCacheKey(params)
 	responseJson, err := json.Marshal(response)
 	if err != nil {
 		return fmt.Errorf("json.Marshal: %w", err)
 	}
 
+	// manifests with signed asset URLs are cached only until the URLs are signed again
+	ttl := 24 * 60 * 60
+	if response.PartName == "manifest" {
+		ttl = downloadURLCacheTTL()
+	}
+
 	cache := srv.infraSvc.Cache()
-	return cache.Set(ctx, cacheKey, string(responseJson), 24*60*60)
+	return cache.Set(ctx, cacheKey, string(responseJson), ttl)
 }
 
 type expoUpdateParams struct {
 	RuntimeVersion  string     `binding:"required"`
 	Platform        string     `binding:"required"`
 	CurrentUpdateId *uuid.UUID `binding:"omitempty"`
-	Channel         string
+	Channel         string     `binding:"printascii,max=100"`
 	ProjectID       uuid.UUID
+	// Flavor of the app, empty for apps without flavors
+	Flavor string
+	// RolloutBucket of the device, by its EAS client ID
+	RolloutBucket int
+	// Region of the storage replica serving the client, empty for the primary bucket
+	Region string
+	// Metered is set for devices on metered connections
+	Metered bool
+	// Generation of the cached responses of the channel, empty until it's looked up
+	Generation string
+}
+
+func (params *expoUpdateParams) hooksRequest() hooks.Request {
+	return hooks.Request{
+		ProjectID:      params.ProjectID,
+		Channel:        params.Channel,
+		RuntimeVersion: params.RuntimeVersion,
+		Platform:       params.Platform,
+		Flavor:         params.Flavor,
+	}
 }
 
 func expoUpdateParseParams(
@@ -282,6 +751,14 @@ func expoUpdateParseParams(
 		params.CurrentUpdateId = request.Params.ExpoCurrentUpdateId
 	}
 
+	// the query parameter is a fallback for clients that can't set the header
+	params.Channel = update.DefaultChannelName
+	if request.Params.ExpoChannelName != nil && *request.Params.ExpoChannelName != "" {
+		params.Channel = *request.Params.ExpoChannelName
+	} else if request.Params.ChannelName != nil && *request.Params.ChannelName != "" {
+		params.Channel = *request.Params.ChannelName
+	}
+
 	if err := binding.Validator.ValidateStruct(&params); err != nil {
 		return nil, err
 	}
@@ -295,8 +772,15 @@ func expoUpdateParseParams(
 		params.RuntimeVersion = runtimeVersion.String()
 	}
 
-	params.Channel = update.DefaultChannelName
 	params.ProjectID = request.ProjectID
+	if request.Params.Flavor != nil {
+		params.Flavor = *request.Params.Flavor
+	}
+	params.RolloutBucket = update.NoRolloutBucket
+	if request.Params.EASClientID != nil {
+		params.RolloutBucket = update.RolloutBucket(*request.Params.EASClientID)
+	}
+	params.Metered = isMeteredNetwork(request.Params.Network)
 
 	return &params, nil
 }
@@ -310,6 +794,88 @@ func (srv *apiServer) GetExpoUpdate(
 		return nil, err
 	}
 
+	resp, err := srv.expoUpdate(ctx, request, params)
+	if multipartResp, ok := resp.(*expoUpdateMultipartResponse); ok {
+		event := queue.UpdateCheckEventPayload{
+			ProjectID:       params.ProjectID,
+			Channel:         params.Channel,
+			RuntimeVersion:  params.RuntimeVersion,
+			Platform:        params.Platform,
+			Decision:        multipartResp.decision(),
+			UpdateID:        multipartResp.UpdateID,
+			Reason:          multipartResp.reason(),
+			CurrentUpdateID: params.CurrentUpdateId,
+		}
+		if request.Params.EASClientID != nil {
+			event.ClientID = *request.Params.EASClientID
+		}
+		srv.analytics.Record(ctx, event)
+		check := stats.UpdateCheck{
+			ProjectID:       params.ProjectID,
+			Platform:        params.Platform,
+			CurrentUpdateID: params.CurrentUpdateId,
+		}
+		if multipartResp.decision() == analytics.DecisionRollBackToEmbedded {
+			check.RollBackToEmbedded = true
+		} else {
+			check.ServedUpdateID = multipartResp.UpdateID
+		}
+		srv.adoption.Record(check)
+
+		// the client already has the manifest
+		if etagMatches(request.Params.IfNoneMatch, multipartResp.ETag) {
+			return api.GetExpoUpdate304Response{
+				Headers: api.GetExpoUpdate304ResponseHeaders{ETag: multipartResp.ETag},
+			}, nil
+		}
+
+		if request.Params.ExpoExpectSignature != nil {
+			signer, err := srv.expoSigner(ctx, params.ProjectID, *request.Params.ExpoExpectSignature)
+			if err != nil {
+				return nil, err
+			}
+			multipartResp.signer = signer
+		}
+	}
+	return resp, err
+}
+
+// expoSigner returns the signer with the project's key requested by the expo-expect-signature
+// header, the signer isn't cached, so keys are rotated immediately
+func (srv *apiServer) expoSigner(
+	ctx context.Context,
+	projectID uuid.UUID,
+	expectSignature string,
+) (*expo.Signer, error) {
+	signatureRequest, err := expo.ParseExpectSignature(expectSignature)
+	if err != nil {
+		return nil, NewValidationError("expo-expect-signature", err.Error())
+	}
+
+	key, err := srv.signingSvc.KeyForSigning(ctx, projectID, signatureRequest.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("signingSvc.KeyForSigning: %w", err)
+	}
+	if key == nil {
+		message := "project has no active signing key"
+		if signatureRequest.KeyID != "" {
+			message = fmt.Sprintf("project has no signing key %s", signatureRequest.KeyID)
+		}
+		return nil, NewValidationError("expo-expect-signature", message)
+	}
+
+	signer, err := expo.NewSigner(key)
+	if err != nil {
+		return nil, fmt.Errorf("expo.NewSigner: %w", err)
+	}
+	return signer, nil
+}
+
+func (srv *apiServer) expoUpdate(
+	ctx context.Context,
+	request api.GetExpoUpdateRequestObject,
+	params *expoUpdateParams,
+) (api.GetExpoUpdateResponseObject, error) {
 	log := logger.FromContext(ctx)
 
 	log.Debug(
@@ -319,8 +885,11 @@ func (srv *apiServer) GetExpoUpdate(
 		zap.String("platform", params.Platform),
 		zap.Stringer("currentUpdateId", params.CurrentUpdateId),
 		zap.String("channel", params.Channel),
+		zap.String("flavor", params.Flavor),
 	)
 
+	params.Region = srv.storage.RequestRegion(ctx)
+
 	cachedResponse, err := srv.expoUpdateCachedResponse(ctx, params)
 	if err != nil {
 		log.Error("failed to get cached response", zap.Error(err))
@@ -346,12 +915,43 @@ func (srv *apiServer) GetExpoUpdate(
 		), nil
 	}
 
+	if err := srv.checkClientFlavor(ctx, proj.ID, params.Flavor); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return api.GetExpoUpdate400JSONResponse(
+				NewValidationErrorResponse(validationErr.Field, validationErr.Message),
+			), nil
+		}
+		return nil, err
+	}
+
+	rollback, err := srv.updateSvc.ActiveChannelRollback(
+		ctx,
+		proj.ID,
+		params.Channel,
+		params.RuntimeVersion,
+		params.Flavor,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.ActiveChannelRollback: %w", err)
+	}
+	if rollback != nil {
+		resp := newExpoRollBackToEmbeddedResponse(rollback.CommittedAt.Time)
+		resp.ChannelRollback = true
+		if err := srv.expoUpdateSetCachedResponse(ctx, params, *resp); err != nil {
+			log.Error("failed to cache response", zap.Error(err))
+		}
+		return resp, nil
+	}
+
 	result, err := srv.updateSvc.UpdateToInstall(
 		ctx,
 		request.ProjectID,
 		params.RuntimeVersion,
 		params.Channel,
 		params.Platform,
+		params.Flavor,
+		params.RolloutBucket,
 		update.CurrentUpdateFilter{
 			ID: params.CurrentUpdateId,
 		},
@@ -361,12 +961,36 @@ func (srv *apiServer) GetExpoUpdate(
 	}
 
 	if result != nil && result.Update.Status == db.UpdateStatusPublished {
-		manifest, err := srv.expoSvc.UpdateManifest(ctx, result.Update, params.Platform)
+		manifest, extensions, err := srv.expoSvc.UpdateManifest(
+			ctx,
+			*proj,
+			result.Update,
+			params.Platform,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("expoSvc.UpdateManifest: %w", err)
 		}
+		if params.Metered &&
+			deferredOnMeteredNetwork(proj, result.Update.IsMandatory, extensions.DownloadSize) {
+			log.Debug("update deferred on metered connection")
+			resp := newExpoNoUpdateResponse()
+			if err := srv.expoUpdateSetCachedResponse(ctx, params, *resp); err != nil {
+				log.Error("failed to cache response", zap.Error(err))
+			}
+			return resp, nil
+		}
+		err = srv.hooks.RewriteExpoManifest(ctx, params.hooksRequest(), manifest, extensions)
+		if err != nil {
+			return nil, fmt.Errorf("hooks.RewriteExpoManifest: %w", err)
+		}
 
-		resp := expoUpdateMultipartResponse{"manifest", manifest}
+		resp := expoUpdateMultipartResponse{
+			PartName:   "manifest",
+			Payload:    manifest,
+			UpdateID:   &result.Update.ID,
+			ETag:       expoManifestETag(result.Update.ContentHash, params.Platform),
+			Extensions: extensions,
+		}
 		if err := srv.expoUpdateSetCachedResponse(ctx, params, resp); err != nil {
 			log.Error("failed to cache response", zap.Error(err))
 		}
@@ -375,29 +999,55 @@ func (srv *apiServer) GetExpoUpdate(
 	}
 
 	if result != nil && result.Update.Status == db.UpdateStatusCanceled {
-		resp := expoUpdateMultipartResponse{
-			"directive",
-			gin.H{
-				"type": "rollBackToEmbedded",
-				"parameters": gin.H{
-					"commitTime": time.Now().UTC().Format("2006-01-02T15:04:05.0Z07"),
-				},
-			},
-		}
-		if err := srv.expoUpdateSetCachedResponse(ctx, params, resp); err != nil {
+		resp := newExpoRollBackToEmbeddedResponse(time.Now())
+		resp.UpdateID = &result.Update.ID
+		if err := srv.expoUpdateSetCachedResponse(ctx, params, *resp); err != nil {
 			log.Error("failed to cache response", zap.Error(err))
 		}
-		return &resp, nil
+		return resp, nil
 	}
 
-	resp := expoUpdateMultipartResponse{
-		"directive",
-		gin.H{"type": "noUpdateAvailable"},
-	}
-	if err := srv.expoUpdateSetCachedResponse(ctx, params, resp); err != nil {
+	resp := newExpoNoUpdateResponse()
+	hasUpdates, err := srv.updateSvc.HasUpdates(
+		ctx,
+		request.ProjectID,
+		params.RuntimeVersion,
+		params.Channel,
+	)
+	if err != nil {
+		log.Error("failed to check for updates", zap.Error(err))
+	} else if !hasUpdates && params.Generation != "" {
+		cacheKey := expoNoUpdateCacheKey(params)
+		err := srv.infraSvc.Cache().Set(ctx, cacheKey, "1", expoNoUpdateCacheTTL)
+		if err != nil {
+			log.Error("failed to cache response", zap.Error(err))
+		}
+		return resp, nil
+	}
+
+	if err := srv.expoUpdateSetCachedResponse(ctx, params, *resp); err != nil {
 		log.Error("failed to cache response", zap.Error(err))
 	}
-	return &resp, nil
+	return resp, nil
+}
+
+func newExpoNoUpdateResponse() *expoUpdateMultipartResponse {
+	return &expoUpdateMultipartResponse{
+		PartName: "directive",
+		Payload:  gin.H{"type": "noUpdateAvailable"},
+	}
+}
+
+func newExpoRollBackToEmbeddedResponse(commitTime time.Time) *expoUpdateMultipartResponse {
+	return &expoUpdateMultipartResponse{
+		PartName: "directive",
+		Payload: gin.H{
+			"type": "rollBackToEmbedded",
+			"parameters": gin.H{
+				"commitTime": commitTime.UTC().Format("2006-01-02T15:04:05.0Z07"),
+			},
+		},
+	}
 }
 
 func (srv *apiServer) RollbackUpdate(
@@ -432,12 +1082,145 @@ func (srv *apiServer) RollbackUpdate(
 	return api.RollbackUpdate204Response{}, nil
 }
 
+func (srv *apiServer) DeleteUpdate(
+	ctx context.Context,
+	request api.DeleteUpdateRequestObject,
+) (api.DeleteUpdateResponseObject, error) {
+	err := srv.updateSvc.DeleteUpdate(ctx, request.ProjectID, request.UpdateID)
+	switch {
+	case errors.Is(err, update.ErrUpdateNotFound):
+		return nil, NewNotFoundError("update not found")
+	case errors.Is(err, update.ErrUpdateBeingProcessed),
+		errors.Is(err, update.ErrUpdatePinned),
+		errors.Is(err, update.ErrUpdateHasLinked):
+		return nil, &HTTPError{StatusCode: http.StatusConflict, Message: err.Error()}
+	case err != nil:
+		return nil, fmt.Errorf("updateSvc.DeleteUpdate: %w", err)
+	}
+
+	return api.DeleteUpdate204Response{}, nil
+}
+
+func (srv *apiServer) PatchUpdate(
+	ctx context.Context,
+	request api.PatchUpdateRequestObject,
+) (api.PatchUpdateResponseObject, error) {
+	if request.Body.IsMandatory == nil {
+		return nil, NewValidationError("is_mandatory", "nothing to change")
+	}
+
+	u, err := srv.updateSvc.SetUpdateMandatory(
+		ctx,
+		request.ProjectID,
+		request.UpdateID,
+		*request.Body.IsMandatory,
+	)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.SetUpdateMandatory: %w", err)
+	}
+
+	return api.PatchUpdate200JSONResponse(updateResponse(u)), nil
+}
+
+func (srv *apiServer) SetUpdateRollout(
+	ctx context.Context,
+	request api.SetUpdateRolloutRequestObject,
+) (api.SetUpdateRolloutResponseObject, error) {
+	u, err := srv.updateSvc.SetRolloutPercentage(
+		ctx,
+		request.ProjectID,
+		request.UpdateID,
+		request.Body.Percentage,
+	)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.SetRolloutPercentage: %w", err)
+	}
+
+	return api.SetUpdateRollout200JSONResponse(updateResponse(u)), nil
+}
+
+func (srv *apiServer) SetUpdateExpiry(
+	ctx context.Context,
+	request api.SetUpdateExpiryRequestObject,
+) (api.SetUpdateExpiryResponseObject, error) {
+	u, err := srv.updateSvc.SetUpdateExpiry(
+		ctx,
+		request.ProjectID,
+		request.UpdateID,
+		request.Body.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.SetUpdateExpiry: %w", err)
+	}
+
+	return api.SetUpdateExpiry200JSONResponse(updateResponse(u)), nil
+}
+
+func updateDiffFilesResponse(files []update.FileDiff) []api.UpdateDiffFile {
+	response := make([]api.UpdateDiffFile, 0, len(files))
+	for _, file := range files {
+		diffFile := api.UpdateDiffFile{
+			Platform:  file.Platform,
+			Path:      file.Path,
+			SizeDelta: file.SizeDelta(),
+		}
+		if file.From != nil {
+			diffFile.PreviousContentSha256 = &file.From.ContentSha256
+			diffFile.PreviousContentLength = &file.From.ContentLength
+		}
+		if file.To != nil {
+			diffFile.ContentSha256 = &file.To.ContentSha256
+			diffFile.ContentLength = &file.To.ContentLength
+		}
+		response = append(response, diffFile)
+	}
+	return response
+}
+
+func (srv *apiServer) DiffUpdates(
+	ctx context.Context,
+	request api.DiffUpdatesRequestObject,
+) (api.DiffUpdatesResponseObject, error) {
+	diff, err := srv.updateSvc.DiffUpdates(
+		ctx,
+		request.ProjectID,
+		request.UpdateID,
+		request.OtherUpdateID,
+	)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return api.DiffUpdates404JSONResponse{Error: err.Error()}, nil
+		}
+		return nil, fmt.Errorf("updateSvc.DiffUpdates: %w", err)
+	}
+
+	return api.DiffUpdates200JSONResponse{
+		UpdateID:      request.UpdateID,
+		OtherUpdateID: request.OtherUpdateID,
+		Added:         updateDiffFilesResponse(diff.Added),
+		Removed:       updateDiffFilesResponse(diff.Removed),
+		Changed:       updateDiffFilesResponse(diff.Changed),
+		SizeDelta:     diff.SizeDelta(),
+	}, nil
+}
+
 func (srv *apiServer) GetCodePushUpdate(
 	ctx context.Context,
 	request api.GetCodePushUpdateRequestObject,
 ) (api.GetCodePushUpdateResponseObject, error) {
 	log := logger.FromContext(ctx)
-	projectID, platform, channel, err := codepush.ParseDeploymentKey(request.Params.DeploymentKey)
+	projectID, flavor, platform, channel, err := codepush.ParseDeploymentKey(
+		request.Params.DeploymentKey,
+	)
 	if err != nil {
 		return api.GetCodePushUpdate400JSONResponse(
 			NewValidationErrorResponse("deployment_key", "invalid deployment key"),
@@ -455,39 +1238,112 @@ func (srv *apiServer) GetCodePushUpdate(
 		"GetCodePushUpdate",
 		zap.String("projectID", projectID.String()),
 		zap.String("channel", channel),
+		zap.String("flavor", flavor),
 		zap.String("platform", platform),
 		zap.String("appVersion", appVersion.String()),
 		zap.Stringp("packageHash", request.Params.PackageHash),
 	)
 
-	proj, err := srv.projectSvc.ProjectByID(ctx, projectID)
+	params := &codePushUpdateParams{
+		ProjectID:   projectID,
+		Channel:     channel,
+		Flavor:      flavor,
+		Platform:    platform,
+		AppVersion:  appVersion.String(),
+		PackageHash: request.Params.PackageHash,
+		Region:      srv.storage.RequestRegion(ctx),
+		Metered:     isMeteredNetwork(request.Params.Network),
+	}
+	params.RolloutBucket = update.NoRolloutBucket
+	if request.Params.ClientUniqueID != nil {
+		params.RolloutBucket = update.RolloutBucket(*request.Params.ClientUniqueID)
+	}
+	updateInfo, err := srv.codePushUpdate(ctx, params)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return api.GetCodePushUpdate400JSONResponse(
+				NewValidationErrorResponse(validationErr.Field, validationErr.Message),
+			), nil
+		}
+		return nil, err
+	}
+
+	event := queue.UpdateCheckEventPayload{
+		ProjectID:      projectID,
+		Channel:        channel,
+		RuntimeVersion: appVersion.String(),
+		Platform:       platform,
+		Decision:       analytics.DecisionNoUpdate,
+	}
+	if request.Params.ClientUniqueID != nil {
+		event.ClientID = *request.Params.ClientUniqueID
+	}
+	if updateInfo.IsAvailable {
+		event.Decision = analytics.DecisionUpdate
+		// updates published with a CodePush label are told apart by the label within the channel
+		if updateID, err := uuid.Parse(updateInfo.Label); err == nil {
+			event.UpdateID = &updateID
+		} else {
+			event.CodePushLabel = updateInfo.Label
+		}
+	}
+	srv.analytics.Record(ctx, event)
+	srv.adoption.Record(stats.UpdateCheck{
+		ProjectID:      projectID,
+		Platform:       platform,
+		ServedUpdateID: event.UpdateID,
+	})
+
+	return api.GetCodePushUpdate200JSONResponse{UpdateInfo: *updateInfo}, nil
+}
+
+// codePushUpdate returns the cached update info when possible, a ValidationError when
+// the project doesn't exist or doesn't use CodePush
+func (srv *apiServer) codePushUpdate(
+	ctx context.Context,
+	params *codePushUpdateParams,
+) (*api.CodePushUpdate, error) {
+	log := logger.FromContext(ctx)
+
+	cachedResponse, cacheKey, err := srv.codePushCachedResponse(ctx, params)
+	if err != nil {
+		log.Error("failed to get cached response", zap.Error(err))
+	} else if cachedResponse != nil {
+		log.Debug("found cached response")
+		return cachedResponse, nil
+	}
+
+	proj, err := srv.projectSvc.ProjectByID(ctx, params.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("projectSvc.ProjectByID: %w", err)
 	}
 
 	if proj == nil {
-		return api.GetCodePushUpdate400JSONResponse(
-			NewValidationErrorResponse("project_id", "project not found"),
-		), nil
+		return nil, NewValidationError("project_id", "project not found")
 	}
 
 	if proj.UpdateProtocol != db.UpdateProtocolCodepush {
-		return api.GetCodePushUpdate400JSONResponse(
-			NewValidationErrorResponse(
-				"project_id",
-				"project does not use CodePush update protocol",
-			),
-		), nil
+		return nil, NewValidationError(
+			"project_id",
+			"project does not use CodePush update protocol",
+		)
+	}
+
+	if err := srv.checkClientFlavor(ctx, proj.ID, params.Flavor); err != nil {
+		return nil, err
 	}
 
 	updateToInstall, err := srv.updateSvc.UpdateToInstall(
 		ctx,
-		projectID,
-		appVersion.String(),
-		channel,
-		platform,
+		params.ProjectID,
+		params.AppVersion,
+		params.Channel,
+		params.Platform,
+		params.Flavor,
+		params.RolloutBucket,
 		update.CurrentUpdateFilter{
-			SHA256: request.Params.PackageHash,
+			SHA256: params.PackageHash,
 		},
 	)
 
@@ -495,51 +1351,116 @@ func (srv *apiServer) GetCodePushUpdate(
 		return nil, fmt.Errorf("updateSvc.UpdateToInstall: %w", err)
 	}
 
-	if updateToInstall == nil {
-		return api.GetCodePushUpdate200JSONResponse{
-			UpdateInfo: api.CodePushUpdate{
-				DownloadURL:            "",
-				Description:            util.StringPtr(""),
-				IsAvailable:            false,
-				IsMandatory:            false,
-				AppVersion:             "",
-				PackageHash:            "",
-				Label:                  "",
-				PackageSize:            0,
-				UpdateAppVersion:       false,
-				ShouldRunBinaryVersion: true,
-			},
-		}, nil
+	updateInfo := srv.codePushSvc.NoUpdate(*proj)
+	if updateToInstall != nil {
+		updateInfo, err = srv.codePushSvc.UpdateToInstall(
+			ctx,
+			*proj,
+			updateToInstall.Update,
+			params.Platform,
+			params.PackageHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("codePushSvc.UpdateToInstall: %w", err)
+		}
+		size := int64(updateInfo.PackageSize)
+		if params.Metered && deferredOnMeteredNetwork(proj, updateInfo.IsMandatory, size) {
+			log.Debug("update deferred on metered connection")
+			updateInfo = srv.codePushSvc.NoUpdate(*proj)
+		}
 	}
+	if err := srv.hooks.RewriteCodePushUpdate(ctx, params.hooksRequest(), updateInfo); err != nil {
+		return nil, fmt.Errorf("hooks.RewriteCodePushUpdate: %w", err)
+	}
+
+	if cacheKey != "" {
+		if err := srv.codePushSetCachedResponse(ctx, cacheKey, updateInfo); err != nil {
+			log.Error("failed to cache response", zap.Error(err))
+		}
+	}
+	return updateInfo, nil
+}
 
-	updateInfo, err := srv.codePushSvc.UpdateToInstall(ctx, updateToInstall.Update, platform)
+// GetCodePushLegacyUpdate serves apps with the path of the standalone CodePush server baked in
+func (srv *apiServer) GetCodePushLegacyUpdate(
+	ctx context.Context,
+	request api.GetCodePushLegacyUpdateRequestObject,
+) (api.GetCodePushLegacyUpdateResponseObject, error) {
+	resp, err := srv.GetCodePushUpdate(ctx, api.GetCodePushUpdateRequestObject{
+		Params: api.GetCodePushUpdateParams{
+			AppVersion:     request.Params.AppVersion,
+			DeploymentKey:  request.Params.DeploymentKey,
+			PackageHash:    request.Params.PackageHash,
+			IsCompanion:    request.Params.IsCompanion,
+			ClientUniqueID: request.Params.ClientUniqueID,
+			Network:        request.Params.Network,
+		},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("codePushSvc.UpdateToInstall: %w", err)
+		return nil, err
 	}
 
-	return api.GetCodePushUpdate200JSONResponse{
-		UpdateInfo: *updateInfo,
-	}, nil
+	switch resp := resp.(type) {
+	case api.GetCodePushUpdate200JSONResponse:
+		info := resp.UpdateInfo
+		return api.GetCodePushLegacyUpdate200JSONResponse{
+			UpdateInfo: api.CodePushLegacyUpdate{
+				AppVersion:             info.AppVersion,
+				Description:            info.Description,
+				DownloadURL:            info.DownloadURL,
+				IsAvailable:            info.IsAvailable,
+				IsMandatory:            info.IsMandatory,
+				Label:                  info.Label,
+				PackageHash:            info.PackageHash,
+				PackageSize:            info.PackageSize,
+				UpdateAppVersion:       info.UpdateAppVersion,
+				ShouldRunBinaryVersion: info.ShouldRunBinaryVersion,
+				TargetBinaryRange:      info.TargetBinaryRange,
+			},
+		}, nil
+	case api.GetCodePushUpdate400JSONResponse:
+		return api.GetCodePushLegacyUpdate400JSONResponse(resp), nil
+	default:
+		return nil, fmt.Errorf("unexpected CodePush update response %T", resp)
+	}
 }
 
 func (srv *apiServer) CreateProject(
 	ctx context.Context,
 	request api.CreateProjectRequestObject,
 ) (api.CreateProjectResponseObject, error) {
+	var environment string
+	if request.Body.Environment != nil {
+		environment = *request.Body.Environment
+	}
+
+	var storageDriverURL string
+	if request.Body.StorageDriverUrl != nil && *request.Body.StorageDriverUrl != "" {
+		storageDriverURL = *request.Body.StorageDriverUrl
+		if err := srv.storage.ValidateProjectDriverURL(ctx, storageDriverURL); err != nil {
+			return api.CreateProject400JSONResponse(
+				NewValidationErrorResponse("storage_driver_url", err.Error()),
+			), nil
+		}
+	}
+
 	proj, err := srv.projectSvc.CreateProject(
 		ctx,
 		request.Body.Name,
 		request.Body.UpdateProtocol,
+		environment,
+		storageDriverURL,
 	)
 	if err != nil {
+		if errors.Is(err, project.ErrProjectExists) {
+			return api.CreateProject400JSONResponse(
+				NewValidationErrorResponse("name", err.Error()),
+			), nil
+		}
 		return nil, fmt.Errorf("projectSvc.CreateProject: %w", err)
 	}
 
-	return api.CreateProject200JSONResponse{
-		ID:             proj.ID,
-		Name:           proj.Name,
-		UpdateProtocol: api.UpdateProtocol(proj.UpdateProtocol),
-	}, nil
+	return api.CreateProject200JSONResponse(projectResponse(proj)), nil
 }
 
 func (srv *apiServer) GetProjectByID(
@@ -551,11 +1472,257 @@ func (srv *apiServer) GetProjectByID(
 		return nil, err
 	}
 
-	return api.GetProjectByID200JSONResponse{
-		ID:             proj.ID,
-		Name:           proj.Name,
-		UpdateProtocol: api.UpdateProtocol(proj.UpdateProtocol),
-	}, nil
+	return api.GetProjectByID200JSONResponse(projectResponse(proj)), nil
+}
+
+func (srv *apiServer) UpdateProject(
+	ctx context.Context,
+	request api.UpdateProjectRequestObject,
+) (api.UpdateProjectResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template := request.Body.AssetUrlTemplate; template != nil && *template != "" {
+		if err := storage.ValidateAssetURLTemplate(*template); err != nil {
+			return nil, NewValidationError("asset_url_template", err.Error())
+		}
+	}
+
+	if regions := request.Body.ReplicaRegions; regions != nil {
+		if err := srv.storage.ValidateReplicaRegions(*regions); err != nil {
+			return nil, NewValidationError("replica_regions", err.Error())
+		}
+	}
+
+	if priorities := request.Body.AssetPriorities; priorities != nil {
+		for _, priority := range *priorities {
+			if err := expo.ValidateAssetPriority(priority); err != nil {
+				return nil, NewValidationError("asset_priorities", err.Error())
+			}
+		}
+	}
+
+	// cached responses of the project are invalidated when the URLs of its assets change
+	invalidateCache := false
+
+	if request.Body.PublicAssetsUrl != nil {
+		proj, err = srv.projectSvc.SetPublicAssetsURL(
+			ctx,
+			proj.ID,
+			*request.Body.PublicAssetsUrl,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetPublicAssetsURL: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+		invalidateCache = true
+	}
+
+	if request.Body.AssetUrlTemplate != nil {
+		proj, err = srv.projectSvc.SetAssetURLTemplate(
+			ctx,
+			request.ProjectID,
+			*request.Body.AssetUrlTemplate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetAssetURLTemplate: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+		invalidateCache = true
+	}
+
+	if request.Body.ReplicaRegions != nil {
+		proj, err = srv.projectSvc.SetReplicaRegions(
+			ctx,
+			request.ProjectID,
+			*request.Body.ReplicaRegions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetReplicaRegions: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if stable := request.Body.StableAssetUrls; stable != nil {
+		if *stable && !srv.storage.StableAssetURLs() {
+			return nil, NewValidationError(
+				"stable_asset_urls",
+				"stable asset URLs require API_PUBLIC_URL",
+			)
+		}
+		proj, err = srv.projectSvc.SetStableAssetURLs(ctx, request.ProjectID, *stable)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetStableAssetURLs: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+		invalidateCache = true
+	}
+
+	if priorities := request.Body.AssetPriorities; priorities != nil {
+		proj, err = srv.projectSvc.SetAssetPriorities(ctx, request.ProjectID, *priorities)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetAssetPriorities: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if limit := request.Body.MeteredDownloadLimit; limit != nil {
+		proj, err = srv.projectSvc.SetMeteredDownloadLimit(ctx, request.ProjectID, *limit)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetMeteredDownloadLimit: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if quota := request.Body.MaxStorageBytes; quota != nil {
+		proj, err = srv.projectSvc.SetMaxStorageBytes(ctx, request.ProjectID, *quota)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetMaxStorageBytes: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if request.Body.MaxAssetCount != nil {
+		proj, err = srv.projectSvc.SetMaxAssetCount(
+			ctx,
+			request.ProjectID,
+			*request.Body.MaxAssetCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetMaxAssetCount: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	suggestBinaryUpdate := request.Body.CodePushSuggestBinaryUpdate
+	descriptionSource := request.Body.CodePushDescriptionSource
+	if suggestBinaryUpdate != nil || descriptionSource != nil {
+		if suggestBinaryUpdate == nil {
+			suggestBinaryUpdate = &proj.CodepushSuggestBinaryUpdate
+		}
+		if descriptionSource == nil {
+			current := api.CodePushDescriptionSource(proj.CodepushDescriptionSource)
+			descriptionSource = &current
+		}
+		proj, err = srv.projectSvc.SetCodePushSettings(
+			ctx,
+			request.ProjectID,
+			*suggestBinaryUpdate,
+			*descriptionSource,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetCodePushSettings: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if request.Body.AdminAllowedCidrs != nil {
+		proj, err = srv.projectSvc.SetAdminAllowedCIDRs(
+			ctx,
+			request.ProjectID,
+			*request.Body.AdminAllowedCidrs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetAdminAllowedCIDRs: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+	}
+
+	if request.Body.Archived != nil {
+		proj, err = srv.projectSvc.SetArchived(ctx, proj.ID, *request.Body.Archived)
+		if err != nil {
+			return nil, fmt.Errorf("projectSvc.SetArchived: %w", err)
+		}
+		if proj == nil {
+			return nil, NewNotFoundError("project not found")
+		}
+		invalidateCache = true
+	}
+
+	if invalidateCache {
+		srv.notifyProjectChanged(ctx, proj.ID)
+	}
+
+	return api.UpdateProject200JSONResponse(projectResponse(proj)), nil
+}
+
+// DeleteProject can be repeated until the project is purged, e.g. when the purge message
+// couldn't be published
+func (srv *apiServer) DeleteProject(
+	ctx context.Context,
+	request api.DeleteProjectRequestObject,
+) (api.DeleteProjectResponseObject, error) {
+	if request.ProjectID == uuid.Nil {
+		return nil, NewValidationError("project_id", "project id is required")
+	}
+
+	proj, err := srv.projectSvc.DeleteProject(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("projectSvc.DeleteProject: %w", err)
+	}
+	if proj == nil {
+		return nil, NewNotFoundError("project not found")
+	}
+	srv.notifyProjectChanged(ctx, proj.ID)
+
+	if err := srv.queueConn.PublishPurgeProjectMessage(ctx, proj.ID); err != nil {
+		return nil, fmt.Errorf("queueConn.PublishPurgeProjectMessage: %w", err)
+	}
+
+	return api.DeleteProject202Response{}, nil
+}
+
+func projectResponse(proj *db.Project) api.Project {
+	resp := api.Project{
+		ID:                          proj.ID,
+		Name:                        proj.Name,
+		UpdateProtocol:              api.UpdateProtocol(proj.UpdateProtocol),
+		ReplicaRegions:              proj.ReplicaRegions,
+		Environment:                 proj.Environment,
+		AdminAllowedCidrs:           proj.AdminAllowedCidrs,
+		MaxAssetCount:               proj.MaxAssetCount,
+		CodePushSuggestBinaryUpdate: proj.CodepushSuggestBinaryUpdate,
+		CodePushDescriptionSource: api.CodePushDescriptionSource(
+			proj.CodepushDescriptionSource,
+		),
+		StableAssetUrls:      proj.StableAssetUrls,
+		AssetPriorities:      proj.AssetPriorities,
+		MeteredDownloadLimit: proj.MeteredDownloadLimit,
+		MaxStorageBytes:      proj.MaxStorageBytes,
+		Archived:             proj.ArchivedAt.Valid,
+	}
+	if proj.PublicAssetsUrl.Valid {
+		resp.PublicAssetsUrl = &proj.PublicAssetsUrl.String
+	}
+	if proj.AssetUrlTemplate.Valid {
+		resp.AssetUrlTemplate = &proj.AssetUrlTemplate.String
+	}
+	if proj.StorageDriverUrl.Valid {
+		resp.StorageDriverUrl = &proj.StorageDriverUrl.String
+	}
+	return resp
 }
 
 func (srv *apiServer) HealthCheck(
@@ -567,5 +1734,64 @@ func (srv *apiServer) HealthCheck(
 		return nil, err
 	}
 
-	return api.HealthCheck200JSONResponse{Status: "ok"}, nil
+	corruptedAssets, err := srv.statsSvc.CorruptedAssetCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.CorruptedAssetCount: %w", err)
+	}
+
+	resp := api.HealthCheck200JSONResponse{
+		Status:          "ok",
+		Queue:           util.StringPtr("ok"),
+		CorruptedAssets: &corruptedAssets,
+	}
+	if err := srv.infraSvc.QueueHealthCheck(); err != nil {
+		logger.FromContext(ctx).Warn("queue is unavailable", zap.Error(err))
+		resp.Status = "degraded"
+		resp.Queue = util.StringPtr("unavailable")
+	}
+	fallingBack, cacheFallbacks := srv.infraSvc.CacheFallback()
+	resp.Cache = util.StringPtr("ok")
+	resp.CacheFallbacks = &cacheFallbacks
+	if fallingBack {
+		resp.Status = "degraded"
+		resp.Cache = util.StringPtr("fallback")
+	}
+	return resp, nil
+}
+
+func (srv *apiServer) GetLogLevels(
+	_ context.Context,
+	_ api.GetLogLevelsRequestObject,
+) (api.GetLogLevelsResponseObject, error) {
+	return api.GetLogLevels200JSONResponse{Levels: logger.Levels()}, nil
+}
+
+func (srv *apiServer) SetLogLevels(
+	_ context.Context,
+	request api.SetLogLevelsRequestObject,
+) (api.SetLogLevelsResponseObject, error) {
+	if err := logger.SetLevels(request.Body.Levels); err != nil {
+		return nil, NewValidationError("levels", err.Error())
+	}
+
+	return api.SetLogLevels200JSONResponse{Levels: logger.Levels()}, nil
+}
+
+func (srv *apiServer) GetMaintenanceMode(
+	ctx context.Context,
+	_ api.GetMaintenanceModeRequestObject,
+) (api.GetMaintenanceModeResponseObject, error) {
+	return api.GetMaintenanceMode200JSONResponse{Enabled: srv.readOnly.Maintenance(ctx)}, nil
+}
+
+func (srv *apiServer) SetMaintenanceMode(
+	ctx context.Context,
+	request api.SetMaintenanceModeRequestObject,
+) (api.SetMaintenanceModeResponseObject, error) {
+	if err := srv.readOnly.SetMaintenance(ctx, request.Body.Enabled); err != nil {
+		return nil, fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+	logger.FromContext(ctx).Warn("maintenance mode changed", zap.Bool("enabled", request.Body.Enabled))
+
+	return api.SetMaintenanceMode200JSONResponse{Enabled: srv.readOnly.Maintenance(ctx)}, nil
 }
@@ -1,16 +1,28 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	memorycache "github.com/a-gierczak/paratrooper/internal/cache/memory"
+	"github.com/a-gierczak/paratrooper/internal/infra"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
 	"github.com/a-gierczak/paratrooper/internal/storage"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestMain(m *testing.M) {
@@ -68,4 +80,360 @@ func TestPrepareUpdateParamsValidation(t *testing.T) {
 			return err.Field() == "ContentType"
 		}))
 	})
+
+	t.Run("too many additional channels", func(t *testing.T) {
+		obj := api.PrepareUpdateBody{
+			RuntimeVersion:     "1.0.0",
+			Message:            "release",
+			AdditionalChannels: make([]string, 11),
+		}
+
+		err := binding.Validator.ValidateStruct(&obj)
+		var validationErrs validator.ValidationErrors
+		assert.True(t, errors.As(err, &validationErrs))
+		assert.Equal(t, "AdditionalChannels", validationErrs[0].Field())
+	})
+
+	t.Run("invalid external ID", func(t *testing.T) {
+		for _, externalID := range []string{"", "release\n1", strings.Repeat("a", 129)} {
+			obj := api.PrepareUpdateBody{
+				RuntimeVersion: "1.0.0",
+				Message:        "release",
+				ExternalID:     &externalID,
+			}
+
+			err := binding.Validator.ValidateStruct(&obj)
+			var validationErrs validator.ValidationErrors
+			assert.True(t, errors.As(err, &validationErrs), externalID)
+			assert.Equal(t, "ExternalID", validationErrs[0].Field())
+		}
+
+		externalID := "train-2024.10#3"
+		obj := api.PrepareUpdateBody{
+			RuntimeVersion: "1.0.0",
+			Message:        "release",
+			ExternalID:     &externalID,
+		}
+		assert.NoError(t, binding.Validator.ValidateStruct(&obj))
+	})
+}
+
+func TestValidateAdditionalChannels(t *testing.T) {
+	assert.NoError(t, validateAdditionalChannels("production", nil))
+	assert.NoError(t, validateAdditionalChannels("production", []string{"staging", "beta"}))
+	assert.Error(t, validateAdditionalChannels("production", []string{"staging", "production"}))
+	assert.Error(t, validateAdditionalChannels("production", []string{"staging", "staging"}))
+}
+
+func TestUpdateProjectParamsValidation(t *testing.T) {
+	valid := []string{"", "https://assets.example.com", "https://cdn.example.com/prefix/"}
+	for _, publicURL := range valid {
+		obj := api.UpdateProjectParams{PublicAssetsUrl: &publicURL}
+		assert.NoError(t, binding.Validator.ValidateStruct(&obj), publicURL)
+	}
+
+	invalidURL := "assets.example.com"
+	obj := api.UpdateProjectParams{PublicAssetsUrl: &invalidURL}
+	assert.Error(t, binding.Validator.ValidateStruct(&obj))
+
+	for _, maxAssetCount := range []int32{0, 100001} {
+		obj := api.UpdateProjectParams{MaxAssetCount: &maxAssetCount}
+		assert.Error(t, binding.Validator.ValidateStruct(&obj), maxAssetCount)
+	}
+
+	priorities := make([]string, 33)
+	obj = api.UpdateProjectParams{AssetPriorities: &priorities}
+	assert.Error(t, binding.Validator.ValidateStruct(&obj))
+}
+
+func TestGetCodePushLegacyUpdate(t *testing.T) {
+	srv := &apiServer{}
+	resp, err := srv.GetCodePushLegacyUpdate(
+		logger.ContextWithLogger(context.Background(), zap.NewNop()),
+		api.GetCodePushLegacyUpdateRequestObject{
+			Params: api.GetCodePushLegacyUpdateParams{
+				AppVersion:    "1.0.0",
+				DeploymentKey: "invalid",
+			},
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		api.GetCodePushLegacyUpdate400JSONResponse(
+			NewValidationErrorResponse("deployment_key", "invalid deployment key"),
+		),
+		resp,
+	)
+}
+
+func TestExpoNoUpdateCachedResponse(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	srv := &apiServer{infraSvc: infra.NewService(nil, nil, memorycache.New())}
+
+	params := &expoUpdateParams{
+		RuntimeVersion: "1.0.0",
+		Platform:       "ios",
+		Channel:        "production",
+		ProjectID:      uuid.New(),
+	}
+	resp, err := srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	err = srv.infraSvc.Cache().Set(ctx, expoNoUpdateCacheKey(params), "1", expoNoUpdateCacheTTL)
+	assert.NoError(t, err)
+
+	// the negative entry applies to every current update
+	currentUpdateID := uuid.New()
+	params.CurrentUpdateId = &currentUpdateID
+	resp, err = srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, newExpoNoUpdateResponse(), resp)
+
+	params.Platform = "android"
+	resp, err = srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestExpoCachedResponseInvalidated(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	srv := &apiServer{infraSvc: infra.NewService(nil, nil, memorycache.New())}
+
+	params := &expoUpdateParams{
+		RuntimeVersion: "1.0.0",
+		Platform:       "ios",
+		Channel:        "production",
+		ProjectID:      uuid.New(),
+	}
+	resp, err := srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.NoError(t, srv.expoUpdateSetCachedResponse(ctx, params, *newExpoNoUpdateResponse()))
+
+	resp, err = srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "directive", resp.PartName)
+
+	// stale responses are deleted by the prefixes of the channel and the project
+	for _, channel := range []string{params.Channel, ""} {
+		prefix := cachedResponsePrefixes(params.ProjectID, channel)[0]
+		assert.True(t, strings.HasPrefix(expoUpdateCacheKey(params), prefix))
+	}
+
+	// the channel changed
+	cacheKey := channelGenerationKey(params.ProjectID, params.Channel)
+	assert.NoError(t, srv.infraSvc.Cache().Delete(ctx, cacheKey))
+	resp, err = srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// the project was archived
+	assert.NoError(t, srv.expoUpdateSetCachedResponse(ctx, params, *newExpoNoUpdateResponse()))
+	cacheKey = projectGenerationKey(params.ProjectID)
+	assert.NoError(t, srv.infraSvc.Cache().Delete(ctx, cacheKey))
+	resp, err = srv.expoUpdateCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestExpoUpdateParseParamsChannel(t *testing.T) {
+	ctx := context.Background()
+	runtimeVersion := "1.0.0"
+	platform := "ios"
+	staging := "staging"
+	preview := "preview"
+
+	request := api.GetExpoUpdateRequestObject{
+		ProjectID: uuid.New(),
+		Params: api.GetExpoUpdateParams{
+			ExpoRuntimeVersion: &runtimeVersion,
+			ExpoPlatform:       &platform,
+		},
+	}
+	params, err := expoUpdateParseParams(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "production", params.Channel)
+
+	request.Params.ChannelName = &preview
+	params, err = expoUpdateParseParams(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "preview", params.Channel)
+
+	// the header takes precedence over the query parameter
+	request.Params.ExpoChannelName = &staging
+	params, err = expoUpdateParseParams(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", params.Channel)
+}
+
+func TestCodePushCachedResponse(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	memCache := memorycache.New()
+	srv := &apiServer{infraSvc: infra.NewService(nil, nil, memCache)}
+
+	params := &codePushUpdateParams{
+		ProjectID:  uuid.New(),
+		Channel:    "production",
+		Platform:   "ios",
+		AppVersion: "1.0.0",
+	}
+	resp, cacheKey, err := srv.codePushCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	updateInfo := &api.CodePushUpdate{IsAvailable: true, Label: uuid.NewString()}
+	assert.NoError(t, srv.codePushSetCachedResponse(ctx, cacheKey, updateInfo))
+	resp, _, err = srv.codePushCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, updateInfo, resp)
+
+	// publishing to the channel invalidates its cached responses
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	assert.NoError(t, err)
+	defer queueConn.Close()
+	assert.NoError(t, ConsumeChannelChangedEvents(ctx, queueConn, memCache))
+	err = queueConn.PublishChannelChangedEvent(ctx, queue.ChannelChangedEventPayload{
+		ProjectID: params.ProjectID,
+		Channel:   params.Channel,
+	})
+	assert.NoError(t, err)
+
+	resp, _, err = srv.codePushCachedResponse(ctx, params)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestExpoManifestETag(t *testing.T) {
+	assert.Empty(t, expoManifestETag(pgtype.Text{}, "ios"))
+
+	etag := expoManifestETag(pgtype.Text{String: "abc", Valid: true}, "ios")
+	assert.Equal(t, `W/"abc-ios"`, etag)
+
+	match := func(ifNoneMatch string) bool {
+		return etagMatches(&ifNoneMatch, etag)
+	}
+	assert.True(t, match(`W/"abc-ios"`))
+	assert.True(t, match(`"abc-ios"`))
+	assert.True(t, match(`"other", W/"abc-ios"`))
+	assert.True(t, match("*"))
+	assert.False(t, match(`W/"abc-android"`))
+	assert.False(t, etagMatches(nil, etag))
+
+	// manifests without a content hash are never matched
+	ifNoneMatch := "*"
+	assert.False(t, etagMatches(&ifNoneMatch, ""))
+}
+
+func TestArchivedProjectRejectsUpdates(t *testing.T) {
+	ctx := context.Background()
+	archived := &db.Project{
+		ID:         uuid.New(),
+		ArchivedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	srv := &apiServer{projectSvc: &stubProjectService{projects: map[uuid.UUID]*db.Project{
+		archived.ID: archived,
+	}}}
+
+	_, err := srv.PrepareUpdate(ctx, api.PrepareUpdateRequestObject{
+		ProjectID: archived.ID,
+		Body: &api.PrepareUpdateBody{
+			RuntimeVersion: "1.0.0",
+			FileMetadata:   []api.StorageObject{{Path: "metadata.json"}},
+		},
+	})
+	var httpErr *HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+
+	_, err = srv.CommitUpdate(ctx, api.CommitUpdateRequestObject{
+		ProjectID: archived.ID,
+		UpdateID:  uuid.New(),
+	})
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+}
+
+func TestDeleteProject(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	assert.NoError(t, err)
+	defer queueConn.Close()
+
+	purged := make(chan uuid.UUID, 1)
+	err = queueConn.ConsumePurgeProjectMessages(ctx, func(msg queue.Message) {
+		payload, err := queue.ParsePurgeProjectMessage(msg.Data())
+		assert.NoError(t, err)
+		purged <- payload.ProjectID
+		assert.NoError(t, msg.Ack())
+	})
+	assert.NoError(t, err)
+
+	proj := &db.Project{ID: uuid.New()}
+	srv := &apiServer{
+		projectSvc: &stubProjectService{projects: map[uuid.UUID]*db.Project{proj.ID: proj}},
+		queueConn:  queueConn,
+	}
+
+	resp, err := srv.DeleteProject(ctx, api.DeleteProjectRequestObject{ProjectID: proj.ID})
+	assert.NoError(t, err)
+	assert.IsType(t, api.DeleteProject202Response{}, resp)
+	select {
+	case projectID := <-purged:
+		assert.Equal(t, proj.ID, projectID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("purge message was not published")
+	}
+
+	_, err = srv.DeleteProject(ctx, api.DeleteProjectRequestObject{ProjectID: uuid.New()})
+	var httpErr *HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+func TestUpdateProjectInvalidatesCachedResponses(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	assert.NoError(t, err)
+	defer queueConn.Close()
+
+	events := make(chan queue.ChannelChangedEventPayload, 2)
+	err = queueConn.ConsumeChannelChangedEvents(ctx, func(data []byte) {
+		event, err := queue.ParseChannelChangedEvent(data)
+		assert.NoError(t, err)
+		events <- *event
+	})
+	assert.NoError(t, err)
+
+	proj := &db.Project{ID: uuid.New()}
+	srv := &apiServer{
+		projectSvc: &stubProjectService{projects: map[uuid.UUID]*db.Project{proj.ID: proj}},
+		queueConn:  queueConn,
+	}
+	update := func(body api.UpdateProjectParams) {
+		_, err := srv.UpdateProject(ctx, api.UpdateProjectRequestObject{
+			ProjectID: proj.ID,
+			Body:      &body,
+		})
+		assert.NoError(t, err)
+	}
+
+	// settings that don't change the served responses keep them cached
+	maxAssetCount := int32(100)
+	update(api.UpdateProjectParams{MaxAssetCount: &maxAssetCount})
+	publicAssetsURL := "https://assets.example.com"
+	update(api.UpdateProjectParams{PublicAssetsUrl: &publicAssetsURL})
+	select {
+	case event := <-events:
+		assert.Equal(t, proj.ID, event.ProjectID)
+		assert.Empty(t, event.Channel)
+	case <-time.After(5 * time.Second):
+		t.Fatal("project changed event was not published")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
 }
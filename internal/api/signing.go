@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/signing"
+)
+
+const defaultSigningKeyOverlapHours = 168
+
+func signingKeyResponse(key *db.SigningKey, now time.Time) api.SigningKey {
+	response := api.SigningKey{
+		KeyID:            key.KeyID,
+		Algorithm:        key.Algorithm,
+		CertificateChain: key.CertificateChain,
+		Status:           api.SigningKeyStatus(signing.Status(key, now)),
+		CreatedAt:        key.CreatedAt.Time.UTC().Truncate(time.Second),
+	}
+	if key.RetiredAt.Valid {
+		retiresAt := key.RetiredAt.Time.UTC().Truncate(time.Second)
+		response.RetiresAt = &retiresAt
+	}
+	return response
+}
+
+func (srv *apiServer) GetSigningKeys(
+	ctx context.Context,
+	request api.GetSigningKeysRequestObject,
+) (api.GetSigningKeysResponseObject, error) {
+	keys, err := srv.signingSvc.Keys(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("signingSvc.Keys: %w", err)
+	}
+
+	now := time.Now()
+	response := make(api.GetSigningKeys200JSONResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, signingKeyResponse(&key, now))
+	}
+	return response, nil
+}
+
+func (srv *apiServer) RotateSigningKey(
+	ctx context.Context,
+	request api.RotateSigningKeyRequestObject,
+) (api.RotateSigningKeyResponseObject, error) {
+	proj, err := srv.projectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	overlapHours := defaultSigningKeyOverlapHours
+	if request.Body.OverlapHours != nil {
+		overlapHours = *request.Body.OverlapHours
+	}
+
+	key, err := srv.signingSvc.RotateKey(ctx, proj.ID, signing.RotateKeyParams{
+		KeyID:               request.Body.KeyID,
+		PrivateKeyPEM:       request.Body.PrivateKey,
+		CertificateChainPEM: request.Body.CertificateChain,
+		Overlap:             time.Duration(overlapHours) * time.Hour,
+	})
+	if err != nil {
+		var invalidKeyErr *signing.InvalidKeyError
+		if errors.As(err, &invalidKeyErr) {
+			return api.RotateSigningKey400JSONResponse(
+				NewValidationErrorResponse("private_key", invalidKeyErr.Error()),
+			), nil
+		}
+		if errors.Is(err, signing.ErrKeyIDTaken) {
+			return api.RotateSigningKey400JSONResponse(
+				NewValidationErrorResponse("key_id", err.Error()),
+			), nil
+		}
+		return nil, fmt.Errorf("signingSvc.RotateKey: %w", err)
+	}
+
+	return api.RotateSigningKey200JSONResponse(signingKeyResponse(key, time.Now())), nil
+}
+
+func (srv *apiServer) RetireSigningKey(
+	ctx context.Context,
+	request api.RetireSigningKeyRequestObject,
+) (api.RetireSigningKeyResponseObject, error) {
+	err := srv.signingSvc.RetireKey(ctx, request.ProjectID, request.KeyID)
+	if err != nil {
+		if errors.Is(err, signing.ErrKeyNotFound) {
+			return api.RetireSigningKey404JSONResponse{Error: err.Error()}, nil
+		}
+		return nil, fmt.Errorf("signingSvc.RetireKey: %w", err)
+	}
+
+	return api.RetireSigningKey204Response{}, nil
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/stats"
+	"github.com/a-gierczak/paratrooper/internal/update"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const defaultStatsLimit = 100
+
+const (
+	defaultUsageRange = 24 * time.Hour
+	maxUsageRange     = 31 * 24 * time.Hour
+)
+
+// statsTimeRange returns the requested time range, the last 24 hours by default
+func statsTimeRange(fromParam, toParam *time.Time) (from, to time.Time, err error) {
+	to = time.Now()
+	if toParam != nil {
+		to = *toParam
+	}
+	from = to.Add(-defaultUsageRange)
+	if fromParam != nil {
+		from = *fromParam
+	}
+	if !from.Before(to) {
+		return from, to, NewValidationError("from", "from must be before to")
+	}
+	if to.Sub(from) > maxUsageRange {
+		return from, to, NewValidationError("to", "the time range can be at most 31 days")
+	}
+	return from, to, nil
+}
+
+func (srv *apiServer) GetAssetDownloadStats(
+	ctx context.Context,
+	request api.GetAssetDownloadStatsRequestObject,
+) (api.GetAssetDownloadStatsResponseObject, error) {
+	limit := int32(defaultStatsLimit)
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	assets, err := srv.statsSvc.AssetDownloads(
+		ctx,
+		request.ProjectID,
+		request.Params.UpdateID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.AssetDownloads: %w", err)
+	}
+
+	response := make(api.GetAssetDownloadStats200JSONResponse, 0, len(assets))
+	for _, asset := range assets {
+		response = append(response, api.AssetDownloadStats{
+			UpdateID:         asset.UpdateID,
+			Path:             asset.Path,
+			Downloads:        asset.Downloads,
+			BytesServed:      asset.BytesServed,
+			LastDownloadedAt: asset.LastDownloadedAt.Time.UTC().Truncate(time.Second),
+		})
+	}
+	return response, nil
+}
+
+func (srv *apiServer) GetCodePushReleaseStats(
+	ctx context.Context,
+	request api.GetCodePushReleaseStatsRequestObject,
+) (api.GetCodePushReleaseStatsResponseObject, error) {
+	limit := int32(defaultStatsLimit)
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	releases, err := srv.statsSvc.CodePushReleases(ctx, request.ProjectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.CodePushReleases: %w", err)
+	}
+
+	response := make(api.GetCodePushReleaseStats200JSONResponse, 0, len(releases))
+	for _, release := range releases {
+		response = append(response, api.CodePushReleaseStats{
+			UpdateID:             release.UpdateID,
+			Downloads:            release.Downloads,
+			DeploymentsSucceeded: release.DeploymentsSucceeded,
+			DeploymentsFailed:    release.DeploymentsFailed,
+			LastReportedAt:       release.LastReportedAt.Time.UTC().Truncate(time.Second),
+		})
+	}
+	return response, nil
+}
+
+func (srv *apiServer) GetAPIUsageStats(
+	ctx context.Context,
+	request api.GetAPIUsageStatsRequestObject,
+) (api.GetAPIUsageStatsResponseObject, error) {
+	from, to, err := statsTimeRange(request.Params.From, request.Params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := srv.statsSvc.APIUsage(ctx, request.ProjectID, from, to, request.Params.Operation)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.APIUsage: %w", err)
+	}
+
+	response := make(api.GetAPIUsageStats200JSONResponse, 0, len(usage))
+	for _, item := range usage {
+		response = append(response, api.APIUsageStats{
+			Operation:    item.Operation,
+			PeriodStart:  item.PeriodStart,
+			Requests:     item.Requests,
+			ClientErrors: item.ClientErrors,
+			ServerErrors: item.ServerErrors,
+			ErrorRate:    item.ErrorRate(),
+			P95LatencyMs: item.P95LatencyMs,
+		})
+	}
+	return response, nil
+}
+
+func (srv *apiServer) GetUpdateCheckEvents(
+	ctx context.Context,
+	request api.GetUpdateCheckEventsRequestObject,
+) (api.GetUpdateCheckEventsResponseObject, error) {
+	from, to, err := statsTimeRange(request.Params.From, request.Params.To)
+	if err != nil {
+		return nil, err
+	}
+	limit := int32(defaultStatsLimit)
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	filter := stats.UpdateCheckFilter{
+		ClientID: request.Params.ClientID,
+		Channel:  request.Params.Channel,
+	}
+	events, err := srv.statsSvc.UpdateChecks(ctx, request.ProjectID, from, to, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.UpdateChecks: %w", err)
+	}
+
+	response := make(api.GetUpdateCheckEvents200JSONResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, updateCheckEventResponse(event))
+	}
+	return response, nil
+}
+
+func updateCheckEventResponse(event db.UpdateCheckEvent) api.UpdateCheckEvent {
+	response := api.UpdateCheckEvent{
+		CheckedAt:      event.CheckedAt.Time.UTC(),
+		Channel:        event.Channel,
+		RuntimeVersion: event.RuntimeVersion,
+		Platform:       event.Platform,
+		Decision:       api.UpdateCheckEventDecision(event.Decision),
+	}
+	if event.Reason.Valid {
+		reason := api.UpdateCheckEventReason(event.Reason.String)
+		response.Reason = &reason
+	}
+	if event.UpdateID.Valid {
+		updateID := uuid.UUID(event.UpdateID.Bytes)
+		response.UpdateID = &updateID
+	}
+	if event.CodepushLabel.Valid {
+		response.CodePushLabel = &event.CodepushLabel.String
+	}
+	if event.ClientID.Valid {
+		response.ClientID = &event.ClientID.String
+	}
+	if event.CurrentUpdateID.Valid {
+		currentUpdateID := uuid.UUID(event.CurrentUpdateID.Bytes)
+		response.CurrentUpdateID = &currentUpdateID
+	}
+	return response
+}
+
+func (srv *apiServer) GetUpdateStats(
+	ctx context.Context,
+	request api.GetUpdateStatsRequestObject,
+) (api.GetUpdateStatsResponseObject, error) {
+	_, err := srv.updateSvc.UpdateByID(ctx, request.ProjectID, request.UpdateID)
+	if err != nil {
+		if errors.Is(err, update.ErrUpdateNotFound) {
+			return nil, NewNotFoundError("update not found")
+		}
+		return nil, fmt.Errorf("updateSvc.UpdateByID: %w", err)
+	}
+
+	adoption, err := srv.statsSvc.UpdateAdoption(ctx, request.UpdateID)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.UpdateAdoption: %w", err)
+	}
+
+	response := api.GetUpdateStats200JSONResponse{
+		UpdateID:              request.UpdateID,
+		Downloads:             adoption.Downloads(),
+		ActiveInstallEstimate: adoption.ActiveInstallEstimate(),
+		Rollbacks:             adoption.Rollbacks(),
+		Days:                  make([]api.UpdateStatsDay, 0, len(adoption.Days)),
+	}
+	for _, day := range adoption.Days {
+		response.Days = append(response.Days, api.UpdateStatsDay{
+			Day:        openapi_types.Date{Time: day.Day.Time},
+			Platform:   day.Platform,
+			Downloads:  day.Downloads,
+			Departures: day.Departures,
+			Rollbacks:  day.Rollbacks,
+		})
+	}
+	return response, nil
+}
+
+func (srv *apiServer) GetCorruptedAssets(
+	ctx context.Context,
+	request api.GetCorruptedAssetsRequestObject,
+) (api.GetCorruptedAssetsResponseObject, error) {
+	assets, err := srv.statsSvc.CorruptedAssets(ctx, request.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("statsSvc.CorruptedAssets: %w", err)
+	}
+
+	response := make(api.GetCorruptedAssets200JSONResponse, 0, len(assets))
+	for _, row := range assets {
+		check := row.AssetIntegrityCheck
+		item := api.AssetIntegrityCheck{
+			UpdateID:    row.UpdateAsset.UpdateID,
+			ObjectKey:   row.UpdateAsset.StorageObjectPath,
+			Status:      api.AssetIntegrityCheckStatus(check.Status),
+			Quarantined: check.Quarantined,
+			CheckedAt:   check.CheckedAt.Time.UTC().Truncate(time.Second),
+		}
+		expectedHash := row.UpdateAsset.ContentSha256
+		if row.UpdateAsset.IsArchive {
+			expectedHash = row.UpdateAsset.ContentMd5
+		}
+		if expectedHash != "" {
+			item.ExpectedHash = &expectedHash
+		}
+		if check.ActualHash.Valid {
+			item.ActualHash = &check.ActualHash.String
+		}
+		if check.Error.Valid {
+			item.Error = &check.Error.String
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
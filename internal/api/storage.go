@@ -1,15 +1,24 @@
 package api
 
 import (
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/stats"
 	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/update"
 	"github.com/a-gierczak/paratrooper/internal/util"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 )
 
 type uploadAssetParams struct {
@@ -19,18 +28,31 @@ type uploadAssetParams struct {
 	ContentLength int64  `binding:"required,min=1,max_object_size"`
 }
 
-func handleGetAsset(svc storage.Service) gin.HandlerFunc {
+func handleGetAsset(
+	svc storage.Service,
+	security AssetSecurityConfig,
+	recorder *stats.Recorder,
+) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		log := logger.FromContext(ctx)
-		objectKey, err := svc.ObjectKeyFromURL(ctx, ctx.Request.URL)
+		objectKey, err := svc.ObjectKeyFromRequest(ctx, ctx.Request)
 		if err != nil {
 			ctx.Error(&HTTPError{
 				StatusCode: http.StatusUnauthorized,
-				Message:    "failed to get object key from URL",
+				Message:    "failed to get object key from request",
 				Inner:      err,
 			})
 			return
 		}
+		if !storage.IsUpdateObjectKey(objectKey) {
+			// e.g. a signed URL of a quarantined object or an upload chunk
+			ctx.Error(&HTTPError{
+				StatusCode: http.StatusForbidden,
+				Message:    "object is not a file of an update",
+			})
+			return
+		}
+		defer recorder.RecordResponse(ctx, objectKey)
 
 		reader, attrs, err := svc.ReadObjectWithAttributes(ctx, objectKey)
 		if err != nil {
@@ -39,17 +61,107 @@ func handleGetAsset(svc storage.Service) gin.HandlerFunc {
 		}
 		defer util.CloseWithLogger(log, reader)
 
+		var extraHeaders map[string]string
+		if attrs.ContentEncoding != "" {
+			extraHeaders = map[string]string{"Content-Encoding": attrs.ContentEncoding}
+		}
+
+		contentType := setAssetSecurityHeaders(ctx, security, objectKey, attrs.ContentType)
 		ctx.DataFromReader(
 			http.StatusOK,
 			reader.Size(),
-			attrs.ContentType,
+			contentType,
 			reader,
-			nil,
+			extraHeaders,
 		)
 	}
 }
 
-func handleUploadAsset(svc storage.Service) gin.HandlerFunc {
+// handleGetStableAsset serves the assets of published updates by their ID, assets never change,
+// so clients and proxies can cache them for good
+func handleGetStableAsset(
+	updateSvc update.Service,
+	st *storage.Storage,
+	security AssetSecurityConfig,
+	recorder *stats.Recorder,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		log := logger.FromContext(ctx)
+		assetID, err := uuid.Parse(ctx.Param("assetID"))
+		if err != nil {
+			ctx.Error(NewNotFoundError("asset not found"))
+			return
+		}
+		if !st.VerifyStableAssetToken(assetID, ctx.Query(storage.StableAssetTokenParam)) {
+			ctx.Error(&HTTPError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "invalid asset token",
+			})
+			return
+		}
+
+		asset, err := updateSvc.PublishedAsset(ctx, assetID)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		if asset == nil {
+			ctx.Error(NewNotFoundError("asset not found"))
+			return
+		}
+		defer recorder.RecordResponse(ctx, asset.StorageObjectPath)
+
+		reader, err := st.Bucket().NewReader(ctx, asset.StorageObjectPath, nil)
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			// moved to cold storage or quarantined
+			ctx.Error(NewNotFoundError("asset not found"))
+			return
+		}
+		if err != nil {
+			ctx.Error(fmt.Errorf("failed to create object reader: %w", err))
+			return
+		}
+		defer util.CloseWithLogger(log, reader)
+
+		contentType := setAssetSecurityHeaders(ctx, security, asset.StorageObjectPath, asset.ContentType)
+		ctx.Header("Content-Type", contentType)
+		if asset.ContentEncoding != "" {
+			ctx.Header("Content-Encoding", asset.ContentEncoding)
+		}
+		ctx.Header("ETag", `"`+asset.ContentSha256+`"`)
+		ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+
+		// handles conditional and range requests of resumed downloads
+		http.ServeContent(ctx.Writer, ctx.Request, "", reader.ModTime(), reader)
+	}
+}
+
+// uploadContentMD5 returns the MD5 the uploaded file must match, of the Content-MD5 header or
+// the one declared when the update was prepared, nil when neither is known
+func uploadContentMD5(
+	ctx *gin.Context,
+	updateSvc update.Service,
+	params *uploadAssetParams,
+) ([]byte, error) {
+	if header := ctx.GetHeader("Content-MD5"); header != "" {
+		contentMD5, err := base64.StdEncoding.DecodeString(header)
+		if err != nil || len(contentMD5) != md5.Size {
+			return nil, NewValidationError("content_md5", "invalid Content-MD5 header")
+		}
+		return contentMD5, nil
+	}
+
+	object, err := updateSvc.DeclaredObject(ctx, uuid.MustParse(params.UpdateID), params.Path)
+	if errors.Is(err, update.ErrFileNotDeclared) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updateSvc.DeclaredObject: %w", err)
+	}
+	return update.DeclaredMD5(object), nil
+}
+
+func handleUploadAsset(svc storage.Service, updateSvc update.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		log := logger.FromContext(ctx)
 
@@ -76,8 +188,25 @@ func handleUploadAsset(svc storage.Service) gin.HandlerFunc {
 		log = log.With(zap.String("object", objectKey),
 			zap.Int64("size", params.ContentLength))
 
+		contentMD5, err := uploadContentMD5(ctx, updateSvc, &params)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
 		log.Debug("saving file to local storage")
-		if err = svc.Upload(ctx, ctx.Request.Body, objectKey); err != nil {
+		// keep the headers a storage provider would keep when uploading with a signed URL
+		opts := &blob.WriterOptions{
+			ContentType:     ctx.ContentType(),
+			ContentEncoding: ctx.GetHeader("Content-Encoding"),
+			ContentMD5:      contentMD5,
+		}
+		err = svc.Upload(ctx, ctx.Request.Body, objectKey, opts)
+		if errors.Is(err, storage.ErrContentMD5Mismatch) {
+			ctx.Error(NewValidationError("content_md5", err.Error()))
+			return
+		}
+		if err != nil {
 			log.Error("failed to save file to local storage", zap.Error(err))
 			ctx.Error(err)
 			return
@@ -88,9 +217,28 @@ func handleUploadAsset(svc storage.Service) gin.HandlerFunc {
 	}
 }
 
-func addStorageRoutes(r gin.IRoutes, st *storage.Storage) {
+func addStorageRoutes(
+	r gin.IRoutes,
+	st *storage.Storage,
+	updateSvc update.Service,
+	security AssetSecurityConfig,
+	recorder *stats.Recorder,
+) {
 	svc := storage.NewService(st)
 
-	r.GET(storage.AssetEndpointPath, handleGetAsset(svc))
-	r.PUT(storage.AssetEndpointPath, handleUploadAsset(svc))
+	r.GET(storage.AssetEndpointPath, handleGetAsset(svc, security, recorder))
+	r.PUT(storage.AssetEndpointPath, handleUploadAsset(svc, updateSvc))
+}
+
+func addStableAssetRoute(
+	r gin.IRoutes,
+	updateSvc update.Service,
+	st *storage.Storage,
+	security AssetSecurityConfig,
+	recorder *stats.Recorder,
+) {
+	r.GET(
+		storage.StableAssetEndpointPath,
+		handleGetStableAsset(updateSvc, st, security, recorder),
+	)
 }
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusClientClosedRequest is logged for requests the client gave up on, as nginx does
+const statusClientClosedRequest = 499
+
+// untimedOperations stream the request body, so they take as long as the client needs to send it
+var untimedOperations = map[string]bool{
+	"UploadChunk": true,
+}
+
+// newRequestTimeoutMiddleware sets the deadline of the request context, which is passed to
+// the storage and database calls of the handler. The context is also canceled when the client
+// disconnects, so the work nobody waits for is stopped.
+func newRequestTimeoutMiddleware(timeout time.Duration) api.StrictMiddlewareFunc {
+	return func(handler api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		if timeout <= 0 || untimedOperations[operationID] {
+			return handler
+		}
+
+		return func(ctx *gin.Context, request interface{}) (response interface{}, err error) {
+			requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+			defer cancel()
+			ctx.Request = ctx.Request.WithContext(requestCtx)
+			return handler(ctx, request)
+		}
+	}
+}
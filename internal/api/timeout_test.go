@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(operationID string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.ContextWithFallback = true
+		r.Use(NewErrorHandlingMiddleware())
+
+		handler := newRequestTimeoutMiddleware(time.Millisecond)(
+			func(ctx *gin.Context, request interface{}) (interface{}, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					return nil, nil
+				}
+				// a storage or database call blocking until the deadline
+				<-ctx.Done()
+				return nil, fmt.Errorf("failed to read object: %w", ctx.Err())
+			},
+			operationID,
+		)
+		r.GET("/", func(ctx *gin.Context) {
+			if _, err := handler(ctx, nil); err != nil {
+				ctx.Error(err)
+				return
+			}
+			ctx.Status(http.StatusOK)
+		})
+
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+		return resp
+	}
+
+	assert.Equal(t, http.StatusGatewayTimeout, serve("GetExpoUpdate").Code)
+	assert.Equal(t, http.StatusOK, serve("UploadChunk").Code)
+}
+
+func TestClientClosedRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(NewErrorHandlingMiddleware())
+	r.GET("/", func(ctx *gin.Context) {
+		<-ctx.Done()
+		ctx.Error(ctx.Err())
+	})
+
+	requestCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(requestCtx))
+	assert.Equal(t, statusClientClosedRequest, resp.Code)
+}
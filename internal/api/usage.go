@@ -0,0 +1,71 @@
+package api
+
+import (
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/codepush"
+	"github.com/a-gierczak/paratrooper/internal/stats"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+)
+
+// usageOperationKey is the key of the ID of the API operation in the gin context
+const usageOperationKey = "usageOperationID"
+
+// newUsageOperationStrictMiddleware passes the ID of the API operation to the usage middleware
+func newUsageOperationStrictMiddleware() api.StrictMiddlewareFunc {
+	return func(f strictgin.StrictGinHandlerFunc, operationID string) strictgin.StrictGinHandlerFunc {
+		return func(ctx *gin.Context, request interface{}) (response interface{}, err error) {
+			ctx.Set(usageOperationKey, operationID)
+			return f(ctx, request)
+		}
+	}
+}
+
+// usageProjectID returns the project of the request, from the path or the deployment key of
+// CodePush update checks, CodePush reports carry it in the body and aren't counted
+func usageProjectID(ctx *gin.Context) (uuid.UUID, bool) {
+	if projectID, err := uuid.Parse(ctx.Param("projectID")); err == nil {
+		return projectID, true
+	}
+	deploymentKey := ctx.Query("deployment_key")
+	if deploymentKey == "" {
+		deploymentKey = ctx.Query("deploymentKey")
+	}
+	if deploymentKey == "" {
+		return uuid.Nil, false
+	}
+	projectID, _, _, _, err := codepush.ParseDeploymentKey(deploymentKey)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
+
+// newUsageMiddleware records the requests of the API operations of projects for the usage
+// stats, it has to run before the error handling middleware to see the status of errors
+func newUsageMiddleware(recorder *stats.UsageRecorder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		operationID := ctx.GetString(usageOperationKey)
+		if operationID == "" {
+			return
+		}
+		projectID, ok := usageProjectID(ctx)
+		if !ok {
+			return
+		}
+		recorder.Record(stats.APIRequest{
+			ProjectID: projectID,
+			Operation: operationID,
+			Status:    ctx.Writer.Status(),
+			Latency:   time.Since(start),
+			Time:      start,
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageProjectID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	projectID := uuid.New()
+
+	projectOf := func(route, target string) (uuid.UUID, bool) {
+		var (
+			found uuid.UUID
+			ok    bool
+		)
+		r := gin.New()
+		r.GET(route, func(ctx *gin.Context) { found, ok = usageProjectID(ctx) })
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+		return found, ok
+	}
+
+	found, ok := projectOf(
+		"/api/v1/public/:projectID/expo",
+		"/api/v1/public/"+projectID.String()+"/expo",
+	)
+	assert.True(t, ok)
+	assert.Equal(t, projectID, found)
+
+	found, ok = projectOf(
+		"/v0.1/public/codepush/update_check",
+		"/v0.1/public/codepush/update_check?deployment_key="+projectID.String()+"/ios/production",
+	)
+	assert.True(t, ok)
+	assert.Equal(t, projectID, found)
+
+	// deployment key of a flavor
+	found, ok = projectOf(
+		"/updateCheck",
+		"/updateCheck?deploymentKey="+projectID.String()+".pro/android/beta",
+	)
+	assert.True(t, ok)
+	assert.Equal(t, projectID, found)
+
+	_, ok = projectOf("/updateCheck", "/updateCheck?deploymentKey=invalid")
+	assert.False(t, ok)
+	_, ok = projectOf("/api/v1/admin/project", "/api/v1/admin/project")
+	assert.False(t, ok)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	apiPathPrefix    = "/api/"
+	APIVersionHeader = "Pt-Api-Version"
+)
+
+// apiVersion is a major version of the API, served under /api/<name>/. Released versions
+// only get backwards compatible changes, breaking ones ship under the next version.
+type apiVersion struct {
+	name string
+	// deprecated versions are still served, with a Deprecation header in every response
+	deprecated bool
+}
+
+// apiVersions are all served versions, the last one is the latest
+var apiVersions = []apiVersion{
+	{name: "v1"},
+}
+
+type apiVersionsResponse struct {
+	Versions   []string `json:"versions"`
+	Latest     string   `json:"latest"`
+	Deprecated []string `json:"deprecated"`
+}
+
+func findAPIVersion(name string) (apiVersion, bool) {
+	for _, version := range apiVersions {
+		if version.name == name {
+			return version, true
+		}
+	}
+	return apiVersion{}, false
+}
+
+// NewAPIVersionMiddleware tags responses of versioned routes with their version
+// and rejects versions that aren't served, routes outside of /api/ are left untouched
+func NewAPIVersionMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		path, ok := strings.CutPrefix(ctx.Request.URL.Path, apiPathPrefix)
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		name, _, _ := strings.Cut(path, "/")
+		version, ok := findAPIVersion(name)
+		if !ok {
+			ctx.Error(NewNotFoundError(fmt.Sprintf("unsupported API version %s", name)))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Header(APIVersionHeader, version.name)
+		if version.deprecated {
+			ctx.Header("Deprecation", "true")
+		}
+		ctx.Next()
+	}
+}
+
+// addAPIVersionsRoute lists the served versions, so clients can detect
+// which ones they can use before calling them
+func addAPIVersionsRoute(r gin.IRoutes) {
+	resp := apiVersionsResponse{
+		Versions:   make([]string, 0, len(apiVersions)),
+		Latest:     apiVersions[len(apiVersions)-1].name,
+		Deprecated: make([]string, 0),
+	}
+	for _, version := range apiVersions {
+		resp.Versions = append(resp.Versions, version.name)
+		if version.deprecated {
+			resp.Deprecated = append(resp.Deprecated, version.name)
+		}
+	}
+
+	r.GET(strings.TrimSuffix(apiPathPrefix, "/"), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, resp)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewErrorHandlingMiddleware())
+	r.Use(NewAPIVersionMiddleware())
+	addAPIVersionsRoute(r)
+	r.GET("/api/v1/health", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.GET("/v0.1/public/codepush/update_check", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	resp := serve("/api/v1/health")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "v1", resp.Header().Get(APIVersionHeader))
+
+	resp = serve("/api/v2/health")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(
+		t,
+		`{"error": "unsupported API version v2", "code": "not_found", "retryable": false}`,
+		resp.Body.String(),
+	)
+
+	resp = serve("/v0.1/public/codepush/update_check")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get(APIVersionHeader))
+
+	resp = serve("/api")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"versions": ["v1"], "latest": "v1", "deprecated": []}`, resp.Body.String())
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	memorycache "github.com/a-gierczak/paratrooper/internal/cache/memory"
 	rediscache "github.com/a-gierczak/paratrooper/internal/cache/redis"
@@ -12,18 +13,33 @@ type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, ttlSeconds int) error
 	Delete(ctx context.Context, key string) error
+	// DeleteByPrefix deletes all keys starting with the prefix, keys set while it runs may be
+	// kept
+	DeleteByPrefix(ctx context.Context, prefix string) error
 }
 
 type Config struct {
 	Driver   string `env:"CACHE_DRIVER"    validate:"required,oneof=memory redis,default=memory"`
 	RedisURL string `env:"CACHE_REDIS_URL"`
+	// FallbackRetryInterval is how long the in-memory cache is used after Redis failed
+	FallbackRetryInterval time.Duration `env:"CACHE_FALLBACK_RETRY_INTERVAL,default=30s"`
 }
 
 func New(ctx context.Context, config Config) (Cache, error) {
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentCache)
 	if config.Driver == "redis" {
 		log.Info("initializing redis cache")
-		return rediscache.New(config.RedisURL)
+		c, err := rediscache.New(config.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+
+		fallbackCache := NewFallbackCache(c, config.FallbackRetryInterval, log)
+		// the server starts anyway, Redis is tried again after the retry interval
+		if err := c.Ping(ctx); err != nil {
+			fallbackCache.failed(ctx, err)
+		}
+		return fallbackCache, nil
 	}
 
 	log.Info("initializing in-memory cache")
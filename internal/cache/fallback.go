@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	memorycache "github.com/a-gierczak/paratrooper/internal/cache/memory"
+
+	"go.uber.org/zap"
+)
+
+// FallbackCache uses the primary cache, and the in-memory cache of the server while the primary
+// fails, so requests don't fail when Redis is down. The primary is tried again after the retry
+// interval, keys deleted in the meantime are deleted from it first, so invalidations aren't lost.
+type FallbackCache struct {
+	primary       Cache
+	fallback      Cache
+	retryInterval time.Duration
+	log           *zap.Logger
+
+	// fallbackUntil is the unix time in nanoseconds until which the fallback is used
+	fallbackUntil atomic.Int64
+	// fallbacks counts the operations served by the fallback
+	fallbacks atomic.Int64
+
+	mu sync.Mutex
+	// pendingDeletes are the keys deleted while the primary was failing
+	pendingDeletes map[string]struct{}
+	// pendingPrefixDeletes are the prefixes deleted while the primary was failing
+	pendingPrefixDeletes map[string]struct{}
+}
+
+func NewFallbackCache(primary Cache, retryInterval time.Duration, log *zap.Logger) *FallbackCache {
+	return &FallbackCache{
+		primary:              primary,
+		fallback:             memorycache.New(),
+		retryInterval:        retryInterval,
+		log:                  log,
+		pendingDeletes:       make(map[string]struct{}),
+		pendingPrefixDeletes: make(map[string]struct{}),
+	}
+}
+
+// FallingBack is true while the fallback is used
+func (c *FallbackCache) FallingBack() bool {
+	return time.Now().UnixNano() < c.fallbackUntil.Load()
+}
+
+// Fallbacks returns the number of operations served by the fallback since the start
+func (c *FallbackCache) Fallbacks() int64 {
+	return c.fallbacks.Load()
+}
+
+func (c *FallbackCache) fail(err error) {
+	if !c.FallingBack() {
+		c.log.Warn(
+			"cache failed, falling back to in-memory cache",
+			zap.Duration("retry_in", c.retryInterval),
+			zap.Error(err),
+		)
+	}
+	c.fallbackUntil.Store(time.Now().Add(c.retryInterval).UnixNano())
+}
+
+// usePrimary returns false while the fallback is used, or when the keys and prefixes deleted in
+// the meantime can't be deleted from the primary yet
+func (c *FallbackCache) usePrimary(ctx context.Context) bool {
+	if c.FallingBack() {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.pendingDeletes {
+		if err := c.primary.Delete(ctx, key); err != nil {
+			if ctx.Err() == nil {
+				c.fail(err)
+			}
+			return false
+		}
+		delete(c.pendingDeletes, key)
+	}
+	for prefix := range c.pendingPrefixDeletes {
+		if err := c.primary.DeleteByPrefix(ctx, prefix); err != nil {
+			if ctx.Err() == nil {
+				c.fail(err)
+			}
+			return false
+		}
+		delete(c.pendingPrefixDeletes, prefix)
+	}
+	return true
+}
+
+// failed switches to the fallback when the primary failed, errors of canceled requests aren't
+// failures of the primary
+func (c *FallbackCache) failed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	c.fail(err)
+	return true
+}
+
+func (c *FallbackCache) Get(ctx context.Context, key string) (string, error) {
+	if c.usePrimary(ctx) {
+		value, err := c.primary.Get(ctx, key)
+		if !c.failed(ctx, err) {
+			return value, err
+		}
+	}
+	c.fallbacks.Add(1)
+	return c.fallback.Get(ctx, key)
+}
+
+func (c *FallbackCache) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
+	if c.usePrimary(ctx) {
+		err := c.primary.Set(ctx, key, value, ttlSeconds)
+		if !c.failed(ctx, err) {
+			return err
+		}
+	}
+	c.fallbacks.Add(1)
+	return c.fallback.Set(ctx, key, value, ttlSeconds)
+}
+
+// Delete deletes the key from both caches, so the fallback doesn't serve stale values during
+// the next outage
+func (c *FallbackCache) Delete(ctx context.Context, key string) error {
+	if err := c.fallback.Delete(ctx, key); err != nil {
+		return err
+	}
+	if c.usePrimary(ctx) {
+		err := c.primary.Delete(ctx, key)
+		if !c.failed(ctx, err) {
+			return err
+		}
+	}
+
+	c.fallbacks.Add(1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pendingDeletes[key] = struct{}{}
+	return nil
+}
+
+// DeleteByPrefix deletes the keys from both caches like Delete
+func (c *FallbackCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if err := c.fallback.DeleteByPrefix(ctx, prefix); err != nil {
+		return err
+	}
+	if c.usePrimary(ctx) {
+		err := c.primary.DeleteByPrefix(ctx, prefix)
+		if !c.failed(ctx, err) {
+			return err
+		}
+	}
+
+	c.fallbacks.Add(1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pendingPrefixDeletes[prefix] = struct{}{}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memorycache "github.com/a-gierczak/paratrooper/internal/cache/memory"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// flakyCache fails every operation while down
+type flakyCache struct {
+	*memorycache.InMemoryCache
+	down bool
+}
+
+var errDown = errors.New("connection refused")
+
+func (c *flakyCache) Get(ctx context.Context, key string) (string, error) {
+	if c.down {
+		return "", errDown
+	}
+	return c.InMemoryCache.Get(ctx, key)
+}
+
+func (c *flakyCache) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
+	if c.down {
+		return errDown
+	}
+	return c.InMemoryCache.Set(ctx, key, value, ttlSeconds)
+}
+
+func (c *flakyCache) Delete(ctx context.Context, key string) error {
+	if c.down {
+		return errDown
+	}
+	return c.InMemoryCache.Delete(ctx, key)
+}
+
+func (c *flakyCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if c.down {
+		return errDown
+	}
+	return c.InMemoryCache.DeleteByPrefix(ctx, prefix)
+}
+
+func TestFallbackCache(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyCache{InMemoryCache: memorycache.New()}
+	c := NewFallbackCache(primary, time.Hour, zap.NewNop())
+
+	require.NoError(t, c.Set(ctx, "generation", "1", 60))
+	require.NoError(t, c.Set(ctx, "response:old", "cached", 60))
+	require.False(t, c.FallingBack())
+
+	// requests don't fail while the primary is down
+	primary.down = true
+	value, err := c.Get(ctx, "generation")
+	require.NoError(t, err)
+	require.Empty(t, value)
+	require.True(t, c.FallingBack())
+	require.NoError(t, c.Set(ctx, "response", "cached", 60))
+	value, err = c.Get(ctx, "response")
+	require.NoError(t, err)
+	require.Equal(t, "cached", value)
+	require.NoError(t, c.Delete(ctx, "generation"))
+	require.NoError(t, c.DeleteByPrefix(ctx, "resp"))
+	value, err = c.Get(ctx, "response")
+	require.NoError(t, err)
+	require.Empty(t, value)
+	require.Equal(t, int64(6), c.Fallbacks())
+
+	// the keys deleted during the outage are deleted from the primary once it's back
+	primary.down = false
+	c.fallbackUntil.Store(0)
+	value, err = c.Get(ctx, "generation")
+	require.NoError(t, err)
+	require.Empty(t, value)
+	require.False(t, c.FallingBack())
+	require.Empty(t, c.pendingDeletes)
+	require.Empty(t, c.pendingPrefixDeletes)
+	value, err = primary.Get(ctx, "response:old")
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	// canceled requests don't switch to the fallback
+	primary.down = true
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = c.Get(canceledCtx, "generation")
+	require.ErrorIs(t, err, errDown)
+	require.False(t, c.FallingBack())
+}
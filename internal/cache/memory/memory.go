@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -34,3 +35,12 @@ func (m *InMemoryCache) Delete(ctx context.Context, key string) error {
 	m.c.Delete(key)
 	return nil
 }
+
+func (m *InMemoryCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	for key := range m.c.Items() {
+		if strings.HasPrefix(key, prefix) {
+			m.c.Delete(key)
+		}
+	}
+	return nil
+}
@@ -2,11 +2,18 @@ package redis
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// scanBatchSize is the number of keys DeleteByPrefix asks for in every SCAN call
+const scanBatchSize = 1000
+
+// globEscaper escapes the special characters of the SCAN match pattern
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
 type RedisCache struct {
 	client *redis.Client
 }
@@ -38,3 +45,29 @@ func (r *RedisCache) Set(ctx context.Context, key string, value string, ttlSecon
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
+
+// DeleteByPrefix scans the keys instead of running KEYS, so Redis isn't blocked, and unlinks
+// them in batches
+func (r *RedisCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	match := globEscaper.Replace(prefix) + "*"
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, match, scanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
@@ -0,0 +1,296 @@
+// Package clientcheck checks for an update the way an Expo or CodePush client does, so the
+// decision of a running server can be inspected without building an app
+package clientcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+	"github.com/a-gierczak/paratrooper/internal/expo"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProtocolExpo     = "expo"
+	ProtocolCodePush = "codepush"
+)
+
+type Config struct {
+	// BaseURL of the server, e.g. http://localhost:8080
+	BaseURL        string
+	ProjectID      uuid.UUID
+	Protocol       string
+	Platform       string
+	RuntimeVersion string
+	Channel        string
+	// Current is the update the client runs, the update ID for Expo and the package hash
+	// for CodePush, empty for the embedded update
+	Current string
+	// ClientID puts the client in a rollout bucket, clients without an ID are outside rollouts
+	ClientID string
+}
+
+func (c *Config) validate() error {
+	if c.BaseURL == "" {
+		return errors.New("base URL is required")
+	}
+	if c.ProjectID == uuid.Nil {
+		return errors.New("project ID is required")
+	}
+	if c.Protocol != ProtocolExpo && c.Protocol != ProtocolCodePush {
+		return fmt.Errorf("protocol must be %s or %s", ProtocolExpo, ProtocolCodePush)
+	}
+	if c.Platform == "" || c.RuntimeVersion == "" || c.Channel == "" {
+		return errors.New("platform, runtime version and channel are required")
+	}
+	return nil
+}
+
+// Asset of the update to download
+type Asset struct {
+	// Key of the asset in the manifest, the archive for CodePush
+	Key string
+	URL string
+	// Hash is the base64url encoded SHA-256 of Expo assets, the package hash for CodePush
+	Hash string
+	// Headers the client sends when downloading the asset
+	Headers map[string]string
+	// Size of the CodePush package, unknown for Expo assets
+	Size int64
+}
+
+// Result of the update check
+type Result struct {
+	Protocol string
+	// Decision is one of the analytics decisions
+	Decision string
+	UpdateID string
+	// CreatedAt of Expo updates
+	CreatedAt string
+	// Description of CodePush updates
+	Description string
+	// Signed is set when the Expo response part had a signature
+	Signed bool
+	// SuggestBinaryUpdate is set when the CodePush server suggests a newer binary
+	SuggestBinaryUpdate bool
+	// Assets with the launch asset first, the package for CodePush
+	Assets []Asset
+}
+
+// Check sends the update check and parses the response
+func Check(ctx context.Context, client *http.Client, config Config) (*Result, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if config.Protocol == ProtocolExpo {
+		return checkExpo(ctx, client, config)
+	}
+	return checkCodePush(ctx, client, config)
+}
+
+func checkExpo(ctx context.Context, client *http.Client, config Config) (*Result, error) {
+	requestURL := fmt.Sprintf("%s/api/v1/public/%s/expo", config.BaseURL, config.ProjectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "multipart/mixed")
+	req.Header.Set("Expo-Protocol-Version", "1")
+	req.Header.Set("Expo-Platform", config.Platform)
+	req.Header.Set("Expo-Runtime-Version", config.RuntimeVersion)
+	req.Header.Set("Expo-Channel-Name", config.Channel)
+	if config.Current != "" {
+		req.Header.Set("Expo-Current-Update-Id", config.Current)
+	}
+	if config.ClientID != "" {
+		req.Header.Set("EAS-Client-ID", config.ClientID)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("expected a multipart response, got %s", mediaType)
+	}
+
+	result := &Result{Protocol: ProtocolExpo}
+	var manifest *expo.Manifest
+	var extensions expo.ManifestExtensions
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response part: %w", err)
+		}
+
+		switch part.FormName() {
+		case "manifest":
+			manifest = &expo.Manifest{}
+			if err := json.NewDecoder(part).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			result.Signed = part.Header.Get("expo-signature") != ""
+		case "directive":
+			var directive struct {
+				Type string `json:"type"`
+			}
+			if err := json.NewDecoder(part).Decode(&directive); err != nil {
+				return nil, fmt.Errorf("invalid directive: %w", err)
+			}
+			result.Signed = part.Header.Get("expo-signature") != ""
+			switch directive.Type {
+			case "noUpdateAvailable":
+				result.Decision = analytics.DecisionNoUpdate
+			case "rollBackToEmbedded":
+				result.Decision = analytics.DecisionRollBackToEmbedded
+			default:
+				return nil, fmt.Errorf("unknown directive %q", directive.Type)
+			}
+		case "extensions":
+			if err := json.NewDecoder(part).Decode(&extensions); err != nil {
+				return nil, fmt.Errorf("invalid extensions: %w", err)
+			}
+		}
+	}
+
+	if manifest == nil {
+		if result.Decision == "" {
+			return nil, errors.New("response has neither a manifest nor a directive")
+		}
+		return result, nil
+	}
+
+	result.Decision = analytics.DecisionUpdate
+	result.UpdateID = manifest.Id
+	result.CreatedAt = manifest.CreatedAt
+	manifestAssets := append([]expo.ManifestAsset{manifest.LaunchAsset}, manifest.Assets...)
+	for _, manifestAsset := range manifestAssets {
+		result.Assets = append(result.Assets, Asset{
+			Key:     manifestAsset.Key,
+			URL:     manifestAsset.Url,
+			Hash:    manifestAsset.Hash,
+			Headers: extensions.AssetRequestHeaders[manifestAsset.Key],
+		})
+	}
+	return result, nil
+}
+
+func checkCodePush(ctx context.Context, client *http.Client, config Config) (*Result, error) {
+	query := url.Values{}
+	query.Set("app_version", config.RuntimeVersion)
+	query.Set(
+		"deployment_key",
+		fmt.Sprintf("%s/%s/%s", config.ProjectID, config.Platform, config.Channel),
+	)
+	if config.Current != "" {
+		query.Set("package_hash", config.Current)
+	}
+	if config.ClientID != "" {
+		query.Set("client_unique_id", config.ClientID)
+	}
+	requestURL := config.BaseURL + "/v0.1/public/codepush/update_check?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	var body api.GetCodePushUpdate200JSONResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	info := body.UpdateInfo
+
+	result := &Result{
+		Protocol:            ProtocolCodePush,
+		Decision:            analytics.DecisionNoUpdate,
+		SuggestBinaryUpdate: info.UpdateAppVersion,
+	}
+	if !info.IsAvailable {
+		return result, nil
+	}
+
+	result.Decision = analytics.DecisionUpdate
+	result.UpdateID = info.Label
+	if info.Description != nil {
+		result.Description = *info.Description
+	}
+	result.Assets = []Asset{{
+		Key:  config.Platform + ".zip",
+		URL:  info.DownloadURL,
+		Hash: info.PackageHash,
+		Size: int64(info.PackageSize),
+	}}
+	return result, nil
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("server responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+func (r *Result) Print(w io.Writer) {
+	fmt.Fprintf(w, "protocol  %s\n", r.Protocol)
+	fmt.Fprintf(w, "decision  %s\n", r.Decision)
+	if r.Protocol == ProtocolExpo {
+		fmt.Fprintf(w, "signed    %t\n", r.Signed)
+	}
+	if r.SuggestBinaryUpdate {
+		fmt.Fprintln(w, "binary    newer binary suggested")
+	}
+	if r.Decision != analytics.DecisionUpdate {
+		return
+	}
+
+	fmt.Fprintf(w, "update    %s\n", r.UpdateID)
+	if r.CreatedAt != "" {
+		fmt.Fprintf(w, "created   %s\n", r.CreatedAt)
+	}
+	if r.Description != "" {
+		fmt.Fprintf(w, "message   %s\n", r.Description)
+	}
+	fmt.Fprintf(w, "assets    %d\n", len(r.Assets))
+	for i, asset := range r.Assets {
+		label := "  asset "
+		if i == 0 {
+			label = "  launch"
+		}
+		fmt.Fprintf(w, "%s  %s hash=%s", label, asset.Key, asset.Hash)
+		if asset.Size > 0 {
+			fmt.Fprintf(w, " size=%d", asset.Size)
+		}
+		fmt.Fprintln(w)
+	}
+}
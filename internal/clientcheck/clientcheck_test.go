@@ -0,0 +1,127 @@
+package clientcheck
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var projectID = uuid.MustParse("019393ed-5085-71ec-943a-1c71617a6282")
+
+func writeJSONPart(t *testing.T, w *multipart.Writer, name string, payload any) {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": []string{"form-data; name=" + name},
+		"Content-Type":        []string{"application/json"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(part).Encode(payload))
+}
+
+func TestCheckExpo(t *testing.T) {
+	bundle := []byte("console.log('hello')")
+	hash := sha256.Sum256(bundle)
+	bundleHash := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bundle" {
+			require.Equal(t, "session=1", r.Header.Get("Cookie"))
+			w.Write(bundle)
+			return
+		}
+
+		require.Equal(t, fmt.Sprintf("/api/v1/public/%s/expo", projectID), r.URL.Path)
+		require.Equal(t, "ios", r.Header.Get("Expo-Platform"))
+		require.Equal(t, "1.2.0", r.Header.Get("Expo-Runtime-Version"))
+		require.Equal(t, "staging", r.Header.Get("Expo-Channel-Name"))
+
+		body := new(bytes.Buffer)
+		mw := multipart.NewWriter(body)
+		if r.Header.Get("Expo-Current-Update-Id") != "" {
+			writeJSONPart(t, mw, "directive", map[string]string{"type": "noUpdateAvailable"})
+		} else {
+			writeJSONPart(t, mw, "manifest", map[string]any{
+				"id":             "0193a0f7-ba7d-742a-a9f6-3a14263f41f1",
+				"createdAt":      "2024-12-01T10:00:00Z",
+				"runtimeVersion": "1.2.0",
+				"launchAsset": map[string]string{
+					"hash": bundleHash,
+					"key":  "bundle-md5",
+					"url":  server.URL + "/bundle",
+				},
+				"assets": []any{},
+			})
+			writeJSONPart(t, mw, "extensions", map[string]any{
+				"assetRequestHeaders": map[string]any{
+					"bundle-md5": map[string]string{"Cookie": "session=1"},
+				},
+			})
+		}
+		require.NoError(t, mw.Close())
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		w.Write(body.Bytes())
+	}))
+	defer server.Close()
+
+	config := Config{
+		BaseURL:        server.URL,
+		ProjectID:      projectID,
+		Protocol:       ProtocolExpo,
+		Platform:       "ios",
+		RuntimeVersion: "1.2.0",
+		Channel:        "staging",
+	}
+	result, err := Check(context.Background(), server.Client(), config)
+	require.NoError(t, err)
+	require.Equal(t, analytics.DecisionUpdate, result.Decision)
+	require.Equal(t, "0193a0f7-ba7d-742a-a9f6-3a14263f41f1", result.UpdateID)
+	require.Len(t, result.Assets, 1)
+
+	checks := Verify(context.Background(), server.Client(), result)
+	require.Len(t, checks, 1)
+	require.Empty(t, checks[0].Error)
+	require.Equal(t, int64(len(bundle)), checks[0].Size)
+
+	config.Current = result.UpdateID
+	result, err = Check(context.Background(), server.Client(), config)
+	require.NoError(t, err)
+	require.Equal(t, analytics.DecisionNoUpdate, result.Decision)
+}
+
+func TestVerifyPackage(t *testing.T) {
+	body := new(bytes.Buffer)
+	zw := zip.NewWriter(body)
+	w, err := zw.Create("CodePush/index.android.bundle")
+	require.NoError(t, err)
+	w.Write([]byte("bundle"))
+	require.NoError(t, zw.Close())
+
+	fileHash := sha256.Sum256([]byte("bundle"))
+	manifest, err := json.Marshal([]string{fmt.Sprintf("CodePush/index.android.bundle:%x", fileHash)})
+	require.NoError(t, err)
+	packageHash := fmt.Sprintf("%x", sha256.Sum256(manifest))
+
+	diff, err := verifyPackage(body.Bytes(), Asset{Hash: packageHash, Size: int64(body.Len())})
+	require.NoError(t, err)
+	require.False(t, diff)
+
+	_, err = verifyPackage(body.Bytes(), Asset{Hash: "other"})
+	require.ErrorContains(t, err, "package hash mismatch")
+
+	_, err = verifyPackage(body.Bytes(), Asset{Hash: packageHash, Size: 1})
+	require.ErrorContains(t, err, "size mismatch")
+}
@@ -0,0 +1,141 @@
+package clientcheck
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// diffManifestFileName marks a CodePush diff package, its hash can't be verified without the
+// files of the release the client runs
+const diffManifestFileName = "hotcodepush.json"
+
+// AssetCheck is the outcome of downloading an asset
+type AssetCheck struct {
+	Key  string
+	Size int64
+	// Error is empty when the asset was downloaded and its hash matches
+	Error string
+	// Diff is set for CodePush diff packages, only their size is verified
+	Diff bool
+}
+
+// Verify downloads the assets of the update with the headers the client would send, and
+// compares them with the hashes of the manifest or the CodePush package hash
+func Verify(ctx context.Context, client *http.Client, result *Result) []AssetCheck {
+	checks := make([]AssetCheck, 0, len(result.Assets))
+	for _, asset := range result.Assets {
+		check := AssetCheck{Key: asset.Key}
+		content, err := download(ctx, client, asset)
+		if err != nil {
+			check.Error = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		check.Size = int64(len(content))
+
+		if result.Protocol == ProtocolCodePush {
+			check.Diff, err = verifyPackage(content, asset)
+		} else {
+			err = verifyExpoAsset(content, asset)
+		}
+		if err != nil {
+			check.Error = err.Error()
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func download(ctx context.Context, client *http.Client, asset Asset) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range asset.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func verifyExpoAsset(content []byte, asset Asset) error {
+	hash := sha256.Sum256(content)
+	if actual := base64.RawURLEncoding.EncodeToString(hash[:]); actual != asset.Hash {
+		return fmt.Errorf("hash mismatch, got %s", actual)
+	}
+	return nil
+}
+
+// verifyPackage compares the hash of the files of the package with the package hash, like
+// the CodePush client does after applying a diff
+func verifyPackage(content []byte, asset Asset) (bool, error) {
+	if asset.Size > 0 && int64(len(content)) != asset.Size {
+		return false, fmt.Errorf("size mismatch, got %d bytes", len(content))
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return false, fmt.Errorf("invalid package: %w", err)
+	}
+
+	tokens := make([]string, 0, len(zipReader.File))
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if file.Name == diffManifestFileName {
+			return true, nil
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return false, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		hash := sha256.New()
+		_, err = io.Copy(hash, reader)
+		reader.Close()
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+		tokens = append(tokens, fmt.Sprintf("%s:%x", file.Name, hash.Sum(nil)))
+	}
+	slices.Sort(tokens)
+
+	manifest, err := json.Marshal(tokens)
+	if err != nil {
+		return false, err
+	}
+	if actual := fmt.Sprintf("%x", sha256.Sum256(manifest)); !strings.EqualFold(actual, asset.Hash) {
+		return false, fmt.Errorf("package hash mismatch, got %s", actual)
+	}
+	return false, nil
+}
+
+func PrintChecks(w io.Writer, checks []AssetCheck) {
+	for _, check := range checks {
+		status := "ok"
+		if check.Error != "" {
+			status = "FAILED " + check.Error
+		} else if check.Diff {
+			status = "ok (diff package, hash not verified)"
+		}
+		fmt.Fprintf(w, "  %s size=%d %s\n", check.Key, check.Size, status)
+	}
+}
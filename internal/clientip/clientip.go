@@ -0,0 +1,55 @@
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const ContextKey = "client_ip"
+
+type Config struct {
+	// TrustedProxies is a comma separated list of IPs or CIDRs of the load balancers
+	// allowed to set X-Forwarded-For / X-Real-IP, when empty the headers are ignored
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+	// TrustedPlatform is a header set by the platform with the client IP, e.g. CF-Connecting-IP
+	TrustedPlatform string `env:"TRUSTED_PLATFORM"`
+}
+
+// Configure sets up which proxies gin trusts when resolving the client IP
+func Configure(engine *gin.Engine, config Config) error {
+	var trustedProxies []string
+	for _, proxy := range strings.Split(config.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	if err := engine.SetTrustedProxies(trustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	engine.RemoteIPHeaders = []string{"X-Forwarded-For", "X-Real-IP"}
+	engine.TrustedPlatform = config.TrustedPlatform
+
+	return nil
+}
+
+// NewMiddleware stores the resolved client IP in the request context and adds it to the logger
+func NewMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ip := ctx.ClientIP()
+		ctx.Set(ContextKey, ip)
+		ctx.Set(logger.ContextKey, logger.FromContext(ctx).With(zap.String("client_ip", ip)))
+		ctx.Next()
+	}
+}
+
+func FromContext(c context.Context) string {
+	ip, _ := c.Value(ContextKey).(string)
+	return ip
+}
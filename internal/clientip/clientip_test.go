@@ -0,0 +1,63 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func resolveClientIP(t *testing.T, config Config, headers map[string]string) string {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	require.NoError(t, Configure(r, config))
+	r.Use(logger.NewMiddleware(zap.NewNop()))
+	r.Use(NewMiddleware())
+
+	var ip string
+	r.GET("/", func(ctx *gin.Context) {
+		ip = FromContext(ctx)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	return ip
+}
+
+func TestClientIP(t *testing.T) {
+	forwarded := map[string]string{"X-Forwarded-For": "203.0.113.7, 10.0.0.1"}
+
+	t.Run("forwarded headers are ignored without trusted proxies", func(t *testing.T) {
+		require.Equal(t, "10.0.0.1", resolveClientIP(t, Config{}, forwarded))
+	})
+
+	t.Run("forwarded headers of a trusted proxy", func(t *testing.T) {
+		config := Config{TrustedProxies: "10.0.0.0/8, 192.168.0.1"}
+		require.Equal(t, "203.0.113.7", resolveClientIP(t, config, forwarded))
+		require.Equal(
+			t,
+			"203.0.113.8",
+			resolveClientIP(t, config, map[string]string{"X-Real-IP": "203.0.113.8"}),
+		)
+	})
+
+	t.Run("trusted platform header", func(t *testing.T) {
+		config := Config{TrustedPlatform: "CF-Connecting-IP"}
+		headers := map[string]string{"CF-Connecting-IP": "203.0.113.9"}
+		require.Equal(t, "203.0.113.9", resolveClientIP(t, config, headers))
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		require.Error(t, Configure(gin.New(), Config{TrustedProxies: "not-an-ip"}))
+	})
+}
@@ -0,0 +1,81 @@
+package codepush
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// statuses of the installs reported by the CodePush clients
+const (
+	DeploymentSucceeded = "DeploymentSucceeded"
+	DeploymentFailed    = "DeploymentFailed"
+)
+
+// ReportDownload counts the download of the release, reports of labels that aren't updates
+// of the project are ignored
+func (svc *service) ReportDownload(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	label string,
+) error {
+	params := db.IncrementCodePushReleaseStatsParams{Downloads: 1}
+	return svc.incrementReleaseStats(ctx, projectID, channel, label, params)
+}
+
+// ReportDeployment counts the install of the release, reports of the binary have no label and
+// are ignored like the labels that aren't updates of the project
+func (svc *service) ReportDeployment(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	label string,
+	status string,
+) error {
+	var params db.IncrementCodePushReleaseStatsParams
+	switch status {
+	case DeploymentSucceeded:
+		params.DeploymentsSucceeded = 1
+	case DeploymentFailed:
+		params.DeploymentsFailed = 1
+	default:
+		return nil
+	}
+	return svc.incrementReleaseStats(ctx, projectID, channel, label, params)
+}
+
+func (svc *service) incrementReleaseStats(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	label string,
+	params db.IncrementCodePushReleaseStatsParams,
+) error {
+	if label == "" {
+		return nil
+	}
+	// the label of the releases is the update ID, unless the update was imported from CodePush
+	updateID, err := uuid.Parse(label)
+	if err != nil {
+		updateID, err = svc.q.GetUpdateIDByCodePushLabel(ctx, projectID, channel, label)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("GetUpdateIDByCodePushLabel: %w", err)
+		}
+	}
+
+	params.UpdateID = updateID
+	params.ProjectID = projectID
+	if err := svc.q.IncrementCodePushReleaseStats(ctx, params); err != nil {
+		return fmt.Errorf("IncrementCodePushReleaseStats: %w", err)
+	}
+	return nil
+}
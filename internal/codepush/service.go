@@ -2,20 +2,41 @@ package codepush
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 	"github.com/a-gierczak/paratrooper/generated/db"
 	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"gocloud.dev/blob"
 )
 
 type Service interface {
 	UpdateToInstall(
 		ctx context.Context,
+		project db.Project,
 		update db.Update,
 		platform string,
+		clientPackageHash *string,
 	) (*api.CodePushUpdate, error)
+	// NoUpdate is the response when no update matches the client
+	NoUpdate(project db.Project) *api.CodePushUpdate
+	// ReportDownload counts the download of the release reported by the client
+	ReportDownload(ctx context.Context, projectID uuid.UUID, channel string, label string) error
+	// ReportDeployment counts the install of the release reported by the client
+	ReportDeployment(
+		ctx context.Context,
+		projectID uuid.UUID,
+		channel string,
+		label string,
+		status string,
+	) error
 }
 
 type service struct {
@@ -29,35 +50,245 @@ func NewService(q *db.Queries, st *storage.Storage) Service {
 
 func (svc *service) UpdateToInstall(
 	ctx context.Context,
+	project db.Project,
 	update db.Update,
 	platform string,
+	clientPackageHash *string,
 ) (*api.CodePushUpdate, error) {
 	asset, err := svc.q.GetLaunchAssetOrArchiveByPlatform(ctx, update.ID, platform)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get asset from db: %w", err)
 	}
 
-	assetURL, err := svc.storage.Bucket().
-		SignedURL(ctx, asset.StorageObjectPath, &blob.SignedURLOptions{
-			Method: "GET",
-			Expiry: storage.DownloadURLExpiry,
-		})
+	replica, err := svc.downloadReplica(ctx, project, update)
+	if err != nil {
+		return nil, err
+	}
+	// CodePush clients don't send any request headers with the package download
+	strategy := svc.storage.AssetURLStrategyFor(project, replica, false)
+
+	// the package hash stays the hash of the full package, the client ends up with its files
+	download := asset
+	// asset pipelines serving templated URLs and the stable asset route only know the full
+	// packages
+	if clientPackageHash != nil && asset.IsArchive && strategy != storage.AssetURLTemplated &&
+		strategy != storage.AssetURLStable {
+		diff, err := svc.diffPackage(ctx, update, platform, *clientPackageHash)
+		if err != nil {
+			return nil, err
+		}
+		if diff != nil {
+			download = *diff
+		}
+	}
 
+	assetURL, err := svc.downloadURL(ctx, project, strategy, replica, download)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign asset download URL: %w", err)
+		return nil, err
+	}
+
+	mandatory, err := svc.isMandatory(ctx, update, platform, clientPackageHash)
+	if err != nil {
+		return nil, err
 	}
 
 	return &api.CodePushUpdate{
 		AppVersion:             update.RuntimeVersion,
-		Description:            &update.Message.String,
+		Description:            description(project, update),
 		DownloadURL:            assetURL,
 		IsAvailable:            true,
-		IsMandatory:            true,
-		Label:                  update.ID.String(),
+		IsMandatory:            mandatory,
+		Label:                  label(update),
 		PackageHash:            asset.ContentSha256,
-		PackageSize:            int(asset.ContentLength),
+		PackageSize:            int(download.ContentLength),
 		ShouldRunBinaryVersion: false,
 		TargetBinaryRange:      update.RuntimeVersion,
 		UpdateAppVersion:       false,
 	}, nil
 }
+
+// isMandatory reports whether the client has to install the update right away, like
+// code-push-server it's mandatory also when the client skips a mandatory update to get it
+func (svc *service) isMandatory(
+	ctx context.Context,
+	update db.Update,
+	platform string,
+	clientPackageHash *string,
+) (bool, error) {
+	if update.IsMandatory || !update.CommittedAt.Valid {
+		return update.IsMandatory, nil
+	}
+
+	params := db.HasSkippedMandatoryUpdateParams{
+		ProjectID:      update.ProjectID,
+		Channel:        update.Channel,
+		RuntimeVersion: update.RuntimeVersion,
+		CommittedAt:    update.CommittedAt,
+		Platform:       platform,
+	}
+	if clientPackageHash != nil {
+		params.PackageHash = *clientPackageHash
+	}
+	skipped, err := svc.q.HasSkippedMandatoryUpdate(ctx, params)
+	if err != nil {
+		return false, fmt.Errorf("HasSkippedMandatoryUpdate: %w", err)
+	}
+	return skipped, nil
+}
+
+// diffPackage returns the diff package of the update for the clients running the package,
+// as an archive asset, nil when there's none
+func (svc *service) diffPackage(
+	ctx context.Context,
+	update db.Update,
+	platform string,
+	clientPackageHash string,
+) (*db.UpdateAsset, error) {
+	// linked updates serve the packages of the update they were published with
+	updateID := update.ID
+	if update.LinkedUpdateID.Valid {
+		updateID = update.LinkedUpdateID.Bytes
+	}
+
+	diff, err := svc.q.GetCodePushDiffPackage(ctx, updateID, platform, clientPackageHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff package from db: %w", err)
+	}
+
+	return &db.UpdateAsset{
+		UpdateID:          updateID,
+		StorageObjectPath: diff.StorageObjectPath,
+		ContentType:       "application/zip",
+		Extension:         ".zip",
+		ContentMd5:        diff.ContentMd5,
+		IsArchive:         true,
+		Platform:          platform,
+		ContentLength:     diff.ContentLength,
+	}, nil
+}
+
+// NoUpdate keeps the client on the binary's bundle, suggesting a newer binary when the project
+// is configured to
+func (svc *service) NoUpdate(project db.Project) *api.CodePushUpdate {
+	return &api.CodePushUpdate{
+		DownloadURL:            "",
+		Description:            util.StringPtr(""),
+		IsAvailable:            false,
+		IsMandatory:            false,
+		AppVersion:             "",
+		PackageHash:            "",
+		Label:                  "",
+		PackageSize:            0,
+		UpdateAppVersion:       project.CodepushSuggestBinaryUpdate,
+		ShouldRunBinaryVersion: true,
+	}
+}
+
+// label of the release, the update ID unless the update was imported from CodePush with
+// its label
+func label(update db.Update) string {
+	if update.CodepushLabel.Valid {
+		return update.CodepushLabel.String
+	}
+	return update.ID.String()
+}
+
+// description of the update shown by apps prompting to install it
+func description(project db.Project, update db.Update) *string {
+	if api.CodePushDescriptionSource(project.CodepushDescriptionSource) == api.None {
+		return util.StringPtr("")
+	}
+	return &update.Message.String
+}
+
+func (svc *service) downloadURL(
+	ctx context.Context,
+	project db.Project,
+	strategy storage.AssetURLStrategy,
+	replica *storage.Replica,
+	asset db.UpdateAsset,
+) (string, error) {
+	objectKey := asset.StorageObjectPath
+	switch strategy {
+	case storage.AssetURLTemplated:
+		_, _, filePath := storage.AssetObjectKeySegments(objectKey)
+		if asset.IsArchive {
+			filePath = path.Base(objectKey)
+		}
+		// linked updates and diff packages are stored under the update they were made for
+		projectID, updateID, err := storage.UpdateObjectKeyIDs(objectKey)
+		if err != nil {
+			return "", err
+		}
+
+		return storage.ExpandAssetURLTemplate(project.AssetUrlTemplate.String, storage.AssetURLVars{
+			ProjectID: projectID,
+			UpdateID:  updateID,
+			ObjectKey: objectKey,
+			Path:      filePath,
+			SHA256:    asset.ContentSha256,
+			MD5:       asset.ContentMd5,
+		}), nil
+	case storage.AssetURLPublic:
+		assetURL, err := storage.PublicObjectURL(project.PublicAssetsUrl.String, objectKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to build public asset URL: %w", err)
+		}
+		return assetURL, nil
+	case storage.AssetURLStable:
+		return svc.storage.StableAssetURL(asset.ID), nil
+	case storage.AssetURLEdge:
+		assetURL, err := svc.storage.EdgeURL(ctx, objectKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign edge download URL: %w", err)
+		}
+		return assetURL, nil
+	}
+
+	bucket := svc.storage.Bucket()
+	if strategy == storage.AssetURLReplica {
+		bucket = replica.Bucket()
+	}
+
+	assetURL, err := bucket.
+		SignedURL(ctx, objectKey, &blob.SignedURLOptions{
+			Method: "GET",
+			Expiry: storage.SignedDownloadURLExpiry(),
+		})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign asset download URL: %w", err)
+	}
+
+	return assetURL, nil
+}
+
+// downloadReplica returns the replica the client downloads the package of the update from, nil
+// when the update wasn't copied to the replica of the client's region, e.g. when the region was
+// added to the project after the update was published
+func (svc *service) downloadReplica(
+	ctx context.Context,
+	project db.Project,
+	update db.Update,
+) (*storage.Replica, error) {
+	replica := svc.storage.DownloadReplica(ctx, project.ReplicaRegions)
+	if replica == nil {
+		return nil, nil
+	}
+
+	// linked updates serve the packages of the update they were published with
+	updateID := update.ID
+	if update.LinkedUpdateID.Valid {
+		updateID = update.LinkedUpdateID.Bytes
+	}
+	regions, err := svc.q.GetUpdateReplicaRegions(ctx, updateID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpdateReplicaRegions: %w", err)
+	}
+	if !slices.Contains(regions, replica.Region) {
+		return nil, nil
+	}
+	return replica, nil
+}
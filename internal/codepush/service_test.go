@@ -0,0 +1,83 @@
+package codepush
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoUpdate(t *testing.T) {
+	svc := &service{}
+
+	info := svc.NoUpdate(db.Project{})
+	require.False(t, info.IsAvailable)
+	require.True(t, info.ShouldRunBinaryVersion)
+	require.False(t, info.UpdateAppVersion)
+
+	info = svc.NoUpdate(db.Project{CodepushSuggestBinaryUpdate: true})
+	require.True(t, info.UpdateAppVersion)
+	require.True(t, info.ShouldRunBinaryVersion)
+}
+
+func TestDescription(t *testing.T) {
+	update := db.Update{Message: pgtype.Text{String: "fix login", Valid: true}}
+
+	project := db.Project{CodepushDescriptionSource: string(api.Message)}
+	require.Equal(t, "fix login", *description(project, update))
+
+	project.CodepushDescriptionSource = string(api.None)
+	require.Empty(t, *description(project, update))
+}
+
+func TestIsMandatory(t *testing.T) {
+	svc := &service{}
+	ctx := context.Background()
+
+	mandatory, err := svc.isMandatory(ctx, db.Update{IsMandatory: true}, "ios", nil)
+	require.NoError(t, err)
+	require.True(t, mandatory)
+
+	// updates published before the commit time was recorded have nothing to skip
+	mandatory, err = svc.isMandatory(ctx, db.Update{}, "ios", nil)
+	require.NoError(t, err)
+	require.False(t, mandatory)
+}
+
+func TestDownloadURLTemplate(t *testing.T) {
+	svc := &service{}
+	ctx := context.Background()
+	project := db.Project{
+		ID: uuid.New(),
+		AssetUrlTemplate: pgtype.Text{
+			String: "https://assets.example.com/{project}/{update}/{path}",
+			Valid:  true,
+		},
+	}
+	// a linked update serves the package of the update it was published with
+	publishedID := uuid.New()
+	update := db.Update{
+		ID:             uuid.New(),
+		ProjectID:      project.ID,
+		LinkedUpdateID: pgtype.UUID{Bytes: publishedID, Valid: true},
+	}
+	asset := db.UpdateAsset{
+		UpdateID:          update.ID,
+		StorageObjectPath: storage.ArchiveObjectKey(project.ID, publishedID, "ios"),
+		IsArchive:         true,
+	}
+
+	assetURL, err := svc.downloadURL(ctx, project, storage.AssetURLTemplated, nil, asset)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"https://assets.example.com/"+project.ID.String()+"/"+publishedID.String()+"/ios.zip",
+		assetURL,
+	)
+}
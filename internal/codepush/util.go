@@ -3,31 +3,53 @@ package codepush
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 )
 
+// platforms supported by the CodePush clients, including react-native-windows and react-native-macos
+var platforms = []string{"android", "ios", "windows", "macos"}
+
+// ErrUnsupportedPlatform is returned for deployment keys of platforms CodePush doesn't support
+var ErrUnsupportedPlatform = fmt.Errorf("unsupported platform, expected one of %v", platforms)
+
+// flavorSeparator separates the project ID and the flavor in the deployment keys of flavors
+const flavorSeparator = "."
+
+// ParseDeploymentKey parses projectID/platform/channel deployment keys, the project ID of
+// flavors' keys is followed by the flavor, e.g. projectID.flavor/platform/channel
 func ParseDeploymentKey(
 	deploymentKey string,
-) (projectID uuid.UUID, platform, channel string, err error) {
+) (projectID uuid.UUID, flavor, platform, channel string, err error) {
 	decoded, err := url.QueryUnescape(deploymentKey)
 	if err != nil {
-		return uuid.Nil, "", "", fmt.Errorf("failed to decode deployment key: %w", err)
+		return uuid.Nil, "", "", "", fmt.Errorf("failed to decode deployment key: %w", err)
 	}
 
 	parts := strings.SplitN(decoded, "/", 3)
 	if len(parts) != 3 {
-		return uuid.Nil, "", "", fmt.Errorf(
+		return uuid.Nil, "", "", "", fmt.Errorf(
 			"invalid deployment key format, expected projectID/platform/channel, got: %s",
 			decoded,
 		)
 	}
 
-	projectID, err = uuid.Parse(parts[0])
+	projectPart, flavor, hasFlavor := strings.Cut(parts[0], flavorSeparator)
+	if hasFlavor && flavor == "" {
+		return uuid.Nil, "", "", "", fmt.Errorf("empty flavor, got: %s", decoded)
+	}
+
+	projectID, err = uuid.Parse(projectPart)
 	if err != nil {
-		return uuid.Nil, "", "", fmt.Errorf("invalid project id: %w", err)
+		return uuid.Nil, "", "", "", fmt.Errorf("invalid project id: %w", err)
+	}
+
+	platform = strings.ToLower(parts[1])
+	if !slices.Contains(platforms, platform) {
+		return uuid.Nil, "", "", "", fmt.Errorf("%w, got: %s", ErrUnsupportedPlatform, parts[1])
 	}
 
-	return projectID, parts[1], parts[2], nil
+	return projectID, flavor, platform, parts[2], nil
 }
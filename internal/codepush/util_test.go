@@ -0,0 +1,45 @@
+package codepush
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeploymentKey(t *testing.T) {
+	projectID := uuid.MustParse("0193a0f7-ba7d-742a-a9f6-3a14263f41f0")
+
+	for _, platform := range []string{"android", "ios", "windows", "macos"} {
+		parsedID, flavor, parsedPlatform, channel, err := ParseDeploymentKey(
+			projectID.String() + "/" + platform + "/production",
+		)
+		require.NoError(t, err)
+		require.Equal(t, projectID, parsedID)
+		require.Empty(t, flavor)
+		require.Equal(t, platform, parsedPlatform)
+		require.Equal(t, "production", channel)
+	}
+
+	_, _, platform, _, err := ParseDeploymentKey(projectID.String() + "%2FWindows%2Fstaging")
+	require.NoError(t, err)
+	require.Equal(t, "windows", platform)
+
+	_, _, _, _, err = ParseDeploymentKey(projectID.String() + "/web/production")
+	require.ErrorIs(t, err, ErrUnsupportedPlatform)
+
+	_, _, _, _, err = ParseDeploymentKey("not-a-uuid/ios/production")
+	require.Error(t, err)
+
+	parsedID, flavor, platform, channel, err := ParseDeploymentKey(
+		projectID.String() + ".acme-bank/ios/staging",
+	)
+	require.NoError(t, err)
+	require.Equal(t, projectID, parsedID)
+	require.Equal(t, "acme-bank", flavor)
+	require.Equal(t, "ios", platform)
+	require.Equal(t, "staging", channel)
+
+	_, _, _, _, err = ParseDeploymentKey(projectID.String() + "./ios/production")
+	require.Error(t, err)
+}
@@ -0,0 +1,281 @@
+// Package codepushimport recreates the release history of a code-push-server or App Center
+// deployment as updates of a project, with the labels of the releases, so migrated apps keep
+// the releases they run and their rollback history
+package codepushimport
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/adminclient"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+)
+
+// archiveName is the path the repacked releases are uploaded with
+const archiveName = "codepush.zip"
+
+// maxMessageLength is the longest message of an update
+const maxMessageLength = 500
+
+type Config struct {
+	// BaseURL of the server, e.g. http://localhost:8080
+	BaseURL   string
+	ProjectID uuid.UUID
+	// Platform of the deployment, CodePush deployments serve a single platform
+	Platform string
+	// Channel the releases are imported to, usually named like the deployment
+	Channel string
+	// HistoryPath is the deployment history, the response of code-push-server's
+	// GET /apps/{app}/deployments/{deployment}/history or an App Center export. Blob URLs
+	// that aren't http(s) are paths relative to it.
+	HistoryPath string
+	// From is the label of the first release to import, the earlier ones are skipped, to
+	// resume an interrupted import
+	From string
+	// PollInterval of the status of the committed updates
+	PollInterval time.Duration
+}
+
+func (c *Config) validate() error {
+	if c.BaseURL == "" {
+		return errors.New("base URL is required")
+	}
+	if c.ProjectID == uuid.Nil {
+		return errors.New("project ID is required")
+	}
+	if c.Platform == "" || c.Channel == "" {
+		return errors.New("platform and channel are required")
+	}
+	if c.HistoryPath == "" {
+		return errors.New("history file is required")
+	}
+	return nil
+}
+
+// Release of the deployment history
+type Release struct {
+	Label       string `json:"label"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+	IsDisabled  bool   `json:"isDisabled"`
+	IsMandatory bool   `json:"isMandatory"`
+	// Rollout is the share of the devices getting the release, all of them when it's nil
+	Rollout     *int   `json:"rollout"`
+	PackageHash string `json:"packageHash"`
+	BlobURL     string `json:"blobUrl"`
+	// UploadTime in milliseconds since the epoch
+	UploadTime int64 `json:"uploadTime"`
+}
+
+// ReadHistory reads the releases of the deployment, oldest first. The history is either
+// a list of releases or an object with the list in the history field.
+func ReadHistory(r io.Reader) ([]Release, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		var history struct {
+			History []Release `json:"history"`
+		}
+		err = json.Unmarshal(content, &history)
+		releases = history.History
+	} else {
+		err = json.Unmarshal(content, &releases)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment history: %w", err)
+	}
+
+	slices.SortStableFunc(releases, func(a, b Release) int {
+		return cmp.Compare(a.UploadTime, b.UploadTime)
+	})
+	return releases, nil
+}
+
+// runtimeVersion returns the runtime version of the release, CodePush releases targeting
+// ranges of app versions can't be imported
+func runtimeVersion(release Release) (string, error) {
+	version, err := semver.NewVersion(release.AppVersion)
+	if err != nil {
+		return "", fmt.Errorf(
+			"app version %q isn't a version, ranges of app versions can't be imported",
+			release.AppVersion,
+		)
+	}
+	return version.String(), nil
+}
+
+func message(release Release) string {
+	msg := strings.TrimSpace(release.Description)
+	if msg == "" {
+		msg = "Imported from CodePush " + release.Label
+	}
+	if runes := []rune(msg); len(runes) > maxMessageLength {
+		msg = string(runes[:maxMessageLength])
+	}
+	return msg
+}
+
+// Import recreates the releases of the history in order and reports the outcome of each of
+// them to out. Releases that can't be imported are skipped, it stops at the first failed one.
+func Import(ctx context.Context, client *http.Client, config Config, out io.Writer) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	historyFile, err := os.Open(config.HistoryPath)
+	if err != nil {
+		return err
+	}
+	releases, err := ReadHistory(historyFile)
+	historyFile.Close()
+	if err != nil {
+		return err
+	}
+
+	if config.From != "" {
+		from := slices.IndexFunc(releases, func(release Release) bool {
+			return release.Label == config.From
+		})
+		if from < 0 {
+			return fmt.Errorf("release %s not found in the history", config.From)
+		}
+		releases = releases[from:]
+	}
+
+	im := &importer{
+		client: client,
+		admin: &adminclient.Client{
+			HTTP:         client,
+			BaseURL:      config.BaseURL,
+			ProjectID:    config.ProjectID,
+			PollInterval: config.PollInterval,
+		},
+		config: config,
+	}
+	for _, release := range releases {
+		updateID, warnings, err := im.importRelease(ctx, release)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", release.Label, err)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(out, "%-8s warning: %s\n", release.Label, warning)
+		}
+		if updateID == uuid.Nil {
+			continue
+		}
+		status := "imported"
+		if release.IsDisabled {
+			status = "imported, rolled back as it's disabled"
+		}
+		fmt.Fprintf(out, "%-8s %s update=%s\n", release.Label, status, updateID)
+	}
+	return nil
+}
+
+type importer struct {
+	client *http.Client
+	admin  *adminclient.Client
+	config Config
+}
+
+// importRelease returns the ID of the update of the release, uuid.Nil when it's skipped, and
+// the warnings about it
+func (im *importer) importRelease(
+	ctx context.Context,
+	release Release,
+) (uuid.UUID, []string, error) {
+	if release.Label == "" {
+		return uuid.Nil, nil, errors.New("release has no label")
+	}
+	version, err := runtimeVersion(release)
+	if err != nil {
+		return uuid.Nil, []string{"skipped, " + err.Error()}, nil
+	}
+	if release.BlobURL == "" {
+		return uuid.Nil, []string{"skipped, release has no package"}, nil
+	}
+
+	blob, err := im.loadBlob(ctx, release.BlobURL)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	pkg, err := Repack(blob, im.config.Platform)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	warnings := make([]string, 0)
+	if release.PackageHash != "" && !strings.EqualFold(pkg.Hash, release.PackageHash) {
+		warnings = append(warnings, fmt.Sprintf(
+			"package hash %s differs from %s, clients running the release download it again",
+			pkg.Hash,
+			release.PackageHash,
+		))
+	}
+	if pkg.Signed {
+		warnings = append(warnings, "the signature of the release was dropped")
+	}
+
+	body := api.PrepareUpdateBody{
+		RuntimeVersion:    version,
+		Message:           message(release),
+		Channel:           &im.config.Channel,
+		RolloutPercentage: release.Rollout,
+		CodePushLabel:     &release.Label,
+		IsMandatory:       &release.IsMandatory,
+	}
+	updateID, err := im.admin.PublishArchive(ctx, body, adminclient.Archive{
+		Name:    archiveName,
+		Content: pkg.Archive,
+	})
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	if release.IsDisabled {
+		if err := im.admin.RollbackUpdate(ctx, updateID); err != nil {
+			return uuid.Nil, nil, err
+		}
+	}
+	return updateID, warnings, nil
+}
+
+// loadBlob downloads the package of the release, or reads it next to the history file
+func (im *importer) loadBlob(ctx context.Context, blobURL string) ([]byte, error) {
+	if !strings.HasPrefix(blobURL, "http://") && !strings.HasPrefix(blobURL, "https://") {
+		blobPath := filepath.Join(filepath.Dir(im.config.HistoryPath), filepath.FromSlash(blobURL))
+		return os.ReadFile(blobPath)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := im.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("package download failed with %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,224 @@
+package codepushimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var projectID = uuid.MustParse("019393ed-5085-71ec-943a-1c71617a6282")
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(f, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readZip(t *testing.T, content []byte) map[string]string {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		files[file.Name] = string(data)
+	}
+	return files
+}
+
+// packageHash is the hash code-push-server computes for the files
+func packageHash(files map[string]string) string {
+	tokens := make([]string, 0, len(files))
+	for name, content := range files {
+		tokens = append(tokens, fmt.Sprintf("%s:%x", name, sha256.Sum256([]byte(content))))
+	}
+	slices.Sort(tokens)
+	manifest, _ := json.Marshal(tokens)
+	return fmt.Sprintf("%x", sha256.Sum256(manifest))
+}
+
+func TestRepack(t *testing.T) {
+	blob := zipFiles(t, map[string]string{
+		"CodePush/main.jsbundle":               "console.log('hello')",
+		"CodePush/assets/logo.png":             "png",
+		"CodePush/.codepushrelease":            "signature",
+		"__MACOSX/CodePush/._main.jsbundle":    "resource fork",
+		"CodePush/assets/.DS_Store":            "finder",
+		"CodePush/assets/fonts/Inter.ttf":      "ttf",
+		"CodePush/assets/fonts/Inter-Bold.ttf": "bold",
+	})
+
+	pkg, err := Repack(blob, "ios")
+	require.NoError(t, err)
+	require.True(t, pkg.Signed)
+
+	files := readZip(t, pkg.Archive)
+	require.Len(t, files, 5)
+	require.Equal(t, "console.log('hello')", files["ios/CodePush/main.jsbundle"])
+	require.Equal(t, "png", files["ios/CodePush/assets/logo.png"])
+
+	var meta metadata
+	require.NoError(t, json.Unmarshal([]byte(files["metadata.json"]), &meta))
+	require.Equal(t, "ios/CodePush/main.jsbundle", meta.FileMetadata["ios"].Bundle)
+	require.ElementsMatch(t, []metadataAsset{
+		{Path: "ios/CodePush/assets/logo.png", Ext: ".png"},
+		{Path: "ios/CodePush/assets/fonts/Inter.ttf", Ext: ".ttf"},
+		{Path: "ios/CodePush/assets/fonts/Inter-Bold.ttf", Ext: ".ttf"},
+	}, meta.FileMetadata["ios"].Assets)
+
+	single := map[string]string{"main.jsbundle": "console.log('hello')"}
+	pkg, err = Repack(zipFiles(t, single), "android")
+	require.NoError(t, err)
+	require.False(t, pkg.Signed)
+	require.Equal(t, packageHash(single), pkg.Hash)
+
+	_, err = Repack(zipFiles(t, map[string]string{"assets/logo.png": "png"}), "ios")
+	require.ErrorContains(t, err, "no .bundle")
+
+	_, err = Repack(zipFiles(t, map[string]string{
+		"main.jsbundle": "js",
+		"LICENSE":       "MIT",
+	}), "ios")
+	require.ErrorContains(t, err, "LICENSE has no extension")
+}
+
+func TestReadHistory(t *testing.T) {
+	releases, err := ReadHistory(strings.NewReader(`{"history": [
+		{"label": "v2", "appVersion": "1.0.0", "uploadTime": 2000},
+		{"label": "v1", "appVersion": "1.0.0", "uploadTime": 1000, "rollout": 50}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+	require.Equal(t, "v1", releases[0].Label)
+	require.Equal(t, 50, *releases[0].Rollout)
+	require.Nil(t, releases[1].Rollout)
+
+	releases, err = ReadHistory(strings.NewReader(`[{"label": "v1", "isDisabled": true}]`))
+	require.NoError(t, err)
+	require.True(t, releases[0].IsDisabled)
+
+	_, err = ReadHistory(strings.NewReader(`{"history": 1}`))
+	require.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	blob := zipFiles(t, map[string]string{"main.jsbundle": "console.log('v1')"})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v1.zip"), blob, 0644))
+	history := `{"history": [
+		{"label": "v1", "appVersion": "1.0", "blobUrl": "v1.zip", "uploadTime": 1,
+		 "packageHash": "` + packageHash(map[string]string{"main.jsbundle": "console.log('v1')"}) + `"},
+		{"label": "v2", "appVersion": "1.0.x", "blobUrl": "v1.zip", "uploadTime": 2},
+		{"label": "v3", "appVersion": "1.0.0", "blobUrl": "v1.zip", "uploadTime": 3,
+		 "isDisabled": true, "isMandatory": true, "description": "fix"}
+	]}`
+	historyPath := filepath.Join(dir, "history.json")
+	require.NoError(t, os.WriteFile(historyPath, []byte(history), 0644))
+
+	updateIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	prepared := make([]api.PrepareUpdateBody, 0)
+	requests := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/api/v1/admin/%s", projectID))
+		requests = append(requests, r.Method+" "+path)
+		switch {
+		case path == "/update":
+			var body api.PrepareUpdateBody
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(api.PrepareUpdateResponse{
+				UpdateID: updateIDs[len(prepared)],
+			})
+			prepared = append(prepared, body)
+		case strings.HasSuffix(path, "/assets"):
+			file, header, err := r.FormFile(archiveName)
+			require.NoError(t, err)
+			file.Close()
+			require.Equal(t, int64(prepared[len(prepared)-1].Archive.ContentLength), header.Size)
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(api.Update{Status: api.UpdateStatusPublished})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	out := new(bytes.Buffer)
+	err := Import(context.Background(), server.Client(), Config{
+		BaseURL:      server.URL,
+		ProjectID:    projectID,
+		Platform:     "android",
+		Channel:      "Production",
+		HistoryPath:  historyPath,
+		PollInterval: time.Millisecond,
+	}, out)
+	require.NoError(t, err)
+
+	require.Len(t, prepared, 2)
+	require.Equal(t, "v1", *prepared[0].CodePushLabel)
+	require.Equal(t, "1.0.0", prepared[0].RuntimeVersion)
+	require.Equal(t, "Imported from CodePush v1", prepared[0].Message)
+	require.Equal(t, "Production", *prepared[0].Channel)
+	require.False(t, *prepared[0].IsMandatory)
+	require.Equal(t, "v3", *prepared[1].CodePushLabel)
+	require.Equal(t, "fix", prepared[1].Message)
+	require.True(t, *prepared[1].IsMandatory)
+	require.Equal(t, []string{
+		"POST /update",
+		fmt.Sprintf("POST /update/%s/assets", updateIDs[0]),
+		fmt.Sprintf("POST /update/%s/commit", updateIDs[0]),
+		fmt.Sprintf("GET /update/%s", updateIDs[0]),
+		"POST /update",
+		fmt.Sprintf("POST /update/%s/assets", updateIDs[1]),
+		fmt.Sprintf("POST /update/%s/commit", updateIDs[1]),
+		fmt.Sprintf("GET /update/%s", updateIDs[1]),
+		fmt.Sprintf("POST /update/%s/rollback", updateIDs[1]),
+	}, requests)
+
+	output := out.String()
+	require.NotContains(t, output, "v1       warning")
+	require.Contains(t, output, "v2       warning: skipped")
+	require.Contains(t, output, "v3       imported, rolled back")
+
+	// resuming from a label skips the earlier releases
+	prepared = prepared[:0]
+	requests = requests[:0]
+	err = Import(context.Background(), server.Client(), Config{
+		BaseURL:     server.URL,
+		ProjectID:   projectID,
+		Platform:    "android",
+		Channel:     "Production",
+		HistoryPath: historyPath,
+		From:        "v2",
+	}, io.Discard)
+	require.NoError(t, err)
+	require.Len(t, prepared, 1)
+	require.Equal(t, "v3", *prepared[0].CodePushLabel)
+}
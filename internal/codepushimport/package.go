@@ -0,0 +1,150 @@
+package codepushimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// signatureFileName is the signature of releases of code signing apps, CodePush leaves it out
+// of the package hash and the signature doesn't cover the repacked package
+const signatureFileName = ".codepushrelease"
+
+// extRegex matches the extensions the metadata of updates accepts
+var extRegex = regexp.MustCompile(`^\.[a-zA-Z0-9\.\-]+$`)
+
+// ignoredFile reports the files CodePush leaves out of packages and their hashes
+func ignoredFile(name string) bool {
+	return strings.HasPrefix(name, "__MACOSX/") ||
+		path.Base(name) == ".DS_Store" ||
+		path.Base(name) == signatureFileName
+}
+
+// isBundle reports the JS bundles of React Native packages
+func isBundle(name string) bool {
+	return strings.HasSuffix(name, ".bundle") || strings.HasSuffix(name, ".jsbundle")
+}
+
+type metadata struct {
+	Version      int                     `json:"version"`
+	Bundler      string                  `json:"bundler"`
+	FileMetadata map[string]fileMetadata `json:"fileMetadata"`
+}
+
+type fileMetadata struct {
+	Bundle string          `json:"bundle"`
+	Assets []metadataAsset `json:"assets"`
+}
+
+type metadataAsset struct {
+	Path string `json:"path"`
+	Ext  string `json:"ext"`
+}
+
+// Package is a release repacked as an update archive
+type Package struct {
+	Archive []byte
+	// Hash is the CodePush package hash of the files, the server computes the same one
+	Hash string
+	// Signed is set when the release had a signature, it's dropped from the archive
+	Signed bool
+}
+
+// Repack moves the files of the release package under the platform directory and adds
+// metadata.json, the server serves them by their paths in the release package again
+func Repack(blob []byte, platform string) (*Package, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(blob), int64(len(blob)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid release package: %w", err)
+	}
+
+	pkg := &Package{}
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	meta := fileMetadata{Assets: make([]metadataAsset, 0)}
+	tokens := make([]string, 0, len(zipReader.File))
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if path.Base(file.Name) == signatureFileName {
+			pkg.Signed = true
+		}
+		if ignoredFile(file.Name) {
+			continue
+		}
+
+		filePath := platform + "/" + file.Name
+		if isBundle(file.Name) {
+			if meta.Bundle != "" {
+				return nil, fmt.Errorf("release package has more than one bundle: %s", file.Name)
+			}
+			meta.Bundle = filePath
+		} else {
+			ext := path.Ext(file.Name)
+			if !extRegex.MatchString(ext) {
+				return nil, fmt.Errorf("file %s has no extension, updates can't contain it", file.Name)
+			}
+			meta.Assets = append(meta.Assets, metadataAsset{Path: filePath, Ext: ext})
+		}
+
+		hash, err := copyFile(zipWriter, filePath, file)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, fmt.Sprintf("%s:%s", file.Name, hash))
+	}
+	if meta.Bundle == "" {
+		return nil, fmt.Errorf("release package has no .bundle or .jsbundle file")
+	}
+
+	metadataWriter, err := zipWriter.Create("metadata.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata.json: %w", err)
+	}
+	err = json.NewEncoder(metadataWriter).Encode(metadata{
+		Bundler:      "metro",
+		FileMetadata: map[string]fileMetadata{platform: meta},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write metadata.json: %w", err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	pkg.Archive = buf.Bytes()
+
+	slices.Sort(tokens)
+	manifest, err := json.Marshal(tokens)
+	if err != nil {
+		return nil, err
+	}
+	pkg.Hash = fmt.Sprintf("%x", sha256.Sum256(manifest))
+	return pkg, nil
+}
+
+// copyFile copies the file of the release package to the archive and returns its hex SHA256
+func copyFile(zipWriter *zip.Writer, filePath string, file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	writer, err := zipWriter.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s in archive: %w", filePath, err)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(writer, hash), reader); err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", file.Name, err)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,111 @@
+// Package devmode runs the API server without any external services: with an ephemeral
+// embedded database, in-process queue, in-memory cache and local file storage.
+package devmode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dbschema "github.com/a-gierczak/paratrooper/db"
+	"github.com/a-gierczak/paratrooper/internal/api"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	// DataDir keeps the uploaded assets, signing key and downloaded Postgres binaries
+	DataDir      string `env:"DEV_DATA_DIR,default=.paratrooper-dev"`
+	PostgresPort uint32 `env:"DEV_POSTGRES_PORT,default=5434"`
+}
+
+// Setup starts the embedded database and points the API config to the local services,
+// stop must be called before the process exits, otherwise the database keeps running
+func Setup(
+	ctx context.Context,
+	devConfig Config,
+	config *api.Config,
+) (stop func() error, err error) {
+	log := logger.FromContext(ctx)
+
+	dataDir, err := filepath.Abs(devConfig.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data dir: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data dir: %w", err)
+	}
+
+	// the database lives in a temporary directory, so every run starts from the seed
+	runtimeDir, err := os.MkdirTemp("", "paratrooper-dev-postgres-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database dir: %w", err)
+	}
+
+	dbConfig := embeddedpostgres.DefaultConfig().
+		Port(devConfig.PostgresPort).
+		Username("paratrooper").
+		Password("secret").
+		Database("paratrooper").
+		RuntimePath(runtimeDir).
+		CachePath(filepath.Join(dataDir, "postgres-binaries")).
+		Logger(zap.NewStdLog(log).Writer())
+	database := embeddedpostgres.NewDatabase(dbConfig)
+
+	log.Info("starting embedded postgres", zap.Uint32("port", devConfig.PostgresPort))
+	if err := database.Start(); err != nil {
+		os.RemoveAll(runtimeDir)
+		return nil, fmt.Errorf("failed to start embedded postgres: %w", err)
+	}
+	stop = func() error {
+		defer os.RemoveAll(runtimeDir)
+		return database.Stop()
+	}
+
+	dsn := dbConfig.GetConnectionURL() + "?sslmode=disable"
+	if err := initDatabase(ctx, dsn); err != nil {
+		if stopErr := stop(); stopErr != nil {
+			log.Error("failed to stop embedded postgres", zap.Error(stopErr))
+		}
+		return nil, err
+	}
+
+	config.DebugMode = true
+	config.PostgresDSN = dsn
+	config.Queue.Driver = queue.DriverMemory
+	config.Cache.Driver = "memory"
+	apiPublicURL := config.Storage.ApiPublicURL
+	if apiPublicURL == "" {
+		apiPublicURL = fmt.Sprintf("http://localhost:%d", config.Listener.Port)
+	}
+	config.Storage = storage.Config{
+		LocalPath:     filepath.Join(dataDir, "assets"),
+		SecretKeyPath: filepath.Join(dataDir, "secret.key"),
+		ApiPublicURL:  apiPublicURL,
+	}
+
+	return stop, nil
+}
+
+func initDatabase(ctx context.Context, dsn string) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to embedded postgres: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, dbschema.Schema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+	if _, err := conn.Exec(ctx, dbschema.TestSeed); err != nil {
+		return fmt.Errorf("failed to seed database: %w", err)
+	}
+
+	return nil
+}
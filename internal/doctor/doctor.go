@@ -0,0 +1,259 @@
+// Package doctor diagnoses the configuration of the API server and its connectivity
+// to the services it depends on
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/api"
+	rediscache "github.com/a-gierczak/paratrooper/internal/cache/redis"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gocloud.dev/blob"
+)
+
+const checkTimeout = 10 * time.Second
+
+// errSkipped marks checks not relevant for the configuration
+var errSkipped = errors.New("skipped")
+
+type Result struct {
+	Name   string
+	Detail string
+	Err    error
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, config *api.Config) (string, error)
+}
+
+var checks = []check{
+	{"config", checkConfig},
+	{"postgres", checkPostgres},
+	{"schema", checkSchema},
+	{"nats", checkNATS},
+	{"redis", checkRedis},
+	{"storage", checkStorage},
+	{"signed urls", checkSignedURLs},
+}
+
+// Run runs all checks, a failed check doesn't stop the following ones
+func Run(ctx context.Context, config api.Config) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		detail, err := c.run(checkCtx, &config)
+		cancel()
+		results = append(results, Result{Name: c.name, Detail: detail, Err: err})
+	}
+	return results
+}
+
+// PrintReport writes a line per check and returns false if any of them failed
+func PrintReport(w io.Writer, results []Result) bool {
+	ok := true
+	for _, result := range results {
+		status := "PASS"
+		detail := result.Detail
+		switch {
+		case errors.Is(result.Err, errSkipped):
+			status = "SKIP"
+			detail = result.Err.Error()
+		case result.Err != nil:
+			status = "FAIL"
+			detail = result.Err.Error()
+			ok = false
+		}
+
+		if detail != "" {
+			fmt.Fprintf(w, "[%s] %-12s %s\n", status, result.Name, detail)
+		} else {
+			fmt.Fprintf(w, "[%s] %s\n", status, result.Name)
+		}
+	}
+	return ok
+}
+
+func checkConfig(_ context.Context, config *api.Config) (string, error) {
+	var problems []string
+	require := func(value string, name string) {
+		if value == "" {
+			problems = append(problems, name+" is not set")
+		}
+	}
+
+	require(config.PostgresDSN, "POSTGRES_DSN")
+
+	switch config.Queue.Driver {
+	case queue.DriverNATS:
+		require(config.Queue.NATSURL, "NATS_URL")
+	case queue.DriverMemory:
+	default:
+		problems = append(problems, "unknown QUEUE_DRIVER "+config.Queue.Driver)
+	}
+
+	switch config.Cache.Driver {
+	case "redis":
+		require(config.Cache.RedisURL, "CACHE_REDIS_URL")
+	case "", "memory":
+	default:
+		problems = append(problems, "unknown CACHE_DRIVER "+config.Cache.Driver)
+	}
+
+	if !config.Storage.IsExternal() {
+		require(config.Storage.SecretKeyPath, "STORAGE_LOCAL_SECRET_KEY_PATH")
+		require(config.Storage.ApiPublicURL, "API_PUBLIC_URL")
+		if config.Storage.ReplicasFile != "" {
+			problems = append(problems, "STORAGE_REPLICAS_FILE requires STORAGE_DRIVER_URL")
+		}
+	} else if config.Storage.CDNBaseURL != "" {
+		require(config.Storage.CDNKeyPairID, "STORAGE_CDN_KEY_PAIR_ID")
+		require(config.Storage.CDNPrivateKeyPath, "STORAGE_CDN_PRIVATE_KEY_PATH")
+	}
+
+	if config.Storage.EdgeBaseURL != "" {
+		require(config.Storage.EdgeSecretKeyPath, "STORAGE_EDGE_SECRET_KEY_PATH")
+	}
+	if config.EdgeCache.Dir != "" {
+		require(config.Storage.EdgeBaseURL, "STORAGE_EDGE_BASE_URL")
+	}
+
+	if len(problems) > 0 {
+		return "", errors.New(strings.Join(problems, "; "))
+	}
+	return "", nil
+}
+
+func checkPostgres(ctx context.Context, config *api.Config) (string, error) {
+	conn, err := pgx.Connect(ctx, config.PostgresDSN)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	var version string
+	if err := conn.QueryRow(ctx, "show server_version").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to query server version: %w", err)
+	}
+	return "server version " + version, nil
+}
+
+func checkNATS(ctx context.Context, config *api.Config) (string, error) {
+	if config.Queue.Driver != queue.DriverNATS {
+		return "", fmt.Errorf("%w: queue driver is %s", errSkipped, config.Queue.Driver)
+	}
+
+	conn, err := queue.Connect(ctx, config.Queue.NATSURL)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if err := conn.HealthCheck(); err != nil {
+		return "", err
+	}
+	return "JetStream is healthy", nil
+}
+
+func checkRedis(ctx context.Context, config *api.Config) (string, error) {
+	if config.Cache.Driver != "redis" {
+		return "", fmt.Errorf("%w: in-memory cache is used", errSkipped)
+	}
+
+	// without the fallback to the in-memory cache
+	c, err := rediscache.New(config.Cache.RedisURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to init cache: %w", err)
+	}
+
+	key := "doctor:" + uuid.NewString()
+	if err := c.Set(ctx, key, "ok", 10); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	defer c.Delete(ctx, key)
+
+	value, err := c.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read: %w", err)
+	}
+	if value != "ok" {
+		return "", fmt.Errorf("read %q, expected %q", value, "ok")
+	}
+	return "", nil
+}
+
+// probeObjectKey doesn't start with a project ID, so it never collides with assets
+func probeObjectKey() string {
+	return "doctor/" + uuid.NewString()
+}
+
+func checkStorage(ctx context.Context, config *api.Config) (string, error) {
+	st, err := storage.Init(ctx, &config.Storage)
+	if err != nil {
+		return "", fmt.Errorf("failed to init storage: %w", err)
+	}
+	defer st.Bucket().Close()
+
+	key := probeObjectKey()
+	if err := st.Bucket().WriteAll(ctx, key, []byte("ok"), nil); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	defer st.Bucket().Delete(ctx, key)
+
+	data, err := st.Bucket().ReadAll(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read: %w", err)
+	}
+	if string(data) != "ok" {
+		return "", fmt.Errorf("read %q, expected %q", data, "ok")
+	}
+	return st.Provider() + " storage is writable", nil
+}
+
+func checkSignedURLs(ctx context.Context, config *api.Config) (string, error) {
+	if config.Storage.IsExternal() {
+		return "", fmt.Errorf("%w: URLs are signed by the storage provider", errSkipped)
+	}
+
+	st, err := storage.Init(ctx, &config.Storage)
+	if err != nil {
+		return "", fmt.Errorf("failed to init storage: %w", err)
+	}
+	defer st.Bucket().Close()
+
+	key := probeObjectKey()
+	signedURL, err := st.Bucket().SignedURL(ctx, key, &blob.SignedURLOptions{
+		Method: "GET",
+		Expiry: time.Minute,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign URL: %w", err)
+	}
+
+	parsedURL, err := url.Parse(signedURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signed URL: %w", err)
+	}
+	if !strings.HasPrefix(signedURL, strings.TrimSuffix(config.Storage.ApiPublicURL, "/")) {
+		return "", fmt.Errorf("signed URL %s doesn't point to API_PUBLIC_URL", parsedURL.Redacted())
+	}
+
+	verifiedKey, err := storage.NewService(st).ObjectKeyFromURL(ctx, parsedURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify signed URL: %w", err)
+	}
+	if verifiedKey != key {
+		return "", fmt.Errorf("signed URL resolves to %s, expected %s", verifiedKey, key)
+	}
+	return "", nil
+}
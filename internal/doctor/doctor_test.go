@@ -0,0 +1,30 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintReport(t *testing.T) {
+	var out bytes.Buffer
+	ok := PrintReport(&out, []Result{
+		{Name: "postgres", Detail: "server version 16.1"},
+		{Name: "redis", Err: fmt.Errorf("%w: in-memory cache is used", errSkipped)},
+	})
+	require.True(t, ok)
+	require.Equal(
+		t,
+		"[PASS] postgres     server version 16.1\n"+
+			"[SKIP] redis        skipped: in-memory cache is used\n",
+		out.String(),
+	)
+
+	out.Reset()
+	ok = PrintReport(&out, []Result{{Name: "nats", Err: errors.New("connection refused")}})
+	require.False(t, ok)
+	require.Equal(t, "[FAIL] nats         connection refused\n", out.String())
+}
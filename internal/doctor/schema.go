@@ -0,0 +1,32 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dbschema "github.com/a-gierczak/paratrooper/db"
+	"github.com/a-gierczak/paratrooper/internal/api"
+	"github.com/a-gierczak/paratrooper/internal/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// checkSchema verifies that the database has every table and column of db/schema.sql,
+// there are no migrations, so the schema has to be updated manually
+func checkSchema(ctx context.Context, config *api.Config) (string, error) {
+	conn, err := pgx.Connect(ctx, config.PostgresDSN)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	missing, err := schema.MissingColumns(ctx, conn)
+	if err != nil {
+		return "", err
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing columns: %s", strings.Join(missing, ", "))
+	}
+	return fmt.Sprintf("%d tables up to date", len(schema.Columns(dbschema.Schema))), nil
+}
@@ -0,0 +1,194 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// expoHashRegex matches a base64url encoded SHA-256 without padding
+	expoHashRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+	// codePushHashRegex matches a hex encoded SHA-256
+	codePushHashRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+type expoAsset struct {
+	Hash          string `json:"hash"`
+	Key           string `json:"key"`
+	ContentType   string `json:"contentType"`
+	FileExtension string `json:"fileExtension"`
+	URL           string `json:"url"`
+}
+
+type expoManifest struct {
+	ID             string      `json:"id"`
+	CreatedAt      string      `json:"createdAt"`
+	RuntimeVersion string      `json:"runtimeVersion"`
+	LaunchAsset    expoAsset   `json:"launchAsset"`
+	Assets         []expoAsset `json:"assets"`
+}
+
+// requireExpoResponse checks the headers and the parts of a response of the Expo Updates
+// protocol v1, it returns the bodies of the parts by their names
+func requireExpoResponse(t *testing.T, resp *http.Response) map[string][]byte {
+	t.Helper()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "1", resp.Header.Get("expo-protocol-version"))
+	require.Equal(t, "0", resp.Header.Get("expo-sfv-version"))
+	require.Contains(t, resp.Header.Get("Cache-Control"), "private")
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+	require.NotEmpty(t, params["boundary"])
+
+	parts := make(map[string][]byte)
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		disposition, dispositionParams, err := mime.ParseMediaType(
+			part.Header.Get("Content-Disposition"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "form-data", disposition)
+		name := dispositionParams["name"]
+		require.NotEmpty(t, name)
+		require.NotContains(t, parts, name, "duplicate part")
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		if name != "certificate_chain" {
+			contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			require.Equal(t, "application/json", contentType, name)
+			require.True(t, json.Valid(body), name)
+		}
+		parts[name] = body
+	}
+
+	// a response has either the manifest or the directive
+	_, hasManifest := parts["manifest"]
+	_, hasDirective := parts["directive"]
+	require.True(t, hasManifest != hasDirective, "expected a manifest or a directive part")
+	if _, hasExtensions := parts["extensions"]; hasExtensions {
+		require.True(t, hasManifest, "extensions are sent only with a manifest")
+	}
+	return parts
+}
+
+func requireExpoAsset(t *testing.T, asset expoAsset, launch bool) {
+	t.Helper()
+	require.Regexp(t, expoHashRegex, asset.Hash, asset.Key)
+	require.NotEmpty(t, asset.Key)
+	require.NotEmpty(t, asset.ContentType, asset.Key)
+	if !launch || asset.FileExtension != "" {
+		require.True(t, strings.HasPrefix(asset.FileExtension, "."), asset.Key)
+	}
+
+	assetURL, err := url.Parse(asset.URL)
+	require.NoError(t, err, asset.Key)
+	require.True(t, assetURL.IsAbs(), asset.Key)
+}
+
+// requireExpoManifest checks the manifest part, the hashes are checked by downloading the
+// assets
+func requireExpoManifest(
+	t *testing.T,
+	parts map[string][]byte,
+	runtimeVersion string,
+) expoManifest {
+	t.Helper()
+	require.Contains(t, parts, "manifest")
+
+	var manifest expoManifest
+	require.NoError(t, json.Unmarshal(parts["manifest"], &manifest))
+	_, err := uuid.Parse(manifest.ID)
+	require.NoError(t, err)
+	_, err = time.Parse(time.RFC3339, manifest.CreatedAt)
+	require.NoError(t, err)
+	require.Equal(t, runtimeVersion, manifest.RuntimeVersion)
+
+	requireExpoAsset(t, manifest.LaunchAsset, true)
+	keys := map[string]bool{manifest.LaunchAsset.Key: true}
+	for _, asset := range manifest.Assets {
+		requireExpoAsset(t, asset, false)
+		require.False(t, keys[asset.Key], "duplicate asset key %s", asset.Key)
+		keys[asset.Key] = true
+	}
+
+	if extensions, ok := parts["extensions"]; ok {
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(extensions, &decoded))
+	}
+	return manifest
+}
+
+func requireExpoDirective(t *testing.T, parts map[string][]byte, directiveType string) {
+	t.Helper()
+	require.Contains(t, parts, "directive")
+
+	var directive struct {
+		Type string `json:"type"`
+	}
+	require.NoError(t, json.Unmarshal(parts["directive"], &directive))
+	require.Equal(t, directiveType, directive.Type)
+}
+
+// requireCodePushUpdate checks the update check response of code-push-server, the keys of
+// the update info are snake case, clients ignore the camel case ones
+func requireCodePushUpdate(t *testing.T, resp *http.Response) api.CodePushUpdate {
+	t.Helper()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "application/json", mediaType)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var raw map[string]map[string]any
+	require.NoError(t, json.Unmarshal(body, &raw))
+	require.Contains(t, raw, "update_info")
+	for _, key := range []string{"is_available", "should_run_binary_version", "update_app_version"} {
+		require.IsType(t, true, raw["update_info"][key], key)
+	}
+
+	var decoded struct {
+		UpdateInfo api.CodePushUpdate `json:"update_info"`
+	}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	info := decoded.UpdateInfo
+	if !info.IsAvailable {
+		return info
+	}
+
+	require.Regexp(t, codePushHashRegex, info.PackageHash)
+	require.NotEmpty(t, info.Label)
+	require.NotEmpty(t, info.AppVersion)
+	require.NotEmpty(t, info.TargetBinaryRange)
+	require.Positive(t, info.PackageSize)
+	require.False(t, info.ShouldRunBinaryVersion)
+	downloadURL, err := url.Parse(info.DownloadURL)
+	require.NoError(t, err)
+	require.True(t, downloadURL.IsAbs())
+	return info
+}
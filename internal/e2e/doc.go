@@ -0,0 +1,6 @@
+// Package e2e runs the API server and the worker against Postgres and NATS containers, publishes
+// updates through the admin API and checks the responses of the update checks against the Expo
+// and CodePush protocols. The tests need Docker and run only with the e2e build tag:
+//
+//	go test -tags e2e ./internal/e2e
+package e2e
@@ -0,0 +1,324 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/adminclient"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+	internalapi "github.com/a-gierczak/paratrooper/internal/api"
+	"github.com/a-gierczak/paratrooper/internal/clientcheck"
+	"github.com/a-gierczak/paratrooper/internal/worker"
+
+	"github.com/Netflix/go-env"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.uber.org/zap"
+)
+
+// publishTimeout covers the processing of the update by the worker
+const publishTimeout = 2 * time.Minute
+
+type environment struct {
+	baseURL string
+	client  *http.Client
+}
+
+func startPostgres(t *testing.T, ctx context.Context) string {
+	ctr, err := postgres.Run(ctx,
+		"postgres:13",
+		postgres.WithInitScripts(filepath.Join("..", "..", "db", "schema.sql")),
+		postgres.WithDatabase("paratrooper"),
+		postgres.WithUsername("paratrooper"),
+		postgres.WithPassword("secret"),
+		postgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	dsn, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	return dsn
+}
+
+func startNATS(t *testing.T, ctx context.Context) string {
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nats:2.10",
+			Cmd:          []string{"-js"},
+			ExposedPorts: []string{"4222/tcp"},
+			WaitingFor:   wait.ForLog("Server is ready"),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	natsURL, err := ctr.PortEndpoint(ctx, "4222/tcp", "nats")
+	require.NoError(t, err)
+	return natsURL
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// startServices runs the API server and the worker like their commands do, configured with
+// the environment, with local storage shared by both
+func startServices(t *testing.T) *environment {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	port := freePort(t)
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	t.Setenv("POSTGRES_DSN", startPostgres(t, ctx))
+	t.Setenv("QUEUE_DRIVER", "nats")
+	t.Setenv("NATS_URL", startNATS(t, ctx))
+	t.Setenv("CACHE_DRIVER", "memory")
+	t.Setenv("STORAGE_LOCAL_PATH", filepath.Join(dataDir, "assets"))
+	t.Setenv("STORAGE_LOCAL_SECRET_KEY_PATH", filepath.Join(dataDir, "secret.key"))
+	t.Setenv("API_PUBLIC_URL", baseURL)
+	t.Setenv("HOST", "127.0.0.1")
+	t.Setenv("PORT", strconv.Itoa(port))
+	t.Setenv("LEADER_RETRY_INTERVAL", "1s")
+
+	var apiConfig internalapi.Config
+	_, err := env.UnmarshalFromEnviron(&apiConfig)
+	require.NoError(t, err)
+	var workerConfig worker.Config
+	_, err = env.UnmarshalFromEnviron(&workerConfig)
+	require.NoError(t, err)
+
+	// neither of them returns until they fail
+	failed := make(chan error, 2)
+	go func() { failed <- fmt.Errorf("api: %w", internalapi.Run(apiConfig, zap.NewNop(), nil)) }()
+	go func() { failed <- fmt.Errorf("worker: %w", worker.Run(workerConfig, zap.NewNop())) }()
+
+	e := &environment{baseURL: baseURL, client: &http.Client{Timeout: 30 * time.Second}}
+	deadline := time.After(time.Minute)
+	for {
+		select {
+		case err := <-failed:
+			t.Fatalf("failed to start: %v", err)
+		case <-deadline:
+			t.Fatal("API server didn't become healthy")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		resp, err := e.client.Get(baseURL + "/api/v1/health")
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return e
+		}
+	}
+}
+
+func (e *environment) createProject(t *testing.T, protocol api.UpdateProtocol) uuid.UUID {
+	body, err := json.Marshal(api.CreateProjectParams{
+		Name:           "e2e " + string(protocol),
+		UpdateProtocol: protocol,
+	})
+	require.NoError(t, err)
+
+	resp, err := e.client.Post(
+		e.baseURL+"/api/v1/admin/project",
+		"application/json",
+		bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var project api.Project
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&project))
+	return project.ID
+}
+
+func (e *environment) adminClient(projectID uuid.UUID) *adminclient.Client {
+	return &adminclient.Client{
+		HTTP:         e.client,
+		BaseURL:      e.baseURL,
+		ProjectID:    projectID,
+		PollInterval: 200 * time.Millisecond,
+	}
+}
+
+func (e *environment) get(t *testing.T, path string, header http.Header) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, e.baseURL+path, nil)
+	require.NoError(t, err)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := e.client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(f, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// metadataJSON is the metadata.json of an export with the bundle and the asset per platform
+func metadataJSON(t *testing.T, bundles map[string]string, asset string) string {
+	fileMetadata := make(map[string]any)
+	for platform, bundle := range bundles {
+		fileMetadata[platform] = map[string]any{
+			"bundle": bundle,
+			"assets": []map[string]string{{"path": asset, "ext": ".png"}},
+		}
+	}
+	data, err := json.Marshal(map[string]any{
+		"version":      0,
+		"bundler":      "metro",
+		"fileMetadata": fileMetadata,
+	})
+	require.NoError(t, err)
+	return string(data)
+}
+
+func verify(t *testing.T, e *environment, result *clientcheck.Result) {
+	for _, check := range clientcheck.Verify(context.Background(), e.client, result) {
+		require.Empty(t, check.Error, check.Key)
+	}
+}
+
+func TestPublishFlow(t *testing.T) {
+	e := startServices(t)
+
+	t.Run("expo", func(t *testing.T) {
+		projectID := e.createProject(t, api.Expo)
+		archive := adminclient.Archive{
+			Name: "export.zip",
+			Content: zipFiles(t, map[string]string{
+				"metadata.json": metadataJSON(t, map[string]string{
+					"ios":     "_expo/static/js/ios/entry.hbc",
+					"android": "_expo/static/js/android/entry.hbc",
+				}, "assets/4f1cb2cac2370cd5050681232e8575a8"),
+				"_expo/static/js/ios/entry.hbc":           "ios bundle",
+				"_expo/static/js/android/entry.hbc":       "android bundle",
+				"assets/4f1cb2cac2370cd5050681232e8575a8": "png",
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		updateID, err := e.adminClient(projectID).PublishArchive(
+			ctx,
+			api.PrepareUpdateBody{Message: "e2e", RuntimeVersion: "1.0.0"},
+			archive,
+		)
+		require.NoError(t, err)
+
+		path := fmt.Sprintf("/api/v1/public/%s/expo", projectID)
+		header := http.Header{
+			"Accept":                {"multipart/mixed"},
+			"Expo-Protocol-Version": {"1"},
+			"Expo-Platform":         {"ios"},
+			"Expo-Runtime-Version":  {"1.0.0"},
+		}
+		parts := requireExpoResponse(t, e.get(t, path, header))
+		manifest := requireExpoManifest(t, parts, "1.0.0")
+		require.Equal(t, updateID.String(), manifest.ID)
+		require.Len(t, manifest.Assets, 1)
+
+		result, err := clientcheck.Check(ctx, e.client, clientcheck.Config{
+			BaseURL:        e.baseURL,
+			ProjectID:      projectID,
+			Protocol:       clientcheck.ProtocolExpo,
+			Platform:       "ios",
+			RuntimeVersion: "1.0.0",
+			Channel:        "production",
+		})
+		require.NoError(t, err)
+		require.Equal(t, analytics.DecisionUpdate, result.Decision)
+		verify(t, e, result)
+
+		header.Set("Expo-Current-Update-Id", updateID.String())
+		parts = requireExpoResponse(t, e.get(t, path, header))
+		requireExpoDirective(t, parts, "noUpdateAvailable")
+	})
+
+	t.Run("codepush", func(t *testing.T) {
+		projectID := e.createProject(t, api.Codepush)
+		archive := adminclient.Archive{
+			Name: "release.zip",
+			Content: zipFiles(t, map[string]string{
+				"metadata.json": metadataJSON(t, map[string]string{
+					"ios": "ios/CodePush/main.jsbundle",
+				}, "ios/CodePush/assets/logo.png"),
+				"ios/CodePush/main.jsbundle":   "console.log('e2e')",
+				"ios/CodePush/assets/logo.png": "png",
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		label := "v1"
+		_, err := e.adminClient(projectID).PublishArchive(
+			ctx,
+			api.PrepareUpdateBody{Message: "e2e", RuntimeVersion: "1.0.0", CodePushLabel: &label},
+			archive,
+		)
+		require.NoError(t, err)
+
+		query := url.Values{}
+		query.Set("app_version", "1.0.0")
+		query.Set("deployment_key", fmt.Sprintf("%s/ios/production", projectID))
+		query.Set("client_unique_id", uuid.NewString())
+		path := "/v0.1/public/codepush/update_check?" + query.Encode()
+		info := requireCodePushUpdate(t, e.get(t, path, nil))
+		require.True(t, info.IsAvailable)
+		require.Equal(t, label, info.Label)
+		require.Equal(t, "1.0.0", info.TargetBinaryRange)
+
+		result, err := clientcheck.Check(ctx, e.client, clientcheck.Config{
+			BaseURL:        e.baseURL,
+			ProjectID:      projectID,
+			Protocol:       clientcheck.ProtocolCodePush,
+			Platform:       "ios",
+			RuntimeVersion: "1.0.0",
+			Channel:        "production",
+		})
+		require.NoError(t, err)
+		require.Equal(t, analytics.DecisionUpdate, result.Decision)
+		verify(t, e, result)
+
+		query.Set("package_hash", info.PackageHash)
+		path = "/v0.1/public/codepush/update_check?" + query.Encode()
+		info = requireCodePushUpdate(t, e.get(t, path, nil))
+		require.False(t, info.IsAvailable)
+	})
+}
@@ -0,0 +1,293 @@
+// Package edge serves assets of the external bucket through a read-through cache on local disk,
+// for deployments without a CDN in front of the bucket
+package edge
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"golang.org/x/sync/singleflight"
+)
+
+type CacheConfig struct {
+	// Dir enables the edge cache, every run starts with an empty cache in a subdirectory of it
+	Dir       string `env:"EDGE_CACHE_DIR"`
+	MaxSizeMB int64  `env:"EDGE_CACHE_MAX_SIZE_MB,default=1024"`
+}
+
+const runDirPattern = "run-*"
+
+// ErrTooLarge is returned for objects that would take more than the whole cache
+var ErrTooLarge = errors.New("object is larger than the cache")
+
+// Object is an open cached file, it must be closed by the caller
+type Object struct {
+	*os.File
+	Size            int64
+	ContentType     string
+	ContentEncoding string
+	ModTime         time.Time
+	// Hit is false when the object was fetched from the origin by this request
+	Hit bool
+}
+
+type entry struct {
+	key             string
+	path            string
+	size            int64
+	contentType     string
+	contentEncoding string
+	modTime         time.Time
+}
+
+// Cache keeps the most recently used objects of the origin bucket on disk,
+// the least recently used ones are evicted when the cache exceeds its size
+type Cache struct {
+	origin   *blob.Bucket
+	dir      string
+	maxBytes int64
+	fetches  singleflight.Group
+
+	mu sync.Mutex
+	// front is the most recently used entry
+	lru     *list.List
+	entries map[string]*list.Element
+	size    int64
+}
+
+func NewCache(ctx context.Context, origin *blob.Bucket, config CacheConfig) (*Cache, error) {
+	if config.MaxSizeMB <= 0 {
+		return nil, errors.New("EDGE_CACHE_MAX_SIZE_MB must be positive")
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	// entries of previous runs aren't indexed, so they would never be evicted
+	staleDirs, err := filepath.Glob(filepath.Join(config.Dir, runDirPattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale cache dirs: %w", err)
+	}
+	for _, staleDir := range staleDirs {
+		if err := os.RemoveAll(staleDir); err != nil {
+			return nil, fmt.Errorf("failed to remove stale cache dir: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp(config.Dir, runDirPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	log.Info(
+		"initialized edge cache",
+		zap.String("dir", dir),
+		zap.Int64("max_size_mb", config.MaxSizeMB),
+	)
+
+	return &Cache{
+		origin:   origin,
+		dir:      dir,
+		maxBytes: config.MaxSizeMB * 1024 * 1024,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// Open returns the cached object, fetching it from the origin on a miss.
+// Concurrent misses of the same object fetch it only once.
+func (c *Cache) Open(ctx context.Context, objectKey string) (*Object, error) {
+	hit := true
+	// the object can be evicted right after it's fetched, so a second attempt may be needed
+	for attempt := 0; attempt < 2; attempt++ {
+		object, err := c.lookup(objectKey)
+		if err != nil {
+			return nil, err
+		}
+		if object != nil {
+			object.Hit = hit
+			return object, nil
+		}
+
+		hit = false
+		_, err, _ = c.fetches.Do(objectKey, func() (any, error) {
+			// the fetch is shared with other requests, so it mustn't be canceled with this one
+			return nil, c.fetch(context.WithoutCancel(ctx), objectKey)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("object %s was evicted before it could be served", objectKey)
+}
+
+// Size returns the total size of the cached objects
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Purge removes the cached objects with keys starting with any of the prefixes, so objects
+// deleted from the origin aren't served anymore. It returns the number of removed objects.
+func (c *Cache) Purge(ctx context.Context, prefixes []string) int {
+	log := logger.FromContext(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var purged int
+	for key, element := range c.entries {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				c.remove(log, element)
+				purged++
+				break
+			}
+		}
+	}
+	return purged
+}
+
+// ConsumeObjectsDeletedEvents purges the objects deleted from the origin, or moved to cold
+// storage, from the cache
+func ConsumeObjectsDeletedEvents(ctx context.Context, queueConn queue.Queue, c *Cache) error {
+	log := logger.FromContext(ctx)
+	return queueConn.ConsumeObjectsDeletedEvents(ctx, func(data []byte) {
+		event, err := queue.ParseObjectsDeletedEvent(data)
+		if err != nil {
+			log.Error("failed to parse objects deleted event", zap.Error(err))
+			return
+		}
+		purged := c.Purge(ctx, event.ObjectKeyPrefixes)
+		log.Debug("purged deleted objects from edge cache", zap.Int("count", purged))
+	})
+}
+
+// Close removes the cached files
+func (c *Cache) Close() error {
+	return os.RemoveAll(c.dir)
+}
+
+func (c *Cache) lookup(objectKey string) (*Object, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[objectKey]
+	if !ok {
+		return nil, nil
+	}
+	c.lru.MoveToFront(element)
+
+	e := element.Value.(*entry)
+	// evicted files are unlinked, but stay readable while they're open
+	file, err := os.Open(e.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cached file: %w", err)
+	}
+	return &Object{
+		File:            file,
+		Size:            e.size,
+		ContentType:     e.contentType,
+		ContentEncoding: e.contentEncoding,
+		ModTime:         e.modTime,
+	}, nil
+}
+
+func (c *Cache) fetch(ctx context.Context, objectKey string) error {
+	attrs, err := c.origin.Attributes(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to read object attributes: %w", err)
+	}
+	if attrs.Size > c.maxBytes {
+		return ErrTooLarge
+	}
+
+	reader, err := c.origin.NewReader(ctx, objectKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read object: %w", err)
+	}
+	log := logger.FromContext(ctx)
+	defer util.CloseWithLogger(log, reader)
+
+	tmpFile, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	size, err := io.Copy(tmpFile, reader)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	e := &entry{
+		key:             objectKey,
+		path:            filepath.Join(c.dir, fileName(objectKey)),
+		size:            size,
+		contentType:     attrs.ContentType,
+		contentEncoding: attrs.ContentEncoding,
+		modTime:         attrs.ModTime,
+	}
+	if err := os.Rename(tmpFile.Name(), e.path); err != nil {
+		return fmt.Errorf("failed to move cache file: %w", err)
+	}
+
+	c.add(log, e)
+	log.Debug("cached object", zap.String("object", objectKey), zap.Int64("size", size))
+	return nil
+}
+
+func (c *Cache) add(log *zap.Logger, e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[e.key]; ok {
+		// the file was already replaced by the rename
+		c.size -= element.Value.(*entry).size
+		element.Value = e
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[e.key] = c.lru.PushFront(e)
+	}
+	c.size += e.size
+
+	for c.size > c.maxBytes {
+		c.remove(log, c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(log *zap.Logger, element *list.Element) {
+	e := c.lru.Remove(element).(*entry)
+	delete(c.entries, e.key)
+	c.size -= e.size
+
+	if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error("failed to remove evicted cache file", zap.Error(err))
+	}
+}
+
+// fileName maps the object key to a flat file name, keys may contain any characters
+func fileName(objectKey string) string {
+	sum := sha256.Sum256([]byte(objectKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,154 @@
+package edge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/blob/fileblob"
+	"gocloud.dev/blob/memblob"
+)
+
+func newTestCache(t *testing.T, maxSizeMB int64) (*Cache, *blob.Bucket) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	origin := memblob.OpenBucket(nil)
+	cache, err := NewCache(ctx, origin, CacheConfig{Dir: t.TempDir(), MaxSizeMB: maxSizeMB})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache, origin
+}
+
+func readObject(t *testing.T, cache *Cache, key string) (string, bool) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	object, err := cache.Open(ctx, key)
+	require.NoError(t, err)
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	return string(data), object.Hit
+}
+
+func TestCacheReadThrough(t *testing.T) {
+	cache, origin := newTestCache(t, 1)
+	ctx := context.Background()
+	require.NoError(t, origin.WriteAll(ctx, "bundle", []byte("v1"), nil))
+
+	data, hit := readObject(t, cache, "bundle")
+	require.Equal(t, "v1", data)
+	require.False(t, hit)
+
+	// cached objects are served without the origin
+	require.NoError(t, origin.Delete(ctx, "bundle"))
+	data, hit = readObject(t, cache, "bundle")
+	require.Equal(t, "v1", data)
+	require.True(t, hit)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache, origin := newTestCache(t, 1)
+	ctx := context.Background()
+	half := []byte(strings.Repeat("a", 512*1024))
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, origin.WriteAll(ctx, key, half, nil))
+	}
+
+	readObject(t, cache, "a")
+	readObject(t, cache, "b")
+	// a is now more recently used than b
+	readObject(t, cache, "a")
+	readObject(t, cache, "c")
+	require.Equal(t, int64(1024*1024), cache.Size())
+
+	_, hit := readObject(t, cache, "a")
+	require.True(t, hit)
+	_, hit = readObject(t, cache, "b")
+	require.False(t, hit)
+}
+
+func TestCacheTooLarge(t *testing.T) {
+	cache, origin := newTestCache(t, 1)
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	large := []byte(strings.Repeat("a", 1024*1024+1))
+	require.NoError(t, origin.WriteAll(ctx, "large", large, nil))
+
+	_, err := cache.Open(ctx, "large")
+	require.ErrorIs(t, err, ErrTooLarge)
+	require.Zero(t, cache.Size())
+}
+
+func TestCachePurgeDeletedObjects(t *testing.T) {
+	cache, origin := newTestCache(t, 1)
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	for _, key := range []string{"project/update1/bundle", "project/update2/bundle", "shared"} {
+		require.NoError(t, origin.WriteAll(ctx, key, []byte(key), nil))
+		readObject(t, cache, key)
+	}
+
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	require.NoError(t, err)
+	defer queueConn.Close()
+	require.NoError(t, ConsumeObjectsDeletedEvents(ctx, queueConn, cache))
+	err = queueConn.PublishObjectsDeletedEvent(ctx, queue.ObjectsDeletedEventPayload{
+		ObjectKeyPrefixes: []string{"project/update1/", "shared"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len("project/update2/bundle")), cache.Size())
+
+	_, hit := readObject(t, cache, "project/update2/bundle")
+	require.True(t, hit)
+	for _, key := range []string{"project/update1/bundle", "shared"} {
+		require.NoError(t, origin.Delete(ctx, key))
+		_, err := cache.Open(ctx, key)
+		require.Error(t, err, key)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cache, origin := newTestCache(t, 1)
+	ctx := context.Background()
+	require.NoError(t, origin.WriteAll(ctx, "bundle", []byte("bundle"), &blob.WriterOptions{
+		ContentType:     "application/javascript",
+		ContentEncoding: "gzip",
+	}))
+
+	baseURL, err := url.Parse("http://localhost/edge")
+	require.NoError(t, err)
+	signer := fileblob.NewURLSignerHMAC(baseURL, []byte("secret"))
+
+	r := gin.New()
+	r.Use(logger.NewMiddleware(zap.NewNop()))
+	AddRoutes(r, cache, signer, nil, nil)
+
+	signedURL, err := signer.URLFromKey(ctx, "bundle", &driver.SignedURLOptions{
+		Method: http.MethodGet,
+		Expiry: time.Minute,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, signedURL.String(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "bundle", rec.Body.String())
+	require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	require.Equal(t, "application/javascript", rec.Header().Get("Content-Type"))
+	require.Equal(t, "MISS", rec.Header().Get(cacheStatusHeader))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/edge?obj=bundle", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,115 @@
+package edge
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/stats"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gocloud.dev/blob/fileblob"
+	"gocloud.dev/gcerrors"
+)
+
+const cacheStatusHeader = "X-Cache"
+
+// AddRoutes serves the objects of the cache at the storage edge endpoint, the URLs are signed by
+// the storage edge URL signer, or requested with an asset token when the token signer is set
+func AddRoutes(
+	r gin.IRoutes,
+	cache *Cache,
+	signer fileblob.URLSigner,
+	tokenSigner *storage.AssetTokenSigner,
+	recorder *stats.Recorder,
+) {
+	handler := newHandler(cache, signer, tokenSigner, recorder)
+	r.GET(storage.EdgeEndpointPath, handler)
+	r.HEAD(storage.EdgeEndpointPath, handler)
+}
+
+func newHandler(
+	cache *Cache,
+	signer fileblob.URLSigner,
+	tokenSigner *storage.AssetTokenSigner,
+	recorder *stats.Recorder,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		log := logger.FromContext(ctx)
+		objectKey, err := storage.ObjectKeyFromRequest(ctx, ctx.Request, signer, tokenSigner)
+		if err != nil {
+			ctx.AbortWithStatusJSON(
+				http.StatusUnauthorized,
+				api.GenericError{Error: "failed to get object key from request"},
+			)
+			return
+		}
+		defer recorder.RecordResponse(ctx, objectKey)
+
+		object, err := cache.Open(ctx, objectKey)
+		if errors.Is(err, ErrTooLarge) {
+			serveFromOrigin(ctx, cache, objectKey)
+			return
+		}
+		if err != nil {
+			abortWithOriginError(ctx, log, err)
+			return
+		}
+		defer util.CloseWithLogger(log, object)
+
+		if object.ContentType != "" {
+			ctx.Header("Content-Type", object.ContentType)
+		}
+		if object.ContentEncoding != "" {
+			ctx.Header("Content-Encoding", object.ContentEncoding)
+		}
+		cacheStatus := "MISS"
+		if object.Hit {
+			cacheStatus = "HIT"
+		}
+		ctx.Header(cacheStatusHeader, cacheStatus)
+
+		// handles HEAD and range requests of resumed downloads
+		http.ServeContent(ctx.Writer, ctx.Request, "", object.ModTime, object)
+	}
+}
+
+// serveFromOrigin streams objects too large to be cached
+func serveFromOrigin(ctx *gin.Context, cache *Cache, objectKey string) {
+	log := logger.FromContext(ctx)
+	attrs, err := cache.origin.Attributes(ctx, objectKey)
+	if err != nil {
+		abortWithOriginError(ctx, log, err)
+		return
+	}
+
+	reader, err := cache.origin.NewReader(ctx, objectKey, nil)
+	if err != nil {
+		abortWithOriginError(ctx, log, err)
+		return
+	}
+	defer util.CloseWithLogger(log, reader)
+
+	extraHeaders := map[string]string{cacheStatusHeader: "BYPASS"}
+	if attrs.ContentEncoding != "" {
+		extraHeaders["Content-Encoding"] = attrs.ContentEncoding
+	}
+	ctx.DataFromReader(http.StatusOK, reader.Size(), attrs.ContentType, reader, extraHeaders)
+}
+
+func abortWithOriginError(ctx *gin.Context, log *zap.Logger, err error) {
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, api.GenericError{Error: "object not found"})
+		return
+	}
+
+	log.Error("failed to read object from origin", zap.Error(err))
+	ctx.AbortWithStatusJSON(
+		http.StatusBadGateway,
+		api.GenericError{Error: "failed to read object from origin"},
+	)
+}
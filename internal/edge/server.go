@@ -0,0 +1,117 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/clientip"
+	"github.com/a-gierczak/paratrooper/internal/listener"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/stats"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	ginzap "github.com/gin-contrib/zap"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Config of the dedicated edge server, it needs the storage config of the API server,
+// including the edge secret key. The database is optional, it's used only to record download
+// stats. NATS is optional too, it's used only to purge deleted objects from the cache.
+type Config struct {
+	DebugMode   bool   `env:"DEBUG"`
+	PostgresDSN string `env:"POSTGRES_DSN"`
+	NATSURL     string `env:"NATS_URL"`
+	Storage     storage.Config
+	Cache       CacheConfig
+	Listener    listener.Config
+	ClientIP    clientip.Config
+	Log         logger.Config
+	RequestLog  logger.RequestLogConfig
+}
+
+func Run(config Config, log *zap.Logger) error {
+	log = logger.Component(log, logger.ComponentStorage)
+	ctx := logger.ContextWithLogger(context.Background(), log)
+
+	if config.DebugMode {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	if config.Cache.Dir == "" {
+		return errors.New("EDGE_CACHE_DIR is required")
+	}
+
+	storageDriver, err := storage.Init(ctx, &config.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to init storage: %w", err)
+	}
+	if storageDriver.EdgeURLSigner() == nil {
+		return errors.New("STORAGE_EDGE_BASE_URL is required")
+	}
+
+	cache, err := NewCache(ctx, storageDriver.Bucket(), config.Cache)
+	if err != nil {
+		return fmt.Errorf("failed to init edge cache: %w", err)
+	}
+	defer cache.Close()
+
+	if config.NATSURL != "" {
+		queueConn, err := queue.ConnectLazily(ctx, config.NATSURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+		defer queueConn.Close()
+		if err := ConsumeObjectsDeletedEvents(ctx, queueConn, cache); err != nil {
+			return err
+		}
+	}
+
+	var recorder *stats.Recorder
+	if config.PostgresDSN != "" {
+		pgConn, err := pgxpool.New(ctx, config.PostgresDSN)
+		if err != nil {
+			return fmt.Errorf("failed create a connection pool to postgres: %w", err)
+		}
+		defer pgConn.Close()
+		recorder = stats.NewRecorder(db.New(pgConn))
+		go recorder.Run(ctx)
+	}
+
+	r := gin.New()
+	// cancel the reads from the origin when the client disconnects
+	r.ContextWithFallback = true
+	if err := clientip.Configure(r, config.ClientIP); err != nil {
+		return err
+	}
+	r.Use(logger.NewMiddleware(log))
+	r.Use(clientip.NewMiddleware())
+	r.Use(logger.NewRequestLogMiddleware(log, config.RequestLog))
+	r.Use(ginzap.RecoveryWithZap(log, true))
+
+	AddRoutes(
+		r,
+		cache,
+		storageDriver.EdgeURLSigner(),
+		storageDriver.AssetTokenSigner(),
+		recorder,
+	)
+	r.GET("/health", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"cacheSizeBytes": cache.Size()})
+	})
+
+	l, err := listener.Listen(ctx, config.Listener)
+	if err != nil {
+		return err
+	}
+
+	log.Info("edge server started")
+	return (&http.Server{Handler: r}).Serve(l)
+}
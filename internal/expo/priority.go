@@ -0,0 +1,75 @@
+package expo
+
+import (
+	"errors"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+// defaultAssetPriorities apply to projects without priorities of their own, images are usually
+// shown on the first screen
+var defaultAssetPriorities = []string{"image/*"}
+
+var priorityExtRegex = regexp.MustCompile(`^\.[a-zA-Z0-9\.\-]+$`)
+
+// ValidateAssetPriority checks an asset priority of a project, either a file extension like
+// .ttf or a content type like image/png, image/* matches all images
+func ValidateAssetPriority(priority string) error {
+	if strings.HasPrefix(priority, ".") {
+		if !priorityExtRegex.MatchString(priority) {
+			return errors.New("invalid file extension " + priority)
+		}
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(priority)
+	if err != nil || len(params) > 0 || !strings.Contains(mediaType, "/") ||
+		strings.HasPrefix(mediaType, "*/") {
+		return errors.New("invalid content type " + priority)
+	}
+	return nil
+}
+
+// matchesPriority reports whether the asset has the extension or the content type of the
+// priority
+func matchesPriority(priority string, asset *ManifestAsset) bool {
+	if strings.HasPrefix(priority, ".") {
+		return strings.EqualFold(priority, asset.FileExtension)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(asset.ContentType)
+	if err != nil {
+		return false
+	}
+	priority = strings.ToLower(priority)
+	if prefix, wildcard := strings.CutSuffix(priority, "/*"); wildcard {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return mediaType == priority
+}
+
+// prefetchAssets returns the keys of the assets to download first, the launch asset and then
+// the assets matching the priorities, in the order of the priorities. Other assets aren't
+// listed, clients download them in any order.
+func prefetchAssets(
+	priorities []string,
+	launchAsset ManifestAsset,
+	assets []ManifestAsset,
+) []string {
+	if len(priorities) == 0 {
+		priorities = defaultAssetPriorities
+	}
+
+	keys := []string{launchAsset.Key}
+	listed := map[string]bool{launchAsset.Key: true}
+	for _, priority := range priorities {
+		for i := range assets {
+			if !listed[assets[i].Key] && matchesPriority(priority, &assets[i]) {
+				keys = append(keys, assets[i].Key)
+				listed[assets[i].Key] = true
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,38 @@
+package expo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAssetPriority(t *testing.T) {
+	for _, priority := range []string{".ttf", ".tar.gz", "image/png", "image/*", "font/woff2"} {
+		require.NoError(t, ValidateAssetPriority(priority), priority)
+	}
+	for _, priority := range []string{"", ".", "ttf", "image", "*/*", "image/png; q=1", ".t f"} {
+		require.Error(t, ValidateAssetPriority(priority), priority)
+	}
+}
+
+func TestPrefetchAssets(t *testing.T) {
+	bundle := ManifestAsset{Key: "bundle", FileExtension: ".hbc"}
+	assets := []ManifestAsset{
+		{Key: "font", FileExtension: ".ttf", ContentType: "font/ttf"},
+		{Key: "logo", FileExtension: ".png", ContentType: "image/png"},
+		{Key: "data", FileExtension: ".json", ContentType: "application/json"},
+		{Key: "photo", FileExtension: ".JPG", ContentType: "image/jpeg"},
+	}
+
+	require.Equal(t, []string{"bundle", "logo", "photo"}, prefetchAssets(nil, bundle, assets))
+	require.Equal(
+		t,
+		[]string{"bundle", "font", "photo", "logo"},
+		prefetchAssets([]string{".ttf", ".jpg", "image/*"}, bundle, assets),
+	)
+	require.Equal(
+		t,
+		[]string{"bundle"},
+		prefetchAssets([]string{"video/*"}, bundle, assets),
+	)
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/cache"
 	"github.com/a-gierczak/paratrooper/internal/storage"
 
-	"gocloud.dev/blob"
+	"github.com/google/uuid"
 )
 
 type Manifest struct {
@@ -29,53 +32,159 @@ type ManifestAsset struct {
 	Url           string `json:"url"`
 }
 
+// ManifestExtensions is sent in the extensions part of the update response
+type ManifestExtensions struct {
+	AssetRequestHeaders map[string]map[string]string `json:"assetRequestHeaders,omitempty"`
+	// PrefetchAssets are the keys of the assets clients with selective downloads fetch first,
+	// the launch asset first
+	PrefetchAssets []string `json:"prefetchAssets,omitempty"`
+	// DownloadSize is the total size in bytes of the assets of the manifest
+	DownloadSize int64 `json:"downloadSize,omitempty"`
+}
+
 type service struct {
 	q       *db.Queries
 	storage *storage.Storage
+	// cache of the signed download URLs, nil disables caching
+	cache cache.Cache
 }
 
 type Service interface {
 	UpdateManifest(
 		ctx context.Context,
+		project db.Project,
 		update db.Update,
 		platform string,
-	) (*Manifest, error)
+	) (*Manifest, *ManifestExtensions, error)
 }
 
-func NewService(q *db.Queries, st *storage.Storage) Service {
-	return &service{q, st}
+func NewService(q *db.Queries, st *storage.Storage, c cache.Cache) Service {
+	return &service{q, st, c}
 }
 
 func (svc *service) UpdateManifest(
 	ctx context.Context,
+	project db.Project,
 	update db.Update,
 	platform string,
-) (*Manifest, error) {
+) (*Manifest, *ManifestExtensions, error) {
 	updateAssets, err := svc.q.GetUpdateAssetsByPlatform(ctx, update.ID, platform)
 	if err != nil {
-		return nil, fmt.Errorf("GetUpdateAssetsByPlatform: %w", err)
+		return nil, nil, fmt.Errorf("GetUpdateAssetsByPlatform: %w", err)
 	}
 
 	if len(updateAssets) == 0 {
-		return nil, fmt.Errorf("no assets found for update %s", update.ID)
+		return nil, nil, fmt.Errorf("no assets found for update %s", update.ID)
+	}
+
+	replica, err := svc.downloadReplica(ctx, project, update)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Expo clients send the request headers of the manifest extensions with every asset request
+	strategy := svc.storage.AssetURLStrategyFor(project, replica, true)
+	cdnSigner := svc.storage.CDNSigner()
+	tokenSigner := svc.storage.AssetTokenSigner()
+	extensions := &ManifestExtensions{}
+	if strategy == storage.AssetURLCDN || strategy == storage.AssetURLToken {
+		extensions.AssetRequestHeaders = make(map[string]map[string]string)
+	}
+	// cookies are signed per update the objects belong to, linked updates and shared files
+	// use the objects of other updates
+	cookieHeaders := make(map[string]string)
+	cdnCookieHeader := func(objectKey string) (string, error) {
+		_, objectsUpdateID, _ := storage.AssetObjectKeySegments(objectKey)
+		if header, ok := cookieHeaders[objectsUpdateID]; ok {
+			return header, nil
+		}
+		updateID, err := uuid.Parse(objectsUpdateID)
+		if err != nil {
+			return "", fmt.Errorf("invalid asset object key %s: %w", objectKey, err)
+		}
+		cookies, err := cdnSigner.UpdateCookies(
+			update.ProjectID,
+			updateID,
+			storage.SignedDownloadURLExpiry(),
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign CDN cookies: %w", err)
+		}
+
+		cookieValues := make([]string, 0, len(cookies))
+		for _, cookie := range cookies {
+			cookieValues = append(cookieValues, cookie.String())
+		}
+		cookieHeaders[objectsUpdateID] = strings.Join(cookieValues, "; ")
+		return cookieHeaders[objectsUpdateID], nil
 	}
 
+	var signedURLs map[string]string
+	if strategy == storage.AssetURLReplica || strategy == storage.AssetURLSigned {
+		bucket := svc.storage.Bucket()
+		bucketName := primaryBucketName
+		if strategy == storage.AssetURLReplica {
+			bucket = replica.Bucket()
+			bucketName = replica.Region
+		}
+		objectKeys := make([]string, 0, len(updateAssets))
+		for _, asset := range updateAssets {
+			objectKeys = append(objectKeys, asset.StorageObjectPath)
+		}
+		signedURLs, err = svc.signDownloadURLs(ctx, bucket, bucketName, objectKeys)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get asset URLs: %w", err)
+		}
+	}
+
+	now := time.Now()
 	var launchAsset *ManifestAsset
 	manifestAssets := make([]ManifestAsset, 0)
 
 	for _, asset := range updateAssets {
 		sha256Bytes, err := hex.DecodeString(asset.ContentSha256)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode sha256: %w", err)
+			return nil, nil, fmt.Errorf("failed to decode sha256: %w", err)
 		}
 
-		assetURL, err := svc.storage.Bucket().
-			SignedURL(ctx, asset.StorageObjectPath, &blob.SignedURLOptions{
-				Method: "GET",
-				Expiry: storage.DownloadURLExpiry,
-			})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get asset URL: %w", err)
+		var assetURL string
+		switch strategy {
+		case storage.AssetURLTemplated:
+			assetURL, err = templatedAssetURL(project.AssetUrlTemplate.String, asset)
+			if err != nil {
+				return nil, nil, err
+			}
+		case storage.AssetURLPublic:
+			assetURL, err = storage.PublicObjectURL(
+				project.PublicAssetsUrl.String,
+				asset.StorageObjectPath,
+			)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to build public asset URL: %w", err)
+			}
+		case storage.AssetURLStable:
+			assetURL = svc.storage.StableAssetURL(asset.ID)
+		case storage.AssetURLCDN:
+			cookieHeader, err := cdnCookieHeader(asset.StorageObjectPath)
+			if err != nil {
+				return nil, nil, err
+			}
+			assetURL = cdnSigner.AssetURL(asset.StorageObjectPath)
+			extensions.AssetRequestHeaders[asset.ContentMd5] = map[string]string{
+				"Cookie": cookieHeader,
+			}
+		case storage.AssetURLToken:
+			assetURL = tokenSigner.AssetURL(asset.StorageObjectPath)
+			extensions.AssetRequestHeaders[asset.ContentMd5] = tokenSigner.RequestHeaders(
+				asset.StorageObjectPath,
+				now,
+			)
+		case storage.AssetURLEdge:
+			assetURL, err = svc.storage.EdgeURL(ctx, asset.StorageObjectPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get edge asset URL: %w", err)
+			}
+		default:
+			assetURL = signedURLs[asset.StorageObjectPath]
 		}
 
 		manifestAsset := ManifestAsset{
@@ -85,6 +194,7 @@ func (svc *service) UpdateManifest(
 			ContentType:   asset.ContentType,
 			Url:           assetURL,
 		}
+		extensions.DownloadSize += asset.ContentLength
 		if asset.IsLaunchAsset {
 			launchAsset = &manifestAsset
 		} else {
@@ -93,8 +203,13 @@ func (svc *service) UpdateManifest(
 	}
 
 	if launchAsset == nil {
-		return nil, fmt.Errorf("no launch asset found for update %s", update.ID)
+		return nil, nil, fmt.Errorf("no launch asset found for update %s", update.ID)
 	}
+	extensions.PrefetchAssets = prefetchAssets(
+		project.AssetPriorities,
+		*launchAsset,
+		manifestAssets,
+	)
 
 	return &Manifest{
 		Id:             update.ID.String(),
@@ -102,5 +217,52 @@ func (svc *service) UpdateManifest(
 		RuntimeVersion: update.RuntimeVersion,
 		Assets:         manifestAssets,
 		LaunchAsset:    *launchAsset,
-	}, nil
+	}, extensions, nil
+}
+
+// templatedAssetURL expands the project's asset URL template with the project and the update
+// the object is stored under, shared files and the files of linked updates are stored under the
+// update that uploaded them
+func templatedAssetURL(template string, asset db.UpdateAsset) (string, error) {
+	projectID, updateID, err := storage.UpdateObjectKeyIDs(asset.StorageObjectPath)
+	if err != nil {
+		return "", err
+	}
+	_, _, filePath := storage.AssetObjectKeySegments(asset.StorageObjectPath)
+	return storage.ExpandAssetURLTemplate(template, storage.AssetURLVars{
+		ProjectID: projectID,
+		UpdateID:  updateID,
+		ObjectKey: asset.StorageObjectPath,
+		Path:      filePath,
+		SHA256:    asset.ContentSha256,
+		MD5:       asset.ContentMd5,
+	}), nil
+}
+
+// downloadReplica returns the replica the client downloads the assets of the update from, nil
+// when the update wasn't copied to the replica of the client's region, e.g. when the region was
+// added to the project after the update was published
+func (svc *service) downloadReplica(
+	ctx context.Context,
+	project db.Project,
+	update db.Update,
+) (*storage.Replica, error) {
+	replica := svc.storage.DownloadReplica(ctx, project.ReplicaRegions)
+	if replica == nil {
+		return nil, nil
+	}
+
+	// linked updates serve the objects of the update they were published with
+	updateID := update.ID
+	if update.LinkedUpdateID.Valid {
+		updateID = update.LinkedUpdateID.Bytes
+	}
+	regions, err := svc.q.GetUpdateReplicaRegions(ctx, updateID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpdateReplicaRegions: %w", err)
+	}
+	if !slices.Contains(regions, replica.Region) {
+		return nil, nil
+	}
+	return replica, nil
 }
@@ -0,0 +1,187 @@
+package expo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/geo"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"go.uber.org/zap"
+)
+
+const (
+	primaryBaseURL = "https://primary.example.com/assets"
+	euBaseURL      = "https://eu.example.com/assets"
+)
+
+// newReplicatedStorage returns external storage with signed file URLs and a replica serving
+// the clients in Germany
+func newReplicatedStorage(t *testing.T, ctx context.Context) *storage.Storage {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "secret.key")
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath, key, 0o600))
+
+	driverURL := func(name string, baseURL string) string {
+		return fmt.Sprintf(
+			"file://%s?create_dir=true&base_url=%s&secret_key_path=%s",
+			filepath.Join(dir, name),
+			baseURL,
+			keyPath,
+		)
+	}
+	replicas, err := json.Marshal([]storage.ReplicaConfig{{
+		Region:    "eu",
+		DriverURL: driverURL("eu", euBaseURL),
+		Countries: []string{"DE"},
+	}})
+	require.NoError(t, err)
+	replicasFile := filepath.Join(dir, "replicas.json")
+	require.NoError(t, os.WriteFile(replicasFile, replicas, 0o600))
+
+	st, err := storage.Init(ctx, &storage.Config{
+		DriverURL:    driverURL("primary", primaryBaseURL),
+		ReplicasFile: replicasFile,
+	})
+	require.NoError(t, err)
+	return st
+}
+
+// createTestUpdate creates an update of the project with a launch asset at the object key
+func createTestUpdate(
+	t *testing.T,
+	ctx context.Context,
+	q *db.Queries,
+	projectID uuid.UUID,
+	objectKey func(updateID uuid.UUID) string,
+) db.Update {
+	update := db.Update{
+		ID:             uuid.Must(uuid.NewV7()),
+		ProjectID:      projectID,
+		RuntimeVersion: "1.0.0",
+		Channel:        "production",
+	}
+	err := q.CreateUpdate(ctx, db.CreateUpdateParams{
+		ID:             update.ID,
+		ProjectID:      update.ProjectID,
+		RuntimeVersion: update.RuntimeVersion,
+		Message:        pgtype.Text{String: "test", Valid: true},
+		Channel:        update.Channel,
+	})
+	require.NoError(t, err)
+
+	sha := sha256.Sum256([]byte(update.ID.String()))
+	_, err = q.CreateUpdateAssets(ctx, []db.CreateUpdateAssetsParams{{
+		ID:                uuid.Must(uuid.NewV7()),
+		UpdateID:          update.ID,
+		StorageObjectPath: objectKey(update.ID),
+		ContentType:       "application/javascript",
+		Extension:         ".bundle",
+		ContentMd5:        "md5-" + update.ID.String(),
+		ContentSha256:     hex.EncodeToString(sha[:]),
+		IsLaunchAsset:     true,
+		Platform:          "ios",
+		ContentLength:     6,
+	}})
+	require.NoError(t, err)
+	return update
+}
+
+func TestUpdateManifestReplica(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	ctr, err := postgres.Run(ctx,
+		"postgres:13",
+		postgres.WithInitScripts(filepath.Join("..", "..", "db", "schema.sql")),
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("user"),
+		postgres.WithPassword("password"),
+		postgres.BasicWaitStrategies(),
+		postgres.WithSQLDriver("pgx"),
+	)
+	defer testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	dbDsn, err := ctr.ConnectionString(ctx)
+	require.NoError(t, err)
+	conn, err := pgx.Connect(ctx, dbDsn)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+	q := db.New(conn)
+
+	project, err := q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           "test_expo",
+		UpdateProtocol: db.UpdateProtocolExpo,
+		Environment:    "production",
+	})
+	require.NoError(t, err)
+	launchAssetKey := func(updateID uuid.UUID) string {
+		return storage.AssetObjectKey(project.ID, updateID, "bundles/ios.js")
+	}
+
+	// published before the project replicated to the region
+	before := createTestUpdate(t, ctx, q, project.ID, launchAssetKey)
+	project, err = q.SetProjectReplicaRegions(ctx, project.ID, []string{"eu"})
+	require.NoError(t, err)
+	after := createTestUpdate(t, ctx, q, project.ID, launchAssetKey)
+	require.NoError(t, q.CreateUpdateReplicas(ctx, after.ID, []string{"eu"}))
+
+	svc := NewService(q, newReplicatedStorage(t, ctx), nil)
+	germanyCtx := context.WithValue(ctx, geo.ContextKey, "DE")
+
+	manifest, _, err := svc.UpdateManifest(germanyCtx, project, before, "ios")
+	require.NoError(t, err)
+	require.Contains(t, manifest.LaunchAsset.Url, primaryBaseURL)
+
+	manifest, _, err = svc.UpdateManifest(germanyCtx, project, after, "ios")
+	require.NoError(t, err)
+	require.Contains(t, manifest.LaunchAsset.Url, euBaseURL)
+
+	// clients outside of the replica's countries download from the primary bucket
+	manifest, _, err = svc.UpdateManifest(ctx, project, after, "ios")
+	require.NoError(t, err)
+	require.Contains(t, manifest.LaunchAsset.Url, primaryBaseURL)
+}
+
+func TestTemplatedAssetURL(t *testing.T) {
+	projectID := uuid.New()
+	// the logo didn't change since the earlier update, the update shares its object
+	earlierID := uuid.New()
+	asset := db.UpdateAsset{
+		UpdateID:          uuid.New(),
+		StorageObjectPath: storage.AssetObjectKey(projectID, earlierID, "assets/logo.png"),
+		ContentSha256:     "sha",
+	}
+
+	assetURL, err := templatedAssetURL(
+		"https://assets.example.com/{project}/{update}/{path}?v={sha256}",
+		asset,
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"https://assets.example.com/"+projectID.String()+"/"+earlierID.String()+
+			"/assets/logo.png?v=sha",
+		assetURL,
+	)
+}
@@ -0,0 +1,137 @@
+package expo
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/signing"
+)
+
+// SignatureRequest is the expo-expect-signature header of a client with code signing enabled
+type SignatureRequest struct {
+	// KeyID of the certificate embedded in the app, empty when it's not set
+	KeyID     string
+	Algorithm string
+}
+
+// ParseExpectSignature parses the structured field dictionary of the expo-expect-signature
+// header, e.g. `sig, keyid="main", alg="rsa-v1_5-sha256"`
+func ParseExpectSignature(header string) (*SignatureRequest, error) {
+	dictionary, err := parseDictionary(header)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &SignatureRequest{
+		KeyID:     dictionary["keyid"],
+		Algorithm: dictionary["alg"],
+	}
+	if request.Algorithm == "" {
+		request.Algorithm = signing.AlgorithmRSASHA256
+	}
+	if request.Algorithm != signing.AlgorithmRSASHA256 {
+		return nil, fmt.Errorf("unsupported signature algorithm %s", request.Algorithm)
+	}
+	return request, nil
+}
+
+// Signer signs the parts of update responses with a signing key of the project
+type Signer struct {
+	key   *rsa.PrivateKey
+	keyID string
+	// CertificateChain is sent in the certificate_chain part when the signing certificate
+	// isn't the one embedded in the app, it's empty otherwise
+	CertificateChain string
+}
+
+func NewSigner(key *db.SigningKey) (*Signer, error) {
+	privateKey, err := signing.ParsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	certificates, err := signing.ParseCertificateChain(key.CertificateChain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate chain: %w", err)
+	}
+
+	signer := &Signer{key: privateKey, keyID: key.KeyID}
+	if len(certificates) > 1 {
+		signer.CertificateChain = key.CertificateChain
+	}
+	return signer, nil
+}
+
+// Signature returns the expo-signature header of the part with the body
+func (s *Signer) Signature(body []byte) (string, error) {
+	digest := sha256.Sum256(body)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"sig=%s, keyid=%s, alg=%s",
+		strconv.Quote(base64.StdEncoding.EncodeToString(signature)),
+		strconv.Quote(s.keyID),
+		strconv.Quote(signing.AlgorithmRSASHA256),
+	), nil
+}
+
+// parseDictionary parses a structured field dictionary (RFC 8941) of string and token values,
+// members without a value are true booleans, parameters of the members are ignored
+func parseDictionary(header string) (map[string]string, error) {
+	dictionary := make(map[string]string)
+	for _, member := range splitOutsideStrings(header, ',') {
+		member = strings.TrimSpace(splitOutsideStrings(member, ';')[0])
+		if member == "" {
+			return nil, errors.New("empty dictionary member")
+		}
+
+		key, value, hasValue := strings.Cut(member, "=")
+		key = strings.TrimSpace(key)
+		if !hasValue {
+			dictionary[key] = "?1"
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string value of %s: %w", key, err)
+			}
+			value = unquoted
+		}
+		dictionary[key] = value
+	}
+	return dictionary, nil
+}
+
+// splitOutsideStrings splits the header by the separator, except in quoted strings
+func splitOutsideStrings(header string, separator byte) []string {
+	var parts []string
+	var inString, escaped bool
+	start := 0
+	for i := 0; i < len(header); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && header[i] == '\\':
+			escaped = true
+		case header[i] == '"':
+			inString = !inString
+		case !inString && header[i] == separator:
+			parts = append(parts, header[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, header[start:])
+}
@@ -0,0 +1,95 @@
+package expo
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpectSignature(t *testing.T) {
+	request, err := ParseExpectSignature(`sig, keyid="main", alg="rsa-v1_5-sha256"`)
+	require.NoError(t, err)
+	require.Equal(t, &SignatureRequest{KeyID: "main", Algorithm: "rsa-v1_5-sha256"}, request)
+
+	request, err = ParseExpectSignature(`sig`)
+	require.NoError(t, err)
+	require.Equal(t, &SignatureRequest{Algorithm: "rsa-v1_5-sha256"}, request)
+
+	request, err = ParseExpectSignature(`keyid="a,b;c";x=1, sig=?1`)
+	require.NoError(t, err)
+	require.Equal(t, "a,b;c", request.KeyID)
+
+	_, err = ParseExpectSignature(`sig, alg="ecdsa-p256-sha256"`)
+	require.ErrorContains(t, err, "unsupported signature algorithm")
+	_, err = ParseExpectSignature(`sig, keyid="main`)
+	require.Error(t, err)
+	_, err = ParseExpectSignature(`sig,, keyid="main"`)
+	require.Error(t, err)
+}
+
+func newSigningKey(t *testing.T, certificates int) (*db.SigningKey, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "paratrooper"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	var chain []byte
+	for range certificates {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	return &db.SigningKey{
+		KeyID: "main",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		CertificateChain: string(chain),
+	}, key
+}
+
+func TestSigner(t *testing.T) {
+	signingKey, key := newSigningKey(t, 1)
+	signer, err := NewSigner(signingKey)
+	require.NoError(t, err)
+	// the certificate is embedded in the app
+	require.Empty(t, signer.CertificateChain)
+
+	body := []byte(`{"id":"update"}` + "\n")
+	header, err := signer.Signature(body)
+	require.NoError(t, err)
+
+	dictionary, err := parseDictionary(header)
+	require.NoError(t, err)
+	require.Equal(t, "main", dictionary["keyid"])
+	require.Equal(t, "rsa-v1_5-sha256", dictionary["alg"])
+	signature, err := base64.StdEncoding.DecodeString(dictionary["sig"])
+	require.NoError(t, err)
+	digest := sha256.Sum256(body)
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	signingKey, _ = newSigningKey(t, 2)
+	signer, err = NewSigner(signingKey)
+	require.NoError(t, err)
+	require.Equal(t, signingKey.CertificateChain, signer.CertificateChain)
+
+	_, err = NewSigner(&db.SigningKey{PrivateKey: "not a key"})
+	require.Error(t, err)
+}
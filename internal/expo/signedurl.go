@@ -0,0 +1,83 @@
+package expo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"golang.org/x/sync/errgroup"
+)
+
+// signConcurrency bounds the URLs signed at once for a single manifest, signing can call the
+// cloud provider, e.g. GCS without a private key signs with the IAM API
+const signConcurrency = 16
+
+// primaryBucketName names the primary bucket in the cache keys, replicas by their region
+const primaryBucketName = "primary"
+
+// signedURLCacheKey is unique per bucket, object and window, the download URLs signed in the
+// same window are the same, see storage.DownloadURLWindow
+func signedURLCacheKey(bucketName string, objectKey string, expiresAt time.Time) string {
+	return fmt.Sprintf("pt:signedurl:%s:%d:%s", bucketName, expiresAt.Unix(), objectKey)
+}
+
+// signDownloadURLs signs the download URLs of the objects concurrently, the signed URLs are
+// cached until the window ends, so manifests of the same update reuse them
+func (svc *service) signDownloadURLs(
+	ctx context.Context,
+	bucket *blob.Bucket,
+	bucketName string,
+	objectKeys []string,
+) (map[string]string, error) {
+	log := logger.FromContext(ctx)
+	expiresAt := storage.DownloadURLExpiresAt(time.Now())
+	ttl := max(int(storage.DownloadURLCacheTTL().Seconds()), 1)
+
+	signedURLs := make([]string, len(objectKeys))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(signConcurrency)
+	for i, objectKey := range objectKeys {
+		g.Go(func() error {
+			cacheKey := signedURLCacheKey(bucketName, objectKey, expiresAt)
+			if svc.cache != nil {
+				cached, err := svc.cache.Get(gctx, cacheKey)
+				if err != nil {
+					log.Warn("failed to get cached signed URL", zap.Error(err))
+				} else if cached != "" {
+					signedURLs[i] = cached
+					return nil
+				}
+			}
+
+			signedURL, err := bucket.SignedURL(gctx, objectKey, &blob.SignedURLOptions{
+				Method: "GET",
+				Expiry: storage.SignedDownloadURLExpiry(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to sign URL of %s: %w", objectKey, err)
+			}
+			signedURLs[i] = signedURL
+
+			if svc.cache != nil {
+				if err := svc.cache.Set(gctx, cacheKey, signedURL, ttl); err != nil {
+					log.Warn("failed to cache signed URL", zap.Error(err))
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	urls := make(map[string]string, len(objectKeys))
+	for i, objectKey := range objectKeys {
+		urls[objectKey] = signedURLs[i]
+	}
+	return urls, nil
+}
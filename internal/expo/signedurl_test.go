@@ -0,0 +1,52 @@
+package expo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	memorycache "github.com/a-gierczak/paratrooper/internal/cache/memory"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob/fileblob"
+)
+
+func TestSignDownloadURLs(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	baseURL, err := url.Parse("http://localhost:8080/assets")
+	require.NoError(t, err)
+	bucket, err := fileblob.OpenBucket(t.TempDir(), &fileblob.Options{
+		URLSigner: fileblob.NewURLSignerHMAC(baseURL, []byte("secret")),
+	})
+	require.NoError(t, err)
+	defer bucket.Close()
+
+	c := memorycache.New()
+	svc := &service{cache: c}
+	objectKeys := make([]string, 0, 40)
+	for i := range 40 {
+		objectKeys = append(objectKeys, fmt.Sprintf("project/update/assets/%d.png", i))
+	}
+
+	signedURLs, err := svc.signDownloadURLs(ctx, bucket, primaryBucketName, objectKeys)
+	require.NoError(t, err)
+	require.Len(t, signedURLs, len(objectKeys))
+	for _, objectKey := range objectKeys {
+		require.Contains(t, signedURLs[objectKey], url.QueryEscape(objectKey))
+	}
+
+	// signed again only when the window ended in the meantime
+	expiresAt := storage.DownloadURLExpiresAt(time.Now())
+	cacheKey := signedURLCacheKey(primaryBucketName, objectKeys[0], expiresAt)
+	require.NoError(t, c.Set(ctx, cacheKey, "http://cached", 60))
+	signedURLs, err = svc.signDownloadURLs(ctx, bucket, primaryBucketName, objectKeys[:1])
+	require.NoError(t, err)
+	if storage.DownloadURLExpiresAt(time.Now()).Equal(expiresAt) {
+		require.Equal(t, "http://cached", signedURLs[objectKeys[0]])
+	}
+}
@@ -0,0 +1,334 @@
+// Package expoimport registers the updates of an EAS Update branch, or a set of expo export
+// outputs, as updates of a project. The IDs of the updates are kept where possible, so devices
+// running them don't download them again after migrating.
+package expoimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/adminclient"
+
+	"github.com/google/uuid"
+)
+
+// metadataFileName is the file expo export writes the bundles and assets of the platforms to
+const metadataFileName = "metadata.json"
+
+// maxMessageLength is the longest message of an update
+const maxMessageLength = 500
+
+type Config struct {
+	// BaseURL of the server, e.g. http://localhost:8080
+	BaseURL   string
+	ProjectID uuid.UUID
+	// Channel the updates are imported to, usually named like the branch
+	Channel string
+	// HistoryPath is the list of updates to import, the exports are paths relative to it
+	HistoryPath string
+	// IDPlatform is the platform whose update ID is kept, EAS gives the updates of a group an
+	// ID per platform and an update has a single ID. The first update of the group when empty.
+	IDPlatform string
+	// PollInterval of the status of the committed updates
+	PollInterval time.Duration
+}
+
+func (c *Config) validate() error {
+	if c.BaseURL == "" {
+		return errors.New("base URL is required")
+	}
+	if c.ProjectID == uuid.Nil {
+		return errors.New("project ID is required")
+	}
+	if c.Channel == "" {
+		return errors.New("channel is required")
+	}
+	if c.HistoryPath == "" {
+		return errors.New("history file is required")
+	}
+	return nil
+}
+
+// Update of the history, the updates of EAS update groups share the group
+type Update struct {
+	ID             uuid.UUID `json:"id"`
+	Group          string    `json:"group"`
+	Platform       string    `json:"platform"`
+	RuntimeVersion string    `json:"runtimeVersion"`
+	Message        string    `json:"message"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Export is the output directory of expo export, or a .zip, .tar.gz or .tgz archive of it
+	Export        string         `json:"export"`
+	ExpoAppConfig map[string]any `json:"expoAppConfig"`
+}
+
+// group of updates published together, imported as a single update
+type group struct {
+	name    string
+	updates []Update
+}
+
+// ReadHistory reads the updates to import. The history is either a list of updates or an
+// object with the list in the updates field.
+func ReadHistory(r io.Reader) ([]Update, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []Update
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		var history struct {
+			Updates []Update `json:"updates"`
+		}
+		err = json.Unmarshal(content, &history)
+		updates = history.Updates
+	} else {
+		err = json.Unmarshal(content, &updates)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid update history: %w", err)
+	}
+	return updates, nil
+}
+
+// groupUpdates groups the updates by their group, updates without one are groups of their
+// own, oldest first
+func groupUpdates(updates []Update) []group {
+	groups := make([]group, 0, len(updates))
+	for _, update := range updates {
+		i := slices.IndexFunc(groups, func(g group) bool {
+			return update.Group != "" && g.name == update.Group
+		})
+		if i < 0 {
+			groups = append(groups, group{name: update.Group})
+			i = len(groups) - 1
+		}
+		groups[i].updates = append(groups[i].updates, update)
+	}
+
+	slices.SortStableFunc(groups, func(a, b group) int {
+		return a.updates[0].CreatedAt.Compare(b.updates[0].CreatedAt)
+	})
+	return groups
+}
+
+// updateID returns the ID the update of the group is imported with
+func (g group) updateID(idPlatform string) uuid.UUID {
+	for _, update := range g.updates {
+		if idPlatform != "" && update.Platform == idPlatform && update.ID != uuid.Nil {
+			return update.ID
+		}
+	}
+	return g.updates[0].ID
+}
+
+// export returns the export of the group, the updates of a group share it
+func (g group) export() (string, error) {
+	export := ""
+	for _, update := range g.updates {
+		if update.Export == "" {
+			continue
+		}
+		if export != "" && update.Export != export {
+			return "", fmt.Errorf("updates of group %s have different exports", g.name)
+		}
+		export = update.Export
+	}
+	if export == "" {
+		return "", errors.New("update has no export")
+	}
+	return export, nil
+}
+
+func (g group) message() string {
+	msg := strings.TrimSpace(g.updates[0].Message)
+	if msg == "" {
+		msg = "Imported from EAS Update"
+	}
+	if runes := []rune(msg); len(runes) > maxMessageLength {
+		msg = string(runes[:maxMessageLength])
+	}
+	return msg
+}
+
+func (g group) description() string {
+	if g.name != "" {
+		return "group " + g.name
+	}
+	if g.updates[0].ID != uuid.Nil {
+		return "update " + g.updates[0].ID.String()
+	}
+	return "update " + g.updates[0].Export
+}
+
+// Import registers the updates of the history oldest first and reports the outcome of each of
+// them to out. Updates of the project with the same ID are skipped, so an interrupted import
+// can be run again.
+func Import(ctx context.Context, client *http.Client, config Config, out io.Writer) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	historyFile, err := os.Open(config.HistoryPath)
+	if err != nil {
+		return err
+	}
+	updates, err := ReadHistory(historyFile)
+	historyFile.Close()
+	if err != nil {
+		return err
+	}
+
+	admin := &adminclient.Client{
+		HTTP:         client,
+		BaseURL:      config.BaseURL,
+		ProjectID:    config.ProjectID,
+		PollInterval: config.PollInterval,
+	}
+	for _, g := range groupUpdates(updates) {
+		updateID := g.updateID(config.IDPlatform)
+		if updateID != uuid.Nil {
+			existing, err := admin.GetUpdate(ctx, updateID)
+			if err != nil {
+				return err
+			}
+			if existing != nil && existing.Status != api.UpdateStatusPublished &&
+				existing.Status != api.UpdateStatusCanceled {
+				return fmt.Errorf(
+					"update %s of %s is %s, delete it to import it again",
+					updateID,
+					g.description(),
+					existing.Status,
+				)
+			}
+			if existing != nil {
+				fmt.Fprintf(out, "%s already imported\n", updateID)
+				continue
+			}
+		}
+
+		imported, err := importGroup(ctx, admin, config, g, updateID)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", g.description(), err)
+		}
+		if updateID != uuid.Nil && imported != updateID {
+			fmt.Fprintf(
+				out,
+				"%s imported as %s, the ID is used by another project\n",
+				updateID,
+				imported,
+			)
+			continue
+		}
+		fmt.Fprintf(out, "%s imported\n", imported)
+	}
+	return nil
+}
+
+// importGroup publishes the export of the group, with the update ID unless another project
+// has an update with it
+func importGroup(
+	ctx context.Context,
+	admin *adminclient.Client,
+	config Config,
+	g group,
+	updateID uuid.UUID,
+) (uuid.UUID, error) {
+	export, err := g.export()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	archive, err := loadExport(filepath.Join(filepath.Dir(config.HistoryPath), export))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	first := g.updates[0]
+	body := api.PrepareUpdateBody{
+		RuntimeVersion: first.RuntimeVersion,
+		Message:        g.message(),
+		Channel:        &config.Channel,
+	}
+	if updateID != uuid.Nil {
+		body.ID = &updateID
+	}
+	if !first.CreatedAt.IsZero() {
+		body.CreatedAt = &first.CreatedAt
+	}
+	if first.ExpoAppConfig != nil {
+		body.ExpoAppConfig = &first.ExpoAppConfig
+	}
+
+	imported, err := admin.PublishArchive(ctx, body, *archive)
+	if adminclient.IsValidationError(err, "id") {
+		body.ID = nil
+		imported, err = admin.PublishArchive(ctx, body, *archive)
+	}
+	return imported, err
+}
+
+// loadExport reads the archive of the export, or archives the export directory
+func loadExport(exportPath string) (*adminclient.Archive, error) {
+	info, err := os.Stat(exportPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		content, err := os.ReadFile(exportPath)
+		if err != nil {
+			return nil, err
+		}
+		return &adminclient.Archive{Name: filepath.Base(exportPath), Content: content}, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(exportPath, metadataFileName)); err != nil {
+		return nil, fmt.Errorf("%s isn't an expo export output: %w", exportPath, err)
+	}
+	content, err := zipDir(os.DirFS(exportPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %w", exportPath, err)
+	}
+	return &adminclient.Archive{Name: "export.zip", Content: content}, nil
+}
+
+// zipDir archives the files of the directory by their paths in it
+func zipDir(dir fs.FS) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	err := fs.WalkDir(dir, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		content, err := fs.ReadFile(dir, filePath)
+		if err != nil {
+			return err
+		}
+		writer, err := zipWriter.Create(filePath)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
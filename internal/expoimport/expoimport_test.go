@@ -0,0 +1,154 @@
+package expoimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var projectID = uuid.MustParse("019393ed-5085-71ec-943a-1c71617a6282")
+
+func writeExport(t *testing.T, dir string) {
+	files := map[string]string{
+		"metadata.json":                           `{"version":0,"bundler":"metro","fileMetadata":{}}`,
+		"_expo/static/js/ios/entry.hbc":           "ios",
+		"assets/4f1cb2cac2370cd5050681232e8575a8": "png",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+	}
+}
+
+func TestGroupUpdates(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	iosID, androidID := uuid.New(), uuid.New()
+	groups := groupUpdates([]Update{
+		{ID: uuid.New(), CreatedAt: day.Add(48 * time.Hour)},
+		{ID: iosID, Group: "g1", Platform: "ios", CreatedAt: day, Export: "g1"},
+		{ID: androidID, Group: "g1", Platform: "android", CreatedAt: day},
+		{ID: uuid.New(), Group: "g2", CreatedAt: day.Add(24 * time.Hour), Export: "a"},
+		{ID: uuid.New(), Group: "g2", CreatedAt: day.Add(24 * time.Hour), Export: "b"},
+	})
+
+	require.Len(t, groups, 3)
+	require.Equal(t, "g1", groups[0].name)
+	require.Equal(t, iosID, groups[0].updateID(""))
+	require.Equal(t, androidID, groups[0].updateID("android"))
+	require.Equal(t, iosID, groups[0].updateID("web"))
+	export, err := groups[0].export()
+	require.NoError(t, err)
+	require.Equal(t, "g1", export)
+
+	_, err = groups[1].export()
+	require.ErrorContains(t, err, "different exports")
+	_, err = groups[2].export()
+	require.ErrorContains(t, err, "no export")
+	require.Equal(t, "Imported from EAS Update", groups[2].message())
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	writeExport(t, filepath.Join(dir, "exports", "1"))
+	importedID, takenID, newID := uuid.New(), uuid.New(), uuid.New()
+	history, err := json.Marshal(map[string]any{"updates": []map[string]any{
+		{"id": takenID, "group": "g2", "platform": "ios", "runtimeVersion": "1.0.0",
+			"createdAt": "2024-06-02T00:00:00Z", "export": "exports/1"},
+		{"id": importedID, "group": "g1", "platform": "ios", "runtimeVersion": "1.0.0",
+			"message": "first", "createdAt": "2024-06-01T00:00:00Z", "export": "exports/1",
+			"expoAppConfig": map[string]any{"name": "app"}},
+	}})
+	require.NoError(t, err)
+	historyPath := filepath.Join(dir, "history.json")
+	require.NoError(t, os.WriteFile(historyPath, history, 0644))
+
+	prepared := make([]api.PrepareUpdateBody, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/api/v1/admin/%s", projectID))
+		switch {
+		case path == "/update/"+importedID.String():
+			json.NewEncoder(w).Encode(api.Update{Status: api.UpdateStatusPublished})
+		case path == "/update/"+takenID.String():
+			w.WriteHeader(http.StatusNotFound)
+		case path == "/update":
+			var body api.PrepareUpdateBody
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			prepared = append(prepared, body)
+			if body.ID != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(api.ValidationErrorJSONResponse{
+					Errors: []api.ValidationFieldError{{Field: "id", Message: "taken"}},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(api.PrepareUpdateResponse{UpdateID: newID})
+		case path == "/update/"+newID.String()+"/assets":
+			file, _, err := r.FormFile("export.zip")
+			require.NoError(t, err)
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			require.NoError(t, err)
+			names := make([]string, 0)
+			for _, f := range zipReader.File {
+				names = append(names, f.Name)
+			}
+			require.ElementsMatch(t, []string{
+				"metadata.json",
+				"_expo/static/js/ios/entry.hbc",
+				"assets/4f1cb2cac2370cd5050681232e8575a8",
+			}, names)
+		case path == "/update/"+newID.String():
+			json.NewEncoder(w).Encode(api.Update{Status: api.UpdateStatusPublished})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	out := new(bytes.Buffer)
+	err = Import(context.Background(), server.Client(), Config{
+		BaseURL:      server.URL,
+		ProjectID:    projectID,
+		Channel:      "main",
+		HistoryPath:  historyPath,
+		PollInterval: time.Millisecond,
+	}, out)
+	require.NoError(t, err)
+
+	require.Equal(
+		t,
+		fmt.Sprintf(
+			"%s already imported\n%s imported as %s, the ID is used by another project\n",
+			importedID,
+			takenID,
+			newID,
+		),
+		out.String(),
+	)
+	// retried without the ID taken by another project
+	require.Len(t, prepared, 2)
+	require.Equal(t, takenID, *prepared[0].ID)
+	require.Nil(t, prepared[1].ID)
+	require.Equal(t, "main", *prepared[1].Channel)
+	require.Equal(t, "Imported from EAS Update", prepared[1].Message)
+	require.Equal(t, time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), prepared[1].CreatedAt.UTC())
+	require.Equal(t, ".zip", prepared[1].Archive.Extension)
+}
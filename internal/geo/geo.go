@@ -0,0 +1,37 @@
+// Package geo resolves the country of the client from a header set by the CDN or load balancer
+package geo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContextKey = "client_country"
+
+type Config struct {
+	// CountryHeader carries the ISO 3166-1 alpha-2 country code of the client,
+	// e.g. CloudFront-Viewer-Country or CF-IPCountry
+	CountryHeader string `env:"GEO_COUNTRY_HEADER,default=CloudFront-Viewer-Country"`
+}
+
+// NewMiddleware stores the upper-cased country of the client in the request context,
+// requests without the header have no country
+func NewMiddleware(config Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if config.CountryHeader != "" {
+			country := strings.ToUpper(strings.TrimSpace(ctx.GetHeader(config.CountryHeader)))
+			// Cloudflare sends XX for unknown and T1 for Tor clients
+			if country != "" && country != "XX" && country != "T1" {
+				ctx.Set(ContextKey, country)
+			}
+		}
+		ctx.Next()
+	}
+}
+
+func CountryFromContext(c context.Context) string {
+	country, _ := c.Value(ContextKey).(string)
+	return country
+}
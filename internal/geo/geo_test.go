@@ -0,0 +1,32 @@
+package geo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewMiddleware(Config{CountryHeader: "CF-IPCountry"}))
+
+	var country string
+	r.GET("/", func(ctx *gin.Context) {
+		country = CountryFromContext(ctx)
+	})
+
+	for header, expected := range map[string]string{
+		"de": "DE",
+		"XX": "",
+		"":   "",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("CF-IPCountry", header)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		require.Equal(t, expected, country, header)
+	}
+}
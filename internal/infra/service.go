@@ -10,30 +10,40 @@ import (
 )
 
 type Service interface {
+	// HealthCheck fails when the database is unreachable
 	HealthCheck(ctx context.Context) error
+	// QueueHealthCheck fails when the queue is unreachable, the server keeps working without it
+	QueueHealthCheck() error
+	// CacheFallback returns whether the in-memory fallback is used instead of the configured
+	// cache, and the number of operations it served since the start
+	CacheFallback() (fallingBack bool, fallbacks int64)
 	Cache() cache.Cache
 }
 
 type service struct {
 	pgPool    *pgxpool.Pool
-	queueConn *queue.Connection
+	queueConn queue.Queue
 	cache     cache.Cache
 }
 
-func NewService(pgPool *pgxpool.Pool, queueConn *queue.Connection, cache cache.Cache) Service {
+func NewService(pgPool *pgxpool.Pool, queueConn queue.Queue, cache cache.Cache) Service {
 	return &service{pgPool, queueConn, cache}
 }
 
 func (svc *service) HealthCheck(ctx context.Context) error {
-	if err := svc.pgPool.Ping(ctx); err != nil {
-		return err
-	}
+	return svc.pgPool.Ping(ctx)
+}
 
-	if err := svc.queueConn.HealthCheck(); err != nil {
-		return err
-	}
+func (svc *service) QueueHealthCheck() error {
+	return svc.queueConn.HealthCheck()
+}
 
-	return nil
+func (svc *service) CacheFallback() (bool, int64) {
+	fallbackCache, ok := svc.cache.(*cache.FallbackCache)
+	if !ok {
+		return false, 0
+	}
+	return fallbackCache.FallingBack(), fallbackCache.Fallbacks()
 }
 
 func (svc *service) Cache() cache.Cache {
@@ -0,0 +1,133 @@
+// Package leader makes singleton background jobs run on a single worker replica at a time
+package leader
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	// RetryInterval is the time between attempts of standby replicas to take over a job,
+	// and between checks that the leader's connection is still alive
+	RetryInterval time.Duration `env:"LEADER_RETRY_INTERVAL,default=15s"`
+}
+
+// Elector elects the replica running a job with Postgres advisory locks. The lock is held by
+// a connection taken out of the pool, so it's released by Postgres when the leader dies.
+type Elector struct {
+	pool   *pgxpool.Pool
+	config Config
+}
+
+func NewElector(pool *pgxpool.Pool, config Config) *Elector {
+	return &Elector{pool: pool, config: config}
+}
+
+// lockKey derives the advisory lock key from the job name
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("paratrooper:" + name))
+	return int64(h.Sum64())
+}
+
+// Run runs the job while the replica is the leader of it, the other replicas wait on standby.
+// The job's context is canceled when the leadership is lost, the job is started again once it's
+// regained. Run returns when ctx is canceled or the job returns on its own.
+func (e *Elector) Run(ctx context.Context, name string, job func(ctx context.Context)) {
+	log := logger.FromContext(ctx).With(zap.String("job", name))
+	ctx = logger.ContextWithLogger(ctx, log)
+
+	ticker := time.NewTicker(e.config.RetryInterval)
+	defer ticker.Stop()
+	for {
+		done, err := e.lead(ctx, name, job)
+		if err != nil {
+			log.Warn("failed to run job as the leader", zap.Error(err))
+		}
+		if done {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead runs the job if the lock is acquired, done is true when the job returned on its own
+func (e *Elector) lead(
+	ctx context.Context,
+	name string,
+	job func(ctx context.Context),
+) (done bool, err error) {
+	poolConn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	acquired, err := db.New(poolConn).TryAdvisoryLock(ctx, lockKey(name))
+	if err != nil || !acquired {
+		poolConn.Release()
+		if err != nil {
+			return false, fmt.Errorf("TryAdvisoryLock: %w", err)
+		}
+		return false, nil
+	}
+
+	// the connection must not go back to the pool while holding the lock,
+	// closing it releases the lock
+	conn := poolConn.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	log := logger.FromContext(ctx)
+	log.Info("became the leader, starting job")
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobDone := make(chan struct{})
+	go func() {
+		defer close(jobDone)
+		job(jobCtx)
+	}()
+
+	lost := e.watch(jobCtx, conn, jobDone)
+	cancel()
+	<-jobDone
+
+	if lost {
+		log.Warn("lost the leadership, job stopped")
+		return false, nil
+	}
+	return ctx.Err() == nil, nil
+}
+
+// watch pings the connection holding the lock until the job is done, it returns true when
+// the connection is lost, and another replica can take over the job
+func (e *Elector) watch(ctx context.Context, conn *pgx.Conn, jobDone <-chan struct{}) bool {
+	ticker := time.NewTicker(e.config.RetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-jobDone:
+			return false
+		case <-ticker.C:
+			if err := conn.Ping(ctx); err != nil && ctx.Err() == nil {
+				logger.FromContext(ctx).Error("leader connection lost", zap.Error(err))
+				return true
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"go.uber.org/zap"
+)
+
+func TestLockKey(t *testing.T) {
+	require.Equal(t, lockKey("integrity-verifier"), lockKey("integrity-verifier"))
+	require.NotEqual(t, lockKey("integrity-verifier"), lockKey("janitor"))
+}
+
+// replica runs the job of an elector with its own pool, like a worker replica
+type replica struct {
+	pool    *pgxpool.Pool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func startReplica(t *testing.T, ctx context.Context, dbDsn string, config Config) *replica {
+	pool, err := pgxpool.New(ctx, dbDsn)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	r := &replica{
+		pool:    pool,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		started: make(chan struct{}, 10),
+		stopped: make(chan struct{}, 10),
+	}
+	t.Cleanup(func() {
+		r.cancel()
+		<-r.done
+		r.pool.Close()
+	})
+
+	go func() {
+		defer close(r.done)
+		NewElector(pool, config).Run(ctx, "job", func(ctx context.Context) {
+			r.started <- struct{}{}
+			<-ctx.Done()
+			r.stopped <- struct{}{}
+		})
+	}()
+	return r
+}
+
+func waitFor(t *testing.T, ch <-chan struct{}, msg string) {
+	select {
+	case <-ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal(msg)
+	}
+}
+
+func TestElectorFailover(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	ctr, err := postgres.Run(ctx,
+		"postgres:13",
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("user"),
+		postgres.WithPassword("password"),
+		postgres.BasicWaitStrategies(),
+		postgres.WithSQLDriver("pgx"),
+	)
+	defer testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	dbDsn, err := ctr.ConnectionString(ctx)
+	require.NoError(t, err)
+	adminConn, err := pgx.Connect(ctx, dbDsn)
+	require.NoError(t, err)
+	defer adminConn.Close(ctx)
+
+	config := Config{RetryInterval: 100 * time.Millisecond}
+	leader := startReplica(t, ctx, dbDsn, config)
+	waitFor(t, leader.started, "the first replica didn't become the leader")
+	standby := startReplica(t, ctx, dbDsn, config)
+
+	// the standby replica doesn't run the job while the leader holds the lock
+	select {
+	case <-standby.started:
+		t.Fatal("the job runs on both replicas")
+	case <-time.After(5 * config.RetryInterval):
+	}
+
+	// the leader's replica loses its database, the pool first, so it can't take the lock back
+	leader.pool.Close()
+	_, err = adminConn.Exec(
+		ctx,
+		"select pg_terminate_backend(pid) from pg_locks where locktype = 'advisory' and granted",
+	)
+	require.NoError(t, err)
+	waitFor(t, leader.stopped, "the job kept running after the leader's connection was lost")
+	waitFor(t, standby.started, "the standby replica didn't take over the job")
+
+	// canceling the new leader stops the job and releases the lock right away
+	standby.cancel()
+	waitFor(t, standby.stopped, "the job kept running after the leader was canceled")
+	waitFor(t, standby.done, "Run didn't return after the leader was canceled")
+	acquired, err := db.New(adminConn).TryAdvisoryLock(ctx, lockKey("job"))
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
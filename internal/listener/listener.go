@@ -0,0 +1,137 @@
+package listener
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// Config of a listener, the Unix socket takes precedence over the TCP address
+type Config struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT,default=8080"`
+	// UnixSocketPath for sidecar or reverse proxy setups
+	UnixSocketPath string `env:"UNIX_SOCKET_PATH"`
+	// UnixSocketMode is the octal permission of the socket file, e.g. 660
+	UnixSocketMode string `env:"UNIX_SOCKET_MODE,default=660"`
+	// TLSCertPath and TLSKeyPath make the listener serve TLS
+	TLSCertPath string `env:"TLS_CERT_PATH"`
+	TLSKeyPath  string `env:"TLS_KEY_PATH"`
+	// TLSClientCAPath makes the listener verify client certificates signed by the CA,
+	// clients without a certificate are still accepted
+	TLSClientCAPath string `env:"TLS_CLIENT_CA_PATH"`
+}
+
+func (c Config) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+func Listen(ctx context.Context, config Config) (net.Listener, error) {
+	l, err := listen(ctx, config)
+	if err != nil || config.TLSCertPath == "" {
+		return l, err
+	}
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	logger.FromContext(ctx).Info(
+		"serving TLS",
+		zap.Bool("verify_client_certs", tlsConfig.ClientCAs != nil),
+	)
+	return tls.NewListener(l, tlsConfig), nil
+}
+
+func (c Config) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.TLSCertPath, c.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.TLSClientCAPath != "" {
+		caPEM, err := os.ReadFile(c.TLSClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("client CA file contains no certificates")
+		}
+		tlsConfig.ClientCAs = clientCAs
+		// the device facing endpoints are called without certificates,
+		// the endpoints requiring them reject such requests themselves
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+func listen(ctx context.Context, config Config) (net.Listener, error) {
+	log := logger.FromContext(ctx)
+
+	if config.UnixSocketPath == "" {
+		l, err := net.Listen("tcp", config.Addr())
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", config.Addr(), err)
+		}
+		log.Info("listening on TCP address", zap.String("addr", l.Addr().String()))
+		return l, nil
+	}
+
+	mode, err := strconv.ParseUint(config.UnixSocketMode, 8, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unix socket mode %q: %w", config.UnixSocketMode, err)
+	}
+
+	if err := removeStaleSocket(config.UnixSocketPath); err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("unix", config.UnixSocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", config.UnixSocketPath, err)
+	}
+
+	if err := os.Chmod(config.UnixSocketPath, fs.FileMode(mode)); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("failed to set unix socket mode: %w", err)
+	}
+
+	log.Info("listening on unix socket", zap.String("path", config.UnixSocketPath))
+	return l, nil
+}
+
+// removeStaleSocket removes a socket file left over after an unclean shutdown, which would make
+// the listen fail. Other files at the path are kept, the path is likely misconfigured.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat unix socket path: %w", err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("unix socket path %s exists and is not a socket", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale unix socket: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package listener
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeTestCertificate(t *testing.T, dir string) (certPath, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+	return certPath, keyPath
+}
+
+func TestListen(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	t.Run("tcp", func(t *testing.T) {
+		l, err := Listen(ctx, Config{Host: "127.0.0.1", Port: 0})
+		require.NoError(t, err)
+		defer l.Close()
+		require.Equal(t, "tcp", l.Addr().Network())
+	})
+
+	t.Run("unix socket replaces a stale socket file", func(t *testing.T) {
+		// socket paths are limited to ~100 characters, so t.TempDir() may be too long
+		dir, err := os.MkdirTemp("", "pt")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		socketPath := filepath.Join(dir, "api.sock")
+		stale, err := net.Listen("unix", socketPath)
+		require.NoError(t, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, stale.Close())
+
+		l, err := Listen(ctx, Config{UnixSocketPath: socketPath, UnixSocketMode: "660"})
+		require.NoError(t, err)
+		defer l.Close()
+		require.Equal(t, "unix", l.Addr().Network())
+
+		info, err := os.Stat(socketPath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0660), info.Mode().Perm())
+	})
+
+	t.Run("unix socket path of a regular file", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "api.sock")
+		require.NoError(t, os.WriteFile(filePath, []byte("data"), 0600))
+
+		_, err := Listen(ctx, Config{UnixSocketPath: filePath, UnixSocketMode: "660"})
+		require.ErrorContains(t, err, "not a socket")
+
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		require.Equal(t, "data", string(data))
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		certPath, keyPath := writeTestCertificate(t, t.TempDir())
+		l, err := Listen(ctx, Config{
+			Host:        "127.0.0.1",
+			Port:        0,
+			TLSCertPath: certPath,
+			TLSKeyPath:  keyPath,
+		})
+		require.NoError(t, err)
+		defer l.Close()
+
+		go func() {
+			conn, err := l.Accept()
+			if err == nil {
+				_ = conn.(*tls.Conn).Handshake()
+				_ = conn.Close()
+			}
+		}()
+
+		conn, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		require.NoError(t, err)
+		defer conn.Close()
+		require.True(t, conn.ConnectionState().HandshakeComplete)
+	})
+
+	t.Run("invalid client CA", func(t *testing.T) {
+		dir := t.TempDir()
+		certPath, keyPath := writeTestCertificate(t, dir)
+		caPath := filepath.Join(dir, "ca.pem")
+		require.NoError(t, os.WriteFile(caPath, []byte("not a certificate"), 0600))
+
+		_, err := Listen(ctx, Config{
+			Host:            "127.0.0.1",
+			Port:            0,
+			TLSCertPath:     certPath,
+			TLSKeyPath:      keyPath,
+			TLSClientCAPath: caPath,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid socket mode", func(t *testing.T) {
+		_, err := Listen(ctx, Config{UnixSocketPath: "api.sock", UnixSocketMode: "rw"})
+		require.Error(t, err)
+	})
+}
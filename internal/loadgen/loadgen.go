@@ -0,0 +1,258 @@
+// Package loadgen simulates update checks of Expo and CodePush clients against a running server
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProtocolExpo     = "expo"
+	ProtocolCodePush = "codepush"
+)
+
+type Config struct {
+	// BaseURL of the server, e.g. http://localhost:8080
+	BaseURL     string
+	Duration    time.Duration
+	Concurrency int
+	// at least one of the projects has to be set, requests are split evenly between them
+	ExpoProjectID     uuid.UUID
+	CodePushProjectID uuid.UUID
+	// Channels are used only by CodePush, Expo clients always check the default channel
+	Channels        []string
+	RuntimeVersions []string
+	Platforms       []string
+	// UpdateIDs are sent as the currently installed update, together with IDs of
+	// unknown updates (UnknownUpdateRatio of requests) and of fresh installs
+	UpdateIDs          []uuid.UUID
+	UnknownUpdateRatio float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		BaseURL:            "http://localhost:8080",
+		Duration:           30 * time.Second,
+		Concurrency:        16,
+		Channels:           []string{"production"},
+		RuntimeVersions:    []string{"1.0.0"},
+		Platforms:          []string{"android", "ios"},
+		UnknownUpdateRatio: 0.2,
+	}
+}
+
+func (c *Config) validate() error {
+	if c.BaseURL == "" {
+		return errors.New("base URL is required")
+	}
+	if c.ExpoProjectID == uuid.Nil && c.CodePushProjectID == uuid.Nil {
+		return errors.New("at least one of Expo or CodePush project IDs is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be positive")
+	}
+	if len(c.Channels) == 0 || len(c.RuntimeVersions) == 0 || len(c.Platforms) == 0 {
+		return errors.New("channels, runtime versions and platforms can't be empty")
+	}
+	return nil
+}
+
+// Generator builds update check requests with parameters picked at random from the config
+type Generator struct {
+	config Config
+	rand   *rand.Rand
+}
+
+func NewGenerator(config Config, seed uint64) (*Generator, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &Generator{config: config, rand: rand.New(rand.NewPCG(seed, seed))}, nil
+}
+
+// Next returns the protocol of the request together with the request
+func (g *Generator) Next(ctx context.Context) (string, *http.Request, error) {
+	protocol := ProtocolExpo
+	if g.config.ExpoProjectID == uuid.Nil ||
+		(g.config.CodePushProjectID != uuid.Nil && g.rand.IntN(2) == 0) {
+		protocol = ProtocolCodePush
+	}
+
+	var requestURL string
+	if protocol == ProtocolExpo {
+		requestURL = fmt.Sprintf(
+			"%s/api/v1/public/%s/expo",
+			g.config.BaseURL,
+			g.config.ExpoProjectID,
+		)
+	} else {
+		query := url.Values{}
+		query.Set("app_version", pick(g.rand, g.config.RuntimeVersions))
+		query.Set("deployment_key", fmt.Sprintf(
+			"%s/%s/%s",
+			g.config.CodePushProjectID,
+			pick(g.rand, g.config.Platforms),
+			pick(g.rand, g.config.Channels),
+		))
+		query.Set("client_unique_id", uuid.NewString())
+		requestURL = g.config.BaseURL + "/v0.1/public/codepush/update_check?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if protocol == ProtocolExpo {
+		req.Header.Set("Expo-Platform", pick(g.rand, g.config.Platforms))
+		req.Header.Set("Expo-Runtime-Version", pick(g.rand, g.config.RuntimeVersions))
+		req.Header.Set("Expo-Protocol-Version", "1")
+		if updateID := g.currentUpdateID(); updateID != uuid.Nil {
+			req.Header.Set("Expo-Current-Update-Id", updateID.String())
+		}
+	} else if updateID := g.currentUpdateID(); updateID != uuid.Nil {
+		// CodePush sends the hash of the installed package, unknown hashes behave the same
+		query := req.URL.Query()
+		query.Set("package_hash", updateID.String())
+		req.URL.RawQuery = query.Encode()
+	}
+
+	return protocol, req, nil
+}
+
+// currentUpdateID returns uuid.Nil for fresh installs running the embedded update
+func (g *Generator) currentUpdateID() uuid.UUID {
+	if g.rand.Float64() < g.config.UnknownUpdateRatio {
+		return uuid.New()
+	}
+	if len(g.config.UpdateIDs) == 0 || g.rand.IntN(len(g.config.UpdateIDs)+1) == 0 {
+		return uuid.Nil
+	}
+	return pick(g.rand, g.config.UpdateIDs)
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.IntN(len(values))]
+}
+
+// Run sends requests from Concurrency workers until the duration elapses or ctx is canceled
+func Run(ctx context.Context, client *http.Client, config Config) (*Report, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	recorder := newRecorder()
+	var wg sync.WaitGroup
+	start := time.Now()
+	for worker := 0; worker < config.Concurrency; worker++ {
+		generator, err := NewGenerator(config, uint64(worker))
+		if err != nil {
+			return nil, err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				protocol, req, err := generator.Next(ctx)
+				if err != nil {
+					// the request URL is invalid, so every other request would fail too
+					recorder.recordError(protocol)
+					return
+				}
+				statusCode, latency, err := Do(client, req)
+				if ctx.Err() != nil {
+					// requests interrupted by the end of the run don't count
+					return
+				}
+				if err != nil {
+					recorder.recordError(protocol)
+					continue
+				}
+				recorder.record(protocol, statusCode, latency)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return recorder.report(time.Since(start)), nil
+}
+
+// Do sends the request and reads the whole response, the latency includes reading the body
+func Do(client *http.Client, req *http.Request) (statusCode int, latency time.Duration, err error) {
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, 0, err
+	}
+	return resp.StatusCode, time.Since(start), nil
+}
+
+type recorder struct {
+	mu          sync.Mutex
+	latencies   map[string][]time.Duration
+	statusCodes map[string]map[int]int
+	errors      map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies:   make(map[string][]time.Duration),
+		statusCodes: make(map[string]map[int]int),
+		errors:      make(map[string]int),
+	}
+}
+
+func (r *recorder) record(protocol string, statusCode int, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies[protocol] = append(r.latencies[protocol], latency)
+	if r.statusCodes[protocol] == nil {
+		r.statusCodes[protocol] = make(map[int]int)
+	}
+	r.statusCodes[protocol][statusCode]++
+}
+
+func (r *recorder) recordError(protocol string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[protocol]++
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{Elapsed: elapsed}
+	for _, protocol := range []string{ProtocolExpo, ProtocolCodePush} {
+		latencies := r.latencies[protocol]
+		if len(latencies) == 0 && r.errors[protocol] == 0 {
+			continue
+		}
+		report.Protocols = append(report.Protocols, NewProtocolReport(
+			protocol,
+			latencies,
+			r.statusCodes[protocol],
+			r.errors[protocol],
+		))
+	}
+	return report
+}
@@ -0,0 +1,150 @@
+package loadgen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, Percentile(latencies, 50))
+	require.Equal(t, 99*time.Millisecond, Percentile(latencies, 99))
+	require.Equal(t, time.Millisecond, Percentile(latencies[:1], 99))
+	require.Zero(t, Percentile(nil, 50))
+}
+
+func TestGenerator(t *testing.T) {
+	config := DefaultConfig()
+	config.ExpoProjectID = uuid.New()
+	config.CodePushProjectID = uuid.New()
+	config.Channels = []string{"production", "staging"}
+
+	generator, err := NewGenerator(config, 1)
+	require.NoError(t, err)
+
+	protocols := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		protocol, req, err := generator.Next(context.Background())
+		require.NoError(t, err)
+		protocols[protocol]++
+
+		if protocol == ProtocolExpo {
+			require.Contains(t, req.URL.Path, config.ExpoProjectID.String())
+			require.NotEmpty(t, req.Header.Get("Expo-Platform"))
+			require.Equal(t, "1.0.0", req.Header.Get("Expo-Runtime-Version"))
+		} else {
+			deploymentKey := req.URL.Query().Get("deployment_key")
+			require.True(t, strings.HasPrefix(deploymentKey, config.CodePushProjectID.String()))
+			require.Equal(t, "1.0.0", req.URL.Query().Get("app_version"))
+		}
+	}
+	require.Positive(t, protocols[ProtocolExpo])
+	require.Positive(t, protocols[ProtocolCodePush])
+}
+
+func TestRun(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.Duration = 100 * time.Millisecond
+	config.Concurrency = 2
+	config.ExpoProjectID = uuid.New()
+
+	report, err := Run(context.Background(), server.Client(), config)
+	require.NoError(t, err)
+	require.Len(t, report.Protocols, 1)
+	require.Equal(t, ProtocolExpo, report.Protocols[0].Protocol)
+	require.Positive(t, report.Protocols[0].Requests)
+	require.LessOrEqual(t, int64(report.Protocols[0].Requests), requests.Load())
+	require.Equal(t, report.Protocols[0].Requests, report.Protocols[0].StatusCodes[http.StatusOK])
+}
+
+// benchmarkConfig reads the target of the benchmarks from the environment, e.g.
+// LOADGEN_URL=http://localhost:8080 LOADGEN_EXPO_PROJECT=<id> go test -bench . ./internal/loadgen
+func benchmarkConfig(b *testing.B) Config {
+	config := DefaultConfig()
+	config.BaseURL = os.Getenv("LOADGEN_URL")
+	if config.BaseURL == "" {
+		b.Skip("LOADGEN_URL is not set")
+	}
+
+	var err error
+	if id := os.Getenv("LOADGEN_EXPO_PROJECT"); id != "" {
+		config.ExpoProjectID, err = uuid.Parse(id)
+		require.NoError(b, err)
+	}
+	if id := os.Getenv("LOADGEN_CODEPUSH_PROJECT"); id != "" {
+		config.CodePushProjectID, err = uuid.Parse(id)
+		require.NoError(b, err)
+	}
+	if versions := os.Getenv("LOADGEN_RUNTIME_VERSIONS"); versions != "" {
+		config.RuntimeVersions = strings.Split(versions, ",")
+	}
+	if channels := os.Getenv("LOADGEN_CHANNELS"); channels != "" {
+		config.Channels = strings.Split(channels, ",")
+	}
+	return config
+}
+
+func benchmarkUpdateCheck(b *testing.B, config Config) {
+	if err := config.validate(); err != nil {
+		b.Skip(err)
+	}
+
+	var seed atomic.Uint64
+	latencies := make(chan time.Duration, b.N)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		generator, err := NewGenerator(config, seed.Add(1))
+		require.NoError(b, err)
+		for pb.Next() {
+			_, req, err := generator.Next(context.Background())
+			require.NoError(b, err)
+			statusCode, latency, err := Do(http.DefaultClient, req)
+			require.NoError(b, err)
+			require.Less(b, statusCode, http.StatusInternalServerError)
+			latencies <- latency
+		}
+	})
+	b.StopTimer()
+	close(latencies)
+
+	sorted := make([]time.Duration, 0, b.N)
+	for latency := range latencies {
+		sorted = append(sorted, latency)
+	}
+	report := NewProtocolReport("", sorted, nil, 0)
+	b.ReportMetric(float64(report.P50.Microseconds()), "p50-us")
+	b.ReportMetric(float64(report.P99.Microseconds()), "p99-us")
+}
+
+func BenchmarkExpoUpdateCheck(b *testing.B) {
+	config := benchmarkConfig(b)
+	config.CodePushProjectID = uuid.Nil
+	benchmarkUpdateCheck(b, config)
+}
+
+func BenchmarkCodePushUpdateCheck(b *testing.B) {
+	config := benchmarkConfig(b)
+	config.ExpoProjectID = uuid.Nil
+	benchmarkUpdateCheck(b, config)
+}
@@ -0,0 +1,84 @@
+package loadgen
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+type Report struct {
+	Elapsed   time.Duration
+	Protocols []ProtocolReport
+}
+
+type ProtocolReport struct {
+	Protocol    string
+	Requests    int
+	Errors      int
+	StatusCodes map[int]int
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+func NewProtocolReport(
+	protocol string,
+	latencies []time.Duration,
+	statusCodes map[int]int,
+	errors int,
+) ProtocolReport {
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	report := ProtocolReport{
+		Protocol:    protocol,
+		Requests:    len(sorted),
+		Errors:      errors,
+		StatusCodes: statusCodes,
+		P50:         Percentile(sorted, 50),
+		P90:         Percentile(sorted, 90),
+		P99:         Percentile(sorted, 99),
+	}
+	if len(sorted) > 0 {
+		report.Max = sorted[len(sorted)-1]
+	}
+	return report
+}
+
+// Percentile uses the nearest-rank method, latencies have to be sorted
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "elapsed %s\n", r.Elapsed.Round(time.Millisecond))
+	for _, p := range r.Protocols {
+		rps := float64(p.Requests) / r.Elapsed.Seconds()
+		fmt.Fprintf(
+			w,
+			"%-9s requests=%d errors=%d rps=%.1f p50=%s p90=%s p99=%s max=%s\n",
+			p.Protocol,
+			p.Requests,
+			p.Errors,
+			rps,
+			p.P50.Round(time.Microsecond),
+			p.P90.Round(time.Microsecond),
+			p.P99.Round(time.Microsecond),
+			p.Max.Round(time.Microsecond),
+		)
+		codes := make([]int, 0, len(p.StatusCodes))
+		for code := range p.StatusCodes {
+			codes = append(codes, code)
+		}
+		slices.Sort(codes)
+		for _, code := range codes {
+			fmt.Fprintf(w, "          status %d: %d\n", code, p.StatusCodes[code])
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	ComponentAPI     = "api"
+	ComponentWorker  = "worker"
+	ComponentStorage = "storage"
+	ComponentQueue   = "queue"
+	ComponentCache   = "cache"
+	// DefaultComponent is the level of components without their own level
+	DefaultComponent = "default"
+)
+
+var Components = []string{
+	ComponentAPI,
+	ComponentWorker,
+	ComponentStorage,
+	ComponentQueue,
+	ComponentCache,
+}
+
+// levels of all components are shared by every logger of the process,
+// so they can be changed at runtime
+var levels = &levelRegistry{
+	defaultLevel: zap.NewAtomicLevelAt(zap.InfoLevel),
+	components:   make(map[string]zapcore.Level),
+}
+
+type levelRegistry struct {
+	mu           sync.RWMutex
+	defaultLevel zap.AtomicLevel
+	components   map[string]zapcore.Level
+}
+
+func (r *levelRegistry) enabled(component string, level zapcore.Level) bool {
+	r.mu.RLock()
+	componentLevel, ok := r.components[component]
+	r.mu.RUnlock()
+
+	if !ok {
+		return r.defaultLevel.Enabled(level)
+	}
+	return componentLevel.Enabled(level)
+}
+
+// SetLevel changes the level of the component,
+// empty level makes it follow the default level again
+func SetLevel(component string, level string) error {
+	if component == DefaultComponent {
+		return levels.defaultLevel.UnmarshalText([]byte(level))
+	}
+
+	if !isComponent(component) {
+		return fmt.Errorf("unknown component %q", component)
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if level == "" {
+		delete(levels.components, component)
+		return nil
+	}
+
+	var parsedLevel zapcore.Level
+	if err := parsedLevel.UnmarshalText([]byte(level)); err != nil {
+		return err
+	}
+	levels.components[component] = parsedLevel
+	return nil
+}
+
+// SetLevels changes levels of multiple components, nothing is changed if any of them is invalid
+func SetLevels(componentLevels map[string]string) error {
+	for component, level := range componentLevels {
+		if component != DefaultComponent && !isComponent(component) {
+			return fmt.Errorf("unknown component %q", component)
+		}
+		if level == "" && component != DefaultComponent {
+			continue
+		}
+		var parsedLevel zapcore.Level
+		if err := parsedLevel.UnmarshalText([]byte(level)); err != nil {
+			return fmt.Errorf("invalid log level of %s: %w", component, err)
+		}
+	}
+
+	for component, level := range componentLevels {
+		if err := SetLevel(component, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Levels returns the effective level of every component and the default level
+func Levels() map[string]string {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	defaultLevel := levels.defaultLevel.Level().String()
+	result := map[string]string{DefaultComponent: defaultLevel}
+	for _, component := range Components {
+		result[component] = defaultLevel
+	}
+	for component, level := range levels.components {
+		result[component] = level.String()
+	}
+	return result
+}
+
+func isComponent(component string) bool {
+	for _, c := range Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// setLevels applies LOG_LEVEL and LOG_LEVELS, e.g. LOG_LEVELS=storage=debug,queue=warn
+func setLevels(defaultLevel string, componentLevels string) error {
+	if defaultLevel != "" {
+		if err := SetLevel(DefaultComponent, defaultLevel); err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
+	}
+
+	for _, componentLevel := range splitList(componentLevels) {
+		component, level, found := strings.Cut(componentLevel, "=")
+		if !found {
+			return fmt.Errorf("invalid log level %q, expected component=level", componentLevel)
+		}
+		if err := SetLevel(strings.TrimSpace(component), strings.TrimSpace(level)); err != nil {
+			return fmt.Errorf("invalid log level of %s: %w", component, err)
+		}
+	}
+
+	return nil
+}
+
+// componentCore filters entries by the current level of its component
+type componentCore struct {
+	zapcore.Core
+	component string
+}
+
+func (c *componentCore) Enabled(level zapcore.Level) bool {
+	return levels.enabled(c.component, level)
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentCore{Core: c.Core.With(fields), component: c.component}
+}
+
+func (c *componentCore) Check(
+	entry zapcore.Entry,
+	checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	// the wrapped core is checked as well, so sampling of production logs still applies
+	return c.Core.Check(entry, checked)
+}
+
+// Component returns a logger of the component, following the component's log level
+func Component(log *zap.Logger, component string) *zap.Logger {
+	return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if cc, ok := core.(*componentCore); ok {
+			core = cc.Core
+		}
+		return &componentCore{Core: core, component: component}
+	})).Named(component)
+}
+
+func ComponentFromContext(c context.Context, component string) *zap.Logger {
+	return Component(FromContext(c), component)
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestComponentLevels(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, SetLevel(DefaultComponent, "info"))
+		for _, component := range Components {
+			require.NoError(t, SetLevel(component, ""))
+		}
+	})
+
+	require.NoError(t, setLevels("warn", "storage=debug"))
+
+	observedCore, logs := observer.New(zap.DebugLevel)
+	log := zap.New(&componentCore{Core: observedCore, component: DefaultComponent})
+	apiLog := Component(log, ComponentAPI)
+	storageLog := Component(apiLog, ComponentStorage)
+
+	log.Info("default info")
+	apiLog.Warn("api warn")
+	storageLog.Debug("storage debug")
+	require.Len(t, logs.TakeAll(), 2)
+
+	// changed at runtime, also for already created loggers
+	require.NoError(t, SetLevels(map[string]string{"api": "debug", "storage": ""}))
+	apiLog.Debug("api debug")
+	storageLog.Debug("storage debug")
+	require.Len(t, logs.TakeAll(), 1)
+
+	require.Error(t, SetLevels(map[string]string{"api": "info", "unknown": "debug"}))
+	require.Error(t, SetLevels(map[string]string{"api": "info", "queue": "verbose"}))
+	require.Equal(t, "debug", Levels()[ComponentAPI])
+	require.Equal(t, "warn", Levels()[ComponentQueue])
+}
@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 
@@ -13,14 +15,113 @@ import (
 
 const ContextKey = "logger"
 
-func NewLogger(isDebug bool) (*zap.Logger, error) {
+type Config struct {
+	// RedactFields is a comma separated list of additional field and header names
+	// whose values are never logged
+	RedactFields string `env:"LOG_REDACT_FIELDS"`
+	// RedactQueryParams is a comma separated list of additional query params
+	// redacted in logged URLs and queries
+	RedactQueryParams string `env:"LOG_REDACT_QUERY_PARAMS"`
+	// Level is the default log level, debug in debug mode and info otherwise
+	Level string `env:"LOG_LEVEL"`
+	// ComponentLevels overrides the level of components, e.g. storage=debug,queue=warn
+	ComponentLevels string `env:"LOG_LEVELS"`
+	File            FileConfig
+	Sampling        SamplingConfig
+}
+
+// SamplingConfig limits repeated log entries, of entries with the same level and message
+// logged within a second, the first Initial and then every Thereafter-th entry is logged
+type SamplingConfig struct {
+	// Initial set to 0 disables sampling
+	Initial    int `env:"LOG_SAMPLING_INITIAL,default=100"`
+	Thereafter int `env:"LOG_SAMPLING_THEREAFTER,default=100"`
+}
+
+// FileConfig enables JSON logs written to a rotated file, in addition to stdout
+type FileConfig struct {
+	Path string `env:"LOG_FILE_PATH"`
+	// MaxSizeMB is the size after which the file is rotated, 0 disables the size limit
+	MaxSizeMB int `env:"LOG_FILE_MAX_SIZE_MB,default=100"`
+	// MaxAge is the age after which the file is rotated, 0 disables the age limit
+	MaxAge time.Duration `env:"LOG_FILE_MAX_AGE,default=24h"`
+	// MaxBackups is the number of rotated files kept, 0 keeps all of them
+	MaxBackups int `env:"LOG_FILE_MAX_BACKUPS,default=7"`
+}
+
+func NewLogger(isDebug bool, config Config) (*zap.Logger, error) {
+	defaultLevel := config.Level
+	if defaultLevel == "" && isDebug {
+		defaultLevel = zap.DebugLevel.String()
+	}
+	if err := setLevels(defaultLevel, config.ComponentLevels); err != nil {
+		return nil, err
+	}
+
+	options := make([]zap.Option, 0, 4)
+	if config.File.Path != "" {
+		file, err := newRotatingFile(
+			config.File.Path,
+			int64(config.File.MaxSizeMB)*1024*1024,
+			config.File.MaxAge,
+			config.File.MaxBackups,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fileCore := zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			file,
+			zap.DebugLevel,
+		)
+		options = append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, fileCore)
+		}))
+	}
+
+	redactor := newRedactor(splitList(config.RedactFields), splitList(config.RedactQueryParams))
+	redaction := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core, redactor: redactor}
+	})
+	// the levels are checked by componentCore, the encoding core lets everything through
+	levelFilter := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &componentCore{Core: core, component: DefaultComponent}
+	})
+
+	var zapConfig zap.Config
 	if isDebug {
-		config := zap.NewDevelopmentConfig()
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		return config.Build()
+		zapConfig = zap.NewDevelopmentConfig()
+		zapConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	} else {
-		return zap.NewProduction()
+		zapConfig = zap.NewProductionConfig()
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	zapConfig.Sampling = nil
+
+	options = append(options, redaction)
+	if config.Sampling.Initial > 0 {
+		// sampled outside of the encoding core, so file logs are sampled as well
+		options = append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(
+				core,
+				time.Second,
+				config.Sampling.Initial,
+				config.Sampling.Thereafter,
+			)
+		}))
+	}
+	options = append(options, levelFilter)
+	return zapConfig.Build(options...)
+}
+
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 func NewMiddleware(log *zap.Logger) gin.HandlerFunc {
@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const redactedValue = "[REDACTED]"
+
+var defaultRedactedFields = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"deployment_key",
+	"deploymentkey",
+	"token",
+	"password",
+	"secret",
+}
+
+var defaultRedactedQueryParams = []string{
+	"signature",
+	"deployment_key",
+	"deploymentkey",
+	"token",
+	"x-amz-signature",
+	"x-amz-credential",
+	"x-amz-security-token",
+	"x-goog-signature",
+	"x-goog-credential",
+	"policy",
+	"key-pair-id",
+}
+
+var urlRegex = regexp.MustCompile(`https?://[^\s"']+`)
+
+type redactor struct {
+	fields      map[string]bool
+	queryParams map[string]bool
+}
+
+func newRedactor(extraFields []string, extraQueryParams []string) *redactor {
+	r := &redactor{
+		fields:      make(map[string]bool),
+		queryParams: make(map[string]bool),
+	}
+	for _, field := range append(defaultRedactedFields, extraFields...) {
+		r.fields[strings.ToLower(field)] = true
+	}
+	for _, param := range append(defaultRedactedQueryParams, extraQueryParams...) {
+		r.queryParams[strings.ToLower(param)] = true
+	}
+	return r
+}
+
+// redactQuery keeps the order of the params, so the logged query still reads like the original
+func (r *redactor) redactQuery(rawQuery string) string {
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, found := strings.Cut(param, "=")
+		if found && r.queryParams[strings.ToLower(key)] {
+			params[i] = key + "=" + redactedValue
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func (r *redactor) redactURLs(str string) string {
+	if !strings.Contains(str, "?") {
+		return str
+	}
+
+	return urlRegex.ReplaceAllStringFunc(str, func(rawURL string) string {
+		base, query, found := strings.Cut(rawURL, "?")
+		if !found {
+			return rawURL
+		}
+		return base + "?" + r.redactQuery(query)
+	})
+}
+
+func (r *redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := fields
+	copied := false
+	for i, field := range fields {
+		if field.Type != zapcore.StringType {
+			continue
+		}
+
+		value := field.String
+		switch {
+		case r.fields[strings.ToLower(field.Key)]:
+			value = redactedValue
+		case field.Key == "query":
+			// gin logs the raw query of the request
+			value = r.redactQuery(value)
+		default:
+			value = r.redactURLs(value)
+		}
+
+		if value != field.String {
+			if !copied {
+				// don't modify the fields slice owned by the caller
+				redacted = append([]zapcore.Field(nil), fields...)
+				copied = true
+			}
+			redacted[i].String = value
+		}
+	}
+	return redacted
+}
+
+// redactingCore redacts sensitive values before the entries reach the encoder
+type redactingCore struct {
+	zapcore.Core
+	redactor *redactor
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{
+		Core:     c.Core.With(c.redactor.redactFields(fields)),
+		redactor: c.redactor,
+	}
+}
+
+func (c *redactingCore) Check(
+	entry zapcore.Entry,
+	checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	// the wrapped core is checked as well, so a sampler below still applies, but the entry is
+	// written through this core, so it's redacted
+	if c.Core.Check(entry, nil) == nil {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactor.redactURLs(entry.Message)
+	return c.Core.Write(entry, c.redactor.redactFields(fields))
+}
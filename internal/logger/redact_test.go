@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactingCore(t *testing.T) {
+	observedCore, logs := observer.New(zap.DebugLevel)
+	core := &redactingCore{
+		Core:     observedCore,
+		redactor: newRedactor([]string{"Pt-Api-Key"}, []string{"sig"}),
+	}
+	log := zap.New(core).With(zap.String("authorization", "Bearer abc"))
+
+	fields := []zap.Field{
+		zap.String("query", "deployment_key=abc&appVersion=1.0.0&sig=xyz"),
+		zap.String("url", "https://bucket.s3.amazonaws.com/a.png?X-Amz-Signature=abc&x=1"),
+		zap.String("pt-api-key", "secret-key"),
+		zap.String("path", "/api/v1/public/codepush/update_check"),
+	}
+	log.Info("signed https://localhost/assets?obj=a&signature=abc", fields...)
+
+	// the caller's fields are left intact
+	require.Equal(t, "deployment_key=abc&appVersion=1.0.0&sig=xyz", fields[0].String)
+
+	entry := logs.All()[0]
+	require.Equal(t, "signed https://localhost/assets?obj=a&signature=[REDACTED]", entry.Message)
+
+	loggedFields := entry.ContextMap()
+	require.Equal(t, "[REDACTED]", loggedFields["authorization"])
+	require.Equal(t, "deployment_key=[REDACTED]&appVersion=1.0.0&sig=[REDACTED]", loggedFields["query"])
+	require.Equal(
+		t,
+		"https://bucket.s3.amazonaws.com/a.png?X-Amz-Signature=[REDACTED]&x=1",
+		loggedFields["url"],
+	)
+	require.Equal(t, "[REDACTED]", loggedFields["pt-api-key"])
+	require.Equal(t, "/api/v1/public/codepush/update_check", loggedFields["path"])
+}
+
+func TestRedactingCoreChecksWrappedCore(t *testing.T) {
+	observedCore, logs := observer.New(zap.DebugLevel)
+	sampler := zapcore.NewSamplerWithOptions(observedCore, time.Minute, 2, 0)
+	core := &redactingCore{
+		Core:     sampler,
+		redactor: newRedactor(nil, []string{"sig"}),
+	}
+	log := zap.New(core)
+
+	for range 5 {
+		log.Info("download", zap.String("query", "sig=xyz"))
+	}
+
+	// only the first entries pass the sampler, still redacted
+	require.Equal(t, 2, logs.Len())
+	for _, entry := range logs.All() {
+		require.Equal(t, "sig=[REDACTED]", entry.ContextMap()["query"])
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	ginzap "github.com/gin-contrib/zap"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequestLogConfig suppresses request logs of hot paths, paths are gin route patterns,
+// e.g. /api/v1/public/:projectID/expo
+type RequestLogConfig struct {
+	// SkipPaths is a comma separated list of routes whose requests are never logged
+	SkipPaths string `env:"LOG_REQUESTS_SKIP_PATHS"`
+	// ErrorsOnlyPaths is a comma separated list of routes whose requests are logged
+	// only when they don't succeed with 200 OK
+	ErrorsOnlyPaths string `env:"LOG_REQUESTS_ERRORS_ONLY_PATHS"`
+}
+
+// NewRequestLogMiddleware logs every request, except for those suppressed by the config
+func NewRequestLogMiddleware(log *zap.Logger, config RequestLogConfig) gin.HandlerFunc {
+	skipPaths := splitList(config.SkipPaths)
+	errorsOnlyPaths := splitList(config.ErrorsOnlyPaths)
+
+	return ginzap.GinzapWithConfig(log, &ginzap.Config{
+		TimeFormat:   time.RFC3339,
+		UTC:          true,
+		DefaultLevel: zap.InfoLevel,
+		Skipper: func(c *gin.Context) bool {
+			return skipRequestLog(c, skipPaths, errorsOnlyPaths)
+		},
+	})
+}
+
+func skipRequestLog(c *gin.Context, skipPaths []string, errorsOnlyPaths []string) bool {
+	route := c.FullPath()
+	if slices.Contains(skipPaths, route) {
+		return true
+	}
+
+	succeeded := c.Writer.Status() == http.StatusOK && len(c.Errors) == 0
+	return succeeded && slices.Contains(errorsOnlyPaths, route)
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLogMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	observedCore, logs := observer.New(zap.DebugLevel)
+
+	r := gin.New()
+	r.Use(NewRequestLogMiddleware(zap.New(observedCore), RequestLogConfig{
+		SkipPaths:       "/health",
+		ErrorsOnlyPaths: "/:projectID/expo",
+	}))
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/:projectID/expo", func(c *gin.Context) {
+		if c.Param("projectID") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/other", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/health", "/project/expo", "/missing/expo", "/other"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	require.Equal(t, "/missing/expo", entries[0].Message)
+	require.Equal(t, "/other", entries[1].Message)
+}
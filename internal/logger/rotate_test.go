@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paratrooper.log")
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err := newRotatingFile(path, 10, time.Hour, 2)
+	require.NoError(t, err)
+	f.now = func() time.Time { return now }
+	f.openedAt = now
+
+	write := func(data string) {
+		_, err := f.Write([]byte(data))
+		require.NoError(t, err)
+	}
+
+	// rotated by size
+	write("12345")
+	write("67890")
+	now = now.Add(time.Second)
+	write("abc")
+
+	backups, err := filepath.Glob(filepath.Join(dir, "paratrooper-*.log"))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "paratrooper-2024-01-01T00-00-01.000.log")}, backups)
+
+	// rotated by age
+	now = now.Add(time.Hour)
+	write("def")
+	now = now.Add(time.Hour)
+	write("ghi")
+
+	// only the newest backups are kept
+	backups, err = filepath.Glob(filepath.Join(dir, "paratrooper-*.log"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "paratrooper-2024-01-01T01-00-01.000.log"),
+		filepath.Join(dir, "paratrooper-2024-01-01T02-00-01.000.log"),
+	}, backups)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "ghi", string(content))
+	require.NoError(t, f.Sync())
+}
@@ -0,0 +1,157 @@
+// Package mtls authenticates clients of the management endpoints by their TLS certificates
+package mtls
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/project"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// RoleAdmin can call every management endpoint of its projects,
+	// including the endpoints not scoped to a project when it isn't limited to projects
+	RoleAdmin = "admin"
+	// RolePublisher can call every endpoint scoped to its projects, e.g. publish and roll back
+	RolePublisher = "publisher"
+	// RoleViewer can only read data of its projects
+	RoleViewer = "viewer"
+)
+
+type Config struct {
+	// ClientsFile is a JSON array of ClientConfig, it makes the management endpoints require
+	// a client certificate verified by the listener, see listener.Config.TLSClientCAPath
+	ClientsFile string `env:"MTLS_CLIENTS_FILE"`
+}
+
+// ClientConfig is an entry of the MTLS_CLIENTS_FILE JSON array
+type ClientConfig struct {
+	// Fingerprint is the hex encoded SHA256 of the DER encoded certificate, colons are ignored
+	Fingerprint string `json:"fingerprint"`
+	Role        string `json:"role"`
+	// ProjectIDs limit the client to the projects, it can access all projects when empty
+	ProjectIDs []uuid.UUID `json:"projectIds"`
+}
+
+// Allows checks if the client can call the endpoint, projectID is uuid.Nil for the endpoints
+// not scoped to a project
+func (c *ClientConfig) Allows(method string, projectID uuid.UUID) bool {
+	if projectID == uuid.Nil {
+		return c.Role == RoleAdmin && len(c.ProjectIDs) == 0
+	}
+	if len(c.ProjectIDs) > 0 && !slices.Contains(c.ProjectIDs, projectID) {
+		return false
+	}
+	if c.Role == RoleViewer {
+		return method == http.MethodGet || method == http.MethodHead
+	}
+	return true
+}
+
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
+
+// Fingerprint returns the hex encoded SHA256 of the DER encoded certificate
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseClients returns the clients by their normalized fingerprints
+func ParseClients(data []byte) (map[string]ClientConfig, error) {
+	var configs []ClientConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse mTLS clients: %w", err)
+	}
+
+	clients := make(map[string]ClientConfig, len(configs))
+	for i, config := range configs {
+		fingerprint := normalizeFingerprint(config.Fingerprint)
+		if decoded, err := hex.DecodeString(fingerprint); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("client %d: fingerprint must be a hex encoded SHA256", i)
+		}
+		if !slices.Contains([]string{RoleAdmin, RolePublisher, RoleViewer}, config.Role) {
+			return nil, fmt.Errorf(
+				"client %d: role must be one of %s, %s, %s",
+				i,
+				RoleAdmin,
+				RolePublisher,
+				RoleViewer,
+			)
+		}
+		if _, ok := clients[fingerprint]; ok {
+			return nil, fmt.Errorf("client %d: duplicate fingerprint", i)
+		}
+		config.Fingerprint = fingerprint
+		clients[fingerprint] = config
+	}
+	return clients, nil
+}
+
+func LoadClients(path string) (map[string]ClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mTLS clients file: %w", err)
+	}
+	return ParseClients(data)
+}
+
+// NewMiddleware requires a verified client certificate of a configured client
+// on the management endpoints, the device facing endpoints stay open
+func NewMiddleware(clients map[string]ClientConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !strings.HasPrefix(ctx.Request.URL.Path, project.AdminPathPrefix) {
+			ctx.Next()
+			return
+		}
+
+		state := ctx.Request.TLS
+		if state == nil || len(state.VerifiedChains) == 0 {
+			ctx.AbortWithStatusJSON(
+				http.StatusUnauthorized,
+				api.GenericError{Error: "client certificate required"},
+			)
+			return
+		}
+
+		fingerprint := Fingerprint(state.VerifiedChains[0][0])
+		client, ok := clients[fingerprint]
+		if !ok {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				api.GenericError{Error: "unknown client certificate"},
+			)
+			return
+		}
+
+		log := logger.FromContext(ctx).With(
+			zap.String("client_cert", fingerprint),
+			zap.String("client_role", client.Role),
+		)
+		ctx.Set(logger.ContextKey, log)
+
+		if !client.Allows(ctx.Request.Method, project.IDFromAdminPath(ctx.Request.URL.Path)) {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				api.GenericError{Error: "client certificate is not allowed to call the endpoint"},
+			)
+			return
+		}
+
+		ctx.Next()
+	}
+}
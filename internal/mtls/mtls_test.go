@@ -0,0 +1,109 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseClients(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("cert")}
+	fingerprint := Fingerprint(cert)
+
+	// fingerprints copied from openssl are uppercase and colon separated
+	var colonSeparated []string
+	for i := 0; i < len(fingerprint); i += 2 {
+		colonSeparated = append(colonSeparated, strings.ToUpper(fingerprint[i:i+2]))
+	}
+	clients, err := ParseClients([]byte(fmt.Sprintf(
+		`[{"fingerprint": %q, "role": "admin"}]`,
+		strings.Join(colonSeparated, ":"),
+	)))
+	require.NoError(t, err)
+	require.Contains(t, clients, fingerprint)
+
+	_, err = ParseClients([]byte(`[{"fingerprint": "abc", "role": "admin"}]`))
+	require.Error(t, err)
+
+	_, err = ParseClients([]byte(fmt.Sprintf(`[{"fingerprint": %q, "role": "root"}]`, fingerprint)))
+	require.Error(t, err)
+
+	_, err = ParseClients([]byte(fmt.Sprintf(
+		`[{"fingerprint": %q, "role": "admin"}, {"fingerprint": %q, "role": "viewer"}]`,
+		fingerprint,
+		fingerprint,
+	)))
+	require.Error(t, err)
+}
+
+func TestClientAllows(t *testing.T) {
+	projectID := uuid.New()
+	otherProjectID := uuid.New()
+
+	admin := ClientConfig{Role: RoleAdmin}
+	require.True(t, admin.Allows(http.MethodPut, uuid.Nil))
+	require.True(t, admin.Allows(http.MethodPost, projectID))
+
+	projectAdmin := ClientConfig{Role: RoleAdmin, ProjectIDs: []uuid.UUID{projectID}}
+	require.False(t, projectAdmin.Allows(http.MethodGet, uuid.Nil))
+	require.True(t, projectAdmin.Allows(http.MethodPost, projectID))
+	require.False(t, projectAdmin.Allows(http.MethodGet, otherProjectID))
+
+	publisher := ClientConfig{Role: RolePublisher}
+	require.False(t, publisher.Allows(http.MethodPost, uuid.Nil))
+	require.True(t, publisher.Allows(http.MethodPost, projectID))
+
+	viewer := ClientConfig{Role: RoleViewer, ProjectIDs: []uuid.UUID{projectID}}
+	require.True(t, viewer.Allows(http.MethodGet, projectID))
+	require.False(t, viewer.Allows(http.MethodPost, projectID))
+	require.False(t, viewer.Allows(http.MethodGet, otherProjectID))
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	projectID := uuid.New()
+	viewerCert := &x509.Certificate{Raw: []byte("viewer")}
+	unknownCert := &x509.Certificate{Raw: []byte("unknown")}
+	clients := map[string]ClientConfig{
+		Fingerprint(viewerCert): {
+			Fingerprint: Fingerprint(viewerCert),
+			Role:        RoleViewer,
+			ProjectIDs:  []uuid.UUID{projectID},
+		},
+	}
+
+	r := gin.New()
+	r.Use(logger.NewMiddleware(zap.NewNop()))
+	r.Use(NewMiddleware(clients))
+	r.Any("/*path", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	serve := func(method, path string, cert *x509.Certificate) int {
+		req := httptest.NewRequest(method, path, nil)
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	updatesPath := "/api/v1/admin/" + projectID.String() + "/updates"
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/public/expo", nil))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, updatesPath, nil))
+	require.Equal(t, http.StatusForbidden, serve(http.MethodGet, updatesPath, unknownCert))
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, updatesPath, viewerCert))
+	require.Equal(t, http.StatusForbidden, serve(http.MethodPost, updatesPath, viewerCert))
+}
@@ -0,0 +1,53 @@
+package project
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AdminPathPrefix is the prefix of the management endpoints
+const AdminPathPrefix = "/api/v1/admin/"
+
+// IDFromAdminPath returns the project of /api/v1/admin/{projectID}/... and
+// /api/v1/admin/project/{projectID}/... paths, uuid.Nil for the other paths
+func IDFromAdminPath(path string) uuid.UUID {
+	rest, ok := strings.CutPrefix(path, AdminPathPrefix)
+	if !ok {
+		return uuid.Nil
+	}
+	segments := strings.Split(rest, "/")
+	if segments[0] == "project" {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return uuid.Nil
+	}
+
+	projectID, err := uuid.Parse(segments[0])
+	if err != nil {
+		return uuid.Nil
+	}
+	return projectID
+}
+
+// IPAllowed checks if the IP is in one of the CIDR ranges, all IPs are allowed without ranges
+func IPAllowed(cidrs []string, ip string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
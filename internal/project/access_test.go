@@ -0,0 +1,28 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDFromAdminPath(t *testing.T) {
+	projectID := uuid.New()
+	assert.Equal(t, projectID, IDFromAdminPath("/api/v1/admin/"+projectID.String()+"/updates"))
+	assert.Equal(t, projectID, IDFromAdminPath("/api/v1/admin/project/"+projectID.String()))
+	assert.Equal(t, uuid.Nil, IDFromAdminPath("/api/v1/admin/project"))
+	assert.Equal(t, uuid.Nil, IDFromAdminPath("/api/v1/admin/log-levels"))
+	assert.Equal(t, uuid.Nil, IDFromAdminPath("/api/v1/public/"+projectID.String()+"/expo"))
+}
+
+func TestIPAllowed(t *testing.T) {
+	assert.True(t, IPAllowed(nil, "203.0.113.7"))
+
+	cidrs := []string{"10.0.0.0/8", "2001:db8::/32"}
+	assert.True(t, IPAllowed(cidrs, "10.1.2.3"))
+	assert.True(t, IPAllowed(cidrs, "::ffff:10.1.2.3"))
+	assert.True(t, IPAllowed(cidrs, "2001:db8::1"))
+	assert.False(t, IPAllowed(cidrs, "203.0.113.7"))
+	assert.False(t, IPAllowed(cidrs, ""))
+}
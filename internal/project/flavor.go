@@ -0,0 +1,75 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFlavorExists   = errors.New("flavor with the name already exists in the project")
+	ErrFlavorNotFound = errors.New("flavor not found")
+	// ErrInvalidFlavorName is returned for names that can't be a part of a deployment key
+	ErrInvalidFlavorName = errors.New(
+		"flavor name must be 1-64 lowercase letters, digits or dashes, starting with a letter or digit",
+	)
+)
+
+var flavorNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+func ValidateFlavorName(name string) error {
+	if !flavorNameRegex.MatchString(name) {
+		return ErrInvalidFlavorName
+	}
+	return nil
+}
+
+func (s *service) Flavors(ctx context.Context, id uuid.UUID) ([]db.ProjectFlavor, error) {
+	return s.q.GetProjectFlavors(ctx, id)
+}
+
+func (s *service) CreateFlavor(
+	ctx context.Context,
+	id uuid.UUID,
+	name string,
+) (*db.ProjectFlavor, error) {
+	if err := ValidateFlavorName(name); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.q.HasProjectFlavor(ctx, id, name)
+	if err != nil {
+		return nil, fmt.Errorf("HasProjectFlavor: %w", err)
+	}
+	if exists {
+		return nil, ErrFlavorExists
+	}
+
+	flavor, err := s.q.CreateProjectFlavor(ctx, id, name)
+	if err != nil {
+		return nil, fmt.Errorf("CreateProjectFlavor: %w", err)
+	}
+	return &flavor, nil
+}
+
+// DeleteFlavor stops the flavor's clients from getting updates, the updates targeting it
+// are kept
+func (s *service) DeleteFlavor(ctx context.Context, id uuid.UUID, name string) error {
+	deleted, err := s.q.DeleteProjectFlavor(ctx, id, name)
+	if err != nil {
+		return fmt.Errorf("DeleteProjectFlavor: %w", err)
+	}
+	if deleted == 0 {
+		return ErrFlavorNotFound
+	}
+	return nil
+}
+
+func (s *service) HasFlavor(ctx context.Context, id uuid.UUID, name string) (bool, error) {
+	return s.q.HasProjectFlavor(ctx, id, name)
+}
@@ -0,0 +1,19 @@
+package project
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFlavorName(t *testing.T) {
+	for _, name := range []string{"acme", "acme-bank", "7eleven", strings.Repeat("a", 64)} {
+		require.NoError(t, ValidateFlavorName(name), name)
+	}
+
+	invalid := []string{"", "Acme", "-acme", "acme.bank", "acme/bank", strings.Repeat("a", 65)}
+	for _, name := range invalid {
+		require.ErrorIs(t, ValidateFlavorName(name), ErrInvalidFlavorName, name)
+	}
+}
@@ -0,0 +1,188 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/update"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// purgeBatchSize is the number of updates deleted in a single transaction
+	purgeBatchSize = 100
+	// purgeRetryDelay before a failed purge is retried
+	purgeRetryDelay = time.Minute
+)
+
+// ErrProjectNotDeleted is returned when a purge of a project that wasn't deleted is requested
+var ErrProjectNotDeleted = errors.New("project is not deleted")
+
+// Purger deletes the updates, assets and storage objects of the deleted projects, then the
+// projects themselves
+type Purger struct {
+	q      *db.Queries
+	pgPool *pgxpool.Pool
+	st     *storage.Storage
+}
+
+func NewPurger(q *db.Queries, pgPool *pgxpool.Pool, st *storage.Storage) *Purger {
+	return &Purger{q: q, pgPool: pgPool, st: st}
+}
+
+// Start consumes the purge messages in the background
+func (p *Purger) Start(ctx context.Context, queueConn queue.Queue) error {
+	if err := queueConn.ConsumePurgeProjectMessages(ctx, p.newMessageHandler(ctx)); err != nil {
+		return fmt.Errorf("failed to consume purge project messages: %w", err)
+	}
+	return nil
+}
+
+func (p *Purger) newMessageHandler(ctx context.Context) queue.MessageHandler {
+	log := logger.FromContext(ctx)
+	log = log.With(zap.String("consumer", "purge-project"))
+
+	return func(msg queue.Message) {
+		payload, err := queue.ParsePurgeProjectMessage(msg.Data())
+		if err != nil {
+			log.Error("failed to unmarshal payload", zap.Error(err))
+			if err := msg.Term(); err != nil {
+				log.Error("failed to terminate message", zap.Error(err))
+			}
+			return
+		}
+
+		projectLog := log.With(zap.String("project_id", payload.ProjectID.String()))
+		projectLog.Info("purging project")
+
+		err = p.Purge(ctx, payload.ProjectID)
+		if errors.Is(err, ErrProjectNotDeleted) {
+			projectLog.Error("project is not deleted, dropping")
+			if err := msg.Term(); err != nil {
+				projectLog.Error("failed to terminate message", zap.Error(err))
+			}
+			return
+		}
+		if err != nil {
+			projectLog.Error("failed to purge project, retrying later", zap.Error(err))
+			if err := msg.NakWithDelay(purgeRetryDelay); err != nil {
+				projectLog.Error("failed to nak message", zap.Error(err))
+			}
+			return
+		}
+
+		projectLog.Info("project purged")
+		if err := msg.Ack(); err != nil {
+			projectLog.Error("failed to ack message", zap.Error(err))
+		}
+	}
+}
+
+// Purge deletes the objects of the deleted project from every bucket, then its rows. It can
+// be retried, a purged project is skipped.
+func (p *Purger) Purge(ctx context.Context, projectID uuid.UUID) error {
+	log := logger.FromContext(ctx).With(zap.String("project_id", projectID.String()))
+	deletedAt, err := p.q.GetProjectDeletedAt(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("GetProjectDeletedAt: %w", err)
+	}
+	if !deletedAt.Valid {
+		return ErrProjectNotDeleted
+	}
+
+	// objects first, the purge is retried until the project's row is deleted
+	for _, bucket := range p.st.Buckets() {
+		for _, prefix := range storage.ProjectObjectKeyPrefixes(projectID) {
+			deleted, err := storage.DeleteObjects(ctx, bucket, prefix)
+			if err != nil {
+				return fmt.Errorf("failed to delete objects: %w", err)
+			}
+			if deleted > 0 {
+				log.Debug("deleted objects", zap.String("prefix", prefix), zap.Int("count", deleted))
+			}
+		}
+	}
+
+	for {
+		updateIDs, err := p.q.GetProjectUpdateIDsToPurge(ctx, projectID, purgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("GetProjectUpdateIDsToPurge: %w", err)
+		}
+		if len(updateIDs) == 0 {
+			break
+		}
+		if err := p.deleteUpdates(ctx, updateIDs); err != nil {
+			return err
+		}
+		log.Debug("deleted updates", zap.Int("count", len(updateIDs)))
+	}
+
+	return p.deleteProject(ctx, projectID)
+}
+
+// deleteUpdates deletes the updates with the rows referencing them
+func (p *Purger) deleteUpdates(ctx context.Context, updateIDs []uuid.UUID) error {
+	return p.inTx(ctx, func(qtx *db.Queries) error {
+		return update.DeleteUpdateRows(ctx, qtx, updateIDs)
+	})
+}
+
+// deleteProject deletes the project with the rows referencing it, its updates are deleted
+// already
+func (p *Purger) deleteProject(ctx context.Context, projectID uuid.UUID) error {
+	return p.inTx(ctx, func(qtx *db.Queries) error {
+		deletes := []func(ctx context.Context, projectID uuid.UUID) error{
+			qtx.DeleteChannelHeadsOfProject,
+			qtx.DeleteChannelPoliciesOfProject,
+			qtx.DeleteChannelRollbacksOfProject,
+			qtx.DeleteSigningKeysOfProject,
+			qtx.DeleteEmbeddedUpdatesOfProject,
+			qtx.DeleteFlavorsOfProject,
+			qtx.DeleteCodePushLabelSequencesOfProject,
+			qtx.DeleteUpdateCheckEventsOfProject,
+			qtx.DeleteAPIUsageStatsOfProject,
+			qtx.DeleteUsageOfProject,
+			qtx.DeleteProject,
+		}
+		for _, deleteRows := range deletes {
+			if err := deleteRows(ctx, projectID); err != nil {
+				return fmt.Errorf("failed to delete project: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *Purger) inTx(ctx context.Context, fn func(qtx *db.Queries) error) error {
+	tx, err := p.pgPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("Purge: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if err := fn(p.q.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
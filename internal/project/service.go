@@ -3,12 +3,28 @@ package project
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 	"github.com/a-gierczak/paratrooper/generated/db"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultEnvironment of projects created without one
+const DefaultEnvironment = "production"
+
+var (
+	ErrProjectExists          = errors.New("project with the name already exists in the environment")
+	ErrSameEnvironment        = errors.New("project is already in the environment")
+	ErrUpdateProtocolMismatch = errors.New(
+		"project in the environment uses a different update protocol",
+	)
+	ErrProjectDeleted  = errors.New("project in the environment is being deleted")
+	ErrProjectArchived = errors.New("project is archived")
 )
 
 type Service interface {
@@ -16,8 +32,67 @@ type Service interface {
 		ctx context.Context,
 		name string,
 		updateProtocol api.UpdateProtocol,
+		environment string,
+		storageDriverURL string,
 	) (*db.Project, error)
 	ProjectByID(ctx context.Context, id uuid.UUID) (*db.Project, error)
+	// Environments returns the projects of the same name in all environments
+	Environments(ctx context.Context, project *db.Project) ([]db.Project, error)
+	// CopyToEnvironment copies the configuration of the project to the project of the same name
+	// in the environment, which is created when it doesn't exist
+	CopyToEnvironment(
+		ctx context.Context,
+		id uuid.UUID,
+		environment string,
+	) (*db.Project, error)
+	// CloneProject creates a project with the configuration, channel policies and flavors
+	// of the project
+	CloneProject(
+		ctx context.Context,
+		id uuid.UUID,
+		name string,
+		environment string,
+	) (*db.Project, error)
+	SetPublicAssetsURL(
+		ctx context.Context,
+		id uuid.UUID,
+		publicAssetsURL string,
+	) (*db.Project, error)
+	SetAssetURLTemplate(
+		ctx context.Context,
+		id uuid.UUID,
+		template string,
+	) (*db.Project, error)
+	SetReplicaRegions(ctx context.Context, id uuid.UUID, regions []string) (*db.Project, error)
+	SetAdminAllowedCIDRs(ctx context.Context, id uuid.UUID, cidrs []string) (*db.Project, error)
+	SetMaxAssetCount(ctx context.Context, id uuid.UUID, maxAssetCount int32) (*db.Project, error)
+	// SetMeteredDownloadLimit sets the size of the largest optional update served to devices on
+	// metered connections, 0 removes the limit
+	SetMeteredDownloadLimit(ctx context.Context, id uuid.UUID, limit int64) (*db.Project, error)
+	// SetMaxStorageBytes sets the storage quota of the project, 0 removes it
+	SetMaxStorageBytes(ctx context.Context, id uuid.UUID, maxStorageBytes int64) (*db.Project, error)
+	// SetCodePushSettings sets the defaults of the CodePush responses of the project
+	SetCodePushSettings(
+		ctx context.Context,
+		id uuid.UUID,
+		suggestBinaryUpdate bool,
+		descriptionSource api.CodePushDescriptionSource,
+	) (*db.Project, error)
+	SetStableAssetURLs(ctx context.Context, id uuid.UUID, enabled bool) (*db.Project, error)
+	// SetAssetPriorities sets the order Expo clients download the assets of the updates in
+	SetAssetPriorities(ctx context.Context, id uuid.UUID, priorities []string) (*db.Project, error)
+	// SetArchived makes the project reject new updates, its updates are still served
+	SetArchived(ctx context.Context, id uuid.UUID, archived bool) (*db.Project, error)
+	// DeleteProject stops serving the project, its updates, assets and storage objects are
+	// purged by the worker. Deleting a deleted project again returns it, so the purge can be
+	// requested again.
+	DeleteProject(ctx context.Context, id uuid.UUID) (*db.Project, error)
+	// StorageDriverURL returns the bucket of the project, empty for the primary bucket
+	StorageDriverURL(ctx context.Context, id uuid.UUID) (string, error)
+	Flavors(ctx context.Context, id uuid.UUID) ([]db.ProjectFlavor, error)
+	CreateFlavor(ctx context.Context, id uuid.UUID, name string) (*db.ProjectFlavor, error)
+	DeleteFlavor(ctx context.Context, id uuid.UUID, name string) error
+	HasFlavor(ctx context.Context, id uuid.UUID, name string) (bool, error)
 }
 
 type service struct {
@@ -32,13 +107,31 @@ func (s *service) CreateProject(
 	ctx context.Context,
 	name string,
 	updateProtocol api.UpdateProtocol,
+	environment string,
+	storageDriverURL string,
 ) (*db.Project, error) {
-	project, err := s.q.CreateProject(
-		ctx,
-		uuid.Must(uuid.NewV7()),
-		name,
-		db.UpdateProtocol(updateProtocol),
-	)
+	if environment == "" {
+		environment = DefaultEnvironment
+	}
+
+	_, err := s.q.GetProjectByNameAndEnvironment(ctx, name, environment)
+	if err == nil {
+		return nil, ErrProjectExists
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	project, err := s.q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           name,
+		UpdateProtocol: db.UpdateProtocol(updateProtocol),
+		Environment:    environment,
+		StorageDriverUrl: pgtype.Text{
+			String: storageDriverURL,
+			Valid:  storageDriverURL != "",
+		},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -58,3 +151,340 @@ func (s *service) ProjectByID(ctx context.Context, id uuid.UUID) (*db.Project, e
 
 	return &project, nil
 }
+
+// SetPublicAssetsURL enables serving assets from a public bucket, empty URL disables it
+func (s *service) SetPublicAssetsURL(
+	ctx context.Context,
+	id uuid.UUID,
+	publicAssetsURL string,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectPublicAssetsURL(ctx, id, pgtype.Text{
+		String: publicAssetsURL,
+		Valid:  publicAssetsURL != "",
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetAssetURLTemplate makes manifests reference assets by the templated URLs,
+// empty template disables it
+func (s *service) SetAssetURLTemplate(
+	ctx context.Context,
+	id uuid.UUID,
+	template string,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectAssetURLTemplate(ctx, id, pgtype.Text{
+		String: template,
+		Valid:  template != "",
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetReplicaRegions makes the worker copy assets of the updates published from now on
+// to the replicas in the regions, empty list disables replication
+func (s *service) SetReplicaRegions(
+	ctx context.Context,
+	id uuid.UUID,
+	regions []string,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectReplicaRegions(ctx, id, regions)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetAdminAllowedCIDRs restricts the management endpoints of the project to the CIDR ranges,
+// empty list allows all IPs
+func (s *service) SetAdminAllowedCIDRs(
+	ctx context.Context,
+	id uuid.UUID,
+	cidrs []string,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectAdminAllowedCIDRs(ctx, id, cidrs)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetMaxAssetCount limits the number of files of the updates prepared from now on
+func (s *service) SetMaxAssetCount(
+	ctx context.Context,
+	id uuid.UUID,
+	maxAssetCount int32,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectMaxAssetCount(ctx, id, maxAssetCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetMeteredDownloadLimit applies to the responses cached from now on, deferred updates are
+// served once their channel changes or the cached responses expire
+func (s *service) SetMeteredDownloadLimit(
+	ctx context.Context,
+	id uuid.UUID,
+	limit int64,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectMeteredDownloadLimit(ctx, id, limit)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetMaxStorageBytes applies to the updates prepared from now on, stored files aren't deleted
+// when the project is already over the quota
+func (s *service) SetMaxStorageBytes(
+	ctx context.Context,
+	id uuid.UUID,
+	maxStorageBytes int64,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectMaxStorageBytes(ctx, id, maxStorageBytes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetCodePushSettings applies to the responses cached from now on, cached responses expire
+// with their download URLs
+func (s *service) SetCodePushSettings(
+	ctx context.Context,
+	id uuid.UUID,
+	suggestBinaryUpdate bool,
+	descriptionSource api.CodePushDescriptionSource,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectCodePushSettings(
+		ctx,
+		id,
+		suggestBinaryUpdate,
+		string(descriptionSource),
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetStableAssetURLs applies to the responses cached from now on, cached responses expire
+// with their download URLs
+func (s *service) SetStableAssetURLs(
+	ctx context.Context,
+	id uuid.UUID,
+	enabled bool,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectStableAssetUrls(ctx, id, enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// SetAssetPriorities applies to the responses cached from now on, cached responses expire
+// with their download URLs
+func (s *service) SetAssetPriorities(
+	ctx context.Context,
+	id uuid.UUID,
+	priorities []string,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectAssetPriorities(ctx, id, priorities)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (s *service) SetArchived(
+	ctx context.Context,
+	id uuid.UUID,
+	archived bool,
+) (*db.Project, error) {
+	project, err := s.q.SetProjectArchivedAt(ctx, id, pgtype.Timestamptz{
+		Time:  time.Now(),
+		Valid: archived,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (s *service) DeleteProject(ctx context.Context, id uuid.UUID) (*db.Project, error) {
+	project, err := s.q.SoftDeleteProject(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (s *service) StorageDriverURL(ctx context.Context, id uuid.UUID) (string, error) {
+	driverURL, err := s.q.GetProjectStorageDriverURL(ctx, id)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+	return driverURL.String, nil
+}
+
+func (s *service) Environments(ctx context.Context, project *db.Project) ([]db.Project, error) {
+	return s.q.GetProjectEnvironments(ctx, project.Name)
+}
+
+// CopyToEnvironment copies the asset serving settings, channels, signing keys and updates
+// stay separate in every environment
+func (s *service) CopyToEnvironment(
+	ctx context.Context,
+	id uuid.UUID,
+	environment string,
+) (*db.Project, error) {
+	source, err := s.ProjectByID(ctx, id)
+	if err != nil || source == nil {
+		return nil, err
+	}
+	if source.Environment == environment {
+		return nil, ErrSameEnvironment
+	}
+
+	target, err := s.q.GetProjectByNameAndEnvironment(ctx, source.Name, environment)
+	if errors.Is(err, pgx.ErrNoRows) {
+		target, err = s.q.CreateProject(ctx, db.CreateProjectParams{
+			ID:             uuid.Must(uuid.NewV7()),
+			Name:           source.Name,
+			UpdateProtocol: source.UpdateProtocol,
+			Environment:    environment,
+			// the app stays in the same bucket in every environment
+			StorageDriverUrl: source.StorageDriverUrl,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.DeletedAt.Valid {
+		return nil, ErrProjectDeleted
+	}
+	if target.UpdateProtocol != source.UpdateProtocol {
+		return nil, ErrUpdateProtocolMismatch
+	}
+
+	project, err := s.q.SetProjectConfig(ctx, db.SetProjectConfigParams{
+		ID:               target.ID,
+		PublicAssetsUrl:  source.PublicAssetsUrl,
+		AssetUrlTemplate: source.AssetUrlTemplate,
+		ReplicaRegions:   source.ReplicaRegions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// CloneProject copies the asset serving settings, limits, admin CIDRs, bucket, channel
+// policies and flavors, signing keys, channel pins and updates aren't copied
+func (s *service) CloneProject(
+	ctx context.Context,
+	id uuid.UUID,
+	name string,
+	environment string,
+) (*db.Project, error) {
+	source, err := s.ProjectByID(ctx, id)
+	if err != nil || source == nil {
+		return nil, err
+	}
+	if environment == "" {
+		environment = source.Environment
+	}
+
+	_, err = s.q.GetProjectByNameAndEnvironment(ctx, name, environment)
+	if err == nil {
+		return nil, ErrProjectExists
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	project, err := s.q.CloneProject(ctx, db.CloneProjectParams{
+		ID:          uuid.Must(uuid.NewV7()),
+		Name:        name,
+		Environment: environment,
+		SourceID:    source.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.q.CopyChannelPolicies(ctx, project.ID, source.ID); err != nil {
+		return nil, fmt.Errorf("CopyChannelPolicies: %w", err)
+	}
+	if err := s.q.CopyProjectFlavors(ctx, project.ID, source.ID); err != nil {
+		return nil, fmt.Errorf("CopyProjectFlavors: %w", err)
+	}
+
+	return &project, nil
+}
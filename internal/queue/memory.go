@@ -0,0 +1,337 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryRedeliveryDelay is used when the handler neither acked nor nacked the message
+const memoryRedeliveryDelay = 5 * time.Second
+
+// memoryUpdateCheckBuffer is the number of update check events waiting to be consumed,
+// further events are dropped
+const memoryUpdateCheckBuffer = 4096
+
+// memoryQueue is an in-process queue, its messages are lost when the process exits.
+// Like the NATS consumer, it delivers one message at a time.
+type memoryQueue struct {
+	messages     chan *memoryMessage
+	purges       chan *memoryMessage
+	updateChecks chan []byte
+	done         chan struct{}
+
+	mu     sync.Mutex
+	timers map[*time.Timer]struct{}
+	closed bool
+	wg     sync.WaitGroup
+
+	channelChangedHandlers []func(data []byte)
+	objectsDeletedHandlers []func(data []byte)
+	// deadLetterHandler is set while the dead letters are consumed, the dead letters are kept
+	// until then, like they stay in the stream
+	deadLetterHandler func(data []byte)
+	deadLetters       [][]byte
+}
+
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{
+		messages:     make(chan *memoryMessage, 1024),
+		purges:       make(chan *memoryMessage, 1024),
+		updateChecks: make(chan []byte, memoryUpdateCheckBuffer),
+		done:         make(chan struct{}),
+		timers:       make(map[*time.Timer]struct{}),
+	}
+}
+
+func (q *memoryQueue) PublishProcessUpdateMessage(ctx context.Context, updateID uuid.UUID) error {
+	data, err := json.Marshal(ProcessUpdateMessagePayload{UpdateID: updateID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return q.publish(ctx, &memoryMessage{data: data, queue: q.messages})
+}
+
+func (q *memoryQueue) publish(ctx context.Context, msg *memoryMessage) error {
+	select {
+	case msg.queue <- msg:
+		return nil
+	case <-q.done:
+		return fmt.Errorf("queue is closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *memoryQueue) Consume(ctx context.Context, msgHandler MessageHandler) error {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case msg := <-q.messages:
+				q.handle(ctx, msg, msgHandler, true)
+			case <-q.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (q *memoryQueue) ConsumeDeadLetters(ctx context.Context, handler func(data []byte)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.deadLetterHandler = handler
+	pending := q.deadLetters
+	q.deadLetters = nil
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for _, data := range pending {
+			handler(data)
+		}
+		select {
+		case <-ctx.Done():
+		case <-q.done:
+		}
+		q.mu.Lock()
+		q.deadLetterHandler = nil
+		q.mu.Unlock()
+	}()
+
+	return nil
+}
+
+func (q *memoryQueue) PublishPurgeProjectMessage(
+	ctx context.Context,
+	projectID uuid.UUID,
+) error {
+	data, err := json.Marshal(PurgeProjectMessagePayload{ProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return q.publish(ctx, &memoryMessage{data: data, queue: q.purges})
+}
+
+func (q *memoryQueue) ConsumePurgeProjectMessages(
+	ctx context.Context,
+	msgHandler MessageHandler,
+) error {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case msg := <-q.purges:
+				q.handle(ctx, msg, msgHandler, false)
+			case <-q.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (q *memoryQueue) PublishUpdateCheckEvent(
+	ctx context.Context,
+	event UpdateCheckEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// never block the update check on a slow consumer
+	select {
+	case q.updateChecks <- data:
+		return nil
+	case <-q.done:
+		return fmt.Errorf("queue is closed")
+	default:
+		return fmt.Errorf("update check buffer is full")
+	}
+}
+
+func (q *memoryQueue) ConsumeUpdateCheckEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case data := <-q.updateChecks:
+				handler(data)
+			case <-q.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (q *memoryQueue) PublishChannelChangedEvent(
+	ctx context.Context,
+	event ChannelChangedEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	q.mu.Lock()
+	handlers := q.channelChangedHandlers
+	q.mu.Unlock()
+	for _, handler := range handlers {
+		handler(data)
+	}
+	return nil
+}
+
+func (q *memoryQueue) ConsumeChannelChangedEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.channelChangedHandlers = append(q.channelChangedHandlers, handler)
+	return nil
+}
+
+func (q *memoryQueue) PublishObjectsDeletedEvent(
+	ctx context.Context,
+	event ObjectsDeletedEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	q.mu.Lock()
+	handlers := q.objectsDeletedHandlers
+	q.mu.Unlock()
+	for _, handler := range handlers {
+		handler(data)
+	}
+	return nil
+}
+
+func (q *memoryQueue) ConsumeObjectsDeletedEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.objectsDeletedHandlers = append(q.objectsDeletedHandlers, handler)
+	return nil
+}
+
+func (q *memoryQueue) handle(
+	ctx context.Context,
+	msg *memoryMessage,
+	msgHandler MessageHandler,
+	deadLetters bool,
+) {
+	msg.deliveries++
+	msg.acked = false
+	msg.redeliveryDelay = memoryRedeliveryDelay
+
+	msgHandler(msg)
+
+	if msg.acked {
+		return
+	}
+	if deadLetters && msg.deliveries >= maxDeliveries {
+		q.mu.Lock()
+		handler := q.deadLetterHandler
+		if handler == nil {
+			q.deadLetters = append(q.deadLetters, msg.data)
+		}
+		q.mu.Unlock()
+		if handler != nil {
+			handler(msg.data)
+		}
+		return
+	}
+	q.redeliver(ctx, msg)
+}
+
+func (q *memoryQueue) redeliver(ctx context.Context, msg *memoryMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(msg.redeliveryDelay, func() {
+		q.mu.Lock()
+		delete(q.timers, timer)
+		q.mu.Unlock()
+
+		_ = q.publish(ctx, msg)
+	})
+	q.timers[timer] = struct{}{}
+}
+
+func (q *memoryQueue) HealthCheck() error {
+	return nil
+}
+
+func (q *memoryQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	for timer := range q.timers {
+		timer.Stop()
+	}
+	close(q.done)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+type memoryMessage struct {
+	data []byte
+	// queue the message is published and redelivered to
+	queue           chan *memoryMessage
+	deliveries      int
+	acked           bool
+	redeliveryDelay time.Duration
+}
+
+func (m *memoryMessage) Data() []byte {
+	return m.data
+}
+
+func (m *memoryMessage) Ack() error {
+	m.acked = true
+	return nil
+}
+
+func (m *memoryMessage) NakWithDelay(delay time.Duration) error {
+	m.redeliveryDelay = delay
+	return nil
+}
+
+// Term drops the message without handing it to the dead letter handler
+func (m *memoryMessage) Term() error {
+	m.acked = true
+	return nil
+}
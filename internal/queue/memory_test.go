@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQueue(t *testing.T) {
+	ctx := context.Background()
+	q := newMemoryQueue()
+	defer q.Close()
+
+	deliveries := make(chan uuid.UUID, maxDeliveries)
+	dropped := make(chan []byte, 1)
+	err := q.Consume(ctx, func(msg Message) {
+		payload, err := ParseProcessUpdateMessage(msg.Data())
+		require.NoError(t, err)
+		deliveries <- payload.UpdateID
+		require.NoError(t, msg.NakWithDelay(time.Millisecond))
+	})
+	require.NoError(t, err)
+	require.NoError(t, q.ConsumeDeadLetters(ctx, func(data []byte) { dropped <- data }))
+
+	updateID := uuid.New()
+	require.NoError(t, q.PublishProcessUpdateMessage(ctx, updateID))
+
+	select {
+	case data := <-dropped:
+		payload, err := ParseProcessUpdateMessage(data)
+		require.NoError(t, err)
+		require.Equal(t, updateID, payload.UpdateID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not passed to the dlq handler")
+	}
+	require.Len(t, deliveries, maxDeliveries)
+}
+
+func TestMemoryQueueDeadLettersKept(t *testing.T) {
+	ctx := context.Background()
+	q := newMemoryQueue()
+	defer q.Close()
+
+	err := q.Consume(ctx, func(msg Message) {
+		require.NoError(t, msg.NakWithDelay(time.Millisecond))
+	})
+	require.NoError(t, err)
+
+	updateID := uuid.New()
+	require.NoError(t, q.PublishProcessUpdateMessage(ctx, updateID))
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return len(q.deadLetters) == 1
+	}, 5*time.Second, time.Millisecond)
+
+	// the dead letters are handled once a replica consumes them, and only by the current one
+	consumerCtx, cancel := context.WithCancel(ctx)
+	dropped := make(chan []byte, 1)
+	require.NoError(t, q.ConsumeDeadLetters(consumerCtx, func(data []byte) { dropped <- data }))
+	select {
+	case data := <-dropped:
+		payload, err := ParseProcessUpdateMessage(data)
+		require.NoError(t, err)
+		require.Equal(t, updateID, payload.UpdateID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dead letter was not passed to the handler")
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.deadLetterHandler == nil
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestMemoryQueuePurgeProject(t *testing.T) {
+	ctx := context.Background()
+	q := newMemoryQueue()
+	defer q.Close()
+
+	// purges are retried until they succeed, there's no dlq handler
+	deliveries := make(chan uuid.UUID, maxDeliveries+1)
+	err := q.ConsumePurgeProjectMessages(ctx, func(msg Message) {
+		payload, err := ParsePurgeProjectMessage(msg.Data())
+		require.NoError(t, err)
+		deliveries <- payload.ProjectID
+		if len(deliveries) <= maxDeliveries {
+			require.NoError(t, msg.NakWithDelay(time.Millisecond))
+			return
+		}
+		require.NoError(t, msg.Ack())
+	})
+	require.NoError(t, err)
+
+	projectID := uuid.New()
+	require.NoError(t, q.PublishPurgeProjectMessage(ctx, projectID))
+
+	require.Eventually(t, func() bool {
+		return len(deliveries) == maxDeliveries+1
+	}, 5*time.Second, time.Millisecond)
+	require.Equal(t, projectID, <-deliveries)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,7 +21,20 @@ func (c *Connection) PublishProcessUpdateMessage(
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	return c.nc.Publish(processUpdateSubjectName, data)
+	if _, err := c.ensureStream(ctx); err != nil {
+		return err
+	}
+	if !c.nc.IsConnected() {
+		return ErrUnavailable
+	}
+
+	// acknowledged by the stream, so a commit isn't lost in the buffer of a reconnecting client
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := c.js.Publish(ctx, processUpdateSubjectName, data); err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	return nil
 }
 
 func ParseProcessUpdateMessage(data []byte) (*ProcessUpdateMessagePayload, error) {
@@ -30,3 +44,131 @@ func ParseProcessUpdateMessage(data []byte) (*ProcessUpdateMessagePayload, error
 	}
 	return &payload, nil
 }
+
+// PurgeProjectMessagePayload is sent when the project was deleted
+type PurgeProjectMessagePayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+func (c *Connection) PublishPurgeProjectMessage(
+	ctx context.Context,
+	projectID uuid.UUID,
+) error {
+	data, err := json.Marshal(PurgeProjectMessagePayload{ProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if _, err := c.ensureStream(ctx); err != nil {
+		return err
+	}
+	if !c.nc.IsConnected() {
+		return ErrUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := c.js.Publish(ctx, purgeProjectSubjectName, data); err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	return nil
+}
+
+func ParsePurgeProjectMessage(data []byte) (*PurgeProjectMessagePayload, error) {
+	var payload PurgeProjectMessagePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// UpdateCheckEventPayload is an update check sampled for analytics
+type UpdateCheckEventPayload struct {
+	ProjectID      uuid.UUID `json:"project_id"`
+	Channel        string    `json:"channel"`
+	RuntimeVersion string    `json:"runtime_version"`
+	Platform       string    `json:"platform"`
+	Decision       string    `json:"decision"`
+	// UpdateID is the update served to the client, nil when there was nothing to install
+	UpdateID  *uuid.UUID `json:"update_id,omitempty"`
+	CheckedAt time.Time  `json:"checked_at"`
+	// Reason tells the rollbacks to the embedded update apart, empty for other decisions
+	Reason string `json:"reason,omitempty"`
+	// ClientID identifies the device, empty when the client didn't send one
+	ClientID string `json:"client_id,omitempty"`
+	// CurrentUpdateID is the update the device was running, sent only by Expo clients
+	CurrentUpdateID *uuid.UUID `json:"current_update_id,omitempty"`
+	// CodePushLabel of the served update, set when the label isn't the update ID
+	CodePushLabel string `json:"codepush_label,omitempty"`
+}
+
+func (c *Connection) PublishUpdateCheckEvent(
+	ctx context.Context,
+	event UpdateCheckEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return c.nc.Publish(updateCheckSubjectName, data)
+}
+
+func ParseUpdateCheckEvent(data []byte) (*UpdateCheckEventPayload, error) {
+	var payload UpdateCheckEventPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// ChannelChangedEventPayload is sent when an update of the channel was published, rolled back
+// or pinned
+type ChannelChangedEventPayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	// Channel is empty when all channels of the project changed, e.g. it was archived or deleted
+	Channel string `json:"channel"`
+}
+
+func (c *Connection) PublishChannelChangedEvent(
+	ctx context.Context,
+	event ChannelChangedEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return c.nc.Publish(channelChangedSubjectName, data)
+}
+
+func ParseChannelChangedEvent(data []byte) (*ChannelChangedEventPayload, error) {
+	var payload ChannelChangedEventPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// ObjectsDeletedEventPayload is sent when objects of the primary bucket were deleted or moved
+// to cold storage
+type ObjectsDeletedEventPayload struct {
+	// ObjectKeyPrefixes of the objects, full object keys are prefixes of themselves
+	ObjectKeyPrefixes []string `json:"object_key_prefixes"`
+}
+
+func (c *Connection) PublishObjectsDeletedEvent(
+	ctx context.Context,
+	event ObjectsDeletedEventPayload,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return c.nc.Publish(objectsDeletedSubjectName, data)
+}
+
+func ParseObjectsDeletedEvent(data []byte) (*ObjectsDeletedEventPayload, error) {
+	var payload ObjectsDeletedEventPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
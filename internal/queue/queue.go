@@ -3,13 +3,16 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/a-gierczak/paratrooper/internal/logger"
 
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 	"go.uber.org/zap"
@@ -19,19 +22,111 @@ const (
 	streamName               = "UPDATES"
 	updateSubjectsWildcard   = "UPDATE.>"
 	processUpdateSubjectName = "UPDATE.PROCESS"
+	purgeProjectSubjectName  = "UPDATE.PURGE_PROJECT"
+	// purgeProjectAckWait is the time a purge may take before the message is redelivered,
+	// purges of projects with many updates take long
+	purgeProjectAckWait = 10 * time.Minute
+	// maxDeliveries of a message before it's handed to the dead letter handler
+	maxDeliveries = 5
+	// processUpdateConsumerName is the durable consumer of the process update messages
+	processUpdateConsumerName = "process-update"
+	// updateCheckSubjectName is outside of the stream, analytics events aren't persisted
+	updateCheckSubjectName = "ANALYTICS.UPDATE_CHECK"
+	// analyticsQueueGroup makes every event delivered to a single worker
+	analyticsQueueGroup = "analytics"
+	// channelChangedSubjectName is outside of the stream, every API server receives the events
+	channelChangedSubjectName = "CACHE.CHANNEL_CHANGED"
+	// objectsDeletedSubjectName is outside of the stream, every edge cache receives the events
+	objectsDeletedSubjectName = "CACHE.OBJECTS_DELETED"
+	// reconnectWait between the attempts to reconnect to NATS
+	reconnectWait = 2 * time.Second
 )
 
+// ErrUnavailable is returned when a message that must not be lost can't be published,
+// because NATS is unreachable
+var ErrUnavailable = errors.New("queue is unavailable")
+
+const (
+	DriverNATS   = "nats"
+	DriverMemory = "memory"
+)
+
+type Config struct {
+	// Driver memory is an in-process queue, messages are then processed by the API server itself
+	Driver  string `env:"QUEUE_DRIVER,default=nats" validate:"oneof=nats memory"`
+	NATSURL string `env:"NATS_URL"`
+}
+
+type Queue interface {
+	PublishProcessUpdateMessage(ctx context.Context, updateID uuid.UUID) error
+	// Consume delivers messages to msgHandler, until they are acked or terminated, or they
+	// were delivered too many times
+	Consume(ctx context.Context, msgHandler MessageHandler) error
+	// ConsumeDeadLetters passes the messages that Consume delivered too many times to handler
+	// and deletes them, until ctx is canceled. A single replica should consume them, e.g. the
+	// leader, a message is handled again when it's passed to the handler concurrently.
+	ConsumeDeadLetters(ctx context.Context, handler func(data []byte)) error
+	// PublishPurgeProjectMessage must not be lost either, the deleted project would never be
+	// purged
+	PublishPurgeProjectMessage(ctx context.Context, projectID uuid.UUID) error
+	// ConsumePurgeProjectMessages delivers purge messages to msgHandler until they are acked or
+	// terminated, they're redelivered for as long as the purge fails
+	ConsumePurgeProjectMessages(ctx context.Context, msgHandler MessageHandler) error
+	// PublishUpdateCheckEvent is best effort, events are dropped when nothing consumes them
+	PublishUpdateCheckEvent(ctx context.Context, event UpdateCheckEventPayload) error
+	// ConsumeUpdateCheckEvents delivers update check events to handler, they're never redelivered
+	ConsumeUpdateCheckEvents(ctx context.Context, handler func(data []byte)) error
+	// PublishChannelChangedEvent is best effort, it notifies the API servers that the updates
+	// served on a channel changed, so they invalidate the cached responses
+	PublishChannelChangedEvent(ctx context.Context, event ChannelChangedEventPayload) error
+	// ConsumeChannelChangedEvents delivers every channel changed event to every consumer
+	ConsumeChannelChangedEvents(ctx context.Context, handler func(data []byte)) error
+	// PublishObjectsDeletedEvent is best effort, it notifies the edge caches that the objects
+	// were deleted or moved to cold storage, so they stop serving them
+	PublishObjectsDeletedEvent(ctx context.Context, event ObjectsDeletedEventPayload) error
+	// ConsumeObjectsDeletedEvents delivers every objects deleted event to every consumer
+	ConsumeObjectsDeletedEvents(ctx context.Context, handler func(data []byte)) error
+	HealthCheck() error
+	Close()
+}
+
+// Message is implemented by jetstream.Msg
+type Message interface {
+	Data() []byte
+	Ack() error
+	NakWithDelay(delay time.Duration) error
+	Term() error
+}
+
+type MessageHandler func(msg Message)
+
+func New(ctx context.Context, config Config) (Queue, error) {
+	if config.Driver == DriverMemory {
+		log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+		log.Info("initializing in-process queue")
+		return newMemoryQueue(), nil
+	}
+
+	return ConnectLazily(ctx, config.NATSURL)
+}
+
 type Connection struct {
-	nc                   *nats.Conn
-	js                   jetstream.JetStream
+	nc *nats.Conn
+	js jetstream.JetStream
+	// streamMu guards the stream, it's created once NATS is reachable
+	streamMu             sync.Mutex
 	stream               jetstream.Stream
 	dlqSub               *nats.Subscription
 	processUpdateCons    jetstream.Consumer
 	processUpdateConsCtx jetstream.ConsumeContext
+	purgeProjectConsCtx  jetstream.ConsumeContext
+	updateCheckSub       *nats.Subscription
+	channelChangedSub    *nats.Subscription
+	objectsDeletedSub    *nats.Subscription
 }
 
-func (c *Connection) connect(uri string) error {
-	conn, err := nats.Connect(uri)
+func (c *Connection) connect(uri string, opts ...nats.Option) error {
+	conn, err := nats.Connect(uri, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to nats: %w", err)
 	}
@@ -44,57 +139,109 @@ func (c *Connection) connect(uri string) error {
 	}
 	c.js = js
 
+	return nil
+}
+
+// ensureStream creates the stream on the first use
+func (c *Connection) ensureStream(ctx context.Context) (jetstream.Stream, error) {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	if c.stream != nil {
+		return c.stream, nil
+	}
+	if !c.nc.IsConnected() {
+		return nil, ErrUnavailable
+	}
+
 	cfg := jetstream.StreamConfig{
 		Name:      streamName,
 		Retention: jetstream.WorkQueuePolicy,
 		Subjects:  []string{updateSubjectsWildcard},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	stream, err := c.js.CreateOrUpdateStream(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create stream: %w", err)
+		return nil, fmt.Errorf("failed to create stream: %w", err)
 	}
 	c.stream = stream
 
-	return nil
+	return stream, nil
 }
 
+// Connect fails when NATS is unreachable
 func Connect(ctx context.Context, uri string) (*Connection, error) {
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
 	conn := new(Connection)
 
 	err := conn.connect(uri)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := conn.ensureStream(ctx); err != nil {
+		conn.nc.Close()
+		return nil, err
+	}
 
 	log.Info("connected to NATS")
 	return conn, nil
 }
 
-func (c *Connection) Consume(
-	ctx context.Context,
-	msgHandler jetstream.MessageHandler,
-	dlqHandler func(msg *jetstream.RawStreamMsg),
-) error {
-	log := logger.FromContext(ctx)
+// ConnectLazily returns the connection even when NATS is unreachable, it keeps reconnecting in
+// the background. Subscriptions are made once it's reachable, the messages that must not be
+// lost fail to publish with ErrUnavailable until then.
+func ConnectLazily(ctx context.Context, uri string) (*Connection, error) {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+	conn := new(Connection)
+
+	err := conn.connect(
+		uri,
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(reconnectWait),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Warn("disconnected from NATS", zap.Error(err))
+			}
+		}),
+		nats.ReconnectHandler(func(_ *nats.Conn) {
+			log.Info("reconnected to NATS")
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ensureStream(ctx); err != nil {
+		log.Warn("NATS is unreachable, reconnecting in the background", zap.Error(err))
+		return conn, nil
+	}
+
+	log.Info("connected to NATS")
+	return conn, nil
+}
+
+func (c *Connection) Consume(ctx context.Context, msgHandler MessageHandler) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+
+	if _, err := c.ensureStream(ctx); err != nil {
+		return err
+	}
 
 	streamCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	consumerName := "process-update"
 	cons, err := c.js.CreateOrUpdateConsumer(
 		streamCtx,
 		streamName,
 		jetstream.ConsumerConfig{
 			AckPolicy:     jetstream.AckExplicitPolicy,
-			Name:          consumerName,
-			Durable:       consumerName,
+			Name:          processUpdateConsumerName,
+			Durable:       processUpdateConsumerName,
 			FilterSubject: processUpdateSubjectName,
-			MaxDeliver:    5,
+			MaxDeliver:    maxDeliveries,
 			BackOff: []time.Duration{
 				5 * time.Second,
 				12 * time.Second,
@@ -109,116 +256,249 @@ func (c *Connection) Consume(
 	c.processUpdateCons = cons
 	log.Info("process update consumer created")
 
-	consumeCtx, err := c.processUpdateCons.Consume(msgHandler, jetstream.PullMaxMessages(1))
+	consumeCtx, err := c.processUpdateCons.Consume(
+		func(msg jetstream.Msg) { msgHandler(msg) },
+		jetstream.PullMaxMessages(1),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to consume messages: %w", err)
 	}
 	c.processUpdateConsCtx = consumeCtx
 
-	dlqEventSubject := fmt.Sprintf("$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES.%s.>", streamName)
-	dlqSub, err := c.nc.Subscribe(dlqEventSubject, c.maxDeliveriesHandlerWrapper(ctx, dlqHandler))
+	return nil
+}
+
+// ConsumeDeadLetters subscribes to the max deliveries advisories of the process update
+// consumer, NATS publishes them to every subscriber
+func (c *Connection) ConsumeDeadLetters(ctx context.Context, handler func(data []byte)) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+
+	subject := fmt.Sprintf(
+		"$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES.%s.%s",
+		streamName,
+		processUpdateConsumerName,
+	)
+	sub, err := c.nc.Subscribe(subject, c.deadLetterHandler(ctx, handler))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to max deliveries advisories: %w", err)
+	}
+	c.dlqSub = sub
+	log.Info("subscribed to max deliveries advisories")
+
+	go func() {
+		<-ctx.Done()
+		// fails when the connection was closed first, the subscription is gone then too
+		_ = sub.Unsubscribe()
+		log.Info("unsubscribed from max deliveries advisories")
+	}()
+
+	return nil
+}
+
+func (c *Connection) ConsumePurgeProjectMessages(
+	ctx context.Context,
+	msgHandler MessageHandler,
+) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+
+	if _, err := c.ensureStream(ctx); err != nil {
+		return err
+	}
+
+	streamCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	consumerName := "purge-project"
+	cons, err := c.js.CreateOrUpdateConsumer(
+		streamCtx,
+		streamName,
+		jetstream.ConsumerConfig{
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			Name:          consumerName,
+			Durable:       consumerName,
+			FilterSubject: purgeProjectSubjectName,
+			AckWait:       purgeProjectAckWait,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	log.Info("purge project consumer created")
+
+	consumeCtx, err := cons.Consume(
+		func(msg jetstream.Msg) { msgHandler(msg) },
+		jetstream.PullMaxMessages(1),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to subscribe to max deliveries dlq: %w", err)
+		return fmt.Errorf("failed to consume messages: %w", err)
 	}
-	c.dlqSub = dlqSub
-	log.Info("subscribed to max deliveries dlq")
+	c.purgeProjectConsCtx = consumeCtx
 
 	return nil
 }
 
-func (c *Connection) maxDeliveriesHandlerWrapper(
+func (c *Connection) deadLetterHandler(
 	ctx context.Context,
-	handler func(msg *jetstream.RawStreamMsg),
+	handler func(data []byte),
 ) func(msg *nats.Msg) {
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
 	log = log.With(zap.String("consumer", "dlq"))
 	return func(msg *nats.Msg) {
-		type DLQMessage struct {
-			StreamSeq *int `json:"stream_seq,omitempty"`
-		}
-
-		var dlqMsg DLQMessage
-		err := json.Unmarshal(msg.Data, &dlqMsg)
+		streamSeq, err := parseMaxDeliveriesAdvisory(msg.Data)
 		if err != nil {
-			log.Error("failed to unmarshal dlq message", zap.Error(err))
+			log.Error("failed to parse max deliveries advisory", zap.Error(err))
 			return
 		}
 
-		if dlqMsg.StreamSeq == nil {
-			log.Error("stream_seq is not set")
-			return
+		seqLog := log.With(zap.Uint64("stream_seq", streamSeq))
+		if err := c.popDeadLetter(ctx, streamSeq, handler); err != nil {
+			seqLog.Error("failed to handle dead letter", zap.Error(err))
 		}
+	}
+}
 
-		streamSeq := uint64(*dlqMsg.StreamSeq)
-		rawMsg, err := c.stream.GetMsg(ctx, streamSeq)
-		if err != nil {
-			log.Error("failed to get message from stream", zap.Error(err))
-			return
-		}
+// popDeadLetter passes the message to the handler and deletes it from the stream. It's
+// idempotent, a message that's already deleted was handled before, e.g. by the previous
+// leader or for a redelivered advisory.
+func (c *Connection) popDeadLetter(
+	ctx context.Context,
+	streamSeq uint64,
+	handler func(data []byte),
+) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue).
+		With(zap.Uint64("stream_seq", streamSeq))
 
-		handler(rawMsg)
+	stream, err := c.ensureStream(ctx)
+	if err != nil {
+		return err
+	}
 
-		if err := c.stream.DeleteMsg(ctx, streamSeq); err != nil {
-			log.Error(
-				"failed to delete message from stream",
-				zap.Error(err),
-				zap.Uint64("stream_seq", streamSeq),
-			)
-		} else {
-			log.Info("deleted message from stream", zap.Uint64("stream_seq", streamSeq))
-		}
+	rawMsg, err := stream.GetMsg(ctx, streamSeq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		log.Debug("dead letter was already handled")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get message from stream: %w", err)
+	}
+
+	handler(rawMsg.Data)
+
+	// deleting a missing message is reported as unsuccessful without a reason
+	err = stream.DeleteMsg(ctx, streamSeq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) ||
+		errors.Is(err, jetstream.ErrMsgDeleteUnsuccessful) {
+		log.Debug("dead letter was already deleted")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete message from stream: %w", err)
 	}
+	log.Info("deleted dead letter from stream")
+
+	return nil
 }
 
-func (c *Connection) PopOriginalMessage(
-	ctx context.Context,
-	msg *nats.Msg,
-) ([]byte, error) {
-	type DLQMessage struct {
-		StreamSeq *int `json:"stream_seq,omitempty"`
+// parseMaxDeliveriesAdvisory returns the stream sequence of the message that was delivered
+// too many times
+func parseMaxDeliveriesAdvisory(data []byte) (uint64, error) {
+	var advisory struct {
+		StreamSeq *uint64 `json:"stream_seq,omitempty"`
+	}
+	if err := json.Unmarshal(data, &advisory); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal advisory: %w", err)
+	}
+	if advisory.StreamSeq == nil {
+		return 0, errors.New("stream_seq is not set")
 	}
+	return *advisory.StreamSeq, nil
+}
+
+func (c *Connection) ConsumeUpdateCheckEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
 
-	var dlqMsg DLQMessage
-	err := json.Unmarshal(msg.Data, &dlqMsg)
+	sub, err := c.nc.QueueSubscribe(
+		updateCheckSubjectName,
+		analyticsQueueGroup,
+		func(msg *nats.Msg) { handler(msg.Data) },
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal dlq message: %w", err)
+		return fmt.Errorf("failed to subscribe to update check events: %w", err)
 	}
+	c.updateCheckSub = sub
+	log.Info("subscribed to update check events")
 
-	if dlqMsg.StreamSeq == nil {
-		return nil, fmt.Errorf("stream_seq is not set")
-	}
+	return nil
+}
 
-	streamSeq := uint64(*dlqMsg.StreamSeq)
-	rawMsg, err := c.stream.GetMsg(ctx, streamSeq)
+func (c *Connection) ConsumeChannelChangedEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+
+	sub, err := c.nc.Subscribe(
+		channelChangedSubjectName,
+		func(msg *nats.Msg) { handler(msg.Data) },
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get message from stream: %w", err)
+		return fmt.Errorf("failed to subscribe to channel changed events: %w", err)
 	}
+	c.channelChangedSub = sub
+	log.Info("subscribed to channel changed events")
 
-	log := logger.FromContext(ctx)
-	if err := c.stream.DeleteMsg(ctx, streamSeq); err != nil {
-		log.Error(
-			"failed to delete message from stream",
-			zap.Error(err),
-			zap.Uint64("stream_seq", streamSeq),
-		)
-	} else {
-		log.Info("deleted message from stream", zap.Uint64("stream_seq", streamSeq))
+	return nil
+}
+
+func (c *Connection) ConsumeObjectsDeletedEvents(
+	ctx context.Context,
+	handler func(data []byte),
+) error {
+	log := logger.ComponentFromContext(ctx, logger.ComponentQueue)
+
+	sub, err := c.nc.Subscribe(
+		objectsDeletedSubjectName,
+		func(msg *nats.Msg) { handler(msg.Data) },
+	)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to objects deleted events: %w", err)
 	}
+	c.objectsDeletedSub = sub
+	log.Info("subscribed to objects deleted events")
 
-	return rawMsg.Data, nil
+	return nil
 }
 
 func (c *Connection) Close() {
 	if c.dlqSub != nil {
 		c.dlqSub.Unsubscribe()
 	}
+	if c.updateCheckSub != nil {
+		c.updateCheckSub.Unsubscribe()
+	}
+	if c.channelChangedSub != nil {
+		c.channelChangedSub.Unsubscribe()
+	}
+	if c.objectsDeletedSub != nil {
+		c.objectsDeletedSub.Unsubscribe()
+	}
 	if c.processUpdateConsCtx != nil {
 		c.processUpdateConsCtx.Stop()
 	}
+	if c.purgeProjectConsCtx != nil {
+		c.purgeProjectConsCtx.Stop()
+	}
 	c.nc.Close()
 }
 
 func (c *Connection) HealthCheck() error {
+	if !c.nc.IsConnected() {
+		return ErrUnavailable
+	}
+
 	natsServerURLs := c.nc.Servers()
 	if len(natsServerURLs) == 0 {
 		return nats.ErrNoServers
@@ -0,0 +1,46 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// nothing listens on the port
+const unreachableNATSURL = "nats://127.0.0.1:1"
+
+func TestConnectLazily(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	_, err := Connect(ctx, unreachableNATSURL)
+	require.Error(t, err)
+
+	conn, err := ConnectLazily(ctx, unreachableNATSURL)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.ErrorIs(t, conn.HealthCheck(), ErrUnavailable)
+	require.ErrorIs(t, conn.PublishProcessUpdateMessage(ctx, uuid.New()), ErrUnavailable)
+	// events are best effort, they're buffered until NATS is reachable
+	err = conn.PublishChannelChangedEvent(
+		ctx,
+		ChannelChangedEventPayload{ProjectID: uuid.New(), Channel: "production"},
+	)
+	require.NoError(t, err)
+}
+
+func TestParseMaxDeliveriesAdvisory(t *testing.T) {
+	streamSeq, err := parseMaxDeliveriesAdvisory([]byte(`{"stream":"UPDATES","stream_seq":42}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), streamSeq)
+
+	_, err = parseMaxDeliveriesAdvisory([]byte(`{"stream":"UPDATES"}`))
+	require.Error(t, err)
+	_, err = parseMaxDeliveriesAdvisory([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+// Package schema detects drift between the database and db/schema.sql the queries were generated
+// from, there are no migrations, so a partially updated database fails queries at runtime
+package schema
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	dbschema "github.com/a-gierczak/paratrooper/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// DriftActionFail refuses to start
+	DriftActionFail = "fail"
+	// DriftActionReadOnly starts, but rejects the management requests changing data
+	DriftActionReadOnly = "readonly"
+	// DriftActionIgnore only logs the drift
+	DriftActionIgnore = "ignore"
+)
+
+type Config struct {
+	// DriftAction is fail, readonly or ignore
+	DriftAction string `env:"SCHEMA_DRIFT_ACTION,default=fail"`
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+var createTableRegex = regexp.MustCompile(`(?is)create table (\w+)\s*\((.*?)\n\);`)
+
+// Columns returns the columns of every table of the schema, constraints are skipped
+func Columns(schema string) map[string][]string {
+	tables := make(map[string][]string)
+	for _, match := range createTableRegex.FindAllStringSubmatch(schema, -1) {
+		columns := make([]string, 0)
+		for _, line := range strings.Split(match[2], "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "--") {
+				continue
+			}
+			switch strings.ToLower(fields[0]) {
+			case "constraint", "primary", "unique", "foreign", "check":
+				continue
+			}
+			columns = append(columns, fields[0])
+		}
+		tables[match[1]] = columns
+	}
+	return tables
+}
+
+// MissingColumns returns the sorted table.column names of db/schema.sql the database doesn't have
+func MissingColumns(ctx context.Context, conn querier) ([]string, error) {
+	rows, err := conn.Query(
+		ctx,
+		`select table_name, column_name
+		from information_schema.columns
+		where table_schema = current_schema()`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+
+	actual := make(map[string][]string)
+	var table, column string
+	_, err = pgx.ForEachRow(rows, []any{&table, &column}, func() error {
+		actual[table] = append(actual[table], column)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	var missing []string
+	for table, columns := range Columns(dbschema.Schema) {
+		for _, column := range columns {
+			if !slices.Contains(actual[table], column) {
+				missing = append(missing, table+"."+column)
+			}
+		}
+	}
+	slices.Sort(missing)
+	return missing, nil
+}
+
+// Check compares the database with the schema on startup, it returns an error when the server
+// should refuse to start, and readOnly when it should start rejecting changes
+func Check(ctx context.Context, conn querier, config Config) (readOnly bool, err error) {
+	if !slices.Contains(
+		[]string{DriftActionFail, DriftActionReadOnly, DriftActionIgnore},
+		config.DriftAction,
+	) {
+		return false, fmt.Errorf(
+			"SCHEMA_DRIFT_ACTION must be one of %s, %s, %s",
+			DriftActionFail,
+			DriftActionReadOnly,
+			DriftActionIgnore,
+		)
+	}
+
+	missing, err := MissingColumns(ctx, conn)
+	if err != nil {
+		return false, fmt.Errorf("failed to check database schema: %w", err)
+	}
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	log := logger.FromContext(ctx).With(
+		zap.Strings("missing_columns", missing),
+		zap.String("action", config.DriftAction),
+	)
+	switch config.DriftAction {
+	case DriftActionIgnore:
+		log.Warn("database schema drifted from db/schema.sql")
+		return false, nil
+	case DriftActionReadOnly:
+		log.Error("database schema drifted from db/schema.sql, starting read-only")
+		return true, nil
+	default:
+		return false, fmt.Errorf(
+			"database schema drifted from db/schema.sql, missing columns: %s",
+			strings.Join(missing, ", "),
+		)
+	}
+}
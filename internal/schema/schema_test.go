@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	dbschema "github.com/a-gierczak/paratrooper/db"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumns(t *testing.T) {
+	tables := Columns(dbschema.Schema)
+
+	require.Contains(t, tables, "projects")
+	require.Contains(t, tables["projects"], "update_protocol")
+	require.Contains(t, tables["update_assets"], "content_encoding")
+	require.NotContains(t, tables["updates"], "constraint")
+}
+
+func TestCheckConfig(t *testing.T) {
+	_, err := Check(context.Background(), nil, Config{DriftAction: "panic"})
+	require.Error(t, err)
+}
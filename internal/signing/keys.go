@@ -0,0 +1,133 @@
+// Package signing manages the per-project keys signing update manifests. A rotated key
+// keeps being usable during the overlap period, so clients still requesting it by its key ID
+// validate their manifests, while the rest get manifests signed with the new key.
+package signing
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+)
+
+// AlgorithmRSASHA256 is the only algorithm of the Expo code signing spec
+const AlgorithmRSASHA256 = "rsa-v1_5-sha256"
+
+type KeyStatus string
+
+const (
+	// KeyStatusActive keys sign manifests of clients which don't request a specific key
+	KeyStatusActive KeyStatus = "active"
+	// KeyStatusRetiring keys were rotated, they sign only manifests requesting their key ID
+	// until the overlap period ends
+	KeyStatusRetiring KeyStatus = "retiring"
+	KeyStatusRetired  KeyStatus = "retired"
+)
+
+func Status(key *db.SigningKey, now time.Time) KeyStatus {
+	if !key.RetiredAt.Valid {
+		return KeyStatusActive
+	}
+	if key.RetiredAt.Time.After(now) {
+		return KeyStatusRetiring
+	}
+	return KeyStatusRetired
+}
+
+// SelectKey returns the key signing a manifest, keys have to be sorted from the newest.
+// The requested key is used while it's not retired, without a key ID the newest active
+// key is used. It returns nil when there's no such key.
+func SelectKey(keys []db.SigningKey, keyID string, now time.Time) *db.SigningKey {
+	for i := range keys {
+		status := Status(&keys[i], now)
+		if keyID != "" && keys[i].KeyID == keyID && status != KeyStatusRetired {
+			return &keys[i]
+		}
+		if keyID == "" && status == KeyStatusActive {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+func ParsePrivateKey(keyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+// ParseCertificateChain returns the certificates of the chain, the leaf certificate first
+func ParseCertificateChain(chainPEM string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(chainPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return certificates, nil
+}
+
+// validateKeyPair checks that the leaf certificate of the chain belongs to the private key
+// and that it's valid for code signing now
+func validateKeyPair(keyPEM string, chainPEM string, now time.Time) error {
+	privateKey, err := ParsePrivateKey(keyPEM)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+
+	certificates, err := ParseCertificateChain(chainPEM)
+	if err != nil {
+		return fmt.Errorf("invalid certificate chain: %w", err)
+	}
+
+	leaf := certificates[0]
+	if !privateKey.PublicKey.Equal(leaf.PublicKey) {
+		return errors.New("first certificate of the chain doesn't match the private key")
+	}
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return errors.New("certificate is not valid at this time")
+	}
+
+	for i := 1; i < len(certificates); i++ {
+		if err := certificates[i-1].CheckSignatureFrom(certificates[i]); err != nil {
+			return fmt.Errorf("certificate %d is not signed by the next one: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func newCertificate(
+	t *testing.T,
+	key *rsa.PrivateKey,
+	parent *x509.Certificate,
+	parentKey *rsa.PrivateKey,
+) (*x509.Certificate, string) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "paratrooper"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func newKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return key, string(keyPEM)
+}
+
+func TestValidateKeyPair(t *testing.T) {
+	rootKey, _ := newKey(t)
+	root, rootPEM := newCertificate(t, rootKey, nil, nil)
+	leafKey, leafKeyPEM := newKey(t)
+	_, leafPEM := newCertificate(t, leafKey, root, rootKey)
+	now := time.Now()
+
+	require.NoError(t, validateKeyPair(leafKeyPEM, leafPEM+rootPEM, now))
+	require.NoError(t, validateKeyPair(leafKeyPEM, leafPEM, now))
+
+	_, otherKeyPEM := newKey(t)
+	require.ErrorContains(
+		t,
+		validateKeyPair(otherKeyPEM, leafPEM, now),
+		"doesn't match the private key",
+	)
+	require.ErrorContains(
+		t,
+		validateKeyPair(leafKeyPEM, leafPEM, now.Add(2*time.Hour)),
+		"not valid at this time",
+	)
+	// the root doesn't sign itself as the next certificate of the chain
+	require.Error(t, validateKeyPair(leafKeyPEM, rootPEM+leafPEM, now))
+	require.Error(t, validateKeyPair("not a key", leafPEM, now))
+	require.Error(t, validateKeyPair(leafKeyPEM, "not a certificate", now))
+}
+
+func TestSelectKey(t *testing.T) {
+	now := time.Now()
+	// the newest first
+	keys := []db.SigningKey{
+		{KeyID: "new"},
+		{KeyID: "previous", RetiredAt: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}},
+		{KeyID: "old", RetiredAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}},
+	}
+
+	require.Equal(t, KeyStatusActive, Status(&keys[0], now))
+	require.Equal(t, KeyStatusRetiring, Status(&keys[1], now))
+	require.Equal(t, KeyStatusRetired, Status(&keys[2], now))
+
+	require.Equal(t, "new", SelectKey(keys, "", now).KeyID)
+	require.Equal(t, "new", SelectKey(keys, "new", now).KeyID)
+	require.Equal(t, "previous", SelectKey(keys, "previous", now).KeyID)
+	require.Nil(t, SelectKey(keys, "old", now))
+	require.Nil(t, SelectKey(keys, "unknown", now))
+
+	// after the overlap period
+	require.Nil(t, SelectKey(keys, "previous", now.Add(2*time.Hour)))
+	require.Nil(t, SelectKey(keys[1:], "", now))
+}
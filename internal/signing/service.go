@@ -0,0 +1,152 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrKeyIDTaken  = errors.New("key ID is already used by another key of the project")
+	ErrKeyNotFound = errors.New("signing key not found or already retired")
+)
+
+// InvalidKeyError is returned when the uploaded key or certificate chain can't be used
+type InvalidKeyError struct {
+	Err error
+}
+
+func (e *InvalidKeyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidKeyError) Unwrap() error {
+	return e.Err
+}
+
+type RotateKeyParams struct {
+	KeyID               string
+	PrivateKeyPEM       string
+	CertificateChainPEM string
+	// Overlap is how long the previously active keys can still be requested by their key ID
+	Overlap time.Duration
+}
+
+type Service interface {
+	// Keys returns all keys of the project, the newest first
+	Keys(ctx context.Context, projectID uuid.UUID) ([]db.SigningKey, error)
+	// RotateKey makes the new key active and retires the previously active keys
+	// after the overlap period
+	RotateKey(ctx context.Context, projectID uuid.UUID, params RotateKeyParams) (*db.SigningKey, error)
+	// RetireKey stops using the key immediately
+	RetireKey(ctx context.Context, projectID uuid.UUID, keyID string) error
+	// KeyForSigning selects the key signing a manifest with SelectKey
+	KeyForSigning(ctx context.Context, projectID uuid.UUID, keyID string) (*db.SigningKey, error)
+}
+
+type service struct {
+	q      *db.Queries
+	pgPool *pgxpool.Pool
+}
+
+func NewService(q *db.Queries, pgPool *pgxpool.Pool) Service {
+	return &service{q, pgPool}
+}
+
+func (s *service) Keys(ctx context.Context, projectID uuid.UUID) ([]db.SigningKey, error) {
+	return s.q.GetSigningKeys(ctx, projectID)
+}
+
+func (s *service) RotateKey(
+	ctx context.Context,
+	projectID uuid.UUID,
+	params RotateKeyParams,
+) (*db.SigningKey, error) {
+	now := time.Now()
+	if err := validateKeyPair(params.PrivateKeyPEM, params.CertificateChainPEM, now); err != nil {
+		return nil, &InvalidKeyError{Err: err}
+	}
+
+	tx, err := s.pgPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			logger.FromContext(ctx).
+				Error("RotateKey: failed to rollback transaction", zap.Error(err))
+		}
+	}(tx, ctx)
+
+	qtx := s.q.WithTx(tx)
+	keys, err := qtx.GetSigningKeys(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetSigningKeys: %w", err)
+	}
+	for _, key := range keys {
+		if key.KeyID == params.KeyID {
+			return nil, ErrKeyIDTaken
+		}
+	}
+
+	key, err := qtx.CreateSigningKey(ctx, db.CreateSigningKeyParams{
+		ID:               uuid.Must(uuid.NewV7()),
+		ProjectID:        projectID,
+		KeyID:            params.KeyID,
+		Algorithm:        AlgorithmRSASHA256,
+		PrivateKey:       params.PrivateKeyPEM,
+		CertificateChain: params.CertificateChainPEM,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateSigningKey: %w", err)
+	}
+
+	err = qtx.ScheduleSigningKeysRetirement(
+		ctx,
+		pgtype.Timestamptz{Time: now.Add(params.Overlap), Valid: true},
+		projectID,
+		key.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduleSigningKeysRetirement: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &key, nil
+}
+
+func (s *service) RetireKey(ctx context.Context, projectID uuid.UUID, keyID string) error {
+	retired, err := s.q.RetireSigningKey(ctx, projectID, keyID)
+	if err != nil {
+		return fmt.Errorf("RetireSigningKey: %w", err)
+	}
+	if retired == 0 {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+func (s *service) KeyForSigning(
+	ctx context.Context,
+	projectID uuid.UUID,
+	keyID string,
+) (*db.SigningKey, error) {
+	keys, err := s.q.GetSigningKeys(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetSigningKeys: %w", err)
+	}
+	return SelectKey(keys, keyID, time.Now()), nil
+}
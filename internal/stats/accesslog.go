@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const s3AccessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// splitS3AccessLogLine splits the space separated fields, keeping [bracketed] and "quoted"
+// fields together without the brackets and quotes
+func splitS3AccessLogLine(line string) []string {
+	var fields []string
+	for line = strings.TrimLeft(line, " "); line != ""; line = strings.TrimLeft(line, " ") {
+		end := " "
+		switch line[0] {
+		case '[':
+			end = "]"
+			line = line[1:]
+		case '"':
+			end = `"`
+			line = line[1:]
+		}
+
+		field, rest, _ := strings.Cut(line, end)
+		fields = append(fields, field)
+		line = rest
+	}
+	return fields
+}
+
+// parseS3AccessLogLine returns the download of a successful GET object request,
+// ok is false for other requests
+func parseS3AccessLogLine(line string) (download Download, ok bool) {
+	// bucket owner, bucket, time, remote IP, requester, request ID, operation, key,
+	// request URI, HTTP status, error code, bytes sent, ...
+	fields := splitS3AccessLogLine(line)
+	if len(fields) < 12 || fields[6] != "REST.GET.OBJECT" {
+		return Download{}, false
+	}
+
+	status, err := strconv.Atoi(fields[9])
+	if err != nil || (status != http.StatusOK && status != http.StatusPartialContent) {
+		return Download{}, false
+	}
+
+	objectKey, err := url.PathUnescape(fields[7])
+	if err != nil {
+		return Download{}, false
+	}
+
+	downloadTime, err := time.Parse(s3AccessLogTimeLayout, fields[2])
+	if err != nil {
+		return Download{}, false
+	}
+
+	// "-" when nothing was sent
+	bytesSent, _ := strconv.ParseInt(fields[11], 10, 64)
+	return Download{ObjectKey: objectKey, Bytes: bytesSent, Time: downloadTime}, true
+}
+
+// ImportS3AccessLog records downloads of the S3 server access log, the recorder has to be flushed
+// afterwards. It returns the number of recorded downloads.
+func ImportS3AccessLog(recorder *Recorder, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	imported := 0
+	for scanner.Scan() {
+		download, ok := parseS3AccessLogLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		if _, _, _, ok := parseObjectKey(download.ObjectKey); !ok {
+			continue
+		}
+		recorder.Record(download)
+		imported++
+	}
+	return imported, scanner.Err()
+}
@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportS3AccessLog(t *testing.T) {
+	objectKey := uuid.NewString() + "/" + uuid.NewString() + "/assets/my logo.png"
+	encodedKey := strings.ReplaceAll(objectKey, " ", "%20")
+	line := func(operation, key, status, bytesSent string) string {
+		return `79a5 bucket [06/Feb/2024:00:00:38 +0000] 192.0.2.3 - 3E57427F3EXAMPLE ` +
+			operation + ` ` + key + ` "GET /bucket/` + key + ` HTTP/1.1" ` + status +
+			` - ` + bytesSent + ` 4096 12 10 "-" "okhttp/4.9.2" - s9lzHYrFp Sig TLS_AES_128 ` +
+			`AuthHeader bucket.s3.amazonaws.com TLSv1.3 - -`
+	}
+
+	log := strings.Join([]string{
+		line("REST.GET.OBJECT", encodedKey, "200", "4096"),
+		line("REST.GET.OBJECT", encodedKey, "206", "1024"),
+		line("REST.GET.OBJECT", encodedKey, "304", "-"),
+		line("REST.HEAD.OBJECT", encodedKey, "200", "-"),
+		line("REST.PUT.OBJECT", encodedKey, "200", "-"),
+		line("REST.GET.OBJECT", "unrelated.txt", "200", "10"),
+		"malformed",
+	}, "\n")
+
+	recorder := NewRecorder(nil)
+	imported, err := ImportS3AccessLog(recorder, strings.NewReader(log))
+	require.NoError(t, err)
+	require.Equal(t, 2, imported)
+
+	asset := recorder.pending[objectKey]
+	require.NotNil(t, asset)
+	require.Equal(t, "assets/my logo.png", asset.path)
+	require.Equal(t, int64(2), asset.downloads)
+	require.Equal(t, int64(5120), asset.bytesServed)
+	require.Equal(t, time.Date(2024, 2, 6, 0, 0, 38, 0, time.UTC), asset.lastDownloadedAt.UTC())
+}
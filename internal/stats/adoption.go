@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UpdateCheck is an update check answered by the server
+type UpdateCheck struct {
+	ProjectID uuid.UUID
+	Platform  string
+	// CurrentUpdateID is the update the client runs, nil when the client doesn't send it
+	CurrentUpdateID *uuid.UUID
+	// ServedUpdateID is the update served to the client, nil when there was nothing to install
+	ServedUpdateID *uuid.UUID
+	// RollBackToEmbedded is true when the client was told to roll back to the embedded update
+	RollBackToEmbedded bool
+	Time               time.Time
+}
+
+type adoptionKey struct {
+	projectID uuid.UUID
+	updateID  uuid.UUID
+	day       time.Time
+	platform  string
+}
+
+type adoptionCounts struct {
+	downloads  int64
+	departures int64
+	rollbacks  int64
+}
+
+// AdoptionRecorder aggregates update checks per update, day and platform in memory and
+// periodically adds them to the database, every check is recorded, unlike the sampled
+// analytics. A nil AdoptionRecorder records nothing.
+type AdoptionRecorder struct {
+	q       *db.Queries
+	mu      sync.Mutex
+	pending map[adoptionKey]*adoptionCounts
+}
+
+func NewAdoptionRecorder(q *db.Queries) *AdoptionRecorder {
+	return &AdoptionRecorder{q: q, pending: make(map[adoptionKey]*adoptionCounts)}
+}
+
+func (r *AdoptionRecorder) counts(
+	projectID, updateID uuid.UUID,
+	day time.Time,
+	platform string,
+) *adoptionCounts {
+	key := adoptionKey{projectID: projectID, updateID: updateID, day: day, platform: platform}
+	counts, ok := r.pending[key]
+	if !ok {
+		counts = new(adoptionCounts)
+		r.pending[key] = counts
+	}
+	return counts
+}
+
+// Record adds the check, serving the update the client already runs isn't a download
+func (r *AdoptionRecorder) Record(check UpdateCheck) {
+	if r == nil {
+		return
+	}
+	if check.Time.IsZero() {
+		check.Time = time.Now()
+	}
+	day := check.Time.UTC().Truncate(24 * time.Hour)
+	current, served := check.CurrentUpdateID, check.ServedUpdateID
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if served != nil && (current == nil || *current != *served) {
+		r.counts(check.ProjectID, *served, day, check.Platform).downloads++
+		if current != nil {
+			r.counts(check.ProjectID, *current, day, check.Platform).departures++
+		}
+	}
+	if check.RollBackToEmbedded && current != nil {
+		r.counts(check.ProjectID, *current, day, check.Platform).rollbacks++
+	}
+}
+
+// Flush adds the recorded checks to the database, checks failed to be written are dropped
+func (r *AdoptionRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[adoptionKey]*adoptionCounts)
+	r.mu.Unlock()
+
+	var errs []error
+	for key, counts := range pending {
+		err := r.q.IncrementUpdateAdoptionStats(ctx, db.IncrementUpdateAdoptionStatsParams{
+			Day:        pgtype.Date{Time: key.day, Valid: true},
+			Platform:   key.platform,
+			Downloads:  counts.downloads,
+			Departures: counts.departures,
+			Rollbacks:  counts.rollbacks,
+			UpdateID:   key.updateID,
+			ProjectID:  key.projectID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("IncrementUpdateAdoptionStats: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes the recorded checks every FlushInterval until ctx is canceled
+func (r *AdoptionRecorder) Run(ctx context.Context) {
+	runFlushes(ctx, r.Flush, "failed to flush update adoption stats")
+}
+
+// UpdateAdoption of an update, rolled up from the update checks and the CodePush reports
+type UpdateAdoption struct {
+	// Days with the most recent first
+	Days []db.UpdateAdoptionStat
+	// CodePush is nil when no CodePush client reported the update
+	CodePush *db.CodepushReleaseStat
+}
+
+func (a *UpdateAdoption) Downloads() int64 {
+	var downloads int64
+	for _, day := range a.Days {
+		downloads += day.Downloads
+	}
+	return downloads
+}
+
+// Rollbacks counts the Expo clients told to roll back and the CodePush installs rolled back
+func (a *UpdateAdoption) Rollbacks() int64 {
+	var rollbacks int64
+	for _, day := range a.Days {
+		rollbacks += day.Rollbacks
+	}
+	if a.CodePush != nil {
+		rollbacks += a.CodePush.DeploymentsFailed
+	}
+	return rollbacks
+}
+
+// ActiveInstallEstimate is the number of downloads not followed by a departure or rollback,
+// CodePush clients don't send the update they run, so only their rollbacks are subtracted
+func (a *UpdateAdoption) ActiveInstallEstimate() int64 {
+	estimate := a.Downloads() - a.Rollbacks()
+	for _, day := range a.Days {
+		estimate -= day.Departures
+	}
+	return max(estimate, 0)
+}
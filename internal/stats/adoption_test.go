@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordUpdateCheck(t *testing.T) {
+	recorder := NewAdoptionRecorder(nil)
+	projectID := uuid.New()
+	previous, latest := uuid.New(), uuid.New()
+	checkedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	check := func(current, served *uuid.UUID, rollBack bool) {
+		recorder.Record(UpdateCheck{
+			ProjectID:          projectID,
+			Platform:           "ios",
+			CurrentUpdateID:    current,
+			ServedUpdateID:     served,
+			RollBackToEmbedded: rollBack,
+			Time:               checkedAt,
+		})
+	}
+
+	// first install, a switch from the previous update, and a client that has the update already
+	check(nil, &latest, false)
+	check(&previous, &latest, false)
+	check(&latest, &latest, false)
+	// a client of the latest update rolled back
+	check(&latest, nil, true)
+
+	require.Len(t, recorder.pending, 2)
+	latestCounts := recorder.pending[adoptionKey{projectID, latest, day, "ios"}]
+	require.Equal(t, adoptionCounts{downloads: 2, rollbacks: 1}, *latestCounts)
+	previousCounts := recorder.pending[adoptionKey{projectID, previous, day, "ios"}]
+	require.Equal(t, adoptionCounts{departures: 1}, *previousCounts)
+
+	// nil recorder is a no-op
+	var nilRecorder *AdoptionRecorder
+	nilRecorder.Record(UpdateCheck{ServedUpdateID: &latest})
+}
+
+func TestUpdateAdoption(t *testing.T) {
+	adoption := UpdateAdoption{
+		Days: []db.UpdateAdoptionStat{
+			{Downloads: 10, Departures: 2, Rollbacks: 1},
+			{Downloads: 5, Departures: 1},
+		},
+	}
+	require.Equal(t, int64(15), adoption.Downloads())
+	require.Equal(t, int64(1), adoption.Rollbacks())
+	require.Equal(t, int64(11), adoption.ActiveInstallEstimate())
+
+	adoption.CodePush = &db.CodepushReleaseStat{DeploymentsFailed: 20}
+	require.Equal(t, int64(21), adoption.Rollbacks())
+	require.Equal(t, int64(0), adoption.ActiveInstallEstimate())
+}
@@ -0,0 +1,170 @@
+// Package stats aggregates asset downloads and update checks, so the assets dominating the
+// bandwidth and the adoption of updates can be found
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// FlushInterval is how often the recorded downloads are written to the database
+const FlushInterval = 10 * time.Second
+
+// Download of an asset, possibly partial
+type Download struct {
+	ObjectKey string
+	Bytes     int64
+	Time      time.Time
+}
+
+type assetDownloads struct {
+	projectID        uuid.UUID
+	updateID         uuid.UUID
+	path             string
+	downloads        int64
+	bytesServed      int64
+	lastDownloadedAt time.Time
+}
+
+// Recorder aggregates downloads in memory and periodically adds them to the database,
+// a nil Recorder records nothing
+type Recorder struct {
+	q       *db.Queries
+	mu      sync.Mutex
+	pending map[string]*assetDownloads
+}
+
+func NewRecorder(q *db.Queries) *Recorder {
+	return &Recorder{q: q, pending: make(map[string]*assetDownloads)}
+}
+
+// parseObjectKey returns the update and the path within the update of asset and archive keys,
+// ok is false for other objects
+func parseObjectKey(objectKey string) (projectID, updateID uuid.UUID, path string, ok bool) {
+	segments := strings.SplitN(objectKey, "/", 4)
+	if len(segments) < 3 {
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	projectID, err := uuid.Parse(segments[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	// archives are stored under <project>/archives/<update>/<platform>.zip
+	updateSegment, path := segments[1], strings.Join(segments[2:], "/")
+	if segments[1] == "archives" && len(segments) == 4 {
+		updateSegment, path = segments[2], segments[3]
+	}
+
+	updateID, err = uuid.Parse(updateSegment)
+	if err != nil || path == "" {
+		return uuid.Nil, uuid.Nil, "", false
+	}
+	return projectID, updateID, path, true
+}
+
+// Record adds the download, downloads of objects other than assets and archives are ignored
+func (r *Recorder) Record(download Download) {
+	if r == nil {
+		return
+	}
+	projectID, updateID, path, ok := parseObjectKey(download.ObjectKey)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	asset, ok := r.pending[download.ObjectKey]
+	if !ok {
+		asset = &assetDownloads{projectID: projectID, updateID: updateID, path: path}
+		r.pending[download.ObjectKey] = asset
+	}
+	asset.downloads++
+	asset.bytesServed += download.Bytes
+	if download.Time.After(asset.lastDownloadedAt) {
+		asset.lastDownloadedAt = download.Time
+	}
+}
+
+// RecordResponse records the object served by the handler, it has to run after the handler
+func (r *Recorder) RecordResponse(ctx *gin.Context, objectKey string) {
+	if r == nil || ctx.Request.Method != http.MethodGet {
+		return
+	}
+	status := ctx.Writer.Status()
+	if status != http.StatusOK && status != http.StatusPartialContent {
+		return
+	}
+	r.Record(Download{
+		ObjectKey: objectKey,
+		Bytes:     int64(max(ctx.Writer.Size(), 0)),
+		Time:      time.Now(),
+	})
+}
+
+// Flush adds the recorded downloads to the database, downloads failed to be written are dropped
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*assetDownloads)
+	r.mu.Unlock()
+
+	var errs []error
+	for objectKey, asset := range pending {
+		err := r.q.IncrementAssetDownloads(ctx, db.IncrementAssetDownloadsParams{
+			ObjectKey:        objectKey,
+			ProjectID:        asset.projectID,
+			UpdateID:         asset.updateID,
+			Path:             asset.path,
+			Downloads:        asset.downloads,
+			BytesServed:      asset.bytesServed,
+			LastDownloadedAt: pgtype.Timestamptz{Time: asset.lastDownloadedAt, Valid: true},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("IncrementAssetDownloads: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes the recorded downloads every FlushInterval until ctx is canceled
+func (r *Recorder) Run(ctx context.Context) {
+	runFlushes(ctx, r.Flush, "failed to flush download stats")
+}
+
+// runFlushes calls flush every FlushInterval until ctx is canceled, and once more after that
+func runFlushes(ctx context.Context, flush func(ctx context.Context) error, errMsg string) {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write the stats recorded since the last flush before stopping
+			if err := flush(context.WithoutCancel(ctx)); err != nil {
+				log.Error(errMsg, zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := flush(ctx); err != nil {
+				log.Error(errMsg, zap.Error(err))
+			}
+		}
+	}
+}
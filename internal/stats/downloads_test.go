@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseObjectKey(t *testing.T) {
+	projectID := uuid.New()
+	updateID := uuid.New()
+
+	p, u, path, ok := parseObjectKey(projectID.String() + "/" + updateID.String() + "/assets/logo.png")
+	require.True(t, ok)
+	require.Equal(t, projectID, p)
+	require.Equal(t, updateID, u)
+	require.Equal(t, "assets/logo.png", path)
+
+	p, u, path, ok = parseObjectKey(
+		projectID.String() + "/archives/" + updateID.String() + "/ios.zip",
+	)
+	require.True(t, ok)
+	require.Equal(t, projectID, p)
+	require.Equal(t, updateID, u)
+	require.Equal(t, "ios.zip", path)
+
+	_, _, _, ok = parseObjectKey(projectID.String() + "/chunks/" + updateID.String() + "/a/0")
+	require.False(t, ok)
+	_, _, _, ok = parseObjectKey("healthcheck")
+	require.False(t, ok)
+}
+
+func TestRecord(t *testing.T) {
+	recorder := NewRecorder(nil)
+	objectKey := uuid.NewString() + "/" + uuid.NewString() + "/bundle.js"
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	recorder.Record(Download{ObjectKey: objectKey, Bytes: 100, Time: first.Add(time.Minute)})
+	recorder.Record(Download{ObjectKey: objectKey, Bytes: 50, Time: first})
+	recorder.Record(Download{ObjectKey: "not-an-asset", Bytes: 10, Time: first})
+
+	require.Len(t, recorder.pending, 1)
+	asset := recorder.pending[objectKey]
+	require.Equal(t, int64(2), asset.downloads)
+	require.Equal(t, int64(150), asset.bytesServed)
+	require.Equal(t, first.Add(time.Minute), asset.lastDownloadedAt)
+
+	// nil recorder is a no-op
+	var nilRecorder *Recorder
+	nilRecorder.Record(Download{ObjectKey: objectKey})
+}
+
+func TestRecordResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := NewRecorder(nil)
+	objectKey := uuid.NewString() + "/" + uuid.NewString() + "/bundle.js"
+
+	r := gin.New()
+	r.GET("/ok", func(ctx *gin.Context) {
+		defer recorder.RecordResponse(ctx, objectKey)
+		ctx.String(http.StatusOK, "bundle")
+	})
+	r.GET("/missing", func(ctx *gin.Context) {
+		defer recorder.RecordResponse(ctx, objectKey)
+		ctx.Status(http.StatusNotFound)
+	})
+
+	for _, path := range []string{"/ok", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, int64(1), recorder.pending[objectKey].downloads)
+	require.Equal(t, int64(len("bundle")), recorder.pending[objectKey].bytesServed)
+}
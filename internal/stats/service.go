@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Service interface {
+	// APIUsage returns the hourly usage of the API operations of the project in [from, to), with
+	// the earliest first, of all operations when operation is nil
+	APIUsage(
+		ctx context.Context,
+		projectID uuid.UUID,
+		from, to time.Time,
+		operation *string,
+	) ([]APIUsage, error)
+	// AssetDownloads returns the assets of the project with the most bytes served first
+	AssetDownloads(
+		ctx context.Context,
+		projectID uuid.UUID,
+		updateID *uuid.UUID,
+		limit int32,
+	) ([]db.AssetDownloadStat, error)
+	// CodePushReleases returns the CodePush releases of the project with the most recently
+	// reported first
+	CodePushReleases(
+		ctx context.Context,
+		projectID uuid.UUID,
+		limit int32,
+	) ([]db.CodepushReleaseStat, error)
+	// CorruptedAssets returns the assets of the project that failed integrity verification
+	CorruptedAssets(ctx context.Context, projectID uuid.UUID) ([]db.GetCorruptedAssetsRow, error)
+	// CorruptedAssetCount counts the assets of all projects that failed integrity verification
+	CorruptedAssetCount(ctx context.Context) (int64, error)
+	// UpdateAdoption returns the downloads, departures and rollbacks of the update
+	UpdateAdoption(ctx context.Context, updateID uuid.UUID) (*UpdateAdoption, error)
+	// UpdateChecks returns the recorded update checks of the project in [from, to), with the
+	// most recent first
+	UpdateChecks(
+		ctx context.Context,
+		projectID uuid.UUID,
+		from, to time.Time,
+		filter UpdateCheckFilter,
+		limit int32,
+	) ([]db.UpdateCheckEvent, error)
+}
+
+// UpdateCheckFilter narrows down the recorded update checks, nil fields match all checks
+type UpdateCheckFilter struct {
+	ClientID *string
+	Channel  *string
+}
+
+type service struct {
+	q *db.Queries
+}
+
+func NewService(q *db.Queries) Service {
+	return &service{q}
+}
+
+func (s *service) APIUsage(
+	ctx context.Context,
+	projectID uuid.UUID,
+	from, to time.Time,
+	operation *string,
+) ([]APIUsage, error) {
+	params := db.GetAPIUsageStatsParams{
+		ProjectID:  projectID,
+		PeriodFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		PeriodTo:   pgtype.Timestamptz{Time: to, Valid: true},
+	}
+	if operation != nil {
+		params.Operation = pgtype.Text{String: *operation, Valid: true}
+	}
+	rows, err := s.q.GetAPIUsageStats(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("GetAPIUsageStats: %w", err)
+	}
+	return aggregateAPIUsage(rows), nil
+}
+
+func (s *service) AssetDownloads(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID *uuid.UUID,
+	limit int32,
+) ([]db.AssetDownloadStat, error) {
+	var updateIDParam pgtype.UUID
+	if updateID != nil {
+		updateIDParam = pgtype.UUID{Bytes: *updateID, Valid: true}
+	}
+	return s.q.GetAssetDownloadStats(ctx, projectID, updateIDParam, limit)
+}
+
+func (s *service) CodePushReleases(
+	ctx context.Context,
+	projectID uuid.UUID,
+	limit int32,
+) ([]db.CodepushReleaseStat, error) {
+	return s.q.GetCodePushReleaseStats(ctx, projectID, limit)
+}
+
+func (s *service) CorruptedAssets(
+	ctx context.Context,
+	projectID uuid.UUID,
+) ([]db.GetCorruptedAssetsRow, error) {
+	return s.q.GetCorruptedAssets(ctx, projectID)
+}
+
+func (s *service) CorruptedAssetCount(ctx context.Context) (int64, error) {
+	return s.q.CountCorruptedAssets(ctx)
+}
+
+func (s *service) UpdateAdoption(ctx context.Context, updateID uuid.UUID) (*UpdateAdoption, error) {
+	days, err := s.q.GetUpdateAdoptionStats(ctx, updateID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpdateAdoptionStats: %w", err)
+	}
+	adoption := &UpdateAdoption{Days: days}
+
+	codePush, err := s.q.GetCodePushReleaseStatsByUpdateID(ctx, updateID)
+	if err == nil {
+		adoption.CodePush = &codePush
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("GetCodePushReleaseStatsByUpdateID: %w", err)
+	}
+	return adoption, nil
+}
+
+func (s *service) UpdateChecks(
+	ctx context.Context,
+	projectID uuid.UUID,
+	from, to time.Time,
+	filter UpdateCheckFilter,
+	limit int32,
+) ([]db.UpdateCheckEvent, error) {
+	params := db.GetUpdateCheckEventsParams{
+		ProjectID:   projectID,
+		CheckedFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		CheckedTo:   pgtype.Timestamptz{Time: to, Valid: true},
+		RowLimit:    limit,
+	}
+	if filter.ClientID != nil {
+		params.ClientID = pgtype.Text{String: *filter.ClientID, Valid: true}
+	}
+	if filter.Channel != nil {
+		params.Channel = pgtype.Text{String: *filter.Channel, Valid: true}
+	}
+	return s.q.GetUpdateCheckEvents(ctx, params)
+}
@@ -0,0 +1,185 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// usageLatencyBucketsMs are the upper bounds of the latency buckets of API requests, slower
+// requests are counted in the last one
+var usageLatencyBucketsMs = []int32{
+	5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000,
+}
+
+// APIRequest is a request of an API operation of a project
+type APIRequest struct {
+	ProjectID uuid.UUID
+	// Operation is the ID of the operation in the OpenAPI spec
+	Operation string
+	Status    int
+	Latency   time.Duration
+	Time      time.Time
+}
+
+type usageKey struct {
+	projectID   uuid.UUID
+	operation   string
+	periodStart time.Time
+	latencyLeMs int32
+}
+
+type usageCounts struct {
+	requests     int64
+	clientErrors int64
+	serverErrors int64
+}
+
+// UsageRecorder aggregates the API requests per project, operation, hour and latency bucket in
+// memory and periodically adds them to the database. A nil UsageRecorder records nothing.
+type UsageRecorder struct {
+	q       *db.Queries
+	mu      sync.Mutex
+	pending map[usageKey]*usageCounts
+}
+
+func NewUsageRecorder(q *db.Queries) *UsageRecorder {
+	return &UsageRecorder{q: q, pending: make(map[usageKey]*usageCounts)}
+}
+
+// latencyBucket returns the upper bound of the bucket of the latency in milliseconds
+func latencyBucket(latency time.Duration) int32 {
+	ms := latency.Milliseconds()
+	for _, bound := range usageLatencyBucketsMs {
+		if ms <= int64(bound) {
+			return bound
+		}
+	}
+	return usageLatencyBucketsMs[len(usageLatencyBucketsMs)-1]
+}
+
+// Record adds the request, requests answered with a 4xx or 5xx status count as errors
+func (r *UsageRecorder) Record(request APIRequest) {
+	if r == nil {
+		return
+	}
+	if request.Time.IsZero() {
+		request.Time = time.Now()
+	}
+	key := usageKey{
+		projectID:   request.ProjectID,
+		operation:   request.Operation,
+		periodStart: request.Time.UTC().Truncate(time.Hour),
+		latencyLeMs: latencyBucket(request.Latency),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts, ok := r.pending[key]
+	if !ok {
+		counts = new(usageCounts)
+		r.pending[key] = counts
+	}
+	counts.requests++
+	switch {
+	case request.Status >= 500:
+		counts.serverErrors++
+	case request.Status >= 400:
+		counts.clientErrors++
+	}
+}
+
+// Flush adds the recorded requests to the database, requests failed to be written are dropped
+func (r *UsageRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[usageKey]*usageCounts)
+	r.mu.Unlock()
+
+	var errs []error
+	for key, counts := range pending {
+		err := r.q.IncrementAPIUsageStats(ctx, db.IncrementAPIUsageStatsParams{
+			Operation:    key.operation,
+			PeriodStart:  pgtype.Timestamptz{Time: key.periodStart, Valid: true},
+			LatencyLeMs:  key.latencyLeMs,
+			Requests:     counts.requests,
+			ClientErrors: counts.clientErrors,
+			ServerErrors: counts.serverErrors,
+			ProjectID:    key.projectID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("IncrementAPIUsageStats: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes the recorded requests every FlushInterval until ctx is canceled
+func (r *UsageRecorder) Run(ctx context.Context) {
+	runFlushes(ctx, r.Flush, "failed to flush API usage stats")
+}
+
+// APIUsage of an operation of a project in an hour
+type APIUsage struct {
+	Operation    string
+	PeriodStart  time.Time
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
+	// P95LatencyMs is the upper bound of the latency bucket of the 95th percentile request
+	P95LatencyMs int32
+}
+
+// ErrorRate is the share of the requests answered with a 4xx or 5xx status
+func (u *APIUsage) ErrorRate() float64 {
+	if u.Requests == 0 {
+		return 0
+	}
+	return float64(u.ClientErrors+u.ServerErrors) / float64(u.Requests)
+}
+
+// aggregateAPIUsage sums up the latency buckets of the operations per hour, the rows have to be
+// ordered by hour, operation and latency bucket
+func aggregateAPIUsage(rows []db.ApiUsageStat) []APIUsage {
+	usage := make([]APIUsage, 0)
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) &&
+			rows[end].Operation == rows[start].Operation &&
+			rows[end].PeriodStart.Time.Equal(rows[start].PeriodStart.Time) {
+			end++
+		}
+		buckets := rows[start:end]
+
+		item := APIUsage{
+			Operation:   rows[start].Operation,
+			PeriodStart: rows[start].PeriodStart.Time.UTC(),
+		}
+		for _, bucket := range buckets {
+			item.Requests += bucket.Requests
+			item.ClientErrors += bucket.ClientErrors
+			item.ServerErrors += bucket.ServerErrors
+		}
+		rank := int64(math.Ceil(0.95 * float64(item.Requests)))
+		var seen int64
+		for _, bucket := range buckets {
+			seen += bucket.Requests
+			if seen >= rank {
+				item.P95LatencyMs = bucket.LatencyLeMs
+				break
+			}
+		}
+
+		usage = append(usage, item)
+		start = end
+	}
+	return usage
+}
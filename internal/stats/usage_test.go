@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAPIRequest(t *testing.T) {
+	recorder := NewUsageRecorder(nil)
+	projectID := uuid.New()
+	requestedAt := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	hour := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	request := func(status int, latency time.Duration) {
+		recorder.Record(APIRequest{
+			ProjectID: projectID,
+			Operation: "getExpoUpdate",
+			Status:    status,
+			Latency:   latency,
+			Time:      requestedAt,
+		})
+	}
+
+	request(200, 3*time.Millisecond)
+	request(404, 5*time.Millisecond)
+	request(500, 80*time.Millisecond)
+	// slower than the last bucket
+	request(200, 2*time.Minute)
+
+	require.Len(t, recorder.pending, 3)
+	fast := recorder.pending[usageKey{projectID, "getExpoUpdate", hour, 5}]
+	require.Equal(t, usageCounts{requests: 2, clientErrors: 1}, *fast)
+	slow := recorder.pending[usageKey{projectID, "getExpoUpdate", hour, 100}]
+	require.Equal(t, usageCounts{requests: 1, serverErrors: 1}, *slow)
+	slowest := recorder.pending[usageKey{projectID, "getExpoUpdate", hour, 60000}]
+	require.Equal(t, usageCounts{requests: 1}, *slowest)
+
+	// nil recorder is a no-op
+	var nilRecorder *UsageRecorder
+	nilRecorder.Record(APIRequest{ProjectID: projectID})
+}
+
+func TestAggregateAPIUsage(t *testing.T) {
+	hour := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	row := func(
+		hour time.Time,
+		operation string,
+		latencyLeMs int32,
+		requests, errs int64,
+	) db.ApiUsageStat {
+		return db.ApiUsageStat{
+			Operation:    operation,
+			PeriodStart:  pgtype.Timestamptz{Time: hour, Valid: true},
+			LatencyLeMs:  latencyLeMs,
+			Requests:     requests,
+			ServerErrors: errs,
+		}
+	}
+
+	usage := aggregateAPIUsage([]db.ApiUsageStat{
+		row(hour, "getExpoUpdate", 10, 90, 0),
+		row(hour, "getExpoUpdate", 100, 5, 1),
+		row(hour, "getExpoUpdate", 1000, 5, 3),
+		row(hour, "getUpdates", 25, 4, 0),
+		row(hour.Add(time.Hour), "getExpoUpdate", 50, 1, 0),
+	})
+
+	require.Len(t, usage, 3)
+	require.Equal(t, APIUsage{
+		Operation:    "getExpoUpdate",
+		PeriodStart:  hour,
+		Requests:     100,
+		ServerErrors: 4,
+		P95LatencyMs: 100,
+	}, usage[0])
+	require.InDelta(t, 0.04, usage[0].ErrorRate(), 0.0001)
+	require.Equal(t, "getUpdates", usage[1].Operation)
+	require.EqualValues(t, 25, usage[1].P95LatencyMs)
+	require.Equal(t, hour.Add(time.Hour), usage[2].PeriodStart)
+	require.EqualValues(t, 50, usage[2].P95LatencyMs)
+
+	require.Empty(t, aggregateAPIUsage(nil))
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CloudFront uses a modified base64 alphabet, so the values are safe in cookies and URLs
+var cloudFrontEncoding = strings.NewReplacer("+", "-", "=", "_", "/", "~")
+
+// CDNSigner issues CloudFront signed cookies with a custom policy, so a single signature
+// covers all assets of an update instead of signing a URL per asset per request
+type CDNSigner struct {
+	baseURL    *url.URL
+	keyPairID  string
+	privateKey *rsa.PrivateKey
+}
+
+type cdnPolicy struct {
+	Statement []cdnPolicyStatement `json:"Statement"`
+}
+
+type cdnPolicyStatement struct {
+	Resource  string             `json:"Resource"`
+	Condition cdnPolicyCondition `json:"Condition"`
+}
+
+type cdnPolicyCondition struct {
+	DateLessThan struct {
+		EpochTime int64 `json:"AWS:EpochTime"`
+	} `json:"DateLessThan"`
+}
+
+func newCDNSigner(baseURL, keyPairID, privateKeyPath string) (*CDNSigner, error) {
+	parsedURL, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CDN base URL: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CDN private key: %w", err)
+	}
+
+	privateKey, err := parseRSAPrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CDN private key: %w", err)
+	}
+
+	return &CDNSigner{
+		baseURL:    parsedURL,
+		keyPairID:  keyPairID,
+		privateKey: privateKey,
+	}, nil
+}
+
+func parseRSAPrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+// AssetURL returns the unsigned CDN URL of the object
+func (s *CDNSigner) AssetURL(objectKey string) string {
+	return s.baseURL.JoinPath(objectKey).String()
+}
+
+// UpdateCookies returns signed cookies granting access to all assets of the update until expiry
+func (s *CDNSigner) UpdateCookies(
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	expiry time.Duration,
+) ([]*http.Cookie, error) {
+	resource := s.AssetURL(AssetObjectKey(projectID, updateID, "*"))
+
+	var statement cdnPolicyStatement
+	statement.Resource = resource
+	statement.Condition.DateLessThan.EpochTime = time.Now().Add(expiry).Unix()
+
+	policy, err := json.Marshal(cdnPolicy{Statement: []cdnPolicyStatement{statement}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy: %w", err)
+	}
+
+	hash := sha1.Sum(policy)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA1, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign policy: %w", err)
+	}
+
+	return []*http.Cookie{
+		{Name: "CloudFront-Policy", Value: cloudFrontBase64(policy)},
+		{Name: "CloudFront-Signature", Value: cloudFrontBase64(signature)},
+		{Name: "CloudFront-Key-Pair-Id", Value: s.keyPairID},
+	}, nil
+}
+
+func cloudFrontBase64(data []byte) string {
+	return cloudFrontEncoding.Replace(base64.StdEncoding.EncodeToString(data))
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCDNSignerUpdateCookies(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "cdn.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+
+	signer, err := newCDNSigner("https://cdn.example.com/", "K2JCJMDEHXQW5F", keyPath)
+	require.NoError(t, err)
+
+	projectID, updateID := uuid.New(), uuid.New()
+	require.Equal(
+		t,
+		"https://cdn.example.com/"+projectID.String()+"/"+updateID.String()+"/assets/a.png",
+		signer.AssetURL(AssetObjectKey(projectID, updateID, "assets/a.png")),
+	)
+
+	cookies, err := signer.UpdateCookies(projectID, updateID, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, cookies, 3)
+
+	values := make(map[string]string)
+	for _, cookie := range cookies {
+		values[cookie.Name] = cookie.Value
+	}
+	require.Equal(t, "K2JCJMDEHXQW5F", values["CloudFront-Key-Pair-Id"])
+
+	decode := func(value string) []byte {
+		value = strings.NewReplacer("-", "+", "_", "=", "~", "/").Replace(value)
+		data, err := base64.StdEncoding.DecodeString(value)
+		require.NoError(t, err)
+		return data
+	}
+
+	policy := decode(values["CloudFront-Policy"])
+	require.Contains(t, string(policy), `"Resource":"https://cdn.example.com/`+
+		projectID.String()+"/"+updateID.String()+`/*"`)
+
+	hash := sha1.Sum(policy)
+	signature := decode(values["CloudFront-Signature"])
+	require.NoError(t, rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA1, hash[:], signature))
+}
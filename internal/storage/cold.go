@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"github.com/google/uuid"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+// ColdBucket returns nil when assets of superseded updates aren't moved to cold storage
+func (s *Storage) ColdBucket() *blob.Bucket {
+	return s.cold
+}
+
+// UpdateObjectKeyPrefixes are the prefixes of all objects of the update, its files and archives
+func UpdateObjectKeyPrefixes(projectID uuid.UUID, updateID uuid.UUID) []string {
+	return []string{
+		AssetObjectKey(projectID, updateID, ""),
+		fmt.Sprintf("%s/archives/%s/", projectID, updateID),
+	}
+}
+
+// MoveObjects moves the objects under the prefix to the other bucket, keeping their keys.
+// Objects moved by an interrupted or concurrent run are skipped, so it can be retried.
+func MoveObjects(
+	ctx context.Context,
+	dst *blob.Bucket,
+	src *blob.Bucket,
+	prefix string,
+) (int, error) {
+	var moved int
+	iter := src.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		object, err := iter.Next(ctx)
+		if err == io.EOF {
+			return moved, nil
+		}
+		if err != nil {
+			return moved, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		if err := copyObject(ctx, dst, src, object.Key); err != nil {
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				continue
+			}
+			return moved, fmt.Errorf("failed to copy %s: %w", object.Key, err)
+		}
+		err = src.Delete(ctx, object.Key)
+		if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return moved, fmt.Errorf("failed to delete %s: %w", object.Key, err)
+		}
+		moved++
+	}
+}
+
+// copyObject copies the object between buckets, keeping its content headers
+func copyObject(ctx context.Context, dst *blob.Bucket, src *blob.Bucket, objectKey string) error {
+	reader, err := src.NewReader(ctx, objectKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open source object: %w", err)
+	}
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
+	defer util.CloseWithLogger(log, reader)
+
+	attrs, err := src.Attributes(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to read source object attributes: %w", err)
+	}
+
+	writer, err := dst.NewWriter(ctx, objectKey, &blob.WriterOptions{
+		ContentType:     attrs.ContentType,
+		ContentEncoding: attrs.ContentEncoding,
+		CacheControl:    attrs.CacheControl,
+		Metadata:        attrs.Metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("failed to copy object: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestMoveObjects(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	primary := memblob.OpenBucket(nil)
+	cold := memblob.OpenBucket(nil)
+
+	projectID := uuid.New()
+	updateID := uuid.New()
+	otherKey := AssetObjectKey(projectID, uuid.New(), "bundle.js")
+	keys := []string{
+		AssetObjectKey(projectID, updateID, "bundle.js"),
+		AssetObjectKey(projectID, updateID, "assets/icon.png"),
+		ArchiveObjectKey(projectID, updateID, "ios"),
+	}
+	for _, key := range append(keys, otherKey) {
+		err := primary.WriteAll(ctx, key, []byte(key), &blob.WriterOptions{
+			ContentType: "application/javascript",
+		})
+		require.NoError(t, err)
+	}
+
+	var moved int
+	for _, prefix := range UpdateObjectKeyPrefixes(projectID, updateID) {
+		n, err := MoveObjects(ctx, cold, primary, prefix)
+		require.NoError(t, err)
+		moved += n
+	}
+	require.Equal(t, len(keys), moved)
+
+	for _, key := range keys {
+		exists, err := primary.Exists(ctx, key)
+		require.NoError(t, err)
+		require.False(t, exists, key)
+
+		content, err := cold.ReadAll(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, key, string(content))
+		attrs, err := cold.Attributes(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, "application/javascript", attrs.ContentType)
+	}
+	exists, err := primary.Exists(ctx, otherKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// moving again finds nothing to move
+	n, err := MoveObjects(ctx, cold, primary, AssetObjectKey(projectID, updateID, ""))
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
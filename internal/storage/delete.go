@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+// ProjectObjectKeyPrefixes are the prefixes of all objects of the project, its updates,
+// uploaded chunks and quarantined objects
+func ProjectObjectKeyPrefixes(projectID uuid.UUID) []string {
+	prefix := projectID.String() + "/"
+	return []string{prefix, QuarantineObjectKey(prefix)}
+}
+
+// DeletedUpdateObjectKeyPrefixes are the prefixes of all objects of the update, its files,
+// archives, uploaded chunks and quarantined objects
+func DeletedUpdateObjectKeyPrefixes(projectID uuid.UUID, updateID uuid.UUID) []string {
+	prefixes := UpdateObjectKeyPrefixes(projectID, updateID)
+	for _, prefix := range UpdateObjectKeyPrefixes(projectID, updateID) {
+		prefixes = append(prefixes, QuarantineObjectKey(prefix))
+	}
+	return append(prefixes, fmt.Sprintf("%s/chunks/%s/", projectID, updateID))
+}
+
+// Buckets returns every bucket objects are stored in, the primary bucket, the cold storage
+// bucket and the replicas
+func (s *Storage) Buckets() []*blob.Bucket {
+	buckets := []*blob.Bucket{s.bucket}
+	if s.cold != nil {
+		buckets = append(buckets, s.cold)
+	}
+	for _, replica := range s.replicas {
+		buckets = append(buckets, replica.bucket)
+	}
+	return buckets
+}
+
+// DeleteObjects deletes the objects under the prefix. Objects deleted by an interrupted or
+// concurrent run are skipped, so it can be retried.
+func DeleteObjects(ctx context.Context, bucket *blob.Bucket, prefix string) (int, error) {
+	var deleted int
+	iter := bucket.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		object, err := iter.Next(ctx)
+		if err == io.EOF {
+			return deleted, nil
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		if err := DeleteObject(ctx, bucket, object.Key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+}
+
+// ListObjectKeys returns the keys of the objects under the prefix
+func ListObjectKeys(ctx context.Context, bucket *blob.Bucket, prefix string) ([]string, error) {
+	var keys []string
+	iter := bucket.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		object, err := iter.Next(ctx)
+		if err == io.EOF {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		keys = append(keys, object.Key)
+	}
+}
+
+// DeleteObject deletes the object, an object that doesn't exist is deleted already
+func DeleteObject(ctx context.Context, bucket *blob.Bucket, key string) error {
+	err := bucket.Delete(ctx, key)
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestDeleteObjects(t *testing.T) {
+	ctx := context.Background()
+	bucket := memblob.OpenBucket(nil)
+
+	projectID := uuid.New()
+	updateID := uuid.New()
+	otherKey := AssetObjectKey(uuid.New(), updateID, "bundle.js")
+	keys := []string{
+		AssetObjectKey(projectID, updateID, "bundle.js"),
+		ArchiveObjectKey(projectID, updateID, "ios"),
+		ChunkObjectKey(projectID, uuid.New(), "bundle.js", 0),
+		QuarantineObjectKey(AssetObjectKey(projectID, updateID, "assets/icon.png")),
+	}
+	for _, key := range append(keys, otherKey) {
+		require.NoError(t, bucket.WriteAll(ctx, key, []byte(key), nil))
+	}
+
+	var deleted int
+	for _, prefix := range ProjectObjectKeyPrefixes(projectID) {
+		n, err := DeleteObjects(ctx, bucket, prefix)
+		require.NoError(t, err)
+		deleted += n
+	}
+	require.Equal(t, len(keys), deleted)
+
+	for _, key := range keys {
+		exists, err := bucket.Exists(ctx, key)
+		require.NoError(t, err)
+		require.False(t, exists, key)
+	}
+	exists, err := bucket.Exists(ctx, otherKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// nothing is left to delete by a retried run
+	n, err := DeleteObjects(ctx, bucket, ProjectObjectKeyPrefixes(projectID)[0])
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestDeletedUpdateObjectKeyPrefixes(t *testing.T) {
+	projectID := uuid.New()
+	updateID := uuid.New()
+	prefixes := DeletedUpdateObjectKeyPrefixes(projectID, updateID)
+
+	keys := []string{
+		AssetObjectKey(projectID, updateID, "bundle.js"),
+		ArchiveObjectKey(projectID, updateID, "ios"),
+		DiffArchiveObjectKey(projectID, updateID, "ios", "hash"),
+		ChunkObjectKey(projectID, updateID, "bundle.js", 0),
+		QuarantineObjectKey(AssetObjectKey(projectID, updateID, "assets/icon.png")),
+	}
+	for _, key := range keys {
+		require.True(t, hasAnyPrefix(key, prefixes), key)
+	}
+
+	otherUpdateID := uuid.New()
+	otherKeys := []string{
+		AssetObjectKey(projectID, otherUpdateID, "bundle.js"),
+		ArchiveObjectKey(projectID, otherUpdateID, "ios"),
+		ChunkObjectKey(projectID, otherUpdateID, "bundle.js", 0),
+	}
+	for _, key := range otherKeys {
+		require.False(t, hasAnyPrefix(key, prefixes), key)
+	}
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
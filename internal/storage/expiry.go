@@ -0,0 +1,28 @@
+package storage
+
+import "time"
+
+// DownloadURLWindow is the time window download URLs are signed in. URLs signed in the same
+// window expire together, DownloadURLExpiry after the window ends, so URLs signed by local
+// storage, the edge cache and the CDN are the same for the whole window, and responses cached
+// until the window ends never contain URLs expiring sooner than DownloadURLExpiry.
+const DownloadURLWindow = 15 * time.Minute
+
+// DownloadURLExpiresAt returns the time download URLs signed at now expire
+func DownloadURLExpiresAt(now time.Time) time.Time {
+	return now.Truncate(DownloadURLWindow).Add(DownloadURLWindow + DownloadURLExpiry)
+}
+
+// SignedDownloadURLExpiry returns the expiry to sign a download URL with, signers add it to
+// the current time and truncate the result to seconds, the extra half second keeps the result
+// on the second the window's URLs expire
+func SignedDownloadURLExpiry() time.Duration {
+	return time.Until(DownloadURLExpiresAt(time.Now()).Add(time.Second / 2))
+}
+
+// DownloadURLCacheTTL returns the time until the current window ends, responses with signed
+// download URLs can be cached for that long
+func DownloadURLCacheTTL() time.Duration {
+	now := time.Now()
+	return now.Truncate(DownloadURLWindow).Add(DownloadURLWindow).Sub(now)
+}
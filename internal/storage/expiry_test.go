@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/blob/fileblob"
+)
+
+func TestDownloadURLExpiresAt(t *testing.T) {
+	windowStart := time.Date(2024, 1, 1, 12, 15, 0, 0, time.UTC)
+	expiresAt := windowStart.Add(DownloadURLWindow + DownloadURLExpiry)
+
+	require.Equal(t, expiresAt, DownloadURLExpiresAt(windowStart))
+	lastSecond := windowStart.Add(DownloadURLWindow - time.Second)
+	require.Equal(t, expiresAt, DownloadURLExpiresAt(lastSecond))
+	require.Equal(
+		t,
+		expiresAt.Add(DownloadURLWindow),
+		DownloadURLExpiresAt(windowStart.Add(DownloadURLWindow)),
+	)
+	require.LessOrEqual(t, DownloadURLCacheTTL(), DownloadURLWindow)
+}
+
+func TestSignedDownloadURLIsStable(t *testing.T) {
+	baseURL, err := url.Parse("http://localhost:8080/assets")
+	require.NoError(t, err)
+	signer := fileblob.NewURLSignerHMAC(baseURL, []byte("secret"))
+
+	sign := func() string {
+		opts := &driver.SignedURLOptions{Method: http.MethodGet, Expiry: SignedDownloadURLExpiry()}
+		signedURL, err := signer.URLFromKey(context.Background(), "bundle.js", opts)
+		require.NoError(t, err)
+		return signedURL.String()
+	}
+
+	// retry when the window ended between the signatures
+	first, second := sign(), sign()
+	if first != second {
+		first, second = sign(), sign()
+	}
+	require.Equal(t, first, second)
+
+	signedURL, err := url.Parse(first)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		strconv.FormatInt(DownloadURLExpiresAt(time.Now()).Unix(), 10),
+		signedURL.Query().Get("expiry"),
+	)
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/internal/geo"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+// ReplicaConfig is an entry of the STORAGE_REPLICAS_FILE JSON array
+type ReplicaConfig struct {
+	Region    string `json:"region"`
+	DriverURL string `json:"driverUrl"`
+	// Countries are ISO 3166-1 alpha-2 codes of the clients served from the replica
+	Countries []string `json:"countries"`
+}
+
+// Replica is a secondary bucket in another region, assets of the projects replicating
+// to the region are copied to it when the update is published
+type Replica struct {
+	Region    string
+	countries []string
+	bucket    *blob.Bucket
+}
+
+func (r *Replica) Bucket() *blob.Bucket {
+	return r.bucket
+}
+
+func (r *Replica) Serves(country string) bool {
+	return slices.Contains(r.countries, country)
+}
+
+// CopyFrom copies the object from the primary bucket, keeping its content headers
+func (r *Replica) CopyFrom(ctx context.Context, src *blob.Bucket, objectKey string) error {
+	return copyObject(ctx, r.bucket, src, objectKey)
+}
+
+func parseReplicasConfig(data []byte) ([]ReplicaConfig, error) {
+	var configs []ReplicaConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse replicas: %w", err)
+	}
+
+	regions := make(map[string]bool)
+	for i, config := range configs {
+		if config.Region == "" || config.DriverURL == "" {
+			return nil, fmt.Errorf("replica %d: region and driverUrl are required", i)
+		}
+		if regions[config.Region] {
+			return nil, fmt.Errorf("replica %d: duplicate region %s", i, config.Region)
+		}
+		regions[config.Region] = true
+
+		for j, country := range config.Countries {
+			configs[i].Countries[j] = strings.ToUpper(country)
+		}
+	}
+	return configs, nil
+}
+
+func openReplicas(ctx context.Context, path string) ([]*Replica, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replicas file: %w", err)
+	}
+
+	configs, err := parseReplicasConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
+	replicas := make([]*Replica, 0, len(configs))
+	for _, config := range configs {
+		bucket, err := blob.OpenBucket(ctx, config.DriverURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s replica bucket: %w", config.Region, err)
+		}
+		replicas = append(replicas, &Replica{
+			Region:    config.Region,
+			countries: config.Countries,
+			bucket:    bucket,
+		})
+		log.Info("initialized storage replica", zap.String("region", config.Region))
+	}
+	return replicas, nil
+}
+
+var ErrUnknownReplicaRegion = errors.New("unknown replica region")
+
+// ValidateReplicaRegions checks that every region has a configured replica
+func (s *Storage) ValidateReplicaRegions(regions []string) error {
+	for _, region := range regions {
+		if s.Replica(region) == nil {
+			return fmt.Errorf("%w: %s", ErrUnknownReplicaRegion, region)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) Replicas() []*Replica {
+	return s.replicas
+}
+
+// Replica returns nil when the region has no replica configured
+func (s *Storage) Replica(region string) *Replica {
+	for _, replica := range s.replicas {
+		if replica.Region == region {
+			return replica
+		}
+	}
+	return nil
+}
+
+// RequestRegion returns the region of the replica serving the country of the request client,
+// empty string means the primary bucket
+func (s *Storage) RequestRegion(ctx context.Context) string {
+	country := geo.CountryFromContext(ctx)
+	if country == "" {
+		return ""
+	}
+	for _, replica := range s.replicas {
+		if replica.Serves(country) {
+			return replica.Region
+		}
+	}
+	return ""
+}
+
+// DownloadReplica returns the replica the request client should download the assets from,
+// or nil when the project doesn't replicate to the client's region
+func (s *Storage) DownloadReplica(ctx context.Context, projectRegions []string) *Replica {
+	region := s.RequestRegion(ctx)
+	if region == "" || !slices.Contains(projectRegions, region) {
+		return nil
+	}
+	return s.Replica(region)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/geo"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestParseReplicasConfig(t *testing.T) {
+	configs, err := parseReplicasConfig([]byte(`[
+		{"region": "eu", "driverUrl": "s3://assets-eu?region=eu-central-1", "countries": ["de", "PL"]}
+	]`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"DE", "PL"}, configs[0].Countries)
+
+	_, err = parseReplicasConfig([]byte(`[{"region": "eu"}]`))
+	require.Error(t, err)
+
+	_, err = parseReplicasConfig([]byte(`[
+		{"region": "eu", "driverUrl": "mem://"},
+		{"region": "eu", "driverUrl": "mem://"}
+	]`))
+	require.Error(t, err)
+}
+
+func TestDownloadReplica(t *testing.T) {
+	eu := &Replica{Region: "eu", countries: []string{"DE", "PL"}}
+	st := &Storage{replicas: []*Replica{eu}}
+
+	ctx := context.WithValue(context.Background(), geo.ContextKey, "DE")
+	require.Equal(t, "eu", st.RequestRegion(ctx))
+	require.Same(t, eu, st.DownloadReplica(ctx, []string{"eu"}))
+	require.Nil(t, st.DownloadReplica(ctx, []string{}))
+	require.Nil(t, st.DownloadReplica(context.Background(), []string{"eu"}))
+
+	require.NoError(t, st.ValidateReplicaRegions([]string{"eu"}))
+	require.ErrorIs(t, st.ValidateReplicaRegions([]string{"us"}), ErrUnknownReplicaRegion)
+}
+
+func TestReplicaCopyFrom(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	src := memblob.OpenBucket(nil)
+	replica := &Replica{Region: "eu", bucket: memblob.OpenBucket(nil)}
+
+	require.NoError(t, src.WriteAll(ctx, "key", []byte("bundle"), &blob.WriterOptions{
+		ContentType:     "application/javascript",
+		ContentEncoding: "gzip",
+	}))
+	require.NoError(t, replica.CopyFrom(ctx, src, "key"))
+
+	data, err := replica.Bucket().ReadAll(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, "bundle", string(data))
+
+	attrs, err := replica.Bucket().Attributes(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, "application/javascript", attrs.ContentType)
+	require.Equal(t, "gzip", attrs.ContentEncoding)
+}
@@ -0,0 +1,330 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/gcerrors"
+)
+
+// ProjectBucketResolver returns the driver URL of the project's own bucket,
+// empty string when the project's assets are in the primary bucket
+type ProjectBucketResolver func(ctx context.Context, projectID uuid.UUID) (string, error)
+
+var ErrProjectBucketsUnsupported = errors.New("project buckets require external storage")
+
+// defaultListPageSize is used when listing without a page size, as drivers do
+const defaultListPageSize = 1000
+
+// schemes of buckets that can't hold assets of a project, they're local to a single server
+var localBucketSchemes = []string{"file", "mem"}
+
+// RouteProjectBuckets makes the bucket of the storage route every operation to the bucket of
+// the project the object key belongs to. The bucket of a project is resolved on its first use,
+// it's never changed, so it's resolved only once.
+func (s *Storage) RouteProjectBuckets(resolve ProjectBucketResolver) {
+	if s.provider != ProviderExternal {
+		return
+	}
+	s.bucket = blob.NewBucket(&projectRouter{
+		primary: s.bucket,
+		resolve: resolve,
+		buckets: make(map[uuid.UUID]*blob.Bucket),
+		opened:  make(map[string]*blob.Bucket),
+	})
+}
+
+// ValidateProjectDriverURL checks that the bucket can be used by a project
+func (s *Storage) ValidateProjectDriverURL(ctx context.Context, driverURL string) error {
+	if s.provider != ProviderExternal {
+		return ErrProjectBucketsUnsupported
+	}
+
+	u, err := url.Parse(driverURL)
+	if err != nil {
+		return fmt.Errorf("invalid driver URL: %w", err)
+	}
+	if slices.Contains(localBucketSchemes, u.Scheme) {
+		return fmt.Errorf("%s buckets can't be used by projects", u.Scheme)
+	}
+
+	bucket, err := blob.OpenBucket(ctx, driverURL)
+	if err != nil {
+		return fmt.Errorf("failed to open bucket: %w", err)
+	}
+	defer bucket.Close()
+	accessible, err := bucket.IsAccessible(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to access bucket: %w", err)
+	}
+	if !accessible {
+		return errors.New("bucket doesn't exist")
+	}
+	return nil
+}
+
+// projectIDFromKey returns the project of the object, keys of all project objects start
+// with the project ID, quarantined objects keep their key under the quarantine prefix
+func projectIDFromKey(key string) (uuid.UUID, bool) {
+	key = strings.TrimPrefix(key, QuarantineObjectKey(""))
+	segment, _, _ := strings.Cut(key, "/")
+	projectID, err := uuid.Parse(segment)
+	return projectID, err == nil
+}
+
+// projectRouter is a bucket driver passing the operations to the bucket of the project,
+// objects not belonging to a project are in the primary bucket
+type projectRouter struct {
+	primary *blob.Bucket
+	resolve ProjectBucketResolver
+
+	mu sync.Mutex
+	// buckets of the resolved projects
+	buckets map[uuid.UUID]*blob.Bucket
+	// opened buckets by the driver URL, shared by the projects using the same bucket
+	opened map[string]*blob.Bucket
+}
+
+func (r *projectRouter) bucket(ctx context.Context, key string) (*blob.Bucket, error) {
+	projectID, ok := projectIDFromKey(key)
+	if !ok {
+		return r.primary, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if bucket, ok := r.buckets[projectID]; ok {
+		return bucket, nil
+	}
+
+	driverURL, err := r.resolve(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve bucket of project %s: %w", projectID, err)
+	}
+
+	bucket := r.primary
+	if driverURL != "" {
+		bucket = r.opened[driverURL]
+		if bucket == nil {
+			bucket, err = blob.OpenBucket(ctx, driverURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open bucket of project %s: %w", projectID, err)
+			}
+			r.opened[driverURL] = bucket
+			logger.ComponentFromContext(ctx, logger.ComponentStorage).Info(
+				"opened project bucket",
+				zap.String("project_id", projectID.String()),
+			)
+		}
+	}
+	r.buckets[projectID] = bucket
+	return bucket, nil
+}
+
+func (r *projectRouter) ErrorCode(err error) gcerrors.ErrorCode {
+	return gcerrors.Code(err)
+}
+
+func (r *projectRouter) As(i any) bool {
+	return false
+}
+
+// ErrorAs works only for errors of the primary bucket's driver
+func (r *projectRouter) ErrorAs(err error, i any) bool {
+	return r.primary.ErrorAs(err, i)
+}
+
+func (r *projectRouter) Attributes(ctx context.Context, key string) (*driver.Attributes, error) {
+	bucket, err := r.bucket(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := bucket.Attributes(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &driver.Attributes{
+		CacheControl:       attrs.CacheControl,
+		ContentDisposition: attrs.ContentDisposition,
+		ContentEncoding:    attrs.ContentEncoding,
+		ContentLanguage:    attrs.ContentLanguage,
+		ContentType:        attrs.ContentType,
+		Metadata:           attrs.Metadata,
+		CreateTime:         attrs.CreateTime,
+		ModTime:            attrs.ModTime,
+		Size:               attrs.Size,
+		MD5:                attrs.MD5,
+		ETag:               attrs.ETag,
+		AsFunc:             attrs.As,
+	}, nil
+}
+
+func (r *projectRouter) ListPaged(
+	ctx context.Context,
+	opts *driver.ListOptions,
+) (*driver.ListPage, error) {
+	bucket, err := r.bucket(ctx, opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	pageToken := opts.PageToken
+	if len(pageToken) == 0 {
+		pageToken = blob.FirstPageToken
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	objects, nextPageToken, err := bucket.ListPage(
+		ctx,
+		pageToken,
+		pageSize,
+		&blob.ListOptions{
+			Prefix:     opts.Prefix,
+			Delimiter:  opts.Delimiter,
+			BeforeList: opts.BeforeList,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &driver.ListPage{
+		Objects:       make([]*driver.ListObject, 0, len(objects)),
+		NextPageToken: nextPageToken,
+	}
+	for _, object := range objects {
+		page.Objects = append(page.Objects, &driver.ListObject{
+			Key:     object.Key,
+			ModTime: object.ModTime,
+			Size:    object.Size,
+			MD5:     object.MD5,
+			IsDir:   object.IsDir,
+			AsFunc:  object.As,
+		})
+	}
+	return page, nil
+}
+
+func (r *projectRouter) NewRangeReader(
+	ctx context.Context,
+	key string,
+	offset, length int64,
+	opts *driver.ReaderOptions,
+) (driver.Reader, error) {
+	bucket, err := r.bucket(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := bucket.NewRangeReader(ctx, key, offset, length, &blob.ReaderOptions{
+		BeforeRead: opts.BeforeRead,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &routedReader{reader}, nil
+}
+
+func (r *projectRouter) NewTypedWriter(
+	ctx context.Context,
+	key, contentType string,
+	opts *driver.WriterOptions,
+) (driver.Writer, error) {
+	bucket, err := r.bucket(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return bucket.NewWriter(ctx, key, &blob.WriterOptions{
+		BufferSize:         opts.BufferSize,
+		MaxConcurrency:     opts.MaxConcurrency,
+		CacheControl:       opts.CacheControl,
+		ContentDisposition: opts.ContentDisposition,
+		ContentEncoding:    opts.ContentEncoding,
+		ContentLanguage:    opts.ContentLanguage,
+		// detected by the routing bucket already
+		ContentType: contentType,
+		ContentMD5:  opts.ContentMD5,
+		Metadata:    opts.Metadata,
+		BeforeWrite: opts.BeforeWrite,
+	})
+}
+
+func (r *projectRouter) Copy(
+	ctx context.Context,
+	dstKey, srcKey string,
+	opts *driver.CopyOptions,
+) error {
+	bucket, err := r.bucket(ctx, dstKey)
+	if err != nil {
+		return err
+	}
+	srcBucket, err := r.bucket(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	if srcBucket != bucket {
+		return errors.New("objects can't be copied between buckets of different projects")
+	}
+	return bucket.Copy(ctx, dstKey, srcKey, &blob.CopyOptions{BeforeCopy: opts.BeforeCopy})
+}
+
+func (r *projectRouter) Delete(ctx context.Context, key string) error {
+	bucket, err := r.bucket(ctx, key)
+	if err != nil {
+		return err
+	}
+	return bucket.Delete(ctx, key)
+}
+
+func (r *projectRouter) SignedURL(
+	ctx context.Context,
+	key string,
+	opts *driver.SignedURLOptions,
+) (string, error) {
+	bucket, err := r.bucket(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return bucket.SignedURL(ctx, key, &blob.SignedURLOptions{
+		Expiry:                   opts.Expiry,
+		Method:                   opts.Method,
+		ContentType:              opts.ContentType,
+		EnforceAbsentContentType: opts.EnforceAbsentContentType,
+		BeforeSign:               opts.BeforeSign,
+	})
+}
+
+func (r *projectRouter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	errs := []error{r.primary.Close()}
+	for _, bucket := range r.opened {
+		errs = append(errs, bucket.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// routedReader adapts the reader of the project's bucket to the driver interface
+type routedReader struct {
+	*blob.Reader
+}
+
+func (r *routedReader) Attributes() *driver.ReaderAttributes {
+	return &driver.ReaderAttributes{
+		ContentType: r.ContentType(),
+		ModTime:     r.ModTime(),
+		Size:        r.Size(),
+	}
+}
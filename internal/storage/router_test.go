@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
+	"gocloud.dev/gcerrors"
+)
+
+func TestProjectRouter(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	residentProject := uuid.New()
+	otherProject := uuid.New()
+
+	resolved := make(map[uuid.UUID]int)
+	router := &projectRouter{
+		primary: memblob.OpenBucket(nil),
+		resolve: func(ctx context.Context, projectID uuid.UUID) (string, error) {
+			resolved[projectID]++
+			if projectID == residentProject {
+				return "mem://", nil
+			}
+			return "", nil
+		},
+		buckets: make(map[uuid.UUID]*blob.Bucket),
+		opened:  make(map[string]*blob.Bucket),
+	}
+	bucket := blob.NewBucket(router)
+	defer bucket.Close()
+
+	residentKey := AssetObjectKey(residentProject, uuid.New(), "bundle.js")
+	otherKey := AssetObjectKey(otherProject, uuid.New(), "bundle.js")
+	require.NoError(t, bucket.WriteAll(ctx, residentKey, []byte("resident"), nil))
+	require.NoError(t, bucket.WriteAll(ctx, otherKey, []byte("other"), nil))
+
+	// the objects of the project are only in its own bucket
+	projectBucket := router.opened["mem://"]
+	require.NotNil(t, projectBucket)
+	exists, err := router.primary.Exists(ctx, residentKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+	data, err := projectBucket.ReadAll(ctx, residentKey)
+	require.NoError(t, err)
+	require.Equal(t, "resident", string(data))
+	data, err = router.primary.ReadAll(ctx, otherKey)
+	require.NoError(t, err)
+	require.Equal(t, "other", string(data))
+
+	data, err = bucket.ReadAll(ctx, residentKey)
+	require.NoError(t, err)
+	require.Equal(t, "resident", string(data))
+	attrs, err := bucket.Attributes(ctx, residentKey)
+	require.NoError(t, err)
+	require.EqualValues(t, len("resident"), attrs.Size)
+
+	iter := bucket.List(&blob.ListOptions{Prefix: residentProject.String() + "/"})
+	object, err := iter.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, residentKey, object.Key)
+
+	// quarantined objects stay in the bucket of the project
+	quarantineKey := QuarantineObjectKey(residentKey)
+	require.NoError(t, bucket.Copy(ctx, quarantineKey, residentKey, nil))
+	require.NoError(t, bucket.Delete(ctx, residentKey))
+	exists, err = projectBucket.Exists(ctx, quarantineKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	_, err = bucket.ReadAll(ctx, residentKey)
+	require.Equal(t, gcerrors.NotFound, gcerrors.Code(err))
+
+	// the bucket of a project is resolved once
+	require.Equal(t, 1, resolved[residentProject])
+	require.Equal(t, 1, resolved[otherProject])
+}
+
+func TestProjectIDFromKey(t *testing.T) {
+	projectID := uuid.New()
+	objectKey := AssetObjectKey(projectID, uuid.New(), "bundle.js")
+
+	for _, key := range []string{
+		objectKey,
+		ArchiveObjectKey(projectID, uuid.New(), "ios"),
+		ChunkObjectKeyPrefix(projectID, uuid.New(), "bundle.js"),
+		QuarantineObjectKey(objectKey),
+	} {
+		id, ok := projectIDFromKey(key)
+		require.True(t, ok, key)
+		require.Equal(t, projectID, id)
+	}
+
+	_, ok := projectIDFromKey("paratrooper-doctor-check")
+	require.False(t, ok)
+}
+
+func TestValidateProjectDriverURL(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	local := &Storage{provider: ProviderLocal}
+	err := local.ValidateProjectDriverURL(ctx, "s3://assets")
+	require.ErrorIs(t, err, ErrProjectBucketsUnsupported)
+
+	external := &Storage{provider: ProviderExternal}
+	require.Error(t, external.ValidateProjectDriverURL(ctx, "file:///var/lib/assets"))
+	require.Error(t, external.ValidateProjectDriverURL(ctx, "mem://"))
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/s3blob"
+)
+
+// s3DefaultRegion is signed by clients of S3 compatible servers configured without a region,
+// they usually ignore it
+const s3DefaultRegion = "us-east-1"
+
+// IsExternal is true when the assets are stored in a bucket instead of the local storage
+func (c *Config) IsExternal() bool {
+	return c.DriverURL != "" || c.S3Bucket != ""
+}
+
+// s3DriverURL returns the gocloud driver URL of the bucket configured by the S3 fields
+func (c *Config) s3DriverURL() string {
+	query := url.Values{}
+	region := c.S3Region
+	if region == "" && c.S3Endpoint != "" {
+		region = s3DefaultRegion
+	}
+	if region != "" {
+		query.Set("region", region)
+	}
+	if c.S3Endpoint != "" {
+		query.Set("endpoint", c.S3Endpoint)
+	}
+	if c.S3ForcePathStyle {
+		query.Set("s3ForcePathStyle", "true")
+	}
+
+	driverURL := url.URL{Scheme: s3blob.Scheme, Host: c.S3Bucket, RawQuery: query.Encode()}
+	return driverURL.String()
+}
+
+// openExternalBucket opens the bucket of STORAGE_DRIVER_URL or of the S3 fields, with the
+// credentials read from the configured environment variables if they're set
+func openExternalBucket(ctx context.Context, config *Config) (*blob.Bucket, error) {
+	if config.S3Bucket == "" {
+		return blob.OpenBucket(ctx, config.DriverURL)
+	}
+
+	driverURL, err := url.Parse(config.s3DriverURL())
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 bucket: %w", err)
+	}
+	if config.S3AccessKeyEnv == "" {
+		return blob.DefaultURLMux().OpenBucketURL(ctx, driverURL)
+	}
+
+	accessKeyID := os.Getenv(config.S3AccessKeyEnv)
+	secretAccessKey := os.Getenv(config.S3SecretKeyEnv)
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf(
+			"S3 credentials are missing, set %s and %s",
+			config.S3AccessKeyEnv,
+			config.S3SecretKeyEnv,
+		)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			Credentials: credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
+		},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+	opener := &s3blob.URLOpener{ConfigProvider: sess}
+	return opener.OpenBucketURL(ctx, driverURL)
+}
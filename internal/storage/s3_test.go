@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3DriverURL(t *testing.T) {
+	config := &Config{S3Bucket: "assets"}
+	require.True(t, config.IsExternal())
+	require.Equal(t, "s3://assets", config.s3DriverURL())
+
+	config.S3Region = "eu-central-1"
+	require.Equal(t, "s3://assets?region=eu-central-1", config.s3DriverURL())
+
+	config = &Config{
+		S3Bucket:         "assets",
+		S3Endpoint:       "http://minio:9000",
+		S3ForcePathStyle: true,
+	}
+	require.Equal(
+		t,
+		"s3://assets?endpoint=http%3A%2F%2Fminio%3A9000&region=us-east-1&s3ForcePathStyle=true",
+		config.s3DriverURL(),
+	)
+
+	require.False(t, (&Config{LocalPath: "assets"}).IsExternal())
+}
+
+func TestOpenExternalBucketCredentials(t *testing.T) {
+	ctx := context.Background()
+	config := &Config{
+		S3Bucket:         "assets",
+		S3Endpoint:       "http://minio:9000",
+		S3ForcePathStyle: true,
+		S3AccessKeyEnv:   "TEST_MINIO_ACCESS_KEY",
+		S3SecretKeyEnv:   "TEST_MINIO_SECRET_KEY",
+	}
+
+	_, err := openExternalBucket(ctx, config)
+	require.ErrorContains(t, err, "TEST_MINIO_ACCESS_KEY")
+
+	t.Setenv("TEST_MINIO_ACCESS_KEY", "access")
+	t.Setenv("TEST_MINIO_SECRET_KEY", "secret")
+	bucket, err := openExternalBucket(ctx, config)
+	require.NoError(t, err)
+	defer bucket.Close()
+
+	var client *s3.S3
+	require.True(t, bucket.As(&client))
+	require.Equal(t, "http://minio:9000", client.Endpoint)
+	require.True(t, *client.Config.S3ForcePathStyle)
+	creds, err := client.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "access", creds.AccessKeyID)
+	require.Equal(t, "secret", creds.SecretAccessKey)
+}
@@ -1,25 +1,56 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"net/url"
+	"os"
 
 	"github.com/a-gierczak/paratrooper/internal/logger"
 	"github.com/a-gierczak/paratrooper/internal/util"
 
+	"go.uber.org/zap"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 )
 
+// ErrContentMD5Mismatch is returned when the uploaded content doesn't match the expected MD5
+var ErrContentMD5Mismatch = errors.New("content doesn't match the expected MD5")
+
+// Object metadata keys of the content hashes calculated while uploading to the local storage
+const (
+	MetadataContentSHA256 = "content-sha256"
+	MetadataContentMD5    = "content-md5"
+)
+
+// ContentHashes returns the hashes calculated while the object was uploaded to the local storage,
+// ok is false when they weren't calculated
+func ContentHashes(attrs *blob.Attributes) (sha256Hash, md5Hash string, ok bool) {
+	sha256Hash, md5Hash = attrs.Metadata[MetadataContentSHA256], attrs.Metadata[MetadataContentMD5]
+	return sha256Hash, md5Hash, sha256Hash != "" && md5Hash != ""
+}
+
 type Service interface {
-	Upload(ctx context.Context, reader io.Reader, objectKey string) error
+	Upload(
+		ctx context.Context,
+		reader io.Reader,
+		objectKey string,
+		opts *blob.WriterOptions,
+	) error
 	ReadObjectWithAttributes(
 		ctx context.Context,
 		objectKey string,
 	) (*blob.Reader, *blob.Attributes, error)
 	ObjectKeyFromURL(ctx context.Context, requestURL *url.URL) (string, error)
+	ObjectKeyFromRequest(ctx context.Context, req *http.Request) (string, error)
 }
 
 type service struct {
@@ -30,19 +61,82 @@ func NewService(storage *Storage) Service {
 	return &service{storage}
 }
 
-func (s *service) Upload(ctx context.Context, reader io.Reader, objectKey string) error {
+// Upload hashes the content while it's streamed to a temporary file, and writes it with the hashes
+// in the object metadata, so the worker doesn't have to read it again. Content with an encoding
+// is written as is, the worker hashes the decoded content. Content not matching opts.ContentMD5,
+// when set, isn't written and ErrContentMD5Mismatch is returned.
+func (s *service) Upload(
+	ctx context.Context,
+	reader io.Reader,
+	objectKey string,
+	opts *blob.WriterOptions,
+) error {
+	if opts.ContentEncoding != "" {
+		return s.write(ctx, reader, objectKey, opts)
+	}
+
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
+	// the metadata has to be known before the object is written
+	spool, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		util.CloseWithLogger(log, spool)
+		if err := os.Remove(spool.Name()); err != nil {
+			log.Error("failed to remove temporary file", zap.Error(err))
+		}
+	}()
+
+	sha256Hash, md5Hash := sha256.New(), md5.New()
+	if _, err := io.Copy(io.MultiWriter(spool, sha256Hash, md5Hash), reader); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if len(opts.ContentMD5) > 0 && !bytes.Equal(md5Hash.Sum(nil), opts.ContentMD5) {
+		return ErrContentMD5Mismatch
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind temporary file: %w", err)
+	}
+
+	opts.Metadata = map[string]string{
+		MetadataContentSHA256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		MetadataContentMD5:    hex.EncodeToString(md5Hash.Sum(nil)),
+	}
+	return s.write(ctx, spool, objectKey, opts)
+}
+
+func (s *service) write(
+	ctx context.Context,
+	reader io.Reader,
+	objectKey string,
+	opts *blob.WriterOptions,
+) error {
+	// canceling the context before closing the writer discards the partially written object
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// TODO: check if user has access to this update
-	writer, err := s.storage.Bucket().NewWriter(ctx, objectKey, nil)
+	writer, err := s.storage.Bucket().NewWriter(writeCtx, objectKey, opts)
 	if err != nil {
 		return fmt.Errorf("failed to create object: %w", err)
 	}
-	log := logger.FromContext(ctx)
-	defer util.CloseWithLogger(log, writer)
 
 	if _, err := io.Copy(writer, reader); err != nil {
+		cancel()
+		_ = writer.Close()
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
+	// the writer discards the object when it doesn't match opts.ContentMD5
+	err = writer.Close()
+	if len(opts.ContentMD5) > 0 && gcerrors.Code(err) == gcerrors.FailedPrecondition {
+		return ErrContentMD5Mismatch
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close object writer: %w", err)
+	}
+
 	return nil
 }
 
@@ -50,6 +144,10 @@ func (s *service) ObjectKeyFromURL(ctx context.Context, requestURL *url.URL) (st
 	return s.storage.URLSigner().KeyFromURL(ctx, requestURL)
 }
 
+func (s *service) ObjectKeyFromRequest(ctx context.Context, req *http.Request) (string, error) {
+	return ObjectKeyFromRequest(ctx, req, s.storage.URLSigner(), s.storage.AssetTokenSigner())
+}
+
 type ObjectFile interface {
 	io.ReadSeekCloser
 	fs.FileInfo
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestUploadHashesContent(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	bucket := memblob.OpenBucket(nil)
+	svc := NewService(&Storage{bucket: bucket})
+	content := []byte("var bundle = true;")
+
+	err := svc.Upload(ctx, bytes.NewReader(content), "bundle.js", &blob.WriterOptions{
+		ContentType: "application/javascript",
+	})
+	require.NoError(t, err)
+
+	data, err := bucket.ReadAll(ctx, "bundle.js")
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+
+	attrs, err := bucket.Attributes(ctx, "bundle.js")
+	require.NoError(t, err)
+	sha256Hash, md5Hash, ok := ContentHashes(attrs)
+	require.True(t, ok)
+	require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(content)), sha256Hash)
+	require.Equal(t, fmt.Sprintf("%x", md5.Sum(content)), md5Hash)
+
+	// encoded content is hashed by the worker after decoding
+	err = svc.Upload(ctx, bytes.NewReader(content), "bundle.js.gz", &blob.WriterOptions{
+		ContentEncoding: "gzip",
+	})
+	require.NoError(t, err)
+	attrs, err = bucket.Attributes(ctx, "bundle.js.gz")
+	require.NoError(t, err)
+	_, _, ok = ContentHashes(attrs)
+	require.False(t, ok)
+}
+
+func TestUploadVerifiesContentMD5(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	bucket := memblob.OpenBucket(nil)
+	svc := NewService(&Storage{bucket: bucket})
+	content := []byte("var bundle = true;")
+	contentMD5 := md5.Sum(content)
+
+	err := svc.Upload(ctx, bytes.NewReader(content), "bundle.js", &blob.WriterOptions{
+		ContentMD5: contentMD5[:],
+	})
+	require.NoError(t, err)
+
+	wrongMD5 := md5.Sum([]byte("other"))
+	for _, key := range []string{"other.js", "other.js.gz"} {
+		opts := &blob.WriterOptions{ContentMD5: wrongMD5[:]}
+		if key == "other.js.gz" {
+			opts.ContentEncoding = "gzip"
+		}
+		err = svc.Upload(ctx, bytes.NewReader(content), key, opts)
+		require.ErrorIs(t, err, ErrContentMD5Mismatch, key)
+
+		exists, err := bucket.Exists(ctx, key)
+		require.NoError(t, err)
+		require.False(t, exists, key)
+	}
+}
+
+func TestIsUpdateObjectKey(t *testing.T) {
+	projectID, updateID := uuid.New(), uuid.New()
+
+	require.True(t, IsUpdateObjectKey(AssetObjectKey(projectID, updateID, "assets/logo.png")))
+	require.True(t, IsUpdateObjectKey(ArchiveObjectKey(projectID, updateID, "ios")))
+	require.True(t, IsUpdateObjectKey(DiffArchiveObjectKey(projectID, updateID, "ios", "hash")))
+
+	require.False(t, IsUpdateObjectKey(
+		QuarantineObjectKey(AssetObjectKey(projectID, updateID, "bundle.js")),
+	))
+	require.False(t, IsUpdateObjectKey(ChunkObjectKey(projectID, updateID, "bundle.js", 0)))
+	require.False(t, IsUpdateObjectKey(AssetObjectKey(projectID, updateID, "../other/bundle.js")))
+	require.False(t, IsUpdateObjectKey(projectID.String()+"/"+updateID.String()))
+	require.False(t, IsUpdateObjectKey(projectID.String()+"/archives/"+updateID.String()))
+}
+
+func TestUpdateObjectKeyIDs(t *testing.T) {
+	projectID, updateID := uuid.New(), uuid.New()
+	for _, objectKey := range []string{
+		AssetObjectKey(projectID, updateID, "assets/logo.png"),
+		ArchiveObjectKey(projectID, updateID, "ios"),
+		DiffArchiveObjectKey(projectID, updateID, "ios", "hash"),
+	} {
+		keyProjectID, keyUpdateID, err := UpdateObjectKeyIDs(objectKey)
+		require.NoError(t, err)
+		require.Equal(t, projectID, keyProjectID, objectKey)
+		require.Equal(t, updateID, keyUpdateID, objectKey)
+	}
+
+	_, _, err := UpdateObjectKeyIDs(ChunkObjectKey(projectID, updateID, "bundle.js", 0))
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// StableAssetEndpointPath serves the assets of published updates by their ID, with URLs that
+// never expire, so slow downloads don't fail midway
+const StableAssetEndpointPath = AssetEndpointPath + "/:assetID"
+
+// StableAssetTokenParam is the query parameter of the token of stable asset URLs
+const StableAssetTokenParam = "token"
+
+// stableURLs builds the URLs of the stable asset endpoint, with a token of the asset when the
+// secret key is set
+type stableURLs struct {
+	baseURL   string
+	secretKey []byte
+}
+
+func newStableURLs(publicURL string, secretKeyPath string) (*stableURLs, error) {
+	baseURL, err := url.JoinPath(publicURL, AssetEndpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URL: %w", err)
+	}
+	urls := &stableURLs{baseURL: baseURL}
+	if secretKeyPath != "" {
+		urls.secretKey, err = os.ReadFile(secretKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret key file: %w", err)
+		}
+	}
+	return urls, nil
+}
+
+func (u *stableURLs) token(assetID uuid.UUID) string {
+	hsh := hmac.New(sha256.New, u.secretKey)
+	fmt.Fprintf(hsh, "stable-asset\n%s", assetID)
+	return base64.RawURLEncoding.EncodeToString(hsh.Sum(nil))
+}
+
+// StableAssetURLs reports whether the API serves the stable asset endpoint, it needs the public
+// URL of the API
+func (s *Storage) StableAssetURLs() bool {
+	return s.stable != nil
+}
+
+// StableAssetURL returns the URL of the asset at the stable asset endpoint
+func (s *Storage) StableAssetURL(assetID uuid.UUID) string {
+	assetURL := s.stable.baseURL + "/" + assetID.String()
+	if s.stable.secretKey != nil {
+		assetURL += "?" + StableAssetTokenParam + "=" + s.stable.token(assetID)
+	}
+	return assetURL
+}
+
+// VerifyStableAssetToken checks the token of the stable asset URL, any token is valid when
+// tokens aren't required
+func (s *Storage) VerifyStableAssetToken(assetID uuid.UUID, token string) bool {
+	if s.stable.secretKey == nil {
+		return true
+	}
+	return hmac.Equal([]byte(token), []byte(s.stable.token(assetID)))
+}
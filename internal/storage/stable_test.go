@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableAssetURL(t *testing.T) {
+	assetID := uuid.New()
+
+	t.Run("without token", func(t *testing.T) {
+		stable, err := newStableURLs("https://api.example.com/", "")
+		require.NoError(t, err)
+		st := &Storage{stable: stable}
+
+		require.True(t, st.StableAssetURLs())
+		require.Equal(t, "https://api.example.com/assets/"+assetID.String(), st.StableAssetURL(assetID))
+		require.True(t, st.VerifyStableAssetToken(assetID, ""))
+	})
+
+	t.Run("with token", func(t *testing.T) {
+		keyPath := filepath.Join(t.TempDir(), "stable.key")
+		require.NoError(t, os.WriteFile(keyPath, []byte("0123456789abcdef"), 0600))
+		stable, err := newStableURLs("https://api.example.com", keyPath)
+		require.NoError(t, err)
+		st := &Storage{stable: stable}
+
+		assetURL, err := url.Parse(st.StableAssetURL(assetID))
+		require.NoError(t, err)
+		require.Equal(t, "/assets/"+assetID.String(), assetURL.Path)
+
+		token := assetURL.Query().Get(StableAssetTokenParam)
+		require.NotEmpty(t, token)
+		require.True(t, st.VerifyStableAssetToken(assetID, token))
+		require.False(t, st.VerifyStableAssetToken(assetID, ""))
+		require.False(t, st.VerifyStableAssetToken(uuid.New(), token))
+	})
+
+	t.Run("disabled without public URL", func(t *testing.T) {
+		require.False(t, (&Storage{}).StableAssetURLs())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -20,6 +21,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
 	"gocloud.dev/blob/fileblob"
 	_ "gocloud.dev/blob/fileblob"
 	_ "gocloud.dev/blob/gcsblob"
@@ -32,6 +34,35 @@ type Config struct {
 	SecretKeyPath string `env:"STORAGE_LOCAL_SECRET_KEY_PATH"     validate:"required_with=LocalPath"`
 	ApiPublicURL  string `env:"API_PUBLIC_URL"                    validate:"required_with=LocalPath"`
 	DriverURL     string `env:"STORAGE_DRIVER_URL"                validate:"excluded_with=LocalPath"`
+	// S3 compatible bucket, e.g. of MinIO, configured without a driver URL
+	S3Bucket         string `env:"STORAGE_S3_BUCKET"   validate:"excluded_with=LocalPath DriverURL"`
+	S3Region         string `env:"STORAGE_S3_REGION"`
+	S3Endpoint       string `env:"STORAGE_S3_ENDPOINT" validate:"omitempty,url"`
+	S3ForcePathStyle bool   `env:"STORAGE_S3_FORCE_PATH_STYLE"`
+	// names of the environment variables with the credentials of the S3 bucket, the credentials
+	// are looked up by the AWS SDK, e.g. in AWS_ACCESS_KEY_ID, when they're not set
+	S3AccessKeyEnv string `env:"STORAGE_S3_ACCESS_KEY_ENV" validate:"required_with=S3SecretKeyEnv"`
+	S3SecretKeyEnv string `env:"STORAGE_S3_SECRET_KEY_ENV" validate:"required_with=S3AccessKeyEnv"`
+	// CloudFront distribution in front of the external bucket, assets are then served
+	// with signed cookies instead of signed URLs
+	CDNBaseURL        string `env:"STORAGE_CDN_BASE_URL"         validate:"omitempty,url"`
+	CDNKeyPairID      string `env:"STORAGE_CDN_KEY_PAIR_ID"      validate:"required_with=CDNBaseURL"`
+	CDNPrivateKeyPath string `env:"STORAGE_CDN_PRIVATE_KEY_PATH" validate:"required_with=CDNBaseURL"`
+	// JSON array of secondary buckets in other regions, see ReplicaConfig
+	ReplicasFile string `env:"STORAGE_REPLICAS_FILE"`
+	// public URL of the edge cache (the API server or cmd/edge) serving the external bucket,
+	// download URLs then point to the edge cache and are signed with the edge secret key
+	EdgeBaseURL       string `env:"STORAGE_EDGE_BASE_URL"`
+	EdgeSecretKeyPath string `env:"STORAGE_EDGE_SECRET_KEY_PATH"`
+	// validity of the tokens Expo clients download assets of local storage or the edge cache
+	// with, sent in the assetRequestHeaders manifest extension instead of signing asset URLs
+	AssetTokenExpiry time.Duration `env:"STORAGE_ASSET_TOKEN_EXPIRY"`
+	// key of the tokens of stable asset URLs, served by the API at API_PUBLIC_URL, the URLs
+	// have no token when it's not set
+	StableURLSecretKeyPath string `env:"STORAGE_STABLE_URL_SECRET_KEY_PATH"`
+	// bucket the assets of superseded updates are moved to, e.g. with a lifecycle rule
+	// transitioning its objects to a cheaper storage class
+	ColdDriverURL string `env:"STORAGE_COLD_DRIVER_URL"`
 }
 
 const (
@@ -39,20 +70,40 @@ const (
 	ProviderExternal = "external"
 )
 const UploadURLExpiry = 15 * time.Minute
+
+// DownloadURLExpiry is the minimal validity of download URLs, see DownloadURLWindow
 const DownloadURLExpiry = 30 * time.Minute
 const MaxUpdateTotalSizeMB = 100
 
 // AssetEndpointPath only relevant for local & memory storage
 const AssetEndpointPath = "/assets"
 
+// EdgeEndpointPath serves assets of the external storage from the edge cache
+const EdgeEndpointPath = "/edge"
+
 var ErrUpdateTooLarge = fmt.Errorf("max update size is %dMB", MaxUpdateTotalSizeMB)
 
+// ErrTooManyAssets is returned when an update has more files than its project allows
+var ErrTooManyAssets = errors.New("update has too many files")
+
 type Storage struct {
 	provider  string
 	bucket    *blob.Bucket
 	localPath string
 	// used only in local storage
 	urlSigner fileblob.URLSigner
+	// used only in external storage with a CDN configured
+	cdnSigner *CDNSigner
+	// used only in external storage
+	replicas []*Replica
+	// used only in external storage with an edge cache configured
+	edgeSigner fileblob.URLSigner
+	// used in local storage and with an edge cache, when asset tokens are enabled
+	tokenSigner *AssetTokenSigner
+	// set when the API has a public URL
+	stable *stableURLs
+	// used only in external storage with cold storage configured
+	cold *blob.Bucket
 }
 
 func cleanLocalPath(localPath string) string {
@@ -64,7 +115,7 @@ func cleanLocalPath(localPath string) string {
 }
 
 func generateSecretKeyFile(ctx context.Context, path string) error {
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
 	key := make([]byte, 32)
 	_, err := rand.Read(key)
 	if err != nil {
@@ -91,29 +142,122 @@ func generateSecretKeyFile(ctx context.Context, path string) error {
 	return nil
 }
 
+func initStableURLs(ctx context.Context, config *Config) (*stableURLs, error) {
+	if config.ApiPublicURL == "" {
+		return nil, nil
+	}
+	if config.StableURLSecretKeyPath != "" {
+		err := generateSecretKeyFile(ctx, config.StableURLSecretKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate stable URL secret key file: %w", err)
+		}
+	}
+	stable, err := newStableURLs(config.ApiPublicURL, config.StableURLSecretKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stable asset URLs: %w", err)
+	}
+	return stable, nil
+}
+
 func Init(ctx context.Context, config *Config) (*Storage, error) {
 	err := binding.Validator.ValidateStruct(config)
 	if err != nil {
 		return nil, err
 	}
 
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
 
 	if err := RegisterValidators(); err != nil {
 		return nil, fmt.Errorf("failed to register storage validators: %w", err)
 	}
 
-	if config.DriverURL != "" {
-		storage := Storage{provider: ProviderExternal}
-		bucket, err := blob.OpenBucket(ctx, config.DriverURL)
+	stable, err := initStableURLs(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.IsExternal() {
+		storage := Storage{provider: ProviderExternal, stable: stable}
+		bucket, err := openExternalBucket(ctx, config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open cloud storage bucket: %w", err)
 		}
 		storage.bucket = bucket
+
+		if config.CDNBaseURL != "" {
+			storage.cdnSigner, err = newCDNSigner(
+				config.CDNBaseURL,
+				config.CDNKeyPairID,
+				config.CDNPrivateKeyPath,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create CDN signer: %w", err)
+			}
+			log.Info("using CDN signed cookies", zap.String("cdn_url", config.CDNBaseURL))
+		}
+
+		if config.ReplicasFile != "" {
+			storage.replicas, err = openReplicas(ctx, config.ReplicasFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if config.EdgeBaseURL != "" {
+			if config.EdgeSecretKeyPath == "" {
+				return nil, errors.New("edge cache requires STORAGE_EDGE_SECRET_KEY_PATH")
+			}
+			err := generateSecretKeyFile(ctx, config.EdgeSecretKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate edge secret key file: %w", err)
+			}
+			storage.edgeSigner, err = newURLSigner(
+				config.EdgeBaseURL,
+				EdgeEndpointPath,
+				config.EdgeSecretKeyPath,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create edge URL signer: %w", err)
+			}
+			log.Info("serving assets from edge cache", zap.String("edge_url", config.EdgeBaseURL))
+
+			if config.AssetTokenExpiry > 0 {
+				storage.tokenSigner, err = newAssetTokenSigner(
+					config.EdgeBaseURL,
+					EdgeEndpointPath,
+					config.EdgeSecretKeyPath,
+					config.AssetTokenExpiry,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create asset token signer: %w", err)
+				}
+			}
+		} else if config.AssetTokenExpiry > 0 {
+			return nil, errors.New("asset tokens require local storage or an edge cache")
+		}
+
+		if config.ColdDriverURL != "" {
+			storage.cold, err = blob.OpenBucket(ctx, config.ColdDriverURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open cold storage bucket: %w", err)
+			}
+			log.Info("moving superseded updates to cold storage")
+		}
+
 		log.Info("initialized external storage")
 		return &storage, nil
 	} else if config.LocalPath != "" {
-		storage := Storage{provider: ProviderLocal}
+		if config.ReplicasFile != "" {
+			return nil, errors.New("storage replicas require external storage")
+		}
+		if config.EdgeBaseURL != "" {
+			return nil, errors.New("edge cache requires external storage")
+		}
+		if config.ColdDriverURL != "" {
+			return nil, errors.New("cold storage requires external storage")
+		}
+
+		storage := Storage{provider: ProviderLocal, stable: stable}
 		storage.localPath = cleanLocalPath(config.LocalPath)
 
 		// generate secret key file if it doesn't exist
@@ -124,10 +268,25 @@ func Init(ctx context.Context, config *Config) (*Storage, error) {
 			}
 		}
 
-		storage.urlSigner, err = newLocalURLSigner(config.ApiPublicURL, config.SecretKeyPath)
+		storage.urlSigner, err = newURLSigner(
+			config.ApiPublicURL,
+			AssetEndpointPath,
+			config.SecretKeyPath,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create URL signer: %w", err)
 		}
+		if config.AssetTokenExpiry > 0 {
+			storage.tokenSigner, err = newAssetTokenSigner(
+				config.ApiPublicURL,
+				AssetEndpointPath,
+				config.SecretKeyPath,
+				config.AssetTokenExpiry,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create asset token signer: %w", err)
+			}
+		}
 
 		bucket, err := fileblob.OpenBucket(storage.localPath, &fileblob.Options{
 			URLSigner: storage.urlSigner,
@@ -161,10 +320,88 @@ func AssetObjectKey(projectID uuid.UUID, updateId uuid.UUID, path string) string
 	return fmt.Sprintf("%s/%s/%s", projectID, updateId, path)
 }
 
+// PublicObjectURL returns an unsigned URL of the object in a public bucket
+func PublicObjectURL(baseURL string, objectKey string) (string, error) {
+	return url.JoinPath(baseURL, objectKey)
+}
+
 func ArchiveObjectKey(projectID uuid.UUID, updateId uuid.UUID, platform string) string {
 	return fmt.Sprintf("%s/archives/%s/%s.zip", projectID, updateId, platform)
 }
 
+// DiffArchiveObjectKey is the key of the CodePush package with the files of the update changed
+// since the package with the base hash
+func DiffArchiveObjectKey(
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	platform string,
+	basePackageHash string,
+) string {
+	return fmt.Sprintf(
+		"%s/archives/%s/diffs/%s/%s.zip",
+		projectID,
+		updateID,
+		platform,
+		basePackageHash,
+	)
+}
+
+// IsUpdateObjectKey reports whether the key is of a file or a package of an update, under the
+// project/update or project/archives/update prefix, e.g. not of a quarantined object or a chunk
+func IsUpdateObjectKey(objectKey string) bool {
+	if CleanPath(objectKey) != objectKey {
+		return false
+	}
+	segments := strings.SplitN(objectKey, "/", 4)
+	if len(segments) < 3 {
+		return false
+	}
+	if _, err := uuid.Parse(segments[0]); err != nil {
+		return false
+	}
+	if segments[1] == "archives" {
+		segments = segments[1:]
+		if len(segments) < 3 {
+			return false
+		}
+	}
+	_, err := uuid.Parse(segments[1])
+	return err == nil && segments[2] != ""
+}
+
+// UpdateObjectKeyIDs returns the project and the update the object of a file or a package is
+// stored under, which isn't the update serving it for shared files and linked updates
+func UpdateObjectKeyIDs(objectKey string) (projectID uuid.UUID, updateID uuid.UUID, err error) {
+	if !IsUpdateObjectKey(objectKey) {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%s isn't a key of an update object", objectKey)
+	}
+	segments := strings.SplitN(objectKey, "/", 4)
+	updateSegment := segments[1]
+	if updateSegment == "archives" {
+		updateSegment = segments[2]
+	}
+	return uuid.MustParse(segments[0]), uuid.MustParse(updateSegment), nil
+}
+
+// QuarantineObjectKey is where corrupted objects are moved, so they're no longer served
+func QuarantineObjectKey(objectKey string) string {
+	return "quarantine/" + objectKey
+}
+
+// ProjectChunksObjectKeyPrefix is the prefix of the uploaded chunks of all files of the project
+func ProjectChunksObjectKeyPrefix(projectID uuid.UUID) string {
+	return fmt.Sprintf("%s/chunks/", projectID)
+}
+
+// ChunkObjectKeyPrefix is the prefix of all uploaded chunks of a file
+func ChunkObjectKeyPrefix(projectID uuid.UUID, updateId uuid.UUID, path string) string {
+	return fmt.Sprintf("%s%s/%s/", ProjectChunksObjectKeyPrefix(projectID), updateId, path)
+}
+
+func ChunkObjectKey(projectID uuid.UUID, updateId uuid.UUID, path string, index int) string {
+	return fmt.Sprintf("%s%d", ChunkObjectKeyPrefix(projectID, updateId, path), index)
+}
+
 func AssetObjectKeySegments(assetObjectKey string) (projectID, updateID, path string) {
 	segments := strings.SplitN(assetObjectKey, "/", 3)
 	if len(segments) != 3 {
@@ -179,7 +416,12 @@ func (s *Storage) UploadURLs(
 	projectID uuid.UUID,
 	updateID uuid.UUID,
 	objects []api.StorageObject,
+	maxAssetCount int,
 ) ([]api.StorageObjectPathWithURL, error) {
+	if len(objects) > maxAssetCount {
+		return nil, fmt.Errorf("%w, max is %d", ErrTooManyAssets, maxAssetCount)
+	}
+
 	totalSize := 0
 	for _, object := range objects {
 		totalSize += object.ContentLength
@@ -188,7 +430,7 @@ func (s *Storage) UploadURLs(
 		return nil, ErrUpdateTooLarge
 	}
 
-	log := logger.FromContext(ctx)
+	log := logger.ComponentFromContext(ctx, logger.ComponentStorage)
 	urls := make([]api.StorageObjectPathWithURL, 0, len(objects))
 	for _, object := range objects {
 		cleanPath := CleanPath(object.Path)
@@ -226,10 +468,37 @@ func (s *Storage) URLSigner() fileblob.URLSigner {
 	return s.urlSigner
 }
 
+// CDNSigner returns nil when assets are served directly from the bucket
+func (s *Storage) CDNSigner() *CDNSigner {
+	return s.cdnSigner
+}
+
+// EdgeURLSigner returns nil when assets aren't served from the edge cache
+func (s *Storage) EdgeURLSigner() fileblob.URLSigner {
+	return s.edgeSigner
+}
+
+// AssetTokenSigner returns nil when assets are requested with signed URLs only
+func (s *Storage) AssetTokenSigner() *AssetTokenSigner {
+	return s.tokenSigner
+}
+
+// EdgeURL returns the signed URL of the object in the edge cache
+func (s *Storage) EdgeURL(ctx context.Context, objectKey string) (string, error) {
+	signedURL, err := s.edgeSigner.URLFromKey(ctx, objectKey, &driver.SignedURLOptions{
+		Method: http.MethodGet,
+		Expiry: SignedDownloadURLExpiry(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return signedURL.String(), nil
+}
+
 // use the same logic as fileblob.OpenBucket, but we need to do it manually
 // because they don't expose the URLSigner
-func newLocalURLSigner(apiPublicURL, secretKeyPath string) (fileblob.URLSigner, error) {
-	baseURL, err := url.JoinPath(apiPublicURL, AssetEndpointPath)
+func newURLSigner(publicURL, endpointPath, secretKeyPath string) (fileblob.URLSigner, error) {
+	baseURL, err := url.JoinPath(publicURL, endpointPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create URL: %w", err)
 	}
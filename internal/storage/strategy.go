@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"github.com/a-gierczak/paratrooper/generated/db"
+)
+
+// AssetURLStrategy is how the clients of a project download the assets
+type AssetURLStrategy int
+
+const (
+	// AssetURLTemplated URLs are expanded from the project's asset URL template
+	AssetURLTemplated AssetURLStrategy = iota + 1
+	// AssetURLPublic URLs point to the project's public bucket, unsigned
+	AssetURLPublic
+	// AssetURLStable URLs point to the stable asset route and never expire
+	AssetURLStable
+	// AssetURLReplica URLs are signed URLs of the replica of the client's region
+	AssetURLReplica
+	// AssetURLCDN URLs point to the CDN, the clients send signed cookies
+	AssetURLCDN
+	// AssetURLToken URLs point to the edge cache or the local storage route, the clients send
+	// a token in the request headers
+	AssetURLToken
+	// AssetURLEdge URLs are signed URLs of the edge cache
+	AssetURLEdge
+	// AssetURLSigned URLs are signed URLs of the bucket of the project
+	AssetURLSigned
+)
+
+// AssetURLStrategyFor returns how the client downloads the assets of the project, replica is
+// the replica serving the update to the client, if any. The CDN and asset tokens are used only
+// when the client sends the request headers of the manifest, like Expo clients do.
+func (s *Storage) AssetURLStrategyFor(
+	project db.Project,
+	replica *Replica,
+	requestHeaders bool,
+) AssetURLStrategy {
+	switch {
+	case project.AssetUrlTemplate.Valid:
+		return AssetURLTemplated
+	case project.PublicAssetsUrl.Valid:
+		return AssetURLPublic
+	case project.StableAssetUrls && s.StableAssetURLs():
+		return AssetURLStable
+	case replica != nil:
+		return AssetURLReplica
+	case project.StorageDriverUrl.Valid:
+		// the CDN, the edge cache and the asset tokens serve the primary bucket only
+		return AssetURLSigned
+	case requestHeaders && s.cdnSigner != nil:
+		return AssetURLCDN
+	case requestHeaders && s.tokenSigner != nil:
+		return AssetURLToken
+	case s.edgeSigner != nil:
+		return AssetURLEdge
+	default:
+		return AssetURLSigned
+	}
+}
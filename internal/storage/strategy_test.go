@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/fileblob"
+)
+
+func TestAssetURLStrategyFor(t *testing.T) {
+	edgeURL := &url.URL{Scheme: "https", Host: "edge.example.com"}
+	st := &Storage{
+		cdnSigner:   &CDNSigner{},
+		edgeSigner:  fileblob.NewURLSignerHMAC(edgeURL, []byte("key")),
+		tokenSigner: &AssetTokenSigner{},
+		stable:      &stableURLs{},
+	}
+	text := pgtype.Text{String: "value", Valid: true}
+	replica := &Replica{Region: "eu"}
+
+	// every case also matches all of the cases below it
+	tests := []struct {
+		name           string
+		storage        *Storage
+		project        db.Project
+		replica        *Replica
+		requestHeaders bool
+		want           AssetURLStrategy
+	}{
+		{
+			name: "templated",
+			project: db.Project{
+				AssetUrlTemplate: text,
+				PublicAssetsUrl:  text,
+				StableAssetUrls:  true,
+				StorageDriverUrl: text,
+			},
+			replica:        replica,
+			requestHeaders: true,
+			want:           AssetURLTemplated,
+		},
+		{
+			name: "public",
+			project: db.Project{
+				PublicAssetsUrl:  text,
+				StableAssetUrls:  true,
+				StorageDriverUrl: text,
+			},
+			replica:        replica,
+			requestHeaders: true,
+			want:           AssetURLPublic,
+		},
+		{
+			name:           "stable",
+			project:        db.Project{StableAssetUrls: true, StorageDriverUrl: text},
+			replica:        replica,
+			requestHeaders: true,
+			want:           AssetURLStable,
+		},
+		{
+			name:           "stable URLs disabled in the API",
+			storage:        &Storage{},
+			project:        db.Project{StableAssetUrls: true},
+			requestHeaders: true,
+			want:           AssetURLSigned,
+		},
+		{
+			name:           "replica",
+			project:        db.Project{StorageDriverUrl: text},
+			replica:        replica,
+			requestHeaders: true,
+			want:           AssetURLReplica,
+		},
+		{
+			name:           "project's own bucket",
+			project:        db.Project{StorageDriverUrl: text},
+			requestHeaders: true,
+			want:           AssetURLSigned,
+		},
+		{
+			name:           "cdn",
+			requestHeaders: true,
+			want:           AssetURLCDN,
+		},
+		{
+			name:           "token",
+			storage:        &Storage{edgeSigner: st.edgeSigner, tokenSigner: st.tokenSigner},
+			requestHeaders: true,
+			want:           AssetURLToken,
+		},
+		{
+			name: "edge without request headers",
+			want: AssetURLEdge,
+		},
+		{
+			name: "signed",
+			storage: &Storage{
+				cdnSigner:   st.cdnSigner,
+				tokenSigner: st.tokenSigner,
+			},
+			want: AssetURLSigned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := st
+			if tt.storage != nil {
+				s = tt.storage
+			}
+			require.Equal(t, tt.want, s.AssetURLStrategyFor(tt.project, tt.replica, tt.requestHeaders))
+		})
+	}
+}
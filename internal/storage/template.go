@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var placeholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+
+// AssetURLVars are substituted into a project's asset URL template
+type AssetURLVars struct {
+	ProjectID uuid.UUID
+	UpdateID  uuid.UUID
+	// ObjectKey is the full key of the object in the bucket
+	ObjectKey string
+	// Path of the file within the update
+	Path   string
+	SHA256 string
+	MD5    string
+}
+
+func escapePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (v AssetURLVars) replacer() *strings.Replacer {
+	return strings.NewReplacer(
+		"{project}", v.ProjectID.String(),
+		"{update}", v.UpdateID.String(),
+		"{key}", escapePath(v.ObjectKey),
+		"{path}", escapePath(v.Path),
+		"{sha256}", v.SHA256,
+		"{md5}", v.MD5,
+	)
+}
+
+// ExpandAssetURLTemplate builds the asset URL from a validated template
+func ExpandAssetURLTemplate(template string, vars AssetURLVars) string {
+	return vars.replacer().Replace(template)
+}
+
+// ValidateAssetURLTemplate checks that the template identifies the file
+// and expands to an absolute URL
+func ValidateAssetURLTemplate(template string) error {
+	for _, placeholder := range placeholderRegex.FindAllString(template, -1) {
+		switch placeholder {
+		case "{project}", "{update}", "{key}", "{path}", "{sha256}", "{md5}":
+		default:
+			return fmt.Errorf("unknown placeholder %s", placeholder)
+		}
+	}
+
+	if !strings.Contains(template, "{path}") && !strings.Contains(template, "{key}") {
+		return errors.New("template must contain {path} or {key} placeholder")
+	}
+
+	expanded := ExpandAssetURLTemplate(template, AssetURLVars{
+		ObjectKey: "project/update/assets/file",
+		Path:      "assets/file",
+		SHA256:    "sha256",
+		MD5:       "md5",
+	})
+	parsedURL, err := url.Parse(expanded)
+	if err != nil {
+		return fmt.Errorf("template is not a valid URL: %w", err)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return errors.New("template must be an absolute http(s) URL")
+	}
+
+	return nil
+}
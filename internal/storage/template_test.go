@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAssetURLTemplate(t *testing.T) {
+	projectID, updateID := uuid.New(), uuid.New()
+	vars := AssetURLVars{
+		ProjectID: projectID,
+		UpdateID:  updateID,
+		ObjectKey: AssetObjectKey(projectID, updateID, "assets/my image.png"),
+		Path:      "assets/my image.png",
+		SHA256:    "abc",
+		MD5:       "def",
+	}
+
+	require.Equal(
+		t,
+		"https://assets.example.com/"+projectID.String()+"/"+updateID.String()+
+			"/assets/my%20image.png?v=abc",
+		ExpandAssetURLTemplate("https://assets.example.com/{project}/{update}/{path}?v={sha256}", vars),
+	)
+	require.Equal(
+		t,
+		"https://cdn.example.com/"+projectID.String()+"/"+updateID.String()+"/assets/my%20image.png",
+		ExpandAssetURLTemplate("https://cdn.example.com/{key}", vars),
+	)
+}
+
+func TestValidateAssetURLTemplate(t *testing.T) {
+	require.NoError(
+		t,
+		ValidateAssetURLTemplate("https://assets.example.com/{project}/{update}/{path}"),
+	)
+	require.NoError(t, ValidateAssetURLTemplate("http://localhost:3000/{key}?md5={md5}"))
+
+	require.ErrorContains(t, ValidateAssetURLTemplate("https://assets.example.com/{sha256}"), "{path}")
+	require.ErrorContains(t, ValidateAssetURLTemplate("https://assets.example.com/{file}"), "unknown")
+	require.Error(t, ValidateAssetURLTemplate("/assets/{path}"))
+	require.Error(t, ValidateAssetURLTemplate("ftp://assets.example.com/{path}"))
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gocloud.dev/blob/fileblob"
+)
+
+const (
+	// AssetTokenHeader carries the asset token, as a bearer token
+	AssetTokenHeader = "Authorization"
+	assetTokenPrefix = "Bearer "
+)
+
+var errInvalidAssetToken = errors.New("invalid or expired asset token")
+
+// AssetTokenSigner signs tokens granting access to all objects of an update at the local asset
+// endpoint or the edge cache. Expo clients get them in the assetRequestHeaders extension of the
+// manifest, so the asset URLs themselves don't expire and a token can outlive signed URLs.
+type AssetTokenSigner struct {
+	baseURL   *url.URL
+	secretKey []byte
+	expiry    time.Duration
+}
+
+func newAssetTokenSigner(
+	publicURL string,
+	endpointPath string,
+	secretKeyPath string,
+	expiry time.Duration,
+) (*AssetTokenSigner, error) {
+	baseURL, err := url.JoinPath(publicURL, endpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URL: %w", err)
+	}
+	burl, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	sk, err := os.ReadFile(secretKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret key file: %w", err)
+	}
+	return &AssetTokenSigner{burl, sk, expiry}, nil
+}
+
+// AssetURL returns the unsigned URL of the object, requested with the update's token
+func (s *AssetTokenSigner) AssetURL(objectKey string) string {
+	assetURL := *s.baseURL
+	assetURL.RawQuery = url.Values{"obj": {objectKey}}.Encode()
+	return assetURL.String()
+}
+
+// RequestHeaders returns the headers granting access to the objects of the update the object
+// belongs to. Tokens signed in the same download URL window expire together, so the headers of
+// cached manifests stay valid for at least the token expiry.
+func (s *AssetTokenSigner) RequestHeaders(objectKey string, now time.Time) map[string]string {
+	projectID, updateID, _ := AssetObjectKeySegments(objectKey)
+	expiresAt := now.Truncate(DownloadURLWindow).Add(DownloadURLWindow + s.expiry).Unix()
+	token := strconv.FormatInt(expiresAt, 10) + "." + s.mac(projectID, updateID, expiresAt)
+	return map[string]string{AssetTokenHeader: assetTokenPrefix + token}
+}
+
+func (s *AssetTokenSigner) mac(projectID, updateID string, expiresAt int64) string {
+	hsh := hmac.New(sha256.New, s.secretKey)
+	fmt.Fprintf(hsh, "asset-token\n%s/%s\n%d", projectID, updateID, expiresAt)
+	return base64.RawURLEncoding.EncodeToString(hsh.Sum(nil))
+}
+
+// KeyFromRequest returns the requested object key only if the request's token is authentic,
+// unexpired and signed for the update the object belongs to
+func (s *AssetTokenSigner) KeyFromRequest(req *http.Request, now time.Time) (string, error) {
+	token, ok := strings.CutPrefix(req.Header.Get(AssetTokenHeader), assetTokenPrefix)
+	if !ok {
+		return "", errInvalidAssetToken
+	}
+	expiresAtStr, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", errInvalidAssetToken
+	}
+	expiresAt, err := strconv.ParseInt(expiresAtStr, 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return "", errInvalidAssetToken
+	}
+
+	objectKey := req.URL.Query().Get("obj")
+	// a token of one update never grants access to objects outside of its prefix
+	if objectKey == "" || CleanPath(objectKey) != objectKey {
+		return "", errInvalidAssetToken
+	}
+	projectID, updateID, filePath := AssetObjectKeySegments(objectKey)
+	if filePath == "" {
+		return "", errInvalidAssetToken
+	}
+	if !hmac.Equal([]byte(mac), []byte(s.mac(projectID, updateID, expiresAt))) {
+		return "", errInvalidAssetToken
+	}
+	return objectKey, nil
+}
+
+// ObjectKeyFromRequest returns the key of the object requested with a signed URL, or with an
+// asset token when tokens are enabled
+func ObjectKeyFromRequest(
+	ctx context.Context,
+	req *http.Request,
+	urlSigner fileblob.URLSigner,
+	tokenSigner *AssetTokenSigner,
+) (string, error) {
+	if tokenSigner != nil && req.Header.Get(AssetTokenHeader) != "" {
+		return tokenSigner.KeyFromRequest(req, time.Now())
+	}
+	return urlSigner.KeyFromURL(ctx, req.URL)
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetTokenSigner(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "secret.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte("0123456789abcdef0123456789abcdef"), 0600))
+
+	signer, err := newAssetTokenSigner(
+		"https://api.example.com",
+		AssetEndpointPath,
+		keyPath,
+		24*time.Hour,
+	)
+	require.NoError(t, err)
+
+	projectID, updateID := uuid.New(), uuid.New()
+	objectKey := AssetObjectKey(projectID, updateID, "assets/a.png")
+	now := time.Date(2024, 5, 1, 12, 20, 0, 0, time.UTC)
+	headers := signer.RequestHeaders(objectKey, now)
+
+	request := func(objectKey string, headers map[string]string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, signer.AssetURL(objectKey), nil)
+		require.NoError(t, err)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		return req
+	}
+
+	t.Run("grants access to the objects of the update", func(t *testing.T) {
+		key, err := signer.KeyFromRequest(request(objectKey, headers), now)
+		require.NoError(t, err)
+		require.Equal(t, objectKey, key)
+
+		otherKey := AssetObjectKey(projectID, updateID, "bundles/ios.js")
+		key, err = signer.KeyFromRequest(request(otherKey, headers), now)
+		require.NoError(t, err)
+		require.Equal(t, otherKey, key)
+	})
+
+	t.Run("signs the same token in a window", func(t *testing.T) {
+		require.Equal(t, headers, signer.RequestHeaders(objectKey, now.Add(9*time.Minute)))
+		require.NotEqual(t, headers, signer.RequestHeaders(objectKey, now.Add(10*time.Minute)))
+	})
+
+	t.Run("expires after the window and the expiry", func(t *testing.T) {
+		expiresAt := time.Date(2024, 5, 2, 12, 30, 0, 0, time.UTC)
+		_, err := signer.KeyFromRequest(request(objectKey, headers), expiresAt)
+		require.NoError(t, err)
+		_, err = signer.KeyFromRequest(request(objectKey, headers), expiresAt.Add(time.Second))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects objects of other updates", func(t *testing.T) {
+		otherKeys := []string{
+			AssetObjectKey(projectID, uuid.New(), "assets/a.png"),
+			AssetObjectKey(uuid.New(), updateID, "assets/a.png"),
+			AssetObjectKey(projectID, updateID, "../../other/update/a.png"),
+			ArchiveObjectKey(projectID, updateID, "ios"),
+		}
+		for _, otherKey := range otherKeys {
+			_, err := signer.KeyFromRequest(request(otherKey, headers), now)
+			require.Error(t, err, otherKey)
+		}
+	})
+
+	t.Run("rejects invalid tokens", func(t *testing.T) {
+		invalidHeaders := []map[string]string{
+			nil,
+			{AssetTokenHeader: headers[AssetTokenHeader] + "x"},
+			{AssetTokenHeader: "Bearer 9999999999.invalid"},
+			{AssetTokenHeader: "Bearer invalid"},
+		}
+		for _, invalid := range invalidHeaders {
+			_, err := signer.KeyFromRequest(request(objectKey, invalid), now)
+			require.Error(t, err)
+		}
+	})
+}
@@ -41,7 +41,11 @@ func validateMaxObjectSize(fl validator.FieldLevel) bool {
 // validateAssetPath asset path is the local path of the file.
 // It's sent by the client, and is not prefixed with the project and update id
 func validateAssetPath(fl validator.FieldLevel) bool {
-	str := fl.Field().String()
+	return IsValidAssetPath(fl.Field().String())
+}
+
+// IsValidAssetPath checks if the path is relative and doesn't point outside the update directory
+func IsValidAssetPath(str string) bool {
 	dir, file := path.Split(str)
 	return !path.IsAbs(str) && !strings.Contains(dir, "..") && file != ""
 }
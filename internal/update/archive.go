@@ -0,0 +1,127 @@
+package update
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"gocloud.dev/blob"
+)
+
+const (
+	// archivePartSize is the size of the parts of archives buffered for upload, the minimal part
+	// size of S3 multipart uploads
+	archivePartSize = 5 << 20
+	// archiveCopyBufferSize is the size of the buffer the assets are copied into archives through
+	archiveCopyBufferSize = 256 << 10
+)
+
+// archiveMemory bounds the memory of building an archive: the parts of the archive buffered
+// for upload, and the buffer the assets are copied through, one asset at a time
+type archiveMemory struct {
+	partSize    int
+	concurrency int
+}
+
+// newArchiveMemory fits the buffers of an archive in the budget, parts of the archive are
+// uploaded concurrently when it fits more than one. At least one part and the copy buffer are
+// used with smaller budgets.
+func newArchiveMemory(budget int64) archiveMemory {
+	concurrency := int((budget - archiveCopyBufferSize) / archivePartSize)
+	return archiveMemory{partSize: archivePartSize, concurrency: max(concurrency, 1)}
+}
+
+// zipObject streams a zip archive to the bucket. The object is written only when the archive
+// is closed, discarding it after an error leaves no partial object behind.
+type zipObject struct {
+	cancel     context.CancelFunc
+	blobWriter *blob.Writer
+	zipWriter  *zip.Writer
+	copyBuffer []byte
+	// bucket the assets are read from
+	bucket *blob.Bucket
+}
+
+func (a *archiver) createZip(ctx context.Context, objectKey string) (*zipObject, error) {
+	writeCtx, cancel := context.WithCancel(ctx)
+	blobWriter, err := a.st.Bucket().NewWriter(writeCtx, objectKey, &blob.WriterOptions{
+		ContentType:    "application/zip",
+		BufferSize:     a.memory.partSize,
+		MaxConcurrency: a.memory.concurrency,
+	})
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create blob: %w", err)
+	}
+
+	return &zipObject{
+		cancel:     cancel,
+		blobWriter: blobWriter,
+		zipWriter:  zip.NewWriter(blobWriter),
+		copyBuffer: make([]byte, archiveCopyBufferSize),
+		bucket:     a.st.Bucket(),
+	}, nil
+}
+
+// add copies the decoded content of the asset into the archive, its readers are closed before
+// it returns, so only a single asset is read at a time
+func (z *zipObject) add(ctx context.Context, pathInZip string, asset db.UpdateAsset) error {
+	fileWriter, err := z.zipWriter.Create(pathInZip)
+	if err != nil {
+		return fmt.Errorf("failed to create file in zip: %w", err)
+	}
+
+	blobReader, err := z.bucket.NewReader(ctx, asset.StorageObjectPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read asset from storage: %w", err)
+	}
+
+	contentReader, err := decodeContent(blobReader, asset.ContentEncoding)
+	if err != nil {
+		_ = blobReader.Close()
+		return fmt.Errorf("failed to decode asset: %w", err)
+	}
+
+	// the reader is wrapped, so it's copied through the buffer instead of its own
+	_, copyErr := io.CopyBuffer(fileWriter, struct{ io.Reader }{contentReader}, z.copyBuffer)
+	decodeErr := contentReader.Close()
+	closeErr := blobReader.Close()
+	if copyErr != nil {
+		return fmt.Errorf("failed to copy asset to zip: %w", copyErr)
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to close asset decoder: %w", decodeErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close blob reader: %w", closeErr)
+	}
+	return nil
+}
+
+// create adds a file written by the caller to the archive
+func (z *zipObject) create(name string) (io.Writer, error) {
+	return z.zipWriter.Create(name)
+}
+
+// close finishes the archive and writes the object
+func (z *zipObject) close() error {
+	defer z.cancel()
+
+	if err := z.zipWriter.Close(); err != nil {
+		z.discard()
+		return fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	if err := z.blobWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close blob writer: %w", err)
+	}
+	return nil
+}
+
+// discard drops the archive without writing the object
+func (z *zipObject) discard() {
+	z.cancel()
+	_ = z.blobWriter.Close()
+}
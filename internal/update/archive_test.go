@@ -0,0 +1,121 @@
+package update
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewArchiveMemory(t *testing.T) {
+	require.Equal(t, archiveMemory{partSize: archivePartSize, concurrency: 1}, newArchiveMemory(0))
+	require.Equal(t, 3, newArchiveMemory(16<<20).concurrency)
+}
+
+func TestZipObject(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	update := db.Update{ID: uuid.Must(uuid.NewV7()), ProjectID: uuid.Must(uuid.NewV7())}
+	a := &archiver{st: st, update: update, log: zap.NewNop(), memory: newArchiveMemory(0)}
+
+	gzipped := new(bytes.Buffer)
+	gw := gzip.NewWriter(gzipped)
+	_, err := io.WriteString(gw, "bundle")
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	bundleKey := storage.AssetObjectKey(update.ProjectID, update.ID, "index.bundle.gz")
+	writeTestObject(t, ctx, st, bundleKey, gzipped.Bytes())
+	logoKey := storage.AssetObjectKey(update.ProjectID, update.ID, "assets/logo.png")
+	writeTestObject(t, ctx, st, logoKey, []byte("png"))
+
+	t.Run("streams the decoded assets", func(t *testing.T) {
+		objectKey := storage.ArchiveObjectKey(update.ProjectID, update.ID, "ios")
+		archive, err := a.createZip(ctx, objectKey)
+		require.NoError(t, err)
+		require.NoError(t, archive.add(ctx, "index.bundle", db.UpdateAsset{
+			StorageObjectPath: bundleKey,
+			ContentEncoding:   "gzip",
+		}))
+		require.NoError(t, archive.add(ctx, "assets/logo.png", db.UpdateAsset{
+			StorageObjectPath: logoKey,
+		}))
+		require.NoError(t, archive.close())
+
+		data, err := st.Bucket().ReadAll(ctx, objectKey)
+		require.NoError(t, err)
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		files := make(map[string]string)
+		for _, f := range zipReader.File {
+			reader, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			files[f.Name] = string(content)
+		}
+		require.Equal(t, map[string]string{"index.bundle": "bundle", "assets/logo.png": "png"}, files)
+	})
+
+	t.Run("discards the archive after an error", func(t *testing.T) {
+		objectKey := storage.ArchiveObjectKey(update.ProjectID, update.ID, "android")
+		archive, err := a.createZip(ctx, objectKey)
+		require.NoError(t, err)
+		require.NoError(t, archive.add(ctx, "assets/logo.png", db.UpdateAsset{
+			StorageObjectPath: logoKey,
+		}))
+		err = archive.add(ctx, "missing.png", db.UpdateAsset{StorageObjectPath: "missing"})
+		require.Error(t, err)
+		archive.discard()
+
+		exists, err := st.Bucket().Exists(ctx, objectKey)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+// BenchmarkZipObject archives 8 assets of growing sizes, the allocated bytes per archive stay
+// flat because the assets are streamed one at a time through the same buffer
+func BenchmarkZipObject(b *testing.B) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(b, ctx)
+	update := db.Update{ID: uuid.Must(uuid.NewV7()), ProjectID: uuid.Must(uuid.NewV7())}
+	a := &archiver{st: st, update: update, log: zap.NewNop(), memory: newArchiveMemory(16 << 20)}
+
+	for _, size := range []int{64 << 10, 1 << 20, 16 << 20} {
+		assets := make([]db.UpdateAsset, 0, 8)
+		for i := range 8 {
+			content := make([]byte, size)
+			_, err := rand.Read(content)
+			require.NoError(b, err)
+			key := storage.AssetObjectKey(update.ProjectID, update.ID, fmt.Sprintf("%d/%d", size, i))
+			require.NoError(b, st.Bucket().WriteAll(ctx, key, content, nil))
+			assets = append(assets, db.UpdateAsset{StorageObjectPath: key})
+		}
+
+		b.Run(fmt.Sprintf("%dKiB", size>>10), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(size * len(assets)))
+			for range b.N {
+				objectKey := storage.ArchiveObjectKey(update.ProjectID, update.ID, "ios")
+				archive, err := a.createZip(ctx, objectKey)
+				require.NoError(b, err)
+				for i, asset := range assets {
+					require.NoError(b, archive.add(ctx, fmt.Sprintf("assets/%d", i), asset))
+				}
+				require.NoError(b, archive.close())
+			}
+		})
+	}
+}
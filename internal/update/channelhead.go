@@ -0,0 +1,150 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// backfillBatchSize is the number of channels whose heads are refreshed in a single batch
+const backfillBatchSize = 100
+
+// channelKey identifies the heads of a channel, a head per platform
+type channelKey struct {
+	projectID      uuid.UUID
+	channel        string
+	runtimeVersion string
+}
+
+func updateChannelKey(update *db.Update) channelKey {
+	return channelKey{update.ProjectID, update.Channel, update.RuntimeVersion}
+}
+
+// refreshChannelHeads resolves the latest updates of the channels of the updates again. It runs
+// in the transaction of the status transitions, so it reads them, and a failure rolls them back
+// instead of leaving heads pointing to the previous updates.
+func refreshChannelHeads(ctx context.Context, qtx *db.Queries, updates ...db.Update) error {
+	refreshed := make(map[channelKey]bool)
+	for i := range updates {
+		key := updateChannelKey(&updates[i])
+		if refreshed[key] {
+			continue
+		}
+		refreshed[key] = true
+		if err := refreshChannelKey(ctx, qtx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func refreshChannelKey(ctx context.Context, q *db.Queries, key channelKey) error {
+	err := q.RefreshChannelHeads(ctx, db.RefreshChannelHeadsParams{
+		ProjectID:      key.projectID,
+		Channel:        key.channel,
+		RuntimeVersion: key.runtimeVersion,
+		Platforms:      platforms,
+	})
+	if err != nil {
+		return fmt.Errorf("RefreshChannelHeads: %w", err)
+	}
+	return nil
+}
+
+// headMatches reports whether the update of the head is still resolved like when the head was
+// refreshed, the heads are read without the full query only then
+func headMatches(update *db.Update, published bool, now time.Time) bool {
+	if len(update.Flavors) > 0 {
+		return false
+	}
+	if !published {
+		return update.Status == db.UpdateStatusCanceled ||
+			(update.Status == db.UpdateStatusPublished && IsExpired(update, now))
+	}
+	return update.Status == db.UpdateStatusPublished && !update.ExpiresAt.Valid &&
+		update.RolloutPercentage >= 100
+}
+
+// latestUpdates returns the rows of GetLatestPublishedAndCanceledUpdates. The channel head is
+// read by its primary key, the full query runs only for channels without an exact head, e.g.
+// with updates of flavors, rolled out partially or expiring later.
+func (svc *service) latestUpdates(
+	ctx context.Context,
+	params db.GetLatestPublishedAndCanceledUpdatesParams,
+) ([]db.GetLatestPublishedAndCanceledUpdatesRow, error) {
+	heads, err := svc.q.GetChannelHeadUpdates(ctx, db.GetChannelHeadUpdatesParams{
+		ProjectID:      params.ProjectID,
+		Channel:        params.Channel,
+		RuntimeVersion: params.RuntimeVersion,
+		Platform:       params.Platform,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetChannelHeadUpdates: %w", err)
+	}
+
+	rows := make([]db.GetLatestPublishedAndCanceledUpdatesRow, 0, len(heads))
+	for i := range heads {
+		// the published update comes first, a single update is published unless it's expired
+		published := i == 0 && (len(heads) == 2 ||
+			(heads[i].Update.Status == db.UpdateStatusPublished &&
+				!IsExpired(&heads[i].Update, params.Now.Time)))
+		if !headMatches(&heads[i].Update, published, params.Now.Time) {
+			rows = nil
+			break
+		}
+		rows = append(rows, db.GetLatestPublishedAndCanceledUpdatesRow(heads[i]))
+	}
+	if len(rows) > 0 {
+		return rows, nil
+	}
+
+	rows, err = svc.q.GetLatestPublishedAndCanceledUpdates(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("GetLatestPublishedAndCanceledUpdates: %w", err)
+	}
+	return rows, nil
+}
+
+// ChannelHeadBackfill refreshes the heads of the channels whose updates were published before
+// the heads were kept
+type ChannelHeadBackfill struct {
+	q *db.Queries
+}
+
+func NewChannelHeadBackfill(q *db.Queries) *ChannelHeadBackfill {
+	return &ChannelHeadBackfill{q: q}
+}
+
+// Run refreshes the channels without heads in batches until there are none, or ctx is canceled
+func (b *ChannelHeadBackfill) Run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	count := 0
+	for ctx.Err() == nil {
+		channels, err := b.q.GetChannelsWithoutHeads(ctx, backfillBatchSize)
+		if err != nil {
+			log.Error("failed to get channels without heads", zap.Error(err))
+			return
+		}
+		if len(channels) == 0 {
+			break
+		}
+		for _, channel := range channels {
+			key := channelKey{channel.ProjectID, channel.Channel, channel.RuntimeVersion}
+			if err := refreshChannelKey(ctx, b.q, key); err != nil {
+				// the channel would be returned again
+				log.Error("failed to backfill channel heads", zap.Error(err))
+				return
+			}
+		}
+		count += len(channels)
+	}
+	if count > 0 {
+		log.Info("backfilled channel heads", zap.Int("channels", count))
+	}
+}
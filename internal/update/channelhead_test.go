@@ -0,0 +1,41 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadMatches(t *testing.T) {
+	now := time.Now()
+	published := &db.Update{Status: db.UpdateStatusPublished, RolloutPercentage: 100}
+	canceled := &db.Update{Status: db.UpdateStatusCanceled, RolloutPercentage: 100}
+	expired := &db.Update{
+		Status:            db.UpdateStatusPublished,
+		RolloutPercentage: 100,
+		ExpiresAt:         pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true},
+	}
+
+	require.True(t, headMatches(published, true, now))
+	require.True(t, headMatches(canceled, false, now))
+	require.True(t, headMatches(expired, false, now))
+	// changed since the head was refreshed
+	require.False(t, headMatches(canceled, true, now))
+	require.False(t, headMatches(published, false, now))
+	require.False(t, headMatches(expired, true, now))
+
+	// resolved by the full query
+	partial := *published
+	partial.RolloutPercentage = 50
+	require.False(t, headMatches(&partial, true, now))
+	expiring := *published
+	expiring.ExpiresAt = pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}
+	require.False(t, headMatches(&expiring, true, now))
+	flavored := *canceled
+	flavored.Flavors = []string{"beta"}
+	require.False(t, headMatches(&flavored, false, now))
+}
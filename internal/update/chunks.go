@@ -0,0 +1,326 @@
+package update
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+// UploadChunkSize is the size of every chunk of a file, except the last one
+const UploadChunkSize = 5 * 1024 * 1024
+
+var (
+	ErrChunkOutOfRange       = errors.New("chunk index is out of range")
+	ErrChunkSizeMismatch     = errors.New("chunk size doesn't match the expected chunk size")
+	ErrChunkChecksumMismatch = errors.New("chunk MD5 doesn't match the Content-MD5 header")
+	ErrChunksMissing         = errors.New("some chunks have not been uploaded")
+	ErrFileChecksumMismatch  = errors.New("file MD5 doesn't match the declared MD5 hash")
+)
+
+type ChunkedUploadStatus struct {
+	Path           string
+	ChunkSize      int64
+	TotalChunks    int
+	ReceivedChunks []int
+	MissingChunks  []int
+}
+
+func chunkCount(contentLength int64) int {
+	return int((contentLength + UploadChunkSize - 1) / UploadChunkSize)
+}
+
+func chunkSize(contentLength int64, index int) int64 {
+	if index == chunkCount(contentLength)-1 {
+		return contentLength - int64(index)*UploadChunkSize
+	}
+	return UploadChunkSize
+}
+
+func (svc *service) UploadChunk(
+	ctx context.Context,
+	update db.Update,
+	filePath string,
+	index int,
+	contentMD5 string,
+	reader io.Reader,
+) error {
+	object, err := svc.DeclaredObject(ctx, update.ID, filePath)
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= chunkCount(object.ContentLength) {
+		return ErrChunkOutOfRange
+	}
+	expectedSize := chunkSize(object.ContentLength, index)
+
+	// canceling the context before closing the writer discards the partially written object
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objectKey := storage.ChunkObjectKey(update.ProjectID, update.ID, object.Path, index)
+	writer, err := svc.storage.Bucket().NewWriter(writeCtx, objectKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create chunk object: %w", err)
+	}
+
+	hash := md5.New()
+	limitedReader := io.LimitReader(reader, expectedSize+1)
+	written, err := io.Copy(writer, io.TeeReader(limitedReader, hash))
+
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to write chunk: %w", err)
+	case written != expectedSize:
+		err = ErrChunkSizeMismatch
+	case base64.StdEncoding.EncodeToString(hash.Sum(nil)) != contentMD5:
+		err = ErrChunkChecksumMismatch
+	}
+	if err != nil {
+		cancel()
+		_ = writer.Close()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close chunk writer: %w", err)
+	}
+
+	return nil
+}
+
+func (svc *service) ChunkedUploadStatus(
+	ctx context.Context,
+	update db.Update,
+	filePath string,
+) (*ChunkedUploadStatus, error) {
+	object, err := svc.DeclaredObject(ctx, update.ID, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := storage.ChunkObjectKeyPrefix(update.ProjectID, update.ID, object.Path)
+	iter := svc.storage.Bucket().List(&blob.ListOptions{Prefix: prefix})
+
+	totalChunks := chunkCount(object.ContentLength)
+	received := make([]int, 0, totalChunks)
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks: %w", err)
+		}
+
+		index, err := strconv.Atoi(strings.TrimPrefix(obj.Key, prefix))
+		if err != nil || index < 0 || index >= totalChunks {
+			continue
+		}
+		received = append(received, index)
+	}
+	slices.Sort(received)
+
+	missing := make([]int, 0)
+	for i := 0; i < totalChunks; i++ {
+		if _, found := slices.BinarySearch(received, i); !found {
+			missing = append(missing, i)
+		}
+	}
+
+	return &ChunkedUploadStatus{
+		Path:           object.Path,
+		ChunkSize:      UploadChunkSize,
+		TotalChunks:    totalChunks,
+		ReceivedChunks: received,
+		MissingChunks:  missing,
+	}, nil
+}
+
+// CompleteChunkedUpload concatenates all chunks of the file into the final object
+// and removes the chunks once the file is verified against its declared MD5 hash
+func (svc *service) CompleteChunkedUpload(
+	ctx context.Context,
+	update db.Update,
+	filePath string,
+) error {
+	status, err := svc.ChunkedUploadStatus(ctx, update, filePath)
+	if err != nil {
+		return err
+	}
+
+	if len(status.MissingChunks) > 0 {
+		return fmt.Errorf("%w: %v", ErrChunksMissing, status.MissingChunks)
+	}
+
+	object, err := svc.DeclaredObject(ctx, update.ID, filePath)
+	if err != nil {
+		return err
+	}
+
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objectKey := storage.AssetObjectKey(update.ProjectID, update.ID, object.Path)
+	writer, err := svc.storage.Bucket().NewWriter(writeCtx, objectKey, &blob.WriterOptions{
+		ContentType:     object.ContentType,
+		ContentEncoding: object.ContentEncoding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+
+	hash := md5.New()
+	dst := io.MultiWriter(writer, hash)
+	for i := 0; i < status.TotalChunks; i++ {
+		err = svc.copyChunk(ctx, dst, storage.ChunkObjectKey(update.ProjectID, update.ID, object.Path, i))
+		if err != nil {
+			cancel()
+			_ = writer.Close()
+			return err
+		}
+	}
+
+	if !strings.EqualFold(fmt.Sprintf("%x", hash.Sum(nil)), object.ContentMd5) {
+		cancel()
+		_ = writer.Close()
+		return ErrFileChecksumMismatch
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close object writer: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	for i := 0; i < status.TotalChunks; i++ {
+		chunkKey := storage.ChunkObjectKey(update.ProjectID, update.ID, object.Path, i)
+		if err := svc.storage.Bucket().Delete(ctx, chunkKey); err != nil {
+			log.Error("failed to delete chunk", zap.String("object", chunkKey), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) copyChunk(ctx context.Context, dst io.Writer, chunkKey string) error {
+	reader, err := svc.storage.Bucket().NewReader(ctx, chunkKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read chunk: %w", err)
+	}
+	defer util.CloseWithLogger(logger.FromContext(ctx), reader)
+
+	if _, err := io.Copy(dst, reader); err != nil {
+		return fmt.Errorf("failed to copy chunk: %w", err)
+	}
+
+	return nil
+}
+
+type ChunkConfig struct {
+	// StaleAfter is how long the chunks of uploads that weren't completed are kept, e.g. of
+	// abandoned updates
+	StaleAfter time.Duration `env:"CHUNK_UPLOAD_TTL,default=24h"`
+	// CleanupInterval is how often the stale chunks are deleted
+	CleanupInterval time.Duration `env:"CHUNK_CLEANUP_INTERVAL,default=1h"`
+}
+
+// ChunkCleaner periodically deletes the chunks uploaded longer than the TTL ago, clients
+// resuming such uploads see the chunks as missing and upload them again
+type ChunkCleaner struct {
+	q      *db.Queries
+	st     *storage.Storage
+	config ChunkConfig
+}
+
+func NewChunkCleaner(q *db.Queries, st *storage.Storage, config ChunkConfig) *ChunkCleaner {
+	return &ChunkCleaner{q: q, st: st, config: config}
+}
+
+// Run deletes the stale chunks every interval until ctx is canceled
+func (c *ChunkCleaner) Run(ctx context.Context) {
+	if c.config.StaleAfter <= 0 || c.config.CleanupInterval <= 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(c.config.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.DeleteStale(ctx); err != nil {
+				log.Error("failed to delete stale chunks", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DeleteStale deletes the chunks of all projects uploaded before the TTL
+func (c *ChunkCleaner) DeleteStale(ctx context.Context) error {
+	projectIDs, err := c.q.GetProjectIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("GetProjectIDs: %w", err)
+	}
+
+	cutoff := time.Now().Add(-c.config.StaleAfter)
+	var deleted int
+	for _, projectID := range projectIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		prefix := storage.ProjectChunksObjectKeyPrefix(projectID)
+		n, err := deleteChunksBefore(ctx, c.st.Bucket(), prefix, cutoff)
+		deleted += n
+		if err != nil {
+			return err
+		}
+	}
+	if deleted > 0 {
+		logger.FromContext(ctx).Info("deleted stale chunks", zap.Int("count", deleted))
+	}
+	return nil
+}
+
+// deleteChunksBefore deletes the chunks under the prefix last written before the cutoff
+func deleteChunksBefore(
+	ctx context.Context,
+	bucket *blob.Bucket,
+	prefix string,
+	cutoff time.Time,
+) (int, error) {
+	var deleted int
+	iter := bucket.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return deleted, nil
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list chunks: %w", err)
+		}
+		if !obj.ModTime.Before(cutoff) {
+			continue
+		}
+		if err := storage.DeleteObject(ctx, bucket, obj.Key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+}
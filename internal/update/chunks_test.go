@@ -0,0 +1,61 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestChunkSize(t *testing.T) {
+	require.Equal(t, 0, chunkCount(0))
+	require.Equal(t, 1, chunkCount(1))
+	require.Equal(t, 1, chunkCount(UploadChunkSize))
+	require.Equal(t, 2, chunkCount(UploadChunkSize+1))
+
+	contentLength := int64(2*UploadChunkSize + 10)
+	require.Equal(t, 3, chunkCount(contentLength))
+	require.Equal(t, int64(UploadChunkSize), chunkSize(contentLength, 0))
+	require.Equal(t, int64(UploadChunkSize), chunkSize(contentLength, 1))
+	require.Equal(t, int64(10), chunkSize(contentLength, 2))
+}
+
+func TestDeleteChunksBefore(t *testing.T) {
+	ctx := context.Background()
+	bucket := memblob.OpenBucket(nil)
+	defer bucket.Close()
+
+	projectID := uuid.New()
+	updateID := uuid.New()
+	chunkKeys := []string{
+		storage.ChunkObjectKey(projectID, updateID, "bundle.js", 0),
+		storage.ChunkObjectKey(projectID, updateID, "bundle.js", 1),
+	}
+	assetKey := storage.AssetObjectKey(projectID, updateID, "bundle.js")
+	for _, key := range append(chunkKeys, assetKey) {
+		require.NoError(t, bucket.WriteAll(ctx, key, []byte("data"), nil))
+	}
+	prefix := storage.ProjectChunksObjectKeyPrefix(projectID)
+
+	// chunks written after the cutoff are kept
+	deleted, err := deleteChunksBefore(ctx, bucket, prefix, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = deleteChunksBefore(ctx, bucket, prefix, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, len(chunkKeys), deleted)
+	for _, key := range chunkKeys {
+		exists, err := bucket.Exists(ctx, key)
+		require.NoError(t, err)
+		require.False(t, exists, key)
+	}
+	exists, err := bucket.Exists(ctx, assetKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
@@ -0,0 +1,156 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// ErrClientHashMismatch is returned when a verified file doesn't match its declared SHA256
+var ErrClientHashMismatch = errors.New("file doesn't match the SHA256 declared by the client")
+
+type ProcessingConfig struct {
+	// ClientHashVerifyRate is the fraction of the files hashed by the client read by the worker
+	// to verify the hashes, the declared hashes of the other files are trusted
+	ClientHashVerifyRate float64 `env:"CLIENT_HASH_VERIFY_RATE,default=1"`
+	// CodePushDiffBases is the number of earlier releases on the channel a CodePush update gets
+	// diff packages for, 0 disables them
+	CodePushDiffBases int `env:"CODEPUSH_DIFF_BASES,default=5"`
+	// ContentTypeCheck is off, normalize or strict. Normalize serves assets whose content
+	// contradicts the content type of their extension with the detected one, strict fails the
+	// processing of such assets.
+	ContentTypeCheck string `env:"CONTENT_TYPE_CHECK,default=off"`
+	// AllowedContentTypes is a comma separated list of media types of assets, e.g. image/*,
+	// checked unless ContentTypeCheck is off. All types are allowed when it's empty.
+	AllowedContentTypes string `env:"CONTENT_TYPE_ALLOWLIST"`
+	// ArchiveMaxEntries, ArchiveMaxUnpackedBytes and ArchiveMaxDepth limit the archives
+	// unpacked by the worker, so e.g. zip bombs fail the processing instead of filling the disk
+	// or the memory of the worker. 0 disables a limit.
+	ArchiveMaxEntries       int   `env:"ARCHIVE_MAX_ENTRIES,default=10000"`
+	ArchiveMaxUnpackedBytes int64 `env:"ARCHIVE_MAX_UNPACKED_BYTES,default=2147483648"`
+	ArchiveMaxDepth         int   `env:"ARCHIVE_MAX_DEPTH,default=32"`
+	// ArchiveMemoryBudget bounds the memory of building a CodePush package or diff package,
+	// the assets are streamed into it one at a time
+	ArchiveMemoryBudget int64 `env:"ARCHIVE_MEMORY_BUDGET_BYTES,default=16777216"`
+}
+
+func (c ProcessingConfig) validate() error {
+	if c.ArchiveMaxEntries < 0 || c.ArchiveMaxUnpackedBytes < 0 || c.ArchiveMaxDepth < 0 {
+		return fmt.Errorf("archive limits can't be negative")
+	}
+	// negated, so NaN is rejected too
+	if !(c.ClientHashVerifyRate >= 0 && c.ClientHashVerifyRate <= 1) {
+		return fmt.Errorf("client hash verify rate must be between 0 and 1")
+	}
+	switch c.ContentTypeCheck {
+	case "", ContentTypeCheckOff, ContentTypeCheckNormalize, ContentTypeCheckStrict:
+		return nil
+	}
+	return fmt.Errorf("unknown content type check %q", c.ContentTypeCheck)
+}
+
+func (c ProcessingConfig) archiveLimits() archiveLimits {
+	return archiveLimits{
+		maxEntries:       c.ArchiveMaxEntries,
+		maxUnpackedBytes: c.ArchiveMaxUnpackedBytes,
+		maxDepth:         c.ArchiveMaxDepth,
+	}
+}
+
+// declaredHashes of a file hashed by the client
+type declaredHashes struct {
+	sha256 string
+	md5    string
+}
+
+// clientHashes returns the hashes declared for the files of the storage objects by their paths,
+// files with a content encoding are skipped, the MD5 of their content isn't known
+func clientHashes(objects []db.UpdateStorageObject) map[string]declaredHashes {
+	hashes := make(map[string]declaredHashes)
+	for _, object := range objects {
+		if object.IsArchive || object.ContentEncoding != "" || !object.ContentSha256.Valid {
+			continue
+		}
+		hashes[object.Path] = declaredHashes{
+			sha256: object.ContentSha256.String,
+			md5:    object.ContentMd5,
+		}
+	}
+	return hashes
+}
+
+// trustedHashes returns the declared hashes of the file, unless it's sampled for verification
+func (p *assetParser) trustedHashes(filePath string) (declaredHashes, bool) {
+	hashes, ok := p.clientHashes[storage.CleanPath(filePath)]
+	if !ok || rand.Float64() < p.clientHashVerifyRate {
+		return declaredHashes{}, false
+	}
+	return hashes, true
+}
+
+// verifyClientHash returns ErrClientHashMismatch when the file was hashed by the client
+// and the hash doesn't match the calculated one
+func (p *assetParser) verifyClientHash(filePath string, contentSha256 string) error {
+	hashes, ok := p.clientHashes[storage.CleanPath(filePath)]
+	if ok && hashes.sha256 != contentSha256 {
+		return fmt.Errorf("%w: %s", ErrClientHashMismatch, filePath)
+	}
+	return nil
+}
+
+// parseHashed creates the asset from known hashes without reading the file, the hashes are either
+// declared by the client or calculated when the file was uploaded to the local storage
+func (p *assetParser) parseHashed(
+	ctx context.Context,
+	filePath string,
+	objectKey string,
+	meta parseAssetMeta,
+) (*db.CreateUpdateAssetsParams, bool, error) {
+	hashes, trusted := p.trustedHashes(filePath)
+	uploadHashed := p.st.Provider() == storage.ProviderLocal && p.contentEncoding(filePath) == ""
+	if !trusted && !uploadHashed {
+		return nil, false, nil
+	}
+
+	attrs, err := p.st.Bucket().Attributes(ctx, objectKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get attributes: %w", err)
+	}
+
+	if !trusted {
+		sha256Hash, md5Hash, ok := storage.ContentHashes(attrs)
+		if !ok {
+			return nil, false, nil
+		}
+		if err := p.verifyClientHash(filePath, sha256Hash); err != nil {
+			return nil, false, err
+		}
+		hashes = declaredHashes{sha256: sha256Hash, md5: md5Hash}
+	}
+
+	return &db.CreateUpdateAssetsParams{
+		ID:                uuid.Must(uuid.NewV7()),
+		UpdateID:          p.update.ID,
+		StorageObjectPath: objectKey,
+		ContentMd5:        hashes.md5,
+		ContentSha256:     hashes.sha256,
+		ContentLength:     attrs.Size,
+		Extension:         meta.extension,
+		IsLaunchAsset:     meta.isLaunchAsset,
+		Platform:          meta.platform,
+		ContentType:       meta.contentType,
+	}, true, nil
+}
+
+// isPermanentProcessingError reports errors that retrying the processing won't fix
+func isPermanentProcessingError(err error) bool {
+	var metadataErr *InvalidMetadataError
+	return errors.Is(err, storage.ErrTooManyAssets) || errors.Is(err, ErrClientHashMismatch) ||
+		errors.Is(err, ErrContentTypeMismatch) || errors.As(err, &metadataErr)
+}
@@ -0,0 +1,122 @@
+package update
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+func TestClientHashes(t *testing.T) {
+	sha := pgtype.Text{String: "abc", Valid: true}
+	hashes := clientHashes([]db.UpdateStorageObject{
+		{Path: "bundle.js", ContentMd5: "md5", ContentSha256: sha},
+		{Path: "bundle.js.gz", ContentEncoding: "gzip", ContentSha256: sha},
+		{Path: "update.zip", IsArchive: true, ContentSha256: sha},
+		{Path: "logo.png"},
+	})
+	require.Equal(t, map[string]declaredHashes{"bundle.js": {sha256: "abc", md5: "md5"}}, hashes)
+}
+
+func TestValidateClientHashVerifyRate(t *testing.T) {
+	for _, rate := range []float64{0, 0.5, 1} {
+		require.NoError(t, ProcessingConfig{ClientHashVerifyRate: rate}.validate(), rate)
+	}
+	for _, rate := range []float64{-0.1, 1.1, math.NaN(), math.Inf(1)} {
+		require.Error(t, ProcessingConfig{ClientHashVerifyRate: rate}.validate(), rate)
+	}
+}
+
+func TestParseClientHashedAsset(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	u := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+
+	content := []byte("var bundle = true;")
+	bundlePath := "_expo/static/js/ios/entry.hbc"
+	writeTestObject(t, ctx, st, storage.AssetObjectKey(u.ProjectID, u.ID, bundlePath), content)
+
+	contentSha256 := fmt.Sprintf("%x", sha256.Sum256(content))
+	contentMd5 := fmt.Sprintf("%x", md5.Sum(content))
+	meta := parseAssetMeta{extension: ".hbc", isLaunchAsset: true, platform: "ios"}
+	newParser := func(declaredSha256 string, verifyRate float64) *assetParser {
+		return &assetParser{
+			st:     st,
+			update: u,
+			log:    zap.NewNop(),
+			clientHashes: map[string]declaredHashes{
+				bundlePath: {sha256: declaredSha256, md5: contentMd5},
+			},
+			clientHashVerifyRate: verifyRate,
+		}
+	}
+
+	t.Run("declared hashes are trusted", func(t *testing.T) {
+		declaredSha256 := fmt.Sprintf("%x", sha256.Sum256([]byte("not read")))
+		asset, err := newParser(declaredSha256, 0).parse(ctx, bundlePath, meta)
+		require.NoError(t, err)
+		require.Equal(t, declaredSha256, asset.ContentSha256)
+		require.Equal(t, contentMd5, asset.ContentMd5)
+		require.Equal(t, int64(len(content)), asset.ContentLength)
+	})
+
+	t.Run("verified hashes match", func(t *testing.T) {
+		asset, err := newParser(contentSha256, 1).parse(ctx, bundlePath, meta)
+		require.NoError(t, err)
+		require.Equal(t, contentSha256, asset.ContentSha256)
+	})
+
+	t.Run("verified hashes don't match", func(t *testing.T) {
+		declaredSha256 := fmt.Sprintf("%x", sha256.Sum256([]byte("other")))
+		_, err := newParser(declaredSha256, 1).parse(ctx, bundlePath, meta)
+		require.ErrorIs(t, err, ErrClientHashMismatch)
+		require.True(t, isPermanentProcessingError(err))
+	})
+}
+
+func TestParseUploadHashedAsset(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	u := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+
+	bundlePath := "_expo/static/js/ios/entry.hbc"
+	objectKey := storage.AssetObjectKey(u.ProjectID, u.ID, bundlePath)
+	// the stored hashes are used without reading the file
+	storedSha256 := fmt.Sprintf("%x", sha256.Sum256([]byte("stored")))
+	storedMd5 := fmt.Sprintf("%x", md5.Sum([]byte("stored")))
+	err := st.Bucket().WriteAll(ctx, objectKey, []byte("var bundle = true;"), &blob.WriterOptions{
+		Metadata: map[string]string{
+			storage.MetadataContentSHA256: storedSha256,
+			storage.MetadataContentMD5:    storedMd5,
+		},
+	})
+	require.NoError(t, err)
+
+	parser := &assetParser{st: st, update: u, log: zap.NewNop()}
+	meta := parseAssetMeta{extension: ".hbc", isLaunchAsset: true, platform: "ios"}
+	asset, err := parser.parse(ctx, bundlePath, meta)
+	require.NoError(t, err)
+	require.Equal(t, storedSha256, asset.ContentSha256)
+	require.Equal(t, storedMd5, asset.ContentMd5)
+	require.Equal(t, int64(len("var bundle = true;")), asset.ContentLength)
+
+	// a declared hash still has to match the stored one when it's verified
+	parser.clientHashes = map[string]declaredHashes{
+		bundlePath: {sha256: fmt.Sprintf("%x", sha256.Sum256([]byte("other"))), md5: storedMd5},
+	}
+	parser.clientHashVerifyRate = 1
+	_, err = parser.parse(ctx, bundlePath, meta)
+	require.ErrorIs(t, err, ErrClientHashMismatch)
+}
@@ -0,0 +1,165 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// CopyLatestUpdates copies the latest published update of every channel and runtime version
+// of the project to the target project, with copies of their assets
+func (svc *service) CopyLatestUpdates(
+	ctx context.Context,
+	projectID uuid.UUID,
+	target *db.Project,
+) ([]db.Update, error) {
+	updates, err := svc.q.GetLatestPublishedUpdates(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetLatestPublishedUpdates: %w", err)
+	}
+
+	copies := make([]db.Update, 0, len(updates))
+	for _, update := range updates {
+		copied, err := svc.copyUpdate(ctx, &update, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy update %s: %w", update.ID, err)
+		}
+		copies = append(copies, *copied)
+	}
+	return copies, nil
+}
+
+func (svc *service) copyUpdate(
+	ctx context.Context,
+	update *db.Update,
+	target *db.Project,
+) (*db.Update, error) {
+	log := logger.FromContext(ctx).With(zap.String("update_id", update.ID.String()))
+	if err := svc.restoreFromColdStorage(ctx, update); err != nil {
+		return nil, err
+	}
+
+	assets, err := svc.q.GetAllUpdateAssets(ctx, update.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllUpdateAssets: %w", err)
+	}
+
+	copyID := uuid.Must(uuid.NewV7())
+	assetParams := make([]db.CreateUpdateAssetsParams, 0, len(assets))
+	objectKeys := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		objectKey := copiedObjectKey(&asset, target.ID, copyID)
+		// the projects are in the same bucket, the clone keeps the bucket of the project
+		err := svc.storage.Bucket().Copy(ctx, objectKey, asset.StorageObjectPath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", asset.StorageObjectPath, err)
+		}
+		objectKeys = append(objectKeys, objectKey)
+		assetParams = append(assetParams, db.CreateUpdateAssetsParams{
+			ID:                uuid.Must(uuid.NewV7()),
+			UpdateID:          copyID,
+			StorageObjectPath: objectKey,
+			ContentType:       asset.ContentType,
+			ContentEncoding:   asset.ContentEncoding,
+			Extension:         asset.Extension,
+			ContentMd5:        asset.ContentMd5,
+			ContentSha256:     asset.ContentSha256,
+			IsLaunchAsset:     asset.IsLaunchAsset,
+			IsArchive:         asset.IsArchive,
+			Platform:          asset.Platform,
+			ContentLength:     asset.ContentLength,
+		})
+	}
+	if len(target.ReplicaRegions) > 0 {
+		if err := replicate(ctx, svc.storage, target.ReplicaRegions, objectKeys); err != nil {
+			return nil, fmt.Errorf("failed to replicate assets: %w", err)
+		}
+	}
+
+	tx, err := svc.pgPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error("copyUpdate: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := svc.q.WithTx(tx)
+
+	// copies keep the labels of the CodePush releases, so clients report them with the same ones
+	label := update.CodepushLabel
+	if !label.Valid && target.UpdateProtocol == db.UpdateProtocolCodepush {
+		label, err = nextCodePushLabel(ctx, qtx, target.ID, update.Channel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = qtx.CreateUpdate(ctx, db.CreateUpdateParams{
+		ID:             copyID,
+		ProjectID:      target.ID,
+		RuntimeVersion: update.RuntimeVersion,
+		Message:        update.Message,
+		Channel:        update.Channel,
+		Flavors:        update.Flavors,
+		ExpiresAt:      update.ExpiresAt,
+		RolloutPercentage: pgtype.Int2{
+			Int16: update.RolloutPercentage,
+			Valid: true,
+		},
+		IsMandatory:   update.IsMandatory,
+		CodepushLabel: label,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateUpdate: %w", err)
+	}
+	err = qtx.CopyUpdateMetadata(ctx, uuid.Must(uuid.NewV7()), copyID, update.ID)
+	if err != nil {
+		return nil, fmt.Errorf("CopyUpdateMetadata: %w", err)
+	}
+	if _, err := qtx.CreateUpdateAssets(ctx, assetParams); err != nil {
+		return nil, fmt.Errorf("CreateUpdateAssets: %w", err)
+	}
+	contentHash := pgtype.Text{String: ContentHash(copyID, assetParams), Valid: true}
+	if err := qtx.SetUpdateContentHash(ctx, copyID, contentHash); err != nil {
+		return nil, fmt.Errorf("SetUpdateContentHash: %w", err)
+	}
+	if len(target.ReplicaRegions) > 0 {
+		if err := qtx.CreateUpdateReplicas(ctx, copyID, target.ReplicaRegions); err != nil {
+			return nil, fmt.Errorf("CreateUpdateReplicas: %w", err)
+		}
+	}
+	copied, err := qtx.SetUpdateStatus(ctx, copyID, db.UpdateStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("SetUpdateStatus: %w", err)
+	}
+	if err := refreshChannelHeads(ctx, qtx, copied); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Info("copied update", zap.String("copy_id", copyID.String()))
+	return &copied, nil
+}
+
+// copiedObjectKey is the key of the asset's copy in the update of the other project
+func copiedObjectKey(asset *db.UpdateAsset, projectID uuid.UUID, updateID uuid.UUID) string {
+	if asset.IsArchive {
+		return storage.ArchiveObjectKey(projectID, updateID, asset.Platform)
+	}
+	_, _, path := storage.AssetObjectKeySegments(asset.StorageObjectPath)
+	return storage.AssetObjectKey(projectID, updateID, path)
+}
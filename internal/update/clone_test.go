@@ -0,0 +1,36 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopiedObjectKey(t *testing.T) {
+	projectID, updateID := uuid.New(), uuid.New()
+	cloneID, copyID := uuid.New(), uuid.New()
+
+	asset := &db.UpdateAsset{
+		StorageObjectPath: storage.AssetObjectKey(projectID, updateID, "assets/icon.png"),
+	}
+	require.Equal(
+		t,
+		storage.AssetObjectKey(cloneID, copyID, "assets/icon.png"),
+		copiedObjectKey(asset, cloneID, copyID),
+	)
+
+	archive := &db.UpdateAsset{
+		StorageObjectPath: storage.ArchiveObjectKey(projectID, updateID, "ios"),
+		IsArchive:         true,
+		Platform:          "ios",
+	}
+	require.Equal(
+		t,
+		storage.ArchiveObjectKey(cloneID, copyID, "ios"),
+		copiedObjectKey(archive, cloneID, copyID),
+	)
+}
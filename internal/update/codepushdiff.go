@@ -0,0 +1,198 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+// diffManifestFileName marks a CodePush package as a diff, the client copies the files of the
+// release it runs except the deleted ones, then adds the files of the package
+const diffManifestFileName = "hotcodepush.json"
+
+type diffManifest struct {
+	DeletedFiles []string `json:"deletedFiles"`
+}
+
+// packageFiles returns the SHA-256 of the files of the package by their paths in the archive
+func packageFiles(assets []db.UpdateAsset, platform string) map[string]string {
+	files := make(map[string]string, len(assets))
+	for _, asset := range assets {
+		files[archivePath(asset, platform)] = asset.ContentSha256
+	}
+	return files
+}
+
+// packageDiff returns the sorted paths of the files added or changed since the base package,
+// and of the files deleted since then
+func packageDiff(files map[string]string, baseFiles map[string]string) ([]string, []string) {
+	changed := make([]string, 0)
+	for filePath, sha256 := range files {
+		if baseFiles[filePath] != sha256 {
+			changed = append(changed, filePath)
+		}
+	}
+	deleted := make([]string, 0)
+	for filePath := range baseFiles {
+		if _, ok := files[filePath]; !ok {
+			deleted = append(deleted, filePath)
+		}
+	}
+	slices.Sort(changed)
+	slices.Sort(deleted)
+	return changed, deleted
+}
+
+// diffsForPlatform makes diff packages of the archive for the latest releases published on the
+// channels of the update, so clients running them download only the changed files. Diffs that
+// aren't smaller than the archive are dropped. It returns the object keys of the diffs.
+func (a *archiver) diffsForPlatform(
+	ctx context.Context,
+	platform string,
+	archive *db.CreateUpdateAssetsParams,
+) ([]string, error) {
+	if a.diffBases <= 0 {
+		return nil, nil
+	}
+
+	bases, err := a.svc.CodePushDiffBases(
+		ctx,
+		a.update,
+		platform,
+		archive.ContentSha256,
+		a.diffBases,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earlier releases: %w", err)
+	}
+	if len(bases) == 0 {
+		return nil, nil
+	}
+
+	assets, err := a.svc.AssetsByPlatform(ctx, a.update.ID, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assets from db: %w", err)
+	}
+	files := packageFiles(assets, platform)
+	assetsByPath := make(map[string]db.UpdateAsset, len(assets))
+	for _, asset := range assets {
+		assetsByPath[archivePath(asset, platform)] = asset
+	}
+
+	objectKeys := make([]string, 0, len(bases))
+	for _, base := range bases {
+		baseAssets, err := a.svc.AssetsByPlatform(ctx, base.UpdateID, platform)
+		if err != nil {
+			return objectKeys, fmt.Errorf("failed to get assets of %s: %w", base.UpdateID, err)
+		}
+		changed, deleted := packageDiff(files, packageFiles(baseAssets, platform))
+
+		objectKey := storage.DiffArchiveObjectKey(
+			a.update.ProjectID,
+			a.update.ID,
+			platform,
+			base.PackageHash,
+		)
+		diffAssets := make([]db.UpdateAsset, 0, len(changed))
+		for _, filePath := range changed {
+			diffAssets = append(diffAssets, assetsByPath[filePath])
+		}
+		if err := a.writeDiff(ctx, objectKey, platform, diffAssets, deleted); err != nil {
+			return objectKeys, err
+		}
+
+		attrs, err := a.st.Bucket().Attributes(ctx, objectKey)
+		if err != nil {
+			return objectKeys, fmt.Errorf("failed to get attributes: %w", err)
+		}
+		if attrs.Size >= archive.ContentLength {
+			if err := a.st.Bucket().Delete(ctx, objectKey); err != nil {
+				a.log.Warn("failed to delete diff package", zap.String("key", objectKey), zap.Error(err))
+			}
+			continue
+		}
+
+		err = a.svc.CreateCodePushDiffPackage(ctx, db.CreateCodePushDiffPackageParams{
+			UpdateID:          a.update.ID,
+			Platform:          platform,
+			BasePackageHash:   base.PackageHash,
+			StorageObjectPath: objectKey,
+			ContentMd5:        fmt.Sprintf("%x", attrs.MD5),
+			ContentLength:     attrs.Size,
+		})
+		if err != nil {
+			return objectKeys, fmt.Errorf("failed to save diff package to db: %w", err)
+		}
+		objectKeys = append(objectKeys, objectKey)
+	}
+
+	a.log.Info(
+		fmt.Sprintf("made %d diff packages", len(objectKeys)),
+		zap.String("platform", platform),
+	)
+	return objectKeys, nil
+}
+
+func (a *archiver) writeDiff(
+	ctx context.Context,
+	objectKey string,
+	platform string,
+	assets []db.UpdateAsset,
+	deleted []string,
+) error {
+	archive, err := a.createZip(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		if err := archive.add(ctx, archivePath(asset, platform), asset); err != nil {
+			archive.discard()
+			return err
+		}
+	}
+
+	manifestWriter, err := archive.create(diffManifestFileName)
+	if err != nil {
+		archive.discard()
+		return fmt.Errorf("failed to create diff manifest in zip: %w", err)
+	}
+	if err := json.NewEncoder(manifestWriter).Encode(diffManifest{deleted}); err != nil {
+		archive.discard()
+		return fmt.Errorf("failed to write diff manifest: %w", err)
+	}
+
+	return archive.close()
+}
+
+// CodePushDiffBases returns the latest distinct packages published on the channels of the
+// update and its linked updates, other than the package of the update
+func (svc *service) CodePushDiffBases(
+	ctx context.Context,
+	update db.Update,
+	platform string,
+	packageHash string,
+	limit int,
+) ([]db.GetCodePushDiffBasesRow, error) {
+	return svc.q.GetCodePushDiffBases(ctx, db.GetCodePushDiffBasesParams{
+		ProjectID:      update.ProjectID,
+		RuntimeVersion: update.RuntimeVersion,
+		UpdateID:       update.ID,
+		Platform:       platform,
+		PackageHash:    packageHash,
+		RowLimit:       int32(limit),
+	})
+}
+
+func (svc *service) CreateCodePushDiffPackage(
+	ctx context.Context,
+	params db.CreateCodePushDiffPackageParams,
+) error {
+	return svc.q.CreateCodePushDiffPackage(ctx, params)
+}
@@ -0,0 +1,56 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageDiff(t *testing.T) {
+	baseFiles := map[string]string{
+		"CodePush/index.android.bundle":  "aaa",
+		"CodePush/assets/logo.png":       "bbb",
+		"CodePush/assets/removed.png":    "ccc",
+		"CodePush/assets/unchanged.json": "ddd",
+	}
+	files := map[string]string{
+		"CodePush/index.android.bundle":  "eee",
+		"CodePush/assets/logo.png":       "bbb",
+		"CodePush/assets/added.png":      "fff",
+		"CodePush/assets/unchanged.json": "ddd",
+	}
+
+	changed, deleted := packageDiff(files, baseFiles)
+	require.Equal(t, []string{"CodePush/assets/added.png", "CodePush/index.android.bundle"}, changed)
+	require.Equal(t, []string{"CodePush/assets/removed.png"}, deleted)
+
+	changed, deleted = packageDiff(files, files)
+	require.Empty(t, changed)
+	require.Empty(t, deleted)
+}
+
+func TestCalculateSHA256ForArchiveUsesArchivePaths(t *testing.T) {
+	asset := func(filePath string, encoding string) db.UpdateAsset {
+		return db.UpdateAsset{
+			StorageObjectPath: "0193a0f7-ba7d-742a-a9f6-3a14263f41f0/" +
+				"0193a0f7-ba7d-742a-a9f6-3a14263f41f1/" + filePath,
+			ContentSha256:   "aaa",
+			ContentEncoding: encoding,
+		}
+	}
+
+	// the hash of the files the client ends up with, without the platform folder and encoding
+	hash, err := calculateSHA256ForArchive(
+		[]db.UpdateAsset{asset("android/CodePush/index.android.bundle.gz", "gzip")},
+		"android",
+	)
+	require.NoError(t, err)
+	expected, err := calculateSHA256ForArchive(
+		[]db.UpdateAsset{asset("CodePush/index.android.bundle", "")},
+		"android",
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, hash)
+}
@@ -0,0 +1,32 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// nextCodePushLabel labels the next CodePush release of the channel, the releases are numbered
+// v1, v2, … like code-push-server numbers the releases of a deployment. The number is taken in
+// the transaction creating the update, so concurrent releases wait for it to commit.
+func nextCodePushLabel(
+	ctx context.Context,
+	qtx *db.Queries,
+	projectID uuid.UUID,
+	channel string,
+) (pgtype.Text, error) {
+	number, err := qtx.NextCodePushLabelNumber(ctx, projectID, channel)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("NextCodePushLabelNumber: %w", err)
+	}
+	return pgtype.Text{String: codePushLabel(number), Valid: true}, nil
+}
+
+func codePushLabel(number int32) string {
+	return "v" + strconv.Itoa(int(number))
+}
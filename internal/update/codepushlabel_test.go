@@ -0,0 +1,128 @@
+package update
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func TestCodePushLabel(t *testing.T) {
+	// explicit labels matching the pattern of NextCodePushLabelNumber are skipped by the numbering
+	pattern := regexp.MustCompile(`^v([0-9]{1,9})$`)
+	for number, want := range map[int32]string{1: "v1", 12: "v12", 999999999: "v999999999"} {
+		label := codePushLabel(number)
+		require.Equal(t, want, label)
+		require.Regexp(t, pattern, label)
+	}
+}
+
+// createLabeledUpdate creates an update of the channel with the explicit label, or with the
+// next label of the channel when it's empty, like PrepareUpdate
+func createLabeledUpdate(
+	t *testing.T,
+	ctx context.Context,
+	q *db.Queries,
+	projectID uuid.UUID,
+	channel string,
+	explicitLabel string,
+	linkedUpdateID pgtype.UUID,
+) db.Update {
+	update := db.Update{
+		ID:             uuid.Must(uuid.NewV7()),
+		ProjectID:      projectID,
+		Channel:        channel,
+		LinkedUpdateID: linkedUpdateID,
+		CodepushLabel:  pgtype.Text{String: explicitLabel, Valid: true},
+	}
+	if explicitLabel == "" {
+		var err error
+		update.CodepushLabel, err = nextCodePushLabel(ctx, q, projectID, channel)
+		require.NoError(t, err)
+	}
+	require.NoError(t, q.CreateUpdate(ctx, db.CreateUpdateParams{
+		ID:             update.ID,
+		ProjectID:      update.ProjectID,
+		RuntimeVersion: "1.0.0",
+		Message:        pgtype.Text{String: "test", Valid: true},
+		Channel:        update.Channel,
+		LinkedUpdateID: update.LinkedUpdateID,
+		CodepushLabel:  update.CodepushLabel,
+		IsMandatory:    true,
+	}))
+	return update
+}
+
+func TestNextCodePushLabelNumber(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := postgres.Run(ctx,
+		"postgres:13",
+		postgres.WithInitScripts(filepath.Join("..", "..", "db", "schema.sql")),
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("user"),
+		postgres.WithPassword("password"),
+		postgres.BasicWaitStrategies(),
+		postgres.WithSQLDriver("pgx"),
+	)
+	defer testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	dbDsn, err := ctr.ConnectionString(ctx)
+	require.NoError(t, err)
+	conn, err := pgx.Connect(ctx, dbDsn)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+	q := db.New(conn)
+
+	project, err := q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           "test_codepush",
+		UpdateProtocol: db.UpdateProtocolCodepush,
+		Environment:    "production",
+	})
+	require.NoError(t, err)
+	create := func(channel string, explicitLabel string, linkedUpdateID pgtype.UUID) db.Update {
+		return createLabeledUpdate(t, ctx, q, project.ID, channel, explicitLabel, linkedUpdateID)
+	}
+
+	t.Run("numbers the releases per channel", func(t *testing.T) {
+		require.Equal(t, "v1", create("production", "", pgtype.UUID{}).CodepushLabel.String)
+		require.Equal(t, "v2", create("production", "", pgtype.UUID{}).CodepushLabel.String)
+		require.Equal(t, "v1", create("staging", "", pgtype.UUID{}).CodepushLabel.String)
+	})
+
+	t.Run("skips explicit labels", func(t *testing.T) {
+		// imported releases keep their labels, other labels don't take a number
+		create("production", "v7", pgtype.UUID{})
+		create("production", "hotfix", pgtype.UUID{})
+		create("production", "v1234567890", pgtype.UUID{})
+		require.Equal(t, "v8", create("production", "", pgtype.UUID{}).CodepushLabel.String)
+	})
+
+	t.Run("doesn't reuse the labels of deleted updates", func(t *testing.T) {
+		latest := create("production", "", pgtype.UUID{})
+		require.Equal(t, "v9", latest.CodepushLabel.String)
+		require.NoError(t, DeleteUpdateRows(ctx, q, []uuid.UUID{latest.ID}))
+		require.Equal(t, "v10", create("production", "", pgtype.UUID{}).CodepushLabel.String)
+	})
+
+	t.Run("numbers linked updates in their channels", func(t *testing.T) {
+		update := create("production", "", pgtype.UUID{})
+		require.Equal(t, "v11", update.CodepushLabel.String)
+		linkedID := pgtype.UUID{Bytes: update.ID, Valid: true}
+		require.Equal(t, "v2", create("staging", "", linkedID).CodepushLabel.String)
+		require.Equal(t, "v1", create("beta", "", linkedID).CodepushLabel.String)
+		require.Equal(t, "v12", create("production", "", pgtype.UUID{}).CodepushLabel.String)
+	})
+}
@@ -0,0 +1,184 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+var errColdStorageDisabled = errors.New(
+	"update is in cold storage, but STORAGE_COLD_DRIVER_URL isn't set",
+)
+
+type ColdStorageConfig struct {
+	// After is the age of superseded updates moved to the cold storage bucket,
+	// they're never moved when it's not set
+	After time.Duration `env:"COLD_STORAGE_AFTER"`
+	// Interval between batches
+	Interval time.Duration `env:"COLD_STORAGE_INTERVAL,default=1h"`
+	// BatchSize is the number of updates moved in a single batch
+	BatchSize int32 `env:"COLD_STORAGE_BATCH_SIZE,default=10"`
+}
+
+// ColdStorageMover periodically moves assets of superseded updates to the cold storage bucket.
+// An update is superseded when a newer update of its channel and runtime version was published
+// and it isn't pinned, its metadata stays in the database.
+type ColdStorageMover struct {
+	q         *db.Queries
+	st        *storage.Storage
+	queueConn queue.Queue
+	config    ColdStorageConfig
+}
+
+func NewColdStorageMover(
+	q *db.Queries,
+	st *storage.Storage,
+	queueConn queue.Queue,
+	config ColdStorageConfig,
+) *ColdStorageMover {
+	return &ColdStorageMover{q: q, st: st, queueConn: queueConn, config: config}
+}
+
+// Run moves a batch of updates every interval until ctx is canceled
+func (m *ColdStorageMover) Run(ctx context.Context) {
+	if m.config.After <= 0 || m.config.Interval <= 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	if m.st.ColdBucket() == nil {
+		log.Warn("COLD_STORAGE_AFTER is set, but STORAGE_COLD_DRIVER_URL isn't")
+		return
+	}
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.MoveBatch(ctx); err != nil {
+				log.Error("failed to move updates to cold storage", zap.Error(err))
+			}
+		}
+	}
+}
+
+// MoveBatch moves the oldest superseded updates
+func (m *ColdStorageMover) MoveBatch(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	createdBefore := pgtype.Timestamptz{Time: time.Now().Add(-m.config.After), Valid: true}
+	updates, err := m.q.GetUpdatesToMoveToColdStorage(ctx, createdBefore, m.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get updates to move: %w", err)
+	}
+
+	for _, update := range updates {
+		updateLog := log.With(zap.String("update_id", update.ID.String()))
+		// marked first, so the update is restored when it's served while it's being moved
+		err := m.q.SetUpdateColdStorageAt(
+			ctx,
+			update.ID,
+			pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to mark update as moved: %w", err)
+		}
+
+		moved, err := moveUpdateObjects(ctx, m.st.ColdBucket(), m.st.Bucket(), &update)
+		// the edge caches would keep serving the moved objects, the moved ones included when
+		// the move failed halfway
+		notifyObjectsDeleted(
+			ctx,
+			m.queueConn,
+			storage.UpdateObjectKeyPrefixes(update.ProjectID, update.ID),
+		)
+		if err != nil {
+			// it stays marked, so the objects are moved back from the cold bucket when it's
+			// served again
+			updateLog.Error("failed to move update to cold storage", zap.Error(err))
+			continue
+		}
+
+		// the update could be restored while it was being moved
+		current, err := m.q.GetUpdateByID(ctx, update.ID, update.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to get moved update: %w", err)
+		}
+		if !current.ColdStorageAt.Valid {
+			_, err := moveUpdateObjects(ctx, m.st.Bucket(), m.st.ColdBucket(), &update)
+			if err != nil {
+				return fmt.Errorf("failed to restore update restored while moved: %w", err)
+			}
+			updateLog.Info("update was restored while it was moved to cold storage")
+			continue
+		}
+		updateLog.Info("moved update to cold storage", zap.Int("objects", moved))
+	}
+	return nil
+}
+
+// restoreFromColdStorage moves the assets of the update back to the primary bucket, when the
+// update was moved to cold storage. Concurrent restores of the update wait for a single one.
+func (svc *service) restoreFromColdStorage(ctx context.Context, update *db.Update) error {
+	if !update.ColdStorageAt.Valid {
+		return nil
+	}
+
+	_, err, _ := svc.restores.Do(update.ID.String(), func() (any, error) {
+		// finished even when the client that triggered it is gone
+		ctx := context.WithoutCancel(ctx)
+		cold := svc.storage.ColdBucket()
+		if cold == nil {
+			return nil, errColdStorageDisabled
+		}
+
+		moved, err := moveUpdateObjects(ctx, svc.storage.Bucket(), cold, update)
+		if err != nil {
+			return nil, err
+		}
+		err = svc.q.SetUpdateColdStorageAt(ctx, update.ID, pgtype.Timestamptz{})
+		if err != nil {
+			return nil, fmt.Errorf("SetUpdateColdStorageAt: %w", err)
+		}
+		logger.FromContext(ctx).Info(
+			"restored update from cold storage",
+			zap.String("update_id", update.ID.String()),
+			zap.Int("objects", moved),
+		)
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore update from cold storage: %w", err)
+	}
+	update.ColdStorageAt = pgtype.Timestamptz{}
+	return nil
+}
+
+func moveUpdateObjects(
+	ctx context.Context,
+	dst *blob.Bucket,
+	src *blob.Bucket,
+	update *db.Update,
+) (int, error) {
+	var moved int
+	for _, prefix := range storage.UpdateObjectKeyPrefixes(update.ProjectID, update.ID) {
+		n, err := storage.MoveObjects(ctx, dst, src, prefix)
+		moved += n
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
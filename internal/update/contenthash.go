@@ -0,0 +1,51 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ContentHash identifies the manifests of the update, it's the same as long as the update
+// serves the same assets, whatever URLs they're served from
+func ContentHash(updateID uuid.UUID, assets []db.CreateUpdateAssetsParams) string {
+	lines := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		lines = append(lines, strings.Join([]string{
+			asset.Platform,
+			asset.StorageObjectPath,
+			asset.ContentSha256,
+			asset.ContentType,
+			asset.Extension,
+			strconv.FormatBool(asset.IsLaunchAsset),
+			strconv.FormatBool(asset.IsArchive),
+		}, "\t"))
+	}
+	slices.Sort(lines)
+
+	h := sha256.New()
+	h.Write([]byte(updateID.String() + "\n"))
+	h.Write([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (svc *service) SetUpdateContentHash(
+	ctx context.Context,
+	updateID uuid.UUID,
+	contentHash string,
+) error {
+	err := svc.q.SetUpdateContentHash(ctx, updateID, pgtype.Text{String: contentHash, Valid: true})
+	if err != nil {
+		return fmt.Errorf("SetUpdateContentHash: %w", err)
+	}
+	return nil
+}
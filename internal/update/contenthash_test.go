@@ -0,0 +1,29 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentHash(t *testing.T) {
+	updateID := uuid.New()
+	assets := []db.CreateUpdateAssetsParams{
+		{Platform: "ios", StorageObjectPath: "ios/bundle.js", ContentSha256: "a", IsLaunchAsset: true},
+		{Platform: "ios", StorageObjectPath: "assets/icon.png", ContentSha256: "b"},
+	}
+	hash := ContentHash(updateID, assets)
+	assert.Len(t, hash, 64)
+
+	// the order the assets were uploaded in doesn't matter
+	reversed := []db.CreateUpdateAssetsParams{assets[1], assets[0]}
+	assert.Equal(t, hash, ContentHash(updateID, reversed))
+
+	changed := []db.CreateUpdateAssetsParams{assets[0], assets[1]}
+	changed[1].ContentSha256 = "c"
+	assert.NotEqual(t, hash, ContentHash(updateID, changed))
+	assert.NotEqual(t, hash, ContentHash(uuid.New(), assets))
+}
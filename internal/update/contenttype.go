@@ -0,0 +1,234 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/util"
+)
+
+// modes of ProcessingConfig.ContentTypeCheck
+const (
+	// ContentTypeCheckOff serves the assets with the content types of their extensions
+	ContentTypeCheckOff = "off"
+	// ContentTypeCheckNormalize serves the assets whose content contradicts the content type of
+	// their extension with the detected one
+	ContentTypeCheckNormalize = "normalize"
+	// ContentTypeCheckStrict fails the processing of such assets
+	ContentTypeCheckStrict = "strict"
+)
+
+// ErrContentTypeMismatch is returned by the strict check for assets whose content contradicts
+// their content type, or whose content type isn't allowed
+var ErrContentTypeMismatch = errors.New("asset content doesn't match its content type")
+
+// sniffLength is the number of bytes http.DetectContentType reads
+const sniffLength = 512
+
+// contentTypeAliases maps legacy and vendor names of the types of update files to the names
+// http.DetectContentType detects them with
+var contentTypeAliases = map[string]string{
+	"image/jpg":                   "image/jpeg",
+	"image/pjpeg":                 "image/jpeg",
+	"image/x-png":                 "image/png",
+	"image/x-ms-bmp":              "image/bmp",
+	"image/vnd.microsoft.icon":    "image/x-icon",
+	"application/font-sfnt":       "font/ttf",
+	"application/x-font-ttf":      "font/ttf",
+	"application/x-font-truetype": "font/ttf",
+	"application/x-font-otf":      "font/otf",
+	"application/x-font-opentype": "font/otf",
+	"application/font-woff":       "font/woff",
+	"application/x-font-woff":     "font/woff",
+	"application/font-woff2":      "font/woff2",
+	"audio/wav":                   "audio/wave",
+	"audio/x-wav":                 "audio/wave",
+	"audio/vnd.wave":              "audio/wave",
+	"audio/mp3":                   "audio/mpeg",
+	"audio/x-aiff":                "audio/aiff",
+	"audio/x-midi":                "audio/midi",
+	"video/x-msvideo":             "video/avi",
+}
+
+// compatibleTypes maps detected types to the types sharing their format, e.g. ogg audio and
+// video are both detected as application/ogg
+var compatibleTypes = map[string][]string{
+	"application/ogg":    {"audio/ogg", "video/ogg", "audio/opus"},
+	"application/x-gzip": {"application/gzip"},
+	"video/mp4":          {"audio/mp4", "audio/x-m4a", "video/quicktime"},
+	"video/webm":         {"audio/webm"},
+	"text/xml":           {"application/xml"},
+}
+
+// canonicalMediaType returns the media type of the content type under its canonical name and
+// its parameters, an empty media type when the content type is empty or invalid
+func canonicalMediaType(contentType string) (string, map[string]string) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil
+	}
+	if alias, ok := contentTypeAliases[mediaType]; ok {
+		mediaType = alias
+	}
+	return mediaType, params
+}
+
+// contradicts reports whether the content detected as the media type can't be of the declared
+// one. Plain text and binary content is detected for any format http.DetectContentType
+// doesn't know, so it contradicts nothing.
+func contradicts(declared string, detected string) bool {
+	if detected == "application/octet-stream" || detected == "text/plain" ||
+		detected == declared {
+		return false
+	}
+	if detected == "text/xml" && strings.HasSuffix(declared, "+xml") {
+		return false
+	}
+	if detected == "application/zip" && strings.HasSuffix(declared, "+zip") {
+		return false
+	}
+	return !slices.Contains(compatibleTypes[detected], declared)
+}
+
+type contentTypeChecker struct {
+	mode string
+	// allowed media types, wildcards like image/* included, all types when it's empty
+	allowed []string
+}
+
+func newContentTypeChecker(config ProcessingConfig) contentTypeChecker {
+	allowed := make([]string, 0)
+	for _, contentType := range strings.Split(config.AllowedContentTypes, ",") {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			allowed = append(allowed, strings.ToLower(contentType))
+		}
+	}
+	return contentTypeChecker{mode: config.ContentTypeCheck, allowed: allowed}
+}
+
+func (c contentTypeChecker) enabled() bool {
+	return c.mode == ContentTypeCheckNormalize || c.mode == ContentTypeCheckStrict
+}
+
+func (c contentTypeChecker) allows(mediaType string) bool {
+	if len(c.allowed) == 0 {
+		return true
+	}
+	for _, allowed := range c.allowed {
+		prefix, wildcard := strings.CutSuffix(allowed, "/*")
+		if mediaType == allowed || (wildcard && strings.HasPrefix(mediaType, prefix+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns the content type to serve the file with, from the declared content type and
+// the first sniffLength bytes of its content. Aliases are renamed, unknown types and types the
+// content contradicts are replaced by the detected one, or fail the strict check.
+func (c contentTypeChecker) check(filePath string, declared string, head []byte) (string, error) {
+	mediaType, params := canonicalMediaType(declared)
+	detected := http.DetectContentType(head)
+	detectedMediaType, _ := canonicalMediaType(detected)
+
+	contentType := mime.FormatMediaType(mediaType, params)
+	switch {
+	case mediaType == "":
+		contentType, mediaType = detected, detectedMediaType
+	case !contradicts(mediaType, detectedMediaType):
+	case c.mode == ContentTypeCheckStrict:
+		return "", fmt.Errorf(
+			"%w: %s is %s, not %s",
+			ErrContentTypeMismatch,
+			filePath,
+			detectedMediaType,
+			mediaType,
+		)
+	default:
+		contentType, mediaType = detected, detectedMediaType
+	}
+
+	if c.allows(mediaType) {
+		return contentType, nil
+	}
+	if c.mode == ContentTypeCheckStrict {
+		return "", fmt.Errorf(
+			"%w: %s is %s, which isn't allowed",
+			ErrContentTypeMismatch,
+			filePath,
+			mediaType,
+		)
+	}
+	return "application/octet-stream", nil
+}
+
+// headBuffer keeps the first sniffLength bytes written to it
+type headBuffer struct {
+	bytes.Buffer
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if n := sniffLength - b.Len(); n > 0 {
+		b.Buffer.Write(p[:min(n, len(p))])
+	}
+	return len(p), nil
+}
+
+// checkContentType sets the content type of the asset checked against the head of its content,
+// bundles keep theirs
+func (p *assetParser) checkContentType(
+	filePath string,
+	asset *db.CreateUpdateAssetsParams,
+	head []byte,
+) error {
+	if !p.contentTypes.enabled() || asset.IsLaunchAsset {
+		return nil
+	}
+
+	contentType, err := p.contentTypes.check(filePath, asset.ContentType, head)
+	if err != nil {
+		return err
+	}
+	if contentType != asset.ContentType {
+		p.report.warn(
+			"content type of %s changed from %q to %q",
+			filePath,
+			asset.ContentType,
+			contentType,
+		)
+		asset.ContentType = contentType
+	}
+	return nil
+}
+
+// checkStoredContentType works like checkContentType for assets that weren't read, it reads
+// the head of their stored object
+func (p *assetParser) checkStoredContentType(
+	ctx context.Context,
+	filePath string,
+	asset *db.CreateUpdateAssetsParams,
+) error {
+	if !p.contentTypes.enabled() || asset.IsLaunchAsset {
+		return nil
+	}
+
+	reader, err := p.st.Bucket().NewRangeReader(ctx, asset.StorageObjectPath, 0, sniffLength, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	defer util.CloseWithLogger(p.log, reader)
+
+	head, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return p.checkContentType(filePath, asset, head)
+}
@@ -0,0 +1,114 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+func TestCheckContentType(t *testing.T) {
+	normalize := contentTypeChecker{mode: ContentTypeCheckNormalize}
+	strict := contentTypeChecker{mode: ContentTypeCheckStrict}
+
+	tests := []struct {
+		name     string
+		declared string
+		head     []byte
+		expected string
+	}{
+		{"matching", "image/png", pngHeader, "image/png"},
+		{"alias", "image/x-png", pngHeader, "image/png"},
+		{"unknown format", "font/ttf", []byte{0x00, 0x01, 0x00, 0x00}, "font/ttf"},
+		{"text", "application/json", []byte(`{"v": 1}`), "application/json"},
+		{"xml", "image/svg+xml", []byte(`<?xml version="1.0"?><svg/>`), "image/svg+xml"},
+		{"no type", "", pngHeader, "image/png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, err := strict.check("assets/a", tt.declared, tt.head)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, contentType)
+		})
+	}
+
+	t.Run("contradicting content", func(t *testing.T) {
+		contentType, err := normalize.check("assets/a", "image/jpeg", pngHeader)
+		require.NoError(t, err)
+		require.Equal(t, "image/png", contentType)
+
+		_, err = strict.check("assets/a", "image/png", []byte("<html><script>"))
+		require.ErrorIs(t, err, ErrContentTypeMismatch)
+		require.ErrorContains(t, err, "assets/a is text/html, not image/png")
+	})
+
+	t.Run("allowlist", func(t *testing.T) {
+		config := ProcessingConfig{
+			ContentTypeCheck:    ContentTypeCheckNormalize,
+			AllowedContentTypes: "image/*, font/ttf",
+		}
+		checker := newContentTypeChecker(config)
+		contentType, err := checker.check("assets/a", "image/png", pngHeader)
+		require.NoError(t, err)
+		require.Equal(t, "image/png", contentType)
+		contentType, err = checker.check("assets/a", "application/pdf", []byte("%PDF-"))
+		require.NoError(t, err)
+		require.Equal(t, "application/octet-stream", contentType)
+
+		config.ContentTypeCheck = ContentTypeCheckStrict
+		_, err = newContentTypeChecker(config).check("assets/a", "application/pdf", []byte("%PDF-"))
+		require.ErrorIs(t, err, ErrContentTypeMismatch)
+	})
+
+	require.Error(t, ProcessingConfig{ContentTypeCheck: "sniff"}.validate())
+	require.NoError(t, ProcessingConfig{}.validate())
+}
+
+func TestParseAssetContentType(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	u := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+
+	assetPath := "assets/logo.jpg"
+	writeTestObject(t, ctx, st, storage.AssetObjectKey(u.ProjectID, u.ID, assetPath), pngHeader)
+	bundlePath := "_expo/static/js/ios/entry.hbc"
+	writeTestObject(t, ctx, st, storage.AssetObjectKey(u.ProjectID, u.ID, bundlePath), pngHeader)
+
+	report := newProcessingReport()
+	parser := &assetParser{
+		st:           st,
+		update:       u,
+		log:          zap.NewNop(),
+		contentTypes: contentTypeChecker{mode: ContentTypeCheckNormalize},
+		report:       report,
+	}
+
+	asset, err := parser.parse(ctx, assetPath, parseAssetMeta{
+		extension:   ".jpg",
+		contentType: "image/jpeg",
+		platform:    "ios",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "image/png", asset.ContentType)
+	require.Equal(t, []string{
+		`content type of assets/logo.jpg changed from "image/jpeg" to "image/png"`,
+	}, report.warnings)
+
+	// bundles keep their content type
+	asset, err = parser.parse(ctx, bundlePath, parseAssetMeta{
+		extension:     ".hbc",
+		isLaunchAsset: true,
+		contentType:   "application/javascript",
+		platform:      "ios",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "application/javascript", asset.ContentType)
+}
@@ -0,0 +1,106 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// sharable reports whether the declared file can share the object of an earlier update, its
+// content must be known from the declared hash, metadata.json is always uploaded
+func sharable(object api.StorageObject) bool {
+	return object.SHA256Hash != "" &&
+		object.ContentEncoding == "" &&
+		storage.CleanPath(object.Path) != MetadataFileName
+}
+
+// sharedObjectKeys returns the objects of published updates of the project the declared files
+// share by their paths, they have the same path and content, so they aren't uploaded again
+func (svc *service) sharedObjectKeys(
+	ctx context.Context,
+	projectID uuid.UUID,
+	objects []api.StorageObject,
+) (map[string]string, error) {
+	hashes := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if sharable(object) {
+			hashes = append(hashes, strings.ToLower(object.SHA256Hash))
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	assets, err := svc.q.GetSharableAssets(ctx, projectID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("GetSharableAssets: %w", err)
+	}
+	return matchSharedObjects(objects, assets), nil
+}
+
+// matchSharedObjects returns the object keys of the assets by the paths of the declared files
+// with the same path and hashes, the assets of the most recent updates are first
+func matchSharedObjects(
+	objects []api.StorageObject,
+	assets []db.GetSharableAssetsRow,
+) map[string]string {
+	assetsBySHA256 := make(map[string][]db.GetSharableAssetsRow)
+	for _, asset := range assets {
+		assetsBySHA256[asset.ContentSha256] = append(assetsBySHA256[asset.ContentSha256], asset)
+	}
+
+	shared := make(map[string]string)
+	for _, object := range objects {
+		if !sharable(object) {
+			continue
+		}
+		path := storage.CleanPath(object.Path)
+		for _, asset := range assetsBySHA256[strings.ToLower(object.SHA256Hash)] {
+			_, _, assetPath := storage.AssetObjectKeySegments(asset.StorageObjectPath)
+			if assetPath == path &&
+				strings.EqualFold(asset.ContentMd5, object.MD5Hash) &&
+				asset.ContentLength == int64(object.ContentLength) {
+				shared[path] = asset.StorageObjectPath
+				break
+			}
+		}
+	}
+	return shared
+}
+
+// sharedObjects returns the storage objects sharing the object of an earlier update by their paths
+func sharedObjects(objects []db.UpdateStorageObject) map[string]db.UpdateStorageObject {
+	shared := make(map[string]db.UpdateStorageObject)
+	for _, object := range objects {
+		if object.SharedObjectKey.Valid {
+			shared[object.Path] = object
+		}
+	}
+	return shared
+}
+
+// parseShared creates the asset of a file sharing the object of an earlier update, its hashes
+// were matched with the asset of the object when the update was prepared
+func (p *assetParser) parseShared(
+	object db.UpdateStorageObject,
+	meta parseAssetMeta,
+) *db.CreateUpdateAssetsParams {
+	return &db.CreateUpdateAssetsParams{
+		ID:                uuid.Must(uuid.NewV7()),
+		UpdateID:          p.update.ID,
+		StorageObjectPath: object.SharedObjectKey.String,
+		ContentMd5:        strings.ToLower(object.ContentMd5),
+		ContentSha256:     object.ContentSha256.String,
+		ContentLength:     object.ContentLength,
+		Extension:         meta.extension,
+		IsLaunchAsset:     meta.isLaunchAsset,
+		Platform:          meta.platform,
+		ContentType:       meta.contentType,
+	}
+}
@@ -0,0 +1,117 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/api"
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchSharedObjects(t *testing.T) {
+	projectID := uuid.New()
+	newer, older := uuid.New(), uuid.New()
+	sha := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	logo := api.StorageObject{
+		Path:          "assets/logo.png",
+		MD5Hash:       "D41D8CD98F00B204E9800998ECF8427E",
+		SHA256Hash:    sha,
+		ContentLength: 100,
+	}
+	renamed := logo
+	renamed.Path = "assets/icon.png"
+	encoded := logo
+	encoded.Path = "assets/font.ttf"
+	encoded.ContentEncoding = "gzip"
+	metadata := logo
+	metadata.Path = MetadataFileName
+	withoutHash := logo
+	withoutHash.SHA256Hash = ""
+
+	asset := func(updateID uuid.UUID, path string) db.GetSharableAssetsRow {
+		return db.GetSharableAssetsRow{
+			StorageObjectPath: storage.AssetObjectKey(projectID, updateID, path),
+			ContentMd5:        "d41d8cd98f00b204e9800998ecf8427e",
+			ContentSha256:     sha,
+			ContentLength:     100,
+		}
+	}
+	shared := matchSharedObjects(
+		[]api.StorageObject{logo, renamed, encoded, metadata, withoutHash},
+		[]db.GetSharableAssetsRow{
+			asset(newer, "assets/logo.png"),
+			asset(older, "assets/logo.png"),
+			asset(older, "assets/font.ttf"),
+			asset(older, MetadataFileName),
+		},
+	)
+	// only the file with the same path, the most recent update's object
+	require.Equal(
+		t,
+		map[string]string{"assets/logo.png": storage.AssetObjectKey(projectID, newer, "assets/logo.png")},
+		shared,
+	)
+
+	// a different length is a different file
+	logo.ContentLength = 99
+	require.Empty(t, matchSharedObjects(
+		[]api.StorageObject{logo},
+		[]db.GetSharableAssetsRow{asset(newer, "assets/logo.png")},
+	))
+}
+
+func TestParseShared(t *testing.T) {
+	update := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+	objectKey := storage.AssetObjectKey(update.ProjectID, uuid.New(), "assets/logo.png")
+	objects := sharedObjects([]db.UpdateStorageObject{
+		{
+			Path:            "assets/logo.png",
+			ContentMd5:      "D41D8CD98F00B204E9800998ECF8427E",
+			ContentSha256:   pgtype.Text{String: "abc", Valid: true},
+			ContentLength:   100,
+			SharedObjectKey: pgtype.Text{String: objectKey, Valid: true},
+		},
+		{Path: "bundle.js"},
+	})
+	require.Len(t, objects, 1)
+
+	parser := &assetParser{update: update, sharedObjects: objects}
+	asset := parser.parseShared(objects["assets/logo.png"], parseAssetMeta{
+		extension:   ".png",
+		contentType: "image/png",
+		platform:    "ios",
+	})
+	require.Equal(t, update.ID, asset.UpdateID)
+	require.Equal(t, objectKey, asset.StorageObjectPath)
+	require.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", asset.ContentMd5)
+	require.Equal(t, "abc", asset.ContentSha256)
+	require.Equal(t, int64(100), asset.ContentLength)
+	require.Equal(t, "image/png", asset.ContentType)
+}
+
+func TestNewUploadPlan(t *testing.T) {
+	objects := []api.StorageObject{
+		{Path: "metadata.json", ContentLength: 100},
+		{Path: "./assets/logo.png", ContentLength: 1000},
+		{Path: "bundles/ios.hbc", ContentLength: 5000},
+	}
+	sharedKeys := map[string]string{"assets/logo.png": "project/update/assets/logo.png"}
+
+	plan := newUploadPlan(objects, sharedKeys, 1000, db.GetProjectStorageQuotaRow{})
+	require.Equal(t, int64(5100), plan.UploadBytes)
+	require.Equal(t, int64(1000), plan.SharedBytes)
+	require.Equal(t, int64(storage.MaxUpdateTotalSizeMB*1024*1024-6100), plan.RemainingUpdateSize)
+	require.Equal(t, 997, plan.RemainingAssetCount)
+	require.Equal(t, int64(storage.MaxObjectSize), plan.MaxObjectSize)
+	require.Nil(t, plan.RemainingStorageBytes)
+
+	// the shared logo takes no new storage
+	quota := db.GetProjectStorageQuotaRow{MaxStorageBytes: 10000, StorageBytes: 4000}
+	plan = newUploadPlan(objects, sharedKeys, 1000, quota)
+	require.NotNil(t, plan.RemainingStorageBytes)
+	require.Equal(t, int64(900), *plan.RemainingStorageBytes)
+}
@@ -0,0 +1,211 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUpdateBeingProcessed = errors.New("update is being processed")
+	ErrUpdatePinned         = errors.New("update is pinned to its channel, unpin it first")
+	ErrUpdateHasLinked      = errors.New(
+		"update was published together with updates of other channels, delete them first",
+	)
+)
+
+// DeleteUpdate deletes the update with its assets and the objects no other update shares
+func (svc *service) DeleteUpdate(ctx context.Context, projectID uuid.UUID, updateID uuid.UUID) error {
+	update, err := svc.UpdateByID(ctx, projectID, updateID)
+	if err != nil {
+		if errors.Is(err, ErrUpdateNotFound) {
+			return err
+		}
+		return fmt.Errorf("UpdateByID: %w", err)
+	}
+
+	if update.Status == db.UpdateStatusPending || update.Status == db.UpdateStatusProcessing {
+		return ErrUpdateBeingProcessed
+	}
+	pinned, err := svc.q.IsUpdatePinned(ctx, updateID)
+	if err != nil {
+		return fmt.Errorf("IsUpdatePinned: %w", err)
+	}
+	if pinned {
+		return ErrUpdatePinned
+	}
+	linked, err := svc.q.GetLinkedUpdates(ctx, updateID)
+	if err != nil {
+		return fmt.Errorf("GetLinkedUpdates: %w", err)
+	}
+	if len(linked) > 0 {
+		return ErrUpdateHasLinked
+	}
+
+	if err := deleteUpdates(
+		ctx,
+		svc.q,
+		svc.pgPool,
+		svc.storage,
+		svc.queueConn,
+		[]db.Update{*update},
+	); err != nil {
+		return err
+	}
+	if update.Status == db.UpdateStatusPublished {
+		notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
+	}
+	return nil
+}
+
+// DeleteUpdateRows deletes the updates with the rows referencing them, the queries should run
+// in a transaction
+func DeleteUpdateRows(ctx context.Context, qtx *db.Queries, updateIDs []uuid.UUID) error {
+	deletes := []func(ctx context.Context, updateIDs []uuid.UUID) error{
+		qtx.DeleteIntegrityChecksOfUpdates,
+		qtx.DeleteAssetsOfUpdates,
+		qtx.DeleteMetadataOfUpdates,
+		qtx.DeleteStorageObjectsOfUpdates,
+		qtx.DeleteProcessingReportsOfUpdates,
+		qtx.DeleteOutboxEntriesOfUpdates,
+		qtx.DeleteAdoptionStatsOfUpdates,
+		qtx.DeleteReplicasOfUpdates,
+		qtx.DeleteCodePushDiffPackagesOfUpdates,
+		qtx.DeleteCodePushReleaseStatsOfUpdates,
+		qtx.DeleteDownloadStatsOfUpdates,
+		qtx.DeleteChannelPinsOfUpdates,
+		qtx.DeleteUpdates,
+	}
+	for _, deleteRows := range deletes {
+		if err := deleteRows(ctx, updateIDs); err != nil {
+			return fmt.Errorf("failed to delete updates: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteUpdates deletes the rows of the updates and refreshes the heads of their channels, then
+// deletes their objects in every bucket. Objects
+// served or shared by other updates are kept, including the objects of the deleted updates
+// stored under the prefixes of other updates.
+func deleteUpdates(
+	ctx context.Context,
+	q *db.Queries,
+	pgPool *pgxpool.Pool,
+	st *storage.Storage,
+	queueConn queue.Queue,
+	updates []db.Update,
+) error {
+	log := logger.FromContext(ctx)
+	updateIDs := make([]uuid.UUID, 0, len(updates))
+	for _, update := range updates {
+		updateIDs = append(updateIDs, update.ID)
+	}
+	servedKeys, err := q.GetObjectKeysOfUpdates(ctx, updateIDs)
+	if err != nil {
+		return fmt.Errorf("GetObjectKeysOfUpdates: %w", err)
+	}
+
+	// rows first, so the objects are no longer served or shared once they're deleted
+	tx, err := pgPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error("deleteUpdates: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := q.WithTx(tx)
+	if err := DeleteUpdateRows(ctx, qtx, updateIDs); err != nil {
+		return err
+	}
+	// the heads may point to the deleted updates, canceled ones included
+	if err := refreshChannelHeads(ctx, qtx, updates...); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// the edge caches serve the files and archives, deleted objects of other updates included
+	var purgedPrefixes []string
+	for _, update := range updates {
+		prefixes := storage.UpdateObjectKeyPrefixes(update.ProjectID, update.ID)
+		purgedPrefixes = append(purgedPrefixes, prefixes...)
+	}
+	defer func() {
+		notifyObjectsDeleted(ctx, queueConn, purgedPrefixes)
+	}()
+
+	for _, bucket := range st.Buckets() {
+		keys := slices.Clone(servedKeys)
+		for _, update := range updates {
+			prefixes := storage.DeletedUpdateObjectKeyPrefixes(update.ProjectID, update.ID)
+			for _, prefix := range prefixes {
+				prefixKeys, err := storage.ListObjectKeys(ctx, bucket, prefix)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, prefixKeys...)
+			}
+		}
+
+		unreferenced, err := unreferencedObjectKeys(ctx, q, keys)
+		if err != nil {
+			return err
+		}
+		for _, key := range unreferenced {
+			if err := storage.DeleteObject(ctx, bucket, key); err != nil {
+				return err
+			}
+			underPrefix := func(prefix string) bool { return strings.HasPrefix(key, prefix) }
+			if bucket == st.Bucket() && !slices.ContainsFunc(purgedPrefixes, underPrefix) {
+				purgedPrefixes = append(purgedPrefixes, key)
+			}
+		}
+		log.Debug("deleted objects of updates", zap.Int("count", len(unreferenced)))
+	}
+	return nil
+}
+
+// unreferencedObjectKeys returns the keys no update serves or shares
+func unreferencedObjectKeys(ctx context.Context, q *db.Queries, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	referenced, err := q.GetReferencedObjectKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("GetReferencedObjectKeys: %w", err)
+	}
+	return filterObjectKeys(keys, referenced), nil
+}
+
+// filterObjectKeys returns the unique keys that aren't excluded
+func filterObjectKeys(keys []string, excluded []string) []string {
+	skip := make(map[string]bool, len(keys))
+	for _, key := range excluded {
+		skip[key] = true
+	}
+	filtered := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !skip[key] {
+			filtered = append(filtered, key)
+			skip[key] = true
+		}
+	}
+	return filtered
+}
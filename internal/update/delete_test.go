@@ -0,0 +1,203 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"go.uber.org/zap"
+)
+
+func TestFilterObjectKeys(t *testing.T) {
+	keys := []string{"p/u1/bundle.js", "p/u1/icon.png", "p/u0/font.ttf", "p/u1/bundle.js"}
+
+	// the font is served by another update, the bundle is listed twice
+	filtered := filterObjectKeys(keys, []string{"p/u0/font.ttf"})
+	require.Equal(t, []string{"p/u1/bundle.js", "p/u1/icon.png"}, filtered)
+
+	require.Empty(t, filterObjectKeys(keys, keys))
+	require.Empty(t, filterObjectKeys(nil, nil))
+}
+
+// newBucketsStorage returns external storage with a cold storage bucket and a replica
+func newBucketsStorage(t *testing.T, ctx context.Context) *storage.Storage {
+	dir := t.TempDir()
+	driverURL := func(name string) string {
+		return fmt.Sprintf("file://%s?create_dir=true", filepath.Join(dir, name))
+	}
+	replicas, err := json.Marshal([]storage.ReplicaConfig{{
+		Region:    "eu",
+		DriverURL: driverURL("eu"),
+		Countries: []string{"DE"},
+	}})
+	require.NoError(t, err)
+	replicasFile := filepath.Join(dir, "replicas.json")
+	require.NoError(t, os.WriteFile(replicasFile, replicas, 0o600))
+
+	st, err := storage.Init(ctx, &storage.Config{
+		DriverURL:     driverURL("primary"),
+		ColdDriverURL: driverURL("cold"),
+		ReplicasFile:  replicasFile,
+	})
+	require.NoError(t, err)
+	require.Len(t, st.Buckets(), 3)
+	return st
+}
+
+// createDeletedTestUpdate creates an update of the project serving the object keys, with the
+// shared ones stored under the prefix of another update
+func createDeletedTestUpdate(
+	t *testing.T,
+	ctx context.Context,
+	q *db.Queries,
+	projectID uuid.UUID,
+	objectKeys []string,
+	sharedKeys map[string]string,
+) db.Update {
+	update := db.Update{
+		ID:             uuid.Must(uuid.NewV7()),
+		ProjectID:      projectID,
+		RuntimeVersion: "1.0.0",
+		Channel:        "production",
+	}
+	require.NoError(t, q.CreateUpdate(ctx, db.CreateUpdateParams{
+		ID:             update.ID,
+		ProjectID:      update.ProjectID,
+		RuntimeVersion: update.RuntimeVersion,
+		Message:        pgtype.Text{String: "test", Valid: true},
+		Channel:        update.Channel,
+	}))
+
+	var assets []db.CreateUpdateAssetsParams
+	var objects []db.CreateUpdateStorageObjectsParams
+	addAsset := func(filePath string, objectKey string, sharedKey pgtype.Text) {
+		assets = append(assets, db.CreateUpdateAssetsParams{
+			ID:                uuid.Must(uuid.NewV7()),
+			UpdateID:          update.ID,
+			StorageObjectPath: objectKey,
+			ContentType:       "application/octet-stream",
+			Extension:         filepath.Ext(filePath),
+			ContentMd5:        "md5-" + filePath,
+			ContentSha256:     "sha-" + filePath,
+			Platform:          "ios",
+			ContentLength:     4,
+		})
+		objects = append(objects, db.CreateUpdateStorageObjectsParams{
+			ID:              uuid.Must(uuid.NewV7()),
+			UpdateID:        update.ID,
+			Path:            filePath,
+			ContentType:     "application/octet-stream",
+			Extension:       filepath.Ext(filePath),
+			ContentMd5:      "md5-" + filePath,
+			ContentLength:   4,
+			SharedObjectKey: sharedKey,
+		})
+	}
+	for _, filePath := range objectKeys {
+		addAsset(filePath, storage.AssetObjectKey(projectID, update.ID, filePath), pgtype.Text{})
+	}
+	for filePath, sharedKey := range sharedKeys {
+		addAsset(filePath, sharedKey, pgtype.Text{String: sharedKey, Valid: true})
+	}
+	_, err := q.CreateUpdateAssets(ctx, assets)
+	require.NoError(t, err)
+	_, err = q.CreateUpdateStorageObjects(ctx, objects)
+	require.NoError(t, err)
+	return update
+}
+
+func TestDeleteUpdatesSharedObjects(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+
+	ctr, err := postgres.Run(ctx,
+		"postgres:13",
+		postgres.WithInitScripts(filepath.Join("..", "..", "db", "schema.sql")),
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("user"),
+		postgres.WithPassword("password"),
+		postgres.BasicWaitStrategies(),
+		postgres.WithSQLDriver("pgx"),
+	)
+	defer testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	dbDsn, err := ctr.ConnectionString(ctx)
+	require.NoError(t, err)
+	pgPool, err := pgxpool.New(ctx, dbDsn)
+	require.NoError(t, err)
+	defer pgPool.Close()
+	q := db.New(pgPool)
+
+	project, err := q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           "test_expo",
+		UpdateProtocol: db.UpdateProtocolExpo,
+		Environment:    "production",
+	})
+	require.NoError(t, err)
+
+	// the logo didn't change since the earlier update, the later update shares its object
+	earlier := createDeletedTestUpdate(
+		t, ctx, q, project.ID, []string{"bundles/ios.js", "assets/logo.png"}, nil,
+	)
+	sharedKey := storage.AssetObjectKey(project.ID, earlier.ID, "assets/logo.png")
+	later := createDeletedTestUpdate(
+		t, ctx, q, project.ID, []string{"bundles/ios.js"},
+		map[string]string{"assets/logo.png": sharedKey},
+	)
+
+	st := newBucketsStorage(t, ctx)
+	unsharedKeys := []string{
+		storage.AssetObjectKey(project.ID, earlier.ID, "bundles/ios.js"),
+		storage.ArchiveObjectKey(project.ID, earlier.ID, "ios"),
+	}
+	keptKeys := []string{
+		sharedKey,
+		storage.AssetObjectKey(project.ID, later.ID, "bundles/ios.js"),
+	}
+	for _, bucket := range st.Buckets() {
+		for _, key := range append(unsharedKeys, keptKeys...) {
+			require.NoError(t, bucket.WriteAll(ctx, key, []byte("data"), nil))
+		}
+	}
+
+	queueConn, err := queue.New(ctx, queue.Config{Driver: queue.DriverMemory})
+	require.NoError(t, err)
+	require.NoError(t, deleteUpdates(ctx, q, pgPool, st, queueConn, []db.Update{earlier}))
+
+	for i, bucket := range st.Buckets() {
+		for _, key := range unsharedKeys {
+			exists, err := bucket.Exists(ctx, key)
+			require.NoError(t, err)
+			require.False(t, exists, "bucket %d kept %s", i, key)
+		}
+		for _, key := range keptKeys {
+			exists, err := bucket.Exists(ctx, key)
+			require.NoError(t, err)
+			require.True(t, exists, "bucket %d deleted %s", i, key)
+		}
+	}
+
+	// the later update still serves the shared object
+	referenced, err := q.GetReferencedObjectKeys(ctx, []string{sharedKey})
+	require.NoError(t, err)
+	require.Equal(t, []string{sharedKey}, referenced)
+	_, err = q.GetUpdateByID(ctx, earlier.ID, project.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,143 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// FileDiff is a file of the update that was added, removed or changed,
+// From is nil for added files and To is nil for removed files
+type FileDiff struct {
+	Platform string
+	Path     string
+	From     *db.UpdateAsset
+	To       *db.UpdateAsset
+}
+
+// SizeDelta is the difference of the stored (possibly compressed) sizes
+func (f *FileDiff) SizeDelta() int64 {
+	var delta int64
+	if f.To != nil {
+		delta += f.To.ContentLength
+	}
+	if f.From != nil {
+		delta -= f.From.ContentLength
+	}
+	return delta
+}
+
+type Diff struct {
+	Added   []FileDiff
+	Removed []FileDiff
+	Changed []FileDiff
+}
+
+func (d *Diff) SizeDelta() int64 {
+	var delta int64
+	for _, files := range [][]FileDiff{d.Added, d.Removed, d.Changed} {
+		for _, file := range files {
+			delta += file.SizeDelta()
+		}
+	}
+	return delta
+}
+
+type assetKey struct {
+	platform string
+	path     string
+}
+
+// diffKey identifies the file across updates, the path is relative to the update
+// and without the encoding extension, so recompressing a file doesn't add and remove it
+func diffKey(asset *db.UpdateAsset) assetKey {
+	_, _, path := storage.AssetObjectKeySegments(asset.StorageObjectPath)
+	return assetKey{
+		platform: asset.Platform,
+		path:     trimEncodingExtension(path, asset.ContentEncoding),
+	}
+}
+
+// DiffAssets compares the files of two updates by their content hashes,
+// archives are skipped as they're built from the files
+func DiffAssets(from []db.UpdateAsset, to []db.UpdateAsset) *Diff {
+	fromByKey := make(map[assetKey]*db.UpdateAsset, len(from))
+	for i := range from {
+		if from[i].IsArchive {
+			continue
+		}
+		fromByKey[diffKey(&from[i])] = &from[i]
+	}
+
+	diff := &Diff{
+		Added:   make([]FileDiff, 0),
+		Removed: make([]FileDiff, 0),
+		Changed: make([]FileDiff, 0),
+	}
+	for i := range to {
+		toAsset := &to[i]
+		if toAsset.IsArchive {
+			continue
+		}
+		key := diffKey(toAsset)
+		fromAsset, ok := fromByKey[key]
+		delete(fromByKey, key)
+
+		file := FileDiff{Platform: key.platform, Path: key.path, From: fromAsset, To: toAsset}
+		if !ok {
+			diff.Added = append(diff.Added, file)
+		} else if fromAsset.ContentSha256 != toAsset.ContentSha256 {
+			diff.Changed = append(diff.Changed, file)
+		}
+	}
+	for key, fromAsset := range fromByKey {
+		diff.Removed = append(diff.Removed, FileDiff{
+			Platform: key.platform,
+			Path:     key.path,
+			From:     fromAsset,
+		})
+	}
+
+	for _, files := range [][]FileDiff{diff.Added, diff.Removed, diff.Changed} {
+		slices.SortFunc(files, func(a, b FileDiff) int {
+			if c := strings.Compare(a.Platform, b.Platform); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+	return diff
+}
+
+// DiffUpdates compares the files of two updates of the project
+func (svc *service) DiffUpdates(
+	ctx context.Context,
+	projectID uuid.UUID,
+	fromUpdateID uuid.UUID,
+	toUpdateID uuid.UUID,
+) (*Diff, error) {
+	assets := make([][]db.UpdateAsset, 0, 2)
+	for _, updateID := range []uuid.UUID{fromUpdateID, toUpdateID} {
+		if _, err := svc.UpdateByID(ctx, projectID, updateID); err != nil {
+			if errors.Is(err, ErrUpdateNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("UpdateByID: %w", err)
+		}
+
+		updateAssets, err := svc.q.GetUpdateAssets(ctx, updateID)
+		if err != nil {
+			return nil, fmt.Errorf("GetUpdateAssets: %w", err)
+		}
+		assets = append(assets, updateAssets)
+	}
+
+	return DiffAssets(assets[0], assets[1]), nil
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAssets(t *testing.T) {
+	projectID := uuid.New()
+	fromID := uuid.New()
+	toID := uuid.New()
+	asset := func(updateID uuid.UUID, path, encoding, sha256 string, size int64) db.UpdateAsset {
+		return db.UpdateAsset{
+			StorageObjectPath: storage.AssetObjectKey(projectID, updateID, path),
+			ContentEncoding:   encoding,
+			ContentSha256:     sha256,
+			Platform:          "ios",
+			ContentLength:     size,
+		}
+	}
+
+	from := []db.UpdateAsset{
+		asset(fromID, "entry.hbc", "", "bundle-v1", 100),
+		asset(fromID, "assets/logo.png", "", "logo", 10),
+		asset(fromID, "assets/removed.png", "", "removed", 5),
+		{StorageObjectPath: "archive", Platform: "ios", IsArchive: true, ContentLength: 50},
+	}
+	to := []db.UpdateAsset{
+		// recompressed, but with a new content
+		asset(toID, "entry.hbc.gz", "gzip", "bundle-v2", 40),
+		asset(toID, "assets/logo.png", "", "logo", 10),
+		asset(toID, "assets/added.png", "", "added", 20),
+	}
+
+	diff := DiffAssets(from, to)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "assets/added.png", diff.Added[0].Path)
+	require.Nil(t, diff.Added[0].From)
+	require.Equal(t, int64(20), diff.Added[0].SizeDelta())
+
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "assets/removed.png", diff.Removed[0].Path)
+	require.Nil(t, diff.Removed[0].To)
+	require.Equal(t, int64(-5), diff.Removed[0].SizeDelta())
+
+	require.Len(t, diff.Changed, 1)
+	require.Equal(t, "entry.hbc", diff.Changed[0].Path)
+	require.Equal(t, "bundle-v1", diff.Changed[0].From.ContentSha256)
+	require.Equal(t, "bundle-v2", diff.Changed[0].To.ContentSha256)
+	require.Equal(t, int64(-60), diff.Changed[0].SizeDelta())
+
+	require.Equal(t, int64(-45), diff.SizeDelta())
+}
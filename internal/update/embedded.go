@@ -0,0 +1,116 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RegisterEmbeddedUpdate records the update embedded in a store binary, registering the same
+// embedded ID again replaces it
+func (svc *service) RegisterEmbeddedUpdate(
+	ctx context.Context,
+	params db.RegisterEmbeddedUpdateParams,
+) (*db.EmbeddedUpdate, error) {
+	params.EmbeddedID = strings.ToLower(params.EmbeddedID)
+	params.LaunchAssetSha256 = strings.ToLower(params.LaunchAssetSha256)
+
+	embedded, err := svc.q.RegisterEmbeddedUpdate(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("RegisterEmbeddedUpdate: %w", err)
+	}
+	return &embedded, nil
+}
+
+func (svc *service) EmbeddedUpdates(
+	ctx context.Context,
+	projectID uuid.UUID,
+) ([]db.EmbeddedUpdate, error) {
+	return svc.q.GetEmbeddedUpdates(ctx, projectID)
+}
+
+// RollBackToEmbedded tells Expo clients of the channel to roll back to their embedded update,
+// until an update is published to the channel. Empty runtime version rolls back all of them.
+func (svc *service) RollBackToEmbedded(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	runtimeVersion string,
+) (*db.ChannelRollback, error) {
+	rollback, err := svc.q.SetChannelRollback(ctx, projectID, channel, runtimeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("SetChannelRollback: %w", err)
+	}
+	notifyChannelChanged(ctx, svc.queueConn, projectID, channel)
+	return &rollback, nil
+}
+
+// ActiveChannelRollback returns the roll back to the embedded update the clients of the channel
+// are told about, nil when an update was published to the channel after it
+func (svc *service) ActiveChannelRollback(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	runtimeVersion string,
+	flavor string,
+) (*db.ChannelRollback, error) {
+	rollback, err := svc.q.GetActiveChannelRollback(ctx, db.GetActiveChannelRollbackParams{
+		ProjectID:      projectID,
+		Channel:        channel,
+		RuntimeVersion: runtimeVersion,
+		Flavor:         flavor,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetActiveChannelRollback: %w", err)
+	}
+	return &rollback, nil
+}
+
+// EmbeddedID returns what the client reports as its current update, which is the embedded ID
+// when it runs the embedded update, empty when it reports nothing
+func (f CurrentUpdateFilter) EmbeddedID() string {
+	if f.ID != nil {
+		return f.ID.String()
+	}
+	if f.SHA256 != nil {
+		return strings.ToLower(*f.SHA256)
+	}
+	return ""
+}
+
+// matchesEmbeddedUpdate reports whether the client runs a registered embedded update with
+// the same launch asset as the update, installing it wouldn't change anything
+func (svc *service) matchesEmbeddedUpdate(
+	ctx context.Context,
+	projectID uuid.UUID,
+	runtimeVersion string,
+	platform string,
+	currentUpdate CurrentUpdateFilter,
+	update *db.Update,
+) (bool, error) {
+	embeddedID := currentUpdate.EmbeddedID()
+	if embeddedID == "" {
+		return false, nil
+	}
+
+	matches, err := svc.q.MatchesEmbeddedUpdate(ctx, db.MatchesEmbeddedUpdateParams{
+		UpdateID:       update.ID,
+		ProjectID:      projectID,
+		Platform:       platform,
+		EmbeddedID:     embeddedID,
+		RuntimeVersion: runtimeVersion,
+	})
+	if err != nil {
+		return false, fmt.Errorf("MatchesEmbeddedUpdate: %w", err)
+	}
+	return matches, nil
+}
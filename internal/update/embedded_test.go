@@ -0,0 +1,18 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentUpdateFilterEmbeddedID(t *testing.T) {
+	updateID := uuid.MustParse("0193a0f7-ba7d-742a-a9f6-3a14263f41f0")
+	require.Equal(t, updateID.String(), CurrentUpdateFilter{ID: &updateID}.EmbeddedID())
+
+	hash := "ABCDEF0123"
+	require.Equal(t, "abcdef0123", CurrentUpdateFilter{SHA256: &hash}.EmbeddedID())
+
+	require.Empty(t, CurrentUpdateFilter{}.EmbeddedID())
+}
@@ -0,0 +1,57 @@
+package update
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+type contentDecoder func(reader io.Reader) (io.ReadCloser, error)
+
+// contentDecoders decode files uploaded already compressed. Clients receive such files
+// decoded by their HTTP stack, so the hashes in manifests are calculated from decoded content
+var contentDecoders = map[string]contentDecoder{
+	"gzip": func(reader io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(reader)
+	},
+	"br": func(reader io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(brotli.NewReader(reader)), nil
+	},
+}
+
+var encodingExtensions = map[string]string{
+	"gzip": ".gz",
+	"br":   ".br",
+}
+
+// extensionContentEncoding returns the encoding of a compressed file of an archive by its
+// extension, e.g. br for entry.hbc.br. Files of archives aren't declared with their encoding.
+func extensionContentEncoding(filePath string) string {
+	for contentEncoding, extension := range encodingExtensions {
+		if strings.HasSuffix(filePath, extension) {
+			return contentEncoding
+		}
+	}
+	return ""
+}
+
+func decodeContent(reader io.Reader, contentEncoding string) (io.ReadCloser, error) {
+	if contentEncoding == "" {
+		return io.NopCloser(reader), nil
+	}
+
+	decoder, ok := contentDecoders[contentEncoding]
+	if !ok {
+		return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+
+	return decoder(reader)
+}
+
+// trimEncodingExtension strips the compression suffix, e.g. entry.hbc.gz -> entry.hbc
+func trimEncodingExtension(filePath string, contentEncoding string) string {
+	return strings.TrimSuffix(filePath, encodingExtensions[contentEncoding])
+}
@@ -0,0 +1,101 @@
+package update
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/andybalholm/brotli"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+func TestTrimEncodingExtension(t *testing.T) {
+	require.Equal(t, "entry.hbc", trimEncodingExtension("entry.hbc.gz", "gzip"))
+	require.Equal(t, "entry.hbc", trimEncodingExtension("entry.hbc", "gzip"))
+	require.Equal(t, "entry.hbc.gz", trimEncodingExtension("entry.hbc.gz", ""))
+}
+
+func TestExtensionContentEncoding(t *testing.T) {
+	require.Equal(t, "", extensionContentEncoding("_expo/static/js/ios/entry.hbc"))
+	require.Equal(t, "gzip", extensionContentEncoding("_expo/static/js/ios/entry.hbc.gz"))
+	require.Equal(t, "br", extensionContentEncoding("_expo/static/js/ios/entry.hbc.br"))
+	require.Equal(t, "entry.hbc", trimEncodingExtension("entry.hbc.br", "br"))
+}
+
+func TestParseEncodedAsset(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	u := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+
+	content := []byte("var bundle = true;")
+	compressed := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(compressed)
+	_, err := gzipWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	bundlePath := "_expo/static/js/ios/entry.hbc.gz"
+	objectKey := storage.AssetObjectKey(u.ProjectID, u.ID, bundlePath)
+
+	parser := &assetParser{
+		st:               st,
+		update:           u,
+		log:              zap.NewNop(),
+		contentEncodings: map[string]string{bundlePath: "gzip"},
+	}
+	meta := parseAssetMeta{extension: ".hbc", isLaunchAsset: true, platform: "ios"}
+
+	t.Run("object stored without encoding", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey, compressed.Bytes())
+
+		_, err := parser.parse(ctx, bundlePath, meta)
+		require.ErrorContains(t, err, "Content-Encoding")
+	})
+
+	t.Run("hashes are calculated from decoded content", func(t *testing.T) {
+		err := st.Bucket().WriteAll(ctx, objectKey, compressed.Bytes(), &blob.WriterOptions{
+			ContentEncoding: "gzip",
+		})
+		require.NoError(t, err)
+
+		asset, err := parser.parse(ctx, bundlePath, meta)
+		require.NoError(t, err)
+		require.Equal(t, "gzip", asset.ContentEncoding)
+		require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(content)), asset.ContentSha256)
+		require.Equal(t, int64(compressed.Len()), asset.ContentLength)
+	})
+
+	t.Run("brotli", func(t *testing.T) {
+		brotliCompressed := new(bytes.Buffer)
+		brotliWriter := brotli.NewWriter(brotliCompressed)
+		_, err := brotliWriter.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, brotliWriter.Close())
+
+		brotliPath := "_expo/static/js/ios/entry.hbc.br"
+		err = st.Bucket().WriteAll(
+			ctx,
+			storage.AssetObjectKey(u.ProjectID, u.ID, brotliPath),
+			brotliCompressed.Bytes(),
+			&blob.WriterOptions{ContentEncoding: "br"},
+		)
+		require.NoError(t, err)
+		parser.contentEncodings[brotliPath] = "br"
+
+		asset, err := parser.parse(ctx, brotliPath, meta)
+		require.NoError(t, err)
+		require.Equal(t, "br", asset.ContentEncoding)
+		require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(content)), asset.ContentSha256)
+		require.Equal(t, int64(brotliCompressed.Len()), asset.ContentLength)
+	})
+}
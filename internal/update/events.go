@@ -0,0 +1,56 @@
+package update
+
+import (
+	"context"
+
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// notifyChannelChanged makes the API servers invalidate the cached responses of the channel,
+// the cached responses expire anyway, so a failure is only logged
+func notifyChannelChanged(
+	ctx context.Context,
+	queueConn queue.Queue,
+	projectID uuid.UUID,
+	channel string,
+) {
+	if queueConn == nil {
+		return
+	}
+	// the channel changed already, even if the client went away
+	ctx = context.WithoutCancel(ctx)
+
+	err := queueConn.PublishChannelChangedEvent(ctx, queue.ChannelChangedEventPayload{
+		ProjectID: projectID,
+		Channel:   channel,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Warn(
+			"failed to publish channel changed event",
+			zap.String("project_id", projectID.String()),
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+	}
+}
+
+// notifyObjectsDeleted makes the edge caches purge the objects deleted from the primary bucket
+// or moved to cold storage, a failure is only logged, like a cache that missed the event the
+// edge caches keep serving the objects until they're evicted
+func notifyObjectsDeleted(ctx context.Context, queueConn queue.Queue, prefixes []string) {
+	if queueConn == nil || len(prefixes) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	err := queueConn.PublishObjectsDeletedEvent(ctx, queue.ObjectsDeletedEventPayload{
+		ObjectKeyPrefixes: prefixes,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to publish objects deleted event", zap.Error(err))
+	}
+}
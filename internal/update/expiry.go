@@ -0,0 +1,60 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IsExpired reports whether the update is treated as canceled at the time
+func IsExpired(update *db.Update, now time.Time) bool {
+	return update.ExpiresAt.Valid && !update.ExpiresAt.Time.After(now)
+}
+
+// expiresAtTimestamp converts the optional expiry, nil means the update never expires
+func expiresAtTimestamp(expiresAt *time.Time) pgtype.Timestamptz {
+	if expiresAt == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *expiresAt, Valid: true}
+}
+
+// SetUpdateExpiry makes the update treated as canceled from expiresAt on, nil removes the expiry.
+// Expiry in the past cancels the update right away.
+func (svc *service) SetUpdateExpiry(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	expiresAt *time.Time,
+) (*db.Update, error) {
+	var update db.Update
+	err := svc.inTx(ctx, "SetUpdateExpiry", func(qtx *db.Queries) error {
+		var err error
+		update, err = qtx.SetUpdateExpiresAt(ctx, expiresAtTimestamp(expiresAt), updateID, projectID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUpdateNotFound
+			}
+			return fmt.Errorf("SetUpdateExpiresAt: %w", err)
+		}
+		if update.Status != db.UpdateStatusPublished {
+			return nil
+		}
+		return refreshChannelHeads(ctx, qtx, update)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Status == db.UpdateStatusPublished {
+		notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
+	}
+	return &update, nil
+}
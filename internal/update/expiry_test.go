@@ -0,0 +1,22 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsExpired(t *testing.T) {
+	now := time.Now()
+
+	require.False(t, IsExpired(&db.Update{}, now))
+
+	update := &db.Update{ExpiresAt: pgtype.Timestamptz{Time: now.Add(time.Minute), Valid: true}}
+	require.False(t, IsExpired(update, now))
+	require.True(t, IsExpired(update, now.Add(time.Minute)))
+	require.True(t, IsExpired(update, now.Add(time.Hour)))
+}
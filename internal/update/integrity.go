@@ -0,0 +1,228 @@
+package update
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+const (
+	IntegrityStatusOK        = "ok"
+	IntegrityStatusCorrupted = "corrupted"
+	IntegrityStatusMissing   = "missing"
+)
+
+type IntegrityConfig struct {
+	// Interval between verification batches, the verification is disabled when it's not set
+	Interval time.Duration `env:"INTEGRITY_CHECK_INTERVAL"`
+	// BatchSize is the number of assets read in a single batch
+	BatchSize int32 `env:"INTEGRITY_CHECK_BATCH_SIZE,default=100"`
+	// RecheckAfter is the time after which verified assets are read again
+	RecheckAfter time.Duration `env:"INTEGRITY_RECHECK_AFTER,default=720h"`
+	// Quarantine moves corrupted objects under quarantine/, so they're no longer served
+	Quarantine bool `env:"INTEGRITY_QUARANTINE"`
+}
+
+// Verifier periodically re-reads assets of published updates and compares their hashes
+// with the ones calculated when the update was processed
+type Verifier struct {
+	q      *db.Queries
+	st     *storage.Storage
+	config IntegrityConfig
+}
+
+func NewVerifier(q *db.Queries, st *storage.Storage, config IntegrityConfig) *Verifier {
+	return &Verifier{q: q, st: st, config: config}
+}
+
+// Run verifies a batch of assets every interval until ctx is canceled
+func (v *Verifier) Run(ctx context.Context) {
+	if v.config.Interval <= 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(v.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := v.VerifyBatch(ctx); err != nil {
+				log.Error("failed to verify assets", zap.Error(err))
+			}
+		}
+	}
+}
+
+// VerifyBatch verifies the assets that were never verified or were verified the longest ago
+func (v *Verifier) VerifyBatch(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	checkedBefore := pgtype.Timestamptz{
+		Time:  time.Now().Add(-v.config.RecheckAfter),
+		Valid: true,
+	}
+	assets, err := v.q.GetAssetsToVerify(ctx, checkedBefore, v.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get assets to verify: %w", err)
+	}
+
+	var failed int
+	for _, row := range assets {
+		asset := row.UpdateAsset
+		assetLog := log.With(zap.String("object_key", asset.StorageObjectPath))
+
+		result, err := verifyAsset(ctx, v.st.Bucket(), &asset)
+		if err != nil {
+			// the object couldn't be read, it's verified again in the next batch
+			assetLog.Warn("failed to verify asset", zap.Error(err))
+			continue
+		}
+
+		params := db.SetAssetIntegrityCheckParams{
+			AssetID:    asset.ID,
+			Status:     result.status,
+			ActualHash: pgtype.Text{String: result.actualHash, Valid: result.actualHash != ""},
+			Error:      pgtype.Text{String: result.reason, Valid: result.reason != ""},
+		}
+		if result.status != IntegrityStatusOK {
+			failed++
+			assetLog.Error(
+				"asset failed integrity verification",
+				zap.String("status", result.status),
+				zap.String("reason", result.reason),
+			)
+			if v.config.Quarantine && result.status == IntegrityStatusCorrupted {
+				err := quarantineObject(ctx, v.st.Bucket(), asset.StorageObjectPath)
+				if err != nil {
+					assetLog.Error("failed to quarantine asset", zap.Error(err))
+				} else {
+					params.Quarantined = true
+				}
+			}
+		}
+
+		if err := v.q.SetAssetIntegrityCheck(ctx, params); err != nil {
+			return fmt.Errorf("failed to save integrity check: %w", err)
+		}
+	}
+
+	if len(assets) > 0 {
+		log.Info(
+			"verified assets",
+			zap.Int("assets", len(assets)),
+			zap.Int("failed", failed),
+		)
+	}
+	return nil
+}
+
+type integrityResult struct {
+	status     string
+	actualHash string
+	// reason why the asset isn't ok
+	reason string
+}
+
+// sourceReader remembers errors of the storage reader,
+// so they aren't mistaken for corrupted content by the decoder
+type sourceReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// verifyAsset hashes the stored object, an error means the object couldn't be read
+func verifyAsset(
+	ctx context.Context,
+	bucket *blob.Bucket,
+	asset *db.UpdateAsset,
+) (*integrityResult, error) {
+	blobReader, err := bucket.NewReader(ctx, asset.StorageObjectPath, nil)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return &integrityResult{
+				status: IntegrityStatusMissing,
+				reason: "object not found",
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	log := logger.FromContext(ctx)
+	defer util.CloseWithLogger(log, blobReader)
+	source := &sourceReader{reader: blobReader}
+
+	// archives are created by the server, their SHA256 is calculated from the archived assets,
+	// so the stored bytes are compared with the MD5 instead
+	var expectedHash string
+	var hasher hash.Hash
+	var contentReader io.Reader
+	if asset.IsArchive {
+		expectedHash = asset.ContentMd5
+		hasher = md5.New()
+		contentReader = source
+	} else {
+		expectedHash = asset.ContentSha256
+		hasher = sha256.New()
+		decoder, err := decodeContent(source, asset.ContentEncoding)
+		if err != nil {
+			if source.err != nil {
+				return nil, fmt.Errorf("failed to read object: %w", source.err)
+			}
+			return &integrityResult{status: IntegrityStatusCorrupted, reason: err.Error()}, nil
+		}
+		defer util.CloseWithLogger(log, decoder)
+		contentReader = decoder
+	}
+
+	if _, err := io.Copy(hasher, contentReader); err != nil {
+		if source.err != nil {
+			return nil, fmt.Errorf("failed to read object: %w", source.err)
+		}
+		return &integrityResult{status: IntegrityStatusCorrupted, reason: err.Error()}, nil
+	}
+
+	actualHash := fmt.Sprintf("%x", hasher.Sum(nil))
+	// the MD5 of archives is missing when the storage doesn't report it
+	if expectedHash != "" && actualHash != expectedHash {
+		return &integrityResult{
+			status:     IntegrityStatusCorrupted,
+			actualHash: actualHash,
+			reason:     fmt.Sprintf("expected hash %s", expectedHash),
+		}, nil
+	}
+	return &integrityResult{status: IntegrityStatusOK, actualHash: actualHash}, nil
+}
+
+func quarantineObject(ctx context.Context, bucket *blob.Bucket, objectKey string) error {
+	err := bucket.Copy(ctx, storage.QuarantineObjectKey(objectKey), objectKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to copy object: %w", err)
+	}
+	if err := bucket.Delete(ctx, objectKey); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
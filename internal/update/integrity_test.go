@@ -0,0 +1,111 @@
+package update
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestVerifyAsset(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+
+	content := []byte("console.log('bundle')")
+	contentSha256 := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	gzipped := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(gzipped)
+	_, err := gzipWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	writeTestObject(t, ctx, st, "ok", content)
+	writeTestObject(t, ctx, st, "ok.gz", gzipped.Bytes())
+	writeTestObject(t, ctx, st, "modified", []byte("console.log('modified')"))
+	writeTestObject(t, ctx, st, "truncated.gz", gzipped.Bytes()[:gzipped.Len()/2])
+	writeTestObject(t, ctx, st, "archive.zip", content)
+
+	tests := []struct {
+		name   string
+		asset  db.UpdateAsset
+		status string
+	}{
+		{
+			name:   "ok",
+			asset:  db.UpdateAsset{StorageObjectPath: "ok", ContentSha256: contentSha256},
+			status: IntegrityStatusOK,
+		},
+		{
+			name: "decoded content",
+			asset: db.UpdateAsset{
+				StorageObjectPath: "ok.gz",
+				ContentSha256:     contentSha256,
+				ContentEncoding:   "gzip",
+			},
+			status: IntegrityStatusOK,
+		},
+		{
+			name:   "modified",
+			asset:  db.UpdateAsset{StorageObjectPath: "modified", ContentSha256: contentSha256},
+			status: IntegrityStatusCorrupted,
+		},
+		{
+			name: "truncated",
+			asset: db.UpdateAsset{
+				StorageObjectPath: "truncated.gz",
+				ContentSha256:     contentSha256,
+				ContentEncoding:   "gzip",
+			},
+			status: IntegrityStatusCorrupted,
+		},
+		{
+			name: "archive",
+			asset: db.UpdateAsset{
+				StorageObjectPath: "archive.zip",
+				ContentMd5:        fmt.Sprintf("%x", md5.Sum(content)),
+				ContentSha256:     "sha256 of the archived assets",
+				IsArchive:         true,
+			},
+			status: IntegrityStatusOK,
+		},
+		{
+			name:   "missing",
+			asset:  db.UpdateAsset{StorageObjectPath: "missing", ContentSha256: contentSha256},
+			status: IntegrityStatusMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := verifyAsset(ctx, st.Bucket(), &tt.asset)
+			require.NoError(t, err)
+			require.Equal(t, tt.status, result.status, result.reason)
+		})
+	}
+}
+
+func TestQuarantineObject(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	writeTestObject(t, ctx, st, "project/update/bundle.js", []byte("bundle"))
+
+	require.NoError(t, quarantineObject(ctx, st.Bucket(), "project/update/bundle.js"))
+
+	exists, err := st.Bucket().Exists(ctx, "project/update/bundle.js")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	data, err := st.Bucket().ReadAll(ctx, storage.QuarantineObjectKey("project/update/bundle.js"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("bundle"), data)
+}
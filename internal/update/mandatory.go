@@ -0,0 +1,35 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetUpdateMandatory changes whether CodePush clients install the update right away, the
+// published update is served with the change without publishing it again
+func (svc *service) SetUpdateMandatory(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	mandatory bool,
+) (*db.Update, error) {
+	update, err := svc.q.SetUpdateIsMandatory(ctx, mandatory, updateID, projectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUpdateNotFound
+		}
+		return nil, fmt.Errorf("SetUpdateIsMandatory: %w", err)
+	}
+
+	// the responses of clients skipping the update change too, not only the ones getting it
+	if update.Status == db.UpdateStatusPublished {
+		notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
+	}
+	return &update, nil
+}
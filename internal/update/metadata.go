@@ -1,12 +1,40 @@
 package update
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"slices"
+
+	"github.com/a-gierczak/paratrooper/internal/storage"
 
 	"github.com/gin-gonic/gin/binding"
 )
 
+// MetadataFileName is the name of the metadata file uploaded alongside the update files
+const MetadataFileName = "metadata.json"
+
+// MaxMetadataSize is the size limit of metadata.json, which is read into memory, metadata.json
+// of an update with the maximum number of files is a fraction of it
+const MaxMetadataSize = 4 << 20
+
+var ErrMetadataTooLarge = fmt.Errorf("%s is larger than %d bytes", MetadataFileName, MaxMetadataSize)
+
+// InvalidMetadataError is returned when metadata.json is too large, isn't valid JSON or doesn't
+// pass the validation, the update can't be processed without fixing it
+type InvalidMetadataError struct {
+	Err error
+}
+
+func (e *InvalidMetadataError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", MetadataFileName, e.Err)
+}
+
+func (e *InvalidMetadataError) Unwrap() error {
+	return e.Err
+}
+
 type Metadata struct {
 	Version      int                     `json:"version"`
 	Bundler      string                  `json:"bundler"`
@@ -23,16 +51,121 @@ type FileMetadataAsset struct {
 	Ext  string `json:"ext"  binding:"required,asset_ext,max=16"`
 }
 
+// ParseMetadata reads at most MaxMetadataSize bytes, returning ErrMetadataTooLarge for larger
+// files. Files that can't be parsed or don't pass the validation are an InvalidMetadataError.
 func ParseMetadata(r io.Reader) (*Metadata, error) {
-	var metadata Metadata
-	if err := json.NewDecoder(r).Decode(&metadata); err != nil {
+	data, err := io.ReadAll(io.LimitReader(r, MaxMetadataSize+1))
+	if err != nil {
 		return nil, err
 	}
+	if len(data) > MaxMetadataSize {
+		return nil, &InvalidMetadataError{Err: ErrMetadataTooLarge}
+	}
+
+	var metadata Metadata
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&metadata); err != nil {
+		return nil, &InvalidMetadataError{Err: err}
+	}
 
-	err := binding.Validator.ValidateStruct(&metadata)
+	err = binding.Validator.ValidateStruct(&metadata)
 	if err != nil {
-		return nil, err
+		return nil, &InvalidMetadataError{Err: err}
 	}
 
 	return &metadata, nil
 }
+
+// ReferencedPaths returns the cleaned paths of the bundles and assets
+// referenced by the given platforms, or by all platforms if none are given
+func (m *Metadata) ReferencedPaths(platforms ...string) []string {
+	paths := make([]string, 0)
+	for platform, platformMeta := range m.FileMetadata {
+		if len(platforms) > 0 && !slices.Contains(platforms, platform) {
+			continue
+		}
+
+		paths = append(paths, storage.CleanPath(platformMeta.Bundle))
+		for _, asset := range platformMeta.Assets {
+			paths = append(paths, storage.CleanPath(asset.Path))
+		}
+	}
+	return paths
+}
+
+// CheckAssetCount returns storage.ErrTooManyAssets when the referenced files and metadata.json
+// are more than maxAssetCount
+func (m *Metadata) CheckAssetCount(maxAssetCount int) error {
+	paths := m.ReferencedPaths()
+	slices.Sort(paths)
+	count := len(slices.Compact(paths)) + 1
+	if count > maxAssetCount {
+		return fmt.Errorf(
+			"%w, max is %d, got %d",
+			storage.ErrTooManyAssets,
+			maxAssetCount,
+			count,
+		)
+	}
+	return nil
+}
+
+// FilesMismatchError is returned when files referenced in metadata.json
+// don't match the files declared when the update was prepared, or the declared
+// files weren't uploaded intact
+type FilesMismatchError struct {
+	Missing []string
+	Extra   []string
+	// Corrupt files were uploaded with another size or MD5 than declared
+	Corrupt []string
+}
+
+func (e *FilesMismatchError) Error() string {
+	if len(e.Corrupt) > 0 {
+		return fmt.Sprintf(
+			"update files don't match the declared ones (%d missing, %d corrupt)",
+			len(e.Missing),
+			len(e.Corrupt),
+		)
+	}
+	return fmt.Sprintf(
+		"update files don't match metadata.json (%d missing, %d extra)",
+		len(e.Missing),
+		len(e.Extra),
+	)
+}
+
+// diffDeclaredFiles compares the declared file paths against the metadata.
+// Files required by the worker that weren't declared are reported as missing,
+// declared files which aren't referenced by any platform are reported as extra.
+func diffDeclaredFiles(meta *Metadata, declared []string) (missing, extra []string) {
+	declaredSet := make(map[string]struct{}, len(declared))
+	for _, p := range declared {
+		declaredSet[storage.CleanPath(p)] = struct{}{}
+	}
+
+	missing = make([]string, 0)
+	for _, p := range meta.ReferencedPaths(platforms...) {
+		if _, ok := declaredSet[p]; !ok && !slices.Contains(missing, p) {
+			missing = append(missing, p)
+		}
+	}
+
+	referencedSet := make(map[string]struct{})
+	for _, p := range meta.ReferencedPaths() {
+		referencedSet[p] = struct{}{}
+	}
+
+	extra = make([]string, 0)
+	for p := range declaredSet {
+		if p == MetadataFileName {
+			continue
+		}
+		if _, ok := referencedSet[p]; !ok {
+			extra = append(extra, p)
+		}
+	}
+
+	slices.Sort(missing)
+	slices.Sort(extra)
+	return missing, extra
+}
@@ -0,0 +1,113 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDeclaredFiles(t *testing.T) {
+	meta := &Metadata{
+		FileMetadata: map[string]FileMetadata{
+			"ios": {
+				Bundle: "_expo/static/js/ios/entry.hbc",
+				Assets: []FileMetadataAsset{
+					{Path: "assets/a1b2c3", Ext: ".png"},
+				},
+			},
+			"android": {
+				Bundle: "_expo/static/js/android/entry.hbc",
+				Assets: []FileMetadataAsset{
+					{Path: "./assets/a1b2c3", Ext: ".png"},
+				},
+			},
+		},
+	}
+
+	t.Run("matching files", func(t *testing.T) {
+		missing, extra := diffDeclaredFiles(meta, []string{
+			"metadata.json",
+			"_expo/static/js/ios/entry.hbc",
+			"_expo/static/js/android/entry.hbc",
+			"./assets/a1b2c3",
+		})
+		require.Empty(t, missing)
+		require.Empty(t, extra)
+	})
+
+	t.Run("missing and extra files", func(t *testing.T) {
+		missing, extra := diffDeclaredFiles(meta, []string{
+			"metadata.json",
+			"_expo/static/js/ios/entry.hbc",
+			"assets/a1b2c3",
+			"assets/unused",
+		})
+		require.Equal(t, []string{"_expo/static/js/android/entry.hbc"}, missing)
+		require.Equal(t, []string{"assets/unused"}, extra)
+	})
+
+	t.Run("files of unsupported platforms are not required", func(t *testing.T) {
+		webMeta := &Metadata{
+			FileMetadata: map[string]FileMetadata{
+				"web": {Bundle: "_expo/static/js/web/entry.js"},
+			},
+		}
+		missing, extra := diffDeclaredFiles(webMeta, []string{"_expo/static/js/web/entry.js"})
+		require.Empty(t, missing)
+		require.Empty(t, extra)
+	})
+
+	t.Run("files of windows and macos are required", func(t *testing.T) {
+		desktopMeta := &Metadata{
+			FileMetadata: map[string]FileMetadata{
+				"windows": {Bundle: "windows/index.windows.bundle"},
+				"macos":   {Bundle: "macos/main.jsbundle"},
+			},
+		}
+		missing, extra := diffDeclaredFiles(desktopMeta, []string{"windows/index.windows.bundle"})
+		require.Equal(t, []string{"macos/main.jsbundle"}, missing)
+		require.Empty(t, extra)
+	})
+}
+
+func TestCheckAssetCount(t *testing.T) {
+	meta := &Metadata{
+		FileMetadata: map[string]FileMetadata{
+			"ios": {
+				Bundle: "ios/entry.hbc",
+				Assets: []FileMetadataAsset{{Path: "assets/a1b2c3", Ext: ".png"}},
+			},
+			"android": {
+				Bundle: "android/entry.hbc",
+				Assets: []FileMetadataAsset{{Path: "./assets/a1b2c3", Ext: ".png"}},
+			},
+		},
+	}
+
+	// 2 bundles, 1 asset shared by the platforms and metadata.json
+	require.NoError(t, meta.CheckAssetCount(4))
+	require.ErrorIs(t, meta.CheckAssetCount(3), storage.ErrTooManyAssets)
+}
+
+func TestParseMetadataTooLarge(t *testing.T) {
+	padded := `{"version": 0, "bundler": "metro"}` + strings.Repeat(" ", MaxMetadataSize)
+	_, err := ParseMetadata(strings.NewReader(padded))
+	require.ErrorIs(t, err, ErrMetadataTooLarge)
+}
+
+func TestParseMetadataInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"malformed":      `{"version": 0, "fileMetadata": {`,
+		"wrong type":     `{"version": "0"}`,
+		"missing bundle": `{"version": 0, "fileMetadata": {"ios": {"assets": []}}}`,
+	} {
+		_, err := ParseMetadata(strings.NewReader(data))
+		var metadataErr *InvalidMetadataError
+		require.ErrorAs(t, err, &metadataErr, name)
+		require.True(t, isPermanentProcessingError(fmt.Errorf("failed: %w", err)), name)
+	}
+}
@@ -0,0 +1,98 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// outboxRelayInterval between batches of the outbox relay
+	outboxRelayInterval = 30 * time.Second
+	// outboxStaleAfter is the age of the entries published by the relay, younger entries are
+	// still being published by CommitUpdate
+	outboxStaleAfter = 30 * time.Second
+	outboxBatchSize  = 100
+)
+
+// OutboxRelay publishes the process messages of committed updates that couldn't be published,
+// e.g. while the queue was down, so they don't stay pending forever
+type OutboxRelay struct {
+	q         *db.Queries
+	pgPool    *pgxpool.Pool
+	queueConn queue.Queue
+}
+
+func NewOutboxRelay(q *db.Queries, pgPool *pgxpool.Pool, queueConn queue.Queue) *OutboxRelay {
+	return &OutboxRelay{q: q, pgPool: pgPool, queueConn: queueConn}
+}
+
+// Run relays a batch of entries every interval until ctx is canceled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(outboxRelayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RelayBatch(ctx); err != nil {
+				log.Warn("failed to relay outbox", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RelayBatch publishes the oldest stale entries, it stops at the first failure, as the queue
+// is most likely still down
+func (r *OutboxRelay) RelayBatch(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	tx, err := r.pgPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error("RelayBatch: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := r.q.WithTx(tx)
+
+	createdBefore := pgtype.Timestamptz{Time: time.Now().Add(-outboxStaleAfter), Valid: true}
+	updateIDs, err := qtx.GetStaleUpdateOutboxEntries(ctx, createdBefore, outboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get outbox entries: %w", err)
+	}
+
+	var publishErr error
+	for _, updateID := range updateIDs {
+		if publishErr = r.queueConn.PublishProcessUpdateMessage(ctx, updateID); publishErr != nil {
+			break
+		}
+		if err := qtx.DeleteUpdateOutboxEntry(ctx, updateID); err != nil {
+			return fmt.Errorf("failed to delete outbox entry: %w", err)
+		}
+		log.Info("relayed update to processing queue", zap.String("update_id", updateID.String()))
+	}
+
+	// the published entries are deleted even when the rest failed
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if publishErr != nil {
+		return fmt.Errorf("failed to publish process update message: %w", publishErr)
+	}
+	return nil
+}
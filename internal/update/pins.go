@@ -0,0 +1,77 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPinNotPublished    = errors.New("only published updates can be pinned")
+	ErrChannelPinNotFound = errors.New("channel pin not found")
+)
+
+// PinChannel makes the channel of the update serve it to clients of its runtime version
+// instead of the latest published update, until the pin is replaced or removed.
+// Canceling the pinned update suspends the pin.
+func (svc *service) PinChannel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+) (*db.ChannelPin, error) {
+	update, err := svc.UpdateByID(ctx, projectID, updateID)
+	if err != nil {
+		if errors.Is(err, ErrUpdateNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("UpdateByID: %w", err)
+	}
+
+	if update.Status != db.UpdateStatusPublished {
+		return nil, ErrPinNotPublished
+	}
+	if err := svc.restoreFromColdStorage(ctx, update); err != nil {
+		return nil, err
+	}
+
+	pin, err := svc.q.SetChannelPin(ctx, db.SetChannelPinParams{
+		ProjectID:      projectID,
+		Channel:        update.Channel,
+		RuntimeVersion: update.RuntimeVersion,
+		UpdateID:       updateID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SetChannelPin: %w", err)
+	}
+	notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
+	return &pin, nil
+}
+
+func (svc *service) UnpinChannel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	runtimeVersion string,
+) error {
+	deleted, err := svc.q.DeleteChannelPin(ctx, projectID, channel, runtimeVersion)
+	if err != nil {
+		return fmt.Errorf("DeleteChannelPin: %w", err)
+	}
+	if deleted == 0 {
+		return ErrChannelPinNotFound
+	}
+	notifyChannelChanged(ctx, svc.queueConn, projectID, channel)
+	return nil
+}
+
+func (svc *service) ChannelPins(ctx context.Context, projectID uuid.UUID) ([]db.ChannelPin, error) {
+	pins, err := svc.q.GetChannelPins(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetChannelPins: %w", err)
+	}
+	return pins, nil
+}
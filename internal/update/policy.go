@@ -0,0 +1,167 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrChannelPolicyNotFound = errors.New("channel policy not found")
+
+// PolicyViolationError is returned when an update doesn't satisfy the policy of its channel
+type PolicyViolationError struct {
+	Field   string
+	Message string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return e.Message
+}
+
+type ChannelPolicyParams struct {
+	// RuntimeVersionConstraint is a semver constraint, e.g. 1.x, empty allows any version
+	RuntimeVersionConstraint string
+	// MessagePattern is a regular expression the message has to match, empty allows any message
+	MessagePattern string
+}
+
+// Validate returns a PolicyViolationError when the constraint or the pattern can't be parsed
+func (p *ChannelPolicyParams) Validate() error {
+	if p.RuntimeVersionConstraint != "" {
+		if _, err := semver.NewConstraint(p.RuntimeVersionConstraint); err != nil {
+			return &PolicyViolationError{
+				Field:   "runtime_version_constraint",
+				Message: fmt.Sprintf("invalid runtime version constraint: %s", err),
+			}
+		}
+	}
+	if p.MessagePattern != "" {
+		if _, err := regexp.Compile(p.MessagePattern); err != nil {
+			return &PolicyViolationError{
+				Field:   "message_pattern",
+				Message: fmt.Sprintf("invalid message pattern: %s", err),
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPolicy returns a PolicyViolationError when the update doesn't satisfy the policy
+func CheckPolicy(policy *db.ChannelPolicy, runtimeVersion string, message string) error {
+	if policy.RuntimeVersionConstraint.Valid {
+		constraint, err := semver.NewConstraint(policy.RuntimeVersionConstraint.String)
+		if err != nil {
+			return fmt.Errorf("invalid runtime version constraint: %w", err)
+		}
+		version, err := semver.NewVersion(runtimeVersion)
+		if err != nil || !constraint.Check(version) {
+			return &PolicyViolationError{
+				Field: "runtime_version",
+				Message: fmt.Sprintf(
+					"channel %s accepts only runtime versions matching %s",
+					policy.Channel,
+					policy.RuntimeVersionConstraint.String,
+				),
+			}
+		}
+	}
+
+	if policy.MessagePattern.Valid {
+		pattern, err := regexp.Compile(policy.MessagePattern.String)
+		if err != nil {
+			return fmt.Errorf("invalid message pattern: %w", err)
+		}
+		if !pattern.MatchString(message) {
+			return &PolicyViolationError{
+				Field: "message",
+				Message: fmt.Sprintf(
+					"channel %s accepts only messages matching %s",
+					policy.Channel,
+					policy.MessagePattern.String,
+				),
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkChannelPolicy checks the update against the policy of the channel, if it has one
+func (svc *service) checkChannelPolicy(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	runtimeVersion string,
+	message string,
+) error {
+	policy, err := svc.q.GetChannelPolicy(ctx, projectID, channel)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("GetChannelPolicy: %w", err)
+	}
+	return CheckPolicy(&policy, runtimeVersion, message)
+}
+
+func (svc *service) SetChannelPolicy(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	params ChannelPolicyParams,
+) (*db.ChannelPolicy, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy, err := svc.q.SetChannelPolicy(ctx, db.SetChannelPolicyParams{
+		ProjectID: projectID,
+		Channel:   channel,
+		RuntimeVersionConstraint: pgtype.Text{
+			String: params.RuntimeVersionConstraint,
+			Valid:  params.RuntimeVersionConstraint != "",
+		},
+		MessagePattern: pgtype.Text{
+			String: params.MessagePattern,
+			Valid:  params.MessagePattern != "",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SetChannelPolicy: %w", err)
+	}
+	return &policy, nil
+}
+
+func (svc *service) DeleteChannelPolicy(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+) error {
+	deleted, err := svc.q.DeleteChannelPolicy(ctx, projectID, channel)
+	if err != nil {
+		return fmt.Errorf("DeleteChannelPolicy: %w", err)
+	}
+	if deleted == 0 {
+		return ErrChannelPolicyNotFound
+	}
+	return nil
+}
+
+func (svc *service) ChannelPolicies(
+	ctx context.Context,
+	projectID uuid.UUID,
+) ([]db.ChannelPolicy, error) {
+	policies, err := svc.q.GetChannelPolicies(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetChannelPolicies: %w", err)
+	}
+	return policies, nil
+}
@@ -0,0 +1,45 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	policy := &db.ChannelPolicy{
+		Channel:                  "production",
+		RuntimeVersionConstraint: pgtype.Text{String: "1.x", Valid: true},
+		MessagePattern:           pgtype.Text{String: `^\[release\] `, Valid: true},
+	}
+
+	require.NoError(t, CheckPolicy(policy, "1.4.0", "[release] fix crash"))
+
+	var policyErr *PolicyViolationError
+	err := CheckPolicy(policy, "2.0.0", "[release] fix crash")
+	require.ErrorAs(t, err, &policyErr)
+	require.Equal(t, "runtime_version", policyErr.Field)
+
+	err = CheckPolicy(policy, "1.4.0", "fix crash")
+	require.ErrorAs(t, err, &policyErr)
+	require.Equal(t, "message", policyErr.Field)
+
+	require.NoError(t, CheckPolicy(&db.ChannelPolicy{Channel: "staging"}, "2.0.0", "wip"))
+}
+
+func TestChannelPolicyParamsValidate(t *testing.T) {
+	params := ChannelPolicyParams{RuntimeVersionConstraint: ">= 2.0, < 3", MessagePattern: "JIRA-\\d+"}
+	require.NoError(t, params.Validate())
+
+	var policyErr *PolicyViolationError
+	params = ChannelPolicyParams{RuntimeVersionConstraint: "one"}
+	require.ErrorAs(t, params.Validate(), &policyErr)
+	require.Equal(t, "runtime_version_constraint", policyErr.Field)
+
+	params = ChannelPolicyParams{MessagePattern: "("}
+	require.ErrorAs(t, params.Validate(), &policyErr)
+	require.Equal(t, "message_pattern", policyErr.Field)
+}
@@ -1,7 +1,6 @@
 package update
 
 import (
-	"archive/zip"
 	"context"
 	"crypto/md5"
 	"crypto/sha256"
@@ -24,36 +23,64 @@ import (
 	"github.com/a-gierczak/paratrooper/internal/util"
 
 	"github.com/google/uuid"
-	"github.com/nats-io/nats.go/jetstream"
 	"go.uber.org/zap"
-	"gocloud.dev/blob"
 )
 
 var ErrUpdateNotPending = errors.New("update is not pending")
-var platforms = []string{"android", "ios"}
+
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	// windows and macos updates are only served by CodePush, Expo doesn't support them
+	PlatformWindows = "windows"
+	PlatformMacOS   = "macos"
+)
+
+var platforms = []string{PlatformAndroid, PlatformIOS, PlatformWindows, PlatformMacOS}
 
 type Processor struct {
 	storage   *storage.Storage
 	svc       Service
-	queueConn *queue.Connection
+	queueConn queue.Queue
+	config    ProcessingConfig
 }
 
 func NewProcessor(
 	svc Service,
 	storage *storage.Storage,
-	queueConn *queue.Connection,
+	queueConn queue.Queue,
+	config ProcessingConfig,
 ) *Processor {
 	return &Processor{
 		storage:   storage,
 		svc:       svc,
 		queueConn: queueConn,
+		config:    config,
 	}
 }
 
+// Start consumes the queue in the background
+func (p *Processor) Start(ctx context.Context) error {
+	if err := p.config.validate(); err != nil {
+		return err
+	}
+	return p.queueConn.Consume(ctx, p.newMessageHandler(ctx))
+}
+
+// RunDeadLetterConsumer fails the updates whose messages were delivered too many times, until
+// ctx is canceled. It must run on a single replica, e.g. the leader.
+func (p *Processor) RunDeadLetterConsumer(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	if err := p.queueConn.ConsumeDeadLetters(ctx, p.newMaxDeliveriesHandler(ctx)); err != nil {
+		log.Error("failed to consume dead letters", zap.Error(err))
+		return
+	}
+	<-ctx.Done()
+}
+
 func (p *Processor) StartWorker(ctx context.Context) error {
 	log := logger.FromContext(ctx)
-	err := p.queueConn.Consume(ctx, p.newMessageHandler(ctx), p.newMaxDeliveriesHandler(ctx))
-	if err != nil {
+	if err := p.Start(ctx); err != nil {
 		return err
 	}
 	defer p.queueConn.Close()
@@ -66,11 +93,11 @@ func (p *Processor) StartWorker(ctx context.Context) error {
 	return nil
 }
 
-func (p *Processor) newMessageHandler(ctx context.Context) func(msg jetstream.Msg) {
+func (p *Processor) newMessageHandler(ctx context.Context) queue.MessageHandler {
 	log := logger.FromContext(ctx)
 	log = log.With(zap.String("consumer", "process-update"))
 
-	return func(msg jetstream.Msg) {
+	return func(msg queue.Message) {
 		payload, err := queue.ParseProcessUpdateMessage(msg.Data())
 		if err != nil {
 			log.Error("failed to unmarshal payload", zap.Error(err))
@@ -98,6 +125,18 @@ func (p *Processor) newMessageHandler(ctx context.Context) func(msg jetstream.Ms
 				return
 			}
 
+			if isPermanentProcessingError(err) {
+				updateLog.Error("failed to process update, dropping", zap.Error(err))
+				_, err := p.svc.SetUpdateStatus(ctx, payload.UpdateID, db.UpdateStatusFailed)
+				if err != nil {
+					updateLog.Error("failed to set update status to failed", zap.Error(err))
+				}
+				if err := msg.Term(); err != nil {
+					updateLog.Error("failed to terminate message", zap.Error(err))
+				}
+				return
+			}
+
 			updateLog.Error("failed to process update, retrying in a few sec", zap.Error(err))
 
 			_, err = p.svc.SetUpdateStatus(ctx, payload.UpdateID, db.UpdateStatusPending)
@@ -119,11 +158,12 @@ func (p *Processor) newMessageHandler(ctx context.Context) func(msg jetstream.Ms
 	}
 }
 
-func (p *Processor) newMaxDeliveriesHandler(ctx context.Context) func(msg *jetstream.RawStreamMsg) {
+func (p *Processor) newMaxDeliveriesHandler(ctx context.Context) func(data []byte) {
 	log := logger.FromContext(ctx)
+	log = log.With(zap.String("consumer", "dlq"))
 
-	return func(msg *jetstream.RawStreamMsg) {
-		payload, err := queue.ParseProcessUpdateMessage(msg.Data)
+	return func(data []byte) {
+		payload, err := queue.ParseProcessUpdateMessage(data)
 		if err != nil {
 			log.Error("failed to unmarshal payload", zap.Error(err))
 			return
@@ -165,6 +205,21 @@ type assetParser struct {
 	st     *storage.Storage
 	update db.Update
 	log    *zap.Logger
+	// contentEncodings maps paths of files declared as already compressed to their encoding
+	contentEncodings map[string]string
+	// clientHashes maps paths of files hashed by the client to their declared hashes
+	clientHashes map[string]declaredHashes
+	// sharedObjects maps paths of files sharing the object of an earlier update to their
+	// storage objects
+	sharedObjects map[string]db.UpdateStorageObject
+	// clientHashVerifyRate is the fraction of the files hashed by the client that are read
+	clientHashVerifyRate float64
+	contentTypes         contentTypeChecker
+	report               *processingReport
+}
+
+func (p *assetParser) contentEncoding(filePath string) string {
+	return p.contentEncodings[storage.CleanPath(filePath)]
 }
 
 type parseAssetMeta struct {
@@ -179,30 +234,74 @@ func (p *assetParser) parse(
 	filePath string,
 	meta parseAssetMeta,
 ) (*db.CreateUpdateAssetsParams, error) {
+	if object, ok := p.sharedObjects[storage.CleanPath(filePath)]; ok {
+		asset := p.parseShared(object, meta)
+		if err := p.checkStoredContentType(ctx, filePath, asset); err != nil {
+			return nil, err
+		}
+		return asset, nil
+	}
+
 	objectKey := storage.AssetObjectKey(p.update.ProjectID, p.update.ID, filePath)
+	asset, ok, err := p.parseHashed(ctx, filePath, objectKey, meta)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := p.checkStoredContentType(ctx, filePath, asset); err != nil {
+			return nil, err
+		}
+		return asset, nil
+	}
+
+	contentEncoding := p.contentEncoding(filePath)
+	if contentEncoding != "" {
+		attrs, err := p.st.Bucket().Attributes(ctx, objectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attributes: %w", err)
+		}
+		// the encoding is served from object metadata, so it must have been set on upload
+		if attrs.ContentEncoding != contentEncoding {
+			return nil, fmt.Errorf(
+				"file %s was uploaded without Content-Encoding: %s header",
+				filePath,
+				contentEncoding,
+			)
+		}
+	}
+
 	blobReader, err := p.st.Bucket().
 		NewReader(ctx, objectKey, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read bundle file: %w", err)
 	}
-	defer util.CloseWithLogger(
-		p.log.With(zap.String("object_key", objectKey)),
-		blobReader,
-	)
+	log := p.log.With(zap.String("object_key", objectKey))
+	defer util.CloseWithLogger(log, blobReader)
+
+	contentReader, err := decodeContent(blobReader, contentEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bundle file: %w", err)
+	}
+	defer util.CloseWithLogger(log, contentReader)
 
 	shaWriter := sha256.New()
 	md5Writer := md5.New()
-	writer := io.MultiWriter(shaWriter, md5Writer)
+	head := new(headBuffer)
+	writer := io.MultiWriter(shaWriter, md5Writer, head)
 
-	_, err = io.Copy(writer, blobReader)
+	n, err := io.Copy(writer, contentReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy bundle file content: %w", err)
 	}
+	p.report.hashed(n)
 
 	contentSha256 := fmt.Sprintf("%x", shaWriter.Sum(nil))
 	contentMd5 := fmt.Sprintf("%x", md5Writer.Sum(nil))
+	if err := p.verifyClientHash(filePath, contentSha256); err != nil {
+		return nil, err
+	}
 
-	return &db.CreateUpdateAssetsParams{
+	asset = &db.CreateUpdateAssetsParams{
 		ID:                uuid.Must(uuid.NewV7()),
 		UpdateID:          p.update.ID,
 		StorageObjectPath: objectKey,
@@ -213,7 +312,12 @@ func (p *assetParser) parse(
 		IsLaunchAsset:     meta.isLaunchAsset,
 		Platform:          meta.platform,
 		ContentType:       meta.contentType,
-	}, nil
+		ContentEncoding:   contentEncoding,
+	}
+	if err := p.checkContentType(filePath, asset, head.Bytes()); err != nil {
+		return nil, err
+	}
+	return asset, nil
 }
 
 func (p *assetParser) parseAssets(
@@ -225,12 +329,17 @@ func (p *assetParser) parseAssets(
 	for _, platform := range platforms {
 		platformMeta, ok := meta.FileMetadata[platform]
 		if !ok {
-			p.log.Warn("missing platform metadata, skipping", zap.String("platform", platform))
+			p.log.Debug("missing platform metadata, skipping", zap.String("platform", platform))
+			p.report.warn("missing %s platform metadata, skipped", platform)
 			continue
 		}
 
 		{
-			extension := path.Ext(platformMeta.Bundle)
+			bundlePath := trimEncodingExtension(
+				platformMeta.Bundle,
+				p.contentEncoding(platformMeta.Bundle),
+			)
+			extension := path.Ext(bundlePath)
 			if extension == "" {
 				extension = ".bundle"
 			}
@@ -279,7 +388,30 @@ func (p *assetParser) parseAssets(
 	return parsedAssets, parseErrors
 }
 
+// ProcessUpdate processes a pending update and saves the report of the run
 func (p *Processor) ProcessUpdate(ctx context.Context, id uuid.UUID) error {
+	report := newProcessingReport()
+	err := p.processUpdate(ctx, id, report)
+	if errors.Is(err, ErrUpdateNotPending) {
+		// nothing was processed, the message was delivered again
+		return err
+	}
+
+	if err := p.svc.CreateProcessingReport(ctx, report.params(id, err)); err != nil {
+		logger.FromContext(ctx).Warn(
+			"failed to save processing report",
+			zap.String("update_id", id.String()),
+			zap.Error(err),
+		)
+	}
+	return err
+}
+
+func (p *Processor) processUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+	report *processingReport,
+) error {
 	log := logger.FromContext(ctx).With(zap.String("update_id", id.String()))
 
 	updateWithProtocol, err := p.svc.UpdateByIDWithProtocol(ctx, id)
@@ -299,21 +431,71 @@ func (p *Processor) ProcessUpdate(ctx context.Context, id uuid.UUID) error {
 
 	log = log.With(zap.String("project_id", update.ProjectID.String()))
 
-	metadataJsonPath := storage.AssetObjectKey(update.ProjectID, update.ID, "metadata.json")
-	meta, err := readMetadata(ctx, p.storage, metadataJsonPath)
+	storageObjects, err := p.svc.StorageObjects(ctx, update.ID)
 	if err != nil {
-		return fmt.Errorf("failed to read metadata.json: %w", err)
+		return fmt.Errorf("failed to get declared storage objects: %w", err)
 	}
 
-	assetParser := &assetParser{
+	unpacker := &unpacker{
 		st:     p.storage,
 		update: *update,
 		log:    log,
+		limits: p.config.archiveLimits(),
+	}
+	for _, object := range storageObjects {
+		if !object.IsArchive {
+			continue
+		}
+
+		numUnpacked, err := unpacker.unpack(ctx, object.Path)
+		if err != nil {
+			return fmt.Errorf("failed to unpack archive: %w", err)
+		}
+		log.Info(fmt.Sprintf("unpacked %d files from %s", numUnpacked, object.Path))
+		report.unpackedFiles += numUnpacked
+	}
+
+	metadataJsonPath := storage.AssetObjectKey(update.ProjectID, update.ID, MetadataFileName)
+	meta, err := readMetadata(ctx, p.storage, metadataJsonPath)
+	if err != nil {
+		return fmt.Errorf("failed to read metadata.json: %w", err)
+	}
+
+	// files of archives weren't counted when they were declared
+	if err := meta.CheckAssetCount(int(updateWithProtocol.MaxAssetCount)); err != nil {
+		return err
+	}
+
+	contentEncodings := make(map[string]string)
+	for _, object := range storageObjects {
+		if object.ContentEncoding != "" {
+			contentEncodings[object.Path] = object.ContentEncoding
+		}
+		if object.IsArchive {
+			for _, filePath := range meta.ReferencedPaths() {
+				if contentEncoding := extensionContentEncoding(filePath); contentEncoding != "" {
+					contentEncodings[filePath] = contentEncoding
+				}
+			}
+		}
+	}
+
+	assetParser := &assetParser{
+		st:                   p.storage,
+		update:               *update,
+		log:                  log,
+		contentEncodings:     contentEncodings,
+		clientHashes:         clientHashes(storageObjects),
+		sharedObjects:        sharedObjects(storageObjects),
+		clientHashVerifyRate: p.config.ClientHashVerifyRate,
+		contentTypes:         newContentTypeChecker(p.config),
+		report:               report,
 	}
 	// TODO: parse only assets that are not already in the DB
 	parsedAssets, parseErrors := assetParser.parseAssets(ctx, meta)
 
 	log.Info(fmt.Sprintf("processed %d files (%d errors)", len(parsedAssets), len(parseErrors)))
+	report.assetCount = len(parsedAssets)
 
 	numSaved, err := p.svc.CreateUpdateAssets(ctx, parsedAssets)
 	if err != nil {
@@ -323,20 +505,27 @@ func (p *Processor) ProcessUpdate(ctx context.Context, id uuid.UUID) error {
 	log.Info(fmt.Sprintf("saved %d parsed assets to db", numSaved))
 
 	if len(parseErrors) > 0 {
-		return fmt.Errorf("failed to parse some assets")
+		// a client declaring a wrong hash can't be trusted with the files that weren't verified
+		if err := errors.Join(parseErrors...); errors.Is(err, ErrClientHashMismatch) {
+			return err
+		}
+		return fmt.Errorf("failed to parse some assets: %w", errors.Join(parseErrors...))
 	}
 
 	archiver := &archiver{
-		st:     p.storage,
-		update: *update,
-		svc:    p.svc,
-		log:    log,
+		st:        p.storage,
+		update:    *update,
+		svc:       p.svc,
+		log:       log,
+		diffBases: p.config.CodePushDiffBases,
+		memory:    newArchiveMemory(p.config.ArchiveMemoryBudget),
 	}
 	archivedAssets := make([]db.CreateUpdateAssetsParams, 0)
+	diffObjectKeys := make([]string, 0)
 	for _, platform := range platforms {
 		platformMeta, ok := meta.FileMetadata[platform]
 		if !ok {
-			log.Warn("missing platform metadata, skipping", zap.String("platform", platform))
+			log.Debug("missing platform metadata, skipping", zap.String("platform", platform))
 			continue
 		}
 
@@ -349,6 +538,14 @@ func (p *Processor) ProcessUpdate(ctx context.Context, id uuid.UUID) error {
 				return fmt.Errorf("failed to archive update: %w", err)
 			}
 			archivedAssets = append(archivedAssets, *assetParams)
+
+			// diffs only save downloads, the full package is served without them
+			keys, err := archiver.diffsForPlatform(ctx, platform, assetParams)
+			if err != nil {
+				log.Warn("failed to make diff packages", zap.String("platform", platform), zap.Error(err))
+				report.warn("failed to make %s diff packages: %s", platform, err)
+			}
+			diffObjectKeys = append(diffObjectKeys, keys...)
 		}
 	}
 
@@ -358,13 +555,72 @@ func (p *Processor) ProcessUpdate(ctx context.Context, id uuid.UUID) error {
 	}
 
 	log.Info(fmt.Sprintf("saved %d archive assets to db", numSaved))
+	report.archiveCount = len(archivedAssets)
+
+	if len(updateWithProtocol.ReplicaRegions) > 0 {
+		objectKeys := make([]string, 0, len(parsedAssets)+len(archivedAssets))
+		for _, asset := range slices.Concat(parsedAssets, archivedAssets) {
+			objectKeys = append(objectKeys, asset.StorageObjectPath)
+		}
+		objectKeys = append(objectKeys, diffObjectKeys...)
+		err := replicate(ctx, p.storage, updateWithProtocol.ReplicaRegions, objectKeys)
+		if err != nil {
+			return fmt.Errorf("failed to replicate assets: %w", err)
+		}
+		// manifests point to the replicas of the regions the update was copied to only
+		err = p.svc.CreateUpdateReplicas(ctx, update.ID, updateWithProtocol.ReplicaRegions)
+		if err != nil {
+			return err
+		}
+		log.Info("replicated assets", zap.Strings("regions", updateWithProtocol.ReplicaRegions))
+	}
 
-	_, err = p.svc.SetUpdateStatus(ctx, update.ID, db.UpdateStatusPublished)
+	contentHash := ContentHash(update.ID, slices.Concat(parsedAssets, archivedAssets))
+	if err := p.svc.SetUpdateContentHash(ctx, update.ID, contentHash); err != nil {
+		return err
+	}
+
+	published, err := p.svc.PublishUpdate(
+		ctx,
+		update.ID,
+		slices.Concat(parsedAssets, archivedAssets),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to set update status to published: %w", err)
+		return fmt.Errorf("failed to publish update: %w", err)
 	}
-	log.Info("set update status to published")
+	log.Info("set update status to published", zap.Int("linked_updates", len(published)-1))
+	for _, u := range published {
+		notifyChannelChanged(ctx, p.queueConn, u.ProjectID, u.Channel)
+	}
+
+	// the storage quota of the next update of the project counts the files of this one
+	if err := p.svc.RefreshProjectUsage(ctx, update.ProjectID); err != nil {
+		log.Warn("failed to refresh project usage", zap.Error(err))
+	}
+
+	return nil
+}
+
+// replicate copies the assets to the replicas before the update is published,
+// so the manifests never point to a replica missing them
+func replicate(
+	ctx context.Context,
+	st *storage.Storage,
+	regions []string,
+	objectKeys []string,
+) error {
+	for _, region := range regions {
+		replica := st.Replica(region)
+		if replica == nil {
+			return fmt.Errorf("no storage replica configured for region %s", region)
+		}
 
+		for _, objectKey := range objectKeys {
+			if err := replica.CopyFrom(ctx, st.Bucket(), objectKey); err != nil {
+				return fmt.Errorf("failed to copy %s to %s: %w", objectKey, region, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -373,6 +629,9 @@ type archiver struct {
 	update db.Update
 	svc    Service
 	log    *zap.Logger
+	// diffBases is the number of earlier releases to make diff packages for
+	diffBases int
+	memory    archiveMemory
 }
 
 func (a *archiver) archiveForPlatform(
@@ -381,14 +640,6 @@ func (a *archiver) archiveForPlatform(
 ) (*db.CreateUpdateAssetsParams, error) {
 	log := a.log.With(zap.String("platform", platform))
 
-	objectKey := storage.ArchiveObjectKey(a.update.ProjectID, a.update.ID, platform)
-	blobWriter, err := a.st.Bucket().
-		NewWriter(ctx, objectKey, &blob.WriterOptions{ContentType: "application/zip"})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create blob: %w", err)
-	}
-	defer blobWriter.Close()
-
 	assets, err := a.svc.AssetsByPlatform(ctx, a.update.ID, platform)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get assets from db: %w", err)
@@ -398,54 +649,28 @@ func (a *archiver) archiveForPlatform(
 		return nil, fmt.Errorf("no assets found for platform %s", platform)
 	}
 
-	zipWriter := zip.NewWriter(blobWriter)
-	defer zipWriter.Close()
+	objectKey := storage.ArchiveObjectKey(a.update.ProjectID, a.update.ID, platform)
+	archive, err := a.createZip(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
 
 	archivedAssets := 0
 	for _, asset := range assets {
-		_, _, fileLocalPath := storage.AssetObjectKeySegments(asset.StorageObjectPath)
-
-		// during bundling assets are stored in a platform-specific folder,
-		// so we need to trim the platform prefix from the path,
-		// so that the path is the same as in the original build
-		pathInZip := strings.TrimPrefix(fileLocalPath, platform+"/")
-
-		zipFileWriter, err := zipWriter.Create(pathInZip)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create file in zip: %w", err)
-		}
-
-		blobReader, err := a.st.Bucket().NewReader(ctx, asset.StorageObjectPath, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read asset from storage: %w", err)
-		}
-		defer blobReader.Close()
-
-		_, err = io.Copy(zipFileWriter, blobReader)
-		if err != nil {
-			return nil, fmt.Errorf("failed to copy asset to zip: %w", err)
-		}
-
-		err = blobReader.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to close blob reader: %w", err)
+		if err := archive.add(ctx, archivePath(asset, platform), asset); err != nil {
+			archive.discard()
+			return nil, err
 		}
 		archivedAssets += 1
 	}
 
-	err = zipWriter.Close()
-	if err != nil {
-		return nil, fmt.Errorf("failed to close zip writer: %w", err)
-	}
-
-	err = blobWriter.Close()
-	if err != nil {
-		return nil, fmt.Errorf("failed to close blob writer: %w", err)
+	if err := archive.close(); err != nil {
+		return nil, err
 	}
 
 	log.Info(fmt.Sprintf("archived %d assets", archivedAssets))
 
-	contentSha256, err := calculateSHA256ForArchive(assets)
+	contentSha256, err := calculateSHA256ForArchive(assets, platform)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate sha256: %w", err)
 	}
@@ -470,12 +695,24 @@ func (a *archiver) archiveForPlatform(
 	}, nil
 }
 
-// calculateSHA256ForArchive calculates CodePush compatible SHA256 hash for the archive
-func calculateSHA256ForArchive(assets []db.UpdateAsset) (string, error) {
+// archivePath is the path of the asset in the CodePush package
+func archivePath(asset db.UpdateAsset, platform string) string {
+	_, _, fileLocalPath := storage.AssetObjectKeySegments(asset.StorageObjectPath)
+
+	// during bundling assets are stored in a platform-specific folder,
+	// so we need to trim the platform prefix from the path,
+	// so that the path is the same as in the original build
+	pathInZip := strings.TrimPrefix(fileLocalPath, platform+"/")
+	return trimEncodingExtension(pathInZip, asset.ContentEncoding)
+}
+
+// calculateSHA256ForArchive calculates CodePush compatible SHA256 hash for the archive, the
+// hash of the files by their paths in the archive, clients applying a diff package compare it
+// with the hash of the files they end up with
+func calculateSHA256ForArchive(assets []db.UpdateAsset, platform string) (string, error) {
 	tokens := make([]string, 0, len(assets))
 	for _, asset := range assets {
-		_, _, filePath := storage.AssetObjectKeySegments(asset.StorageObjectPath)
-		tokens = append(tokens, fmt.Sprintf("%s:%s", filePath, asset.ContentSha256))
+		tokens = append(tokens, fmt.Sprintf("%s:%s", archivePath(asset, platform), asset.ContentSha256))
 	}
 	slices.Sort(tokens)
 
@@ -0,0 +1,155 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+	"golang.org/x/sync/errgroup"
+)
+
+// verifyConcurrency bounds the objects of an update checked at once on commit
+const verifyConcurrency = 16
+
+type FileUploadState string
+
+const (
+	FileUploadStatePending  FileUploadState = "pending"
+	FileUploadStatePartial  FileUploadState = "partial"
+	FileUploadStateUploaded FileUploadState = "uploaded"
+)
+
+type FileUploadStatus struct {
+	Path          string
+	State         FileUploadState
+	ContentLength int64
+	ReceivedBytes int64
+	// LastModified is zero when nothing was received for the file yet
+	LastModified time.Time
+}
+
+// UploadStatus reports how much of every file declared for the update has reached
+// the storage, either as a complete object or as chunks of a chunked upload
+func (svc *service) UploadStatus(ctx context.Context, update db.Update) ([]FileUploadStatus, error) {
+	objects, err := svc.q.GetUpdateStorageObjects(ctx, update.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpdateStorageObjects: %w", err)
+	}
+
+	files := make([]FileUploadStatus, 0, len(objects))
+	for _, object := range objects {
+		status, err := svc.fileUploadStatus(ctx, update, object)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *status)
+	}
+
+	return files, nil
+}
+
+func (svc *service) fileUploadStatus(
+	ctx context.Context,
+	update db.Update,
+	object db.UpdateStorageObject,
+) (*FileUploadStatus, error) {
+	status := &FileUploadStatus{
+		Path:          object.Path,
+		State:         FileUploadStatePending,
+		ContentLength: object.ContentLength,
+	}
+
+	objectKey := storage.AssetObjectKey(update.ProjectID, update.ID, object.Path)
+	if object.SharedObjectKey.Valid {
+		objectKey = object.SharedObjectKey.String
+	}
+	attrs, err := svc.storage.Bucket().Attributes(ctx, objectKey)
+	if err == nil {
+		status.State = FileUploadStateUploaded
+		status.ReceivedBytes = attrs.Size
+		status.LastModified = attrs.ModTime
+		return status, nil
+	}
+	if gcerrors.Code(err) != gcerrors.NotFound {
+		return nil, fmt.Errorf("failed to get attributes of %s: %w", object.Path, err)
+	}
+
+	prefix := storage.ChunkObjectKeyPrefix(update.ProjectID, update.ID, object.Path)
+	iter := svc.storage.Bucket().List(&blob.ListOptions{Prefix: prefix})
+	for {
+		chunk, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks of %s: %w", object.Path, err)
+		}
+
+		status.State = FileUploadStatePartial
+		status.ReceivedBytes += chunk.Size
+		if chunk.ModTime.After(status.LastModified) {
+			status.LastModified = chunk.ModTime
+		}
+	}
+
+	return status, nil
+}
+
+// verifyUploadedObjects checks that every file declared for the update was uploaded with the
+// declared size and MD5, without reading the files. It returns the paths of the files that
+// weren't uploaded and of the ones that don't match, the MD5 is compared only when the storage
+// reports it.
+func (svc *service) verifyUploadedObjects(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	objects []db.UpdateStorageObject,
+) (missing, corrupt []string, err error) {
+	missingFiles := make([]bool, len(objects))
+	corruptFiles := make([]bool, len(objects))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(verifyConcurrency)
+	for i, object := range objects {
+		g.Go(func() error {
+			objectKey := storage.AssetObjectKey(projectID, updateID, object.Path)
+			if object.SharedObjectKey.Valid {
+				objectKey = object.SharedObjectKey.String
+			}
+			attrs, err := svc.storage.Bucket().Attributes(gctx, objectKey)
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				missingFiles[i] = true
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get attributes of %s: %w", object.Path, err)
+			}
+
+			md5Matches := len(attrs.MD5) == 0 ||
+				strings.EqualFold(fmt.Sprintf("%x", attrs.MD5), object.ContentMd5)
+			corruptFiles[i] = attrs.Size != object.ContentLength || !md5Matches
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	missing, corrupt = []string{}, []string{}
+	for i, object := range objects {
+		if missingFiles[i] {
+			missing = append(missing, object.Path)
+		} else if corruptFiles[i] {
+			corrupt = append(corrupt, object.Path)
+		}
+	}
+	return missing, corrupt, nil
+}
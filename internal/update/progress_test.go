@@ -0,0 +1,89 @@
+package update
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileUploadStatus(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	svc := &service{storage: st}
+	u := db.Update{ID: uuid.New(), ProjectID: uuid.New()}
+
+	uploaded := db.UpdateStorageObject{Path: "assets/uploaded", ContentLength: 4}
+	uploadedKey := storage.AssetObjectKey(u.ProjectID, u.ID, uploaded.Path)
+	writeTestObject(t, ctx, st, uploadedKey, []byte("data"))
+
+	partial := db.UpdateStorageObject{Path: "assets/partial", ContentLength: 2 * UploadChunkSize}
+	chunkKey := storage.ChunkObjectKey(u.ProjectID, u.ID, partial.Path, 0)
+	writeTestObject(t, ctx, st, chunkKey, []byte("chunk"))
+
+	pending := db.UpdateStorageObject{Path: "assets/pending", ContentLength: 10}
+
+	status, err := svc.fileUploadStatus(ctx, u, uploaded)
+	require.NoError(t, err)
+	require.Equal(t, FileUploadStateUploaded, status.State)
+	require.Equal(t, int64(4), status.ReceivedBytes)
+	require.False(t, status.LastModified.IsZero())
+
+	status, err = svc.fileUploadStatus(ctx, u, partial)
+	require.NoError(t, err)
+	require.Equal(t, FileUploadStatePartial, status.State)
+	require.Equal(t, int64(5), status.ReceivedBytes)
+
+	status, err = svc.fileUploadStatus(ctx, u, pending)
+	require.NoError(t, err)
+	require.Equal(t, FileUploadStatePending, status.State)
+	require.Zero(t, status.ReceivedBytes)
+	require.True(t, status.LastModified.IsZero())
+}
+
+func TestVerifyUploadedObjects(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	svc := &service{storage: st}
+	projectID, updateID := uuid.New(), uuid.New()
+
+	data := []byte("data")
+	md5Hash := fmt.Sprintf("%x", md5.Sum(data))
+	for _, path := range []string{"assets/intact", "assets/resized", "assets/changed"} {
+		writeTestObject(t, ctx, st, storage.AssetObjectKey(projectID, updateID, path), data)
+	}
+	sharedKey := storage.AssetObjectKey(projectID, uuid.New(), "assets/shared")
+	writeTestObject(t, ctx, st, sharedKey, data)
+
+	objects := []db.UpdateStorageObject{
+		{Path: "assets/intact", ContentLength: 4, ContentMd5: strings.ToUpper(md5Hash)},
+		{Path: "assets/resized", ContentLength: 5, ContentMd5: md5Hash},
+		{Path: "assets/changed", ContentLength: 4, ContentMd5: fmt.Sprintf("%x", md5.Sum(nil))},
+		{Path: "assets/missing", ContentLength: 4, ContentMd5: md5Hash},
+		{
+			Path:            "assets/shared",
+			ContentLength:   4,
+			ContentMd5:      md5Hash,
+			SharedObjectKey: pgtype.Text{String: sharedKey, Valid: true},
+		},
+	}
+	missing, corrupt, err := svc.verifyUploadedObjects(ctx, projectID, updateID, objects)
+	require.NoError(t, err)
+	require.Equal(t, []string{"assets/missing"}, missing)
+	require.Equal(t, []string{"assets/resized", "assets/changed"}, corrupt)
+
+	missing, corrupt, err = svc.verifyUploadedObjects(ctx, projectID, updateID, objects[:1])
+	require.NoError(t, err)
+	require.Empty(t, missing)
+	require.Empty(t, corrupt)
+}
@@ -0,0 +1,126 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type QuotaConfig struct {
+	// UsageRefreshInterval is how often the usage of the projects with a storage quota is
+	// recalculated, so files deleted by the retention policy free up the quota
+	UsageRefreshInterval time.Duration `env:"PROJECT_USAGE_REFRESH_INTERVAL,default=10m"`
+}
+
+// QuotaExceededError is returned when the files of a new update would take the storage used by
+// the project over its quota
+type QuotaExceededError struct {
+	StorageBytesUsed int64
+	MaxStorageBytes  int64
+	// UploadBytes is the size of the files of the new update to upload, files shared with
+	// earlier updates take no new storage
+	UploadBytes int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"storage quota exceeded, the project uses %d of %d bytes and the update uploads %d bytes",
+		e.StorageBytesUsed,
+		e.MaxStorageBytes,
+		e.UploadBytes,
+	)
+}
+
+// quotaError rejects the update when its uploaded files would take the project over its quota.
+// The usage is recalculated by the worker, so updates prepared since then aren't counted.
+func quotaError(quota db.GetProjectStorageQuotaRow, uploadBytes int64) error {
+	if quota.MaxStorageBytes <= 0 || quota.StorageBytes+uploadBytes <= quota.MaxStorageBytes {
+		return nil
+	}
+	return &QuotaExceededError{
+		StorageBytesUsed: quota.StorageBytes,
+		MaxStorageBytes:  quota.MaxStorageBytes,
+		UploadBytes:      uploadBytes,
+	}
+}
+
+// remainingStorageBytes is what's left of the quota of the project after the uploaded files,
+// nil without a quota
+func remainingStorageBytes(quota db.GetProjectStorageQuotaRow, uploadBytes int64) *int64 {
+	if quota.MaxStorageBytes <= 0 {
+		return nil
+	}
+	remaining := max(quota.MaxStorageBytes-quota.StorageBytes-uploadBytes, 0)
+	return &remaining
+}
+
+// RefreshProjectUsage recalculates the storage used by the project from its files
+func (svc *service) RefreshProjectUsage(ctx context.Context, projectID uuid.UUID) error {
+	return refreshProjectUsage(ctx, svc.q, projectID)
+}
+
+// refreshProjectUsage skips deleted projects, they're purged with their usage
+func refreshProjectUsage(ctx context.Context, q *db.Queries, projectID uuid.UUID) error {
+	_, err := q.RefreshProjectUsage(ctx, projectID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("RefreshProjectUsage: %w", err)
+	}
+	return nil
+}
+
+// UsageRefresher periodically recalculates the storage used by the projects with a quota, the
+// usage of a project is also recalculated whenever one of its updates is processed
+type UsageRefresher struct {
+	q      *db.Queries
+	config QuotaConfig
+}
+
+func NewUsageRefresher(q *db.Queries, config QuotaConfig) *UsageRefresher {
+	return &UsageRefresher{q: q, config: config}
+}
+
+// Run refreshes the usage every interval until ctx is canceled
+func (r *UsageRefresher) Run(ctx context.Context) {
+	if r.config.UsageRefreshInterval <= 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(r.config.UsageRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				log.Error("failed to refresh project usage", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Refresh recalculates the usage of the projects with a quota
+func (r *UsageRefresher) Refresh(ctx context.Context) error {
+	projectIDs, err := r.q.GetProjectIDsWithStorageQuota(ctx)
+	if err != nil {
+		return fmt.Errorf("GetProjectIDsWithStorageQuota: %w", err)
+	}
+	for _, projectID := range projectIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := refreshProjectUsage(ctx, r.q, projectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
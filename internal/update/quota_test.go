@@ -0,0 +1,39 @@
+package update
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaError(t *testing.T) {
+	// no quota
+	require.NoError(t, quotaError(db.GetProjectStorageQuotaRow{StorageBytes: 1 << 40}, 500))
+	// the update fits exactly
+	quota := db.GetProjectStorageQuotaRow{MaxStorageBytes: 1000, StorageBytes: 500}
+	require.NoError(t, quotaError(quota, 500))
+
+	quota.StorageBytes = 501
+	err := quotaError(quota, 500)
+	var quotaErr *QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, &QuotaExceededError{
+		StorageBytesUsed: 501,
+		MaxStorageBytes:  1000,
+		UploadBytes:      500,
+	}, quotaErr)
+	require.Contains(t, err.Error(), "uses 501 of 1000 bytes")
+}
+
+func TestRemainingStorageBytes(t *testing.T) {
+	require.Nil(t, remainingStorageBytes(db.GetProjectStorageQuotaRow{StorageBytes: 500}, 500))
+
+	quota := db.GetProjectStorageQuotaRow{MaxStorageBytes: 1000, StorageBytes: 300}
+	require.Equal(t, int64(200), *remainingStorageBytes(quota, 500))
+	// the usage is recalculated late, parallel uploads can go over the quota
+	quota.StorageBytes = 1200
+	require.Equal(t, int64(0), *remainingStorageBytes(quota, 0))
+}
@@ -0,0 +1,101 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ReportStatusPublished = "published"
+	ReportStatusFailed    = "failed"
+)
+
+// processingReport collects what a processing run did, it's saved whatever the outcome of the run,
+// so publish issues can be inspected with the API
+type processingReport struct {
+	startedAt     time.Time
+	assetCount    int
+	archiveCount  int
+	unpackedFiles int
+	hashedFiles   int
+	bytesHashed   int64
+	warnings      []string
+}
+
+func newProcessingReport() *processingReport {
+	return &processingReport{startedAt: time.Now(), warnings: make([]string, 0)}
+}
+
+// warn and hashed are no-ops on a nil report
+func (r *processingReport) warn(format string, args ...any) {
+	if r == nil {
+		return
+	}
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *processingReport) hashed(bytes int64) {
+	if r == nil {
+		return
+	}
+	r.hashedFiles++
+	r.bytesHashed += bytes
+}
+
+// params of the report of the run that returned err
+func (r *processingReport) params(
+	updateID uuid.UUID,
+	err error,
+) db.CreateUpdateProcessingReportParams {
+	params := db.CreateUpdateProcessingReportParams{
+		ID:            uuid.Must(uuid.NewV7()),
+		UpdateID:      updateID,
+		Status:        ReportStatusPublished,
+		AssetCount:    int32(r.assetCount),
+		ArchiveCount:  int32(r.archiveCount),
+		UnpackedFiles: int32(r.unpackedFiles),
+		HashedFiles:   int32(r.hashedFiles),
+		BytesHashed:   r.bytesHashed,
+		Warnings:      r.warnings,
+		StartedAt:     pgtype.Timestamptz{Time: r.startedAt, Valid: true},
+		DurationMs:    time.Since(r.startedAt).Milliseconds(),
+	}
+	if err != nil {
+		params.Status = ReportStatusFailed
+		params.Error = pgtype.Text{String: err.Error(), Valid: true}
+	}
+	return params
+}
+
+func (svc *service) CreateProcessingReport(
+	ctx context.Context,
+	params db.CreateUpdateProcessingReportParams,
+) error {
+	if err := svc.q.CreateUpdateProcessingReport(ctx, params); err != nil {
+		return fmt.Errorf("CreateUpdateProcessingReport: %w", err)
+	}
+	return nil
+}
+
+// ProcessingReports returns the reports of the processing runs of the update, the latest first
+func (svc *service) ProcessingReports(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+) ([]db.UpdateProcessingReport, error) {
+	if _, err := svc.UpdateByID(ctx, projectID, updateID); err != nil {
+		return nil, err
+	}
+
+	reports, err := svc.q.GetUpdateProcessingReports(ctx, updateID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpdateProcessingReports: %w", err)
+	}
+	return reports, nil
+}
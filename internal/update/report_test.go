@@ -0,0 +1,36 @@
+package update
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessingReportParams(t *testing.T) {
+	updateID := uuid.New()
+	report := newProcessingReport()
+	report.assetCount = 2
+	report.hashed(1024)
+	report.hashed(512)
+	report.warn("missing %s platform metadata, skipped", "android")
+
+	params := report.params(updateID, nil)
+	assert.Equal(t, updateID, params.UpdateID)
+	assert.Equal(t, ReportStatusPublished, params.Status)
+	assert.False(t, params.Error.Valid)
+	assert.EqualValues(t, 2, params.AssetCount)
+	assert.EqualValues(t, 2, params.HashedFiles)
+	assert.EqualValues(t, 1536, params.BytesHashed)
+	assert.Equal(t, []string{"missing android platform metadata, skipped"}, params.Warnings)
+
+	params = report.params(updateID, errors.New("failed to read metadata.json"))
+	assert.Equal(t, ReportStatusFailed, params.Status)
+	assert.Equal(t, "failed to read metadata.json", params.Error.String)
+
+	// parsers without a report don't collect anything
+	var noReport *processingReport
+	noReport.hashed(1024)
+	noReport.warn("ignored")
+}
@@ -0,0 +1,100 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type RetentionConfig struct {
+	// KeepLast is the number of published updates kept per channel, runtime version and flavors,
+	// older updates are deleted, none are when it's not set
+	KeepLast int64 `env:"RETENTION_KEEP_LAST"`
+	// Interval between batches
+	Interval time.Duration `env:"RETENTION_INTERVAL,default=1h"`
+	// BatchSize is the number of updates deleted in a single batch
+	BatchSize int32 `env:"RETENTION_BATCH_SIZE,default=100"`
+}
+
+// RetentionEnforcer periodically deletes the updates of a channel older than its last published
+// updates, with their objects. Pinned updates and updates linked updates of other channels were
+// published with are kept.
+type RetentionEnforcer struct {
+	q         *db.Queries
+	pgPool    *pgxpool.Pool
+	st        *storage.Storage
+	queueConn queue.Queue
+	config    RetentionConfig
+}
+
+func NewRetentionEnforcer(
+	q *db.Queries,
+	pgPool *pgxpool.Pool,
+	st *storage.Storage,
+	queueConn queue.Queue,
+	config RetentionConfig,
+) *RetentionEnforcer {
+	return &RetentionEnforcer{q: q, pgPool: pgPool, st: st, queueConn: queueConn, config: config}
+}
+
+// Run deletes a batch of updates every interval until ctx is canceled
+func (e *RetentionEnforcer) Run(ctx context.Context) {
+	if e.config.KeepLast <= 0 || e.config.Interval <= 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.DeleteBatch(ctx); err != nil {
+				log.Error("failed to delete updates past retention", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DeleteBatch deletes the oldest updates past the retention
+func (e *RetentionEnforcer) DeleteBatch(ctx context.Context) error {
+	updates, err := e.q.GetUpdatesPastRetention(ctx, e.config.KeepLast, e.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get updates past retention: %w", err)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := deleteUpdates(ctx, e.q, e.pgPool, e.st, e.queueConn, updates); err != nil {
+		return err
+	}
+
+	// caches of the channels may still point to the deleted updates, e.g. of partial rollouts
+	type channel struct {
+		projectID uuid.UUID
+		name      string
+	}
+	notified := make(map[channel]bool)
+	for _, update := range updates {
+		key := channel{update.ProjectID, update.Channel}
+		if update.Status != db.UpdateStatusPublished || notified[key] {
+			continue
+		}
+		notified[key] = true
+		notifyChannelChanged(ctx, e.queueConn, update.ProjectID, update.Channel)
+	}
+	logger.FromContext(ctx).Info("deleted updates past retention", zap.Int("count", len(updates)))
+	return nil
+}
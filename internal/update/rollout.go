@@ -0,0 +1,62 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// NoRolloutBucket is the bucket of clients without a client ID, they get only the updates
+// rolled out to all devices
+const NoRolloutBucket = 100
+
+// RolloutBucket assigns the device to one of 100 buckets by its client ID, an update rolled out
+// to N% of the devices is published to the buckets below N. A device stays in the same bucket,
+// so raising the percentage never takes the update away from it.
+func RolloutBucket(clientID string) int {
+	if clientID == "" {
+		return NoRolloutBucket
+	}
+	h := fnv.New32a()
+	h.Write([]byte(clientID))
+	return int(h.Sum32() % 100)
+}
+
+// SetRolloutPercentage changes the share of the devices getting the update, 100 publishes it
+// to all of them without publishing it again
+func (svc *service) SetRolloutPercentage(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+	percentage int,
+) (*db.Update, error) {
+	var update db.Update
+	err := svc.inTx(ctx, "SetRolloutPercentage", func(qtx *db.Queries) error {
+		var err error
+		update, err = qtx.SetUpdateRolloutPercentage(ctx, int16(percentage), updateID, projectID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUpdateNotFound
+			}
+			return fmt.Errorf("SetUpdateRolloutPercentage: %w", err)
+		}
+		if update.Status != db.UpdateStatusPublished {
+			return nil
+		}
+		return refreshChannelHeads(ctx, qtx, update)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Status == db.UpdateStatusPublished {
+		notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
+	}
+	return &update, nil
+}
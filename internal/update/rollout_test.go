@@ -0,0 +1,21 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutBucket(t *testing.T) {
+	require.Equal(t, NoRolloutBucket, RolloutBucket(""))
+	require.Equal(t, RolloutBucket("device-1"), RolloutBucket("device-1"))
+
+	buckets := make(map[int]bool)
+	for _, clientID := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		bucket := RolloutBucket(clientID)
+		require.GreaterOrEqual(t, bucket, 0)
+		require.Less(t, bucket, 100)
+		buckets[bucket] = true
+	}
+	require.Greater(t, len(buckets), 1)
+}
@@ -2,9 +2,15 @@ package update
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/a-gierczak/paratrooper/generated/api"
 	"github.com/a-gierczak/paratrooper/generated/db"
@@ -17,13 +23,25 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+	"golang.org/x/sync/singleflight"
 )
 
 const DefaultChannelName = "production"
 
 var (
 	ErrUpdateNotFound     = errors.New("update not found")
+	ErrAlreadyCommitted   = errors.New("update has already been committed")
+	ErrLinkedUpdateCommit = errors.New(
+		"linked update is committed together with the update it's linked to",
+	)
 	ErrUpdateNotPublished = errors.New("tried to rollback non-published update")
+	ErrFileNotDeclared    = errors.New("file was not declared when preparing the update")
+	ErrFileSizeMismatch   = errors.New("file size doesn't match the declared content length")
+	ErrCodePushLabelTaken = errors.New("CodePush label is already used in the channel")
+	ErrUpdateIDTaken      = errors.New("update ID is already used")
+	ErrExternalIDTaken    = errors.New("external ID is already used in the project")
 )
 
 type Service interface {
@@ -38,17 +56,61 @@ type Service interface {
 		ctx context.Context,
 		projectID uuid.UUID,
 		request api.PrepareUpdateBody,
-	) (uuid.UUID, []api.StorageObjectPathWithURL, error)
-	CommitUpdate(ctx context.Context, updateID uuid.UUID) error
+	) (*api.PrepareUpdateResponse, error)
+	CommitUpdate(ctx context.Context, projectID uuid.UUID, updateID uuid.UUID) error
 	UpdateToInstall(
 		ctx context.Context,
 		projectID uuid.UUID,
 		runtimeVersion string,
 		channel string,
 		platform string,
+		flavor string,
+		rolloutBucket int,
 		filter CurrentUpdateFilter,
 	) (*db.GetLatestPublishedAndCanceledUpdatesRow, error)
+	// ResolveUpdateToInstall works like UpdateToInstall, but explains the result
+	ResolveUpdateToInstall(
+		ctx context.Context,
+		projectID uuid.UUID,
+		runtimeVersion string,
+		channel string,
+		platform string,
+		flavor string,
+		rolloutBucket int,
+		filter CurrentUpdateFilter,
+	) (*Resolution, error)
+	HasUpdates(
+		ctx context.Context,
+		projectID uuid.UUID,
+		runtimeVersion string,
+		channel string,
+	) (bool, error)
 	RollbackUpdate(ctx context.Context, projectID uuid.UUID, updateID uuid.UUID) error
+	// RefreshProjectUsage recalculates the storage used by the project, checked against its quota
+	RefreshProjectUsage(ctx context.Context, projectID uuid.UUID) error
+	// DeleteUpdate deletes the update with its assets and the objects no other update shares
+	DeleteUpdate(ctx context.Context, projectID uuid.UUID, updateID uuid.UUID) error
+	// SetRolloutPercentage changes the share of the devices getting the update
+	SetRolloutPercentage(
+		ctx context.Context,
+		projectID uuid.UUID,
+		updateID uuid.UUID,
+		percentage int,
+	) (*db.Update, error)
+	// SetUpdateExpiry makes the update treated as canceled from expiresAt on, nil removes it
+	SetUpdateExpiry(
+		ctx context.Context,
+		projectID uuid.UUID,
+		updateID uuid.UUID,
+		expiresAt *time.Time,
+	) (*db.Update, error)
+	// SetUpdateMandatory changes whether CodePush clients install the update right away
+	SetUpdateMandatory(
+		ctx context.Context,
+		projectID uuid.UUID,
+		updateID uuid.UUID,
+		mandatory bool,
+	) (*db.Update, error)
 	UpdateByID(
 		ctx context.Context,
 		projectID uuid.UUID,
@@ -59,32 +121,142 @@ type Service interface {
 		updateID uuid.UUID,
 		status db.UpdateStatus,
 	) (*db.Update, error)
+	// PublishUpdate publishes the processed update and its linked updates at once
+	PublishUpdate(
+		ctx context.Context,
+		updateID uuid.UUID,
+		assets []db.CreateUpdateAssetsParams,
+	) ([]db.Update, error)
 	CreateUpdateAssets(ctx context.Context, assets []db.CreateUpdateAssetsParams) (int64, error)
+	SetUpdateContentHash(ctx context.Context, updateID uuid.UUID, contentHash string) error
+	// CreateUpdateReplicas records the replica regions the objects of the update were copied to
+	CreateUpdateReplicas(ctx context.Context, updateID uuid.UUID, regions []string) error
+	CreateProcessingReport(ctx context.Context, params db.CreateUpdateProcessingReportParams) error
+	ProcessingReports(
+		ctx context.Context,
+		projectID uuid.UUID,
+		updateID uuid.UUID,
+	) ([]db.UpdateProcessingReport, error)
 	UpdateByIDWithProtocol(
 		ctx context.Context,
 		updateID uuid.UUID,
 	) (*db.GetUpdateByIDWithProtocolRow, error)
+	// UpdateByExternalID returns the update prepared with the external ID of the deployment
+	// system, ErrUpdateNotFound when there's none
+	UpdateByExternalID(
+		ctx context.Context,
+		projectID uuid.UUID,
+		externalID string,
+	) (*db.Update, error)
 	AssetsByPlatform(
 		ctx context.Context,
 		updateID uuid.UUID,
 		platform string,
 	) ([]db.UpdateAsset, error)
+	// PublishedAsset returns the asset of a published update, nil when there's none
+	PublishedAsset(ctx context.Context, assetID uuid.UUID) (*db.UpdateAsset, error)
+	// CodePushDiffBases returns the earlier releases diff packages of the update are made for
+	CodePushDiffBases(
+		ctx context.Context,
+		update db.Update,
+		platform string,
+		packageHash string,
+		limit int,
+	) ([]db.GetCodePushDiffBasesRow, error)
+	CreateCodePushDiffPackage(ctx context.Context, params db.CreateCodePushDiffPackageParams) error
+	StorageObjects(ctx context.Context, updateID uuid.UUID) ([]db.UpdateStorageObject, error)
+	// DeclaredObject returns the storage object declared for the file when the update was
+	// prepared, ErrFileNotDeclared when there's none
+	DeclaredObject(
+		ctx context.Context,
+		updateID uuid.UUID,
+		filePath string,
+	) (*db.UpdateStorageObject, error)
+	UploadFile(ctx context.Context, update db.Update, filePath string, reader io.Reader) error
+	UploadChunk(
+		ctx context.Context,
+		update db.Update,
+		filePath string,
+		index int,
+		contentMD5 string,
+		reader io.Reader,
+	) error
+	ChunkedUploadStatus(
+		ctx context.Context,
+		update db.Update,
+		filePath string,
+	) (*ChunkedUploadStatus, error)
+	CompleteChunkedUpload(ctx context.Context, update db.Update, filePath string) error
+	UploadStatus(ctx context.Context, update db.Update) ([]FileUploadStatus, error)
+	PinChannel(ctx context.Context, projectID uuid.UUID, updateID uuid.UUID) (*db.ChannelPin, error)
+	UnpinChannel(
+		ctx context.Context,
+		projectID uuid.UUID,
+		channel string,
+		runtimeVersion string,
+	) error
+	ChannelPins(ctx context.Context, projectID uuid.UUID) ([]db.ChannelPin, error)
+	// RegisterEmbeddedUpdate records the update embedded in a store binary, clients running it
+	// aren't served published updates with the same launch asset
+	RegisterEmbeddedUpdate(
+		ctx context.Context,
+		params db.RegisterEmbeddedUpdateParams,
+	) (*db.EmbeddedUpdate, error)
+	EmbeddedUpdates(ctx context.Context, projectID uuid.UUID) ([]db.EmbeddedUpdate, error)
+	// RollBackToEmbedded tells Expo clients of the channel to roll back to their embedded
+	// update until an update is published to the channel, empty runtime version rolls back all
+	RollBackToEmbedded(
+		ctx context.Context,
+		projectID uuid.UUID,
+		channel string,
+		runtimeVersion string,
+	) (*db.ChannelRollback, error)
+	ActiveChannelRollback(
+		ctx context.Context,
+		projectID uuid.UUID,
+		channel string,
+		runtimeVersion string,
+		flavor string,
+	) (*db.ChannelRollback, error)
+	// CopyLatestUpdates copies the latest published update of every channel and runtime version
+	// to the target project
+	CopyLatestUpdates(
+		ctx context.Context,
+		projectID uuid.UUID,
+		target *db.Project,
+	) ([]db.Update, error)
+	DiffUpdates(
+		ctx context.Context,
+		projectID uuid.UUID,
+		fromUpdateID uuid.UUID,
+		toUpdateID uuid.UUID,
+	) (*Diff, error)
+	SetChannelPolicy(
+		ctx context.Context,
+		projectID uuid.UUID,
+		channel string,
+		params ChannelPolicyParams,
+	) (*db.ChannelPolicy, error)
+	DeleteChannelPolicy(ctx context.Context, projectID uuid.UUID, channel string) error
+	ChannelPolicies(ctx context.Context, projectID uuid.UUID) ([]db.ChannelPolicy, error)
 }
 
 type service struct {
 	q         *db.Queries
 	pgPool    *pgxpool.Pool
 	storage   *storage.Storage
-	queueConn *queue.Connection
+	queueConn queue.Queue
+	// restores of updates from cold storage by the update ID
+	restores singleflight.Group
 }
 
 func NewService(
 	q *db.Queries,
 	pgPool *pgxpool.Pool,
 	st *storage.Storage,
-	queueConn *queue.Connection,
+	queueConn queue.Queue,
 ) Service {
-	return &service{q, pgPool, st, queueConn}
+	return &service{q: q, pgPool: pgPool, storage: st, queueConn: queueConn}
 }
 
 func (svc *service) FindUpdates(
@@ -132,11 +304,72 @@ func (svc *service) PrepareUpdate(
 	ctx context.Context,
 	projectID uuid.UUID,
 	request api.PrepareUpdateBody,
-) (uuid.UUID, []api.StorageObjectPathWithURL, error) {
+) (*api.PrepareUpdateResponse, error) {
 	log := logger.FromContext(ctx)
+
+	// rejected releases are never uploaded
+	for _, channel := range slices.Concat([]string{*request.Channel}, request.AdditionalChannels) {
+		err := svc.checkChannelPolicy(
+			ctx,
+			projectID,
+			channel,
+			request.RuntimeVersion,
+			request.Message,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if request.CodePushLabel != nil {
+			if err := svc.checkCodePushLabel(ctx, projectID, channel, *request.CodePushLabel); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if request.ID != nil {
+		_, err := svc.q.GetUpdateByIDWithProtocol(ctx, *request.ID)
+		if err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUpdateIDTaken, *request.ID)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("GetUpdateByIDWithProtocol: %w", err)
+		}
+	}
+
+	if request.ExternalID != nil {
+		existing, err := svc.UpdateByExternalID(ctx, projectID, *request.ExternalID)
+		if err == nil {
+			return nil, fmt.Errorf("%w: update %s", ErrExternalIDTaken, existing.ID)
+		}
+		if !errors.Is(err, ErrUpdateNotFound) {
+			return nil, err
+		}
+	}
+
+	var appConfigJson []byte
+	if request.ExpoAppConfig != nil {
+		var err error
+		appConfigJson, err = json.Marshal(request.ExpoAppConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal app config: %w", err)
+		}
+	}
+
+	maxAssetCount, err := svc.q.GetProjectMaxAssetCount(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetProjectMaxAssetCount: %w", err)
+	}
+
+	protocol, err := svc.q.GetProjectUpdateProtocol(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetProjectUpdateProtocol: %w", err)
+	}
+	// CodePush releases without a label are numbered per channel
+	numberLabels := protocol == db.UpdateProtocolCodepush && request.CodePushLabel == nil
+
 	tx, err := svc.pgPool.Begin(ctx)
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("failed to start transaction: %w", err)
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer func(tx pgx.Tx, ctx context.Context) {
 		err := tx.Rollback(ctx)
@@ -157,61 +390,348 @@ func (svc *service) PrepareUpdate(
 		RuntimeVersion: request.RuntimeVersion,
 		Message:        pgtype.Text{String: request.Message, Valid: true},
 		Channel:        *request.Channel,
+		Flavors:        request.Flavors,
+		ExpiresAt:      expiresAtTimestamp(request.ExpiresAt),
+		IsMandatory:    request.IsMandatory == nil || *request.IsMandatory,
+	}
+	if request.ID != nil {
+		update.ID = *request.ID
 	}
+	if request.CreatedAt != nil {
+		update.CreatedAt = pgtype.Timestamptz{Time: *request.CreatedAt, Valid: true}
+	}
+	if request.CodePushLabel != nil {
+		update.CodepushLabel = pgtype.Text{String: *request.CodePushLabel, Valid: true}
+	}
+	if request.ExternalID != nil {
+		update.ExternalID = pgtype.Text{String: *request.ExternalID, Valid: true}
+	}
+	if request.RolloutPercentage != nil {
+		update.RolloutPercentage = int16(*request.RolloutPercentage)
+	}
+	if numberLabels {
+		update.CodepushLabel, err = nextCodePushLabel(ctx, qtx, projectID, update.Channel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := createUpdate(ctx, qtx, update, appConfigJson); err != nil {
+		return nil, err
+	}
+
+	// the linked updates get the assets of the update when it's published
+	linkedIDs := make([]uuid.UUID, 0, len(request.AdditionalChannels))
+	for _, channel := range request.AdditionalChannels {
+		linked := *update
+		linked.ID = uuid.Must(uuid.NewV7())
+		linked.Channel = channel
+		linked.LinkedUpdateID = pgtype.UUID{Bytes: update.ID, Valid: true}
+		// the deployment system finds the linked updates by the update
+		linked.ExternalID = pgtype.Text{}
+		if numberLabels {
+			linked.CodepushLabel, err = nextCodePushLabel(ctx, qtx, projectID, channel)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := createUpdate(ctx, qtx, &linked, appConfigJson); err != nil {
+			return nil, err
+		}
+		linkedIDs = append(linkedIDs, linked.ID)
+	}
+
+	objects := request.FileMetadata
+	if request.Archive != nil {
+		objects = []api.StorageObject{*request.Archive}
+	}
+
+	// files of archives are unpacked by the worker, their content isn't known yet
+	var sharedKeys map[string]string
+	if request.Archive == nil {
+		sharedKeys, err = svc.sharedObjectKeys(ctx, projectID, objects)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quota, err := qtx.GetProjectStorageQuota(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetProjectStorageQuota: %w", err)
+	}
+	uploadPlan := newUploadPlan(objects, sharedKeys, int(maxAssetCount), quota)
+	if err := quotaError(quota, uploadPlan.UploadBytes); err != nil {
+		return nil, err
+	}
+
+	storageObjects := make([]db.CreateUpdateStorageObjectsParams, 0, len(objects))
+	for _, object := range objects {
+		sharedKey := sharedKeys[storage.CleanPath(object.Path)]
+		storageObjects = append(storageObjects, db.CreateUpdateStorageObjectsParams{
+			ID:              uuid.Must(uuid.NewV7()),
+			UpdateID:        update.ID,
+			Path:            storage.CleanPath(object.Path),
+			ContentType:     object.ContentType,
+			ContentEncoding: string(object.ContentEncoding),
+			Extension:       object.Extension,
+			ContentMd5:      object.MD5Hash,
+			ContentSha256: pgtype.Text{
+				String: strings.ToLower(object.SHA256Hash),
+				Valid:  object.SHA256Hash != "",
+			},
+			ContentLength:   int64(object.ContentLength),
+			IsArchive:       request.Archive != nil,
+			SharedObjectKey: pgtype.Text{String: sharedKey, Valid: sharedKey != ""},
+		})
+	}
+	if _, err := qtx.CreateUpdateStorageObjects(ctx, storageObjects); err != nil {
+		return nil, fmt.Errorf("CreateUpdateStorageObjects: %w", err)
+	}
+
+	uploadURLs, err := svc.storage.UploadURLs(
+		ctx,
+		projectID,
+		update.ID,
+		objects,
+		int(maxAssetCount),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("UploadURLs: %w", err)
+	}
+	sharedFiles := make([]string, 0, len(sharedKeys))
+	uploadURLs = slices.DeleteFunc(uploadURLs, func(url api.StorageObjectPathWithURL) bool {
+		if _, ok := sharedKeys[storage.CleanPath(url.Path)]; ok {
+			sharedFiles = append(sharedFiles, url.Path)
+			return true
+		}
+		return false
+	})
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Info(
+		"update prepared",
+		zap.String("update_id", update.ID.String()),
+		zap.Strings("additional_channels", request.AdditionalChannels),
+		zap.Int("shared_files", len(sharedFiles)),
+	)
+
+	return &api.PrepareUpdateResponse{
+		UpdateID:        update.ID,
+		UploadURLs:      uploadURLs,
+		SharedFiles:     sharedFiles,
+		LinkedUpdateIDs: linkedIDs,
+		UploadPlan:      uploadPlan,
+	}, nil
+}
+
+// newUploadPlan sums up the files the client uploads and skips, so it can plan the uploads
+func newUploadPlan(
+	objects []api.StorageObject,
+	sharedKeys map[string]string,
+	maxAssetCount int,
+	quota db.GetProjectStorageQuotaRow,
+) api.UploadPlan {
+	plan := api.UploadPlan{
+		MaxObjectSize:       storage.MaxObjectSize,
+		ChunkSize:           UploadChunkSize,
+		RemainingUpdateSize: storage.MaxUpdateTotalSizeMB * 1024 * 1024,
+		RemainingAssetCount: max(maxAssetCount-len(objects), 0),
+	}
+	for _, object := range objects {
+		if _, ok := sharedKeys[storage.CleanPath(object.Path)]; ok {
+			plan.SharedBytes += int64(object.ContentLength)
+		} else {
+			plan.UploadBytes += int64(object.ContentLength)
+		}
+	}
+	plan.RemainingUpdateSize = max(plan.RemainingUpdateSize-plan.UploadBytes-plan.SharedBytes, 0)
+	plan.RemainingStorageBytes = remainingStorageBytes(quota, plan.UploadBytes)
+	return plan
+}
+
+// checkCodePushLabel returns ErrCodePushLabelTaken when an update of the channel has the label
+func (svc *service) checkCodePushLabel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	channel string,
+	label string,
+) error {
+	_, err := svc.q.GetUpdateIDByCodePushLabel(ctx, projectID, channel, label)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrCodePushLabelTaken, label)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("GetUpdateIDByCodePushLabel: %w", err)
+	}
+	return nil
+}
 
-	err = qtx.CreateUpdate(ctx, db.CreateUpdateParams{
+func createUpdate(
+	ctx context.Context,
+	qtx *db.Queries,
+	update *db.Update,
+	appConfigJson []byte,
+) error {
+	err := qtx.CreateUpdate(ctx, db.CreateUpdateParams{
 		ID:             update.ID,
 		ProjectID:      update.ProjectID,
 		RuntimeVersion: update.RuntimeVersion,
 		Message:        update.Message,
 		Channel:        update.Channel,
+		Flavors:        update.Flavors,
+		ExpiresAt:      update.ExpiresAt,
+		RolloutPercentage: pgtype.Int2{
+			Int16: update.RolloutPercentage,
+			Valid: update.RolloutPercentage != 0,
+		},
+		LinkedUpdateID: update.LinkedUpdateID,
+		CodepushLabel:  update.CodepushLabel,
+		IsMandatory:    update.IsMandatory,
+		ExternalID:     update.ExternalID,
+		CreatedAt:      update.CreatedAt,
 	})
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("CreateUpdate: %w", err)
+		return fmt.Errorf("CreateUpdate: %w", err)
 	}
 
-	if request.ExpoAppConfig != nil {
-		appConfigJson, err := json.Marshal(request.ExpoAppConfig)
+	if appConfigJson != nil {
+		err := qtx.CreateUpdateMetadata(ctx, uuid.Must(uuid.NewV7()), update.ID, appConfigJson)
 		if err != nil {
-			return uuid.Nil, nil, fmt.Errorf("failed to marshal app config: %w", err)
+			return fmt.Errorf("CreateUpdateMetadata: %w", err)
 		}
+	}
+	return nil
+}
 
-		if err := qtx.CreateUpdateMetadata(ctx, uuid.Must(uuid.NewV7()), update.ID, appConfigJson); err != nil {
-			return uuid.Nil, nil, fmt.Errorf("CreateUpdateMetadata: %w", err)
+// verifyDeclaredFiles checks that the storage objects declared when the update was prepared
+// were uploaded intact, then reads the uploaded metadata.json and cross-checks it against them.
+// Archives are unpacked by the worker, so only the archive itself is checked.
+func (svc *service) verifyDeclaredFiles(
+	ctx context.Context,
+	projectID uuid.UUID,
+	updateID uuid.UUID,
+) error {
+	objects, err := svc.StorageObjects(ctx, updateID)
+	if err != nil {
+		return fmt.Errorf("StorageObjects: %w", err)
+	}
+
+	missing, corrupt, err := svc.verifyUploadedObjects(ctx, projectID, updateID, objects)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 || len(corrupt) > 0 {
+		return &FilesMismatchError{Missing: missing, Extra: []string{}, Corrupt: corrupt}
+	}
+
+	declared := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if object.IsArchive {
+			return nil
 		}
+		declared = append(declared, object.Path)
 	}
 
-	uploadURLs, err := svc.storage.UploadURLs(ctx, projectID, update.ID, request.FileMetadata)
+	metadataObjectKey := storage.AssetObjectKey(projectID, updateID, MetadataFileName)
+	exists, err := svc.storage.Bucket().Exists(ctx, metadataObjectKey)
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("UploadURLs: %w", err)
+		return fmt.Errorf("failed to check if metadata.json exists: %w", err)
+	}
+	if !exists {
+		return &FilesMismatchError{
+			Missing: []string{MetadataFileName},
+			Extra:   []string{},
+			Corrupt: []string{},
+		}
 	}
 
-	err = tx.Commit(ctx)
+	meta, err := readMetadata(ctx, svc.storage, metadataObjectKey)
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to read metadata.json: %w", err)
 	}
 
-	log.Info("update prepared", zap.String("update_id", update.ID.String()))
+	maxAssetCount, err := svc.q.GetProjectMaxAssetCount(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("GetProjectMaxAssetCount: %w", err)
+	}
+	if err := meta.CheckAssetCount(int(maxAssetCount)); err != nil {
+		return err
+	}
 
-	return update.ID, uploadURLs, nil
+	missing, extra := diffDeclaredFiles(meta, declared)
+	if len(missing) > 0 || len(extra) > 0 {
+		return &FilesMismatchError{Missing: missing, Extra: extra, Corrupt: []string{}}
+	}
+
+	return nil
 }
 
 func (svc *service) CommitUpdate(
 	ctx context.Context,
+	projectID uuid.UUID,
 	updateID uuid.UUID,
 ) error {
 	log := logger.FromContext(ctx)
-	update, err := svc.q.SetUpdateStatus(ctx, updateID, db.UpdateStatusPending)
+
+	if err := svc.verifyDeclaredFiles(ctx, projectID, updateID); err != nil {
+		return err
+	}
+
+	tx, err := svc.pgPool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("SetUpdateStatus: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error(
+				"CommitUpdate: failed to rollback transaction",
+				zap.String("update_id", updateID.String()),
+				zap.Error(err),
+			)
+		}
+	}()
+	qtx := svc.q.WithTx(tx)
 
-	err = svc.queueConn.PublishProcessUpdateMessage(ctx, update.ID)
+	// processing of updates committed one after another may finish in any order, the commit
+	// time decides which of them is the latest on the channel
+	update, err := qtx.SetUpdateCommitted(ctx, updateID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// committed concurrently
+		return ErrAlreadyCommitted
+	}
 	if err != nil {
-		return fmt.Errorf("PublishProcessUpdateMessage: %w", err)
+		return fmt.Errorf("SetUpdateCommitted: %w", err)
+	}
+	if err := qtx.SetLinkedUpdatesCommittedAt(ctx, update.CommittedAt, update.ID); err != nil {
+		return fmt.Errorf("SetLinkedUpdatesCommittedAt: %w", err)
+	}
+	// removed once the message is published, otherwise the outbox relay publishes it
+	if err := qtx.CreateUpdateOutboxEntry(ctx, update.ID); err != nil {
+		return fmt.Errorf("CreateUpdateOutboxEntry: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log = log.With(zap.String("update_id", update.ID.String()))
+	// the update is pending already, it must get processed even if the client went away
+	ctx = context.WithoutCancel(ctx)
+	if err := svc.queueConn.PublishProcessUpdateMessage(ctx, update.ID); err != nil {
+		// the commit is accepted, the update stays pending until the queue is reachable
+		log.Warn("failed to publish process update message, left in the outbox", zap.Error(err))
+		return nil
+	}
+	if err := svc.q.DeleteUpdateOutboxEntry(ctx, update.ID); err != nil {
+		// the relay publishes it again, the duplicate is dropped as the update isn't pending
+		log.Warn("failed to delete outbox entry", zap.Error(err))
 	}
 
-	log.Info("update committed to processing queue", zap.String("update_id", update.ID.String()))
+	log.Info("update committed to processing queue")
 
 	return nil
 }
@@ -227,74 +747,239 @@ func (svc *service) UpdateToInstall(
 	runtimeVersion string,
 	channel string,
 	platform string,
+	flavor string,
+	rolloutBucket int,
 	currentUpdate CurrentUpdateFilter,
 ) (*db.GetLatestPublishedAndCanceledUpdatesRow, error) {
+	resolution, err := svc.ResolveUpdateToInstall(
+		ctx,
+		projectID,
+		runtimeVersion,
+		channel,
+		platform,
+		flavor,
+		rolloutBucket,
+		currentUpdate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// canceled updates are returned only to roll them back, their assets aren't downloaded
+	if resolution.Update != nil && resolution.Update.Update.Status == db.UpdateStatusPublished {
+		if err := svc.restoreFromColdStorage(ctx, &resolution.Update.Update); err != nil {
+			return nil, err
+		}
+	}
+	return resolution.Update, nil
+}
+
+// TargetsFlavor reports whether clients of the flavor can install the update, clients without
+// a flavor get only the updates targeting all flavors
+func TargetsFlavor(update *db.Update, flavor string) bool {
+	return len(update.Flavors) == 0 || slices.Contains(update.Flavors, flavor)
+}
+
+// Resolution explains the result of UpdateToInstall
+type Resolution struct {
+	// Candidates are the latest published and the latest canceled update
+	Candidates []db.GetLatestPublishedAndCanceledUpdatesRow
+	// Update is nil when there's nothing to install
+	Update *db.GetLatestPublishedAndCanceledUpdatesRow
+	// PinnedUpdateID is set when the channel is pinned, the pinned update is then the only candidate
+	PinnedUpdateID *uuid.UUID
+	Reason         string
+}
+
+// IsCurrentUpdate reports whether the client runs the update
+func (f CurrentUpdateFilter) IsCurrentUpdate(u *db.GetLatestPublishedAndCanceledUpdatesRow) bool {
+	matches := false
+	if f.ID != nil && u.Update.ID == *f.ID {
+		matches = true
+	}
+
+	if f.SHA256 != nil && u.ContentSha256.Valid && u.ContentSha256.String == *f.SHA256 {
+		matches = true
+	}
+
+	return matches
+}
+
+func (svc *service) ResolveUpdateToInstall(
+	ctx context.Context,
+	projectID uuid.UUID,
+	runtimeVersion string,
+	channel string,
+	platform string,
+	flavor string,
+	rolloutBucket int,
+	currentUpdate CurrentUpdateFilter,
+) (*Resolution, error) {
+	resolution, err := svc.resolve(
+		ctx,
+		projectID,
+		runtimeVersion,
+		channel,
+		platform,
+		flavor,
+		rolloutBucket,
+		currentUpdate,
+	)
+	if err != nil || resolution.Update == nil {
+		return resolution, err
+	}
+	if resolution.Update.Update.Status != db.UpdateStatusPublished {
+		return resolution, nil
+	}
+
+	matchesEmbedded, err := svc.matchesEmbeddedUpdate(
+		ctx,
+		projectID,
+		runtimeVersion,
+		platform,
+		currentUpdate,
+		&resolution.Update.Update,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if matchesEmbedded {
+		resolution.Update = nil
+		resolution.Reason = "update has the same launch asset as the installed embedded update"
+	}
+	return resolution, nil
+}
+
+func (svc *service) resolve(
+	ctx context.Context,
+	projectID uuid.UUID,
+	runtimeVersion string,
+	channel string,
+	platform string,
+	flavor string,
+	rolloutBucket int,
+	currentUpdate CurrentUpdateFilter,
+) (*Resolution, error) {
+	now := time.Now()
+	pinned, err := svc.q.GetPinnedUpdate(ctx, db.GetPinnedUpdateParams{
+		ProjectID:      projectID,
+		RuntimeVersion: runtimeVersion,
+		Channel:        channel,
+		Platform:       platform,
+		Now:            pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err == nil {
+		row := db.GetLatestPublishedAndCanceledUpdatesRow(pinned)
+		resolution := &Resolution{
+			Candidates:     []db.GetLatestPublishedAndCanceledUpdatesRow{row},
+			PinnedUpdateID: &row.Update.ID,
+		}
+		// the pin applies to all flavors, clients of the others stay on their update
+		if !TargetsFlavor(&row.Update, flavor) {
+			resolution.Reason = "channel is pinned to an update of other flavors"
+			return resolution, nil
+		}
+		if currentUpdate.IsCurrentUpdate(&row) {
+			resolution.Reason = "pinned update is already installed"
+			return resolution, nil
+		}
+		resolution.Update = &row
+		resolution.Reason = "channel is pinned to the update"
+		return resolution, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("GetPinnedUpdate: %w", err)
+	}
+
 	params := db.GetLatestPublishedAndCanceledUpdatesParams{
 		ProjectID:      projectID,
 		RuntimeVersion: runtimeVersion,
 		Channel:        channel,
 		Platform:       platform,
+		Flavor:         flavor,
+		Now:            pgtype.Timestamptz{Time: now, Valid: true},
+		RolloutBucket:  int32(rolloutBucket),
 	}
 
-	rows, err := svc.q.GetLatestPublishedAndCanceledUpdates(ctx, params)
+	rows, err := svc.latestUpdates(ctx, params)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrUpdateNotFound
+		return nil, err
+	}
+
+	// expired updates are rolled back like the canceled ones
+	for i := range rows {
+		if IsExpired(&rows[i].Update, now) {
+			rows[i].Update.Status = db.UpdateStatusCanceled
 		}
-		return nil, fmt.Errorf("GetLatestPublishedAndCanceledUpdates: %w", err)
 	}
 
 	if len(rows) > 2 {
 		return nil, fmt.Errorf("should return at most 2 rows, got %d", len(rows))
 	}
 
-	isCurrentUpdate := func(u *db.GetLatestPublishedAndCanceledUpdatesRow) bool {
-		matches := false
-		if currentUpdate.ID != nil && u.Update.ID == *currentUpdate.ID {
-			matches = true
-		}
-
-		if currentUpdate.SHA256 != nil && u.ContentSha256.Valid &&
-			u.ContentSha256.String == *currentUpdate.SHA256 {
-			matches = true
-		}
-
-		return matches
-	}
+	resolution := &Resolution{Candidates: rows}
+	isCurrentUpdate := currentUpdate.IsCurrentUpdate
 
 	if len(rows) == 2 {
 		if rows[0].Update.Status == db.UpdateStatusPublished {
 			if !isCurrentUpdate(&rows[0]) {
-				return &rows[0], nil
+				resolution.Update = &rows[0]
+				resolution.Reason = "latest published update is not installed"
+				return resolution, nil
 			}
 
-			return nil, nil
+			resolution.Reason = "latest published update is already installed"
+			return resolution, nil
 		}
 
 		if rows[0].Update.Status == db.UpdateStatusCanceled &&
 			rows[1].Update.Status == db.UpdateStatusPublished && !isCurrentUpdate(&rows[1]) {
-			return &rows[1], nil
+			resolution.Update = &rows[1]
+			resolution.Reason = "latest published update is not installed"
+			return resolution, nil
 		}
 
-		return nil, nil
+		resolution.Reason = "latest published update is already installed"
+		return resolution, nil
 	}
 
 	if len(rows) == 1 {
 		// current update has been rolled back
 		if rows[0].Update.Status == db.UpdateStatusCanceled && isCurrentUpdate(&rows[0]) {
-			return &rows[0], nil
+			resolution.Update = &rows[0]
+			resolution.Reason = "installed update was canceled"
+			return resolution, nil
 		}
 
 		// there's a new published updated
 		if rows[0].Update.Status == db.UpdateStatusPublished && !isCurrentUpdate(&rows[0]) {
-			return &rows[0], nil
+			resolution.Update = &rows[0]
+			resolution.Reason = "latest published update is not installed"
+			return resolution, nil
 		}
 
 		// published, but already installed, or new but canceled - ignore in both cases
-		return nil, nil
+		if rows[0].Update.Status == db.UpdateStatusPublished {
+			resolution.Reason = "latest published update is already installed"
+		} else {
+			resolution.Reason = "the only update was canceled and it isn't installed"
+		}
+		return resolution, nil
 	}
 
-	return nil, nil
+	resolution.Reason = "no update was published to the channel for the runtime version"
+	return resolution, nil
+}
+
+// HasUpdates reports whether any update of the runtime version was published to the channel,
+// including canceled ones. Without them there's nothing to install, whatever the current update.
+func (svc *service) HasUpdates(
+	ctx context.Context,
+	projectID uuid.UUID,
+	runtimeVersion string,
+	channel string,
+) (bool, error) {
+	return svc.q.HasPublishedOrCanceledUpdates(ctx, projectID, runtimeVersion, channel)
 }
 
 func (svc *service) RollbackUpdate(
@@ -320,10 +1005,16 @@ func (svc *service) RollbackUpdate(
 		return ErrUpdateNotPublished
 	}
 
-	_, err = svc.q.SetUpdateStatus(ctx, updateID, db.UpdateStatusCanceled)
+	err = svc.inTx(ctx, "RollbackUpdate", func(qtx *db.Queries) error {
+		if _, err := qtx.SetUpdateStatus(ctx, updateID, db.UpdateStatusCanceled); err != nil {
+			return fmt.Errorf("SetUpdateStatus: %w", err)
+		}
+		return refreshChannelHeads(ctx, qtx, *update)
+	})
 	if err != nil {
-		return fmt.Errorf("SetUpdateStatus: %w", err)
+		return err
 	}
+	notifyChannelChanged(ctx, svc.queueConn, projectID, update.Channel)
 
 	return nil
 }
@@ -344,6 +1035,22 @@ func (svc *service) UpdateByID(
 	return &u, nil
 }
 
+func (svc *service) UpdateByExternalID(
+	ctx context.Context,
+	projectID uuid.UUID,
+	externalID string,
+) (*db.Update, error) {
+	u, err := svc.q.GetUpdateByExternalID(ctx, projectID, externalID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUpdateNotFound
+		}
+		return nil, fmt.Errorf("GetUpdateByExternalID: %w", err)
+	}
+
+	return &u, nil
+}
+
 func (svc *service) UpdateByIDWithProtocol(
 	ctx context.Context,
 	updateID uuid.UUID,
@@ -363,6 +1070,17 @@ func (svc *service) CreateUpdateAssets(
 	return svc.q.CreateUpdateAssets(ctx, assets)
 }
 
+func (svc *service) CreateUpdateReplicas(
+	ctx context.Context,
+	updateID uuid.UUID,
+	regions []string,
+) error {
+	if err := svc.q.CreateUpdateReplicas(ctx, updateID, regions); err != nil {
+		return fmt.Errorf("CreateUpdateReplicas: %w", err)
+	}
+	return nil
+}
+
 func (svc *service) SetUpdateStatus(
 	ctx context.Context,
 	updateID uuid.UUID,
@@ -373,9 +1091,103 @@ func (svc *service) SetUpdateStatus(
 		return nil, err
 	}
 
+	// the linked updates are never published without the update
+	if status == db.UpdateStatusFailed {
+		if err := svc.q.SetLinkedUpdatesStatus(ctx, status, updateID); err != nil {
+			return nil, fmt.Errorf("SetLinkedUpdatesStatus: %w", err)
+		}
+	}
+
 	return &u, nil
 }
 
+// PublishUpdate publishes the processed update together with its linked updates in a single
+// transaction, the linked updates get copies of the asset rows pointing to the same objects
+func (svc *service) PublishUpdate(
+	ctx context.Context,
+	updateID uuid.UUID,
+	assets []db.CreateUpdateAssetsParams,
+) ([]db.Update, error) {
+	tx, err := svc.pgPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error(
+				"PublishUpdate: failed to rollback transaction",
+				zap.String("update_id", updateID.String()),
+				zap.Error(err),
+			)
+		}
+	}()
+	qtx := svc.q.WithTx(tx)
+
+	linkedUpdates, err := qtx.GetLinkedUpdates(ctx, updateID)
+	if err != nil {
+		return nil, fmt.Errorf("GetLinkedUpdates: %w", err)
+	}
+
+	update, err := qtx.SetUpdateStatus(ctx, updateID, db.UpdateStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("SetUpdateStatus: %w", err)
+	}
+	published := []db.Update{update}
+
+	for _, linked := range linkedUpdates {
+		linkedAssets := make([]db.CreateUpdateAssetsParams, 0, len(assets))
+		for _, asset := range assets {
+			asset.ID = uuid.Must(uuid.NewV7())
+			asset.UpdateID = linked.ID
+			linkedAssets = append(linkedAssets, asset)
+		}
+		if _, err := qtx.CreateUpdateAssets(ctx, linkedAssets); err != nil {
+			return nil, fmt.Errorf("CreateUpdateAssets: %w", err)
+		}
+		contentHash := pgtype.Text{String: ContentHash(linked.ID, linkedAssets), Valid: true}
+		if err := qtx.SetUpdateContentHash(ctx, linked.ID, contentHash); err != nil {
+			return nil, fmt.Errorf("SetUpdateContentHash: %w", err)
+		}
+		linked, err = qtx.SetUpdateStatus(ctx, linked.ID, db.UpdateStatusPublished)
+		if err != nil {
+			return nil, fmt.Errorf("SetUpdateStatus: %w", err)
+		}
+		published = append(published, linked)
+	}
+	if err := refreshChannelHeads(ctx, qtx, published...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return published, nil
+}
+
+// inTx runs fn with queries of a transaction, committed when fn succeeds
+func (svc *service) inTx(ctx context.Context, name string, fn func(qtx *db.Queries) error) error {
+	tx, err := svc.pgPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).
+				Error(name+": failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if err := fn(svc.q.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 func (svc *service) AssetsByPlatform(
 	ctx context.Context,
 	updateID uuid.UUID,
@@ -383,3 +1195,98 @@ func (svc *service) AssetsByPlatform(
 ) ([]db.UpdateAsset, error) {
 	return svc.q.GetUpdateAssetsByPlatform(ctx, updateID, platform)
 }
+
+func (svc *service) PublishedAsset(
+	ctx context.Context,
+	assetID uuid.UUID,
+) (*db.UpdateAsset, error) {
+	asset, err := svc.q.GetPublishedAsset(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetPublishedAsset: %w", err)
+	}
+
+	return &asset, nil
+}
+
+func (svc *service) StorageObjects(
+	ctx context.Context,
+	updateID uuid.UUID,
+) ([]db.UpdateStorageObject, error) {
+	return svc.q.GetUpdateStorageObjects(ctx, updateID)
+}
+
+func (svc *service) DeclaredObject(
+	ctx context.Context,
+	updateID uuid.UUID,
+	filePath string,
+) (*db.UpdateStorageObject, error) {
+	object, err := svc.q.GetUpdateStorageObjectByPath(ctx, updateID, filePath)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFileNotDeclared
+		}
+		return nil, fmt.Errorf("GetUpdateStorageObjectByPath: %w", err)
+	}
+
+	return &object, nil
+}
+
+// DeclaredMD5 returns the MD5 declared for the object, nil when it isn't a valid MD5
+func DeclaredMD5(object *db.UpdateStorageObject) []byte {
+	md5Hash, err := hex.DecodeString(object.ContentMd5)
+	if err != nil || len(md5Hash) != md5.Size {
+		return nil
+	}
+	return md5Hash
+}
+
+// UploadFile streams a declared file of the update to the storage, the file is discarded when
+// it doesn't match the declared MD5
+func (svc *service) UploadFile(
+	ctx context.Context,
+	update db.Update,
+	filePath string,
+	reader io.Reader,
+) error {
+	object, err := svc.DeclaredObject(ctx, update.ID, filePath)
+	if err != nil {
+		return err
+	}
+
+	// canceling the context before closing the writer discards the partially written object
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objectKey := storage.AssetObjectKey(update.ProjectID, update.ID, object.Path)
+	writer, err := svc.storage.Bucket().NewWriter(writeCtx, objectKey, &blob.WriterOptions{
+		ContentType:     object.ContentType,
+		ContentEncoding: object.ContentEncoding,
+		ContentMD5:      DeclaredMD5(object),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+
+	written, err := io.Copy(writer, io.LimitReader(reader, object.ContentLength+1))
+	if err != nil || written != object.ContentLength {
+		cancel()
+		_ = writer.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		return ErrFileSizeMismatch
+	}
+
+	err = writer.Close()
+	if gcerrors.Code(err) == gcerrors.FailedPrecondition {
+		return ErrFileChecksumMismatch
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close object writer: %w", err)
+	}
+
+	return nil
+}
@@ -28,20 +28,20 @@ func setupFixtures(t *testing.T, ctx context.Context, dbDsn string) {
 	defer conn.Close(ctx)
 	q := db.New(conn)
 
-	expoProject, err = q.CreateProject(
-		ctx,
-		uuid.Must(uuid.NewV7()),
-		"test_expo",
-		db.UpdateProtocolExpo,
-	)
+	expoProject, err = q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           "test_expo",
+		UpdateProtocol: db.UpdateProtocolExpo,
+		Environment:    "production",
+	})
 	require.NoError(t, err)
 
-	codePushProject, err = q.CreateProject(
-		ctx,
-		uuid.Must(uuid.NewV7()),
-		"test_codepush",
-		db.UpdateProtocolCodepush,
-	)
+	codePushProject, err = q.CreateProject(ctx, db.CreateProjectParams{
+		ID:             uuid.Must(uuid.NewV7()),
+		Name:           "test_codepush",
+		UpdateProtocol: db.UpdateProtocolCodepush,
+		Environment:    "production",
+	})
 	require.NoError(t, err)
 }
 
@@ -95,6 +95,8 @@ func TestUpdateToInstall(t *testing.T) {
 			runtimeVersion,
 			channel,
 			platform,
+			"",
+			NoRolloutBucket,
 			filter,
 		)
 		require.NoError(t, err)
@@ -137,6 +139,8 @@ func TestUpdateToInstall(t *testing.T) {
 			runtimeVersion,
 			channel,
 			platform,
+			"",
+			NoRolloutBucket,
 			filter,
 		)
 		require.NoError(t, err)
@@ -198,6 +202,8 @@ func TestUpdateToInstall(t *testing.T) {
 			runtimeVersion,
 			channel,
 			platform,
+			"",
+			NoRolloutBucket,
 			filter,
 		)
 		require.NoError(t, err)
@@ -261,6 +267,8 @@ func TestUpdateToInstall(t *testing.T) {
 			runtimeVersion,
 			channel,
 			platform,
+			"",
+			NoRolloutBucket,
 			filter,
 		)
 		require.NoError(t, err)
@@ -333,6 +341,8 @@ func TestUpdateToInstall(t *testing.T) {
 				"1.0.0",
 				"production",
 				"ios",
+				"",
+				NoRolloutBucket,
 				CurrentUpdateFilter{},
 			)
 			require.NoError(t, err)
@@ -373,6 +383,8 @@ func TestUpdateToInstall(t *testing.T) {
 			"1.0.0",
 			"production",
 			"ios",
+			"",
+			NoRolloutBucket,
 			CurrentUpdateFilter{},
 		)
 		require.NoError(t, err)
@@ -431,6 +443,8 @@ func TestUpdateToInstall(t *testing.T) {
 				"1.0.0",
 				"production",
 				"ios",
+				"",
+				NoRolloutBucket,
 				CurrentUpdateFilter{
 					ID: &currentUpdateID,
 				},
@@ -447,6 +461,8 @@ func TestUpdateToInstall(t *testing.T) {
 				"1.0.0",
 				"production",
 				"ios",
+				"",
+				NoRolloutBucket,
 				CurrentUpdateFilter{
 					SHA256: util.StringPtr("sha256"),
 				},
@@ -519,6 +535,8 @@ func TestUpdateToInstall(t *testing.T) {
 			"1.0.0",
 			"production",
 			"ios",
+			"",
+			NoRolloutBucket,
 			CurrentUpdateFilter{},
 		)
 		require.NoError(t, err)
@@ -526,4 +544,127 @@ func TestUpdateToInstall(t *testing.T) {
 		require.Equal(t, updates.Update.ID, updateID)
 		require.Equal(t, updates.ContentSha256, pgtype.Text{String: "archive_sha256", Valid: true})
 	})
+
+	t.Run("should return the pinned update until the pin is removed", func(t *testing.T) {
+		t.Cleanup(func() {
+			err = ctr.Restore(ctx)
+			require.NoError(t, err)
+		})
+
+		conn, err := pgx.Connect(ctx, dbDsn)
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+		q := db.New(conn)
+		svc := NewService(q, nil, nil, nil)
+
+		pinnedUpdateID := uuid.Must(uuid.NewV7())
+		latestUpdateID := uuid.Must(uuid.NewV7())
+		for _, updateID := range []uuid.UUID{pinnedUpdateID, latestUpdateID} {
+			err = q.CreateUpdate(ctx, db.CreateUpdateParams{
+				ID:             updateID,
+				ProjectID:      expoProject.ID,
+				RuntimeVersion: "1.0.0",
+				Channel:        "production",
+			})
+			require.NoError(t, err)
+
+			_, err = q.SetUpdateStatus(ctx, updateID, db.UpdateStatusPublished)
+			require.NoError(t, err)
+		}
+
+		_, err = svc.PinChannel(ctx, expoProject.ID, pinnedUpdateID)
+		require.NoError(t, err)
+
+		updates, err := svc.UpdateToInstall(
+			ctx,
+			expoProject.ID,
+			"1.0.0",
+			"production",
+			"ios",
+			"",
+			NoRolloutBucket,
+			CurrentUpdateFilter{ID: &latestUpdateID},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, updates)
+		require.Equal(t, updates.Update.ID, pinnedUpdateID)
+
+		updates, err = svc.UpdateToInstall(
+			ctx,
+			expoProject.ID,
+			"1.0.0",
+			"production",
+			"ios",
+			"",
+			NoRolloutBucket,
+			CurrentUpdateFilter{ID: &pinnedUpdateID},
+		)
+		require.NoError(t, err)
+		require.Nil(t, updates)
+
+		err = svc.UnpinChannel(ctx, expoProject.ID, "production", "1.0.0")
+		require.NoError(t, err)
+
+		updates, err = svc.UpdateToInstall(
+			ctx,
+			expoProject.ID,
+			"1.0.0",
+			"production",
+			"ios",
+			"",
+			NoRolloutBucket,
+			CurrentUpdateFilter{ID: &pinnedUpdateID},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, updates)
+		require.Equal(t, updates.Update.ID, latestUpdateID)
+	})
+
+	t.Run("should return the update committed last", func(t *testing.T) {
+		t.Cleanup(func() {
+			err = ctr.Restore(ctx)
+			require.NoError(t, err)
+		})
+
+		conn, err := pgx.Connect(ctx, dbDsn)
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+		q := db.New(conn)
+		svc := NewService(q, nil, nil, nil)
+
+		// prepared in reverse order and published before the earlier commit finished processing
+		lastCommittedID := uuid.Must(uuid.NewV7())
+		firstCommittedID := uuid.Must(uuid.NewV7())
+		for _, updateID := range []uuid.UUID{lastCommittedID, firstCommittedID} {
+			err = q.CreateUpdate(ctx, db.CreateUpdateParams{
+				ID:             updateID,
+				ProjectID:      expoProject.ID,
+				RuntimeVersion: "1.0.0",
+				Channel:        "production",
+			})
+			require.NoError(t, err)
+		}
+		for _, updateID := range []uuid.UUID{firstCommittedID, lastCommittedID} {
+			_, err = q.SetUpdateCommitted(ctx, updateID)
+			require.NoError(t, err)
+		}
+		for _, updateID := range []uuid.UUID{lastCommittedID, firstCommittedID} {
+			_, err = q.SetUpdateStatus(ctx, updateID, db.UpdateStatusPublished)
+			require.NoError(t, err)
+		}
+
+		updates, err := svc.UpdateToInstall(
+			ctx,
+			expoProject.ID,
+			"1.0.0",
+			"production",
+			"ios",
+			"",
+			NoRolloutBucket,
+			CurrentUpdateFilter{},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, updates)
+		require.Equal(t, updates.Update.ID, lastCommittedID)
+	})
 }
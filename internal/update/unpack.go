@@ -0,0 +1,359 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+	"github.com/a-gierczak/paratrooper/internal/util"
+
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+)
+
+var (
+	ErrUnsupportedArchive = errors.New("unsupported archive format, expected .zip or .tar.gz")
+	// ErrArchiveLimitExceeded is returned for archives with more entries, unpacked bytes or
+	// nested directories than the limits of the worker
+	ErrArchiveLimitExceeded = errors.New("archive exceeds the unpacking limits")
+)
+
+var uploadArchiveExtensions = []string{".zip", ".tar.gz", ".tgz"}
+
+// IsUploadArchivePath checks if the file can be uploaded as a single update archive
+func IsUploadArchivePath(filePath string) bool {
+	filePath = strings.ToLower(filePath)
+	return slices.ContainsFunc(uploadArchiveExtensions, func(ext string) bool {
+		return strings.HasSuffix(filePath, ext)
+	})
+}
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// archiveLimits bound what's read from an archive, 0 disables a limit
+type archiveLimits struct {
+	maxEntries       int
+	maxUnpackedBytes int64
+	maxDepth         int
+}
+
+// archiveBudget checks the limits during a single walk of an archive. The sizes declared by the
+// entries are checked before they're read, so a zip bomb is rejected before it's decompressed,
+// and the bytes read are counted too, so entries can't lie about their size.
+type archiveBudget struct {
+	limits   archiveLimits
+	entries  int
+	declared int64
+	read     int64
+}
+
+func (b *archiveBudget) sizeError() error {
+	return fmt.Errorf(
+		"%w: unpacks to more than %d bytes",
+		ErrArchiveLimitExceeded,
+		b.limits.maxUnpackedBytes,
+	)
+}
+
+// addEntry checks an entry of the archive, directories and links included, before it's read
+func (b *archiveBudget) addEntry(name string, size int64) error {
+	b.entries++
+	if b.limits.maxEntries > 0 && b.entries > b.limits.maxEntries {
+		return fmt.Errorf(
+			"%w: has more than %d entries",
+			ErrArchiveLimitExceeded,
+			b.limits.maxEntries,
+		)
+	}
+
+	depth := len(strings.Split(strings.Trim(name, "/"), "/"))
+	if b.limits.maxDepth > 0 && depth > b.limits.maxDepth {
+		return fmt.Errorf(
+			"%w: %s is nested in more than %d directories",
+			ErrArchiveLimitExceeded,
+			name,
+			b.limits.maxDepth,
+		)
+	}
+
+	if size < 0 || size > math.MaxInt64-b.declared {
+		return b.sizeError()
+	}
+	b.declared += size
+	if b.limits.maxUnpackedBytes > 0 && b.declared > b.limits.maxUnpackedBytes {
+		return b.sizeError()
+	}
+	return nil
+}
+
+// reader counts the bytes read from an entry against the unpacked size limit
+func (b *archiveBudget) reader(r io.Reader) io.Reader {
+	if b.limits.maxUnpackedBytes <= 0 {
+		return r
+	}
+	return &budgetReader{reader: r, budget: b}
+}
+
+type budgetReader struct {
+	reader io.Reader
+	budget *archiveBudget
+}
+
+func (r *budgetReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.budget.read += int64(n)
+	if r.budget.read > r.budget.limits.maxUnpackedBytes {
+		return n, r.budget.sizeError()
+	}
+	return n, err
+}
+
+// archiveWalker calls fn for every regular file in the archive
+type archiveWalker func(fn func(name string, reader io.Reader) error) error
+
+// newArchiveWalker detects the archive format by its magic bytes, every walk of the archive is
+// checked against the limits
+func newArchiveWalker(file *os.File, size int64, limits archiveLimits) (archiveWalker, error) {
+	header := make([]byte, len(zipMagic))
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("failed to read archive header: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(header, zipMagic):
+		zipReader, err := zip.NewReader(file, size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open zip archive: %w", err)
+		}
+		return zipWalker(zipReader, limits), nil
+	case bytes.HasPrefix(header, gzipMagic):
+		return tarGzWalker(file, limits), nil
+	}
+
+	return nil, ErrUnsupportedArchive
+}
+
+func zipWalker(zipReader *zip.Reader, limits archiveLimits) archiveWalker {
+	return func(fn func(name string, reader io.Reader) error) error {
+		budget := &archiveBudget{limits: limits}
+		for _, f := range zipReader.File {
+			// the zip reader fails entries longer than their declared size
+			size := int64(min(f.UncompressedSize64, math.MaxInt64))
+			if err := budget.addEntry(f.Name, size); err != nil {
+				return err
+			}
+			if !f.Mode().IsRegular() {
+				continue
+			}
+
+			entryReader, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open %s in archive: %w", f.Name, err)
+			}
+
+			err = fn(f.Name, budget.reader(entryReader))
+			entryReader.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// tarGzWalker reads the archive from the beginning on every walk,
+// because tar entries can only be accessed sequentially
+func tarGzWalker(file *os.File, limits archiveLimits) archiveWalker {
+	return func(fn func(name string, reader io.Reader) error) error {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek archive: %w", err)
+		}
+
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gzipReader.Close()
+
+		budget := &archiveBudget{limits: limits}
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			header, err := tarReader.Next()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read tar archive: %w", err)
+			}
+
+			name := strings.TrimPrefix(header.Name, "./")
+			if err := budget.addEntry(name, header.Size); err != nil {
+				return err
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+
+			if err := fn(name, budget.reader(tarReader)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// unpacker extracts the archive uploaded instead of individual update files
+// and stores its entries the same way as files uploaded one by one
+type unpacker struct {
+	st     *storage.Storage
+	update db.Update
+	log    *zap.Logger
+	limits archiveLimits
+}
+
+// archiveRoot returns the directory containing metadata.json, so that archives
+// with a top-level directory (e.g. dist/) are handled the same as flat ones
+func archiveRoot(names []string) (string, bool) {
+	root, found := "", false
+	for _, name := range names {
+		if path.Base(name) != MetadataFileName {
+			continue
+		}
+		dir := path.Dir(name)
+		if dir == "." {
+			return "", true
+		}
+		if !found || len(dir)+1 < len(root) {
+			root, found = dir+"/", true
+		}
+	}
+	return root, found
+}
+
+// download copies the archive object to a temporary file,
+// which is needed because zip requires random access
+func (u *unpacker) download(ctx context.Context, objectKey string) (*os.File, int64, error) {
+	blobReader, err := u.st.Bucket().NewReader(ctx, objectKey, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer util.CloseWithLogger(u.log, blobReader)
+
+	file, err := os.CreateTemp("", "paratrooper-archive-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	size, err := io.Copy(file, blobReader)
+	if err != nil {
+		u.removeTemp(file)
+		return nil, 0, fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	return file, size, nil
+}
+
+func (u *unpacker) removeTemp(file *os.File) {
+	util.CloseWithLogger(u.log, file)
+	if err := os.Remove(file.Name()); err != nil {
+		u.log.Error("failed to remove temp file", zap.Error(err))
+	}
+}
+
+func (u *unpacker) writeObject(ctx context.Context, filePath string, reader io.Reader) error {
+	objectKey := storage.AssetObjectKey(u.update.ProjectID, u.update.ID, filePath)
+	// compressed files are served with their encoding and the type of the decoded file
+	contentEncoding := extensionContentEncoding(filePath)
+	decodedPath := trimEncodingExtension(filePath, contentEncoding)
+	blobWriter, err := u.st.Bucket().NewWriter(ctx, objectKey, &blob.WriterOptions{
+		ContentType:     mime.TypeByExtension(path.Ext(decodedPath)),
+		ContentEncoding: contentEncoding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+
+	if _, err := io.Copy(blobWriter, reader); err != nil {
+		util.CloseWithLogger(u.log, blobWriter)
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err := blobWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close blob writer: %w", err)
+	}
+
+	return nil
+}
+
+// unpack extracts all files of the archive next to it and returns the number of extracted files
+func (u *unpacker) unpack(ctx context.Context, archivePath string) (int, error) {
+	if !IsUploadArchivePath(archivePath) {
+		return 0, ErrUnsupportedArchive
+	}
+
+	objectKey := storage.AssetObjectKey(u.update.ProjectID, u.update.ID, archivePath)
+	file, size, err := u.download(ctx, objectKey)
+	if err != nil {
+		return 0, err
+	}
+	defer u.removeTemp(file)
+
+	// the limits are checked by the first walk before any file is unpacked
+	walk, err := newArchiveWalker(file, size, u.limits)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0)
+	err = walk(func(name string, _ io.Reader) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	root, ok := archiveRoot(names)
+	if !ok {
+		return 0, fmt.Errorf("archive does not contain %s", MetadataFileName)
+	}
+
+	unpacked := 0
+	err = walk(func(name string, reader io.Reader) error {
+		filePath, ok := strings.CutPrefix(name, root)
+		if !ok {
+			u.log.Warn("skipping file outside of archive root", zap.String("path", name))
+			return nil
+		}
+
+		if !storage.IsValidAssetPath(filePath) {
+			return fmt.Errorf("invalid file path in archive: %s", name)
+		}
+
+		if err := u.writeObject(ctx, storage.CleanPath(filePath), reader); err != nil {
+			return fmt.Errorf("failed to unpack %s: %w", name, err)
+		}
+		unpacked += 1
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return unpacked, nil
+}
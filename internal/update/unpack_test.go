@@ -0,0 +1,202 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestStorage(t testing.TB, ctx context.Context) *storage.Storage {
+	dir := t.TempDir()
+	st, err := storage.Init(ctx, &storage.Config{
+		LocalPath:     filepath.Join(dir, "assets"),
+		SecretKeyPath: filepath.Join(dir, "secret.key"),
+		ApiPublicURL:  "http://localhost:8080",
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func writeTestObject(
+	t *testing.T,
+	ctx context.Context,
+	st *storage.Storage,
+	key string,
+	data []byte,
+) {
+	require.NoError(t, st.Bucket().WriteAll(ctx, key, data, nil))
+}
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(f, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func tarGzFiles(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := io.WriteString(tw, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestArchiveRoot(t *testing.T) {
+	root, ok := archiveRoot([]string{"metadata.json", "assets/a.png"})
+	require.True(t, ok)
+	require.Equal(t, "", root)
+
+	root, ok = archiveRoot([]string{
+		"dist/assets/a.png",
+		"dist/metadata.json",
+		"dist/nested/metadata.json",
+	})
+	require.True(t, ok)
+	require.Equal(t, "dist/", root)
+
+	_, ok = archiveRoot([]string{"assets/a.png"})
+	require.False(t, ok)
+}
+
+func TestUnpack(t *testing.T) {
+	ctx := logger.ContextWithLogger(context.Background(), zap.NewNop())
+	st := newTestStorage(t, ctx)
+	update := db.Update{ID: uuid.Must(uuid.NewV7()), ProjectID: uuid.Must(uuid.NewV7())}
+	u := &unpacker{st: st, update: update, log: zap.NewNop()}
+	objectKey := func(filePath string) string {
+		return storage.AssetObjectKey(update.ProjectID, update.ID, filePath)
+	}
+
+	t.Run("unpacks files relative to metadata.json", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey("update.zip"), zipFiles(t, map[string]string{
+			"dist/metadata.json":                 "{}",
+			"dist/_expo/static/js/ios/entry.hbc": "bundle",
+			"dist/assets/a1b2c3":                 "image",
+			"README.md":                          "ignored",
+		}))
+
+		n, err := u.unpack(ctx, "update.zip")
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+
+		data, err := st.Bucket().ReadAll(ctx, objectKey("_expo/static/js/ios/entry.hbc"))
+		require.NoError(t, err)
+		require.Equal(t, "bundle", string(data))
+
+		exists, err := st.Bucket().Exists(ctx, objectKey("README.md"))
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("detects tar.gz by magic bytes", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey("update.tgz"), tarGzFiles(t, map[string]string{
+			"./metadata.json":                     "{}",
+			"./_expo/static/js/android/entry.hbc": "bundle",
+		}))
+
+		n, err := u.unpack(ctx, "update.tgz")
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		data, err := st.Bucket().ReadAll(ctx, objectKey("_expo/static/js/android/entry.hbc"))
+		require.NoError(t, err)
+		require.Equal(t, "bundle", string(data))
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey("fake.zip"), []byte("not an archive"))
+
+		_, err := u.unpack(ctx, "fake.zip")
+		require.ErrorIs(t, err, ErrUnsupportedArchive)
+	})
+
+	t.Run("rejects paths outside of the update", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey("evil.zip"), zipFiles(t, map[string]string{
+			"metadata.json":       "{}",
+			"assets/../../secret": "evil",
+		}))
+
+		_, err := u.unpack(ctx, "evil.zip")
+		require.Error(t, err)
+	})
+
+	t.Run("rejects archives exceeding the limits", func(t *testing.T) {
+		files := map[string]string{
+			"metadata.json":   "{}",
+			"assets/a1b2c3":   strings.Repeat("0", 1000),
+			"a/b/c/d/e/f.png": "image",
+		}
+		writeTestObject(t, ctx, st, objectKey("limits.zip"), zipFiles(t, files))
+		writeTestObject(t, ctx, st, objectKey("limits.tgz"), tarGzFiles(t, files))
+
+		for _, limits := range []archiveLimits{
+			{maxEntries: 2},
+			{maxUnpackedBytes: 1000},
+			{maxDepth: 5},
+		} {
+			for _, archivePath := range []string{"limits.zip", "limits.tgz"} {
+				limited := &unpacker{st: st, update: update, log: zap.NewNop(), limits: limits}
+				_, err := limited.unpack(ctx, archivePath)
+				require.ErrorIs(t, err, ErrArchiveLimitExceeded, archivePath)
+			}
+		}
+
+		limited := &unpacker{st: st, update: update, log: zap.NewNop(), limits: archiveLimits{
+			maxEntries:       3,
+			maxUnpackedBytes: 1007,
+			maxDepth:         6,
+		}}
+		n, err := limited.unpack(ctx, "limits.zip")
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+	})
+
+	t.Run("counts the bytes read against the limit", func(t *testing.T) {
+		budget := &archiveBudget{limits: archiveLimits{maxUnpackedBytes: 10}}
+		require.NoError(t, budget.addEntry("bundle.js", 1))
+
+		_, err := io.Copy(io.Discard, budget.reader(strings.NewReader(strings.Repeat("0", 11))))
+		require.ErrorIs(t, err, ErrArchiveLimitExceeded)
+	})
+
+	t.Run("requires metadata.json", func(t *testing.T) {
+		writeTestObject(t, ctx, st, objectKey("empty.zip"), zipFiles(t, map[string]string{
+			"assets/a1b2c3": "image",
+		}))
+
+		_, err := u.unpack(ctx, "empty.zip")
+		require.Error(t, err)
+	})
+}
@@ -2,11 +2,16 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/a-gierczak/paratrooper/generated/db"
+	"github.com/a-gierczak/paratrooper/internal/analytics"
+	"github.com/a-gierczak/paratrooper/internal/leader"
 	"github.com/a-gierczak/paratrooper/internal/logger"
+	"github.com/a-gierczak/paratrooper/internal/project"
 	"github.com/a-gierczak/paratrooper/internal/queue"
+	"github.com/a-gierczak/paratrooper/internal/schema"
 	"github.com/a-gierczak/paratrooper/internal/storage"
 	"github.com/a-gierczak/paratrooper/internal/update"
 
@@ -17,11 +22,21 @@ import (
 type Config struct {
 	DebugMode   bool   `env:"DEBUG"`
 	PostgresDSN string `env:"POSTGRES_DSN"`
-	NATSURL     string `env:"NATS_URL"`
+	Queue       queue.Config
 	Storage     storage.Config
+	Log         logger.Config
+	Integrity   update.IntegrityConfig
+	ColdStorage update.ColdStorageConfig
+	Retention   update.RetentionConfig
+	Quota       update.QuotaConfig
+	Chunks      update.ChunkConfig
+	Processing  update.ProcessingConfig
+	Leader      leader.Config
+	Schema      schema.Config
 }
 
 func Run(config Config, log *zap.Logger) error {
+	log = logger.Component(log, logger.ComponentWorker)
 	ctx := logger.ContextWithLogger(context.Background(), log)
 
 	// connect to postgres
@@ -30,9 +45,21 @@ func Run(config Config, log *zap.Logger) error {
 		return fmt.Errorf("failed to connect to postgres: %w", err)
 	}
 	queries := db.New(pgConn)
+	readOnly, err := schema.Check(ctx, pgConn, config.Schema)
+	if err != nil {
+		return err
+	}
+	if readOnly {
+		// processing writes to the database, the API server keeps serving updates meanwhile
+		return errors.New("worker can't run read-only, update the database schema")
+	}
+
+	if config.Queue.Driver == queue.DriverMemory {
+		return errors.New("in-process queue is consumed by the API server, worker is not needed")
+	}
 
 	// connect to nats
-	queueConn, err := queue.Connect(ctx, config.NATSURL)
+	queueConn, err := queue.Connect(ctx, config.Queue.NATSURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to NATS: %w", err)
 	}
@@ -42,8 +69,45 @@ func Run(config Config, log *zap.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to init storage: %w", err)
 	}
+	storageDriver.RouteProjectBuckets(project.NewService(queries).StorageDriverURL)
 	updateSvc := update.NewService(queries, pgConn, storageDriver, queueConn)
-	updateProcessor := update.NewProcessor(updateSvc, storageDriver, queueConn)
+	updateProcessor := update.NewProcessor(
+		updateSvc,
+		storageDriver,
+		queueConn,
+		config.Processing,
+	)
+	// singleton jobs run only on the elected replica
+	elector := leader.NewElector(pgConn, config.Leader)
+	verifier := update.NewVerifier(queries, storageDriver, config.Integrity)
+	go elector.Run(ctx, "integrity-verifier", verifier.Run)
+	coldStorageMover := update.NewColdStorageMover(
+		queries,
+		storageDriver,
+		queueConn,
+		config.ColdStorage,
+	)
+	go elector.Run(ctx, "cold-storage-mover", coldStorageMover.Run)
+	retentionEnforcer := update.NewRetentionEnforcer(
+		queries,
+		pgConn,
+		storageDriver,
+		queueConn,
+		config.Retention,
+	)
+	go elector.Run(ctx, "retention-enforcer", retentionEnforcer.Run)
+	go elector.Run(ctx, "channel-head-backfill", update.NewChannelHeadBackfill(queries).Run)
+	go elector.Run(ctx, "usage-refresher", update.NewUsageRefresher(queries, config.Quota).Run)
+	chunkCleaner := update.NewChunkCleaner(queries, storageDriver, config.Chunks)
+	go elector.Run(ctx, "chunk-cleaner", chunkCleaner.Run)
+	// every replica receives the max deliveries advisories, only the leader handles them
+	go elector.Run(ctx, "dead-letter-consumer", updateProcessor.RunDeadLetterConsumer)
+	if err := analytics.NewWriter(queries).Start(ctx, queueConn); err != nil {
+		return err
+	}
+	if err := project.NewPurger(queries, pgConn, storageDriver).Start(ctx, queueConn); err != nil {
+		return err
+	}
 
 	return updateProcessor.StartWorker(ctx)
 }
@@ -0,0 +1,18 @@
+// Package server runs the API server in another program, configured like cmd/server, with the
+// hooks of the program rewriting the responses of update checks
+package server
+
+import (
+	"github.com/a-gierczak/paratrooper/hooks"
+	"github.com/a-gierczak/paratrooper/internal/api"
+
+	"go.uber.org/zap"
+)
+
+// Config is read from the environment, e.g. with env.UnmarshalFromEnviron, see README.md
+type Config = api.Config
+
+// Run serves the API until it fails, manifestHooks may be nil
+func Run(config Config, log *zap.Logger, manifestHooks *hooks.Registry) error {
+	return api.Run(config, log, manifestHooks)
+}